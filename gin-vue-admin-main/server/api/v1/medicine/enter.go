@@ -18,19 +18,31 @@ type ApiGroup struct {
 	MtOrdersApi
 	MtChatMessageApi
 	MtDiscountApi
+	MtInventoryAlertApi
+	MtRefundRecordApi
+	MtDoctorScheduleApi
+	MtDrugInteractionApi
+	MtHotSearchBlockApi
+	MtSymptomApi
 }
 
 var (
-	mtDrugTypeStairService  = service.ServiceGroupApp.MedicineServiceGroup.MtDrugTypeStairService
-	mtDrugTypeLevelService  = service.ServiceGroupApp.MedicineServiceGroup.MtDrugTypeLevelService
-	mtDoctorsService        = service.ServiceGroupApp.MedicineServiceGroup.MtDoctorsService
-	mtOrdersDrugService     = service.ServiceGroupApp.MedicineServiceGroup.MtOrdersDrugService
-	mtUserService           = service.ServiceGroupApp.MedicineServiceGroup.MtUserService
-	mtDrugService           = service.ServiceGroupApp.MedicineServiceGroup.MtDrugService
-	mtGuideService          = service.ServiceGroupApp.MedicineServiceGroup.MtGuideService
-	mtExplainService        = service.ServiceGroupApp.MedicineServiceGroup.MtExplainService
-	mtDoctorPatientsService = service.ServiceGroupApp.MedicineServiceGroup.MtDoctorPatientsService
-	mtOrdersService         = service.ServiceGroupApp.MedicineServiceGroup.MtOrdersService
-	mtChatMessageService    = service.ServiceGroupApp.MedicineServiceGroup.MtChatMessageService
-	mtDiscountService       = service.ServiceGroupApp.MedicineServiceGroup.MtDiscountService
+	mtDrugTypeStairService   = service.ServiceGroupApp.MedicineServiceGroup.MtDrugTypeStairService
+	mtDrugTypeLevelService   = service.ServiceGroupApp.MedicineServiceGroup.MtDrugTypeLevelService
+	mtDoctorsService         = service.ServiceGroupApp.MedicineServiceGroup.MtDoctorsService
+	mtOrdersDrugService      = service.ServiceGroupApp.MedicineServiceGroup.MtOrdersDrugService
+	mtUserService            = service.ServiceGroupApp.MedicineServiceGroup.MtUserService
+	mtDrugService            = service.ServiceGroupApp.MedicineServiceGroup.MtDrugService
+	mtGuideService           = service.ServiceGroupApp.MedicineServiceGroup.MtGuideService
+	mtExplainService         = service.ServiceGroupApp.MedicineServiceGroup.MtExplainService
+	mtDoctorPatientsService  = service.ServiceGroupApp.MedicineServiceGroup.MtDoctorPatientsService
+	mtOrdersService          = service.ServiceGroupApp.MedicineServiceGroup.MtOrdersService
+	mtChatMessageService     = service.ServiceGroupApp.MedicineServiceGroup.MtChatMessageService
+	mtDiscountService        = service.ServiceGroupApp.MedicineServiceGroup.MtDiscountService
+	mtInventoryAlertService  = service.ServiceGroupApp.MedicineServiceGroup.MtInventoryAlertService
+	mtRefundRecordService    = service.ServiceGroupApp.MedicineServiceGroup.MtRefundRecordService
+	mtDoctorScheduleService  = service.ServiceGroupApp.MedicineServiceGroup.MtDoctorScheduleService
+	mtDrugInteractionService = service.ServiceGroupApp.MedicineServiceGroup.MtDrugInteractionService
+	mtHotSearchBlockService  = service.ServiceGroupApp.MedicineServiceGroup.MtHotSearchBlockService
+	mtSymptomService         = service.ServiceGroupApp.MedicineServiceGroup.MtSymptomService
 )
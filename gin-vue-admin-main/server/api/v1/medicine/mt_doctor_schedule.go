@@ -0,0 +1,247 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MtDoctorScheduleApi struct{}
+
+// CreateMtDoctorSchedule 创建排班模板
+// @Tags MtDoctorSchedule
+// @Summary 创建排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicine.MtDoctorSchedule true "创建排班模板"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /mtDoctorSchedule/createMtDoctorSchedule [post]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) CreateMtDoctorSchedule(c *gin.Context) {
+	var record medicine.MtDoctorSchedule
+	err := c.ShouldBindJSON(&record)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtDoctorScheduleService.CreateMtDoctorSchedule(c.Request.Context(), &record)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateMtDoctorSchedule 更新排班模板
+// @Tags MtDoctorSchedule
+// @Summary 更新排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicine.MtDoctorSchedule true "更新排班模板"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /mtDoctorSchedule/updateMtDoctorSchedule [put]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) UpdateMtDoctorSchedule(c *gin.Context) {
+	var record medicine.MtDoctorSchedule
+	err := c.ShouldBindJSON(&record)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtDoctorScheduleService.UpdateMtDoctorSchedule(c.Request.Context(), record)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteMtDoctorSchedule 删除排班模板
+// @Tags MtDoctorSchedule
+// @Summary 删除排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /mtDoctorSchedule/deleteMtDoctorSchedule [delete]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) DeleteMtDoctorSchedule(c *gin.Context) {
+	ID := c.Query("ID")
+	err := mtDoctorScheduleService.DeleteMtDoctorSchedule(c.Request.Context(), ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetMtDoctorScheduleList 分页获取排班模板
+// @Tags MtDoctorSchedule
+// @Summary 分页获取排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtDoctorScheduleSearch true "分页获取排班模板"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtDoctorSchedule/getMtDoctorScheduleList [get]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) GetMtDoctorScheduleList(c *gin.Context) {
+	var pageInfo medicineReq.MtDoctorScheduleSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtDoctorScheduleService.GetMtDoctorScheduleInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// CreateMtScheduleException 创建停诊或加诊
+// @Tags MtDoctorSchedule
+// @Summary 创建停诊或加诊
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicine.MtDoctorScheduleException true "创建停诊或加诊"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /mtDoctorSchedule/createMtScheduleException [post]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) CreateMtScheduleException(c *gin.Context) {
+	var record medicine.MtDoctorScheduleException
+	err := c.ShouldBindJSON(&record)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtDoctorScheduleService.CreateMtScheduleException(c.Request.Context(), &record)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// DeleteMtScheduleException 删除停诊或加诊
+// @Tags MtDoctorSchedule
+// @Summary 删除停诊或加诊
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /mtDoctorSchedule/deleteMtScheduleException [delete]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) DeleteMtScheduleException(c *gin.Context) {
+	ID := c.Query("ID")
+	err := mtDoctorScheduleService.DeleteMtScheduleException(c.Request.Context(), ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetMtScheduleExceptionList 分页获取停诊和加诊
+// @Tags MtDoctorSchedule
+// @Summary 分页获取停诊和加诊
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtScheduleExceptionSearch true "分页获取停诊和加诊"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtDoctorSchedule/getMtScheduleExceptionList [get]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) GetMtScheduleExceptionList(c *gin.Context) {
+	var pageInfo medicineReq.MtScheduleExceptionSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtDoctorScheduleService.GetMtScheduleExceptionInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetMtScheduleSlotList 分页获取号源
+// @Tags MtDoctorSchedule
+// @Summary 分页获取号源
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtScheduleSlotSearch true "分页获取号源"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtDoctorSchedule/getMtScheduleSlotList [get]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) GetMtScheduleSlotList(c *gin.Context) {
+	var pageInfo medicineReq.MtScheduleSlotSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtDoctorScheduleService.GetMtScheduleSlotInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetMtAppointmentList 分页获取预约
+// @Tags MtDoctorSchedule
+// @Summary 分页获取预约
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtAppointmentSearch true "分页获取预约"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtDoctorSchedule/getMtAppointmentList [get]
+func (mtDoctorScheduleApi *MtDoctorScheduleApi) GetMtAppointmentList(c *gin.Context) {
+	var pageInfo medicineReq.MtAppointmentSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtDoctorScheduleService.GetMtAppointmentInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
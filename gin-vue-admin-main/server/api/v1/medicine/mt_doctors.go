@@ -120,6 +120,33 @@ func (mtDoctorsApi *MtDoctorsApi) UpdateMtDoctors(c *gin.Context) {
 	response.OkWithMessage("更新成功", c)
 }
 
+// ResetMtDoctorPassword 重置医生登录密码
+// @Tags MtDoctors
+// @Summary 重置医生登录密码
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body medicineReq.MtDoctorPasswordReset true "医生ID和新密码"
+// @Success 200 {object} response.Response{msg=string} "重置成功"
+// @Router /mtDoctors/resetMtDoctorPassword [put]
+func (mtDoctorsApi *MtDoctorsApi) ResetMtDoctorPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var reset medicineReq.MtDoctorPasswordReset
+	err := c.ShouldBindJSON(&reset)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtDoctorsService.ResetMtDoctorPassword(ctx, reset, utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("重置密码失败!", zap.Error(err))
+		response.FailWithMessage("重置密码失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("重置成功", c)
+}
+
 // FindMtDoctors 用id查询mtDoctors表
 // @Tags MtDoctors
 // @Summary 用id查询mtDoctors表
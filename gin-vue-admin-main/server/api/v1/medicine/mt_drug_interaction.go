@@ -0,0 +1,171 @@
+package medicine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MtDrugInteractionApi struct{}
+
+// 读取上传的CSV文件并交给导入函数
+func importKnowledgeCsv(c *gin.Context, importFunc func(context.Context, io.Reader) (int, error)) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		global.GVA_LOG.Error("文件获取失败!", zap.Error(err))
+		response.FailWithMessage("文件获取失败", c)
+		return
+	}
+	if !strings.HasSuffix(strings.ToLower(header.Filename), ".csv") {
+		response.FailWithMessage("请上传CSV文件", c)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		global.GVA_LOG.Error("文件读取失败!", zap.Error(err))
+		response.FailWithMessage("文件读取失败", c)
+		return
+	}
+	defer file.Close()
+
+	count, err := importFunc(c.Request.Context(), file)
+	if err != nil {
+		global.GVA_LOG.Error("导入失败!", zap.Error(err))
+		response.FailWithMessage("导入失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage(fmt.Sprintf("成功导入%d条", count), c)
+}
+
+// ImportMtDrugInteraction 从CSV导入药物相互作用
+// @Tags MtDrugInteraction
+// @Summary 从CSV导入药物相互作用，列为：药品A,药品B,严重程度,说明,建议
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "CSV文件，首行为表头"
+// @Success 200 {object} response.Response{msg=string} "导入成功"
+// @Router /mtDrugInteraction/importMtDrugInteraction [post]
+func (mtDrugInteractionApi *MtDrugInteractionApi) ImportMtDrugInteraction(c *gin.Context) {
+	importKnowledgeCsv(c, mtDrugInteractionService.ImportMtDrugInteractions)
+}
+
+// ImportMtDrugContraindication 从CSV导入人群禁忌
+// @Tags MtDrugInteraction
+// @Summary 从CSV导入人群禁忌，列为：药品,人群,级别,说明
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "CSV文件，首行为表头"
+// @Success 200 {object} response.Response{msg=string} "导入成功"
+// @Router /mtDrugInteraction/importMtDrugContraindication [post]
+func (mtDrugInteractionApi *MtDrugInteractionApi) ImportMtDrugContraindication(c *gin.Context) {
+	importKnowledgeCsv(c, mtDrugInteractionService.ImportMtDrugContraindications)
+}
+
+// DeleteMtDrugInteraction 删除药物相互作用
+// @Tags MtDrugInteraction
+// @Summary 删除药物相互作用
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /mtDrugInteraction/deleteMtDrugInteraction [delete]
+func (mtDrugInteractionApi *MtDrugInteractionApi) DeleteMtDrugInteraction(c *gin.Context) {
+	ID := c.Query("ID")
+	err := mtDrugInteractionService.DeleteMtDrugInteraction(c.Request.Context(), ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// DeleteMtDrugContraindication 删除人群禁忌
+// @Tags MtDrugInteraction
+// @Summary 删除人群禁忌
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /mtDrugInteraction/deleteMtDrugContraindication [delete]
+func (mtDrugInteractionApi *MtDrugInteractionApi) DeleteMtDrugContraindication(c *gin.Context) {
+	ID := c.Query("ID")
+	err := mtDrugInteractionService.DeleteMtDrugContraindication(c.Request.Context(), ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetMtDrugInteractionList 分页获取药物相互作用
+// @Tags MtDrugInteraction
+// @Summary 分页获取药物相互作用
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtDrugInteractionSearch true "分页获取药物相互作用"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtDrugInteraction/getMtDrugInteractionList [get]
+func (mtDrugInteractionApi *MtDrugInteractionApi) GetMtDrugInteractionList(c *gin.Context) {
+	var pageInfo medicineReq.MtDrugInteractionSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtDrugInteractionService.GetMtDrugInteractionInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetMtDrugContraindicationList 分页获取人群禁忌
+// @Tags MtDrugInteraction
+// @Summary 分页获取人群禁忌
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtDrugContraindicationSearch true "分页获取人群禁忌"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtDrugInteraction/getMtDrugContraindicationList [get]
+func (mtDrugInteractionApi *MtDrugInteractionApi) GetMtDrugContraindicationList(c *gin.Context) {
+	var pageInfo medicineReq.MtDrugContraindicationSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtDrugInteractionService.GetMtDrugContraindicationInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
@@ -0,0 +1,163 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MtHotSearchBlockApi struct{}
+
+// CreateMtHotSearchBlock 创建热搜屏蔽词
+// @Tags MtHotSearchBlock
+// @Summary 创建热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body medicine.MtHotSearchBlock true "创建热搜屏蔽词"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /mtHotSearchBlock/createMtHotSearchBlock [post]
+func (mtHotSearchBlockApi *MtHotSearchBlockApi) CreateMtHotSearchBlock(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var mtHotSearchBlock medicine.MtHotSearchBlock
+	err := c.ShouldBindJSON(&mtHotSearchBlock)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtHotSearchBlockService.CreateMtHotSearchBlock(ctx, &mtHotSearchBlock)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// DeleteMtHotSearchBlock 删除热搜屏蔽词
+// @Tags MtHotSearchBlock
+// @Summary 删除热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /mtHotSearchBlock/deleteMtHotSearchBlock [delete]
+func (mtHotSearchBlockApi *MtHotSearchBlockApi) DeleteMtHotSearchBlock(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ID := c.Query("ID")
+	err := mtHotSearchBlockService.DeleteMtHotSearchBlock(ctx, ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// DeleteMtHotSearchBlockByIds 批量删除热搜屏蔽词
+// @Tags MtHotSearchBlock
+// @Summary 批量删除热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{msg=string} "批量删除成功"
+// @Router /mtHotSearchBlock/deleteMtHotSearchBlockByIds [delete]
+func (mtHotSearchBlockApi *MtHotSearchBlockApi) DeleteMtHotSearchBlockByIds(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	IDs := c.QueryArray("IDs[]")
+	err := mtHotSearchBlockService.DeleteMtHotSearchBlockByIds(ctx, IDs)
+	if err != nil {
+		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
+		response.FailWithMessage("批量删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("批量删除成功", c)
+}
+
+// UpdateMtHotSearchBlock 更新热搜屏蔽词
+// @Tags MtHotSearchBlock
+// @Summary 更新热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body medicine.MtHotSearchBlock true "更新热搜屏蔽词"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /mtHotSearchBlock/updateMtHotSearchBlock [put]
+func (mtHotSearchBlockApi *MtHotSearchBlockApi) UpdateMtHotSearchBlock(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var mtHotSearchBlock medicine.MtHotSearchBlock
+	err := c.ShouldBindJSON(&mtHotSearchBlock)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtHotSearchBlockService.UpdateMtHotSearchBlock(ctx, mtHotSearchBlock)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// FindMtHotSearchBlock 用id查询热搜屏蔽词
+// @Tags MtHotSearchBlock
+// @Summary 用id查询热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query uint true "用id查询热搜屏蔽词"
+// @Success 200 {object} response.Response{data=medicine.MtHotSearchBlock,msg=string} "查询成功"
+// @Router /mtHotSearchBlock/findMtHotSearchBlock [get]
+func (mtHotSearchBlockApi *MtHotSearchBlockApi) FindMtHotSearchBlock(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ID := c.Query("ID")
+	reMtHotSearchBlock, err := mtHotSearchBlockService.GetMtHotSearchBlock(ctx, ID)
+	if err != nil {
+		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		response.FailWithMessage("查询失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithData(reMtHotSearchBlock, c)
+}
+
+// GetMtHotSearchBlockList 分页获取热搜屏蔽词列表
+// @Tags MtHotSearchBlock
+// @Summary 分页获取热搜屏蔽词列表
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtHotSearchBlockSearch true "分页获取热搜屏蔽词列表"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtHotSearchBlock/getMtHotSearchBlockList [get]
+func (mtHotSearchBlockApi *MtHotSearchBlockApi) GetMtHotSearchBlockList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var pageInfo medicineReq.MtHotSearchBlockSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtHotSearchBlockService.GetMtHotSearchBlockInfoList(ctx, pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
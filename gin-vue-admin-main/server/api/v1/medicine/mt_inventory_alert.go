@@ -0,0 +1,112 @@
+package medicine
+
+import (
+	"fmt"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MtInventoryAlertApi struct{}
+
+// FindMtInventoryAlert 用id查询库存告警
+// @Tags MtInventoryAlert
+// @Summary 用id查询库存告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "告警ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"查询成功"}"
+// @Router /mtInventoryAlert/findMtInventoryAlert [get]
+func (mtInventoryAlertApi *MtInventoryAlertApi) FindMtInventoryAlert(c *gin.Context) {
+	ID := c.Query("ID")
+	remtInventoryAlert, err := mtInventoryAlertService.GetMtInventoryAlert(c.Request.Context(), ID)
+	if err != nil {
+		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		response.FailWithMessage("查询失败", c)
+	} else {
+		response.OkWithData(remtInventoryAlert, c)
+	}
+}
+
+// GetMtInventoryAlertList 分页获取库存告警列表
+// @Tags MtInventoryAlert
+// @Summary 分页获取库存告警列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtInventoryAlertSearch true "分页获取库存告警列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtInventoryAlert/getMtInventoryAlertList [get]
+func (mtInventoryAlertApi *MtInventoryAlertApi) GetMtInventoryAlertList(c *gin.Context) {
+	var pageInfo medicineReq.MtInventoryAlertSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtInventoryAlertService.GetMtInventoryAlertInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+	} else {
+		response.OkWithDetailed(response.PageResult{
+			List:     list,
+			Total:    total,
+			Page:     pageInfo.Page,
+			PageSize: pageInfo.PageSize,
+		}, "获取成功", c)
+	}
+}
+
+// AckMtInventoryAlert 确认库存告警
+// @Tags MtInventoryAlert
+// @Summary 确认库存告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicineReq.MtInventoryAlertAck true "待确认的告警ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"确认成功"}"
+// @Router /mtInventoryAlert/ackMtInventoryAlert [put]
+func (mtInventoryAlertApi *MtInventoryAlertApi) AckMtInventoryAlert(c *gin.Context) {
+	var ack medicineReq.MtInventoryAlertAck
+	err := c.ShouldBindJSON(&ack)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if len(ack.IDs) == 0 {
+		response.FailWithMessage("请选择要确认的告警", c)
+		return
+	}
+
+	affected, err := mtInventoryAlertService.AckMtInventoryAlerts(c.Request.Context(), ack.IDs, utils.GetUserName(c))
+	if err != nil {
+		global.GVA_LOG.Error("确认失败!", zap.Error(err))
+		response.FailWithMessage("确认失败", c)
+		return
+	}
+	response.OkWithMessage(fmt.Sprintf("已确认%d条告警", affected), c)
+}
+
+// GetOpenMtInventoryAlertCount 获取待处理库存告警数量
+// @Tags MtInventoryAlert
+// @Summary 获取待处理库存告警数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtInventoryAlert/getOpenMtInventoryAlertCount [get]
+func (mtInventoryAlertApi *MtInventoryAlertApi) GetOpenMtInventoryAlertCount(c *gin.Context) {
+	total, err := mtInventoryAlertService.CountOpenMtInventoryAlerts(c.Request.Context())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithData(gin.H{"total": total}, c)
+}
@@ -0,0 +1,136 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MtRefundRecordApi struct{}
+
+// FindMtRefundRecord 用退款单号查询退款单及明细
+// @Tags MtRefundRecord
+// @Summary 用退款单号查询退款单及明细
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param refundId query string true "退款单号"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"查询成功"}"
+// @Router /mtRefundRecord/findMtRefundRecord [get]
+func (mtRefundRecordApi *MtRefundRecordApi) FindMtRefundRecord(c *gin.Context) {
+	refundId := c.Query("refundId")
+	remtRefundRecord, err := mtRefundRecordService.GetMtRefundRecord(c.Request.Context(), refundId)
+	if err != nil {
+		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		response.FailWithMessage("查询失败", c)
+	} else {
+		response.OkWithData(remtRefundRecord, c)
+	}
+}
+
+// GetMtRefundRecordList 分页获取退款单列表
+// @Tags MtRefundRecord
+// @Summary 分页获取退款单列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtRefundRecordSearch true "分页获取退款单列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtRefundRecord/getMtRefundRecordList [get]
+func (mtRefundRecordApi *MtRefundRecordApi) GetMtRefundRecordList(c *gin.Context) {
+	var pageInfo medicineReq.MtRefundRecordSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtRefundRecordService.GetMtRefundRecordInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+	} else {
+		response.OkWithDetailed(response.PageResult{
+			List:     list,
+			Total:    total,
+			Page:     pageInfo.Page,
+			PageSize: pageInfo.PageSize,
+		}, "获取成功", c)
+	}
+}
+
+// ApproveMtRefundRecord 审核通过退款
+// @Tags MtRefundRecord
+// @Summary 审核通过退款
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicineReq.MtRefundRecordAudit true "退款单号和审核备注"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"审核成功"}"
+// @Router /mtRefundRecord/approveMtRefundRecord [put]
+func (mtRefundRecordApi *MtRefundRecordApi) ApproveMtRefundRecord(c *gin.Context) {
+	var audit medicineReq.MtRefundRecordAudit
+	err := c.ShouldBindJSON(&audit)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	err = mtRefundRecordService.ApproveMtRefundRecord(c.Request.Context(), audit.RefundId, utils.GetUserID(c), audit.Remark)
+	if err != nil {
+		global.GVA_LOG.Error("审核失败!", zap.Error(err))
+		response.FailWithMessage("审核失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("审核通过，等待退款", c)
+}
+
+// RejectMtRefundRecord 驳回退款
+// @Tags MtRefundRecord
+// @Summary 驳回退款
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicineReq.MtRefundRecordAudit true "退款单号和驳回原因"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"驳回成功"}"
+// @Router /mtRefundRecord/rejectMtRefundRecord [put]
+func (mtRefundRecordApi *MtRefundRecordApi) RejectMtRefundRecord(c *gin.Context) {
+	var audit medicineReq.MtRefundRecordAudit
+	err := c.ShouldBindJSON(&audit)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if audit.Remark == "" {
+		response.FailWithMessage("请填写驳回原因", c)
+		return
+	}
+
+	err = mtRefundRecordService.RejectMtRefundRecord(c.Request.Context(), audit.RefundId, utils.GetUserID(c), audit.Remark)
+	if err != nil {
+		global.GVA_LOG.Error("驳回失败!", zap.Error(err))
+		response.FailWithMessage("驳回失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("已驳回", c)
+}
+
+// GetPendingMtRefundRecordCount 获取待审核退款单数量
+// @Tags MtRefundRecord
+// @Summary 获取待审核退款单数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtRefundRecord/getPendingMtRefundRecordCount [get]
+func (mtRefundRecordApi *MtRefundRecordApi) GetPendingMtRefundRecordCount(c *gin.Context) {
+	total, err := mtRefundRecordService.CountPendingMtRefundRecords(c.Request.Context())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithData(gin.H{"total": total}, c)
+}
@@ -0,0 +1,163 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MtSymptomApi struct{}
+
+// CreateMtSymptom 创建症状分类
+// @Tags MtSymptom
+// @Summary 创建症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body medicine.MtSymptom true "创建症状分类"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /mtSymptom/createMtSymptom [post]
+func (mtSymptomApi *MtSymptomApi) CreateMtSymptom(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var mtSymptom medicine.MtSymptom
+	err := c.ShouldBindJSON(&mtSymptom)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtSymptomService.CreateMtSymptom(ctx, &mtSymptom)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// DeleteMtSymptom 删除症状分类
+// @Tags MtSymptom
+// @Summary 删除症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /mtSymptom/deleteMtSymptom [delete]
+func (mtSymptomApi *MtSymptomApi) DeleteMtSymptom(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ID := c.Query("ID")
+	err := mtSymptomService.DeleteMtSymptom(ctx, ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// DeleteMtSymptomByIds 批量删除症状分类
+// @Tags MtSymptom
+// @Summary 批量删除症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{msg=string} "批量删除成功"
+// @Router /mtSymptom/deleteMtSymptomByIds [delete]
+func (mtSymptomApi *MtSymptomApi) DeleteMtSymptomByIds(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	IDs := c.QueryArray("IDs[]")
+	err := mtSymptomService.DeleteMtSymptomByIds(ctx, IDs)
+	if err != nil {
+		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
+		response.FailWithMessage("批量删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("批量删除成功", c)
+}
+
+// UpdateMtSymptom 更新症状分类
+// @Tags MtSymptom
+// @Summary 更新症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body medicine.MtSymptom true "更新症状分类"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /mtSymptom/updateMtSymptom [put]
+func (mtSymptomApi *MtSymptomApi) UpdateMtSymptom(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var mtSymptom medicine.MtSymptom
+	err := c.ShouldBindJSON(&mtSymptom)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = mtSymptomService.UpdateMtSymptom(ctx, mtSymptom)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// FindMtSymptom 用id查询症状分类
+// @Tags MtSymptom
+// @Summary 用id查询症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query uint true "用id查询症状分类"
+// @Success 200 {object} response.Response{data=medicine.MtSymptom,msg=string} "查询成功"
+// @Router /mtSymptom/findMtSymptom [get]
+func (mtSymptomApi *MtSymptomApi) FindMtSymptom(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ID := c.Query("ID")
+	reMtSymptom, err := mtSymptomService.GetMtSymptom(ctx, ID)
+	if err != nil {
+		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		response.FailWithMessage("查询失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithData(reMtSymptom, c)
+}
+
+// GetMtSymptomList 分页获取症状分类列表
+// @Tags MtSymptom
+// @Summary 分页获取症状分类列表
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data query medicineReq.MtSymptomSearch true "分页获取症状分类列表"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /mtSymptom/getMtSymptomList [get]
+func (mtSymptomApi *MtSymptomApi) GetMtSymptomList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var pageInfo medicineReq.MtSymptomSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := mtSymptomService.GetMtSymptomInfoList(ctx, pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
@@ -27,5 +27,11 @@ func initBizRouter(routers ...*gin.RouterGroup) {
 		medicineRouter.InitMtOrdersRouter(privateGroup, publicGroup)
 		medicineRouter.InitMtChatMessageRouter(privateGroup, publicGroup)
 		medicineRouter.InitMtDiscountRouter(privateGroup, publicGroup)
+		medicineRouter.InitMtInventoryAlertRouter(privateGroup, publicGroup)
+		medicineRouter.InitMtRefundRecordRouter(privateGroup, publicGroup)
+		medicineRouter.InitMtDoctorScheduleRouter(privateGroup, publicGroup)
+		medicineRouter.InitMtDrugInteractionRouter(privateGroup, publicGroup)
+		medicineRouter.InitMtHotSearchBlockRouter(privateGroup, publicGroup)
+		medicineRouter.InitMtSymptomRouter(privateGroup, publicGroup)
 	}
 }
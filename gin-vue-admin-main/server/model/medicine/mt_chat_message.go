@@ -2,20 +2,27 @@
 package medicine
 
 import (
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 )
 
 // mtChatMessage表 结构体  MtChatMessage
 type MtChatMessage struct {
 	global.GVA_MODEL
-	FromId      *int    `json:"fromId" form:"fromId" gorm:"comment:发送者id;column:from_id;size:10;" binding:"required"`                //发送者id
-	ToId        *int    `json:"toId" form:"toId" gorm:"comment:接收者id;column:to_id;size:10;" binding:"required"`                      //接收者id
-	Content     *string `json:"content" form:"content" gorm:"comment:消息内容;column:content;size:50;" binding:"required"`              //消息内容
-	MessageType *string `json:"messageType" form:"messageType" gorm:"comment:消息类型;column:message_type;size:20;" binding:"required"` //消息类型
-	RoomId      *int    `json:"roomId" form:"roomId" gorm:"comment:房间id;column:room_id;size:10;" binding:"required"`                  //房间id
-	CreatedBy   uint    `gorm:"column:created_by;comment:创建者"`
-	UpdatedBy   uint    `gorm:"column:updated_by;comment:更新者"`
-	DeletedBy   uint    `gorm:"column:deleted_by;comment:删除者"`
+	FromRole    *string    `json:"fromRole" form:"fromRole" gorm:"comment:发送者角色;column:from_role;size:20;"`                            //发送者角色
+	FromId      *int       `json:"fromId" form:"fromId" gorm:"comment:发送者id;column:from_id;size:10;" binding:"required"`               //发送者id
+	ToRole      *string    `json:"toRole" form:"toRole" gorm:"comment:接收者角色;column:to_role;size:20;"`                                  //接收者角色
+	ToId        *int       `json:"toId" form:"toId" gorm:"comment:接收者id;column:to_id;size:10;" binding:"required"`                     //接收者id
+	Content     *string    `json:"content" form:"content" gorm:"comment:消息内容;column:content;type:text;" binding:"required"`            //消息内容
+	MessageType *string    `json:"messageType" form:"messageType" gorm:"comment:消息类型;column:message_type;size:20;" binding:"required"` //消息类型
+	RoomId      *int       `json:"roomId" form:"roomId" gorm:"comment:房间id;column:room_id;size:10;" binding:"required"`                //房间id
+	Payload     *string    `json:"payload" form:"payload" gorm:"comment:消息结构化数据;column:payload;type:text;"`                            //消息结构化数据
+	DeliveredAt *time.Time `json:"deliveredAt" form:"deliveredAt" gorm:"comment:送达时间;column:delivered_at;"`                            //送达时间
+	ReadAt      *time.Time `json:"readAt" form:"readAt" gorm:"comment:已读时间;column:read_at;"`                                           //已读时间
+	CreatedBy   uint       `gorm:"column:created_by;comment:创建者"`
+	UpdatedBy   uint       `gorm:"column:updated_by;comment:更新者"`
+	DeletedBy   uint       `gorm:"column:deleted_by;comment:删除者"`
 }
 
 // TableName mtChatMessage表 MtChatMessage自定义表名 mt_chat_message
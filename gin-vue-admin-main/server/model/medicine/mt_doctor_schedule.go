@@ -0,0 +1,101 @@
+package medicine
+
+import (
+	"time"
+)
+
+// 排班例外类型，与C端服务保持一致
+const (
+	ScheduleExceptionOff   = "off"   // 停诊，未填时间表示全天停诊
+	ScheduleExceptionExtra = "extra" // 加诊
+)
+
+// 号源时段状态
+const (
+	ScheduleSlotOpen   = "open"   // 可预约
+	ScheduleSlotClosed = "closed" // 已停诊
+)
+
+// mtDoctorSchedule表 结构体  MtDoctorSchedule
+// 医生每周排班模板，C端服务在患者查询号源时按模板和例外生成时段
+type MtDoctorSchedule struct {
+	ID          uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	DoctorId    int        `json:"doctorId" form:"doctorId" gorm:"comment:医生ID;column:doctor_id;" binding:"required"`                    //医生ID
+	Weekday     int        `json:"weekday" form:"weekday" gorm:"comment:星期，1-7表示周一到周日;column:weekday;" binding:"required,min=1,max=7"`   //星期
+	StartTime   string     `json:"startTime" form:"startTime" gorm:"comment:出诊开始时间;column:start_time;size:5;" binding:"required"`        //出诊开始时间
+	EndTime     string     `json:"endTime" form:"endTime" gorm:"comment:出诊结束时间;column:end_time;size:5;" binding:"required"`              //出诊结束时间
+	SlotMinutes int        `json:"slotMinutes" form:"slotMinutes" gorm:"comment:每个时段的分钟数;column:slot_minutes;" binding:"required,min=5"` //每个时段的分钟数
+	Capacity    int        `json:"capacity" form:"capacity" gorm:"comment:每个时段的号源数;column:capacity;" binding:"required,min=1"`           //每个时段的号源数
+	Status      int        `json:"status" form:"status" gorm:"comment:状态：1启用 0停用;column:status;"`                                        //状态：1启用 0停用
+	CreatedAt   time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`                                    //创建时间
+	UpdatedAt   *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`                                    //更新时间
+}
+
+// TableName mtDoctorSchedule表 MtDoctorSchedule自定义表名 mt_doctor_schedule
+func (MtDoctorSchedule) TableName() string {
+	return "mt_doctor_schedule"
+}
+
+// mtDoctorScheduleException表 结构体  MtDoctorScheduleException
+// 按日期的停诊或加诊，停诊时段内不生成号源，加诊时段额外生成号源
+type MtDoctorScheduleException struct {
+	ID            uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	DoctorId      int        `json:"doctorId" form:"doctorId" gorm:"comment:医生ID;column:doctor_id;" binding:"required"`                        //医生ID
+	ExceptionDate string     `json:"exceptionDate" form:"exceptionDate" gorm:"comment:日期;column:exception_date;size:10;" binding:"required"`   //日期
+	Type          string     `json:"type" form:"type" gorm:"comment:类型：off停诊 extra加诊;column:type;size:16;" binding:"required,oneof=off extra"` //类型
+	StartTime     string     `json:"startTime" form:"startTime" gorm:"comment:开始时间，停诊时为空表示全天;column:start_time;size:5;"`                       //开始时间
+	EndTime       string     `json:"endTime" form:"endTime" gorm:"comment:结束时间;column:end_time;size:5;"`                                       //结束时间
+	SlotMinutes   int        `json:"slotMinutes" form:"slotMinutes" gorm:"comment:加诊每个时段的分钟数;column:slot_minutes;"`                            //加诊每个时段的分钟数
+	Capacity      int        `json:"capacity" form:"capacity" gorm:"comment:加诊每个时段的号源数;column:capacity;"`                                      //加诊每个时段的号源数
+	Reason        string     `json:"reason" form:"reason" gorm:"comment:原因;column:reason;size:255;"`                                           //原因
+	CreatedAt     time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`                                        //创建时间
+	UpdatedAt     *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`                                        //更新时间
+}
+
+// TableName mtDoctorScheduleException表 MtDoctorScheduleException自定义表名 mt_doctor_schedule_exception
+func (MtDoctorScheduleException) TableName() string {
+	return "mt_doctor_schedule_exception"
+}
+
+// mtScheduleSlot表 结构体  MtScheduleSlot
+// 由C端服务生成和占用，后台只读
+type MtScheduleSlot struct {
+	ID        uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	DoctorId  int        `json:"doctorId" form:"doctorId" gorm:"comment:医生ID;column:doctor_id;"`                  //医生ID
+	SlotDate  string     `json:"slotDate" form:"slotDate" gorm:"comment:日期;column:slot_date;size:10;"`            //日期
+	StartTime string     `json:"startTime" form:"startTime" gorm:"comment:开始时间;column:start_time;size:5;"`        //开始时间
+	EndTime   string     `json:"endTime" form:"endTime" gorm:"comment:结束时间;column:end_time;size:5;"`              //结束时间
+	Capacity  int        `json:"capacity" form:"capacity" gorm:"comment:号源数;column:capacity;"`                    //号源数
+	Booked    int        `json:"booked" form:"booked" gorm:"comment:已预约数;column:booked;"`                         //已预约数
+	Status    string     `json:"status" form:"status" gorm:"comment:状态：open可预约 closed已停诊;column:status;size:16;"` //状态
+	CreatedAt time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`               //创建时间
+	UpdatedAt *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`               //更新时间
+}
+
+// TableName mtScheduleSlot表 MtScheduleSlot自定义表名 mt_schedule_slot
+func (MtScheduleSlot) TableName() string {
+	return "mt_schedule_slot"
+}
+
+// mtAppointment表 结构体  MtAppointment
+// 患者在C端预约和取消，后台只读
+type MtAppointment struct {
+	ID            uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	AppointmentNo string     `json:"appointmentNo" form:"appointmentNo" gorm:"comment:预约单号;column:appointment_no;size:64;"` //预约单号
+	SlotId        int64      `json:"slotId" form:"slotId" gorm:"comment:时段ID;column:slot_id;"`                              //时段ID
+	DoctorId      int        `json:"doctorId" form:"doctorId" gorm:"comment:医生ID;column:doctor_id;"`                        //医生ID
+	DoctorName    string     `json:"doctorName" form:"doctorName" gorm:"comment:医生姓名;column:doctor_name;size:50;"`          //医生姓名
+	PatientId     int        `json:"patientId" form:"patientId" gorm:"comment:患者用户ID;column:patient_id;"`                   //患者用户ID
+	SlotDate      string     `json:"slotDate" form:"slotDate" gorm:"comment:就诊日期;column:slot_date;size:10;"`                //就诊日期
+	StartTime     string     `json:"startTime" form:"startTime" gorm:"comment:就诊开始时间;column:start_time;size:5;"`            //就诊开始时间
+	EndTime       string     `json:"endTime" form:"endTime" gorm:"comment:就诊结束时间;column:end_time;size:5;"`                  //就诊结束时间
+	Status        string     `json:"status" form:"status" gorm:"comment:状态：booked已预约 cancelled已取消;column:status;size:16;"`  //状态
+	CancelReason  string     `json:"cancelReason" form:"cancelReason" gorm:"comment:取消原因;column:cancel_reason;size:255;"`   //取消原因
+	CancelledAt   *time.Time `json:"cancelledAt" form:"cancelledAt" gorm:"comment:取消时间;column:cancelled_at;"`               //取消时间
+	CreatedAt     time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:预约时间;column:created_at;"`                     //预约时间
+}
+
+// TableName mtAppointment表 MtAppointment自定义表名 mt_appointment
+func (MtAppointment) TableName() string {
+	return "mt_appointment"
+}
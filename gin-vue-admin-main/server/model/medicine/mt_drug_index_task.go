@@ -0,0 +1,19 @@
+package medicine
+
+import (
+	"time"
+)
+
+// mtDrugIndexTask表 结构体  MtDrugIndexTask
+// 药品变更后登记的搜索索引同步任务，由C端服务消费并写入Elasticsearch
+type MtDrugIndexTask struct {
+	ID          uint       `json:"id" gorm:"primarykey;column:id"`
+	DrugId      uint       `json:"drugId" gorm:"column:drug_id;comment:药品ID;not null"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"column:created_at;comment:登记时间"`
+	ProcessedAt *time.Time `json:"processedAt" gorm:"column:processed_at;comment:同步时间"`
+}
+
+// TableName mtDrugIndexTask表 MtDrugIndexTask自定义表名 mt_drug_index_task
+func (MtDrugIndexTask) TableName() string {
+	return "mt_drug_index_task"
+}
@@ -0,0 +1,64 @@
+package medicine
+
+import (
+	"time"
+)
+
+// 药物相互作用严重程度，与C端服务保持一致；严重相互作用拦截开方和下单
+const (
+	InteractionSeverityMinor    = "minor"    // 轻微
+	InteractionSeverityModerate = "moderate" // 中度
+	InteractionSeverityMajor    = "major"    // 严重
+)
+
+// 禁忌人群
+const (
+	PopulationPregnancy = "pregnancy" // 孕妇
+	PopulationLactation = "lactation" // 哺乳期
+	PopulationChild     = "child"     // 儿童
+	PopulationElderly   = "elderly"   // 老人
+)
+
+// 禁忌级别，禁用拦截开方和下单
+const (
+	ContraindicationCaution   = "caution"   // 慎用
+	ContraindicationForbidden = "forbidden" // 禁用
+)
+
+// mtDrugInteraction表 结构体  MtDrugInteraction
+// 药品两两之间的相互作用，DrugAId 小于 DrugBId，由CSV导入
+type MtDrugInteraction struct {
+	ID          uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	DrugAId     int64      `json:"drugAId" form:"drugAId" gorm:"comment:药品A ID;column:drug_a_id;"`                                      //药品A ID
+	DrugBId     int64      `json:"drugBId" form:"drugBId" gorm:"comment:药品B ID;column:drug_b_id;"`                                      //药品B ID
+	Severity    string     `json:"severity" form:"severity" gorm:"comment:严重程度 minor-轻微 moderate-中度 major-严重;column:severity;size:16;"` //严重程度
+	Description string     `json:"description" form:"description" gorm:"comment:相互作用说明;column:description;size:500;"`                   //相互作用说明
+	Advice      string     `json:"advice" form:"advice" gorm:"comment:用药建议;column:advice;size:500;"`                                    //用药建议
+	CreatedAt   time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`                                   //创建时间
+	UpdatedAt   *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`                                   //更新时间
+	DrugAName   string     `json:"drugAName" form:"drugAName" gorm:"-"`                                                                 //药品A名称
+	DrugBName   string     `json:"drugBName" form:"drugBName" gorm:"-"`                                                                 //药品B名称
+}
+
+// TableName mtDrugInteraction表 MtDrugInteraction自定义表名 mt_drug_interaction
+func (MtDrugInteraction) TableName() string {
+	return "mt_drug_interaction"
+}
+
+// mtDrugContraindication表 结构体  MtDrugContraindication
+// 药品对特定人群的禁忌，由CSV导入
+type MtDrugContraindication struct {
+	ID          uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	DrugId      int64      `json:"drugId" form:"drugId" gorm:"comment:药品ID;column:drug_id;"`                                                                 //药品ID
+	Population  string     `json:"population" form:"population" gorm:"comment:人群 pregnancy-孕妇 lactation-哺乳期 child-儿童 elderly-老人;column:population;size:16;"` //人群
+	Level       string     `json:"level" form:"level" gorm:"comment:级别 caution-慎用 forbidden-禁用;column:level;size:16;"`                                       //级别
+	Description string     `json:"description" form:"description" gorm:"comment:禁忌说明;column:description;size:500;"`                                          //禁忌说明
+	CreatedAt   time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`                                                        //创建时间
+	UpdatedAt   *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`                                                        //更新时间
+	DrugName    string     `json:"drugName" form:"drugName" gorm:"-"`                                                                                        //药品名称
+}
+
+// TableName mtDrugContraindication表 MtDrugContraindication自定义表名 mt_drug_contraindication
+func (MtDrugContraindication) TableName() string {
+	return "mt_drug_contraindication"
+}
@@ -0,0 +1,20 @@
+package medicine
+
+import (
+	"time"
+)
+
+// mtHotSearchBlock表 结构体  MtHotSearchBlock
+// 热搜屏蔽词，C端热门搜索中包含屏蔽词的搜索词不展示，修改后立即生效
+type MtHotSearchBlock struct {
+	ID        uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	Term      string     `json:"term" form:"term" gorm:"comment:屏蔽词;column:term;size:64;" binding:"required"` //屏蔽词
+	Remark    string     `json:"remark" form:"remark" gorm:"comment:备注;column:remark;size:255;"`              //备注
+	CreatedAt time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`           //创建时间
+	UpdatedAt *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`           //更新时间
+}
+
+// TableName mtHotSearchBlock表 MtHotSearchBlock自定义表名 mt_hot_search_block
+func (MtHotSearchBlock) TableName() string {
+	return "mt_hot_search_block"
+}
@@ -0,0 +1,33 @@
+package medicine
+
+import (
+	"time"
+)
+
+// 库存告警状态
+const (
+	InventoryAlertOpen         = 0 // 待处理
+	InventoryAlertAcknowledged = 1 // 已确认
+)
+
+// mtInventoryAlert表 结构体  MtInventoryAlert
+// 由C端服务在药店可售库存跌破预警阈值或售罄时写入，后台只做查询和确认
+type MtInventoryAlert struct {
+	ID             uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	InventoryId    *int64     `json:"inventoryId" form:"inventoryId" gorm:"comment:药店库存ID;column:inventory_id;"`        //药店库存ID
+	DrugId         *int64     `json:"drugId" form:"drugId" gorm:"comment:药品ID;column:drug_id;"`                         //药品ID
+	DrugName       *string    `json:"drugName" form:"drugName" gorm:"comment:药品名称;column:drug_name;size:50;"`           //药品名称
+	DrugStoreId    *int32     `json:"drugStoreId" form:"drugStoreId" gorm:"comment:药店ID;column:drug_store_id;"`         //药店ID
+	Level          *int8      `json:"level" form:"level" gorm:"comment:告警级别 1-库存不足 2-缺货;column:level;"`                 //告警级别
+	AvailableQty   *int64     `json:"availableQty" form:"availableQty" gorm:"comment:触发时可售数量;column:available_qty;"`    //触发时可售数量
+	AlertThreshold *int64     `json:"alertThreshold" form:"alertThreshold" gorm:"comment:预警阈值;column:alert_threshold;"` //预警阈值
+	Status         *int8      `json:"status" form:"status" gorm:"comment:处理状态 0-待处理 1-已确认;column:status;"`              //处理状态
+	AckBy          *string    `json:"ackBy" form:"ackBy" gorm:"comment:确认人;column:ack_by;size:50;"`                     //确认人
+	AckAt          *time.Time `json:"ackAt" form:"ackAt" gorm:"comment:确认时间;column:ack_at;"`                            //确认时间
+	CreatedAt      time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:告警时间;column:created_at;"`                //告警时间
+}
+
+// TableName mtInventoryAlert表 MtInventoryAlert自定义表名 mt_inventory_alert
+func (MtInventoryAlert) TableName() string {
+	return "mt_inventory_alert"
+}
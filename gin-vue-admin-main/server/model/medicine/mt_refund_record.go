@@ -0,0 +1,64 @@
+package medicine
+
+import (
+	"time"
+)
+
+// 退款单状态，与C端服务保持一致
+const (
+	RefundStatusRequested  = "requested"  // 待审核
+	RefundStatusApproved   = "approved"   // 审核通过，等待C端退款任务提交支付渠道
+	RefundStatusRejected   = "rejected"   // 已驳回
+	RefundStatusProcessing = "processing" // 退款中
+	RefundStatusSucceeded  = "succeeded"  // 退款成功
+	RefundStatusFailed     = "failed"     // 退款失败
+)
+
+// refundRecords表 结构体  MtRefundRecord
+// 由C端服务在用户申请退款时写入，后台负责审核；审核通过后由C端退款任务调用支付渠道
+type MtRefundRecord struct {
+	ID              uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	OrderId         *string    `json:"orderId" form:"orderId" gorm:"comment:支付订单号;column:order_id;size:64;"`                            //支付订单号
+	OrderNo         *string    `json:"orderNo" form:"orderNo" gorm:"comment:药品订单号;column:order_no;size:64;"`                            //药品订单号
+	UserId          *int64     `json:"userId" form:"userId" gorm:"comment:申请人;column:user_id;"`                                         //申请人
+	RefundId        *string    `json:"refundId" form:"refundId" gorm:"comment:退款单号;column:refund_id;size:64;"`                          //退款单号
+	RefundAmount    *string    `json:"refundAmount" form:"refundAmount" gorm:"comment:退款金额;column:refund_amount;size:16;"`              //退款金额
+	RefundReason    *string    `json:"refundReason" form:"refundReason" gorm:"comment:退款原因;column:refund_reason;"`                      //退款原因
+	RefundStatus    *string    `json:"refundStatus" form:"refundStatus" gorm:"comment:退款状态;column:refund_status;size:32;"`              //退款状态
+	AuditBy         *int64     `json:"auditBy" form:"auditBy" gorm:"comment:审核人;column:audit_by;"`                                      //审核人
+	AuditTime       *time.Time `json:"auditTime" form:"auditTime" gorm:"comment:审核时间;column:audit_time;"`                               //审核时间
+	AuditRemark     *string    `json:"auditRemark" form:"auditRemark" gorm:"comment:审核备注;column:audit_remark;size:255;"`                //审核备注
+	GatewayRefundNo *string    `json:"gatewayRefundNo" form:"gatewayRefundNo" gorm:"comment:渠道退款流水号;column:gateway_refund_no;size:64;"` //渠道退款流水号
+	FailReason      *string    `json:"failReason" form:"failReason" gorm:"comment:失败原因;column:fail_reason;size:255;"`                   //失败原因
+	RefundTime      *time.Time `json:"refundTime" form:"refundTime" gorm:"comment:退款时间;column:refund_time;"`                            //退款时间
+	CreatedAt       time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:申请时间;column:created_at;"`                               //申请时间
+	UpdatedAt       *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`                               //更新时间
+}
+
+// TableName refundRecords表 MtRefundRecord自定义表名 refund_records
+func (MtRefundRecord) TableName() string {
+	return "refund_records"
+}
+
+// mtRefundItem表 结构体  MtRefundItem
+type MtRefundItem struct {
+	ID          uint      `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	RefundId    string    `json:"refundId" form:"refundId" gorm:"comment:退款单号;column:refund_id;size:64;"`     //退款单号
+	OrderItemId int64     `json:"orderItemId" form:"orderItemId" gorm:"comment:订单项ID;column:order_item_id;"`  //订单项ID
+	DrugId      int64     `json:"drugId" form:"drugId" gorm:"comment:药品ID;column:drug_id;"`                   //药品ID
+	DrugName    string    `json:"drugName" form:"drugName" gorm:"comment:药品名称;column:drug_name;size:100;"`    //药品名称
+	Quantity    int32     `json:"quantity" form:"quantity" gorm:"comment:退款数量;column:quantity;"`              //退款数量
+	Amount      string    `json:"amount" form:"amount" gorm:"comment:退款金额;column:amount;type:decimal(10,2);"` //退款金额
+	CreatedAt   time.Time `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`          //创建时间
+}
+
+// TableName mtRefundItem表 MtRefundItem自定义表名 mt_refund_item
+func (MtRefundItem) TableName() string {
+	return "mt_refund_item"
+}
+
+// 退款单详情
+type MtRefundRecordDetail struct {
+	MtRefundRecord
+	Items []MtRefundItem `json:"items"`
+}
@@ -6,8 +6,12 @@ type MtChatRoom struct {
 	Id              int32     `gorm:"column:id;type:int;primaryKey;not null;" json:"id"`
 	RoomId          string    `gorm:"column:room_id;type:varchar(50);comment:房间ID;default:NULL;" json:"room_id"`                                       // 房间ID
 	RoomName        string    `gorm:"column:room_name;type:varchar(50);comment:房间名称;default:NULL;" json:"room_name"`                                   // 房间名称
+	User1Role       string    `gorm:"column:user1_role;type:varchar(20);comment:用户1角色;not null;default:'';" json:"user1_role"`                         // 用户1角色
 	User1Id         int32     `gorm:"column:user1_id;type:int;comment:用户1id;not null;default:0;" json:"user1_id"`                                      // 用户1id
+	User2Role       string    `gorm:"column:user2_role;type:varchar(20);comment:用户2角色;not null;default:'';" json:"user2_role"`                         // 用户2角色
 	User2Id         int32     `gorm:"column:user2_id;type:int;comment:用户2id;not null;default:0;" json:"user2_id"`                                      // 用户2id
+	User1Unread     int32     `gorm:"column:user1_unread;type:int;comment:用户1未读数;not null;default:0;" json:"user1_unread"`                             // 用户1未读数
+	User2Unread     int32     `gorm:"column:user2_unread;type:int;comment:用户2未读数;not null;default:0;" json:"user2_unread"`                             // 用户2未读数
 	LastMessage     string    `gorm:"column:last_message;type:varchar(50);comment:最后一条消息;default:NULL;" json:"last_message"`                           // 最后一条消息
 	LastMessageTime time.Time `gorm:"column:last_message_time;type:datetime(6);comment:最后消息时间;default:CURRENT_TIMESTAMP(6);" json:"last_message_time"` // 最后消息时间
 	CreatedAt       time.Time `gorm:"column:created_at;type:datetime(6);default:CURRENT_TIMESTAMP(6);" json:"created_at"`
@@ -0,0 +1,26 @@
+package medicine
+
+import (
+	"time"
+)
+
+// mtSymptom表 结构体  MtSymptom
+// 症状分类，C端按症状推荐非处方药时使用；同义说法、适应症关键词和药品分类ID均以英文逗号分隔
+type MtSymptom struct {
+	ID           uint       `json:"ID" form:"ID" gorm:"primarykey;column:id;"`
+	Name         string     `json:"name" form:"name" gorm:"comment:症状名称;column:name;size:20;" binding:"required"`            //症状名称
+	Aliases      string     `json:"aliases" form:"aliases" gorm:"comment:同义说法;column:aliases;size:255;"`                     //同义说法
+	Indications  string     `json:"indications" form:"indications" gorm:"comment:适应症关键词;column:indications;size:255;"`       //适应症关键词
+	CategoryIDs  string     `json:"categoryIds" form:"categoryIds" gorm:"comment:对症的药品一级分类ID;column:category_ids;size:255;"` //对症的药品一级分类ID
+	DepartmentID *int       `json:"departmentId" form:"departmentId" gorm:"comment:建议就诊科室ID;column:department_id;"`          //建议就诊科室ID
+	Advice       string     `json:"advice" form:"advice" gorm:"comment:就医提示;column:advice;size:255;"`                        //就医提示
+	Sort         *int       `json:"sort" form:"sort" gorm:"comment:排序;column:sort;"`                                         //排序
+	Enabled      *bool      `json:"enabled" form:"enabled" gorm:"comment:是否启用;column:enabled;"`                              //是否启用
+	CreatedAt    time.Time  `json:"CreatedAt" form:"CreatedAt" gorm:"comment:创建时间;column:created_at;"`                       //创建时间
+	UpdatedAt    *time.Time `json:"UpdatedAt" form:"UpdatedAt" gorm:"comment:更新时间;column:updated_at;"`                       //更新时间
+}
+
+// TableName mtSymptom表 MtSymptom自定义表名 mt_symptom
+func (MtSymptom) TableName() string {
+	return "mt_symptom"
+}
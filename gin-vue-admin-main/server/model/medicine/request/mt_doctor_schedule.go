@@ -0,0 +1,33 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type MtDoctorScheduleSearch struct {
+	request.PageInfo
+	DoctorId *int `json:"doctorId" form:"doctorId"`
+	Weekday  *int `json:"weekday" form:"weekday"`
+}
+
+type MtScheduleExceptionSearch struct {
+	request.PageInfo
+	DoctorId  *int    `json:"doctorId" form:"doctorId"`
+	StartDate *string `json:"startDate" form:"startDate"`
+	EndDate   *string `json:"endDate" form:"endDate"`
+}
+
+type MtScheduleSlotSearch struct {
+	request.PageInfo
+	DoctorId *int    `json:"doctorId" form:"doctorId"`
+	SlotDate *string `json:"slotDate" form:"slotDate"`
+}
+
+type MtAppointmentSearch struct {
+	request.PageInfo
+	AppointmentNo *string `json:"appointmentNo" form:"appointmentNo"`
+	DoctorId      *int    `json:"doctorId" form:"doctorId"`
+	PatientId     *int    `json:"patientId" form:"patientId"`
+	SlotDate      *string `json:"slotDate" form:"slotDate"`
+	Status        *string `json:"status" form:"status"`
+}
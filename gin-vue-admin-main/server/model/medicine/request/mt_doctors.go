@@ -10,3 +10,9 @@ type MtDoctorsSearch struct{
     CreatedAtRange []time.Time `json:"createdAtRange" form:"createdAtRange[]"`
     request.PageInfo
 }
+
+// MtDoctorPasswordReset 重置医生登录密码
+type MtDoctorPasswordReset struct {
+	ID       uint   `json:"ID" binding:"required"`
+	Password string `json:"password" binding:"required,min=6,max=32"`
+}
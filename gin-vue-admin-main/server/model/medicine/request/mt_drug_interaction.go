@@ -0,0 +1,18 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type MtDrugInteractionSearch struct {
+	request.PageInfo
+	DrugId   *int64  `json:"drugId" form:"drugId"`
+	Severity *string `json:"severity" form:"severity"`
+}
+
+type MtDrugContraindicationSearch struct {
+	request.PageInfo
+	DrugId     *int64  `json:"drugId" form:"drugId"`
+	Population *string `json:"population" form:"population"`
+	Level      *string `json:"level" form:"level"`
+}
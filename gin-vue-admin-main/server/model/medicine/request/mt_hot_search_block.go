@@ -0,0 +1,10 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type MtHotSearchBlockSearch struct {
+	request.PageInfo
+	Term *string `json:"term" form:"term"`
+}
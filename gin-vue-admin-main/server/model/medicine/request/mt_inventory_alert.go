@@ -0,0 +1,20 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type MtInventoryAlertSearch struct {
+	request.PageInfo
+	DrugName       *string     `json:"drugName" form:"drugName"`
+	DrugStoreId    *int32      `json:"drugStoreId" form:"drugStoreId"`
+	Level          *int8       `json:"level" form:"level"`
+	Status         *int8       `json:"status" form:"status"`
+	CreatedAtRange []time.Time `json:"createdAtRange" form:"createdAtRange[]"`
+}
+
+type MtInventoryAlertAck struct {
+	IDs []uint `json:"ids" form:"ids" binding:"required"`
+}
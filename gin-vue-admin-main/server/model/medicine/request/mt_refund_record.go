@@ -0,0 +1,21 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type MtRefundRecordSearch struct {
+	request.PageInfo
+	RefundId       *string     `json:"refundId" form:"refundId"`
+	OrderNo        *string     `json:"orderNo" form:"orderNo"`
+	UserId         *int64      `json:"userId" form:"userId"`
+	RefundStatus   *string     `json:"refundStatus" form:"refundStatus"`
+	CreatedAtRange []time.Time `json:"createdAtRange" form:"createdAtRange[]"`
+}
+
+type MtRefundRecordAudit struct {
+	RefundId string `json:"refundId" form:"refundId" binding:"required"`
+	Remark   string `json:"remark" form:"remark"`
+}
@@ -0,0 +1,11 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type MtSymptomSearch struct {
+	request.PageInfo
+	Name    *string `json:"name" form:"name"`
+	Enabled *bool   `json:"enabled" form:"enabled"`
+}
@@ -18,22 +18,34 @@ type RouterGroup struct {
 	MtOrdersRouter
 	MtChatMessageRouter
 	MtDiscountRouter
+	MtInventoryAlertRouter
+	MtRefundRecordRouter
+	MtDoctorScheduleRouter
+	MtDrugInteractionRouter
+	MtHotSearchBlockRouter
+	MtSymptomRouter
 }
 
 var (
-	mtDrugTypeStairApi  = api.ApiGroupApp.MedicineApiGroup.MtDrugTypeStairApi
-	mtDrugTypeLevelApi  = api.ApiGroupApp.MedicineApiGroup.MtDrugTypeLevelApi
-	mtDoctorsApi        = api.ApiGroupApp.MedicineApiGroup.MtDoctorsApi
-	mtHospitalsApi      = api.ApiGroupApp.MedicineApiGroup.MtHospitalsApi
-	mtDepartmentsApi    = api.ApiGroupApp.MedicineApiGroup.MtDepartmentsApi
-	mtDoctorApprovalApi = api.ApiGroupApp.MedicineApiGroup.MtDoctorApprovalApi
-	mtOrdersDrugApi     = api.ApiGroupApp.MedicineApiGroup.MtOrdersDrugApi
-	mtUserApi           = api.ApiGroupApp.MedicineApiGroup.MtUserApi
-	mtDrugApi           = api.ApiGroupApp.MedicineApiGroup.MtDrugApi
-	mtGuideApi          = api.ApiGroupApp.MedicineApiGroup.MtGuideApi
-	mtExplainApi        = api.ApiGroupApp.MedicineApiGroup.MtExplainApi
-	mtDoctorPatientsApi = api.ApiGroupApp.MedicineApiGroup.MtDoctorPatientsApi
-	mtOrdersApi         = api.ApiGroupApp.MedicineApiGroup.MtOrdersApi
-	mtChatMessageApi    = api.ApiGroupApp.MedicineApiGroup.MtChatMessageApi
-	mtDiscountApi       = api.ApiGroupApp.MedicineApiGroup.MtDiscountApi
+	mtDrugTypeStairApi   = api.ApiGroupApp.MedicineApiGroup.MtDrugTypeStairApi
+	mtDrugTypeLevelApi   = api.ApiGroupApp.MedicineApiGroup.MtDrugTypeLevelApi
+	mtDoctorsApi         = api.ApiGroupApp.MedicineApiGroup.MtDoctorsApi
+	mtHospitalsApi       = api.ApiGroupApp.MedicineApiGroup.MtHospitalsApi
+	mtDepartmentsApi     = api.ApiGroupApp.MedicineApiGroup.MtDepartmentsApi
+	mtDoctorApprovalApi  = api.ApiGroupApp.MedicineApiGroup.MtDoctorApprovalApi
+	mtOrdersDrugApi      = api.ApiGroupApp.MedicineApiGroup.MtOrdersDrugApi
+	mtUserApi            = api.ApiGroupApp.MedicineApiGroup.MtUserApi
+	mtDrugApi            = api.ApiGroupApp.MedicineApiGroup.MtDrugApi
+	mtGuideApi           = api.ApiGroupApp.MedicineApiGroup.MtGuideApi
+	mtExplainApi         = api.ApiGroupApp.MedicineApiGroup.MtExplainApi
+	mtDoctorPatientsApi  = api.ApiGroupApp.MedicineApiGroup.MtDoctorPatientsApi
+	mtOrdersApi          = api.ApiGroupApp.MedicineApiGroup.MtOrdersApi
+	mtChatMessageApi     = api.ApiGroupApp.MedicineApiGroup.MtChatMessageApi
+	mtDiscountApi        = api.ApiGroupApp.MedicineApiGroup.MtDiscountApi
+	mtInventoryAlertApi  = api.ApiGroupApp.MedicineApiGroup.MtInventoryAlertApi
+	mtRefundRecordApi    = api.ApiGroupApp.MedicineApiGroup.MtRefundRecordApi
+	mtDoctorScheduleApi  = api.ApiGroupApp.MedicineApiGroup.MtDoctorScheduleApi
+	mtDrugInteractionApi = api.ApiGroupApp.MedicineApiGroup.MtDrugInteractionApi
+	mtHotSearchBlockApi  = api.ApiGroupApp.MedicineApiGroup.MtHotSearchBlockApi
+	mtSymptomApi         = api.ApiGroupApp.MedicineApiGroup.MtSymptomApi
 )
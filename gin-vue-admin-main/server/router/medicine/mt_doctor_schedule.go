@@ -0,0 +1,27 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MtDoctorScheduleRouter struct{}
+
+// InitMtDoctorScheduleRouter 初始化 医生排班 路由信息
+func (s *MtDoctorScheduleRouter) InitMtDoctorScheduleRouter(Router *gin.RouterGroup, PublicRouter *gin.RouterGroup) {
+	mtDoctorScheduleRouter := Router.Group("mtDoctorSchedule").Use(middleware.OperationRecord())
+	mtDoctorScheduleRouterWithoutRecord := Router.Group("mtDoctorSchedule")
+	{
+		mtDoctorScheduleRouter.POST("createMtDoctorSchedule", mtDoctorScheduleApi.CreateMtDoctorSchedule)         // 创建排班模板
+		mtDoctorScheduleRouter.PUT("updateMtDoctorSchedule", mtDoctorScheduleApi.UpdateMtDoctorSchedule)          // 更新排班模板
+		mtDoctorScheduleRouter.DELETE("deleteMtDoctorSchedule", mtDoctorScheduleApi.DeleteMtDoctorSchedule)       // 删除排班模板
+		mtDoctorScheduleRouter.POST("createMtScheduleException", mtDoctorScheduleApi.CreateMtScheduleException)   // 创建停诊或加诊
+		mtDoctorScheduleRouter.DELETE("deleteMtScheduleException", mtDoctorScheduleApi.DeleteMtScheduleException) // 删除停诊或加诊
+	}
+	{
+		mtDoctorScheduleRouterWithoutRecord.GET("getMtDoctorScheduleList", mtDoctorScheduleApi.GetMtDoctorScheduleList)       // 获取排班模板列表
+		mtDoctorScheduleRouterWithoutRecord.GET("getMtScheduleExceptionList", mtDoctorScheduleApi.GetMtScheduleExceptionList) // 获取停诊和加诊列表
+		mtDoctorScheduleRouterWithoutRecord.GET("getMtScheduleSlotList", mtDoctorScheduleApi.GetMtScheduleSlotList)           // 获取号源列表
+		mtDoctorScheduleRouterWithoutRecord.GET("getMtAppointmentList", mtDoctorScheduleApi.GetMtAppointmentList)             // 获取预约列表
+	}
+}
@@ -17,6 +17,7 @@ func (s *MtDoctorsRouter) InitMtDoctorsRouter(Router *gin.RouterGroup,PublicRout
 		mtDoctorsRouter.DELETE("deleteMtDoctors", mtDoctorsApi.DeleteMtDoctors) // 删除mtDoctors表
 		mtDoctorsRouter.DELETE("deleteMtDoctorsByIds", mtDoctorsApi.DeleteMtDoctorsByIds) // 批量删除mtDoctors表
 		mtDoctorsRouter.PUT("updateMtDoctors", mtDoctorsApi.UpdateMtDoctors)    // 更新mtDoctors表
+		mtDoctorsRouter.PUT("resetMtDoctorPassword", mtDoctorsApi.ResetMtDoctorPassword) // 重置医生登录密码
 	}
 	{
 		mtDoctorsRouterWithoutRecord.GET("findMtDoctors", mtDoctorsApi.FindMtDoctors)        // 根据ID获取mtDoctors表
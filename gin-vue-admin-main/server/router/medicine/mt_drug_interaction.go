@@ -0,0 +1,24 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MtDrugInteractionRouter struct{}
+
+// InitMtDrugInteractionRouter 初始化 用药知识库 路由信息
+func (s *MtDrugInteractionRouter) InitMtDrugInteractionRouter(Router *gin.RouterGroup, PublicRouter *gin.RouterGroup) {
+	mtDrugInteractionRouter := Router.Group("mtDrugInteraction").Use(middleware.OperationRecord())
+	mtDrugInteractionRouterWithoutRecord := Router.Group("mtDrugInteraction")
+	{
+		mtDrugInteractionRouter.POST("importMtDrugInteraction", mtDrugInteractionApi.ImportMtDrugInteraction)             // 导入药物相互作用
+		mtDrugInteractionRouter.POST("importMtDrugContraindication", mtDrugInteractionApi.ImportMtDrugContraindication)   // 导入人群禁忌
+		mtDrugInteractionRouter.DELETE("deleteMtDrugInteraction", mtDrugInteractionApi.DeleteMtDrugInteraction)           // 删除药物相互作用
+		mtDrugInteractionRouter.DELETE("deleteMtDrugContraindication", mtDrugInteractionApi.DeleteMtDrugContraindication) // 删除人群禁忌
+	}
+	{
+		mtDrugInteractionRouterWithoutRecord.GET("getMtDrugInteractionList", mtDrugInteractionApi.GetMtDrugInteractionList)           // 获取药物相互作用列表
+		mtDrugInteractionRouterWithoutRecord.GET("getMtDrugContraindicationList", mtDrugInteractionApi.GetMtDrugContraindicationList) // 获取人群禁忌列表
+	}
+}
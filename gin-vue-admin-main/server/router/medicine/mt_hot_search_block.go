@@ -0,0 +1,24 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MtHotSearchBlockRouter struct{}
+
+// InitMtHotSearchBlockRouter 初始化 热搜屏蔽词 路由信息
+func (s *MtHotSearchBlockRouter) InitMtHotSearchBlockRouter(Router *gin.RouterGroup, PublicRouter *gin.RouterGroup) {
+	mtHotSearchBlockRouter := Router.Group("mtHotSearchBlock").Use(middleware.OperationRecord())
+	mtHotSearchBlockRouterWithoutRecord := Router.Group("mtHotSearchBlock")
+	{
+		mtHotSearchBlockRouter.POST("createMtHotSearchBlock", mtHotSearchBlockApi.CreateMtHotSearchBlock)             // 新建热搜屏蔽词
+		mtHotSearchBlockRouter.DELETE("deleteMtHotSearchBlock", mtHotSearchBlockApi.DeleteMtHotSearchBlock)           // 删除热搜屏蔽词
+		mtHotSearchBlockRouter.DELETE("deleteMtHotSearchBlockByIds", mtHotSearchBlockApi.DeleteMtHotSearchBlockByIds) // 批量删除热搜屏蔽词
+		mtHotSearchBlockRouter.PUT("updateMtHotSearchBlock", mtHotSearchBlockApi.UpdateMtHotSearchBlock)              // 更新热搜屏蔽词
+	}
+	{
+		mtHotSearchBlockRouterWithoutRecord.GET("findMtHotSearchBlock", mtHotSearchBlockApi.FindMtHotSearchBlock)       // 根据ID获取热搜屏蔽词
+		mtHotSearchBlockRouterWithoutRecord.GET("getMtHotSearchBlockList", mtHotSearchBlockApi.GetMtHotSearchBlockList) // 获取热搜屏蔽词列表
+	}
+}
@@ -0,0 +1,22 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MtInventoryAlertRouter struct{}
+
+// InitMtInventoryAlertRouter 初始化 库存告警 路由信息
+func (s *MtInventoryAlertRouter) InitMtInventoryAlertRouter(Router *gin.RouterGroup, PublicRouter *gin.RouterGroup) {
+	mtInventoryAlertRouter := Router.Group("mtInventoryAlert").Use(middleware.OperationRecord())
+	mtInventoryAlertRouterWithoutRecord := Router.Group("mtInventoryAlert")
+	{
+		mtInventoryAlertRouter.PUT("ackMtInventoryAlert", mtInventoryAlertApi.AckMtInventoryAlert) // 确认库存告警
+	}
+	{
+		mtInventoryAlertRouterWithoutRecord.GET("findMtInventoryAlert", mtInventoryAlertApi.FindMtInventoryAlert)                 // 根据ID获取库存告警
+		mtInventoryAlertRouterWithoutRecord.GET("getMtInventoryAlertList", mtInventoryAlertApi.GetMtInventoryAlertList)           // 获取库存告警列表
+		mtInventoryAlertRouterWithoutRecord.GET("getOpenMtInventoryAlertCount", mtInventoryAlertApi.GetOpenMtInventoryAlertCount) // 获取待处理告警数量
+	}
+}
@@ -0,0 +1,23 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MtRefundRecordRouter struct{}
+
+// InitMtRefundRecordRouter 初始化 退款审核 路由信息
+func (s *MtRefundRecordRouter) InitMtRefundRecordRouter(Router *gin.RouterGroup, PublicRouter *gin.RouterGroup) {
+	mtRefundRecordRouter := Router.Group("mtRefundRecord").Use(middleware.OperationRecord())
+	mtRefundRecordRouterWithoutRecord := Router.Group("mtRefundRecord")
+	{
+		mtRefundRecordRouter.PUT("approveMtRefundRecord", mtRefundRecordApi.ApproveMtRefundRecord) // 审核通过退款
+		mtRefundRecordRouter.PUT("rejectMtRefundRecord", mtRefundRecordApi.RejectMtRefundRecord)   // 驳回退款
+	}
+	{
+		mtRefundRecordRouterWithoutRecord.GET("findMtRefundRecord", mtRefundRecordApi.FindMtRefundRecord)                       // 根据退款单号获取退款单
+		mtRefundRecordRouterWithoutRecord.GET("getMtRefundRecordList", mtRefundRecordApi.GetMtRefundRecordList)                 // 获取退款单列表
+		mtRefundRecordRouterWithoutRecord.GET("getPendingMtRefundRecordCount", mtRefundRecordApi.GetPendingMtRefundRecordCount) // 获取待审核退款单数量
+	}
+}
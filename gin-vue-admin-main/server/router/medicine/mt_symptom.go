@@ -0,0 +1,24 @@
+package medicine
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MtSymptomRouter struct{}
+
+// InitMtSymptomRouter 初始化 症状分类 路由信息
+func (s *MtSymptomRouter) InitMtSymptomRouter(Router *gin.RouterGroup, PublicRouter *gin.RouterGroup) {
+	mtSymptomRouter := Router.Group("mtSymptom").Use(middleware.OperationRecord())
+	mtSymptomRouterWithoutRecord := Router.Group("mtSymptom")
+	{
+		mtSymptomRouter.POST("createMtSymptom", mtSymptomApi.CreateMtSymptom)             // 新建症状分类
+		mtSymptomRouter.DELETE("deleteMtSymptom", mtSymptomApi.DeleteMtSymptom)           // 删除症状分类
+		mtSymptomRouter.DELETE("deleteMtSymptomByIds", mtSymptomApi.DeleteMtSymptomByIds) // 批量删除症状分类
+		mtSymptomRouter.PUT("updateMtSymptom", mtSymptomApi.UpdateMtSymptom)              // 更新症状分类
+	}
+	{
+		mtSymptomRouterWithoutRecord.GET("findMtSymptom", mtSymptomApi.FindMtSymptom)       // 根据ID获取症状分类
+		mtSymptomRouterWithoutRecord.GET("getMtSymptomList", mtSymptomApi.GetMtSymptomList) // 获取症状分类列表
+	}
+}
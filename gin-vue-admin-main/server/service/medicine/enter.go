@@ -16,4 +16,10 @@ type ServiceGroup struct {
 	MtOrdersService
 	MtChatMessageService
 	MtDiscountService
+	MtInventoryAlertService
+	MtRefundRecordService
+	MtDoctorScheduleService
+	MtDrugInteractionService
+	MtHotSearchBlockService
+	MtSymptomService
 }
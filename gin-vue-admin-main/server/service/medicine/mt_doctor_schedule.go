@@ -0,0 +1,295 @@
+package medicine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"gorm.io/gorm"
+)
+
+const (
+	scheduleDateLayout  = "2006-01-02"
+	scheduleClockLayout = "15:04"
+)
+
+type MtDoctorScheduleService struct{}
+
+// 校验出诊时间段，时间格式为15:04
+func checkScheduleTime(startTime, endTime string) error {
+	start, err := time.Parse(scheduleClockLayout, startTime)
+	if err != nil {
+		return fmt.Errorf("开始时间格式错误: %s", startTime)
+	}
+	end, err := time.Parse(scheduleClockLayout, endTime)
+	if err != nil {
+		return fmt.Errorf("结束时间格式错误: %s", endTime)
+	}
+	if !start.Before(end) {
+		return errors.New("开始时间必须早于结束时间")
+	}
+	return nil
+}
+
+// 删除医生今天及以后未被预约的时段，C端下次查询号源时按新的排班重新生成；已有预约的时段保留
+func clearUnbookedSlots(tx *gorm.DB, doctorId int, slotDate string, dateOnly bool) error {
+	db := tx.Where("doctor_id = ? AND booked = 0", doctorId)
+	if dateOnly {
+		db = db.Where("slot_date = ?", slotDate)
+	} else {
+		db = db.Where("slot_date >= ?", slotDate)
+	}
+	return db.Delete(&medicine.MtScheduleSlot{}).Error
+}
+
+// CreateMtDoctorSchedule 创建排班模板
+func (mtDoctorScheduleService *MtDoctorScheduleService) CreateMtDoctorSchedule(ctx context.Context, schedule *medicine.MtDoctorSchedule) error {
+	if err := checkScheduleTime(schedule.StartTime, schedule.EndTime); err != nil {
+		return err
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(schedule).Error; err != nil {
+			return err
+		}
+		return clearUnbookedSlots(tx, schedule.DoctorId, time.Now().Format(scheduleDateLayout), false)
+	})
+}
+
+// UpdateMtDoctorSchedule 更新排班模板，原医生和新医生未被预约的时段都重新生成
+func (mtDoctorScheduleService *MtDoctorScheduleService) UpdateMtDoctorSchedule(ctx context.Context, schedule medicine.MtDoctorSchedule) error {
+	if err := checkScheduleTime(schedule.StartTime, schedule.EndTime); err != nil {
+		return err
+	}
+	now := time.Now()
+	schedule.UpdatedAt = &now
+	today := now.Format(scheduleDateLayout)
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var old medicine.MtDoctorSchedule
+		if err := tx.Where("id = ?", schedule.ID).First(&old).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&medicine.MtDoctorSchedule{}).Where("id = ?", schedule.ID).
+			Select("doctor_id", "weekday", "start_time", "end_time", "slot_minutes", "capacity", "status", "updated_at").
+			Updates(&schedule).Error
+		if err != nil {
+			return err
+		}
+		if err := clearUnbookedSlots(tx, old.DoctorId, today, false); err != nil {
+			return err
+		}
+		if old.DoctorId == schedule.DoctorId {
+			return nil
+		}
+		return clearUnbookedSlots(tx, schedule.DoctorId, today, false)
+	})
+}
+
+// DeleteMtDoctorSchedule 删除排班模板
+func (mtDoctorScheduleService *MtDoctorScheduleService) DeleteMtDoctorSchedule(ctx context.Context, ID string) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var schedule medicine.MtDoctorSchedule
+		if err := tx.Where("id = ?", ID).First(&schedule).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&schedule).Error; err != nil {
+			return err
+		}
+		return clearUnbookedSlots(tx, schedule.DoctorId, time.Now().Format(scheduleDateLayout), false)
+	})
+}
+
+// GetMtDoctorScheduleInfoList 分页获取排班模板，按医生、星期和开始时间排序
+func (mtDoctorScheduleService *MtDoctorScheduleService) GetMtDoctorScheduleInfoList(ctx context.Context, info medicineReq.MtDoctorScheduleSearch) (list []medicine.MtDoctorSchedule, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtDoctorSchedule{})
+	if info.DoctorId != nil {
+		db = db.Where("doctor_id = ?", *info.DoctorId)
+	}
+	if info.Weekday != nil {
+		db = db.Where("weekday = ?", *info.Weekday)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+
+	err = db.Order("doctor_id ASC, weekday ASC, start_time ASC").Find(&list).Error
+	return list, total, err
+}
+
+// CreateMtScheduleException 创建停诊或加诊
+// 停诊时删除时段内未被预约的号源，已有预约的号源停止预约；加诊号源由C端查询时生成
+func (mtDoctorScheduleService *MtDoctorScheduleService) CreateMtScheduleException(ctx context.Context, exception *medicine.MtDoctorScheduleException) error {
+	date, err := time.ParseInLocation(scheduleDateLayout, exception.ExceptionDate, time.Local)
+	if err != nil {
+		return fmt.Errorf("日期格式错误: %s", exception.ExceptionDate)
+	}
+	today, _ := time.ParseInLocation(scheduleDateLayout, time.Now().Format(scheduleDateLayout), time.Local)
+	if date.Before(today) {
+		return errors.New("不能设置今天之前的排班")
+	}
+	switch exception.Type {
+	case medicine.ScheduleExceptionOff:
+		if exception.StartTime != "" || exception.EndTime != "" {
+			if err := checkScheduleTime(exception.StartTime, exception.EndTime); err != nil {
+				return err
+			}
+		}
+	case medicine.ScheduleExceptionExtra:
+		if err := checkScheduleTime(exception.StartTime, exception.EndTime); err != nil {
+			return err
+		}
+		if exception.SlotMinutes < 5 || exception.Capacity < 1 {
+			return errors.New("加诊须填写时段分钟数和号源数")
+		}
+	default:
+		return fmt.Errorf("不支持的排班例外类型: %s", exception.Type)
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(exception).Error; err != nil {
+			return err
+		}
+		if exception.Type != medicine.ScheduleExceptionOff {
+			return nil
+		}
+		db := tx.Model(&medicine.MtScheduleSlot{}).Where("doctor_id = ? AND slot_date = ?", exception.DoctorId, exception.ExceptionDate)
+		if exception.StartTime != "" {
+			db = db.Where("start_time < ? AND end_time > ?", exception.EndTime, exception.StartTime)
+		}
+		db = db.Session(&gorm.Session{})
+		if err := db.Where("booked = 0").Delete(&medicine.MtScheduleSlot{}).Error; err != nil {
+			return err
+		}
+		return db.Updates(map[string]interface{}{"status": medicine.ScheduleSlotClosed, "updated_at": time.Now()}).Error
+	})
+}
+
+// DeleteMtScheduleException 删除停诊或加诊，当天未被预约的号源按排班重新生成；不再停诊的号源恢复预约
+func (mtDoctorScheduleService *MtDoctorScheduleService) DeleteMtScheduleException(ctx context.Context, ID string) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var exception medicine.MtDoctorScheduleException
+		if err := tx.Where("id = ?", ID).First(&exception).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&exception).Error; err != nil {
+			return err
+		}
+		if err := clearUnbookedSlots(tx, exception.DoctorId, exception.ExceptionDate, true); err != nil {
+			return err
+		}
+
+		var offs []medicine.MtDoctorScheduleException
+		err := tx.Where("doctor_id = ? AND exception_date = ? AND type = ?", exception.DoctorId, exception.ExceptionDate, medicine.ScheduleExceptionOff).
+			Find(&offs).Error
+		if err != nil {
+			return err
+		}
+		db := tx.Model(&medicine.MtScheduleSlot{}).
+			Where("doctor_id = ? AND slot_date = ? AND status = ?", exception.DoctorId, exception.ExceptionDate, medicine.ScheduleSlotClosed)
+		for _, off := range offs {
+			if off.StartTime == "" {
+				return nil
+			}
+			db = db.Where("NOT (start_time < ? AND end_time > ?)", off.EndTime, off.StartTime)
+		}
+		return db.Updates(map[string]interface{}{"status": medicine.ScheduleSlotOpen, "updated_at": time.Now()}).Error
+	})
+}
+
+// GetMtScheduleExceptionInfoList 分页获取停诊和加诊，按日期倒序
+func (mtDoctorScheduleService *MtDoctorScheduleService) GetMtScheduleExceptionInfoList(ctx context.Context, info medicineReq.MtScheduleExceptionSearch) (list []medicine.MtDoctorScheduleException, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtDoctorScheduleException{})
+	if info.DoctorId != nil {
+		db = db.Where("doctor_id = ?", *info.DoctorId)
+	}
+	if info.StartDate != nil && *info.StartDate != "" {
+		db = db.Where("exception_date >= ?", *info.StartDate)
+	}
+	if info.EndDate != nil && *info.EndDate != "" {
+		db = db.Where("exception_date <= ?", *info.EndDate)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+
+	err = db.Order("exception_date DESC, id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// GetMtScheduleSlotInfoList 分页获取已生成的号源，按日期和开始时间排序
+func (mtDoctorScheduleService *MtDoctorScheduleService) GetMtScheduleSlotInfoList(ctx context.Context, info medicineReq.MtScheduleSlotSearch) (list []medicine.MtScheduleSlot, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtScheduleSlot{})
+	if info.DoctorId != nil {
+		db = db.Where("doctor_id = ?", *info.DoctorId)
+	}
+	if info.SlotDate != nil && *info.SlotDate != "" {
+		db = db.Where("slot_date = ?", *info.SlotDate)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+
+	err = db.Order("slot_date ASC, start_time ASC, doctor_id ASC").Find(&list).Error
+	return list, total, err
+}
+
+// GetMtAppointmentInfoList 分页获取预约，按就诊时间倒序
+func (mtDoctorScheduleService *MtDoctorScheduleService) GetMtAppointmentInfoList(ctx context.Context, info medicineReq.MtAppointmentSearch) (list []medicine.MtAppointment, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtAppointment{})
+	if info.AppointmentNo != nil && *info.AppointmentNo != "" {
+		db = db.Where("appointment_no = ?", *info.AppointmentNo)
+	}
+	if info.DoctorId != nil {
+		db = db.Where("doctor_id = ?", *info.DoctorId)
+	}
+	if info.PatientId != nil {
+		db = db.Where("patient_id = ?", *info.PatientId)
+	}
+	if info.SlotDate != nil && *info.SlotDate != "" {
+		db = db.Where("slot_date = ?", *info.SlotDate)
+	}
+	if info.Status != nil && *info.Status != "" {
+		db = db.Where("status = ?", *info.Status)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+
+	err = db.Order("slot_date DESC, start_time DESC, id DESC").Find(&list).Error
+	return list, total, err
+}
@@ -2,9 +2,12 @@ package medicine
 
 import (
 	"context"
+	"errors"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
 	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"gorm.io/gorm"
 )
 
@@ -54,6 +57,27 @@ func (mtDoctorsService *MtDoctorsService) UpdateMtDoctors(ctx context.Context, m
 	return err
 }
 
+// ResetMtDoctorPassword 重置医生登录密码
+// 与C端医生登录一致使用bcrypt，盐值包含在哈希中，salt 列清空
+func (mtDoctorsService *MtDoctorsService) ResetMtDoctorPassword(ctx context.Context, reset medicineReq.MtDoctorPasswordReset, updatedBy uint) error {
+	hash := utils.BcryptHash(reset.Password)
+	if hash == "" {
+		return errors.New("生成密码哈希失败")
+	}
+	result := global.GVA_DB.Model(&medicine.MtDoctors{}).Where("id = ?", reset.ID).Updates(map[string]interface{}{
+		"password_hash": hash,
+		"salt":          "",
+		"updated_by":    updatedBy,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // GetMtDoctors 根据ID获取mtDoctors表记录
 // Author [yourname](https://github.com/yourname)
 func (mtDoctorsService *MtDoctorsService) GetMtDoctors(ctx context.Context, ID string) (mtDoctors medicine.MtDoctors, err error) {
@@ -2,6 +2,9 @@ package medicine
 
 import (
 	"context"
+	"strconv"
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
 	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
@@ -13,7 +16,12 @@ type MtDrugService struct{}
 // CreateMtDrug 创建mtDrug表记录
 // Author [yourname](https://github.com/yourname)
 func (mtDrugService *MtDrugService) CreateMtDrug(ctx context.Context, mtDrug *medicine.MtDrug) (err error) {
-	err = global.GVA_DB.Create(mtDrug).Error
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(mtDrug).Error; err != nil {
+			return err
+		}
+		return enqueueDrugIndexTask(tx, mtDrug.ID)
+	})
 	return err
 }
 
@@ -27,7 +35,11 @@ func (mtDrugService *MtDrugService) DeleteMtDrug(ctx context.Context, ID string,
 		if err = tx.Delete(&medicine.MtDrug{}, "id = ?", ID).Error; err != nil {
 			return err
 		}
-		return nil
+		id, err := strconv.ParseUint(ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		return enqueueDrugIndexTask(tx, uint(id))
 	})
 	return err
 }
@@ -42,7 +54,15 @@ func (mtDrugService *MtDrugService) DeleteMtDrugByIds(ctx context.Context, IDs [
 		if err := tx.Where("id in ?", IDs).Delete(&medicine.MtDrug{}).Error; err != nil {
 			return err
 		}
-		return nil
+		drugIDs := make([]uint, 0, len(IDs))
+		for _, ID := range IDs {
+			id, err := strconv.ParseUint(ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			drugIDs = append(drugIDs, uint(id))
+		}
+		return enqueueDrugIndexTask(tx, drugIDs...)
 	})
 	return err
 }
@@ -50,10 +70,28 @@ func (mtDrugService *MtDrugService) DeleteMtDrugByIds(ctx context.Context, IDs [
 // UpdateMtDrug 更新mtDrug表记录
 // Author [yourname](https://github.com/yourname)
 func (mtDrugService *MtDrugService) UpdateMtDrug(ctx context.Context, mtDrug medicine.MtDrug) (err error) {
-	err = global.GVA_DB.Model(&medicine.MtDrug{}).Where("id = ?", mtDrug.ID).Updates(&mtDrug).Error
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&medicine.MtDrug{}).Where("id = ?", mtDrug.ID).Updates(&mtDrug).Error; err != nil {
+			return err
+		}
+		return enqueueDrugIndexTask(tx, mtDrug.ID)
+	})
 	return err
 }
 
+// enqueueDrugIndexTask 在药品变更的事务内登记搜索索引同步任务，C端服务据此增量更新Elasticsearch
+func enqueueDrugIndexTask(tx *gorm.DB, drugIDs ...uint) error {
+	if len(drugIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	tasks := make([]medicine.MtDrugIndexTask, len(drugIDs))
+	for i, id := range drugIDs {
+		tasks[i] = medicine.MtDrugIndexTask{DrugId: id, CreatedAt: now}
+	}
+	return tx.Create(&tasks).Error
+}
+
 // GetMtDrug 根据ID获取mtDrug表记录
 // Author [yourname](https://github.com/yourname)
 func (mtDrugService *MtDrugService) GetMtDrug(ctx context.Context, ID string) (mtDrug medicine.MtDrug, err error) {
@@ -0,0 +1,321 @@
+package medicine
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MtDrugInteractionService struct{}
+
+// CSV中可填写编码或中文名称
+var (
+	interactionSeverityNames = map[string]string{
+		medicine.InteractionSeverityMinor:    medicine.InteractionSeverityMinor,
+		"轻微":                                 medicine.InteractionSeverityMinor,
+		medicine.InteractionSeverityModerate: medicine.InteractionSeverityModerate,
+		"中度":                                 medicine.InteractionSeverityModerate,
+		medicine.InteractionSeverityMajor:    medicine.InteractionSeverityMajor,
+		"严重":                                 medicine.InteractionSeverityMajor,
+	}
+	populationNames = map[string]string{
+		medicine.PopulationPregnancy: medicine.PopulationPregnancy,
+		"孕妇":                         medicine.PopulationPregnancy,
+		medicine.PopulationLactation: medicine.PopulationLactation,
+		"哺乳期":                        medicine.PopulationLactation,
+		medicine.PopulationChild:     medicine.PopulationChild,
+		"儿童":                         medicine.PopulationChild,
+		medicine.PopulationElderly:   medicine.PopulationElderly,
+		"老人":                         medicine.PopulationElderly,
+		"老年人":                        medicine.PopulationElderly,
+	}
+	contraindicationLevelNames = map[string]string{
+		medicine.ContraindicationCaution:   medicine.ContraindicationCaution,
+		"慎用":                               medicine.ContraindicationCaution,
+		medicine.ContraindicationForbidden: medicine.ContraindicationForbidden,
+		"禁用":                               medicine.ContraindicationForbidden,
+	}
+)
+
+// 读取CSV全部数据行，跳过表头，去掉Excel导出的BOM
+func readKnowledgeCsv(r io.Reader, minFields int) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV格式错误: %v", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("CSV没有数据行")
+	}
+	rows := records[1:]
+	for i, row := range rows {
+		if len(row) < minFields {
+			return nil, fmt.Errorf("第%d行: 至少需要%d列", i+2, minFields)
+		}
+		for j := range row {
+			row[j] = strings.TrimSpace(strings.TrimPrefix(row[j], "\ufeff"))
+		}
+	}
+	return rows, nil
+}
+
+// 按药品ID或药品名称查找药品，名称须唯一
+type drugResolver struct {
+	tx    *gorm.DB
+	cache map[string]int64
+}
+
+func (r *drugResolver) resolve(value string) (int64, error) {
+	if value == "" {
+		return 0, errors.New("药品不能为空")
+	}
+	if id, ok := r.cache[value]; ok {
+		return id, nil
+	}
+	var ids []int64
+	db := r.tx.Model(&medicine.MtDrug{})
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		db = db.Where("id = ?", id)
+	} else {
+		db = db.Where("drug_name = ?", value)
+	}
+	if err := db.Limit(2).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("药品不存在: %s", value)
+	}
+	if len(ids) > 1 {
+		return 0, fmt.Errorf("药品名称重复，请填写药品ID: %s", value)
+	}
+	r.cache[value] = ids[0]
+	return ids[0], nil
+}
+
+// 查询药品名称
+func drugNames(ids []int64) map[int64]string {
+	names := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return names
+	}
+	var drugs []medicine.MtDrug
+	global.GVA_DB.Select("id", "drug_name").Where("id IN ?", ids).Find(&drugs)
+	for _, drug := range drugs {
+		if drug.DrugName != nil {
+			names[int64(drug.ID)] = *drug.DrugName
+		}
+	}
+	return names
+}
+
+// ImportMtDrugInteractions 从CSV导入药物相互作用，列为：药品A,药品B,严重程度,说明,建议
+// 药品可填写ID或名称，已存在的药品对按本次导入覆盖；任一行有误时整批不导入
+func (mtDrugInteractionService *MtDrugInteractionService) ImportMtDrugInteractions(ctx context.Context, r io.Reader) (int, error) {
+	rows, err := readKnowledgeCsv(r, 3)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		resolver := &drugResolver{tx: tx, cache: make(map[string]int64)}
+		records := make(map[[2]int64]*medicine.MtDrugInteraction, len(rows))
+		var order [][2]int64
+		now := time.Now()
+		for i, row := range rows {
+			line := i + 2
+			drugA, err := resolver.resolve(row[0])
+			if err != nil {
+				return fmt.Errorf("第%d行: %v", line, err)
+			}
+			drugB, err := resolver.resolve(row[1])
+			if err != nil {
+				return fmt.Errorf("第%d行: %v", line, err)
+			}
+			if drugA == drugB {
+				return fmt.Errorf("第%d行: 药品A和药品B不能相同", line)
+			}
+			if drugA > drugB {
+				drugA, drugB = drugB, drugA
+			}
+			severity, ok := interactionSeverityNames[strings.ToLower(row[2])]
+			if !ok {
+				return fmt.Errorf("第%d行: 不支持的严重程度: %s", line, row[2])
+			}
+			record := &medicine.MtDrugInteraction{DrugAId: drugA, DrugBId: drugB, Severity: severity, CreatedAt: now, UpdatedAt: &now}
+			if len(row) > 3 {
+				record.Description = row[3]
+			}
+			if len(row) > 4 {
+				record.Advice = row[4]
+			}
+			key := [2]int64{drugA, drugB}
+			if _, ok := records[key]; !ok {
+				order = append(order, key)
+			}
+			records[key] = record
+		}
+
+		list := make([]*medicine.MtDrugInteraction, 0, len(order))
+		for _, key := range order {
+			list = append(list, records[key])
+		}
+		count = len(list)
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "drug_a_id"}, {Name: "drug_b_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"severity", "description", "advice", "updated_at"}),
+		}).CreateInBatches(list, 100).Error
+	})
+	return count, err
+}
+
+// ImportMtDrugContraindications 从CSV导入人群禁忌，列为：药品,人群,级别,说明
+// 药品可填写ID或名称，已存在的药品和人群按本次导入覆盖；任一行有误时整批不导入
+func (mtDrugInteractionService *MtDrugInteractionService) ImportMtDrugContraindications(ctx context.Context, r io.Reader) (int, error) {
+	rows, err := readKnowledgeCsv(r, 3)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		resolver := &drugResolver{tx: tx, cache: make(map[string]int64)}
+		type key struct {
+			drugID     int64
+			population string
+		}
+		records := make(map[key]*medicine.MtDrugContraindication, len(rows))
+		var order []key
+		now := time.Now()
+		for i, row := range rows {
+			line := i + 2
+			drugID, err := resolver.resolve(row[0])
+			if err != nil {
+				return fmt.Errorf("第%d行: %v", line, err)
+			}
+			population, ok := populationNames[strings.ToLower(row[1])]
+			if !ok {
+				return fmt.Errorf("第%d行: 不支持的人群: %s", line, row[1])
+			}
+			level, ok := contraindicationLevelNames[strings.ToLower(row[2])]
+			if !ok {
+				return fmt.Errorf("第%d行: 不支持的禁忌级别: %s", line, row[2])
+			}
+			record := &medicine.MtDrugContraindication{DrugId: drugID, Population: population, Level: level, CreatedAt: now, UpdatedAt: &now}
+			if len(row) > 3 {
+				record.Description = row[3]
+			}
+			k := key{drugID, population}
+			if _, ok := records[k]; !ok {
+				order = append(order, k)
+			}
+			records[k] = record
+		}
+
+		list := make([]*medicine.MtDrugContraindication, 0, len(order))
+		for _, k := range order {
+			list = append(list, records[k])
+		}
+		count = len(list)
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "drug_id"}, {Name: "population"}},
+			DoUpdates: clause.AssignmentColumns([]string{"level", "description", "updated_at"}),
+		}).CreateInBatches(list, 100).Error
+	})
+	return count, err
+}
+
+// DeleteMtDrugInteraction 删除药物相互作用
+func (mtDrugInteractionService *MtDrugInteractionService) DeleteMtDrugInteraction(ctx context.Context, ID string) error {
+	return global.GVA_DB.Where("id = ?", ID).Delete(&medicine.MtDrugInteraction{}).Error
+}
+
+// DeleteMtDrugContraindication 删除人群禁忌
+func (mtDrugInteractionService *MtDrugInteractionService) DeleteMtDrugContraindication(ctx context.Context, ID string) error {
+	return global.GVA_DB.Where("id = ?", ID).Delete(&medicine.MtDrugContraindication{}).Error
+}
+
+// GetMtDrugInteractionInfoList 分页获取药物相互作用，按药品查询时匹配药品对中的任意一方
+func (mtDrugInteractionService *MtDrugInteractionService) GetMtDrugInteractionInfoList(ctx context.Context, info medicineReq.MtDrugInteractionSearch) (list []medicine.MtDrugInteraction, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtDrugInteraction{})
+	if info.DrugId != nil {
+		db = db.Where("drug_a_id = ? OR drug_b_id = ?", *info.DrugId, *info.DrugId)
+	}
+	if info.Severity != nil && *info.Severity != "" {
+		db = db.Where("severity = ?", *info.Severity)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("drug_a_id, drug_b_id").Find(&list).Error
+	if err != nil {
+		return
+	}
+
+	ids := make([]int64, 0, len(list)*2)
+	for _, item := range list {
+		ids = append(ids, item.DrugAId, item.DrugBId)
+	}
+	names := drugNames(ids)
+	for i := range list {
+		list[i].DrugAName = names[list[i].DrugAId]
+		list[i].DrugBName = names[list[i].DrugBId]
+	}
+	return list, total, nil
+}
+
+// GetMtDrugContraindicationInfoList 分页获取人群禁忌
+func (mtDrugInteractionService *MtDrugInteractionService) GetMtDrugContraindicationInfoList(ctx context.Context, info medicineReq.MtDrugContraindicationSearch) (list []medicine.MtDrugContraindication, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtDrugContraindication{})
+	if info.DrugId != nil {
+		db = db.Where("drug_id = ?", *info.DrugId)
+	}
+	if info.Population != nil && *info.Population != "" {
+		db = db.Where("population = ?", *info.Population)
+	}
+	if info.Level != nil && *info.Level != "" {
+		db = db.Where("level = ?", *info.Level)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("drug_id, population").Find(&list).Error
+	if err != nil {
+		return
+	}
+
+	ids := make([]int64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.DrugId)
+	}
+	names := drugNames(ids)
+	for i := range list {
+		list[i].DrugName = names[list[i].DrugId]
+	}
+	return list, total, nil
+}
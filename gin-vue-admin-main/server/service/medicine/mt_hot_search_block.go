@@ -0,0 +1,100 @@
+package medicine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+)
+
+type MtHotSearchBlockService struct{}
+
+// C端热搜词已合并空白并转小写，屏蔽词按同样规则保存
+func normalizeHotSearchBlockTerm(mtHotSearchBlock *medicine.MtHotSearchBlock) error {
+	mtHotSearchBlock.Term = strings.ToLower(strings.Join(strings.Fields(mtHotSearchBlock.Term), " "))
+	if mtHotSearchBlock.Term == "" {
+		return errors.New("屏蔽词不能为空")
+	}
+	return nil
+}
+
+// 屏蔽词不能重复
+func checkHotSearchBlockTerm(term string, excludeID uint) error {
+	var count int64
+	if err := global.GVA_DB.Model(&medicine.MtHotSearchBlock{}).Where("term = ? AND id <> ?", term, excludeID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("屏蔽词已存在: " + term)
+	}
+	return nil
+}
+
+// CreateMtHotSearchBlock 创建热搜屏蔽词
+func (mtHotSearchBlockService *MtHotSearchBlockService) CreateMtHotSearchBlock(ctx context.Context, mtHotSearchBlock *medicine.MtHotSearchBlock) (err error) {
+	if err = normalizeHotSearchBlockTerm(mtHotSearchBlock); err != nil {
+		return err
+	}
+	if err = checkHotSearchBlockTerm(mtHotSearchBlock.Term, 0); err != nil {
+		return err
+	}
+	now := time.Now()
+	mtHotSearchBlock.CreatedAt = now
+	mtHotSearchBlock.UpdatedAt = &now
+	return global.GVA_DB.Create(mtHotSearchBlock).Error
+}
+
+// DeleteMtHotSearchBlock 删除热搜屏蔽词
+func (mtHotSearchBlockService *MtHotSearchBlockService) DeleteMtHotSearchBlock(ctx context.Context, ID string) error {
+	return global.GVA_DB.Where("id = ?", ID).Delete(&medicine.MtHotSearchBlock{}).Error
+}
+
+// DeleteMtHotSearchBlockByIds 批量删除热搜屏蔽词
+func (mtHotSearchBlockService *MtHotSearchBlockService) DeleteMtHotSearchBlockByIds(ctx context.Context, IDs []string) error {
+	return global.GVA_DB.Where("id in ?", IDs).Delete(&medicine.MtHotSearchBlock{}).Error
+}
+
+// UpdateMtHotSearchBlock 更新热搜屏蔽词
+func (mtHotSearchBlockService *MtHotSearchBlockService) UpdateMtHotSearchBlock(ctx context.Context, mtHotSearchBlock medicine.MtHotSearchBlock) (err error) {
+	if err = normalizeHotSearchBlockTerm(&mtHotSearchBlock); err != nil {
+		return err
+	}
+	if err = checkHotSearchBlockTerm(mtHotSearchBlock.Term, mtHotSearchBlock.ID); err != nil {
+		return err
+	}
+	return global.GVA_DB.Model(&medicine.MtHotSearchBlock{}).Where("id = ?", mtHotSearchBlock.ID).Updates(map[string]interface{}{
+		"term":       mtHotSearchBlock.Term,
+		"remark":     mtHotSearchBlock.Remark,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// GetMtHotSearchBlock 根据ID获取热搜屏蔽词
+func (mtHotSearchBlockService *MtHotSearchBlockService) GetMtHotSearchBlock(ctx context.Context, ID string) (mtHotSearchBlock medicine.MtHotSearchBlock, err error) {
+	err = global.GVA_DB.Where("id = ?", ID).First(&mtHotSearchBlock).Error
+	return
+}
+
+// GetMtHotSearchBlockInfoList 分页获取热搜屏蔽词
+func (mtHotSearchBlockService *MtHotSearchBlockService) GetMtHotSearchBlockInfoList(ctx context.Context, info medicineReq.MtHotSearchBlockSearch) (list []medicine.MtHotSearchBlock, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtHotSearchBlock{})
+	if info.Term != nil && *info.Term != "" {
+		db = db.Where("term LIKE ?", "%"+*info.Term+"%")
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("id DESC").Find(&list).Error
+	return list, total, err
+}
@@ -0,0 +1,71 @@
+package medicine
+
+import (
+	"context"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+)
+
+type MtInventoryAlertService struct{}
+
+// GetMtInventoryAlert 根据ID获取库存告警
+func (mtInventoryAlertService *MtInventoryAlertService) GetMtInventoryAlert(ctx context.Context, ID string) (mtInventoryAlert medicine.MtInventoryAlert, err error) {
+	err = global.GVA_DB.Where("id = ?", ID).First(&mtInventoryAlert).Error
+	return
+}
+
+// GetMtInventoryAlertInfoList 分页获取库存告警，默认按告警时间倒序
+func (mtInventoryAlertService *MtInventoryAlertService) GetMtInventoryAlertInfoList(ctx context.Context, info medicineReq.MtInventoryAlertSearch) (list []medicine.MtInventoryAlert, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtInventoryAlert{})
+	var mtInventoryAlerts []medicine.MtInventoryAlert
+	if info.DrugName != nil && *info.DrugName != "" {
+		db = db.Where("drug_name LIKE ?", "%"+*info.DrugName+"%")
+	}
+	if info.DrugStoreId != nil {
+		db = db.Where("drug_store_id = ?", *info.DrugStoreId)
+	}
+	if info.Level != nil {
+		db = db.Where("level = ?", *info.Level)
+	}
+	if info.Status != nil {
+		db = db.Where("status = ?", *info.Status)
+	}
+	if len(info.CreatedAtRange) == 2 {
+		db = db.Where("created_at BETWEEN ? AND ?", info.CreatedAtRange[0], info.CreatedAtRange[1])
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+
+	err = db.Order("created_at DESC").Find(&mtInventoryAlerts).Error
+	return mtInventoryAlerts, total, err
+}
+
+// AckMtInventoryAlerts 确认库存告警，只处理待处理的告警，返回实际确认条数
+func (mtInventoryAlertService *MtInventoryAlertService) AckMtInventoryAlerts(ctx context.Context, IDs []uint, ackBy string) (int64, error) {
+	result := global.GVA_DB.Model(&medicine.MtInventoryAlert{}).
+		Where("id IN ? AND status = ?", IDs, medicine.InventoryAlertOpen).
+		Updates(map[string]interface{}{
+			"status": medicine.InventoryAlertAcknowledged,
+			"ack_by": ackBy,
+			"ack_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CountOpenMtInventoryAlerts 待处理的库存告警数量
+func (mtInventoryAlertService *MtInventoryAlertService) CountOpenMtInventoryAlerts(ctx context.Context) (total int64, err error) {
+	err = global.GVA_DB.Model(&medicine.MtInventoryAlert{}).Where("status = ?", medicine.InventoryAlertOpen).Count(&total).Error
+	return
+}
@@ -0,0 +1,120 @@
+package medicine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+	"gorm.io/gorm"
+)
+
+type MtRefundRecordService struct{}
+
+// GetMtRefundRecord 根据退款单号获取退款单及明细
+func (mtRefundRecordService *MtRefundRecordService) GetMtRefundRecord(ctx context.Context, refundId string) (detail medicine.MtRefundRecordDetail, err error) {
+	err = global.GVA_DB.Where("refund_id = ?", refundId).First(&detail.MtRefundRecord).Error
+	if err != nil {
+		return
+	}
+	err = global.GVA_DB.Where("refund_id = ?", refundId).Order("id ASC").Find(&detail.Items).Error
+	return
+}
+
+// GetMtRefundRecordInfoList 分页获取退款单，默认按申请时间倒序
+func (mtRefundRecordService *MtRefundRecordService) GetMtRefundRecordInfoList(ctx context.Context, info medicineReq.MtRefundRecordSearch) (list []medicine.MtRefundRecord, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtRefundRecord{})
+	var mtRefundRecords []medicine.MtRefundRecord
+	if info.RefundId != nil && *info.RefundId != "" {
+		db = db.Where("refund_id = ?", *info.RefundId)
+	}
+	if info.OrderNo != nil && *info.OrderNo != "" {
+		db = db.Where("order_no = ?", *info.OrderNo)
+	}
+	if info.UserId != nil {
+		db = db.Where("user_id = ?", *info.UserId)
+	}
+	if info.RefundStatus != nil && *info.RefundStatus != "" {
+		db = db.Where("refund_status = ?", *info.RefundStatus)
+	}
+	if len(info.CreatedAtRange) == 2 {
+		db = db.Where("created_at BETWEEN ? AND ?", info.CreatedAtRange[0], info.CreatedAtRange[1])
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+
+	err = db.Order("created_at DESC").Find(&mtRefundRecords).Error
+	return mtRefundRecords, total, err
+}
+
+// ApproveMtRefundRecord 审核通过，只处理待审核的退款单；C端退款任务随后提交支付渠道
+func (mtRefundRecordService *MtRefundRecordService) ApproveMtRefundRecord(ctx context.Context, refundId string, auditBy uint, remark string) error {
+	result := global.GVA_DB.Model(&medicine.MtRefundRecord{}).
+		Where("refund_id = ? AND refund_status = ?", refundId, medicine.RefundStatusRequested).
+		Updates(map[string]interface{}{
+			"refund_status": medicine.RefundStatusApproved,
+			"audit_by":      auditBy,
+			"audit_time":    time.Now(),
+			"audit_remark":  remark,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("退款单不存在或已审核")
+	}
+	return nil
+}
+
+// RejectMtRefundRecord 驳回退款，同时归还订单项的可退数量
+func (mtRefundRecordService *MtRefundRecordService) RejectMtRefundRecord(ctx context.Context, refundId string, auditBy uint, remark string) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&medicine.MtRefundRecord{}).
+			Where("refund_id = ? AND refund_status = ?", refundId, medicine.RefundStatusRequested).
+			Updates(map[string]interface{}{
+				"refund_status": medicine.RefundStatusRejected,
+				"audit_by":      auditBy,
+				"audit_time":    time.Now(),
+				"audit_remark":  remark,
+				"updated_at":    time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("退款单不存在或已审核")
+		}
+
+		var items []medicine.MtRefundItem
+		if err := tx.Where("refund_id = ?", refundId).Find(&items).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			err := tx.Table("mt_order_items").
+				Where("id = ? AND refunded_qty >= ?", item.OrderItemId, item.Quantity).
+				UpdateColumn("refunded_qty", gorm.Expr("refunded_qty - ?", item.Quantity)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CountPendingMtRefundRecords 待审核的退款单数量
+func (mtRefundRecordService *MtRefundRecordService) CountPendingMtRefundRecords(ctx context.Context) (total int64, err error) {
+	err = global.GVA_DB.Model(&medicine.MtRefundRecord{}).Where("refund_status = ?", medicine.RefundStatusRequested).Count(&total).Error
+	return
+}
@@ -0,0 +1,140 @@
+package medicine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/medicine"
+	medicineReq "github.com/flipped-aurora/gin-vue-admin/server/model/medicine/request"
+)
+
+type MtSymptomService struct{}
+
+// 多值字段支持中英文逗号和顿号输入，统一去空白、去重后以英文逗号保存
+func joinSymptomList(value string) string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '，' || r == '、'
+	})
+	seen := make(map[string]bool)
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		items = append(items, field)
+	}
+	return strings.Join(items, ",")
+}
+
+func normalizeSymptom(mtSymptom *medicine.MtSymptom) error {
+	mtSymptom.Name = strings.TrimSpace(mtSymptom.Name)
+	if mtSymptom.Name == "" {
+		return errors.New("症状名称不能为空")
+	}
+	mtSymptom.Aliases = joinSymptomList(mtSymptom.Aliases)
+	mtSymptom.Indications = joinSymptomList(mtSymptom.Indications)
+	mtSymptom.CategoryIDs = joinSymptomList(mtSymptom.CategoryIDs)
+	mtSymptom.Advice = strings.TrimSpace(mtSymptom.Advice)
+	if mtSymptom.DepartmentID == nil {
+		mtSymptom.DepartmentID = new(int)
+	}
+	if mtSymptom.Sort == nil {
+		mtSymptom.Sort = new(int)
+	}
+	if mtSymptom.Enabled == nil {
+		enabled := true
+		mtSymptom.Enabled = &enabled
+	}
+	return nil
+}
+
+// 症状名称不能重复
+func checkSymptomName(name string, excludeID uint) error {
+	var count int64
+	if err := global.GVA_DB.Model(&medicine.MtSymptom{}).Where("name = ? AND id <> ?", name, excludeID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("症状名称已存在: " + name)
+	}
+	return nil
+}
+
+// CreateMtSymptom 创建症状分类
+func (mtSymptomService *MtSymptomService) CreateMtSymptom(ctx context.Context, mtSymptom *medicine.MtSymptom) (err error) {
+	if err = normalizeSymptom(mtSymptom); err != nil {
+		return err
+	}
+	if err = checkSymptomName(mtSymptom.Name, 0); err != nil {
+		return err
+	}
+	now := time.Now()
+	mtSymptom.CreatedAt = now
+	mtSymptom.UpdatedAt = &now
+	return global.GVA_DB.Create(mtSymptom).Error
+}
+
+// DeleteMtSymptom 删除症状分类
+func (mtSymptomService *MtSymptomService) DeleteMtSymptom(ctx context.Context, ID string) error {
+	return global.GVA_DB.Where("id = ?", ID).Delete(&medicine.MtSymptom{}).Error
+}
+
+// DeleteMtSymptomByIds 批量删除症状分类
+func (mtSymptomService *MtSymptomService) DeleteMtSymptomByIds(ctx context.Context, IDs []string) error {
+	return global.GVA_DB.Where("id in ?", IDs).Delete(&medicine.MtSymptom{}).Error
+}
+
+// UpdateMtSymptom 更新症状分类
+func (mtSymptomService *MtSymptomService) UpdateMtSymptom(ctx context.Context, mtSymptom medicine.MtSymptom) (err error) {
+	if err = normalizeSymptom(&mtSymptom); err != nil {
+		return err
+	}
+	if err = checkSymptomName(mtSymptom.Name, mtSymptom.ID); err != nil {
+		return err
+	}
+	return global.GVA_DB.Model(&medicine.MtSymptom{}).Where("id = ?", mtSymptom.ID).Updates(map[string]interface{}{
+		"name":          mtSymptom.Name,
+		"aliases":       mtSymptom.Aliases,
+		"indications":   mtSymptom.Indications,
+		"category_ids":  mtSymptom.CategoryIDs,
+		"department_id": *mtSymptom.DepartmentID,
+		"advice":        mtSymptom.Advice,
+		"sort":          *mtSymptom.Sort,
+		"enabled":       *mtSymptom.Enabled,
+		"updated_at":    time.Now(),
+	}).Error
+}
+
+// GetMtSymptom 根据ID获取症状分类
+func (mtSymptomService *MtSymptomService) GetMtSymptom(ctx context.Context, ID string) (mtSymptom medicine.MtSymptom, err error) {
+	err = global.GVA_DB.Where("id = ?", ID).First(&mtSymptom).Error
+	return
+}
+
+// GetMtSymptomInfoList 分页获取症状分类
+func (mtSymptomService *MtSymptomService) GetMtSymptomInfoList(ctx context.Context, info medicineReq.MtSymptomSearch) (list []medicine.MtSymptom, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&medicine.MtSymptom{})
+	if info.Name != nil && *info.Name != "" {
+		db = db.Where("(name LIKE ? OR aliases LIKE ?)", "%"+*info.Name+"%", "%"+*info.Name+"%")
+	}
+	if info.Enabled != nil {
+		db = db.Where("enabled = ?", *info.Enabled)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("sort ASC, id ASC").Find(&list).Error
+	return list, total, err
+}
@@ -0,0 +1,145 @@
+import service from '@/utils/request'
+
+// @Tags MtDoctorSchedule
+// @Summary 创建排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicine.MtDoctorSchedule true "创建排班模板"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /mtDoctorSchedule/createMtDoctorSchedule [post]
+export const createMtDoctorSchedule = (data) => {
+  return service({
+    url: '/mtDoctorSchedule/createMtDoctorSchedule',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 更新排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicine.MtDoctorSchedule true "更新排班模板"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /mtDoctorSchedule/updateMtDoctorSchedule [put]
+export const updateMtDoctorSchedule = (data) => {
+  return service({
+    url: '/mtDoctorSchedule/updateMtDoctorSchedule',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 删除排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtDoctorSchedule/deleteMtDoctorSchedule [delete]
+export const deleteMtDoctorSchedule = (params) => {
+  return service({
+    url: '/mtDoctorSchedule/deleteMtDoctorSchedule',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 分页获取排班模板
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtDoctorScheduleSearch true "分页获取排班模板"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtDoctorSchedule/getMtDoctorScheduleList [get]
+export const getMtDoctorScheduleList = (params) => {
+  return service({
+    url: '/mtDoctorSchedule/getMtDoctorScheduleList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 创建停诊或加诊
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body medicine.MtDoctorScheduleException true "创建停诊或加诊"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /mtDoctorSchedule/createMtScheduleException [post]
+export const createMtScheduleException = (data) => {
+  return service({
+    url: '/mtDoctorSchedule/createMtScheduleException',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 删除停诊或加诊
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtDoctorSchedule/deleteMtScheduleException [delete]
+export const deleteMtScheduleException = (params) => {
+  return service({
+    url: '/mtDoctorSchedule/deleteMtScheduleException',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 分页获取停诊和加诊
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtScheduleExceptionSearch true "分页获取停诊和加诊"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtDoctorSchedule/getMtScheduleExceptionList [get]
+export const getMtScheduleExceptionList = (params) => {
+  return service({
+    url: '/mtDoctorSchedule/getMtScheduleExceptionList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 分页获取号源
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtScheduleSlotSearch true "分页获取号源"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtDoctorSchedule/getMtScheduleSlotList [get]
+export const getMtScheduleSlotList = (params) => {
+  return service({
+    url: '/mtDoctorSchedule/getMtScheduleSlotList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtDoctorSchedule
+// @Summary 分页获取预约
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtAppointmentSearch true "分页获取预约"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtDoctorSchedule/getMtAppointmentList [get]
+export const getMtAppointmentList = (params) => {
+  return service({
+    url: '/mtDoctorSchedule/getMtAppointmentList',
+    method: 'get',
+    params
+  })
+}
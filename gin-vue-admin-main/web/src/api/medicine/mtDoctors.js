@@ -63,6 +63,22 @@ export const updateMtDoctors = (data) => {
   })
 }
 
+// @Tags MtDoctors
+// @Summary 重置医生登录密码
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body medicineReq.MtDoctorPasswordReset true "医生ID和新密码"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"重置成功"}"
+// @Router /mtDoctors/resetMtDoctorPassword [put]
+export const resetMtDoctorPassword = (data) => {
+  return service({
+    url: '/mtDoctors/resetMtDoctorPassword',
+    method: 'put',
+    data
+  })
+}
+
 // @Tags MtDoctors
 // @Summary 用id查询mtDoctors表
 // @Security ApiKeyAuth
@@ -0,0 +1,99 @@
+import service from '@/utils/request'
+
+// @Tags MtDrugInteraction
+// @Summary 从CSV导入药物相互作用
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "CSV文件，列为：药品A,药品B,严重程度,说明,建议"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"导入成功"}"
+// @Router /mtDrugInteraction/importMtDrugInteraction [post]
+export const importMtDrugInteraction = (data) => {
+  return service({
+    url: '/mtDrugInteraction/importMtDrugInteraction',
+    method: 'post',
+    headers: { 'Content-Type': 'multipart/form-data' },
+    data
+  })
+}
+
+// @Tags MtDrugInteraction
+// @Summary 从CSV导入人群禁忌
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "CSV文件，列为：药品,人群,级别,说明"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"导入成功"}"
+// @Router /mtDrugInteraction/importMtDrugContraindication [post]
+export const importMtDrugContraindication = (data) => {
+  return service({
+    url: '/mtDrugInteraction/importMtDrugContraindication',
+    method: 'post',
+    headers: { 'Content-Type': 'multipart/form-data' },
+    data
+  })
+}
+
+// @Tags MtDrugInteraction
+// @Summary 删除药物相互作用
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtDrugInteraction/deleteMtDrugInteraction [delete]
+export const deleteMtDrugInteraction = (params) => {
+  return service({
+    url: '/mtDrugInteraction/deleteMtDrugInteraction',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtDrugInteraction
+// @Summary 删除人群禁忌
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtDrugInteraction/deleteMtDrugContraindication [delete]
+export const deleteMtDrugContraindication = (params) => {
+  return service({
+    url: '/mtDrugInteraction/deleteMtDrugContraindication',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtDrugInteraction
+// @Summary 分页获取药物相互作用
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtDrugInteractionSearch true "分页获取药物相互作用"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtDrugInteraction/getMtDrugInteractionList [get]
+export const getMtDrugInteractionList = (params) => {
+  return service({
+    url: '/mtDrugInteraction/getMtDrugInteractionList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtDrugInteraction
+// @Summary 分页获取人群禁忌
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtDrugContraindicationSearch true "分页获取人群禁忌"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtDrugInteraction/getMtDrugContraindicationList [get]
+export const getMtDrugContraindicationList = (params) => {
+  return service({
+    url: '/mtDrugInteraction/getMtDrugContraindicationList',
+    method: 'get',
+    params
+  })
+}
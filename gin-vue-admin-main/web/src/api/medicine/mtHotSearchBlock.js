@@ -0,0 +1,97 @@
+import service from '@/utils/request'
+
+// @Tags MtHotSearchBlock
+// @Summary 创建热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body model.MtHotSearchBlock true "创建热搜屏蔽词"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /mtHotSearchBlock/createMtHotSearchBlock [post]
+export const createMtHotSearchBlock = (data) => {
+  return service({
+    url: '/mtHotSearchBlock/createMtHotSearchBlock',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags MtHotSearchBlock
+// @Summary 删除热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtHotSearchBlock/deleteMtHotSearchBlock [delete]
+export const deleteMtHotSearchBlock = (params) => {
+  return service({
+    url: '/mtHotSearchBlock/deleteMtHotSearchBlock',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtHotSearchBlock
+// @Summary 批量删除热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param IDs query []string true "IDs"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtHotSearchBlock/deleteMtHotSearchBlockByIds [delete]
+export const deleteMtHotSearchBlockByIds = (params) => {
+  return service({
+    url: '/mtHotSearchBlock/deleteMtHotSearchBlockByIds',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtHotSearchBlock
+// @Summary 更新热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body model.MtHotSearchBlock true "更新热搜屏蔽词"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /mtHotSearchBlock/updateMtHotSearchBlock [put]
+export const updateMtHotSearchBlock = (data) => {
+  return service({
+    url: '/mtHotSearchBlock/updateMtHotSearchBlock',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags MtHotSearchBlock
+// @Summary 用id查询热搜屏蔽词
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query uint true "用id查询热搜屏蔽词"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"查询成功"}"
+// @Router /mtHotSearchBlock/findMtHotSearchBlock [get]
+export const findMtHotSearchBlock = (params) => {
+  return service({
+    url: '/mtHotSearchBlock/findMtHotSearchBlock',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtHotSearchBlock
+// @Summary 分页获取热搜屏蔽词列表
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data query request.PageInfo true "分页获取热搜屏蔽词列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtHotSearchBlock/getMtHotSearchBlockList [get]
+export const getMtHotSearchBlockList = (params) => {
+  return service({
+    url: '/mtHotSearchBlock/getMtHotSearchBlockList',
+    method: 'get',
+    params
+  })
+}
@@ -0,0 +1,63 @@
+import service from '@/utils/request'
+
+// @Tags MtInventoryAlert
+// @Summary 用id查询库存告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query string true "告警ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"查询成功"}"
+// @Router /mtInventoryAlert/findMtInventoryAlert [get]
+export const findMtInventoryAlert = (params) => {
+  return service({
+    url: '/mtInventoryAlert/findMtInventoryAlert',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtInventoryAlert
+// @Summary 分页获取库存告警列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtInventoryAlertSearch true "分页获取库存告警列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtInventoryAlert/getMtInventoryAlertList [get]
+export const getMtInventoryAlertList = (params) => {
+  return service({
+    url: '/mtInventoryAlert/getMtInventoryAlertList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtInventoryAlert
+// @Summary 确认库存告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MtInventoryAlertAck true "待确认的告警ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"确认成功"}"
+// @Router /mtInventoryAlert/ackMtInventoryAlert [put]
+export const ackMtInventoryAlert = (data) => {
+  return service({
+    url: '/mtInventoryAlert/ackMtInventoryAlert',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags MtInventoryAlert
+// @Summary 获取待处理库存告警数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtInventoryAlert/getOpenMtInventoryAlertCount [get]
+export const getOpenMtInventoryAlertCount = () => {
+  return service({
+    url: '/mtInventoryAlert/getOpenMtInventoryAlertCount',
+    method: 'get'
+  })
+}
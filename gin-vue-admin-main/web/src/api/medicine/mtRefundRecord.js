@@ -0,0 +1,79 @@
+import service from '@/utils/request'
+
+// @Tags MtRefundRecord
+// @Summary 用退款单号查询退款单及明细
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param refundId query string true "退款单号"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"查询成功"}"
+// @Router /mtRefundRecord/findMtRefundRecord [get]
+export const findMtRefundRecord = (params) => {
+  return service({
+    url: '/mtRefundRecord/findMtRefundRecord',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtRefundRecord
+// @Summary 分页获取退款单列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MtRefundRecordSearch true "分页获取退款单列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtRefundRecord/getMtRefundRecordList [get]
+export const getMtRefundRecordList = (params) => {
+  return service({
+    url: '/mtRefundRecord/getMtRefundRecordList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtRefundRecord
+// @Summary 审核通过退款
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MtRefundRecordAudit true "退款单号和审核备注"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"审核成功"}"
+// @Router /mtRefundRecord/approveMtRefundRecord [put]
+export const approveMtRefundRecord = (data) => {
+  return service({
+    url: '/mtRefundRecord/approveMtRefundRecord',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags MtRefundRecord
+// @Summary 驳回退款
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MtRefundRecordAudit true "退款单号和驳回原因"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"驳回成功"}"
+// @Router /mtRefundRecord/rejectMtRefundRecord [put]
+export const rejectMtRefundRecord = (data) => {
+  return service({
+    url: '/mtRefundRecord/rejectMtRefundRecord',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags MtRefundRecord
+// @Summary 获取待审核退款单数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtRefundRecord/getPendingMtRefundRecordCount [get]
+export const getPendingMtRefundRecordCount = () => {
+  return service({
+    url: '/mtRefundRecord/getPendingMtRefundRecordCount',
+    method: 'get'
+  })
+}
@@ -0,0 +1,97 @@
+import service from '@/utils/request'
+
+// @Tags MtSymptom
+// @Summary 创建症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body model.MtSymptom true "创建症状分类"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /mtSymptom/createMtSymptom [post]
+export const createMtSymptom = (data) => {
+  return service({
+    url: '/mtSymptom/createMtSymptom',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags MtSymptom
+// @Summary 删除症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query string true "ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtSymptom/deleteMtSymptom [delete]
+export const deleteMtSymptom = (params) => {
+  return service({
+    url: '/mtSymptom/deleteMtSymptom',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtSymptom
+// @Summary 批量删除症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param IDs query []string true "IDs"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /mtSymptom/deleteMtSymptomByIds [delete]
+export const deleteMtSymptomByIds = (params) => {
+  return service({
+    url: '/mtSymptom/deleteMtSymptomByIds',
+    method: 'delete',
+    params
+  })
+}
+
+// @Tags MtSymptom
+// @Summary 更新症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body model.MtSymptom true "更新症状分类"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /mtSymptom/updateMtSymptom [put]
+export const updateMtSymptom = (data) => {
+  return service({
+    url: '/mtSymptom/updateMtSymptom',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags MtSymptom
+// @Summary 用id查询症状分类
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param ID query uint true "用id查询症状分类"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"查询成功"}"
+// @Router /mtSymptom/findMtSymptom [get]
+export const findMtSymptom = (params) => {
+  return service({
+    url: '/mtSymptom/findMtSymptom',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags MtSymptom
+// @Summary 分页获取症状分类列表
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data query request.PageInfo true "分页获取症状分类列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /mtSymptom/getMtSymptomList [get]
+export const getMtSymptomList = (params) => {
+  return service({
+    url: '/mtSymptom/getMtSymptomList',
+    method: 'get',
+    params
+  })
+}
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	v1 "kratos_client/api/drug/v1"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -438,6 +439,126 @@ func (x *ListCartRequest) GetUserId() int64 {
 	return 0
 }
 
+type CheckCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Populations   []string               `protobuf:"bytes,2,rep,name=populations,proto3" json:"populations,omitempty"` // 用药人所属人群：pregnancy孕妇 lactation哺乳期 child儿童 elderly老人
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCartRequest) Reset() {
+	*x = CheckCartRequest{}
+	mi := &file_cart_v1_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCartRequest) ProtoMessage() {}
+
+func (x *CheckCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_v1_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCartRequest.ProtoReflect.Descriptor instead.
+func (*CheckCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_v1_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheckCartRequest) GetPopulations() []string {
+	if x != nil {
+		return x.Populations
+	}
+	return nil
+}
+
+type CheckCartReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Blocked       bool                   `protobuf:"varint,3,opt,name=blocked,proto3" json:"blocked,omitempty"` // 存在拦截级别的提示时不能下单
+	Warnings      []*v1.DrugWarning      `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCartReply) Reset() {
+	*x = CheckCartReply{}
+	mi := &file_cart_v1_cart_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCartReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCartReply) ProtoMessage() {}
+
+func (x *CheckCartReply) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_v1_cart_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCartReply.ProtoReflect.Descriptor instead.
+func (*CheckCartReply) Descriptor() ([]byte, []int) {
+	return file_cart_v1_cart_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CheckCartReply) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CheckCartReply) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *CheckCartReply) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *CheckCartReply) GetWarnings() []*v1.DrugWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 type ListCartReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -449,7 +570,7 @@ type ListCartReply struct {
 
 func (x *ListCartReply) Reset() {
 	*x = ListCartReply{}
-	mi := &file_cart_v1_cart_proto_msgTypes[8]
+	mi := &file_cart_v1_cart_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -461,7 +582,7 @@ func (x *ListCartReply) String() string {
 func (*ListCartReply) ProtoMessage() {}
 
 func (x *ListCartReply) ProtoReflect() protoreflect.Message {
-	mi := &file_cart_v1_cart_proto_msgTypes[8]
+	mi := &file_cart_v1_cart_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +595,7 @@ func (x *ListCartReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCartReply.ProtoReflect.Descriptor instead.
 func (*ListCartReply) Descriptor() ([]byte, []int) {
-	return file_cart_v1_cart_proto_rawDescGZIP(), []int{8}
+	return file_cart_v1_cart_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListCartReply) GetCode() int64 {
@@ -515,7 +636,7 @@ type InfoCart struct {
 
 func (x *InfoCart) Reset() {
 	*x = InfoCart{}
-	mi := &file_cart_v1_cart_proto_msgTypes[9]
+	mi := &file_cart_v1_cart_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -527,7 +648,7 @@ func (x *InfoCart) String() string {
 func (*InfoCart) ProtoMessage() {}
 
 func (x *InfoCart) ProtoReflect() protoreflect.Message {
-	mi := &file_cart_v1_cart_proto_msgTypes[9]
+	mi := &file_cart_v1_cart_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +661,7 @@ func (x *InfoCart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InfoCart.ProtoReflect.Descriptor instead.
 func (*InfoCart) Descriptor() ([]byte, []int) {
-	return file_cart_v1_cart_proto_rawDescGZIP(), []int{9}
+	return file_cart_v1_cart_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *InfoCart) GetId() int64 {
@@ -610,7 +731,7 @@ var File_cart_v1_cart_proto protoreflect.FileDescriptor
 
 const file_cart_v1_cart_proto_rawDesc = "" +
 	"\n" +
-	"\x12cart/v1/cart.proto\x12\vapi.cart.v1\x1a\x1cgoogle/api/annotations.proto\"]\n" +
+	"\x12cart/v1/cart.proto\x12\vapi.cart.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x12drug/v1/drug.proto\"]\n" +
 	"\x11CreateCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x17\n" +
 	"\adrug_id\x18\x02 \x01(\x03R\x06drugId\x12\x16\n" +
@@ -634,7 +755,15 @@ const file_cart_v1_cart_proto_rawDesc = "" +
 	"\x0eGetCartRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"*\n" +
 	"\x0fListCartRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"`\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"M\n" +
+	"\x10CheckCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12 \n" +
+	"\vpopulations\x18\x02 \x03(\tR\vpopulations\"\x82\x01\n" +
+	"\x0eCheckCartReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\x12\x18\n" +
+	"\ablocked\x18\x03 \x01(\bR\ablocked\x120\n" +
+	"\bwarnings\x18\x04 \x03(\v2\x14.drug.v1.DrugWarningR\bwarnings\"`\n" +
 	"\rListCartReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x12)\n" +
@@ -648,7 +777,7 @@ const file_cart_v1_cart_proto_rawDesc = "" +
 	"\rspecification\x18\x06 \x01(\tR\rspecification\x12\x14\n" +
 	"\x05price\x18\a \x01(\x01R\x05price\x12\x1c\n" +
 	"\tinventory\x18\b \x01(\x03R\tinventory\x12%\n" +
-	"\x0eexhibition_url\x18\t \x01(\tR\rexhibitionUrl2\x9e\x03\n" +
+	"\x0eexhibition_url\x18\t \x01(\tR\rexhibitionUrl2\x82\x04\n" +
 	"\x04Cart\x12f\n" +
 	"\n" +
 	"CreateCart\x12\x1e.api.cart.v1.CreateCartRequest\x1a\x1c.api.cart.v1.CreateCartReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/cart/create\x12f\n" +
@@ -656,7 +785,8 @@ const file_cart_v1_cart_proto_rawDesc = "" +
 	"UpdateCart\x12\x1e.api.cart.v1.UpdateCartRequest\x1a\x1c.api.cart.v1.UpdateCartReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/cart/update\x12f\n" +
 	"\n" +
 	"DeleteCart\x12\x1e.api.cart.v1.DeleteCartRequest\x1a\x1c.api.cart.v1.DeleteCartReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/cart/delete\x12^\n" +
-	"\bListCart\x12\x1c.api.cart.v1.ListCartRequest\x1a\x1a.api.cart.v1.ListCartReply\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/cart/listB-\n" +
+	"\bListCart\x12\x1c.api.cart.v1.ListCartRequest\x1a\x1a.api.cart.v1.ListCartReply\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/cart/list\x12b\n" +
+	"\tCheckCart\x12\x1d.api.cart.v1.CheckCartRequest\x1a\x1b.api.cart.v1.CheckCartReply\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/cart/checkB-\n" +
 	"\vapi.cart.v1P\x01Z\x1ckratos_client/api/cart/v1;v1b\x06proto3"
 
 var (
@@ -671,7 +801,7 @@ func file_cart_v1_cart_proto_rawDescGZIP() []byte {
 	return file_cart_v1_cart_proto_rawDescData
 }
 
-var file_cart_v1_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_cart_v1_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_cart_v1_cart_proto_goTypes = []any{
 	(*CreateCartRequest)(nil), // 0: api.cart.v1.CreateCartRequest
 	(*CreateCartReply)(nil),   // 1: api.cart.v1.CreateCartReply
@@ -681,24 +811,30 @@ var file_cart_v1_cart_proto_goTypes = []any{
 	(*DeleteCartReply)(nil),   // 5: api.cart.v1.DeleteCartReply
 	(*GetCartRequest)(nil),    // 6: api.cart.v1.GetCartRequest
 	(*ListCartRequest)(nil),   // 7: api.cart.v1.ListCartRequest
-	(*ListCartReply)(nil),     // 8: api.cart.v1.ListCartReply
-	(*InfoCart)(nil),          // 9: api.cart.v1.InfoCart
+	(*CheckCartRequest)(nil),  // 8: api.cart.v1.CheckCartRequest
+	(*CheckCartReply)(nil),    // 9: api.cart.v1.CheckCartReply
+	(*ListCartReply)(nil),     // 10: api.cart.v1.ListCartReply
+	(*InfoCart)(nil),          // 11: api.cart.v1.InfoCart
+	(*v1.DrugWarning)(nil),    // 12: drug.v1.DrugWarning
 }
 var file_cart_v1_cart_proto_depIdxs = []int32{
-	9, // 0: api.cart.v1.ListCartReply.cart:type_name -> api.cart.v1.InfoCart
-	0, // 1: api.cart.v1.Cart.CreateCart:input_type -> api.cart.v1.CreateCartRequest
-	2, // 2: api.cart.v1.Cart.UpdateCart:input_type -> api.cart.v1.UpdateCartRequest
-	4, // 3: api.cart.v1.Cart.DeleteCart:input_type -> api.cart.v1.DeleteCartRequest
-	7, // 4: api.cart.v1.Cart.ListCart:input_type -> api.cart.v1.ListCartRequest
-	1, // 5: api.cart.v1.Cart.CreateCart:output_type -> api.cart.v1.CreateCartReply
-	3, // 6: api.cart.v1.Cart.UpdateCart:output_type -> api.cart.v1.UpdateCartReply
-	5, // 7: api.cart.v1.Cart.DeleteCart:output_type -> api.cart.v1.DeleteCartReply
-	8, // 8: api.cart.v1.Cart.ListCart:output_type -> api.cart.v1.ListCartReply
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	12, // 0: api.cart.v1.CheckCartReply.warnings:type_name -> drug.v1.DrugWarning
+	11, // 1: api.cart.v1.ListCartReply.cart:type_name -> api.cart.v1.InfoCart
+	0,  // 2: api.cart.v1.Cart.CreateCart:input_type -> api.cart.v1.CreateCartRequest
+	2,  // 3: api.cart.v1.Cart.UpdateCart:input_type -> api.cart.v1.UpdateCartRequest
+	4,  // 4: api.cart.v1.Cart.DeleteCart:input_type -> api.cart.v1.DeleteCartRequest
+	7,  // 5: api.cart.v1.Cart.ListCart:input_type -> api.cart.v1.ListCartRequest
+	8,  // 6: api.cart.v1.Cart.CheckCart:input_type -> api.cart.v1.CheckCartRequest
+	1,  // 7: api.cart.v1.Cart.CreateCart:output_type -> api.cart.v1.CreateCartReply
+	3,  // 8: api.cart.v1.Cart.UpdateCart:output_type -> api.cart.v1.UpdateCartReply
+	5,  // 9: api.cart.v1.Cart.DeleteCart:output_type -> api.cart.v1.DeleteCartReply
+	10, // 10: api.cart.v1.Cart.ListCart:output_type -> api.cart.v1.ListCartReply
+	9,  // 11: api.cart.v1.Cart.CheckCart:output_type -> api.cart.v1.CheckCartReply
+	7,  // [7:12] is the sub-list for method output_type
+	2,  // [2:7] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_cart_v1_cart_proto_init() }
@@ -712,7 +848,7 @@ func file_cart_v1_cart_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cart_v1_cart_proto_rawDesc), len(file_cart_v1_cart_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const (
 	Cart_UpdateCart_FullMethodName = "/api.cart.v1.Cart/UpdateCart"
 	Cart_DeleteCart_FullMethodName = "/api.cart.v1.Cart/DeleteCart"
 	Cart_ListCart_FullMethodName   = "/api.cart.v1.Cart/ListCart"
+	Cart_CheckCart_FullMethodName  = "/api.cart.v1.Cart/CheckCart"
 )
 
 // CartClient is the client API for Cart service.
@@ -33,6 +34,8 @@ type CartClient interface {
 	UpdateCart(ctx context.Context, in *UpdateCartRequest, opts ...grpc.CallOption) (*UpdateCartReply, error)
 	DeleteCart(ctx context.Context, in *DeleteCartRequest, opts ...grpc.CallOption) (*DeleteCartReply, error)
 	ListCart(ctx context.Context, in *ListCartRequest, opts ...grpc.CallOption) (*ListCartReply, error)
+	// 结账前检查购物车药品的相互作用和用药禁忌
+	CheckCart(ctx context.Context, in *CheckCartRequest, opts ...grpc.CallOption) (*CheckCartReply, error)
 }
 
 type cartClient struct {
@@ -83,6 +86,16 @@ func (c *cartClient) ListCart(ctx context.Context, in *ListCartRequest, opts ...
 	return out, nil
 }
 
+func (c *cartClient) CheckCart(ctx context.Context, in *CheckCartRequest, opts ...grpc.CallOption) (*CheckCartReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckCartReply)
+	err := c.cc.Invoke(ctx, Cart_CheckCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CartServer is the server API for Cart service.
 // All implementations must embed UnimplementedCartServer
 // for forward compatibility.
@@ -91,6 +104,8 @@ type CartServer interface {
 	UpdateCart(context.Context, *UpdateCartRequest) (*UpdateCartReply, error)
 	DeleteCart(context.Context, *DeleteCartRequest) (*DeleteCartReply, error)
 	ListCart(context.Context, *ListCartRequest) (*ListCartReply, error)
+	// 结账前检查购物车药品的相互作用和用药禁忌
+	CheckCart(context.Context, *CheckCartRequest) (*CheckCartReply, error)
 	mustEmbedUnimplementedCartServer()
 }
 
@@ -113,6 +128,9 @@ func (UnimplementedCartServer) DeleteCart(context.Context, *DeleteCartRequest) (
 func (UnimplementedCartServer) ListCart(context.Context, *ListCartRequest) (*ListCartReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCart not implemented")
 }
+func (UnimplementedCartServer) CheckCart(context.Context, *CheckCartRequest) (*CheckCartReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCart not implemented")
+}
 func (UnimplementedCartServer) mustEmbedUnimplementedCartServer() {}
 func (UnimplementedCartServer) testEmbeddedByValue()              {}
 
@@ -206,6 +224,24 @@ func _Cart_ListCart_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cart_CheckCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServer).CheckCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cart_CheckCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServer).CheckCart(ctx, req.(*CheckCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Cart_ServiceDesc is the grpc.ServiceDesc for Cart service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +265,10 @@ var Cart_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCart",
 			Handler:    _Cart_ListCart_Handler,
 		},
+		{
+			MethodName: "CheckCart",
+			Handler:    _Cart_CheckCart_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cart/v1/cart.proto",
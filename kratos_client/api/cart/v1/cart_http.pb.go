@@ -19,12 +19,15 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationCartCheckCart = "/api.cart.v1.Cart/CheckCart"
 const OperationCartCreateCart = "/api.cart.v1.Cart/CreateCart"
 const OperationCartDeleteCart = "/api.cart.v1.Cart/DeleteCart"
 const OperationCartListCart = "/api.cart.v1.Cart/ListCart"
 const OperationCartUpdateCart = "/api.cart.v1.Cart/UpdateCart"
 
 type CartHTTPServer interface {
+	// CheckCart 结账前检查购物车药品的相互作用和用药禁忌
+	CheckCart(context.Context, *CheckCartRequest) (*CheckCartReply, error)
 	CreateCart(context.Context, *CreateCartRequest) (*CreateCartReply, error)
 	DeleteCart(context.Context, *DeleteCartRequest) (*DeleteCartReply, error)
 	ListCart(context.Context, *ListCartRequest) (*ListCartReply, error)
@@ -37,6 +40,7 @@ func RegisterCartHTTPServer(s *http.Server, srv CartHTTPServer) {
 	r.POST("/v1/cart/update", _Cart_UpdateCart0_HTTP_Handler(srv))
 	r.POST("/v1/cart/delete", _Cart_DeleteCart0_HTTP_Handler(srv))
 	r.POST("/v1/cart/list", _Cart_ListCart0_HTTP_Handler(srv))
+	r.POST("/v1/cart/check", _Cart_CheckCart0_HTTP_Handler(srv))
 }
 
 func _Cart_CreateCart0_HTTP_Handler(srv CartHTTPServer) func(ctx http.Context) error {
@@ -127,7 +131,30 @@ func _Cart_ListCart0_HTTP_Handler(srv CartHTTPServer) func(ctx http.Context) err
 	}
 }
 
+func _Cart_CheckCart0_HTTP_Handler(srv CartHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CheckCartRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationCartCheckCart)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CheckCart(ctx, req.(*CheckCartRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CheckCartReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type CartHTTPClient interface {
+	CheckCart(ctx context.Context, req *CheckCartRequest, opts ...http.CallOption) (rsp *CheckCartReply, err error)
 	CreateCart(ctx context.Context, req *CreateCartRequest, opts ...http.CallOption) (rsp *CreateCartReply, err error)
 	DeleteCart(ctx context.Context, req *DeleteCartRequest, opts ...http.CallOption) (rsp *DeleteCartReply, err error)
 	ListCart(ctx context.Context, req *ListCartRequest, opts ...http.CallOption) (rsp *ListCartReply, err error)
@@ -142,6 +169,19 @@ func NewCartHTTPClient(client *http.Client) CartHTTPClient {
 	return &CartHTTPClientImpl{client}
 }
 
+func (c *CartHTTPClientImpl) CheckCart(ctx context.Context, in *CheckCartRequest, opts ...http.CallOption) (*CheckCartReply, error) {
+	var out CheckCartReply
+	pattern := "/v1/cart/check"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationCartCheckCart))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *CartHTTPClientImpl) CreateCart(ctx context.Context, in *CreateCartRequest, opts ...http.CallOption) (*CreateCartReply, error) {
 	var out CreateCartReply
 	pattern := "/v1/cart/create"
@@ -24,10 +24,12 @@ const (
 
 type GetChatHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Token         string                 `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"` // 用户token，患者或医生
 	TargetId      int32                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	TargetRole    string                 `protobuf:"bytes,7,opt,name=target_role,json=targetRole,proto3" json:"target_role,omitempty"` // 对方角色 patient、doctor、pharmacist，未填写时患者默认为医生，其他默认为患者
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                              // 页码，cursor 为 0 时生效
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Cursor        int64                  `protobuf:"varint,5,opt,name=cursor,proto3" json:"cursor,omitempty"` // 游标，返回ID小于游标的消息，首次查询传 0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -62,11 +64,11 @@ func (*GetChatHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_chat_v1_chat_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *GetChatHistoryRequest) GetUserId() int32 {
+func (x *GetChatHistoryRequest) GetToken() string {
 	if x != nil {
-		return x.UserId
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
 func (x *GetChatHistoryRequest) GetTargetId() int32 {
@@ -76,6 +78,13 @@ func (x *GetChatHistoryRequest) GetTargetId() int32 {
 	return 0
 }
 
+func (x *GetChatHistoryRequest) GetTargetRole() string {
+	if x != nil {
+		return x.TargetRole
+	}
+	return ""
+}
+
 func (x *GetChatHistoryRequest) GetPage() int32 {
 	if x != nil {
 		return x.Page
@@ -90,12 +99,21 @@ func (x *GetChatHistoryRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetChatHistoryRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
 type GetChatHistoryReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Messages      []*ChatMessageInfo     `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+	Messages      []*ChatMessageInfo     `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"` // 按发送时间正序
 	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    int64                  `protobuf:"varint,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，即本页最早一条消息的ID
+	HasMore       bool                   `protobuf:"varint,6,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`          // 是否还有更早的消息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -158,15 +176,34 @@ func (x *GetChatHistoryReply) GetTotal() int32 {
 	return 0
 }
 
+func (x *GetChatHistoryReply) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+func (x *GetChatHistoryReply) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
 type SaveChatMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromId        int32                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
-	ToId          int32                  `protobuf:"varint,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	MessageType   string                 `protobuf:"bytes,4,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
-	RoomId        string                 `protobuf:"bytes,5,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,10,opt,name=token,proto3" json:"token,omitempty"` // 用户token，患者或医生
+	ToId           int32                  `protobuf:"varint,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	ToRole         string                 `protobuf:"bytes,11,opt,name=to_role,json=toRole,proto3" json:"to_role,omitempty"`                         // 接收者角色，未填写时患者默认为医生，其他默认为患者
+	Content        string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`                                      // 文本消息内容
+	MessageType    string                 `protobuf:"bytes,4,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`           // text、image、voice、prescription、order，默认 text
+	RoomId         string                 `protobuf:"bytes,5,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`                          // 可选，须为发送者与接收者的房间
+	Image          *ChatImage             `protobuf:"bytes,6,opt,name=image,proto3" json:"image,omitempty"`                                          // message_type 为 image 时必填
+	Voice          *ChatVoice             `protobuf:"bytes,7,opt,name=voice,proto3" json:"voice,omitempty"`                                          // message_type 为 voice 时必填
+	PrescriptionId uint64                 `protobuf:"varint,8,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"` // message_type 为 prescription 时必填
+	OrderNo        string                 `protobuf:"bytes,9,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`                       // message_type 为 order 时必填
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SaveChatMessageRequest) Reset() {
@@ -199,11 +236,11 @@ func (*SaveChatMessageRequest) Descriptor() ([]byte, []int) {
 	return file_chat_v1_chat_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SaveChatMessageRequest) GetFromId() int32 {
+func (x *SaveChatMessageRequest) GetToken() string {
 	if x != nil {
-		return x.FromId
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
 func (x *SaveChatMessageRequest) GetToId() int32 {
@@ -213,6 +250,13 @@ func (x *SaveChatMessageRequest) GetToId() int32 {
 	return 0
 }
 
+func (x *SaveChatMessageRequest) GetToRole() string {
+	if x != nil {
+		return x.ToRole
+	}
+	return ""
+}
+
 func (x *SaveChatMessageRequest) GetContent() string {
 	if x != nil {
 		return x.Content
@@ -234,10 +278,39 @@ func (x *SaveChatMessageRequest) GetRoomId() string {
 	return ""
 }
 
+func (x *SaveChatMessageRequest) GetImage() *ChatImage {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *SaveChatMessageRequest) GetVoice() *ChatVoice {
+	if x != nil {
+		return x.Voice
+	}
+	return nil
+}
+
+func (x *SaveChatMessageRequest) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+func (x *SaveChatMessageRequest) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
 type SaveChatMessageReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ChatMessageInfo       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -286,9 +359,16 @@ func (x *SaveChatMessageReply) GetMessage() string {
 	return ""
 }
 
+func (x *SaveChatMessageReply) GetData() *ChatMessageInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 type GetUserChatRoomsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // 用户token，患者或医生
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -323,11 +403,11 @@ func (*GetUserChatRoomsRequest) Descriptor() ([]byte, []int) {
 	return file_chat_v1_chat_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetUserChatRoomsRequest) GetUserId() int32 {
+func (x *GetUserChatRoomsRequest) GetToken() string {
 	if x != nil {
-		return x.UserId
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
 type GetUserChatRoomsReply struct {
@@ -390,24 +470,165 @@ func (x *GetUserChatRoomsReply) GetRooms() []*ChatRoomInfo {
 	return nil
 }
 
-type ChatMessageInfo struct {
+type MarkChatReadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	FromId        int32                  `protobuf:"varint,2,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
-	ToId          int32                  `protobuf:"varint,3,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
-	FromName      string                 `protobuf:"bytes,4,opt,name=from_name,json=fromName,proto3" json:"from_name,omitempty"`
-	ToName        string                 `protobuf:"bytes,5,opt,name=to_name,json=toName,proto3" json:"to_name,omitempty"`
-	Content       string                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
-	MessageType   string                 `protobuf:"bytes,7,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
-	RoomId        string                 `protobuf:"bytes,8,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"` // 用户token，患者或医生
+	TargetId      int32                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	TargetRole    string                 `protobuf:"bytes,5,opt,name=target_role,json=targetRole,proto3" json:"target_role,omitempty"`             // 对方角色，未填写时患者默认为医生，其他默认为患者
+	LastMessageId int64                  `protobuf:"varint,3,opt,name=last_message_id,json=lastMessageId,proto3" json:"last_message_id,omitempty"` // 已读到的消息ID，为 0 时标记全部已读
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkChatReadRequest) Reset() {
+	*x = MarkChatReadRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkChatReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkChatReadRequest) ProtoMessage() {}
+
+func (x *MarkChatReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkChatReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkChatReadRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MarkChatReadRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *MarkChatReadRequest) GetTargetId() int32 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *MarkChatReadRequest) GetTargetRole() string {
+	if x != nil {
+		return x.TargetRole
+	}
+	return ""
+}
+
+func (x *MarkChatReadRequest) GetLastMessageId() int64 {
+	if x != nil {
+		return x.LastMessageId
+	}
+	return 0
+}
+
+type MarkChatReadReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ReadId        int64                  `protobuf:"varint,3,opt,name=read_id,json=readId,proto3" json:"read_id,omitempty"` // 实际已读到的消息ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkChatReadReply) Reset() {
+	*x = MarkChatReadReply{}
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkChatReadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkChatReadReply) ProtoMessage() {}
+
+func (x *MarkChatReadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkChatReadReply.ProtoReflect.Descriptor instead.
+func (*MarkChatReadReply) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MarkChatReadReply) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *MarkChatReadReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MarkChatReadReply) GetReadId() int64 {
+	if x != nil {
+		return x.ReadId
+	}
+	return 0
+}
+
+type ChatMessageInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromId      int32                  `protobuf:"varint,2,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId        int32                  `protobuf:"varint,3,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	FromName    string                 `protobuf:"bytes,4,opt,name=from_name,json=fromName,proto3" json:"from_name,omitempty"`
+	ToName      string                 `protobuf:"bytes,5,opt,name=to_name,json=toName,proto3" json:"to_name,omitempty"`
+	Content     string                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	MessageType string                 `protobuf:"bytes,7,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	RoomId      string                 `protobuf:"bytes,8,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status      string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"` // 投递状态：sent 已发送、delivered 已送达、read 已读
+	FromRole    string                 `protobuf:"bytes,16,opt,name=from_role,json=fromRole,proto3" json:"from_role,omitempty"`
+	ToRole      string                 `protobuf:"bytes,17,opt,name=to_role,json=toRole,proto3" json:"to_role,omitempty"`
+	// 按 message_type 返回对应的结构化数据，文本消息为空
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ChatMessageInfo_Image
+	//	*ChatMessageInfo_Voice
+	//	*ChatMessageInfo_Prescription
+	//	*ChatMessageInfo_Order
+	//	*ChatMessageInfo_System
+	Payload       isChatMessageInfo_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ChatMessageInfo) Reset() {
 	*x = ChatMessageInfo{}
-	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -419,7 +640,7 @@ func (x *ChatMessageInfo) String() string {
 func (*ChatMessageInfo) ProtoMessage() {}
 
 func (x *ChatMessageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,7 +653,7 @@ func (x *ChatMessageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatMessageInfo.ProtoReflect.Descriptor instead.
 func (*ChatMessageInfo) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{6}
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ChatMessageInfo) GetId() int64 {
@@ -498,6 +719,458 @@ func (x *ChatMessageInfo) GetCreatedAt() string {
 	return ""
 }
 
+func (x *ChatMessageInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChatMessageInfo) GetFromRole() string {
+	if x != nil {
+		return x.FromRole
+	}
+	return ""
+}
+
+func (x *ChatMessageInfo) GetToRole() string {
+	if x != nil {
+		return x.ToRole
+	}
+	return ""
+}
+
+func (x *ChatMessageInfo) GetPayload() isChatMessageInfo_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChatMessageInfo) GetImage() *ChatImage {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageInfo_Image); ok {
+			return x.Image
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessageInfo) GetVoice() *ChatVoice {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageInfo_Voice); ok {
+			return x.Voice
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessageInfo) GetPrescription() *ChatPrescriptionCard {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageInfo_Prescription); ok {
+			return x.Prescription
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessageInfo) GetOrder() *ChatOrderCard {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageInfo_Order); ok {
+			return x.Order
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessageInfo) GetSystem() *ChatSystemNotice {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageInfo_System); ok {
+			return x.System
+		}
+	}
+	return nil
+}
+
+type isChatMessageInfo_Payload interface {
+	isChatMessageInfo_Payload()
+}
+
+type ChatMessageInfo_Image struct {
+	Image *ChatImage `protobuf:"bytes,11,opt,name=image,proto3,oneof"`
+}
+
+type ChatMessageInfo_Voice struct {
+	Voice *ChatVoice `protobuf:"bytes,12,opt,name=voice,proto3,oneof"`
+}
+
+type ChatMessageInfo_Prescription struct {
+	Prescription *ChatPrescriptionCard `protobuf:"bytes,13,opt,name=prescription,proto3,oneof"`
+}
+
+type ChatMessageInfo_Order struct {
+	Order *ChatOrderCard `protobuf:"bytes,14,opt,name=order,proto3,oneof"`
+}
+
+type ChatMessageInfo_System struct {
+	System *ChatSystemNotice `protobuf:"bytes,15,opt,name=system,proto3,oneof"`
+}
+
+func (*ChatMessageInfo_Image) isChatMessageInfo_Payload() {}
+
+func (*ChatMessageInfo_Voice) isChatMessageInfo_Payload() {}
+
+func (*ChatMessageInfo_Prescription) isChatMessageInfo_Payload() {}
+
+func (*ChatMessageInfo_Order) isChatMessageInfo_Payload() {}
+
+func (*ChatMessageInfo_System) isChatMessageInfo_Payload() {}
+
+// 图片，url 为上传接口返回的地址
+type ChatImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"` // 字节数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatImage) Reset() {
+	*x = ChatImage{}
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatImage) ProtoMessage() {}
+
+func (x *ChatImage) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatImage.ProtoReflect.Descriptor instead.
+func (*ChatImage) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ChatImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ChatImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ChatImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ChatImage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// 语音，url 为上传接口返回的地址
+type ChatVoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Duration      int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"` // 秒，最长60秒
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatVoice) Reset() {
+	*x = ChatVoice{}
+	mi := &file_chat_v1_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatVoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatVoice) ProtoMessage() {}
+
+func (x *ChatVoice) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatVoice.ProtoReflect.Descriptor instead.
+func (*ChatVoice) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChatVoice) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ChatVoice) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+// 处方卡片，发送时的处方快照
+type ChatPrescriptionCard struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PrescriptionId   uint64                 `protobuf:"varint,1,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	PrescriptionNo   string                 `protobuf:"bytes,2,opt,name=prescription_no,json=prescriptionNo,proto3" json:"prescription_no,omitempty"`
+	PrescriptionType string                 `protobuf:"bytes,3,opt,name=prescription_type,json=prescriptionType,proto3" json:"prescription_type,omitempty"`
+	Status           string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TotalAmount      string                 `protobuf:"bytes,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	MedicineCount    int32                  `protobuf:"varint,6,opt,name=medicine_count,json=medicineCount,proto3" json:"medicine_count,omitempty"`
+	PrescriptionDate string                 `protobuf:"bytes,7,opt,name=prescription_date,json=prescriptionDate,proto3" json:"prescription_date,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChatPrescriptionCard) Reset() {
+	*x = ChatPrescriptionCard{}
+	mi := &file_chat_v1_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatPrescriptionCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatPrescriptionCard) ProtoMessage() {}
+
+func (x *ChatPrescriptionCard) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatPrescriptionCard.ProtoReflect.Descriptor instead.
+func (*ChatPrescriptionCard) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChatPrescriptionCard) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+func (x *ChatPrescriptionCard) GetPrescriptionNo() string {
+	if x != nil {
+		return x.PrescriptionNo
+	}
+	return ""
+}
+
+func (x *ChatPrescriptionCard) GetPrescriptionType() string {
+	if x != nil {
+		return x.PrescriptionType
+	}
+	return ""
+}
+
+func (x *ChatPrescriptionCard) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChatPrescriptionCard) GetTotalAmount() string {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return ""
+}
+
+func (x *ChatPrescriptionCard) GetMedicineCount() int32 {
+	if x != nil {
+		return x.MedicineCount
+	}
+	return 0
+}
+
+func (x *ChatPrescriptionCard) GetPrescriptionDate() string {
+	if x != nil {
+		return x.PrescriptionDate
+	}
+	return ""
+}
+
+// 订单卡片，发送时的订单快照
+type ChatOrderCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderNo       string                 `protobuf:"bytes,1,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TotalAmount   string                 `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	ItemCount     int32                  `protobuf:"varint,4,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"` // 首个药品名称
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatOrderCard) Reset() {
+	*x = ChatOrderCard{}
+	mi := &file_chat_v1_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatOrderCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatOrderCard) ProtoMessage() {}
+
+func (x *ChatOrderCard) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatOrderCard.ProtoReflect.Descriptor instead.
+func (*ChatOrderCard) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChatOrderCard) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *ChatOrderCard) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChatOrderCard) GetTotalAmount() string {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return ""
+}
+
+func (x *ChatOrderCard) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *ChatOrderCard) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+// 系统通知，如问诊结束
+type ChatSystemNotice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSystemNotice) Reset() {
+	*x = ChatSystemNotice{}
+	mi := &file_chat_v1_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSystemNotice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSystemNotice) ProtoMessage() {}
+
+func (x *ChatSystemNotice) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSystemNotice.ProtoReflect.Descriptor instead.
+func (*ChatSystemNotice) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChatSystemNotice) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *ChatSystemNotice) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
 type ChatRoomInfo struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	RoomId          string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
@@ -513,7 +1186,7 @@ type ChatRoomInfo struct {
 
 func (x *ChatRoomInfo) Reset() {
 	*x = ChatRoomInfo{}
-	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	mi := &file_chat_v1_chat_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -525,7 +1198,7 @@ func (x *ChatRoomInfo) String() string {
 func (*ChatRoomInfo) ProtoMessage() {}
 
 func (x *ChatRoomInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	mi := &file_chat_v1_chat_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -538,7 +1211,7 @@ func (x *ChatRoomInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatRoomInfo.ProtoReflect.Descriptor instead.
 func (*ChatRoomInfo) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{7}
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ChatRoomInfo) GetRoomId() string {
@@ -594,32 +1267,57 @@ var File_chat_v1_chat_proto protoreflect.FileDescriptor
 
 const file_chat_v1_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x12chat/v1/chat.proto\x1a\x1cgoogle/api/annotations.proto\"~\n" +
-	"\x15GetChatHistoryRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
-	"\ttarget_id\x18\x02 \x01(\x05R\btargetId\x12\x12\n" +
+	"\x12chat/v1/chat.proto\x1a\x1cgoogle/api/annotations.proto\"\xba\x01\n" +
+	"\x15GetChatHistoryRequest\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x05R\btargetId\x12\x1f\n" +
+	"\vtarget_role\x18\a \x01(\tR\n" +
+	"targetRole\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x87\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\x03R\x06cursorJ\x04\b\x01\x10\x02\"\xc3\x01\n" +
 	"\x13GetChatHistoryReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
 	"\bmessages\x18\x03 \x03(\v2\x10.ChatMessageInfoR\bmessages\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x05R\x05total\"\x9c\x01\n" +
-	"\x16SaveChatMessageRequest\x12\x17\n" +
-	"\afrom_id\x18\x01 \x01(\x05R\x06fromId\x12\x13\n" +
-	"\x05to_id\x18\x02 \x01(\x05R\x04toId\x12\x18\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\x03R\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x06 \x01(\bR\ahasMore\"\xc0\x02\n" +
+	"\x16SaveChatMessageRequest\x12\x14\n" +
+	"\x05token\x18\n" +
+	" \x01(\tR\x05token\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\x05R\x04toId\x12\x17\n" +
+	"\ato_role\x18\v \x01(\tR\x06toRole\x12\x18\n" +
 	"\acontent\x18\x03 \x01(\tR\acontent\x12!\n" +
 	"\fmessage_type\x18\x04 \x01(\tR\vmessageType\x12\x17\n" +
-	"\aroom_id\x18\x05 \x01(\tR\x06roomId\"D\n" +
+	"\aroom_id\x18\x05 \x01(\tR\x06roomId\x12 \n" +
+	"\x05image\x18\x06 \x01(\v2\n" +
+	".ChatImageR\x05image\x12 \n" +
+	"\x05voice\x18\a \x01(\v2\n" +
+	".ChatVoiceR\x05voice\x12'\n" +
+	"\x0fprescription_id\x18\b \x01(\x04R\x0eprescriptionId\x12\x19\n" +
+	"\border_no\x18\t \x01(\tR\aorderNoJ\x04\b\x01\x10\x02\"j\n" +
 	"\x14SaveChatMessageReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"2\n" +
-	"\x17GetUserChatRoomsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x05R\x06userId\"j\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
+	"\x04data\x18\x03 \x01(\v2\x10.ChatMessageInfoR\x04data\"5\n" +
+	"\x17GetUserChatRoomsRequest\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05tokenJ\x04\b\x01\x10\x02\"j\n" +
 	"\x15GetUserChatRoomsReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
-	"\x05rooms\x18\x03 \x03(\v2\r.ChatRoomInfoR\x05rooms\"\xfa\x01\n" +
+	"\x05rooms\x18\x03 \x03(\v2\r.ChatRoomInfoR\x05rooms\"\x97\x01\n" +
+	"\x13MarkChatReadRequest\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x05R\btargetId\x12\x1f\n" +
+	"\vtarget_role\x18\x05 \x01(\tR\n" +
+	"targetRole\x12&\n" +
+	"\x0flast_message_id\x18\x03 \x01(\x03R\rlastMessageIdJ\x04\b\x01\x10\x02\"Z\n" +
+	"\x11MarkChatReadReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\aread_id\x18\x03 \x01(\x03R\x06readId\"\xad\x04\n" +
 	"\x0fChatMessageInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\afrom_id\x18\x02 \x01(\x05R\x06fromId\x12\x13\n" +
@@ -630,7 +1328,45 @@ const file_chat_v1_chat_proto_rawDesc = "" +
 	"\fmessage_type\x18\a \x01(\tR\vmessageType\x12\x17\n" +
 	"\aroom_id\x18\b \x01(\tR\x06roomId\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\t \x01(\tR\tcreatedAt\"\xf8\x01\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1b\n" +
+	"\tfrom_role\x18\x10 \x01(\tR\bfromRole\x12\x17\n" +
+	"\ato_role\x18\x11 \x01(\tR\x06toRole\x12\"\n" +
+	"\x05image\x18\v \x01(\v2\n" +
+	".ChatImageH\x00R\x05image\x12\"\n" +
+	"\x05voice\x18\f \x01(\v2\n" +
+	".ChatVoiceH\x00R\x05voice\x12;\n" +
+	"\fprescription\x18\r \x01(\v2\x15.ChatPrescriptionCardH\x00R\fprescription\x12&\n" +
+	"\x05order\x18\x0e \x01(\v2\x0e.ChatOrderCardH\x00R\x05order\x12+\n" +
+	"\x06system\x18\x0f \x01(\v2\x11.ChatSystemNoticeH\x00R\x06systemB\t\n" +
+	"\apayload\"_\n" +
+	"\tChatImage\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"9\n" +
+	"\tChatVoice\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x05R\bduration\"\xa4\x02\n" +
+	"\x14ChatPrescriptionCard\x12'\n" +
+	"\x0fprescription_id\x18\x01 \x01(\x04R\x0eprescriptionId\x12'\n" +
+	"\x0fprescription_no\x18\x02 \x01(\tR\x0eprescriptionNo\x12+\n" +
+	"\x11prescription_type\x18\x03 \x01(\tR\x10prescriptionType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
+	"\ftotal_amount\x18\x05 \x01(\tR\vtotalAmount\x12%\n" +
+	"\x0emedicine_count\x18\x06 \x01(\x05R\rmedicineCount\x12+\n" +
+	"\x11prescription_date\x18\a \x01(\tR\x10prescriptionDate\"\x9a\x01\n" +
+	"\rChatOrderCard\x12\x19\n" +
+	"\border_no\x18\x01 \x01(\tR\aorderNo\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\ftotal_amount\x18\x03 \x01(\tR\vtotalAmount\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x04 \x01(\x05R\titemCount\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\"<\n" +
+	"\x10ChatSystemNotice\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\xf8\x01\n" +
 	"\fChatRoomInfo\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
 	"\ttarget_id\x18\x02 \x01(\x05R\btargetId\x12\x1f\n" +
@@ -640,11 +1376,12 @@ const file_chat_v1_chat_proto_rawDesc = "" +
 	"targetRole\x12!\n" +
 	"\flast_message\x18\x05 \x01(\tR\vlastMessage\x12*\n" +
 	"\x11last_message_time\x18\x06 \x01(\tR\x0flastMessageTime\x12!\n" +
-	"\funread_count\x18\a \x01(\x05R\vunreadCount2\x9e\x02\n" +
+	"\funread_count\x18\a \x01(\x05R\vunreadCount2\xf2\x02\n" +
 	"\x04Chat\x12X\n" +
 	"\x0eGetChatHistory\x12\x16.GetChatHistoryRequest\x1a\x14.GetChatHistoryReply\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/chat/history\x12^\n" +
 	"\x0fSaveChatMessage\x12\x17.SaveChatMessageRequest\x1a\x15.SaveChatMessageReply\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/chat/message\x12\\\n" +
-	"\x10GetUserChatRooms\x12\x18.GetUserChatRoomsRequest\x1a\x16.GetUserChatRoomsReply\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/chat/roomsBE\n" +
+	"\x10GetUserChatRooms\x12\x18.GetUserChatRoomsRequest\x1a\x16.GetUserChatRoomsReply\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/chat/rooms\x12R\n" +
+	"\fMarkChatRead\x12\x14.MarkChatReadRequest\x1a\x12.MarkChatReadReply\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/chat/readBE\n" +
 	"\x16dev.kratos.api.chat.v1B\vchatProtoV1P\x01Z\x1ckratos_client/api/chat/v1;v1b\x06proto3"
 
 var (
@@ -659,7 +1396,7 @@ func file_chat_v1_chat_proto_rawDescGZIP() []byte {
 	return file_chat_v1_chat_proto_rawDescData
 }
 
-var file_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_chat_v1_chat_proto_goTypes = []any{
 	(*GetChatHistoryRequest)(nil),   // 0: GetChatHistoryRequest
 	(*GetChatHistoryReply)(nil),     // 1: GetChatHistoryReply
@@ -667,23 +1404,40 @@ var file_chat_v1_chat_proto_goTypes = []any{
 	(*SaveChatMessageReply)(nil),    // 3: SaveChatMessageReply
 	(*GetUserChatRoomsRequest)(nil), // 4: GetUserChatRoomsRequest
 	(*GetUserChatRoomsReply)(nil),   // 5: GetUserChatRoomsReply
-	(*ChatMessageInfo)(nil),         // 6: ChatMessageInfo
-	(*ChatRoomInfo)(nil),            // 7: ChatRoomInfo
+	(*MarkChatReadRequest)(nil),     // 6: MarkChatReadRequest
+	(*MarkChatReadReply)(nil),       // 7: MarkChatReadReply
+	(*ChatMessageInfo)(nil),         // 8: ChatMessageInfo
+	(*ChatImage)(nil),               // 9: ChatImage
+	(*ChatVoice)(nil),               // 10: ChatVoice
+	(*ChatPrescriptionCard)(nil),    // 11: ChatPrescriptionCard
+	(*ChatOrderCard)(nil),           // 12: ChatOrderCard
+	(*ChatSystemNotice)(nil),        // 13: ChatSystemNotice
+	(*ChatRoomInfo)(nil),            // 14: ChatRoomInfo
 }
 var file_chat_v1_chat_proto_depIdxs = []int32{
-	6, // 0: GetChatHistoryReply.messages:type_name -> ChatMessageInfo
-	7, // 1: GetUserChatRoomsReply.rooms:type_name -> ChatRoomInfo
-	0, // 2: Chat.GetChatHistory:input_type -> GetChatHistoryRequest
-	2, // 3: Chat.SaveChatMessage:input_type -> SaveChatMessageRequest
-	4, // 4: Chat.GetUserChatRooms:input_type -> GetUserChatRoomsRequest
-	1, // 5: Chat.GetChatHistory:output_type -> GetChatHistoryReply
-	3, // 6: Chat.SaveChatMessage:output_type -> SaveChatMessageReply
-	5, // 7: Chat.GetUserChatRooms:output_type -> GetUserChatRoomsReply
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	8,  // 0: GetChatHistoryReply.messages:type_name -> ChatMessageInfo
+	9,  // 1: SaveChatMessageRequest.image:type_name -> ChatImage
+	10, // 2: SaveChatMessageRequest.voice:type_name -> ChatVoice
+	8,  // 3: SaveChatMessageReply.data:type_name -> ChatMessageInfo
+	14, // 4: GetUserChatRoomsReply.rooms:type_name -> ChatRoomInfo
+	9,  // 5: ChatMessageInfo.image:type_name -> ChatImage
+	10, // 6: ChatMessageInfo.voice:type_name -> ChatVoice
+	11, // 7: ChatMessageInfo.prescription:type_name -> ChatPrescriptionCard
+	12, // 8: ChatMessageInfo.order:type_name -> ChatOrderCard
+	13, // 9: ChatMessageInfo.system:type_name -> ChatSystemNotice
+	0,  // 10: Chat.GetChatHistory:input_type -> GetChatHistoryRequest
+	2,  // 11: Chat.SaveChatMessage:input_type -> SaveChatMessageRequest
+	4,  // 12: Chat.GetUserChatRooms:input_type -> GetUserChatRoomsRequest
+	6,  // 13: Chat.MarkChatRead:input_type -> MarkChatReadRequest
+	1,  // 14: Chat.GetChatHistory:output_type -> GetChatHistoryReply
+	3,  // 15: Chat.SaveChatMessage:output_type -> SaveChatMessageReply
+	5,  // 16: Chat.GetUserChatRooms:output_type -> GetUserChatRoomsReply
+	7,  // 17: Chat.MarkChatRead:output_type -> MarkChatReadReply
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_chat_v1_chat_proto_init() }
@@ -691,13 +1445,20 @@ func file_chat_v1_chat_proto_init() {
 	if File_chat_v1_chat_proto != nil {
 		return
 	}
+	file_chat_v1_chat_proto_msgTypes[8].OneofWrappers = []any{
+		(*ChatMessageInfo_Image)(nil),
+		(*ChatMessageInfo_Voice)(nil),
+		(*ChatMessageInfo_Prescription)(nil),
+		(*ChatMessageInfo_Order)(nil),
+		(*ChatMessageInfo_System)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_v1_chat_proto_rawDesc), len(file_chat_v1_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,11 +22,14 @@ const (
 	Chat_GetChatHistory_FullMethodName   = "/Chat/GetChatHistory"
 	Chat_SaveChatMessage_FullMethodName  = "/Chat/SaveChatMessage"
 	Chat_GetUserChatRooms_FullMethodName = "/Chat/GetUserChatRooms"
+	Chat_MarkChatRead_FullMethodName     = "/Chat/MarkChatRead"
 )
 
 // ChatClient is the client API for Chat service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 医患聊天服务
 type ChatClient interface {
 	// 获取聊天历史记录
 	GetChatHistory(ctx context.Context, in *GetChatHistoryRequest, opts ...grpc.CallOption) (*GetChatHistoryReply, error)
@@ -34,6 +37,8 @@ type ChatClient interface {
 	SaveChatMessage(ctx context.Context, in *SaveChatMessageRequest, opts ...grpc.CallOption) (*SaveChatMessageReply, error)
 	// 获取用户的聊天房间列表
 	GetUserChatRooms(ctx context.Context, in *GetUserChatRoomsRequest, opts ...grpc.CallOption) (*GetUserChatRoomsReply, error)
+	// 将与对方的会话标记为已读
+	MarkChatRead(ctx context.Context, in *MarkChatReadRequest, opts ...grpc.CallOption) (*MarkChatReadReply, error)
 }
 
 type chatClient struct {
@@ -74,9 +79,21 @@ func (c *chatClient) GetUserChatRooms(ctx context.Context, in *GetUserChatRoomsR
 	return out, nil
 }
 
+func (c *chatClient) MarkChatRead(ctx context.Context, in *MarkChatReadRequest, opts ...grpc.CallOption) (*MarkChatReadReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkChatReadReply)
+	err := c.cc.Invoke(ctx, Chat_MarkChatRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServer is the server API for Chat service.
 // All implementations must embed UnimplementedChatServer
 // for forward compatibility.
+//
+// 医患聊天服务
 type ChatServer interface {
 	// 获取聊天历史记录
 	GetChatHistory(context.Context, *GetChatHistoryRequest) (*GetChatHistoryReply, error)
@@ -84,6 +101,8 @@ type ChatServer interface {
 	SaveChatMessage(context.Context, *SaveChatMessageRequest) (*SaveChatMessageReply, error)
 	// 获取用户的聊天房间列表
 	GetUserChatRooms(context.Context, *GetUserChatRoomsRequest) (*GetUserChatRoomsReply, error)
+	// 将与对方的会话标记为已读
+	MarkChatRead(context.Context, *MarkChatReadRequest) (*MarkChatReadReply, error)
 	mustEmbedUnimplementedChatServer()
 }
 
@@ -103,6 +122,9 @@ func (UnimplementedChatServer) SaveChatMessage(context.Context, *SaveChatMessage
 func (UnimplementedChatServer) GetUserChatRooms(context.Context, *GetUserChatRoomsRequest) (*GetUserChatRoomsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserChatRooms not implemented")
 }
+func (UnimplementedChatServer) MarkChatRead(context.Context, *MarkChatReadRequest) (*MarkChatReadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkChatRead not implemented")
+}
 func (UnimplementedChatServer) mustEmbedUnimplementedChatServer() {}
 func (UnimplementedChatServer) testEmbeddedByValue()              {}
 
@@ -178,6 +200,24 @@ func _Chat_GetUserChatRooms_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Chat_MarkChatRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkChatReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).MarkChatRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_MarkChatRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).MarkChatRead(ctx, req.(*MarkChatReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Chat_ServiceDesc is the grpc.ServiceDesc for Chat service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,6 +237,10 @@ var Chat_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserChatRooms",
 			Handler:    _Chat_GetUserChatRooms_Handler,
 		},
+		{
+			MethodName: "MarkChatRead",
+			Handler:    _Chat_MarkChatRead_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chat/v1/chat.proto",
@@ -21,6 +21,7 @@ const _ = http.SupportPackageIsVersion1
 
 const OperationChatGetChatHistory = "/Chat/GetChatHistory"
 const OperationChatGetUserChatRooms = "/Chat/GetUserChatRooms"
+const OperationChatMarkChatRead = "/Chat/MarkChatRead"
 const OperationChatSaveChatMessage = "/Chat/SaveChatMessage"
 
 type ChatHTTPServer interface {
@@ -28,6 +29,8 @@ type ChatHTTPServer interface {
 	GetChatHistory(context.Context, *GetChatHistoryRequest) (*GetChatHistoryReply, error)
 	// GetUserChatRooms 获取用户的聊天房间列表
 	GetUserChatRooms(context.Context, *GetUserChatRoomsRequest) (*GetUserChatRoomsReply, error)
+	// MarkChatRead 将与对方的会话标记为已读
+	MarkChatRead(context.Context, *MarkChatReadRequest) (*MarkChatReadReply, error)
 	// SaveChatMessage 保存聊天消息
 	SaveChatMessage(context.Context, *SaveChatMessageRequest) (*SaveChatMessageReply, error)
 }
@@ -37,6 +40,7 @@ func RegisterChatHTTPServer(s *http.Server, srv ChatHTTPServer) {
 	r.GET("/v1/chat/history", _Chat_GetChatHistory0_HTTP_Handler(srv))
 	r.POST("/v1/chat/message", _Chat_SaveChatMessage0_HTTP_Handler(srv))
 	r.GET("/v1/chat/rooms", _Chat_GetUserChatRooms0_HTTP_Handler(srv))
+	r.POST("/v1/chat/read", _Chat_MarkChatRead0_HTTP_Handler(srv))
 }
 
 func _Chat_GetChatHistory0_HTTP_Handler(srv ChatHTTPServer) func(ctx http.Context) error {
@@ -99,9 +103,32 @@ func _Chat_GetUserChatRooms0_HTTP_Handler(srv ChatHTTPServer) func(ctx http.Cont
 	}
 }
 
+func _Chat_MarkChatRead0_HTTP_Handler(srv ChatHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in MarkChatReadRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationChatMarkChatRead)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.MarkChatRead(ctx, req.(*MarkChatReadRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*MarkChatReadReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type ChatHTTPClient interface {
 	GetChatHistory(ctx context.Context, req *GetChatHistoryRequest, opts ...http.CallOption) (rsp *GetChatHistoryReply, err error)
 	GetUserChatRooms(ctx context.Context, req *GetUserChatRoomsRequest, opts ...http.CallOption) (rsp *GetUserChatRoomsReply, err error)
+	MarkChatRead(ctx context.Context, req *MarkChatReadRequest, opts ...http.CallOption) (rsp *MarkChatReadReply, err error)
 	SaveChatMessage(ctx context.Context, req *SaveChatMessageRequest, opts ...http.CallOption) (rsp *SaveChatMessageReply, err error)
 }
 
@@ -139,6 +166,19 @@ func (c *ChatHTTPClientImpl) GetUserChatRooms(ctx context.Context, in *GetUserCh
 	return &out, nil
 }
 
+func (c *ChatHTTPClientImpl) MarkChatRead(ctx context.Context, in *MarkChatReadRequest, opts ...http.CallOption) (*MarkChatReadReply, error) {
+	var out MarkChatReadReply
+	pattern := "/v1/chat/read"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationChatMarkChatRead))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *ChatHTTPClientImpl) SaveChatMessage(ctx context.Context, in *SaveChatMessageRequest, opts ...http.CallOption) (*SaveChatMessageReply, error) {
 	var out SaveChatMessageReply
 	pattern := "/v1/chat/message"
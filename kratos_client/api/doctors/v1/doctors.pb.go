@@ -22,9 +22,16 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// The request message containing the user's name.
+// 医生列表查询条件，只返回启用且审核通过的医生
 type DoctorsListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	HospitalId    int32                  `protobuf:"varint,1,opt,name=hospitalId,proto3" json:"hospitalId,omitempty"`
+	DepartmentId  int32                  `protobuf:"varint,2,opt,name=departmentId,proto3" json:"departmentId,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Speciality    string                 `protobuf:"bytes,4,opt,name=speciality,proto3" json:"speciality,omitempty"` // 专业特长，模糊匹配
+	SortBy        string                 `protobuf:"bytes,5,opt,name=sortBy,proto3" json:"sortBy,omitempty"`         // 排序：rating-评分，consultations-问诊量，空为默认
+	Page          int32                  `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,7,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -59,11 +66,61 @@ func (*DoctorsListRequest) Descriptor() ([]byte, []int) {
 	return file_doctors_v1_doctors_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *DoctorsListRequest) GetHospitalId() int32 {
+	if x != nil {
+		return x.HospitalId
+	}
+	return 0
+}
+
+func (x *DoctorsListRequest) GetDepartmentId() int32 {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return 0
+}
+
+func (x *DoctorsListRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DoctorsListRequest) GetSpeciality() string {
+	if x != nil {
+		return x.Speciality
+	}
+	return ""
+}
+
+func (x *DoctorsListRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *DoctorsListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *DoctorsListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
 // The response message containing the greetings
 type DoctorsListReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	DoctorsList   []*DoctorsList         `protobuf:"bytes,2,rep,name=doctorsList,proto3" json:"doctorsList,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -112,12 +169,36 @@ func (x *DoctorsListReply) GetDoctorsList() []*DoctorsList {
 	return nil
 }
 
+func (x *DoctorsListReply) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type DoctorsList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DoctorCode    string                 `protobuf:"bytes,1,opt,name=doctorCode,proto3" json:"doctorCode,omitempty"`
-	DoctorsName   string                 `protobuf:"bytes,2,opt,name=doctorsName,proto3" json:"doctorsName,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DoctorCode        string                 `protobuf:"bytes,1,opt,name=doctorCode,proto3" json:"doctorCode,omitempty"`
+	DoctorsName       string                 `protobuf:"bytes,2,opt,name=doctorsName,proto3" json:"doctorsName,omitempty"`
+	Id                int32                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	Gender            string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
+	Avatar            string                 `protobuf:"bytes,5,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Title             string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Speciality        string                 `protobuf:"bytes,7,opt,name=speciality,proto3" json:"speciality,omitempty"`
+	PracticeScope     string                 `protobuf:"bytes,8,opt,name=practiceScope,proto3" json:"practiceScope,omitempty"`
+	HospitalId        int32                  `protobuf:"varint,9,opt,name=hospitalId,proto3" json:"hospitalId,omitempty"`
+	HospitalName      string                 `protobuf:"bytes,10,opt,name=hospitalName,proto3" json:"hospitalName,omitempty"`
+	HospitalLevel     string                 `protobuf:"bytes,11,opt,name=hospitalLevel,proto3" json:"hospitalLevel,omitempty"`
+	HospitalAddress   string                 `protobuf:"bytes,12,opt,name=hospitalAddress,proto3" json:"hospitalAddress,omitempty"`
+	DepartmentId      int32                  `protobuf:"varint,13,opt,name=departmentId,proto3" json:"departmentId,omitempty"`
+	DepartmentName    string                 `protobuf:"bytes,14,opt,name=departmentName,proto3" json:"departmentName,omitempty"`
+	ConsultationFee   string                 `protobuf:"bytes,15,opt,name=consultationFee,proto3" json:"consultationFee,omitempty"`
+	Rating            string                 `protobuf:"bytes,16,opt,name=rating,proto3" json:"rating,omitempty"`
+	RatingCount       int32                  `protobuf:"varint,17,opt,name=ratingCount,proto3" json:"ratingCount,omitempty"`
+	ConsultationCount int32                  `protobuf:"varint,18,opt,name=consultationCount,proto3" json:"consultationCount,omitempty"`
+	Online            bool                   `protobuf:"varint,19,opt,name=online,proto3" json:"online,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *DoctorsList) Reset() {
@@ -164,23 +245,278 @@ func (x *DoctorsList) GetDoctorsName() string {
 	return ""
 }
 
+func (x *DoctorsList) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DoctorsList) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetSpeciality() string {
+	if x != nil {
+		return x.Speciality
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetPracticeScope() string {
+	if x != nil {
+		return x.PracticeScope
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetHospitalId() int32 {
+	if x != nil {
+		return x.HospitalId
+	}
+	return 0
+}
+
+func (x *DoctorsList) GetHospitalName() string {
+	if x != nil {
+		return x.HospitalName
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetHospitalLevel() string {
+	if x != nil {
+		return x.HospitalLevel
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetHospitalAddress() string {
+	if x != nil {
+		return x.HospitalAddress
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetDepartmentId() int32 {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return 0
+}
+
+func (x *DoctorsList) GetDepartmentName() string {
+	if x != nil {
+		return x.DepartmentName
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetConsultationFee() string {
+	if x != nil {
+		return x.ConsultationFee
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetRating() string {
+	if x != nil {
+		return x.Rating
+	}
+	return ""
+}
+
+func (x *DoctorsList) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *DoctorsList) GetConsultationCount() int32 {
+	if x != nil {
+		return x.ConsultationCount
+	}
+	return 0
+}
+
+func (x *DoctorsList) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+type DoctorDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoctorDetailRequest) Reset() {
+	*x = DoctorDetailRequest{}
+	mi := &file_doctors_v1_doctors_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoctorDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoctorDetailRequest) ProtoMessage() {}
+
+func (x *DoctorDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctors_v1_doctors_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoctorDetailRequest.ProtoReflect.Descriptor instead.
+func (*DoctorDetailRequest) Descriptor() ([]byte, []int) {
+	return file_doctors_v1_doctors_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DoctorDetailRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DoctorDetailReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Doctor        *DoctorsList           `protobuf:"bytes,2,opt,name=doctor,proto3" json:"doctor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoctorDetailReply) Reset() {
+	*x = DoctorDetailReply{}
+	mi := &file_doctors_v1_doctors_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoctorDetailReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoctorDetailReply) ProtoMessage() {}
+
+func (x *DoctorDetailReply) ProtoReflect() protoreflect.Message {
+	mi := &file_doctors_v1_doctors_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoctorDetailReply.ProtoReflect.Descriptor instead.
+func (*DoctorDetailReply) Descriptor() ([]byte, []int) {
+	return file_doctors_v1_doctors_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DoctorDetailReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DoctorDetailReply) GetDoctor() *DoctorsList {
+	if x != nil {
+		return x.Doctor
+	}
+	return nil
+}
+
 var File_doctors_v1_doctors_proto protoreflect.FileDescriptor
 
 const file_doctors_v1_doctors_proto_rawDesc = "" +
 	"\n" +
 	"\x18doctors/v1/doctors.proto\x12\n" +
-	"doctors.v1\x1a\x1cgoogle/api/annotations.proto\"\x14\n" +
-	"\x12DoctorsListRequest\"g\n" +
+	"doctors.v1\x1a\x1cgoogle/api/annotations.proto\"\xd6\x01\n" +
+	"\x12DoctorsListRequest\x12\x1e\n" +
+	"\n" +
+	"hospitalId\x18\x01 \x01(\x05R\n" +
+	"hospitalId\x12\"\n" +
+	"\fdepartmentId\x18\x02 \x01(\x05R\fdepartmentId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1e\n" +
+	"\n" +
+	"speciality\x18\x04 \x01(\tR\n" +
+	"speciality\x12\x16\n" +
+	"\x06sortBy\x18\x05 \x01(\tR\x06sortBy\x12\x12\n" +
+	"\x04page\x18\x06 \x01(\x05R\x04page\x12\x1a\n" +
+	"\bpageSize\x18\a \x01(\x05R\bpageSize\"}\n" +
 	"\x10DoctorsListReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x129\n" +
-	"\vdoctorsList\x18\x02 \x03(\v2\x17.doctors.v1.DoctorsListR\vdoctorsList\"O\n" +
+	"\vdoctorsList\x18\x02 \x03(\v2\x17.doctors.v1.DoctorsListR\vdoctorsList\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"\xf5\x04\n" +
 	"\vDoctorsList\x12\x1e\n" +
 	"\n" +
 	"doctorCode\x18\x01 \x01(\tR\n" +
 	"doctorCode\x12 \n" +
-	"\vdoctorsName\x18\x02 \x01(\tR\vdoctorsName2r\n" +
+	"\vdoctorsName\x18\x02 \x01(\tR\vdoctorsName\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06gender\x18\x04 \x01(\tR\x06gender\x12\x16\n" +
+	"\x06avatar\x18\x05 \x01(\tR\x06avatar\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12\x1e\n" +
+	"\n" +
+	"speciality\x18\a \x01(\tR\n" +
+	"speciality\x12$\n" +
+	"\rpracticeScope\x18\b \x01(\tR\rpracticeScope\x12\x1e\n" +
+	"\n" +
+	"hospitalId\x18\t \x01(\x05R\n" +
+	"hospitalId\x12\"\n" +
+	"\fhospitalName\x18\n" +
+	" \x01(\tR\fhospitalName\x12$\n" +
+	"\rhospitalLevel\x18\v \x01(\tR\rhospitalLevel\x12(\n" +
+	"\x0fhospitalAddress\x18\f \x01(\tR\x0fhospitalAddress\x12\"\n" +
+	"\fdepartmentId\x18\r \x01(\x05R\fdepartmentId\x12&\n" +
+	"\x0edepartmentName\x18\x0e \x01(\tR\x0edepartmentName\x12(\n" +
+	"\x0fconsultationFee\x18\x0f \x01(\tR\x0fconsultationFee\x12\x16\n" +
+	"\x06rating\x18\x10 \x01(\tR\x06rating\x12 \n" +
+	"\vratingCount\x18\x11 \x01(\x05R\vratingCount\x12,\n" +
+	"\x11consultationCount\x18\x12 \x01(\x05R\x11consultationCount\x12\x16\n" +
+	"\x06online\x18\x13 \x01(\bR\x06online\"%\n" +
+	"\x13DoctorDetailRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"^\n" +
+	"\x11DoctorDetailReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12/\n" +
+	"\x06doctor\x18\x02 \x01(\v2\x17.doctors.v1.DoctorsListR\x06doctor2\xde\x01\n" +
 	"\aDoctors\x12g\n" +
-	"\vDoctorsList\x12\x1e.doctors.v1.DoctorsListRequest\x1a\x1c.doctors.v1.DoctorsListReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/DoctorsListBN\n" +
+	"\vDoctorsList\x12\x1e.doctors.v1.DoctorsListRequest\x1a\x1c.doctors.v1.DoctorsListReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/DoctorsList\x12j\n" +
+	"\fDoctorDetail\x12\x1f.doctors.v1.DoctorDetailRequest\x1a\x1d.doctors.v1.DoctorDetailReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/doctors/detailBN\n" +
 	"\x19dev.kratos.api.doctors.v1B\x0edoctorsProtoV1P\x01Z\x1fkratos_client/api/doctors/v1;v1b\x06proto3"
 
 var (
@@ -195,21 +531,26 @@ func file_doctors_v1_doctors_proto_rawDescGZIP() []byte {
 	return file_doctors_v1_doctors_proto_rawDescData
 }
 
-var file_doctors_v1_doctors_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_doctors_v1_doctors_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_doctors_v1_doctors_proto_goTypes = []any{
-	(*DoctorsListRequest)(nil), // 0: doctors.v1.DoctorsListRequest
-	(*DoctorsListReply)(nil),   // 1: doctors.v1.DoctorsListReply
-	(*DoctorsList)(nil),        // 2: doctors.v1.DoctorsList
+	(*DoctorsListRequest)(nil),  // 0: doctors.v1.DoctorsListRequest
+	(*DoctorsListReply)(nil),    // 1: doctors.v1.DoctorsListReply
+	(*DoctorsList)(nil),         // 2: doctors.v1.DoctorsList
+	(*DoctorDetailRequest)(nil), // 3: doctors.v1.DoctorDetailRequest
+	(*DoctorDetailReply)(nil),   // 4: doctors.v1.DoctorDetailReply
 }
 var file_doctors_v1_doctors_proto_depIdxs = []int32{
 	2, // 0: doctors.v1.DoctorsListReply.doctorsList:type_name -> doctors.v1.DoctorsList
-	0, // 1: doctors.v1.Doctors.DoctorsList:input_type -> doctors.v1.DoctorsListRequest
-	1, // 2: doctors.v1.Doctors.DoctorsList:output_type -> doctors.v1.DoctorsListReply
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 1: doctors.v1.DoctorDetailReply.doctor:type_name -> doctors.v1.DoctorsList
+	0, // 2: doctors.v1.Doctors.DoctorsList:input_type -> doctors.v1.DoctorsListRequest
+	3, // 3: doctors.v1.Doctors.DoctorDetail:input_type -> doctors.v1.DoctorDetailRequest
+	1, // 4: doctors.v1.Doctors.DoctorsList:output_type -> doctors.v1.DoctorsListReply
+	4, // 5: doctors.v1.Doctors.DoctorDetail:output_type -> doctors.v1.DoctorDetailReply
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_doctors_v1_doctors_proto_init() }
@@ -223,7 +564,7 @@ func file_doctors_v1_doctors_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_doctors_v1_doctors_proto_rawDesc), len(file_doctors_v1_doctors_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Doctors_DoctorsList_FullMethodName = "/doctors.v1.Doctors/DoctorsList"
+	Doctors_DoctorsList_FullMethodName  = "/doctors.v1.Doctors/DoctorsList"
+	Doctors_DoctorDetail_FullMethodName = "/doctors.v1.Doctors/DoctorDetail"
 )
 
 // DoctorsClient is the client API for Doctors service.
@@ -30,6 +31,8 @@ const (
 type DoctorsClient interface {
 	// Sends a greeting
 	DoctorsList(ctx context.Context, in *DoctorsListRequest, opts ...grpc.CallOption) (*DoctorsListReply, error)
+	// 医生详情
+	DoctorDetail(ctx context.Context, in *DoctorDetailRequest, opts ...grpc.CallOption) (*DoctorDetailReply, error)
 }
 
 type doctorsClient struct {
@@ -50,6 +53,16 @@ func (c *doctorsClient) DoctorsList(ctx context.Context, in *DoctorsListRequest,
 	return out, nil
 }
 
+func (c *doctorsClient) DoctorDetail(ctx context.Context, in *DoctorDetailRequest, opts ...grpc.CallOption) (*DoctorDetailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DoctorDetailReply)
+	err := c.cc.Invoke(ctx, Doctors_DoctorDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DoctorsServer is the server API for Doctors service.
 // All implementations must embed UnimplementedDoctorsServer
 // for forward compatibility.
@@ -58,6 +71,8 @@ func (c *doctorsClient) DoctorsList(ctx context.Context, in *DoctorsListRequest,
 type DoctorsServer interface {
 	// Sends a greeting
 	DoctorsList(context.Context, *DoctorsListRequest) (*DoctorsListReply, error)
+	// 医生详情
+	DoctorDetail(context.Context, *DoctorDetailRequest) (*DoctorDetailReply, error)
 	mustEmbedUnimplementedDoctorsServer()
 }
 
@@ -71,6 +86,9 @@ type UnimplementedDoctorsServer struct{}
 func (UnimplementedDoctorsServer) DoctorsList(context.Context, *DoctorsListRequest) (*DoctorsListReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DoctorsList not implemented")
 }
+func (UnimplementedDoctorsServer) DoctorDetail(context.Context, *DoctorDetailRequest) (*DoctorDetailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DoctorDetail not implemented")
+}
 func (UnimplementedDoctorsServer) mustEmbedUnimplementedDoctorsServer() {}
 func (UnimplementedDoctorsServer) testEmbeddedByValue()                 {}
 
@@ -110,6 +128,24 @@ func _Doctors_DoctorsList_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Doctors_DoctorDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DoctorDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoctorsServer).DoctorDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Doctors_DoctorDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoctorsServer).DoctorDetail(ctx, req.(*DoctorDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Doctors_ServiceDesc is the grpc.ServiceDesc for Doctors service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +157,10 @@ var Doctors_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DoctorsList",
 			Handler:    _Doctors_DoctorsList_Handler,
 		},
+		{
+			MethodName: "DoctorDetail",
+			Handler:    _Doctors_DoctorDetail_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "doctors/v1/doctors.proto",
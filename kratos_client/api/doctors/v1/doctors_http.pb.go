@@ -19,9 +19,12 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationDoctorsDoctorDetail = "/doctors.v1.Doctors/DoctorDetail"
 const OperationDoctorsDoctorsList = "/doctors.v1.Doctors/DoctorsList"
 
 type DoctorsHTTPServer interface {
+	// DoctorDetail 医生详情
+	DoctorDetail(context.Context, *DoctorDetailRequest) (*DoctorDetailReply, error)
 	// DoctorsList Sends a greeting
 	DoctorsList(context.Context, *DoctorsListRequest) (*DoctorsListReply, error)
 }
@@ -29,6 +32,7 @@ type DoctorsHTTPServer interface {
 func RegisterDoctorsHTTPServer(s *http.Server, srv DoctorsHTTPServer) {
 	r := s.Route("/")
 	r.POST("/v1/DoctorsList", _Doctors_DoctorsList0_HTTP_Handler(srv))
+	r.GET("/v1/doctors/detail", _Doctors_DoctorDetail0_HTTP_Handler(srv))
 }
 
 func _Doctors_DoctorsList0_HTTP_Handler(srv DoctorsHTTPServer) func(ctx http.Context) error {
@@ -53,7 +57,27 @@ func _Doctors_DoctorsList0_HTTP_Handler(srv DoctorsHTTPServer) func(ctx http.Con
 	}
 }
 
+func _Doctors_DoctorDetail0_HTTP_Handler(srv DoctorsHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DoctorDetailRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationDoctorsDoctorDetail)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DoctorDetail(ctx, req.(*DoctorDetailRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DoctorDetailReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type DoctorsHTTPClient interface {
+	DoctorDetail(ctx context.Context, req *DoctorDetailRequest, opts ...http.CallOption) (rsp *DoctorDetailReply, err error)
 	DoctorsList(ctx context.Context, req *DoctorsListRequest, opts ...http.CallOption) (rsp *DoctorsListReply, err error)
 }
 
@@ -65,6 +89,19 @@ func NewDoctorsHTTPClient(client *http.Client) DoctorsHTTPClient {
 	return &DoctorsHTTPClientImpl{client}
 }
 
+func (c *DoctorsHTTPClientImpl) DoctorDetail(ctx context.Context, in *DoctorDetailRequest, opts ...http.CallOption) (*DoctorDetailReply, error) {
+	var out DoctorDetailReply
+	pattern := "/v1/doctors/detail"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationDoctorsDoctorDetail))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *DoctorsHTTPClientImpl) DoctorsList(ctx context.Context, in *DoctorsListRequest, opts ...http.CallOption) (*DoctorsListReply, error) {
 	var out DoctorsListReply
 	pattern := "/v1/DoctorsList"
@@ -127,16 +127,17 @@ func (x *GetDrugReply) GetDrug() *InfoDrug {
 }
 
 type InfoDrug struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DrugName      string                 `protobuf:"bytes,1,opt,name=drug_name,json=drugName,proto3" json:"drug_name,omitempty"`
-	Specification string                 `protobuf:"bytes,2,opt,name=specification,proto3" json:"specification,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	SalesVolume   float64                `protobuf:"fixed64,4,opt,name=sales_volume,json=salesVolume,proto3" json:"sales_volume,omitempty"`
-	Inventory     int64                  `protobuf:"varint,5,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	ExhibitionUrl string                 `protobuf:"bytes,6,opt,name=exhibition_url,json=exhibitionUrl,proto3" json:"exhibition_url,omitempty"`
-	Guide         *GuideInfo             `protobuf:"bytes,7,opt,name=guide,proto3" json:"guide,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DrugName       string                 `protobuf:"bytes,1,opt,name=drug_name,json=drugName,proto3" json:"drug_name,omitempty"`
+	Specification  string                 `protobuf:"bytes,2,opt,name=specification,proto3" json:"specification,omitempty"`
+	Price          float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	SalesVolume    float64                `protobuf:"fixed64,4,opt,name=sales_volume,json=salesVolume,proto3" json:"sales_volume,omitempty"`
+	Inventory      int64                  `protobuf:"varint,5,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	ExhibitionUrl  string                 `protobuf:"bytes,6,opt,name=exhibition_url,json=exhibitionUrl,proto3" json:"exhibition_url,omitempty"`
+	Guide          *GuideInfo             `protobuf:"bytes,7,opt,name=guide,proto3" json:"guide,omitempty"`
+	IsPrescription bool                   `protobuf:"varint,8,opt,name=is_prescription,json=isPrescription,proto3" json:"is_prescription,omitempty"` // 是否处方药，须凭处方下单
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *InfoDrug) Reset() {
@@ -218,6 +219,13 @@ func (x *InfoDrug) GetGuide() *GuideInfo {
 	return nil
 }
 
+func (x *InfoDrug) GetIsPrescription() bool {
+	if x != nil {
+		return x.IsPrescription
+	}
+	return false
+}
+
 type GuideInfo struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	MajorFunction  string                 `protobuf:"bytes,1,opt,name=major_function,json=majorFunction,proto3" json:"major_function,omitempty"`
@@ -715,15 +723,16 @@ func (x *ListDrugReply) GetDrug() []*InfoDrugs {
 }
 
 type InfoDrugs struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DrugName      string                 `protobuf:"bytes,1,opt,name=drug_name,json=drugName,proto3" json:"drug_name,omitempty"`
-	Specification string                 `protobuf:"bytes,2,opt,name=specification,proto3" json:"specification,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	SalesVolume   float64                `protobuf:"fixed64,4,opt,name=sales_volume,json=salesVolume,proto3" json:"sales_volume,omitempty"`
-	Inventory     int64                  `protobuf:"varint,5,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	ExhibitionUrl string                 `protobuf:"bytes,6,opt,name=exhibition_url,json=exhibitionUrl,proto3" json:"exhibition_url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DrugName       string                 `protobuf:"bytes,1,opt,name=drug_name,json=drugName,proto3" json:"drug_name,omitempty"`
+	Specification  string                 `protobuf:"bytes,2,opt,name=specification,proto3" json:"specification,omitempty"`
+	Price          float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	SalesVolume    float64                `protobuf:"fixed64,4,opt,name=sales_volume,json=salesVolume,proto3" json:"sales_volume,omitempty"`
+	Inventory      int64                  `protobuf:"varint,5,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	ExhibitionUrl  string                 `protobuf:"bytes,6,opt,name=exhibition_url,json=exhibitionUrl,proto3" json:"exhibition_url,omitempty"`
+	IsPrescription bool                   `protobuf:"varint,7,opt,name=is_prescription,json=isPrescription,proto3" json:"is_prescription,omitempty"` // 是否处方药
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *InfoDrugs) Reset() {
@@ -798,6 +807,13 @@ func (x *InfoDrugs) GetExhibitionUrl() string {
 	return ""
 }
 
+func (x *InfoDrugs) GetIsPrescription() bool {
+	if x != nil {
+		return x.IsPrescription
+	}
+	return false
+}
+
 type GetGuideRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -915,6 +931,8 @@ type SearchDrugsRequest struct {
 	SortBy              string                 `protobuf:"bytes,8,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
 	PriceMin            float64                `protobuf:"fixed64,9,opt,name=price_min,json=priceMin,proto3" json:"price_min,omitempty"`
 	PriceMax            float64                `protobuf:"fixed64,10,opt,name=price_max,json=priceMax,proto3" json:"price_max,omitempty"`
+	Token               string                 `protobuf:"bytes,11,opt,name=token,proto3" json:"token,omitempty"`                  // 可选，登录用户按所选城市计入城市热搜
+	CityId              int32                  `protobuf:"varint,12,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"` // 可选，指定计入热搜的城市，优先于用户所选城市
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -1019,15 +1037,30 @@ func (x *SearchDrugsRequest) GetPriceMax() float64 {
 	return 0
 }
 
+func (x *SearchDrugsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SearchDrugsRequest) GetCityId() int32 {
+	if x != nil {
+		return x.CityId
+	}
+	return 0
+}
+
 type SearchDrugsReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	Drugs         []*SearchDrugInfo      `protobuf:"bytes,3,rep,name=drugs,proto3" json:"drugs,omitempty"`
-	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	Facets        *SearchFacets          `protobuf:"bytes,5,opt,name=facets,proto3" json:"facets,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg              string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Drugs            []*SearchDrugInfo      `protobuf:"bytes,3,rep,name=drugs,proto3" json:"drugs,omitempty"`
+	Total            int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Facets           *SearchFacets          `protobuf:"bytes,5,opt,name=facets,proto3" json:"facets,omitempty"`
+	CorrectedKeyword string                 `protobuf:"bytes,6,opt,name=corrected_keyword,json=correctedKeyword,proto3" json:"corrected_keyword,omitempty"` // 原关键词无结果时纠错后实际搜索的关键词，为空表示未纠错
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *SearchDrugsReply) Reset() {
@@ -1095,6 +1128,13 @@ func (x *SearchDrugsReply) GetFacets() *SearchFacets {
 	return nil
 }
 
+func (x *SearchDrugsReply) GetCorrectedKeyword() string {
+	if x != nil {
+		return x.CorrectedKeyword
+	}
+	return ""
+}
+
 type SearchDrugInfo struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1419,28 +1459,30 @@ func (x *ManufacturerFacet) GetCount() int64 {
 	return 0
 }
 
-// 热门搜索相关消息
-type GetHotSearchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// 搜索联想相关消息
+type SuggestDrugsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Keyword             string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	Limit               int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                                        // 默认10，最多20
+	IncludePrescription bool                   `protobuf:"varint,3,opt,name=include_prescription,json=includePrescription,proto3" json:"include_prescription,omitempty"` // 是否联想处方药，默认不联想
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *GetHotSearchRequest) Reset() {
-	*x = GetHotSearchRequest{}
+func (x *SuggestDrugsRequest) Reset() {
+	*x = SuggestDrugsRequest{}
 	mi := &file_drug_v1_drug_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHotSearchRequest) String() string {
+func (x *SuggestDrugsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHotSearchRequest) ProtoMessage() {}
+func (*SuggestDrugsRequest) ProtoMessage() {}
 
-func (x *GetHotSearchRequest) ProtoReflect() protoreflect.Message {
+func (x *SuggestDrugsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1452,43 +1494,55 @@ func (x *GetHotSearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHotSearchRequest.ProtoReflect.Descriptor instead.
-func (*GetHotSearchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SuggestDrugsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestDrugsRequest) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetHotSearchRequest) GetLimit() int32 {
+func (x *SuggestDrugsRequest) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SuggestDrugsRequest) GetLimit() int32 {
 	if x != nil {
 		return x.Limit
 	}
 	return 0
 }
 
-type GetHotSearchReply struct {
+func (x *SuggestDrugsRequest) GetIncludePrescription() bool {
+	if x != nil {
+		return x.IncludePrescription
+	}
+	return false
+}
+
+type SuggestDrugsReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	HotKeywords   []*HotItem             `protobuf:"bytes,3,rep,name=hot_keywords,json=hotKeywords,proto3" json:"hot_keywords,omitempty"`
-	HotSymptoms   []*HotItem             `protobuf:"bytes,4,rep,name=hot_symptoms,json=hotSymptoms,proto3" json:"hot_symptoms,omitempty"`
-	HotQuestions  []*HotItem             `protobuf:"bytes,5,rep,name=hot_questions,json=hotQuestions,proto3" json:"hot_questions,omitempty"`
+	Suggestions   []*DrugSuggestion      `protobuf:"bytes,3,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHotSearchReply) Reset() {
-	*x = GetHotSearchReply{}
+func (x *SuggestDrugsReply) Reset() {
+	*x = SuggestDrugsReply{}
 	mi := &file_drug_v1_drug_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHotSearchReply) String() string {
+func (x *SuggestDrugsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHotSearchReply) ProtoMessage() {}
+func (*SuggestDrugsReply) ProtoMessage() {}
 
-func (x *GetHotSearchReply) ProtoReflect() protoreflect.Message {
+func (x *SuggestDrugsReply) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1500,69 +1554,55 @@ func (x *GetHotSearchReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHotSearchReply.ProtoReflect.Descriptor instead.
-func (*GetHotSearchReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use SuggestDrugsReply.ProtoReflect.Descriptor instead.
+func (*SuggestDrugsReply) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetHotSearchReply) GetCode() int64 {
+func (x *SuggestDrugsReply) GetCode() int64 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *GetHotSearchReply) GetMsg() string {
+func (x *SuggestDrugsReply) GetMsg() string {
 	if x != nil {
 		return x.Msg
 	}
 	return ""
 }
 
-func (x *GetHotSearchReply) GetHotKeywords() []*HotItem {
-	if x != nil {
-		return x.HotKeywords
-	}
-	return nil
-}
-
-func (x *GetHotSearchReply) GetHotSymptoms() []*HotItem {
-	if x != nil {
-		return x.HotSymptoms
-	}
-	return nil
-}
-
-func (x *GetHotSearchReply) GetHotQuestions() []*HotItem {
+func (x *SuggestDrugsReply) GetSuggestions() []*DrugSuggestion {
 	if x != nil {
-		return x.HotQuestions
+		return x.Suggestions
 	}
 	return nil
 }
 
-type HotItem struct {
+type DrugSuggestion struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`                    // 联想词
+	DrugId        int64                  `protobuf:"varint,2,opt,name=drug_id,json=drugId,proto3" json:"drug_id,omitempty"` // 对应的药品ID
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`                // 来源：drug_name-药品名称，synonym-通用名或商品名
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HotItem) Reset() {
-	*x = HotItem{}
+func (x *DrugSuggestion) Reset() {
+	*x = DrugSuggestion{}
 	mi := &file_drug_v1_drug_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HotItem) String() string {
+func (x *DrugSuggestion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HotItem) ProtoMessage() {}
+func (*DrugSuggestion) ProtoMessage() {}
 
-func (x *HotItem) ProtoReflect() protoreflect.Message {
+func (x *DrugSuggestion) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1574,56 +1614,56 @@ func (x *HotItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HotItem.ProtoReflect.Descriptor instead.
-func (*HotItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use DrugSuggestion.ProtoReflect.Descriptor instead.
+func (*DrugSuggestion) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *HotItem) GetContent() string {
+func (x *DrugSuggestion) GetText() string {
 	if x != nil {
-		return x.Content
+		return x.Text
 	}
 	return ""
 }
 
-func (x *HotItem) GetCount() int64 {
+func (x *DrugSuggestion) GetDrugId() int64 {
 	if x != nil {
-		return x.Count
+		return x.DrugId
 	}
 	return 0
 }
 
-func (x *HotItem) GetScore() float64 {
+func (x *DrugSuggestion) GetSource() string {
 	if x != nil {
-		return x.Score
+		return x.Source
 	}
-	return 0
+	return ""
 }
 
-// 处方药相关消息
-type CreatePrescriptionRequest struct {
+// 症状推荐相关消息
+type RecommendBySymptomsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	ExpiryDate    string                 `protobuf:"bytes,3,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	Symptoms      []string               `protobuf:"bytes,1,rep,name=symptoms,proto3" json:"symptoms,omitempty"`       // 症状，如热门搜索中的症状，最多5个
+	Populations   []string               `protobuf:"bytes,2,rep,name=populations,proto3" json:"populations,omitempty"` // 可选，用药人所属人群：pregnancy孕妇 lactation哺乳期 child儿童 elderly老人，对其禁用的药品不推荐
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`            // 默认10，最多30
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePrescriptionRequest) Reset() {
-	*x = CreatePrescriptionRequest{}
+func (x *RecommendBySymptomsRequest) Reset() {
+	*x = RecommendBySymptomsRequest{}
 	mi := &file_drug_v1_drug_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePrescriptionRequest) String() string {
+func (x *RecommendBySymptomsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePrescriptionRequest) ProtoMessage() {}
+func (*RecommendBySymptomsRequest) ProtoMessage() {}
 
-func (x *CreatePrescriptionRequest) ProtoReflect() protoreflect.Message {
+func (x *RecommendBySymptomsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1635,55 +1675,57 @@ func (x *CreatePrescriptionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePrescriptionRequest.ProtoReflect.Descriptor instead.
-func (*CreatePrescriptionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RecommendBySymptomsRequest.ProtoReflect.Descriptor instead.
+func (*RecommendBySymptomsRequest) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *CreatePrescriptionRequest) GetToken() string {
+func (x *RecommendBySymptomsRequest) GetSymptoms() []string {
 	if x != nil {
-		return x.Token
+		return x.Symptoms
 	}
-	return ""
+	return nil
 }
 
-func (x *CreatePrescriptionRequest) GetImageUrl() string {
+func (x *RecommendBySymptomsRequest) GetPopulations() []string {
 	if x != nil {
-		return x.ImageUrl
+		return x.Populations
 	}
-	return ""
+	return nil
 }
 
-func (x *CreatePrescriptionRequest) GetExpiryDate() string {
+func (x *RecommendBySymptomsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.ExpiryDate
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type CreatePrescriptionReply struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Code           int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Msg            string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	PrescriptionId int64                  `protobuf:"varint,3,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type RecommendBySymptomsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Symptoms      []*MatchedSymptom      `protobuf:"bytes,3,rep,name=symptoms,proto3" json:"symptoms,omitempty"`
+	Drugs         []*SymptomDrug         `protobuf:"bytes,4,rep,name=drugs,proto3" json:"drugs,omitempty"`
+	Consult       *ConsultDoctorAction   `protobuf:"bytes,5,opt,name=consult,proto3" json:"consult,omitempty"` // 问诊入口，始终返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePrescriptionReply) Reset() {
-	*x = CreatePrescriptionReply{}
+func (x *RecommendBySymptomsReply) Reset() {
+	*x = RecommendBySymptomsReply{}
 	mi := &file_drug_v1_drug_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePrescriptionReply) String() string {
+func (x *RecommendBySymptomsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePrescriptionReply) ProtoMessage() {}
+func (*RecommendBySymptomsReply) ProtoMessage() {}
 
-func (x *CreatePrescriptionReply) ProtoReflect() protoreflect.Message {
+func (x *RecommendBySymptomsReply) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1695,55 +1737,70 @@ func (x *CreatePrescriptionReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePrescriptionReply.ProtoReflect.Descriptor instead.
-func (*CreatePrescriptionReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RecommendBySymptomsReply.ProtoReflect.Descriptor instead.
+func (*RecommendBySymptomsReply) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *CreatePrescriptionReply) GetCode() int64 {
+func (x *RecommendBySymptomsReply) GetCode() int64 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *CreatePrescriptionReply) GetMsg() string {
+func (x *RecommendBySymptomsReply) GetMsg() string {
 	if x != nil {
 		return x.Msg
 	}
 	return ""
 }
 
-func (x *CreatePrescriptionReply) GetPrescriptionId() int64 {
+func (x *RecommendBySymptomsReply) GetSymptoms() []*MatchedSymptom {
 	if x != nil {
-		return x.PrescriptionId
+		return x.Symptoms
 	}
-	return 0
+	return nil
 }
 
-type ListPrescriptionsRequest struct {
+func (x *RecommendBySymptomsReply) GetDrugs() []*SymptomDrug {
+	if x != nil {
+		return x.Drugs
+	}
+	return nil
+}
+
+func (x *RecommendBySymptomsReply) GetConsult() *ConsultDoctorAction {
+	if x != nil {
+		return x.Consult
+	}
+	return nil
+}
+
+type MatchedSymptom struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Curated       bool                   `protobuf:"varint,2,opt,name=curated,proto3" json:"curated,omitempty"`                               // 是否为后台维护的症状，否则仅按原文匹配功能主治
+	DepartmentId  int32                  `protobuf:"varint,3,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"` // 建议就诊科室
+	Advice        string                 `protobuf:"bytes,4,opt,name=advice,proto3" json:"advice,omitempty"`                                  // 就医提示
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPrescriptionsRequest) Reset() {
-	*x = ListPrescriptionsRequest{}
+func (x *MatchedSymptom) Reset() {
+	*x = MatchedSymptom{}
 	mi := &file_drug_v1_drug_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPrescriptionsRequest) String() string {
+func (x *MatchedSymptom) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPrescriptionsRequest) ProtoMessage() {}
+func (*MatchedSymptom) ProtoMessage() {}
 
-func (x *ListPrescriptionsRequest) ProtoReflect() protoreflect.Message {
+func (x *MatchedSymptom) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1755,56 +1812,64 @@ func (x *ListPrescriptionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPrescriptionsRequest.ProtoReflect.Descriptor instead.
-func (*ListPrescriptionsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MatchedSymptom.ProtoReflect.Descriptor instead.
+func (*MatchedSymptom) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ListPrescriptionsRequest) GetToken() string {
+func (x *MatchedSymptom) GetName() string {
 	if x != nil {
-		return x.Token
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ListPrescriptionsRequest) GetPage() int32 {
+func (x *MatchedSymptom) GetCurated() bool {
 	if x != nil {
-		return x.Page
+		return x.Curated
 	}
-	return 0
+	return false
 }
 
-func (x *ListPrescriptionsRequest) GetSize() int32 {
+func (x *MatchedSymptom) GetDepartmentId() int32 {
 	if x != nil {
-		return x.Size
+		return x.DepartmentId
 	}
 	return 0
 }
 
-type ListPrescriptionsReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	Prescriptions []*PrescriptionInfo    `protobuf:"bytes,3,rep,name=prescriptions,proto3" json:"prescriptions,omitempty"`
-	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MatchedSymptom) GetAdvice() string {
+	if x != nil {
+		return x.Advice
+	}
+	return ""
 }
 
-func (x *ListPrescriptionsReply) Reset() {
-	*x = ListPrescriptionsReply{}
+type SymptomDrug struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Drug            *SearchDrugInfo        `protobuf:"bytes,1,opt,name=drug,proto3" json:"drug,omitempty"`
+	Score           int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	MatchedSymptoms []string               `protobuf:"bytes,3,rep,name=matched_symptoms,json=matchedSymptoms,proto3" json:"matched_symptoms,omitempty"`
+	Indication      string                 `protobuf:"bytes,4,opt,name=indication,proto3" json:"indication,omitempty"` // 命中的功能主治摘录
+	Warnings        []*DrugWarning         `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`     // 人群禁忌提示
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SymptomDrug) Reset() {
+	*x = SymptomDrug{}
 	mi := &file_drug_v1_drug_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPrescriptionsReply) String() string {
+func (x *SymptomDrug) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPrescriptionsReply) ProtoMessage() {}
+func (*SymptomDrug) ProtoMessage() {}
 
-func (x *ListPrescriptionsReply) ProtoReflect() protoreflect.Message {
+func (x *SymptomDrug) ProtoReflect() protoreflect.Message {
 	mi := &file_drug_v1_drug_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1816,43 +1881,581 @@ func (x *ListPrescriptionsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPrescriptionsReply.ProtoReflect.Descriptor instead.
-func (*ListPrescriptionsReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymptomDrug.ProtoReflect.Descriptor instead.
+func (*SymptomDrug) Descriptor() ([]byte, []int) {
 	return file_drug_v1_drug_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *ListPrescriptionsReply) GetCode() int64 {
+func (x *SymptomDrug) GetDrug() *SearchDrugInfo {
 	if x != nil {
-		return x.Code
+		return x.Drug
 	}
-	return 0
+	return nil
 }
 
-func (x *ListPrescriptionsReply) GetMsg() string {
+func (x *SymptomDrug) GetScore() int32 {
 	if x != nil {
-		return x.Msg
+		return x.Score
 	}
-	return ""
+	return 0
 }
 
-func (x *ListPrescriptionsReply) GetPrescriptions() []*PrescriptionInfo {
+func (x *SymptomDrug) GetMatchedSymptoms() []string {
 	if x != nil {
-		return x.Prescriptions
+		return x.MatchedSymptoms
 	}
 	return nil
 }
 
-func (x *ListPrescriptionsReply) GetTotal() int64 {
+func (x *SymptomDrug) GetIndication() string {
 	if x != nil {
-		return x.Total
+		return x.Indication
 	}
-	return 0
+	return ""
 }
 
-type PrescriptionInfo struct {
+func (x *SymptomDrug) GetWarnings() []*DrugWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// 问诊入口，按 department_id 请求医生列表
+type ConsultDoctorAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                      // 医生列表接口
+	DepartmentId  int32                  `protobuf:"varint,3,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"` // 为0时不按科室筛选
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsultDoctorAction) Reset() {
+	*x = ConsultDoctorAction{}
+	mi := &file_drug_v1_drug_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsultDoctorAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsultDoctorAction) ProtoMessage() {}
+
+func (x *ConsultDoctorAction) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsultDoctorAction.ProtoReflect.Descriptor instead.
+func (*ConsultDoctorAction) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConsultDoctorAction) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ConsultDoctorAction) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ConsultDoctorAction) GetDepartmentId() int32 {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return 0
+}
+
+// 热门搜索相关消息
+type GetHotSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`                // 统计窗口：day-最近24小时（默认），week-最近7天
+	CityId        int32                  `protobuf:"varint,3,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"` // 可选，城市热搜，优先于用户所选城市
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`                  // 可选，登录用户默认查看所选城市的热搜
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHotSearchRequest) Reset() {
+	*x = GetHotSearchRequest{}
+	mi := &file_drug_v1_drug_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHotSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHotSearchRequest) ProtoMessage() {}
+
+func (x *GetHotSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHotSearchRequest.ProtoReflect.Descriptor instead.
+func (*GetHotSearchRequest) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetHotSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetHotSearchRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *GetHotSearchRequest) GetCityId() int32 {
+	if x != nil {
+		return x.CityId
+	}
+	return 0
+}
+
+func (x *GetHotSearchRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetHotSearchReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	HotKeywords   []*HotItem             `protobuf:"bytes,3,rep,name=hot_keywords,json=hotKeywords,proto3" json:"hot_keywords,omitempty"`
+	HotSymptoms   []*HotItem             `protobuf:"bytes,4,rep,name=hot_symptoms,json=hotSymptoms,proto3" json:"hot_symptoms,omitempty"`
+	HotQuestions  []*HotItem             `protobuf:"bytes,5,rep,name=hot_questions,json=hotQuestions,proto3" json:"hot_questions,omitempty"`
+	Window        string                 `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`
+	CityId        int32                  `protobuf:"varint,7,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"` // 榜单所属城市，0表示全国；城市暂无数据时返回全国榜单
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHotSearchReply) Reset() {
+	*x = GetHotSearchReply{}
+	mi := &file_drug_v1_drug_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHotSearchReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHotSearchReply) ProtoMessage() {}
+
+func (x *GetHotSearchReply) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHotSearchReply.ProtoReflect.Descriptor instead.
+func (*GetHotSearchReply) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetHotSearchReply) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetHotSearchReply) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *GetHotSearchReply) GetHotKeywords() []*HotItem {
+	if x != nil {
+		return x.HotKeywords
+	}
+	return nil
+}
+
+func (x *GetHotSearchReply) GetHotSymptoms() []*HotItem {
+	if x != nil {
+		return x.HotSymptoms
+	}
+	return nil
+}
+
+func (x *GetHotSearchReply) GetHotQuestions() []*HotItem {
+	if x != nil {
+		return x.HotQuestions
+	}
+	return nil
+}
+
+func (x *GetHotSearchReply) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *GetHotSearchReply) GetCityId() int32 {
+	if x != nil {
+		return x.CityId
+	}
+	return 0
+}
+
+type HotItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HotItem) Reset() {
+	*x = HotItem{}
+	mi := &file_drug_v1_drug_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotItem) ProtoMessage() {}
+
+func (x *HotItem) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotItem.ProtoReflect.Descriptor instead.
+func (*HotItem) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *HotItem) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *HotItem) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *HotItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// 处方药相关消息
+type CreatePrescriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	ExpiryDate    string                 `protobuf:"bytes,3,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePrescriptionRequest) Reset() {
+	*x = CreatePrescriptionRequest{}
+	mi := &file_drug_v1_drug_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePrescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePrescriptionRequest) ProtoMessage() {}
+
+func (x *CreatePrescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePrescriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePrescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreatePrescriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreatePrescriptionRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *CreatePrescriptionRequest) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+type CreatePrescriptionReply struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg            string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	PrescriptionId int64                  `protobuf:"varint,3,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreatePrescriptionReply) Reset() {
+	*x = CreatePrescriptionReply{}
+	mi := &file_drug_v1_drug_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePrescriptionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePrescriptionReply) ProtoMessage() {}
+
+func (x *CreatePrescriptionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePrescriptionReply.ProtoReflect.Descriptor instead.
+func (*CreatePrescriptionReply) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreatePrescriptionReply) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CreatePrescriptionReply) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *CreatePrescriptionReply) GetPrescriptionId() int64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+type ListPrescriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPrescriptionsRequest) Reset() {
+	*x = ListPrescriptionsRequest{}
+	mi := &file_drug_v1_drug_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPrescriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrescriptionsRequest) ProtoMessage() {}
+
+func (x *ListPrescriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrescriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPrescriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListPrescriptionsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListPrescriptionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPrescriptionsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListPrescriptionsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Prescriptions []*PrescriptionInfo    `protobuf:"bytes,3,rep,name=prescriptions,proto3" json:"prescriptions,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPrescriptionsReply) Reset() {
+	*x = ListPrescriptionsReply{}
+	mi := &file_drug_v1_drug_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPrescriptionsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrescriptionsReply) ProtoMessage() {}
+
+func (x *ListPrescriptionsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrescriptionsReply.ProtoReflect.Descriptor instead.
+func (*ListPrescriptionsReply) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListPrescriptionsReply) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ListPrescriptionsReply) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *ListPrescriptionsReply) GetPrescriptions() []*PrescriptionInfo {
+	if x != nil {
+		return x.Prescriptions
+	}
+	return nil
+}
+
+func (x *ListPrescriptionsReply) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type PrescriptionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
 	Status        int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
 	StatusText    string                 `protobuf:"bytes,4,opt,name=status_text,json=statusText,proto3" json:"status_text,omitempty"`
 	RejectReason  string                 `protobuf:"bytes,5,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
@@ -1864,7 +2467,7 @@ type PrescriptionInfo struct {
 
 func (x *PrescriptionInfo) Reset() {
 	*x = PrescriptionInfo{}
-	mi := &file_drug_v1_drug_proto_msgTypes[26]
+	mi := &file_drug_v1_drug_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1876,7 +2479,7 @@ func (x *PrescriptionInfo) String() string {
 func (*PrescriptionInfo) ProtoMessage() {}
 
 func (x *PrescriptionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_drug_v1_drug_proto_msgTypes[26]
+	mi := &file_drug_v1_drug_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1889,7 +2492,7 @@ func (x *PrescriptionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrescriptionInfo.ProtoReflect.Descriptor instead.
 func (*PrescriptionInfo) Descriptor() ([]byte, []int) {
-	return file_drug_v1_drug_proto_rawDescGZIP(), []int{26}
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *PrescriptionInfo) GetId() int64 {
@@ -1952,7 +2555,7 @@ type GetInventoryRequest struct {
 
 func (x *GetInventoryRequest) Reset() {
 	*x = GetInventoryRequest{}
-	mi := &file_drug_v1_drug_proto_msgTypes[27]
+	mi := &file_drug_v1_drug_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1964,7 +2567,7 @@ func (x *GetInventoryRequest) String() string {
 func (*GetInventoryRequest) ProtoMessage() {}
 
 func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_drug_v1_drug_proto_msgTypes[27]
+	mi := &file_drug_v1_drug_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1977,7 +2580,7 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
 func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_drug_v1_drug_proto_rawDescGZIP(), []int{27}
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetInventoryRequest) GetDrugId() int64 {
@@ -2005,7 +2608,7 @@ type GetInventoryReply struct {
 
 func (x *GetInventoryReply) Reset() {
 	*x = GetInventoryReply{}
-	mi := &file_drug_v1_drug_proto_msgTypes[28]
+	mi := &file_drug_v1_drug_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2017,7 +2620,7 @@ func (x *GetInventoryReply) String() string {
 func (*GetInventoryReply) ProtoMessage() {}
 
 func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_drug_v1_drug_proto_msgTypes[28]
+	mi := &file_drug_v1_drug_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2030,7 +2633,7 @@ func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
 func (*GetInventoryReply) Descriptor() ([]byte, []int) {
-	return file_drug_v1_drug_proto_rawDescGZIP(), []int{28}
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetInventoryReply) GetCode() int64 {
@@ -2058,18 +2661,19 @@ type InventoryInfo struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	DrugId         int64                  `protobuf:"varint,1,opt,name=drug_id,json=drugId,proto3" json:"drug_id,omitempty"`
 	DrugStoreId    int32                  `protobuf:"varint,2,opt,name=drug_store_id,json=drugStoreId,proto3" json:"drug_store_id,omitempty"`
-	Quantity       int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	ReservedQty    int64                  `protobuf:"varint,4,opt,name=reserved_qty,json=reservedQty,proto3" json:"reserved_qty,omitempty"`
+	Quantity       int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`                          // 在库数量，含已预留
+	ReservedQty    int64                  `protobuf:"varint,4,opt,name=reserved_qty,json=reservedQty,proto3" json:"reserved_qty,omitempty"` // 已预留数量（已下单未支付）
 	AlertThreshold int64                  `protobuf:"varint,5,opt,name=alert_threshold,json=alertThreshold,proto3" json:"alert_threshold,omitempty"`
 	Price          float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
 	Status         int32                  `protobuf:"varint,7,opt,name=status,proto3" json:"status,omitempty"`
+	AvailableQty   int64                  `protobuf:"varint,8,opt,name=available_qty,json=availableQty,proto3" json:"available_qty,omitempty"` // 可售数量 = 在库数量 - 已预留数量
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *InventoryInfo) Reset() {
 	*x = InventoryInfo{}
-	mi := &file_drug_v1_drug_proto_msgTypes[29]
+	mi := &file_drug_v1_drug_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2081,7 +2685,7 @@ func (x *InventoryInfo) String() string {
 func (*InventoryInfo) ProtoMessage() {}
 
 func (x *InventoryInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_drug_v1_drug_proto_msgTypes[29]
+	mi := &file_drug_v1_drug_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2094,7 +2698,7 @@ func (x *InventoryInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryInfo.ProtoReflect.Descriptor instead.
 func (*InventoryInfo) Descriptor() ([]byte, []int) {
-	return file_drug_v1_drug_proto_rawDescGZIP(), []int{29}
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *InventoryInfo) GetDrugId() int64 {
@@ -2146,18 +2750,26 @@ func (x *InventoryInfo) GetStatus() int32 {
 	return 0
 }
 
+func (x *InventoryInfo) GetAvailableQty() int64 {
+	if x != nil {
+		return x.AvailableQty
+	}
+	return 0
+}
+
 type UpdateInventoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DrugId        int64                  `protobuf:"varint,1,opt,name=drug_id,json=drugId,proto3" json:"drug_id,omitempty"`
 	DrugStoreId   int32                  `protobuf:"varint,2,opt,name=drug_store_id,json=drugStoreId,proto3" json:"drug_store_id,omitempty"`
-	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"` // 盘点后的在库数量，不能低于已预留数量
+	Remark        string                 `protobuf:"bytes,4,opt,name=remark,proto3" json:"remark,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateInventoryRequest) Reset() {
 	*x = UpdateInventoryRequest{}
-	mi := &file_drug_v1_drug_proto_msgTypes[30]
+	mi := &file_drug_v1_drug_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2169,7 +2781,7 @@ func (x *UpdateInventoryRequest) String() string {
 func (*UpdateInventoryRequest) ProtoMessage() {}
 
 func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_drug_v1_drug_proto_msgTypes[30]
+	mi := &file_drug_v1_drug_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2182,7 +2794,7 @@ func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateInventoryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_drug_v1_drug_proto_rawDescGZIP(), []int{30}
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *UpdateInventoryRequest) GetDrugId() int64 {
@@ -2206,17 +2818,25 @@ func (x *UpdateInventoryRequest) GetQuantity() int64 {
 	return 0
 }
 
+func (x *UpdateInventoryRequest) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
 type UpdateInventoryReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Inventory     *InventoryInfo         `protobuf:"bytes,3,opt,name=inventory,proto3" json:"inventory,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateInventoryReply) Reset() {
 	*x = UpdateInventoryReply{}
-	mi := &file_drug_v1_drug_proto_msgTypes[31]
+	mi := &file_drug_v1_drug_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2228,7 +2848,7 @@ func (x *UpdateInventoryReply) String() string {
 func (*UpdateInventoryReply) ProtoMessage() {}
 
 func (x *UpdateInventoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_drug_v1_drug_proto_msgTypes[31]
+	mi := &file_drug_v1_drug_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2241,7 +2861,7 @@ func (x *UpdateInventoryReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateInventoryReply.ProtoReflect.Descriptor instead.
 func (*UpdateInventoryReply) Descriptor() ([]byte, []int) {
-	return file_drug_v1_drug_proto_rawDescGZIP(), []int{31}
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *UpdateInventoryReply) GetCode() int64 {
@@ -2258,6 +2878,242 @@ func (x *UpdateInventoryReply) GetMsg() string {
 	return ""
 }
 
+func (x *UpdateInventoryReply) GetInventory() *InventoryInfo {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+type CheckDrugInteractionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DrugIds       []int64                `protobuf:"varint,1,rep,packed,name=drug_ids,json=drugIds,proto3" json:"drug_ids,omitempty"`
+	Populations   []string               `protobuf:"bytes,2,rep,name=populations,proto3" json:"populations,omitempty"` // 用药人所属人群：pregnancy孕妇 lactation哺乳期 child儿童 elderly老人
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDrugInteractionsRequest) Reset() {
+	*x = CheckDrugInteractionsRequest{}
+	mi := &file_drug_v1_drug_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDrugInteractionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDrugInteractionsRequest) ProtoMessage() {}
+
+func (x *CheckDrugInteractionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDrugInteractionsRequest.ProtoReflect.Descriptor instead.
+func (*CheckDrugInteractionsRequest) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CheckDrugInteractionsRequest) GetDrugIds() []int64 {
+	if x != nil {
+		return x.DrugIds
+	}
+	return nil
+}
+
+func (x *CheckDrugInteractionsRequest) GetPopulations() []string {
+	if x != nil {
+		return x.Populations
+	}
+	return nil
+}
+
+type CheckDrugInteractionsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Blocked       bool                   `protobuf:"varint,3,opt,name=blocked,proto3" json:"blocked,omitempty"` // 存在拦截级别的提示时不能开方或下单
+	Warnings      []*DrugWarning         `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDrugInteractionsReply) Reset() {
+	*x = CheckDrugInteractionsReply{}
+	mi := &file_drug_v1_drug_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDrugInteractionsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDrugInteractionsReply) ProtoMessage() {}
+
+func (x *CheckDrugInteractionsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDrugInteractionsReply.ProtoReflect.Descriptor instead.
+func (*CheckDrugInteractionsReply) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CheckDrugInteractionsReply) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CheckDrugInteractionsReply) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *CheckDrugInteractionsReply) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *CheckDrugInteractionsReply) GetWarnings() []*DrugWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// 用药提示，购物车、处方和订单共用
+type DrugWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`          // interaction相互作用 contraindication人群禁忌
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`        // 相互作用：minor/moderate/major；人群禁忌：caution慎用/forbidden禁用
+	Blocking      bool                   `protobuf:"varint,3,opt,name=blocking,proto3" json:"blocking,omitempty"` // 严重相互作用和禁用为true
+	DrugIds       []int64                `protobuf:"varint,4,rep,packed,name=drug_ids,json=drugIds,proto3" json:"drug_ids,omitempty"`
+	DrugNames     []string               `protobuf:"bytes,5,rep,name=drug_names,json=drugNames,proto3" json:"drug_names,omitempty"`
+	Population    string                 `protobuf:"bytes,6,opt,name=population,proto3" json:"population,omitempty"` // 人群禁忌对应的人群
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Advice        string                 `protobuf:"bytes,8,opt,name=advice,proto3" json:"advice,omitempty"`
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"` // 可直接展示的提示文案
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrugWarning) Reset() {
+	*x = DrugWarning{}
+	mi := &file_drug_v1_drug_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrugWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrugWarning) ProtoMessage() {}
+
+func (x *DrugWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_drug_v1_drug_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrugWarning.ProtoReflect.Descriptor instead.
+func (*DrugWarning) Descriptor() ([]byte, []int) {
+	return file_drug_v1_drug_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DrugWarning) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DrugWarning) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *DrugWarning) GetBlocking() bool {
+	if x != nil {
+		return x.Blocking
+	}
+	return false
+}
+
+func (x *DrugWarning) GetDrugIds() []int64 {
+	if x != nil {
+		return x.DrugIds
+	}
+	return nil
+}
+
+func (x *DrugWarning) GetDrugNames() []string {
+	if x != nil {
+		return x.DrugNames
+	}
+	return nil
+}
+
+func (x *DrugWarning) GetPopulation() string {
+	if x != nil {
+		return x.Population
+	}
+	return ""
+}
+
+func (x *DrugWarning) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DrugWarning) GetAdvice() string {
+	if x != nil {
+		return x.Advice
+	}
+	return ""
+}
+
+func (x *DrugWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_drug_v1_drug_proto protoreflect.FileDescriptor
 
 const file_drug_v1_drug_proto_rawDesc = "" +
@@ -2268,7 +3124,7 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\fGetDrugReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x12%\n" +
-	"\x04drug\x18\x03 \x01(\v2\x11.drug.v1.InfoDrugR\x04drug\"\xf5\x01\n" +
+	"\x04drug\x18\x03 \x01(\v2\x11.drug.v1.InfoDrugR\x04drug\"\x9e\x02\n" +
 	"\bInfoDrug\x12\x1b\n" +
 	"\tdrug_name\x18\x01 \x01(\tR\bdrugName\x12$\n" +
 	"\rspecification\x18\x02 \x01(\tR\rspecification\x12\x14\n" +
@@ -2276,7 +3132,8 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\fsales_volume\x18\x04 \x01(\x01R\vsalesVolume\x12\x1c\n" +
 	"\tinventory\x18\x05 \x01(\x03R\tinventory\x12%\n" +
 	"\x0eexhibition_url\x18\x06 \x01(\tR\rexhibitionUrl\x12(\n" +
-	"\x05guide\x18\a \x01(\v2\x12.drug.v1.GuideInfoR\x05guide\"\xb7\x01\n" +
+	"\x05guide\x18\a \x01(\v2\x12.drug.v1.GuideInfoR\x05guide\x12'\n" +
+	"\x0fis_prescription\x18\b \x01(\bR\x0eisPrescription\"\xb7\x01\n" +
 	"\tGuideInfo\x12%\n" +
 	"\x0emajor_function\x18\x01 \x01(\tR\rmajorFunction\x12(\n" +
 	"\x10usage_and_dosage\x18\x02 \x01(\tR\x0eusageAndDosage\x12\x16\n" +
@@ -2322,20 +3179,21 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\rListDrugReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x12&\n" +
-	"\x04drug\x18\x03 \x03(\v2\x12.drug.v1.InfoDrugsR\x04drug\"\xcc\x01\n" +
+	"\x04drug\x18\x03 \x03(\v2\x12.drug.v1.InfoDrugsR\x04drug\"\xf5\x01\n" +
 	"\tInfoDrugs\x12\x1b\n" +
 	"\tdrug_name\x18\x01 \x01(\tR\bdrugName\x12$\n" +
 	"\rspecification\x18\x02 \x01(\tR\rspecification\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12!\n" +
 	"\fsales_volume\x18\x04 \x01(\x01R\vsalesVolume\x12\x1c\n" +
 	"\tinventory\x18\x05 \x01(\x03R\tinventory\x12%\n" +
-	"\x0eexhibition_url\x18\x06 \x01(\tR\rexhibitionUrl\"!\n" +
+	"\x0eexhibition_url\x18\x06 \x01(\tR\rexhibitionUrl\x12'\n" +
+	"\x0fis_prescription\x18\a \x01(\bR\x0eisPrescription\"!\n" +
 	"\x0fGetGuideRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"_\n" +
 	"\rGetGuideReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x12(\n" +
-	"\x05guide\x18\x03 \x01(\v2\x12.drug.v1.GuideInfoR\x05guide\"\xc5\x02\n" +
+	"\x05guide\x18\x03 \x01(\v2\x12.drug.v1.GuideInfoR\x05guide\"\xf4\x02\n" +
 	"\x12SearchDrugsRequest\x12\x18\n" +
 	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x1f\n" +
 	"\vcategory_id\x18\x02 \x01(\x05R\n" +
@@ -2348,13 +3206,16 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\asort_by\x18\b \x01(\tR\x06sortBy\x12\x1b\n" +
 	"\tprice_min\x18\t \x01(\x01R\bpriceMin\x12\x1b\n" +
 	"\tprice_max\x18\n" +
-	" \x01(\x01R\bpriceMax\"\xac\x01\n" +
+	" \x01(\x01R\bpriceMax\x12\x14\n" +
+	"\x05token\x18\v \x01(\tR\x05token\x12\x17\n" +
+	"\acity_id\x18\f \x01(\x05R\x06cityId\"\xd9\x01\n" +
 	"\x10SearchDrugsReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x12-\n" +
 	"\x05drugs\x18\x03 \x03(\v2\x17.drug.v1.SearchDrugInfoR\x05drugs\x12\x14\n" +
 	"\x05total\x18\x04 \x01(\x03R\x05total\x12-\n" +
-	"\x06facets\x18\x05 \x01(\v2\x15.drug.v1.SearchFacetsR\x06facets\"\x8b\x02\n" +
+	"\x06facets\x18\x05 \x01(\v2\x15.drug.v1.SearchFacetsR\x06facets\x12+\n" +
+	"\x11corrected_keyword\x18\x06 \x01(\tR\x10correctedKeyword\"\x8b\x02\n" +
 	"\x0eSearchDrugInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
 	"\tdrug_name\x18\x02 \x01(\tR\bdrugName\x12$\n" +
@@ -2380,15 +3241,59 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\x05count\x18\x02 \x01(\x03R\x05count\"=\n" +
 	"\x11ManufacturerFacet\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x03R\x05count\"+\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"x\n" +
+	"\x13SuggestDrugsRequest\x12\x18\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x121\n" +
+	"\x14include_prescription\x18\x03 \x01(\bR\x13includePrescription\"t\n" +
+	"\x11SuggestDrugsReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\x129\n" +
+	"\vsuggestions\x18\x03 \x03(\v2\x17.drug.v1.DrugSuggestionR\vsuggestions\"U\n" +
+	"\x0eDrugSuggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x17\n" +
+	"\adrug_id\x18\x02 \x01(\x03R\x06drugId\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\"p\n" +
+	"\x1aRecommendBySymptomsRequest\x12\x1a\n" +
+	"\bsymptoms\x18\x01 \x03(\tR\bsymptoms\x12 \n" +
+	"\vpopulations\x18\x02 \x03(\tR\vpopulations\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xd9\x01\n" +
+	"\x18RecommendBySymptomsReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\x123\n" +
+	"\bsymptoms\x18\x03 \x03(\v2\x17.drug.v1.MatchedSymptomR\bsymptoms\x12*\n" +
+	"\x05drugs\x18\x04 \x03(\v2\x14.drug.v1.SymptomDrugR\x05drugs\x126\n" +
+	"\aconsult\x18\x05 \x01(\v2\x1c.drug.v1.ConsultDoctorActionR\aconsult\"{\n" +
+	"\x0eMatchedSymptom\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acurated\x18\x02 \x01(\bR\acurated\x12#\n" +
+	"\rdepartment_id\x18\x03 \x01(\x05R\fdepartmentId\x12\x16\n" +
+	"\x06advice\x18\x04 \x01(\tR\x06advice\"\xcd\x01\n" +
+	"\vSymptomDrug\x12+\n" +
+	"\x04drug\x18\x01 \x01(\v2\x17.drug.v1.SearchDrugInfoR\x04drug\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12)\n" +
+	"\x10matched_symptoms\x18\x03 \x03(\tR\x0fmatchedSymptoms\x12\x1e\n" +
+	"\n" +
+	"indication\x18\x04 \x01(\tR\n" +
+	"indication\x120\n" +
+	"\bwarnings\x18\x05 \x03(\v2\x14.drug.v1.DrugWarningR\bwarnings\"b\n" +
+	"\x13ConsultDoctorAction\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12#\n" +
+	"\rdepartment_id\x18\x03 \x01(\x05R\fdepartmentId\"r\n" +
 	"\x13GetHotSearchRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\"\xda\x01\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x12\x17\n" +
+	"\acity_id\x18\x03 \x01(\x05R\x06cityId\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"\x8b\x02\n" +
 	"\x11GetHotSearchReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x123\n" +
 	"\fhot_keywords\x18\x03 \x03(\v2\x10.drug.v1.HotItemR\vhotKeywords\x123\n" +
 	"\fhot_symptoms\x18\x04 \x03(\v2\x10.drug.v1.HotItemR\vhotSymptoms\x125\n" +
-	"\rhot_questions\x18\x05 \x03(\v2\x10.drug.v1.HotItemR\fhotQuestions\"O\n" +
+	"\rhot_questions\x18\x05 \x03(\v2\x10.drug.v1.HotItemR\fhotQuestions\x12\x16\n" +
+	"\x06window\x18\x06 \x01(\tR\x06window\x12\x17\n" +
+	"\acity_id\x18\a \x01(\x05R\x06cityId\"O\n" +
 	"\aHotItem\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x14\n" +
 	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x14\n" +
@@ -2428,7 +3333,7 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\x11GetInventoryReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x124\n" +
-	"\tinventory\x18\x03 \x01(\v2\x16.drug.v1.InventoryInfoR\tinventory\"\xe2\x01\n" +
+	"\tinventory\x18\x03 \x01(\v2\x16.drug.v1.InventoryInfoR\tinventory\"\x87\x02\n" +
 	"\rInventoryInfo\x12\x17\n" +
 	"\adrug_id\x18\x01 \x01(\x03R\x06drugId\x12\"\n" +
 	"\rdrug_store_id\x18\x02 \x01(\x05R\vdrugStoreId\x12\x1a\n" +
@@ -2436,26 +3341,53 @@ const file_drug_v1_drug_proto_rawDesc = "" +
 	"\freserved_qty\x18\x04 \x01(\x03R\vreservedQty\x12'\n" +
 	"\x0falert_threshold\x18\x05 \x01(\x03R\x0ealertThreshold\x12\x14\n" +
 	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x16\n" +
-	"\x06status\x18\a \x01(\x05R\x06status\"q\n" +
+	"\x06status\x18\a \x01(\x05R\x06status\x12#\n" +
+	"\ravailable_qty\x18\b \x01(\x03R\favailableQty\"\x89\x01\n" +
 	"\x16UpdateInventoryRequest\x12\x17\n" +
 	"\adrug_id\x18\x01 \x01(\x03R\x06drugId\x12\"\n" +
 	"\rdrug_store_id\x18\x02 \x01(\x05R\vdrugStoreId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x03R\bquantity\"<\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x12\x16\n" +
+	"\x06remark\x18\x04 \x01(\tR\x06remark\"r\n" +
 	"\x14UpdateInventoryReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
-	"\x03msg\x18\x02 \x01(\tR\x03msg2\x88\b\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\x124\n" +
+	"\tinventory\x18\x03 \x01(\v2\x16.drug.v1.InventoryInfoR\tinventory\"[\n" +
+	"\x1cCheckDrugInteractionsRequest\x12\x19\n" +
+	"\bdrug_ids\x18\x01 \x03(\x03R\adrugIds\x12 \n" +
+	"\vpopulations\x18\x02 \x03(\tR\vpopulations\"\x8e\x01\n" +
+	"\x1aCheckDrugInteractionsReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\x12\x18\n" +
+	"\ablocked\x18\x03 \x01(\bR\ablocked\x120\n" +
+	"\bwarnings\x18\x04 \x03(\v2\x14.drug.v1.DrugWarningR\bwarnings\"\x81\x02\n" +
+	"\vDrugWarning\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x1a\n" +
+	"\bblocking\x18\x03 \x01(\bR\bblocking\x12\x19\n" +
+	"\bdrug_ids\x18\x04 \x03(\x03R\adrugIds\x12\x1d\n" +
+	"\n" +
+	"drug_names\x18\x05 \x03(\tR\tdrugNames\x12\x1e\n" +
+	"\n" +
+	"population\x18\x06 \x01(\tR\n" +
+	"population\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x16\n" +
+	"\x06advice\x18\b \x01(\tR\x06advice\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage2\x81\v\n" +
 	"\x04Drug\x12N\n" +
 	"\aGetDrug\x12\x17.drug.v1.GetDrugRequest\x1a\x15.drug.v1.GetDrugReply\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/drug\x12V\n" +
 	"\bListDrug\x12\x18.drug.v1.ListDrugRequest\x1a\x16.drug.v1.ListDrugReply\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/drug/list\x12_\n" +
 	"\n" +
 	"GetExplain\x12\x1a.drug.v1.GetExplainRequest\x1a\x18.drug.v1.GetExplainReply\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/drug/explain\x12W\n" +
 	"\bGetGuide\x12\x18.drug.v1.GetGuideRequest\x1a\x16.drug.v1.GetGuideReply\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/drug/guide\x12a\n" +
-	"\vSearchDrugs\x12\x1b.drug.v1.SearchDrugsRequest\x1a\x19.drug.v1.SearchDrugsReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/drug/search\x12e\n" +
+	"\vSearchDrugs\x12\x1b.drug.v1.SearchDrugsRequest\x1a\x19.drug.v1.SearchDrugsReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/drug/search\x12b\n" +
+	"\fSuggestDrugs\x12\x1c.drug.v1.SuggestDrugsRequest\x1a\x1a.drug.v1.SuggestDrugsReply\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/drug/suggest\x12\x84\x01\n" +
+	"\x13RecommendBySymptoms\x12#.drug.v1.RecommendBySymptomsRequest\x1a!.drug.v1.RecommendBySymptomsReply\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/drug/symptom-recommend\x12e\n" +
 	"\fGetHotSearch\x12\x1c.drug.v1.GetHotSearchRequest\x1a\x1a.drug.v1.GetHotSearchReply\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/drug/hot-search\x12|\n" +
 	"\x12CreatePrescription\x12\".drug.v1.CreatePrescriptionRequest\x1a .drug.v1.CreatePrescriptionReply\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/drug/prescription\x12w\n" +
 	"\x11ListPrescriptions\x12!.drug.v1.ListPrescriptionsRequest\x1a\x1f.drug.v1.ListPrescriptionsReply\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/drug/prescriptions\x12d\n" +
 	"\fGetInventory\x12\x1c.drug.v1.GetInventoryRequest\x1a\x1a.drug.v1.GetInventoryReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/drug/inventory\x12w\n" +
-	"\x0fUpdateInventory\x12\x1f.drug.v1.UpdateInventoryRequest\x1a\x1d.drug.v1.UpdateInventoryReply\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/drug/inventory/updateBE\n" +
+	"\x0fUpdateInventory\x12\x1f.drug.v1.UpdateInventoryRequest\x1a\x1d.drug.v1.UpdateInventoryReply\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/drug/inventory/update\x12\x8b\x01\n" +
+	"\x15CheckDrugInteractions\x12%.drug.v1.CheckDrugInteractionsRequest\x1a#.drug.v1.CheckDrugInteractionsReply\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/drug/interactions/checkBE\n" +
 	"\x16dev.kratos.api.drug.v1B\vdrugProtoV1P\x01Z\x1ckratos_client/api/drug/v1;v1b\x06proto3"
 
 var (
@@ -2470,40 +3402,51 @@ func file_drug_v1_drug_proto_rawDescGZIP() []byte {
 	return file_drug_v1_drug_proto_rawDescData
 }
 
-var file_drug_v1_drug_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_drug_v1_drug_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_drug_v1_drug_proto_goTypes = []any{
-	(*GetDrugRequest)(nil),            // 0: drug.v1.GetDrugRequest
-	(*GetDrugReply)(nil),              // 1: drug.v1.GetDrugReply
-	(*InfoDrug)(nil),                  // 2: drug.v1.InfoDrug
-	(*GuideInfo)(nil),                 // 3: drug.v1.GuideInfo
-	(*GetExplainRequest)(nil),         // 4: drug.v1.GetExplainRequest
-	(*GetExplainReply)(nil),           // 5: drug.v1.GetExplainReply
-	(*ExplainInfo)(nil),               // 6: drug.v1.ExplainInfo
-	(*ListDrugRequest)(nil),           // 7: drug.v1.ListDrugRequest
-	(*ListDrugReply)(nil),             // 8: drug.v1.ListDrugReply
-	(*InfoDrugs)(nil),                 // 9: drug.v1.InfoDrugs
-	(*GetGuideRequest)(nil),           // 10: drug.v1.GetGuideRequest
-	(*GetGuideReply)(nil),             // 11: drug.v1.GetGuideReply
-	(*SearchDrugsRequest)(nil),        // 12: drug.v1.SearchDrugsRequest
-	(*SearchDrugsReply)(nil),          // 13: drug.v1.SearchDrugsReply
-	(*SearchDrugInfo)(nil),            // 14: drug.v1.SearchDrugInfo
-	(*SearchFacets)(nil),              // 15: drug.v1.SearchFacets
-	(*CategoryFacet)(nil),             // 16: drug.v1.CategoryFacet
-	(*PriceFacet)(nil),                // 17: drug.v1.PriceFacet
-	(*ManufacturerFacet)(nil),         // 18: drug.v1.ManufacturerFacet
-	(*GetHotSearchRequest)(nil),       // 19: drug.v1.GetHotSearchRequest
-	(*GetHotSearchReply)(nil),         // 20: drug.v1.GetHotSearchReply
-	(*HotItem)(nil),                   // 21: drug.v1.HotItem
-	(*CreatePrescriptionRequest)(nil), // 22: drug.v1.CreatePrescriptionRequest
-	(*CreatePrescriptionReply)(nil),   // 23: drug.v1.CreatePrescriptionReply
-	(*ListPrescriptionsRequest)(nil),  // 24: drug.v1.ListPrescriptionsRequest
-	(*ListPrescriptionsReply)(nil),    // 25: drug.v1.ListPrescriptionsReply
-	(*PrescriptionInfo)(nil),          // 26: drug.v1.PrescriptionInfo
-	(*GetInventoryRequest)(nil),       // 27: drug.v1.GetInventoryRequest
-	(*GetInventoryReply)(nil),         // 28: drug.v1.GetInventoryReply
-	(*InventoryInfo)(nil),             // 29: drug.v1.InventoryInfo
-	(*UpdateInventoryRequest)(nil),    // 30: drug.v1.UpdateInventoryRequest
-	(*UpdateInventoryReply)(nil),      // 31: drug.v1.UpdateInventoryReply
+	(*GetDrugRequest)(nil),               // 0: drug.v1.GetDrugRequest
+	(*GetDrugReply)(nil),                 // 1: drug.v1.GetDrugReply
+	(*InfoDrug)(nil),                     // 2: drug.v1.InfoDrug
+	(*GuideInfo)(nil),                    // 3: drug.v1.GuideInfo
+	(*GetExplainRequest)(nil),            // 4: drug.v1.GetExplainRequest
+	(*GetExplainReply)(nil),              // 5: drug.v1.GetExplainReply
+	(*ExplainInfo)(nil),                  // 6: drug.v1.ExplainInfo
+	(*ListDrugRequest)(nil),              // 7: drug.v1.ListDrugRequest
+	(*ListDrugReply)(nil),                // 8: drug.v1.ListDrugReply
+	(*InfoDrugs)(nil),                    // 9: drug.v1.InfoDrugs
+	(*GetGuideRequest)(nil),              // 10: drug.v1.GetGuideRequest
+	(*GetGuideReply)(nil),                // 11: drug.v1.GetGuideReply
+	(*SearchDrugsRequest)(nil),           // 12: drug.v1.SearchDrugsRequest
+	(*SearchDrugsReply)(nil),             // 13: drug.v1.SearchDrugsReply
+	(*SearchDrugInfo)(nil),               // 14: drug.v1.SearchDrugInfo
+	(*SearchFacets)(nil),                 // 15: drug.v1.SearchFacets
+	(*CategoryFacet)(nil),                // 16: drug.v1.CategoryFacet
+	(*PriceFacet)(nil),                   // 17: drug.v1.PriceFacet
+	(*ManufacturerFacet)(nil),            // 18: drug.v1.ManufacturerFacet
+	(*SuggestDrugsRequest)(nil),          // 19: drug.v1.SuggestDrugsRequest
+	(*SuggestDrugsReply)(nil),            // 20: drug.v1.SuggestDrugsReply
+	(*DrugSuggestion)(nil),               // 21: drug.v1.DrugSuggestion
+	(*RecommendBySymptomsRequest)(nil),   // 22: drug.v1.RecommendBySymptomsRequest
+	(*RecommendBySymptomsReply)(nil),     // 23: drug.v1.RecommendBySymptomsReply
+	(*MatchedSymptom)(nil),               // 24: drug.v1.MatchedSymptom
+	(*SymptomDrug)(nil),                  // 25: drug.v1.SymptomDrug
+	(*ConsultDoctorAction)(nil),          // 26: drug.v1.ConsultDoctorAction
+	(*GetHotSearchRequest)(nil),          // 27: drug.v1.GetHotSearchRequest
+	(*GetHotSearchReply)(nil),            // 28: drug.v1.GetHotSearchReply
+	(*HotItem)(nil),                      // 29: drug.v1.HotItem
+	(*CreatePrescriptionRequest)(nil),    // 30: drug.v1.CreatePrescriptionRequest
+	(*CreatePrescriptionReply)(nil),      // 31: drug.v1.CreatePrescriptionReply
+	(*ListPrescriptionsRequest)(nil),     // 32: drug.v1.ListPrescriptionsRequest
+	(*ListPrescriptionsReply)(nil),       // 33: drug.v1.ListPrescriptionsReply
+	(*PrescriptionInfo)(nil),             // 34: drug.v1.PrescriptionInfo
+	(*GetInventoryRequest)(nil),          // 35: drug.v1.GetInventoryRequest
+	(*GetInventoryReply)(nil),            // 36: drug.v1.GetInventoryReply
+	(*InventoryInfo)(nil),                // 37: drug.v1.InventoryInfo
+	(*UpdateInventoryRequest)(nil),       // 38: drug.v1.UpdateInventoryRequest
+	(*UpdateInventoryReply)(nil),         // 39: drug.v1.UpdateInventoryReply
+	(*CheckDrugInteractionsRequest)(nil), // 40: drug.v1.CheckDrugInteractionsRequest
+	(*CheckDrugInteractionsReply)(nil),   // 41: drug.v1.CheckDrugInteractionsReply
+	(*DrugWarning)(nil),                  // 42: drug.v1.DrugWarning
 }
 var file_drug_v1_drug_proto_depIdxs = []int32{
 	2,  // 0: drug.v1.GetDrugReply.drug:type_name -> drug.v1.InfoDrug
@@ -2516,36 +3459,50 @@ var file_drug_v1_drug_proto_depIdxs = []int32{
 	16, // 7: drug.v1.SearchFacets.categories:type_name -> drug.v1.CategoryFacet
 	17, // 8: drug.v1.SearchFacets.price_ranges:type_name -> drug.v1.PriceFacet
 	18, // 9: drug.v1.SearchFacets.manufacturers:type_name -> drug.v1.ManufacturerFacet
-	21, // 10: drug.v1.GetHotSearchReply.hot_keywords:type_name -> drug.v1.HotItem
-	21, // 11: drug.v1.GetHotSearchReply.hot_symptoms:type_name -> drug.v1.HotItem
-	21, // 12: drug.v1.GetHotSearchReply.hot_questions:type_name -> drug.v1.HotItem
-	26, // 13: drug.v1.ListPrescriptionsReply.prescriptions:type_name -> drug.v1.PrescriptionInfo
-	29, // 14: drug.v1.GetInventoryReply.inventory:type_name -> drug.v1.InventoryInfo
-	0,  // 15: drug.v1.Drug.GetDrug:input_type -> drug.v1.GetDrugRequest
-	7,  // 16: drug.v1.Drug.ListDrug:input_type -> drug.v1.ListDrugRequest
-	4,  // 17: drug.v1.Drug.GetExplain:input_type -> drug.v1.GetExplainRequest
-	10, // 18: drug.v1.Drug.GetGuide:input_type -> drug.v1.GetGuideRequest
-	12, // 19: drug.v1.Drug.SearchDrugs:input_type -> drug.v1.SearchDrugsRequest
-	19, // 20: drug.v1.Drug.GetHotSearch:input_type -> drug.v1.GetHotSearchRequest
-	22, // 21: drug.v1.Drug.CreatePrescription:input_type -> drug.v1.CreatePrescriptionRequest
-	24, // 22: drug.v1.Drug.ListPrescriptions:input_type -> drug.v1.ListPrescriptionsRequest
-	27, // 23: drug.v1.Drug.GetInventory:input_type -> drug.v1.GetInventoryRequest
-	30, // 24: drug.v1.Drug.UpdateInventory:input_type -> drug.v1.UpdateInventoryRequest
-	1,  // 25: drug.v1.Drug.GetDrug:output_type -> drug.v1.GetDrugReply
-	8,  // 26: drug.v1.Drug.ListDrug:output_type -> drug.v1.ListDrugReply
-	5,  // 27: drug.v1.Drug.GetExplain:output_type -> drug.v1.GetExplainReply
-	11, // 28: drug.v1.Drug.GetGuide:output_type -> drug.v1.GetGuideReply
-	13, // 29: drug.v1.Drug.SearchDrugs:output_type -> drug.v1.SearchDrugsReply
-	20, // 30: drug.v1.Drug.GetHotSearch:output_type -> drug.v1.GetHotSearchReply
-	23, // 31: drug.v1.Drug.CreatePrescription:output_type -> drug.v1.CreatePrescriptionReply
-	25, // 32: drug.v1.Drug.ListPrescriptions:output_type -> drug.v1.ListPrescriptionsReply
-	28, // 33: drug.v1.Drug.GetInventory:output_type -> drug.v1.GetInventoryReply
-	31, // 34: drug.v1.Drug.UpdateInventory:output_type -> drug.v1.UpdateInventoryReply
-	25, // [25:35] is the sub-list for method output_type
-	15, // [15:25] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	21, // 10: drug.v1.SuggestDrugsReply.suggestions:type_name -> drug.v1.DrugSuggestion
+	24, // 11: drug.v1.RecommendBySymptomsReply.symptoms:type_name -> drug.v1.MatchedSymptom
+	25, // 12: drug.v1.RecommendBySymptomsReply.drugs:type_name -> drug.v1.SymptomDrug
+	26, // 13: drug.v1.RecommendBySymptomsReply.consult:type_name -> drug.v1.ConsultDoctorAction
+	14, // 14: drug.v1.SymptomDrug.drug:type_name -> drug.v1.SearchDrugInfo
+	42, // 15: drug.v1.SymptomDrug.warnings:type_name -> drug.v1.DrugWarning
+	29, // 16: drug.v1.GetHotSearchReply.hot_keywords:type_name -> drug.v1.HotItem
+	29, // 17: drug.v1.GetHotSearchReply.hot_symptoms:type_name -> drug.v1.HotItem
+	29, // 18: drug.v1.GetHotSearchReply.hot_questions:type_name -> drug.v1.HotItem
+	34, // 19: drug.v1.ListPrescriptionsReply.prescriptions:type_name -> drug.v1.PrescriptionInfo
+	37, // 20: drug.v1.GetInventoryReply.inventory:type_name -> drug.v1.InventoryInfo
+	37, // 21: drug.v1.UpdateInventoryReply.inventory:type_name -> drug.v1.InventoryInfo
+	42, // 22: drug.v1.CheckDrugInteractionsReply.warnings:type_name -> drug.v1.DrugWarning
+	0,  // 23: drug.v1.Drug.GetDrug:input_type -> drug.v1.GetDrugRequest
+	7,  // 24: drug.v1.Drug.ListDrug:input_type -> drug.v1.ListDrugRequest
+	4,  // 25: drug.v1.Drug.GetExplain:input_type -> drug.v1.GetExplainRequest
+	10, // 26: drug.v1.Drug.GetGuide:input_type -> drug.v1.GetGuideRequest
+	12, // 27: drug.v1.Drug.SearchDrugs:input_type -> drug.v1.SearchDrugsRequest
+	19, // 28: drug.v1.Drug.SuggestDrugs:input_type -> drug.v1.SuggestDrugsRequest
+	22, // 29: drug.v1.Drug.RecommendBySymptoms:input_type -> drug.v1.RecommendBySymptomsRequest
+	27, // 30: drug.v1.Drug.GetHotSearch:input_type -> drug.v1.GetHotSearchRequest
+	30, // 31: drug.v1.Drug.CreatePrescription:input_type -> drug.v1.CreatePrescriptionRequest
+	32, // 32: drug.v1.Drug.ListPrescriptions:input_type -> drug.v1.ListPrescriptionsRequest
+	35, // 33: drug.v1.Drug.GetInventory:input_type -> drug.v1.GetInventoryRequest
+	38, // 34: drug.v1.Drug.UpdateInventory:input_type -> drug.v1.UpdateInventoryRequest
+	40, // 35: drug.v1.Drug.CheckDrugInteractions:input_type -> drug.v1.CheckDrugInteractionsRequest
+	1,  // 36: drug.v1.Drug.GetDrug:output_type -> drug.v1.GetDrugReply
+	8,  // 37: drug.v1.Drug.ListDrug:output_type -> drug.v1.ListDrugReply
+	5,  // 38: drug.v1.Drug.GetExplain:output_type -> drug.v1.GetExplainReply
+	11, // 39: drug.v1.Drug.GetGuide:output_type -> drug.v1.GetGuideReply
+	13, // 40: drug.v1.Drug.SearchDrugs:output_type -> drug.v1.SearchDrugsReply
+	20, // 41: drug.v1.Drug.SuggestDrugs:output_type -> drug.v1.SuggestDrugsReply
+	23, // 42: drug.v1.Drug.RecommendBySymptoms:output_type -> drug.v1.RecommendBySymptomsReply
+	28, // 43: drug.v1.Drug.GetHotSearch:output_type -> drug.v1.GetHotSearchReply
+	31, // 44: drug.v1.Drug.CreatePrescription:output_type -> drug.v1.CreatePrescriptionReply
+	33, // 45: drug.v1.Drug.ListPrescriptions:output_type -> drug.v1.ListPrescriptionsReply
+	36, // 46: drug.v1.Drug.GetInventory:output_type -> drug.v1.GetInventoryReply
+	39, // 47: drug.v1.Drug.UpdateInventory:output_type -> drug.v1.UpdateInventoryReply
+	41, // 48: drug.v1.Drug.CheckDrugInteractions:output_type -> drug.v1.CheckDrugInteractionsReply
+	36, // [36:49] is the sub-list for method output_type
+	23, // [23:36] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_drug_v1_drug_proto_init() }
@@ -2559,7 +3516,7 @@ func file_drug_v1_drug_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_drug_v1_drug_proto_rawDesc), len(file_drug_v1_drug_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
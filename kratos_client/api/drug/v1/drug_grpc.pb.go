@@ -19,16 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Drug_GetDrug_FullMethodName            = "/drug.v1.Drug/GetDrug"
-	Drug_ListDrug_FullMethodName           = "/drug.v1.Drug/ListDrug"
-	Drug_GetExplain_FullMethodName         = "/drug.v1.Drug/GetExplain"
-	Drug_GetGuide_FullMethodName           = "/drug.v1.Drug/GetGuide"
-	Drug_SearchDrugs_FullMethodName        = "/drug.v1.Drug/SearchDrugs"
-	Drug_GetHotSearch_FullMethodName       = "/drug.v1.Drug/GetHotSearch"
-	Drug_CreatePrescription_FullMethodName = "/drug.v1.Drug/CreatePrescription"
-	Drug_ListPrescriptions_FullMethodName  = "/drug.v1.Drug/ListPrescriptions"
-	Drug_GetInventory_FullMethodName       = "/drug.v1.Drug/GetInventory"
-	Drug_UpdateInventory_FullMethodName    = "/drug.v1.Drug/UpdateInventory"
+	Drug_GetDrug_FullMethodName               = "/drug.v1.Drug/GetDrug"
+	Drug_ListDrug_FullMethodName              = "/drug.v1.Drug/ListDrug"
+	Drug_GetExplain_FullMethodName            = "/drug.v1.Drug/GetExplain"
+	Drug_GetGuide_FullMethodName              = "/drug.v1.Drug/GetGuide"
+	Drug_SearchDrugs_FullMethodName           = "/drug.v1.Drug/SearchDrugs"
+	Drug_SuggestDrugs_FullMethodName          = "/drug.v1.Drug/SuggestDrugs"
+	Drug_RecommendBySymptoms_FullMethodName   = "/drug.v1.Drug/RecommendBySymptoms"
+	Drug_GetHotSearch_FullMethodName          = "/drug.v1.Drug/GetHotSearch"
+	Drug_CreatePrescription_FullMethodName    = "/drug.v1.Drug/CreatePrescription"
+	Drug_ListPrescriptions_FullMethodName     = "/drug.v1.Drug/ListPrescriptions"
+	Drug_GetInventory_FullMethodName          = "/drug.v1.Drug/GetInventory"
+	Drug_UpdateInventory_FullMethodName       = "/drug.v1.Drug/UpdateInventory"
+	Drug_CheckDrugInteractions_FullMethodName = "/drug.v1.Drug/CheckDrugInteractions"
 )
 
 // DrugClient is the client API for Drug service.
@@ -41,6 +44,10 @@ type DrugClient interface {
 	GetGuide(ctx context.Context, in *GetGuideRequest, opts ...grpc.CallOption) (*GetGuideReply, error)
 	// 搜索药品
 	SearchDrugs(ctx context.Context, in *SearchDrugsRequest, opts ...grpc.CallOption) (*SearchDrugsReply, error)
+	// 搜索联想，支持拼音全拼、首字母和通用名、商品名
+	SuggestDrugs(ctx context.Context, in *SuggestDrugsRequest, opts ...grpc.CallOption) (*SuggestDrugsReply, error)
+	// 按症状推荐非处方药，并附带问诊入口
+	RecommendBySymptoms(ctx context.Context, in *RecommendBySymptomsRequest, opts ...grpc.CallOption) (*RecommendBySymptomsReply, error)
 	// 获取热门搜索
 	GetHotSearch(ctx context.Context, in *GetHotSearchRequest, opts ...grpc.CallOption) (*GetHotSearchReply, error)
 	// 处方药管理
@@ -48,7 +55,10 @@ type DrugClient interface {
 	ListPrescriptions(ctx context.Context, in *ListPrescriptionsRequest, opts ...grpc.CallOption) (*ListPrescriptionsReply, error)
 	// 库存管理
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryReply, error)
+	// 盘点调整库存，仅供管理后台调用，请求头 X-Admin-Token 需与配置 inventory.admin_token 一致
 	UpdateInventory(ctx context.Context, in *UpdateInventoryRequest, opts ...grpc.CallOption) (*UpdateInventoryReply, error)
+	// 检查药品之间的相互作用和对人群的禁忌
+	CheckDrugInteractions(ctx context.Context, in *CheckDrugInteractionsRequest, opts ...grpc.CallOption) (*CheckDrugInteractionsReply, error)
 }
 
 type drugClient struct {
@@ -109,6 +119,26 @@ func (c *drugClient) SearchDrugs(ctx context.Context, in *SearchDrugsRequest, op
 	return out, nil
 }
 
+func (c *drugClient) SuggestDrugs(ctx context.Context, in *SuggestDrugsRequest, opts ...grpc.CallOption) (*SuggestDrugsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestDrugsReply)
+	err := c.cc.Invoke(ctx, Drug_SuggestDrugs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drugClient) RecommendBySymptoms(ctx context.Context, in *RecommendBySymptomsRequest, opts ...grpc.CallOption) (*RecommendBySymptomsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendBySymptomsReply)
+	err := c.cc.Invoke(ctx, Drug_RecommendBySymptoms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *drugClient) GetHotSearch(ctx context.Context, in *GetHotSearchRequest, opts ...grpc.CallOption) (*GetHotSearchReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetHotSearchReply)
@@ -159,6 +189,16 @@ func (c *drugClient) UpdateInventory(ctx context.Context, in *UpdateInventoryReq
 	return out, nil
 }
 
+func (c *drugClient) CheckDrugInteractions(ctx context.Context, in *CheckDrugInteractionsRequest, opts ...grpc.CallOption) (*CheckDrugInteractionsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDrugInteractionsReply)
+	err := c.cc.Invoke(ctx, Drug_CheckDrugInteractions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DrugServer is the server API for Drug service.
 // All implementations must embed UnimplementedDrugServer
 // for forward compatibility.
@@ -169,6 +209,10 @@ type DrugServer interface {
 	GetGuide(context.Context, *GetGuideRequest) (*GetGuideReply, error)
 	// 搜索药品
 	SearchDrugs(context.Context, *SearchDrugsRequest) (*SearchDrugsReply, error)
+	// 搜索联想，支持拼音全拼、首字母和通用名、商品名
+	SuggestDrugs(context.Context, *SuggestDrugsRequest) (*SuggestDrugsReply, error)
+	// 按症状推荐非处方药，并附带问诊入口
+	RecommendBySymptoms(context.Context, *RecommendBySymptomsRequest) (*RecommendBySymptomsReply, error)
 	// 获取热门搜索
 	GetHotSearch(context.Context, *GetHotSearchRequest) (*GetHotSearchReply, error)
 	// 处方药管理
@@ -176,7 +220,10 @@ type DrugServer interface {
 	ListPrescriptions(context.Context, *ListPrescriptionsRequest) (*ListPrescriptionsReply, error)
 	// 库存管理
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryReply, error)
+	// 盘点调整库存，仅供管理后台调用，请求头 X-Admin-Token 需与配置 inventory.admin_token 一致
 	UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryReply, error)
+	// 检查药品之间的相互作用和对人群的禁忌
+	CheckDrugInteractions(context.Context, *CheckDrugInteractionsRequest) (*CheckDrugInteractionsReply, error)
 	mustEmbedUnimplementedDrugServer()
 }
 
@@ -202,6 +249,12 @@ func (UnimplementedDrugServer) GetGuide(context.Context, *GetGuideRequest) (*Get
 func (UnimplementedDrugServer) SearchDrugs(context.Context, *SearchDrugsRequest) (*SearchDrugsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchDrugs not implemented")
 }
+func (UnimplementedDrugServer) SuggestDrugs(context.Context, *SuggestDrugsRequest) (*SuggestDrugsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestDrugs not implemented")
+}
+func (UnimplementedDrugServer) RecommendBySymptoms(context.Context, *RecommendBySymptomsRequest) (*RecommendBySymptomsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendBySymptoms not implemented")
+}
 func (UnimplementedDrugServer) GetHotSearch(context.Context, *GetHotSearchRequest) (*GetHotSearchReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHotSearch not implemented")
 }
@@ -217,6 +270,9 @@ func (UnimplementedDrugServer) GetInventory(context.Context, *GetInventoryReques
 func (UnimplementedDrugServer) UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventory not implemented")
 }
+func (UnimplementedDrugServer) CheckDrugInteractions(context.Context, *CheckDrugInteractionsRequest) (*CheckDrugInteractionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDrugInteractions not implemented")
+}
 func (UnimplementedDrugServer) mustEmbedUnimplementedDrugServer() {}
 func (UnimplementedDrugServer) testEmbeddedByValue()              {}
 
@@ -328,6 +384,42 @@ func _Drug_SearchDrugs_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Drug_SuggestDrugs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestDrugsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DrugServer).SuggestDrugs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Drug_SuggestDrugs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DrugServer).SuggestDrugs(ctx, req.(*SuggestDrugsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Drug_RecommendBySymptoms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendBySymptomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DrugServer).RecommendBySymptoms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Drug_RecommendBySymptoms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DrugServer).RecommendBySymptoms(ctx, req.(*RecommendBySymptomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Drug_GetHotSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetHotSearchRequest)
 	if err := dec(in); err != nil {
@@ -418,6 +510,24 @@ func _Drug_UpdateInventory_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Drug_CheckDrugInteractions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDrugInteractionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DrugServer).CheckDrugInteractions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Drug_CheckDrugInteractions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DrugServer).CheckDrugInteractions(ctx, req.(*CheckDrugInteractionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Drug_ServiceDesc is the grpc.ServiceDesc for Drug service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -445,6 +555,14 @@ var Drug_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchDrugs",
 			Handler:    _Drug_SearchDrugs_Handler,
 		},
+		{
+			MethodName: "SuggestDrugs",
+			Handler:    _Drug_SuggestDrugs_Handler,
+		},
+		{
+			MethodName: "RecommendBySymptoms",
+			Handler:    _Drug_RecommendBySymptoms_Handler,
+		},
 		{
 			MethodName: "GetHotSearch",
 			Handler:    _Drug_GetHotSearch_Handler,
@@ -465,6 +583,10 @@ var Drug_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateInventory",
 			Handler:    _Drug_UpdateInventory_Handler,
 		},
+		{
+			MethodName: "CheckDrugInteractions",
+			Handler:    _Drug_CheckDrugInteractions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "drug/v1/drug.proto",
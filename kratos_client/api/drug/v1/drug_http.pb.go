@@ -19,6 +19,7 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationDrugCheckDrugInteractions = "/drug.v1.Drug/CheckDrugInteractions"
 const OperationDrugCreatePrescription = "/drug.v1.Drug/CreatePrescription"
 const OperationDrugGetDrug = "/drug.v1.Drug/GetDrug"
 const OperationDrugGetExplain = "/drug.v1.Drug/GetExplain"
@@ -27,10 +28,14 @@ const OperationDrugGetHotSearch = "/drug.v1.Drug/GetHotSearch"
 const OperationDrugGetInventory = "/drug.v1.Drug/GetInventory"
 const OperationDrugListDrug = "/drug.v1.Drug/ListDrug"
 const OperationDrugListPrescriptions = "/drug.v1.Drug/ListPrescriptions"
+const OperationDrugRecommendBySymptoms = "/drug.v1.Drug/RecommendBySymptoms"
 const OperationDrugSearchDrugs = "/drug.v1.Drug/SearchDrugs"
+const OperationDrugSuggestDrugs = "/drug.v1.Drug/SuggestDrugs"
 const OperationDrugUpdateInventory = "/drug.v1.Drug/UpdateInventory"
 
 type DrugHTTPServer interface {
+	// CheckDrugInteractions 检查药品之间的相互作用和对人群的禁忌
+	CheckDrugInteractions(context.Context, *CheckDrugInteractionsRequest) (*CheckDrugInteractionsReply, error)
 	// CreatePrescription 处方药管理
 	CreatePrescription(context.Context, *CreatePrescriptionRequest) (*CreatePrescriptionReply, error)
 	GetDrug(context.Context, *GetDrugRequest) (*GetDrugReply, error)
@@ -42,8 +47,13 @@ type DrugHTTPServer interface {
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryReply, error)
 	ListDrug(context.Context, *ListDrugRequest) (*ListDrugReply, error)
 	ListPrescriptions(context.Context, *ListPrescriptionsRequest) (*ListPrescriptionsReply, error)
+	// RecommendBySymptoms 按症状推荐非处方药，并附带问诊入口
+	RecommendBySymptoms(context.Context, *RecommendBySymptomsRequest) (*RecommendBySymptomsReply, error)
 	// SearchDrugs 搜索药品
 	SearchDrugs(context.Context, *SearchDrugsRequest) (*SearchDrugsReply, error)
+	// SuggestDrugs 搜索联想，支持拼音全拼、首字母和通用名、商品名
+	SuggestDrugs(context.Context, *SuggestDrugsRequest) (*SuggestDrugsReply, error)
+	// UpdateInventory 盘点调整库存，仅供管理后台调用，请求头 X-Admin-Token 需与配置 inventory.admin_token 一致
 	UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryReply, error)
 }
 
@@ -54,11 +64,14 @@ func RegisterDrugHTTPServer(s *http.Server, srv DrugHTTPServer) {
 	r.POST("/v1/drug/explain", _Drug_GetExplain0_HTTP_Handler(srv))
 	r.POST("/v1/drug/guide", _Drug_GetGuide0_HTTP_Handler(srv))
 	r.POST("/v1/drug/search", _Drug_SearchDrugs0_HTTP_Handler(srv))
+	r.GET("/v1/drug/suggest", _Drug_SuggestDrugs0_HTTP_Handler(srv))
+	r.POST("/v1/drug/symptom-recommend", _Drug_RecommendBySymptoms0_HTTP_Handler(srv))
 	r.GET("/v1/drug/hot-search", _Drug_GetHotSearch0_HTTP_Handler(srv))
 	r.POST("/v1/drug/prescription", _Drug_CreatePrescription0_HTTP_Handler(srv))
 	r.GET("/v1/drug/prescriptions", _Drug_ListPrescriptions0_HTTP_Handler(srv))
 	r.GET("/v1/drug/inventory", _Drug_GetInventory0_HTTP_Handler(srv))
 	r.POST("/v1/drug/inventory/update", _Drug_UpdateInventory0_HTTP_Handler(srv))
+	r.POST("/v1/drug/interactions/check", _Drug_CheckDrugInteractions0_HTTP_Handler(srv))
 }
 
 func _Drug_GetDrug0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Context) error {
@@ -171,6 +184,47 @@ func _Drug_SearchDrugs0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Context)
 	}
 }
 
+func _Drug_SuggestDrugs0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SuggestDrugsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationDrugSuggestDrugs)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SuggestDrugs(ctx, req.(*SuggestDrugsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SuggestDrugsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Drug_RecommendBySymptoms0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RecommendBySymptomsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationDrugRecommendBySymptoms)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RecommendBySymptoms(ctx, req.(*RecommendBySymptomsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RecommendBySymptomsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _Drug_GetHotSearch0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in GetHotSearchRequest
@@ -272,7 +326,30 @@ func _Drug_UpdateInventory0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Conte
 	}
 }
 
+func _Drug_CheckDrugInteractions0_HTTP_Handler(srv DrugHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CheckDrugInteractionsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationDrugCheckDrugInteractions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CheckDrugInteractions(ctx, req.(*CheckDrugInteractionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CheckDrugInteractionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type DrugHTTPClient interface {
+	CheckDrugInteractions(ctx context.Context, req *CheckDrugInteractionsRequest, opts ...http.CallOption) (rsp *CheckDrugInteractionsReply, err error)
 	CreatePrescription(ctx context.Context, req *CreatePrescriptionRequest, opts ...http.CallOption) (rsp *CreatePrescriptionReply, err error)
 	GetDrug(ctx context.Context, req *GetDrugRequest, opts ...http.CallOption) (rsp *GetDrugReply, err error)
 	GetExplain(ctx context.Context, req *GetExplainRequest, opts ...http.CallOption) (rsp *GetExplainReply, err error)
@@ -281,7 +358,9 @@ type DrugHTTPClient interface {
 	GetInventory(ctx context.Context, req *GetInventoryRequest, opts ...http.CallOption) (rsp *GetInventoryReply, err error)
 	ListDrug(ctx context.Context, req *ListDrugRequest, opts ...http.CallOption) (rsp *ListDrugReply, err error)
 	ListPrescriptions(ctx context.Context, req *ListPrescriptionsRequest, opts ...http.CallOption) (rsp *ListPrescriptionsReply, err error)
+	RecommendBySymptoms(ctx context.Context, req *RecommendBySymptomsRequest, opts ...http.CallOption) (rsp *RecommendBySymptomsReply, err error)
 	SearchDrugs(ctx context.Context, req *SearchDrugsRequest, opts ...http.CallOption) (rsp *SearchDrugsReply, err error)
+	SuggestDrugs(ctx context.Context, req *SuggestDrugsRequest, opts ...http.CallOption) (rsp *SuggestDrugsReply, err error)
 	UpdateInventory(ctx context.Context, req *UpdateInventoryRequest, opts ...http.CallOption) (rsp *UpdateInventoryReply, err error)
 }
 
@@ -293,6 +372,19 @@ func NewDrugHTTPClient(client *http.Client) DrugHTTPClient {
 	return &DrugHTTPClientImpl{client}
 }
 
+func (c *DrugHTTPClientImpl) CheckDrugInteractions(ctx context.Context, in *CheckDrugInteractionsRequest, opts ...http.CallOption) (*CheckDrugInteractionsReply, error) {
+	var out CheckDrugInteractionsReply
+	pattern := "/v1/drug/interactions/check"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationDrugCheckDrugInteractions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *DrugHTTPClientImpl) CreatePrescription(ctx context.Context, in *CreatePrescriptionRequest, opts ...http.CallOption) (*CreatePrescriptionReply, error) {
 	var out CreatePrescriptionReply
 	pattern := "/v1/drug/prescription"
@@ -397,6 +489,19 @@ func (c *DrugHTTPClientImpl) ListPrescriptions(ctx context.Context, in *ListPres
 	return &out, nil
 }
 
+func (c *DrugHTTPClientImpl) RecommendBySymptoms(ctx context.Context, in *RecommendBySymptomsRequest, opts ...http.CallOption) (*RecommendBySymptomsReply, error) {
+	var out RecommendBySymptomsReply
+	pattern := "/v1/drug/symptom-recommend"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationDrugRecommendBySymptoms))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *DrugHTTPClientImpl) SearchDrugs(ctx context.Context, in *SearchDrugsRequest, opts ...http.CallOption) (*SearchDrugsReply, error) {
 	var out SearchDrugsReply
 	pattern := "/v1/drug/search"
@@ -410,6 +515,19 @@ func (c *DrugHTTPClientImpl) SearchDrugs(ctx context.Context, in *SearchDrugsReq
 	return &out, nil
 }
 
+func (c *DrugHTTPClientImpl) SuggestDrugs(ctx context.Context, in *SuggestDrugsRequest, opts ...http.CallOption) (*SuggestDrugsReply, error) {
+	var out SuggestDrugsReply
+	pattern := "/v1/drug/suggest"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationDrugSuggestDrugs))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *DrugHTTPClientImpl) UpdateInventory(ctx context.Context, in *UpdateInventoryRequest, opts ...http.CallOption) (*UpdateInventoryReply, error) {
 	var out UpdateInventoryReply
 	pattern := "/v1/drug/inventory/update"
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	v1 "kratos_client/api/drug/v1"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -34,6 +35,14 @@ type CreateOrderRequest struct {
 	AddressDetail string                 `protobuf:"bytes,7,opt,name=address_detail,json=addressDetail,proto3" json:"address_detail,omitempty"`
 	Items         []*OrderItem           `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
 	Remark        string                 `protobuf:"bytes,9,opt,name=remark,proto3" json:"remark,omitempty"`
+	// 使用的用户优惠券ID(mt_discount_user.id)，0表示不使用
+	UserCouponId int64 `protobuf:"varint,10,opt,name=user_coupon_id,json=userCouponId,proto3" json:"user_coupon_id,omitempty"`
+	// 幂等键，也可通过 Idempotency-Key 请求头传递；同一用户重复提交返回首次创建的订单
+	IdempotencyKey string `protobuf:"bytes,11,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// 关联的处方ID，订单含处方药时必填，须为本人审核通过、未过期且未使用的处方
+	PrescriptionId uint64 `protobuf:"varint,12,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	// 用药人所属人群，用于检查用药禁忌：pregnancy孕妇 lactation哺乳期 child儿童 elderly老人
+	Populations   []string `protobuf:"bytes,13,rep,name=populations,proto3" json:"populations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -131,6 +140,34 @@ func (x *CreateOrderRequest) GetRemark() string {
 	return ""
 }
 
+func (x *CreateOrderRequest) GetUserCouponId() int64 {
+	if x != nil {
+		return x.UserCouponId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetPopulations() []string {
+	if x != nil {
+		return x.Populations
+	}
+	return nil
+}
+
 // 订单项
 type OrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -186,13 +223,16 @@ func (x *OrderItem) GetQuantity() int32 {
 
 // 创建订单响应
 type CreateOrderReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderNo       string                 `protobuf:"bytes,1,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
-	TotalAmount   string                 `protobuf:"bytes,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderNo        string                 `protobuf:"bytes,1,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	TotalAmount    string                 `protobuf:"bytes,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	OriginalAmount string                 `protobuf:"bytes,5,opt,name=original_amount,json=originalAmount,proto3" json:"original_amount,omitempty"` // 优惠前金额
+	DiscountAmount string                 `protobuf:"bytes,6,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"` // 优惠金额
+	DrugWarnings   []*v1.DrugWarning      `protobuf:"bytes,7,rep,name=drug_warnings,json=drugWarnings,proto3" json:"drug_warnings,omitempty"`       // 不拦截下单的用药提示
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateOrderReply) Reset() {
@@ -253,6 +293,27 @@ func (x *CreateOrderReply) GetMessage() string {
 	return ""
 }
 
+func (x *CreateOrderReply) GetOriginalAmount() string {
+	if x != nil {
+		return x.OriginalAmount
+	}
+	return ""
+}
+
+func (x *CreateOrderReply) GetDiscountAmount() string {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return ""
+}
+
+func (x *CreateOrderReply) GetDrugWarnings() []*v1.DrugWarning {
+	if x != nil {
+		return x.DrugWarnings
+	}
+	return nil
+}
+
 // 获取订单请求
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -353,27 +414,31 @@ func (x *GetOrderReply) GetItems() []*OrderItemDetail {
 
 // 订单信息
 type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	OrderNo       string                 `protobuf:"bytes,2,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
-	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserName      string                 `protobuf:"bytes,4,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
-	UserPhone     string                 `protobuf:"bytes,5,opt,name=user_phone,json=userPhone,proto3" json:"user_phone,omitempty"`
-	DoctorId      int64                  `protobuf:"varint,6,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
-	DoctorName    string                 `protobuf:"bytes,7,opt,name=doctor_name,json=doctorName,proto3" json:"doctor_name,omitempty"`
-	AddressId     int64                  `protobuf:"varint,8,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
-	AddressDetail string                 `protobuf:"bytes,9,opt,name=address_detail,json=addressDetail,proto3" json:"address_detail,omitempty"`
-	TotalAmount   string                 `protobuf:"bytes,10,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	PayType       string                 `protobuf:"bytes,11,opt,name=pay_type,json=payType,proto3" json:"pay_type,omitempty"`
-	Status        string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
-	PayTime       string                 `protobuf:"bytes,13,opt,name=pay_time,json=payTime,proto3" json:"pay_time,omitempty"`
-	DrugTime      string                 `protobuf:"bytes,14,opt,name=drug_time,json=drugTime,proto3" json:"drug_time,omitempty"`
-	SendTime      string                 `protobuf:"bytes,15,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`
-	FinishTime    string                 `protobuf:"bytes,16,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
-	CancelTime    string                 `protobuf:"bytes,17,opt,name=cancel_time,json=cancelTime,proto3" json:"cancel_time,omitempty"`
-	Remark        string                 `protobuf:"bytes,18,opt,name=remark,proto3" json:"remark,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderNo        string                 `protobuf:"bytes,2,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	UserId         int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName       string                 `protobuf:"bytes,4,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	UserPhone      string                 `protobuf:"bytes,5,opt,name=user_phone,json=userPhone,proto3" json:"user_phone,omitempty"`
+	DoctorId       int64                  `protobuf:"varint,6,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	DoctorName     string                 `protobuf:"bytes,7,opt,name=doctor_name,json=doctorName,proto3" json:"doctor_name,omitempty"`
+	AddressId      int64                  `protobuf:"varint,8,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	AddressDetail  string                 `protobuf:"bytes,9,opt,name=address_detail,json=addressDetail,proto3" json:"address_detail,omitempty"`
+	TotalAmount    string                 `protobuf:"bytes,10,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	PayType        string                 `protobuf:"bytes,11,opt,name=pay_type,json=payType,proto3" json:"pay_type,omitempty"`
+	Status         string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
+	PayTime        string                 `protobuf:"bytes,13,opt,name=pay_time,json=payTime,proto3" json:"pay_time,omitempty"`
+	DrugTime       string                 `protobuf:"bytes,14,opt,name=drug_time,json=drugTime,proto3" json:"drug_time,omitempty"`
+	SendTime       string                 `protobuf:"bytes,15,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`
+	FinishTime     string                 `protobuf:"bytes,16,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
+	CancelTime     string                 `protobuf:"bytes,17,opt,name=cancel_time,json=cancelTime,proto3" json:"cancel_time,omitempty"`
+	Remark         string                 `protobuf:"bytes,18,opt,name=remark,proto3" json:"remark,omitempty"`
+	UserCouponId   int64                  `protobuf:"varint,19,opt,name=user_coupon_id,json=userCouponId,proto3" json:"user_coupon_id,omitempty"`     // 使用的用户优惠券ID
+	OriginalAmount string                 `protobuf:"bytes,20,opt,name=original_amount,json=originalAmount,proto3" json:"original_amount,omitempty"`  // 优惠前金额
+	DiscountAmount string                 `protobuf:"bytes,21,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`  // 优惠金额
+	PrescriptionId uint64                 `protobuf:"varint,22,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"` // 关联处方ID，0表示未关联
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
@@ -532,6 +597,34 @@ func (x *Order) GetRemark() string {
 	return ""
 }
 
+func (x *Order) GetUserCouponId() int64 {
+	if x != nil {
+		return x.UserCouponId
+	}
+	return 0
+}
+
+func (x *Order) GetOriginalAmount() string {
+	if x != nil {
+		return x.OriginalAmount
+	}
+	return ""
+}
+
+func (x *Order) GetDiscountAmount() string {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return ""
+}
+
+func (x *Order) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
 // 订单项详情
 type OrderItemDetail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -938,11 +1031,202 @@ func (x *ProcessPaymentReply) GetSuccess() bool {
 	return false
 }
 
+// 获取订单状态流转记录请求
+type ListOrderStatusLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderNo       string                 `protobuf:"bytes,1,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderStatusLogsRequest) Reset() {
+	*x = ListOrderStatusLogsRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderStatusLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderStatusLogsRequest) ProtoMessage() {}
+
+func (x *ListOrderStatusLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderStatusLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderStatusLogsRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListOrderStatusLogsRequest) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+// 获取订单状态流转记录响应
+type ListOrderStatusLogsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*OrderStatusLog      `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderStatusLogsReply) Reset() {
+	*x = ListOrderStatusLogsReply{}
+	mi := &file_order_v1_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderStatusLogsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderStatusLogsReply) ProtoMessage() {}
+
+func (x *ListOrderStatusLogsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderStatusLogsReply.ProtoReflect.Descriptor instead.
+func (*ListOrderStatusLogsReply) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListOrderStatusLogsReply) GetLogs() []*OrderStatusLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+// 订单状态流转记录
+type OrderStatusLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderNo       string                 `protobuf:"bytes,2,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	OperatorType  string                 `protobuf:"bytes,5,opt,name=operator_type,json=operatorType,proto3" json:"operator_type,omitempty"`
+	OperatorId    int64                  `protobuf:"varint,6,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusLog) Reset() {
+	*x = OrderStatusLog{}
+	mi := &file_order_v1_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusLog) ProtoMessage() {}
+
+func (x *OrderStatusLog) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusLog.ProtoReflect.Descriptor instead.
+func (*OrderStatusLog) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrderStatusLog) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrderStatusLog) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *OrderStatusLog) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusLog) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusLog) GetOperatorType() string {
+	if x != nil {
+		return x.OperatorType
+	}
+	return ""
+}
+
+func (x *OrderStatusLog) GetOperatorId() int64 {
+	if x != nil {
+		return x.OperatorId
+	}
+	return 0
+}
+
+func (x *OrderStatusLog) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusLog) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
 var File_order_v1_order_proto protoreflect.FileDescriptor
 
 const file_order_v1_order_proto_rawDesc = "" +
 	"\n" +
-	"\x14order/v1/order.proto\x12\fapi.order.v1\x1a\x1cgoogle/api/annotations.proto\"\xb4\x02\n" +
+	"\x14order/v1/order.proto\x12\fapi.order.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x12drug/v1/drug.proto\"\xce\x03\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1b\n" +
 	"\tuser_name\x18\x02 \x01(\tR\buserName\x12\x1d\n" +
@@ -955,20 +1239,28 @@ const file_order_v1_order_proto_rawDesc = "" +
 	"address_id\x18\x06 \x01(\x03R\taddressId\x12%\n" +
 	"\x0eaddress_detail\x18\a \x01(\tR\raddressDetail\x12-\n" +
 	"\x05items\x18\b \x03(\v2\x17.api.order.v1.OrderItemR\x05items\x12\x16\n" +
-	"\x06remark\x18\t \x01(\tR\x06remark\"@\n" +
+	"\x06remark\x18\t \x01(\tR\x06remark\x12$\n" +
+	"\x0euser_coupon_id\x18\n" +
+	" \x01(\x03R\fuserCouponId\x12'\n" +
+	"\x0fidempotency_key\x18\v \x01(\tR\x0eidempotencyKey\x12'\n" +
+	"\x0fprescription_id\x18\f \x01(\x04R\x0eprescriptionId\x12 \n" +
+	"\vpopulations\x18\r \x03(\tR\vpopulations\"@\n" +
 	"\tOrderItem\x12\x17\n" +
 	"\adrug_id\x18\x01 \x01(\x03R\x06drugId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x82\x01\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x8f\x02\n" +
 	"\x10CreateOrderReply\x12\x19\n" +
 	"\border_no\x18\x01 \x01(\tR\aorderNo\x12!\n" +
 	"\ftotal_amount\x18\x02 \x01(\tR\vtotalAmount\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\",\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12'\n" +
+	"\x0foriginal_amount\x18\x05 \x01(\tR\x0eoriginalAmount\x12'\n" +
+	"\x0fdiscount_amount\x18\x06 \x01(\tR\x0ediscountAmount\x129\n" +
+	"\rdrug_warnings\x18\a \x03(\v2\x14.drug.v1.DrugWarningR\fdrugWarnings\",\n" +
 	"\x0fGetOrderRequest\x12\x19\n" +
 	"\border_no\x18\x01 \x01(\tR\aorderNo\"o\n" +
 	"\rGetOrderReply\x12)\n" +
 	"\x05order\x18\x01 \x01(\v2\x13.api.order.v1.OrderR\x05order\x123\n" +
-	"\x05items\x18\x02 \x03(\v2\x1d.api.order.v1.OrderItemDetailR\x05items\"\x90\x04\n" +
+	"\x05items\x18\x02 \x03(\v2\x1d.api.order.v1.OrderItemDetailR\x05items\"\xb1\x05\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
 	"\border_no\x18\x02 \x01(\tR\aorderNo\x12\x17\n" +
@@ -993,7 +1285,11 @@ const file_order_v1_order_proto_rawDesc = "" +
 	"finishTime\x12\x1f\n" +
 	"\vcancel_time\x18\x11 \x01(\tR\n" +
 	"cancelTime\x12\x16\n" +
-	"\x06remark\x18\x12 \x01(\tR\x06remark\"\xdd\x01\n" +
+	"\x06remark\x18\x12 \x01(\tR\x06remark\x12$\n" +
+	"\x0euser_coupon_id\x18\x13 \x01(\x03R\fuserCouponId\x12'\n" +
+	"\x0foriginal_amount\x18\x14 \x01(\tR\x0eoriginalAmount\x12'\n" +
+	"\x0fdiscount_amount\x18\x15 \x01(\tR\x0ediscountAmount\x12'\n" +
+	"\x0fprescription_id\x18\x16 \x01(\x04R\x0eprescriptionId\"\xdd\x01\n" +
 	"\x0fOrderItemDetail\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x17\n" +
@@ -1023,12 +1319,29 @@ const file_order_v1_order_proto_rawDesc = "" +
 	"\btrade_no\x18\x04 \x01(\tR\atradeNo\"I\n" +
 	"\x13ProcessPaymentReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess2\xf1\x03\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"7\n" +
+	"\x1aListOrderStatusLogsRequest\x12\x19\n" +
+	"\border_no\x18\x01 \x01(\tR\aorderNo\"L\n" +
+	"\x18ListOrderStatusLogsReply\x120\n" +
+	"\x04logs\x18\x01 \x03(\v2\x1c.api.order.v1.OrderStatusLogR\x04logs\"\xf6\x01\n" +
+	"\x0eOrderStatusLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_no\x18\x02 \x01(\tR\aorderNo\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12#\n" +
+	"\roperator_type\x18\x05 \x01(\tR\foperatorType\x12\x1f\n" +
+	"\voperator_id\x18\x06 \x01(\x03R\n" +
+	"operatorId\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt2\x8a\x05\n" +
 	"\fOrderService\x12j\n" +
 	"\vCreateOrder\x12 .api.order.v1.CreateOrderRequest\x1a\x1e.api.order.v1.CreateOrderReply\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/api/v1/orders\x12i\n" +
 	"\bGetOrder\x12\x1d.api.order.v1.GetOrderRequest\x1a\x1b.api.order.v1.GetOrderReply\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/orders/{order_no}\x12\x80\x01\n" +
 	"\x0eListUserOrders\x12#.api.order.v1.ListUserOrdersRequest\x1a!.api.order.v1.ListUserOrdersReply\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/users/{user_id}/orders\x12\x86\x01\n" +
-	"\x0eProcessPayment\x12#.api.order.v1.ProcessPaymentRequest\x1a!.api.order.v1.ProcessPaymentReply\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/orders/{order_no}/paymentB\x1fZ\x1dkratos_client/api/order/v1;v1b\x06proto3"
+	"\x0eProcessPayment\x12#.api.order.v1.ProcessPaymentRequest\x1a!.api.order.v1.ProcessPaymentReply\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/orders/{order_no}/payment\x12\x96\x01\n" +
+	"\x13ListOrderStatusLogs\x12(.api.order.v1.ListOrderStatusLogsRequest\x1a&.api.order.v1.ListOrderStatusLogsReply\"-\x82\xd3\xe4\x93\x02'\x12%/api/v1/orders/{order_no}/status-logsB\x1fZ\x1dkratos_client/api/order/v1;v1b\x06proto3"
 
 var (
 	file_order_v1_order_proto_rawDescOnce sync.Once
@@ -1042,39 +1355,47 @@ func file_order_v1_order_proto_rawDescGZIP() []byte {
 	return file_order_v1_order_proto_rawDescData
 }
 
-var file_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_order_v1_order_proto_goTypes = []any{
-	(*CreateOrderRequest)(nil),    // 0: api.order.v1.CreateOrderRequest
-	(*OrderItem)(nil),             // 1: api.order.v1.OrderItem
-	(*CreateOrderReply)(nil),      // 2: api.order.v1.CreateOrderReply
-	(*GetOrderRequest)(nil),       // 3: api.order.v1.GetOrderRequest
-	(*GetOrderReply)(nil),         // 4: api.order.v1.GetOrderReply
-	(*Order)(nil),                 // 5: api.order.v1.Order
-	(*OrderItemDetail)(nil),       // 6: api.order.v1.OrderItemDetail
-	(*ListUserOrdersRequest)(nil), // 7: api.order.v1.ListUserOrdersRequest
-	(*ListUserOrdersReply)(nil),   // 8: api.order.v1.ListUserOrdersReply
-	(*OrderSummary)(nil),          // 9: api.order.v1.OrderSummary
-	(*ProcessPaymentRequest)(nil), // 10: api.order.v1.ProcessPaymentRequest
-	(*ProcessPaymentReply)(nil),   // 11: api.order.v1.ProcessPaymentReply
+	(*CreateOrderRequest)(nil),         // 0: api.order.v1.CreateOrderRequest
+	(*OrderItem)(nil),                  // 1: api.order.v1.OrderItem
+	(*CreateOrderReply)(nil),           // 2: api.order.v1.CreateOrderReply
+	(*GetOrderRequest)(nil),            // 3: api.order.v1.GetOrderRequest
+	(*GetOrderReply)(nil),              // 4: api.order.v1.GetOrderReply
+	(*Order)(nil),                      // 5: api.order.v1.Order
+	(*OrderItemDetail)(nil),            // 6: api.order.v1.OrderItemDetail
+	(*ListUserOrdersRequest)(nil),      // 7: api.order.v1.ListUserOrdersRequest
+	(*ListUserOrdersReply)(nil),        // 8: api.order.v1.ListUserOrdersReply
+	(*OrderSummary)(nil),               // 9: api.order.v1.OrderSummary
+	(*ProcessPaymentRequest)(nil),      // 10: api.order.v1.ProcessPaymentRequest
+	(*ProcessPaymentReply)(nil),        // 11: api.order.v1.ProcessPaymentReply
+	(*ListOrderStatusLogsRequest)(nil), // 12: api.order.v1.ListOrderStatusLogsRequest
+	(*ListOrderStatusLogsReply)(nil),   // 13: api.order.v1.ListOrderStatusLogsReply
+	(*OrderStatusLog)(nil),             // 14: api.order.v1.OrderStatusLog
+	(*v1.DrugWarning)(nil),             // 15: drug.v1.DrugWarning
 }
 var file_order_v1_order_proto_depIdxs = []int32{
 	1,  // 0: api.order.v1.CreateOrderRequest.items:type_name -> api.order.v1.OrderItem
-	5,  // 1: api.order.v1.GetOrderReply.order:type_name -> api.order.v1.Order
-	6,  // 2: api.order.v1.GetOrderReply.items:type_name -> api.order.v1.OrderItemDetail
-	9,  // 3: api.order.v1.ListUserOrdersReply.orders:type_name -> api.order.v1.OrderSummary
-	0,  // 4: api.order.v1.OrderService.CreateOrder:input_type -> api.order.v1.CreateOrderRequest
-	3,  // 5: api.order.v1.OrderService.GetOrder:input_type -> api.order.v1.GetOrderRequest
-	7,  // 6: api.order.v1.OrderService.ListUserOrders:input_type -> api.order.v1.ListUserOrdersRequest
-	10, // 7: api.order.v1.OrderService.ProcessPayment:input_type -> api.order.v1.ProcessPaymentRequest
-	2,  // 8: api.order.v1.OrderService.CreateOrder:output_type -> api.order.v1.CreateOrderReply
-	4,  // 9: api.order.v1.OrderService.GetOrder:output_type -> api.order.v1.GetOrderReply
-	8,  // 10: api.order.v1.OrderService.ListUserOrders:output_type -> api.order.v1.ListUserOrdersReply
-	11, // 11: api.order.v1.OrderService.ProcessPayment:output_type -> api.order.v1.ProcessPaymentReply
-	8,  // [8:12] is the sub-list for method output_type
-	4,  // [4:8] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	15, // 1: api.order.v1.CreateOrderReply.drug_warnings:type_name -> drug.v1.DrugWarning
+	5,  // 2: api.order.v1.GetOrderReply.order:type_name -> api.order.v1.Order
+	6,  // 3: api.order.v1.GetOrderReply.items:type_name -> api.order.v1.OrderItemDetail
+	9,  // 4: api.order.v1.ListUserOrdersReply.orders:type_name -> api.order.v1.OrderSummary
+	14, // 5: api.order.v1.ListOrderStatusLogsReply.logs:type_name -> api.order.v1.OrderStatusLog
+	0,  // 6: api.order.v1.OrderService.CreateOrder:input_type -> api.order.v1.CreateOrderRequest
+	3,  // 7: api.order.v1.OrderService.GetOrder:input_type -> api.order.v1.GetOrderRequest
+	7,  // 8: api.order.v1.OrderService.ListUserOrders:input_type -> api.order.v1.ListUserOrdersRequest
+	10, // 9: api.order.v1.OrderService.ProcessPayment:input_type -> api.order.v1.ProcessPaymentRequest
+	12, // 10: api.order.v1.OrderService.ListOrderStatusLogs:input_type -> api.order.v1.ListOrderStatusLogsRequest
+	2,  // 11: api.order.v1.OrderService.CreateOrder:output_type -> api.order.v1.CreateOrderReply
+	4,  // 12: api.order.v1.OrderService.GetOrder:output_type -> api.order.v1.GetOrderReply
+	8,  // 13: api.order.v1.OrderService.ListUserOrders:output_type -> api.order.v1.ListUserOrdersReply
+	11, // 14: api.order.v1.OrderService.ProcessPayment:output_type -> api.order.v1.ProcessPaymentReply
+	13, // 15: api.order.v1.OrderService.ListOrderStatusLogs:output_type -> api.order.v1.ListOrderStatusLogsReply
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_order_v1_order_proto_init() }
@@ -1088,7 +1409,7 @@ func file_order_v1_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_v1_order_proto_rawDesc), len(file_order_v1_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
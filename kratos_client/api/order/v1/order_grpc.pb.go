@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderService_CreateOrder_FullMethodName    = "/api.order.v1.OrderService/CreateOrder"
-	OrderService_GetOrder_FullMethodName       = "/api.order.v1.OrderService/GetOrder"
-	OrderService_ListUserOrders_FullMethodName = "/api.order.v1.OrderService/ListUserOrders"
-	OrderService_ProcessPayment_FullMethodName = "/api.order.v1.OrderService/ProcessPayment"
+	OrderService_CreateOrder_FullMethodName         = "/api.order.v1.OrderService/CreateOrder"
+	OrderService_GetOrder_FullMethodName            = "/api.order.v1.OrderService/GetOrder"
+	OrderService_ListUserOrders_FullMethodName      = "/api.order.v1.OrderService/ListUserOrders"
+	OrderService_ProcessPayment_FullMethodName      = "/api.order.v1.OrderService/ProcessPayment"
+	OrderService_ListOrderStatusLogs_FullMethodName = "/api.order.v1.OrderService/ListOrderStatusLogs"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -39,6 +40,8 @@ type OrderServiceClient interface {
 	ListUserOrders(ctx context.Context, in *ListUserOrdersRequest, opts ...grpc.CallOption) (*ListUserOrdersReply, error)
 	// 处理支付
 	ProcessPayment(ctx context.Context, in *ProcessPaymentRequest, opts ...grpc.CallOption) (*ProcessPaymentReply, error)
+	// 获取订单状态流转记录，仅供客服后台调用，请求头 X-Admin-Token 需与配置 order.admin_token 一致
+	ListOrderStatusLogs(ctx context.Context, in *ListOrderStatusLogsRequest, opts ...grpc.CallOption) (*ListOrderStatusLogsReply, error)
 }
 
 type orderServiceClient struct {
@@ -89,6 +92,16 @@ func (c *orderServiceClient) ProcessPayment(ctx context.Context, in *ProcessPaym
 	return out, nil
 }
 
+func (c *orderServiceClient) ListOrderStatusLogs(ctx context.Context, in *ListOrderStatusLogsRequest, opts ...grpc.CallOption) (*ListOrderStatusLogsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrderStatusLogsReply)
+	err := c.cc.Invoke(ctx, OrderService_ListOrderStatusLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -103,6 +116,8 @@ type OrderServiceServer interface {
 	ListUserOrders(context.Context, *ListUserOrdersRequest) (*ListUserOrdersReply, error)
 	// 处理支付
 	ProcessPayment(context.Context, *ProcessPaymentRequest) (*ProcessPaymentReply, error)
+	// 获取订单状态流转记录，仅供客服后台调用，请求头 X-Admin-Token 需与配置 order.admin_token 一致
+	ListOrderStatusLogs(context.Context, *ListOrderStatusLogsRequest) (*ListOrderStatusLogsReply, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -125,6 +140,9 @@ func (UnimplementedOrderServiceServer) ListUserOrders(context.Context, *ListUser
 func (UnimplementedOrderServiceServer) ProcessPayment(context.Context, *ProcessPaymentRequest) (*ProcessPaymentReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessPayment not implemented")
 }
+func (UnimplementedOrderServiceServer) ListOrderStatusLogs(context.Context, *ListOrderStatusLogsRequest) (*ListOrderStatusLogsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrderStatusLogs not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -218,6 +236,24 @@ func _OrderService_ProcessPayment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_ListOrderStatusLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrderStatusLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrderStatusLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListOrderStatusLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrderStatusLogs(ctx, req.(*ListOrderStatusLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +277,10 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ProcessPayment",
 			Handler:    _OrderService_ProcessPayment_Handler,
 		},
+		{
+			MethodName: "ListOrderStatusLogs",
+			Handler:    _OrderService_ListOrderStatusLogs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order/v1/order.proto",
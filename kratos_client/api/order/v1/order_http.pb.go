@@ -21,6 +21,7 @@ const _ = http.SupportPackageIsVersion1
 
 const OperationOrderServiceCreateOrder = "/api.order.v1.OrderService/CreateOrder"
 const OperationOrderServiceGetOrder = "/api.order.v1.OrderService/GetOrder"
+const OperationOrderServiceListOrderStatusLogs = "/api.order.v1.OrderService/ListOrderStatusLogs"
 const OperationOrderServiceListUserOrders = "/api.order.v1.OrderService/ListUserOrders"
 const OperationOrderServiceProcessPayment = "/api.order.v1.OrderService/ProcessPayment"
 
@@ -29,6 +30,8 @@ type OrderServiceHTTPServer interface {
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderReply, error)
 	// GetOrder 获取订单详情
 	GetOrder(context.Context, *GetOrderRequest) (*GetOrderReply, error)
+	// ListOrderStatusLogs 获取订单状态流转记录，仅供客服后台调用，请求头 X-Admin-Token 需与配置 order.admin_token 一致
+	ListOrderStatusLogs(context.Context, *ListOrderStatusLogsRequest) (*ListOrderStatusLogsReply, error)
 	// ListUserOrders 获取用户订单列表
 	ListUserOrders(context.Context, *ListUserOrdersRequest) (*ListUserOrdersReply, error)
 	// ProcessPayment 处理支付
@@ -41,6 +44,7 @@ func RegisterOrderServiceHTTPServer(s *http.Server, srv OrderServiceHTTPServer)
 	r.GET("/api/v1/orders/{order_no}", _OrderService_GetOrder0_HTTP_Handler(srv))
 	r.GET("/api/v1/users/{user_id}/orders", _OrderService_ListUserOrders0_HTTP_Handler(srv))
 	r.POST("/api/v1/orders/{order_no}/payment", _OrderService_ProcessPayment0_HTTP_Handler(srv))
+	r.GET("/api/v1/orders/{order_no}/status-logs", _OrderService_ListOrderStatusLogs0_HTTP_Handler(srv))
 }
 
 func _OrderService_CreateOrder0_HTTP_Handler(srv OrderServiceHTTPServer) func(ctx http.Context) error {
@@ -134,9 +138,32 @@ func _OrderService_ProcessPayment0_HTTP_Handler(srv OrderServiceHTTPServer) func
 	}
 }
 
+func _OrderService_ListOrderStatusLogs0_HTTP_Handler(srv OrderServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListOrderStatusLogsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationOrderServiceListOrderStatusLogs)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListOrderStatusLogs(ctx, req.(*ListOrderStatusLogsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListOrderStatusLogsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type OrderServiceHTTPClient interface {
 	CreateOrder(ctx context.Context, req *CreateOrderRequest, opts ...http.CallOption) (rsp *CreateOrderReply, err error)
 	GetOrder(ctx context.Context, req *GetOrderRequest, opts ...http.CallOption) (rsp *GetOrderReply, err error)
+	ListOrderStatusLogs(ctx context.Context, req *ListOrderStatusLogsRequest, opts ...http.CallOption) (rsp *ListOrderStatusLogsReply, err error)
 	ListUserOrders(ctx context.Context, req *ListUserOrdersRequest, opts ...http.CallOption) (rsp *ListUserOrdersReply, err error)
 	ProcessPayment(ctx context.Context, req *ProcessPaymentRequest, opts ...http.CallOption) (rsp *ProcessPaymentReply, err error)
 }
@@ -175,6 +202,19 @@ func (c *OrderServiceHTTPClientImpl) GetOrder(ctx context.Context, in *GetOrderR
 	return &out, nil
 }
 
+func (c *OrderServiceHTTPClientImpl) ListOrderStatusLogs(ctx context.Context, in *ListOrderStatusLogsRequest, opts ...http.CallOption) (*ListOrderStatusLogsReply, error) {
+	var out ListOrderStatusLogsReply
+	pattern := "/api/v1/orders/{order_no}/status-logs"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationOrderServiceListOrderStatusLogs))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *OrderServiceHTTPClientImpl) ListUserOrders(ctx context.Context, in *ListUserOrdersRequest, opts ...http.CallOption) (*ListUserOrdersReply, error) {
 	var out ListUserOrdersReply
 	pattern := "/api/v1/users/{user_id}/orders"
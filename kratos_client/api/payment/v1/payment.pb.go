@@ -24,15 +24,17 @@ const (
 
 // 创建支付请求
 type CreatePaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                // 用户token
-	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`                            // 商品标题
-	TotalAmount   string                 `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"` // 支付金额
-	OrderType     string                 `protobuf:"bytes,4,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`       // 订单类型 (drug_order, consultation_order)
-	BusinessId    string                 `protobuf:"bytes,5,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`    // 业务ID (药品订单ID或咨询订单ID)
-	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`                    // 订单描述
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                         // 用户token
+	Subject        string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`                                     // 商品标题
+	TotalAmount    string                 `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`          // 支付金额
+	OrderType      string                 `protobuf:"bytes,4,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`                // 订单类型 (drug_order, consultation_order)
+	BusinessId     string                 `protobuf:"bytes,5,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`             // 业务ID (药品订单ID或咨询订单ID)
+	Description    string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`                             // 订单描述
+	IdempotencyKey string                 `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，也可通过 Idempotency-Key 请求头传递
+	PayType        string                 `protobuf:"bytes,8,opt,name=pay_type,json=payType,proto3" json:"pay_type,omitempty"`                      // 支付方式 1:微信 2:支付宝，默认支付宝
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreatePaymentRequest) Reset() {
@@ -107,6 +109,20 @@ func (x *CreatePaymentRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreatePaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequest) GetPayType() string {
+	if x != nil {
+		return x.PayType
+	}
+	return ""
+}
+
 // 创建支付响应
 type CreatePaymentReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -486,22 +502,551 @@ func (x *PaymentReturnReply) GetRedirectUrl() string {
 	return ""
 }
 
+// 申请退款请求
+type RequestRefundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                    // 用户token
+	OrderNo       string                 `protobuf:"bytes,2,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"` // 药品订单号
+	Items         []*RefundItem          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`                    // 退款的订单项
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                  // 退款原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestRefundRequest) Reset() {
+	*x = RequestRefundRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestRefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestRefundRequest) ProtoMessage() {}
+
+func (x *RequestRefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestRefundRequest.ProtoReflect.Descriptor instead.
+func (*RequestRefundRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RequestRefundRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RequestRefundRequest) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *RequestRefundRequest) GetItems() []*RefundItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RequestRefundRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 申请退款响应
+type RequestRefundReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RefundInfo    *RefundInfo            `protobuf:"bytes,3,opt,name=refund_info,json=refundInfo,proto3" json:"refund_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestRefundReply) Reset() {
+	*x = RequestRefundReply{}
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestRefundReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestRefundReply) ProtoMessage() {}
+
+func (x *RequestRefundReply) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestRefundReply.ProtoReflect.Descriptor instead.
+func (*RequestRefundReply) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RequestRefundReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *RequestRefundReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestRefundReply) GetRefundInfo() *RefundInfo {
+	if x != nil {
+		return x.RefundInfo
+	}
+	return nil
+}
+
+// 查询退款单请求
+type ListRefundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                    // 用户token
+	OrderNo       string                 `protobuf:"bytes,2,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"` // 药品订单号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefundsRequest) Reset() {
+	*x = ListRefundsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefundsRequest) ProtoMessage() {}
+
+func (x *ListRefundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefundsRequest.ProtoReflect.Descriptor instead.
+func (*ListRefundsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRefundsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListRefundsRequest) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+// 查询退款单响应
+type ListRefundsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Refunds       []*RefundInfo          `protobuf:"bytes,3,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefundsReply) Reset() {
+	*x = ListRefundsReply{}
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefundsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefundsReply) ProtoMessage() {}
+
+func (x *ListRefundsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefundsReply.ProtoReflect.Descriptor instead.
+func (*ListRefundsReply) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRefundsReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ListRefundsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListRefundsReply) GetRefunds() []*RefundInfo {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+// 退款通知请求
+type RefundNotifyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Params        map[string]string      `protobuf:"bytes,1,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 支付渠道回调参数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundNotifyRequest) Reset() {
+	*x = RefundNotifyRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundNotifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundNotifyRequest) ProtoMessage() {}
+
+func (x *RefundNotifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundNotifyRequest.ProtoReflect.Descriptor instead.
+func (*RefundNotifyRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RefundNotifyRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// 退款通知响应
+type RefundNotifyReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"` // success 或 fail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundNotifyReply) Reset() {
+	*x = RefundNotifyReply{}
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundNotifyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundNotifyReply) ProtoMessage() {}
+
+func (x *RefundNotifyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundNotifyReply.ProtoReflect.Descriptor instead.
+func (*RefundNotifyReply) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefundNotifyReply) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+// 退款订单项
+type RefundItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderItemId   int64                  `protobuf:"varint,1,opt,name=order_item_id,json=orderItemId,proto3" json:"order_item_id,omitempty"` // 订单项ID
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`                            // 退款数量
+	DrugId        int64                  `protobuf:"varint,3,opt,name=drug_id,json=drugId,proto3" json:"drug_id,omitempty"`                  // 药品ID
+	DrugName      string                 `protobuf:"bytes,4,opt,name=drug_name,json=drugName,proto3" json:"drug_name,omitempty"`             // 药品名称
+	Amount        string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`                                 // 退款金额
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundItem) Reset() {
+	*x = RefundItem{}
+	mi := &file_payment_v1_payment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundItem) ProtoMessage() {}
+
+func (x *RefundItem) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundItem.ProtoReflect.Descriptor instead.
+func (*RefundItem) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefundItem) GetOrderItemId() int64 {
+	if x != nil {
+		return x.OrderItemId
+	}
+	return 0
+}
+
+func (x *RefundItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RefundItem) GetDrugId() int64 {
+	if x != nil {
+		return x.DrugId
+	}
+	return 0
+}
+
+func (x *RefundItem) GetDrugName() string {
+	if x != nil {
+		return x.DrugName
+	}
+	return ""
+}
+
+func (x *RefundItem) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// 退款信息
+type RefundInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundId      string                 `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`             // 退款单号
+	OrderNo       string                 `protobuf:"bytes,2,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`                // 药品订单号
+	RefundAmount  string                 `protobuf:"bytes,3,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"` // 退款金额
+	RefundStatus  string                 `protobuf:"bytes,4,opt,name=refund_status,json=refundStatus,proto3" json:"refund_status,omitempty"` // 退款状态 (requested, approved, rejected, processing, succeeded, failed)
+	RefundReason  string                 `protobuf:"bytes,5,opt,name=refund_reason,json=refundReason,proto3" json:"refund_reason,omitempty"` // 退款原因
+	AuditRemark   string                 `protobuf:"bytes,6,opt,name=audit_remark,json=auditRemark,proto3" json:"audit_remark,omitempty"`    // 审核备注
+	FailReason    string                 `protobuf:"bytes,7,opt,name=fail_reason,json=failReason,proto3" json:"fail_reason,omitempty"`       // 失败原因
+	RefundTime    string                 `protobuf:"bytes,8,opt,name=refund_time,json=refundTime,proto3" json:"refund_time,omitempty"`       // 退款时间
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // 申请时间
+	Items         []*RefundItem          `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundInfo) Reset() {
+	*x = RefundInfo{}
+	mi := &file_payment_v1_payment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundInfo) ProtoMessage() {}
+
+func (x *RefundInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundInfo.ProtoReflect.Descriptor instead.
+func (*RefundInfo) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RefundInfo) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetRefundAmount() string {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetRefundStatus() string {
+	if x != nil {
+		return x.RefundStatus
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetRefundReason() string {
+	if x != nil {
+		return x.RefundReason
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetAuditRemark() string {
+	if x != nil {
+		return x.AuditRemark
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetRefundTime() string {
+	if x != nil {
+		return x.RefundTime
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetItems() []*RefundItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 // 支付信息
 type PaymentInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`             // 订单号
-	TradeNo       string                 `protobuf:"bytes,2,opt,name=trade_no,json=tradeNo,proto3" json:"trade_no,omitempty"`             // 支付宝交易号
+	TradeNo       string                 `protobuf:"bytes,2,opt,name=trade_no,json=tradeNo,proto3" json:"trade_no,omitempty"`             // 渠道交易号
 	TotalAmount   string                 `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"` // 支付金额
 	TradeStatus   string                 `protobuf:"bytes,4,opt,name=trade_status,json=tradeStatus,proto3" json:"trade_status,omitempty"` // 交易状态
 	PayTime       string                 `protobuf:"bytes,5,opt,name=pay_time,json=payTime,proto3" json:"pay_time,omitempty"`             // 支付时间
 	Subject       string                 `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`                            // 商品标题
+	Channel       string                 `protobuf:"bytes,7,opt,name=channel,proto3" json:"channel,omitempty"`                            // 支付渠道 alipay、wechat、sandbox
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PaymentInfo) Reset() {
 	*x = PaymentInfo{}
-	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	mi := &file_payment_v1_payment_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -513,7 +1058,7 @@ func (x *PaymentInfo) String() string {
 func (*PaymentInfo) ProtoMessage() {}
 
 func (x *PaymentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	mi := &file_payment_v1_payment_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,7 +1071,7 @@ func (x *PaymentInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaymentInfo.ProtoReflect.Descriptor instead.
 func (*PaymentInfo) Descriptor() ([]byte, []int) {
-	return file_payment_v1_payment_proto_rawDescGZIP(), []int{8}
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PaymentInfo) GetOrderId() string {
@@ -571,11 +1116,124 @@ func (x *PaymentInfo) GetSubject() string {
 	return ""
 }
 
+func (x *PaymentInfo) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+// 沙箱模拟支付请求
+type SandboxPayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 支付订单号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SandboxPayRequest) Reset() {
+	*x = SandboxPayRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SandboxPayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxPayRequest) ProtoMessage() {}
+
+func (x *SandboxPayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxPayRequest.ProtoReflect.Descriptor instead.
+func (*SandboxPayRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SandboxPayRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// 沙箱模拟支付响应
+type SandboxPayReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PaymentInfo   *PaymentInfo           `protobuf:"bytes,3,opt,name=payment_info,json=paymentInfo,proto3" json:"payment_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SandboxPayReply) Reset() {
+	*x = SandboxPayReply{}
+	mi := &file_payment_v1_payment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SandboxPayReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxPayReply) ProtoMessage() {}
+
+func (x *SandboxPayReply) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxPayReply.ProtoReflect.Descriptor instead.
+func (*SandboxPayReply) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SandboxPayReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *SandboxPayReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SandboxPayReply) GetPaymentInfo() *PaymentInfo {
+	if x != nil {
+		return x.PaymentInfo
+	}
+	return nil
+}
+
 var File_payment_v1_payment_proto protoreflect.FileDescriptor
 
 const file_payment_v1_payment_proto_rawDesc = "" +
 	"\n" +
-	"\x18payment/v1/payment.proto\x12\x0eapi.payment.v1\x1a\x1cgoogle/api/annotations.proto\"\xcb\x01\n" +
+	"\x18payment/v1/payment.proto\x12\x0eapi.payment.v1\x1a\x1cgoogle/api/annotations.proto\"\x8f\x02\n" +
 	"\x14CreatePaymentRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x18\n" +
 	"\asubject\x18\x02 \x01(\tR\asubject\x12!\n" +
@@ -584,7 +1242,9 @@ const file_payment_v1_payment_proto_rawDesc = "" +
 	"order_type\x18\x04 \x01(\tR\torderType\x12\x1f\n" +
 	"\vbusiness_id\x18\x05 \x01(\tR\n" +
 	"businessId\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\"~\n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x12\x19\n" +
+	"\bpay_type\x18\b \x01(\tR\apayType\"~\n" +
 	"\x12CreatePaymentReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
@@ -613,19 +1273,78 @@ const file_payment_v1_payment_proto_rawDesc = "" +
 	"\x12PaymentReturnReply\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
-	"\fredirect_url\x18\x03 \x01(\tR\vredirectUrl\"\xbe\x01\n" +
+	"\fredirect_url\x18\x03 \x01(\tR\vredirectUrl\"\x91\x01\n" +
+	"\x14RequestRefundRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\border_no\x18\x02 \x01(\tR\aorderNo\x120\n" +
+	"\x05items\x18\x03 \x03(\v2\x1a.api.payment.v1.RefundItemR\x05items\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x7f\n" +
+	"\x12RequestRefundReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\vrefund_info\x18\x03 \x01(\v2\x1a.api.payment.v1.RefundInfoR\n" +
+	"refundInfo\"E\n" +
+	"\x12ListRefundsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\border_no\x18\x02 \x01(\tR\aorderNo\"v\n" +
+	"\x10ListRefundsReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\arefunds\x18\x03 \x03(\v2\x1a.api.payment.v1.RefundInfoR\arefunds\"\x99\x01\n" +
+	"\x13RefundNotifyRequest\x12G\n" +
+	"\x06params\x18\x01 \x03(\v2/.api.payment.v1.RefundNotifyRequest.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"+\n" +
+	"\x11RefundNotifyReply\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"\x9a\x01\n" +
+	"\n" +
+	"RefundItem\x12\"\n" +
+	"\rorder_item_id\x18\x01 \x01(\x03R\vorderItemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x17\n" +
+	"\adrug_id\x18\x03 \x01(\x03R\x06drugId\x12\x1b\n" +
+	"\tdrug_name\x18\x04 \x01(\tR\bdrugName\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\"\xe9\x02\n" +
+	"\n" +
+	"RefundInfo\x12\x1b\n" +
+	"\trefund_id\x18\x01 \x01(\tR\brefundId\x12\x19\n" +
+	"\border_no\x18\x02 \x01(\tR\aorderNo\x12#\n" +
+	"\rrefund_amount\x18\x03 \x01(\tR\frefundAmount\x12#\n" +
+	"\rrefund_status\x18\x04 \x01(\tR\frefundStatus\x12#\n" +
+	"\rrefund_reason\x18\x05 \x01(\tR\frefundReason\x12!\n" +
+	"\faudit_remark\x18\x06 \x01(\tR\vauditRemark\x12\x1f\n" +
+	"\vfail_reason\x18\a \x01(\tR\n" +
+	"failReason\x12\x1f\n" +
+	"\vrefund_time\x18\b \x01(\tR\n" +
+	"refundTime\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x120\n" +
+	"\x05items\x18\n" +
+	" \x03(\v2\x1a.api.payment.v1.RefundItemR\x05items\"\xd8\x01\n" +
 	"\vPaymentInfo\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x19\n" +
 	"\btrade_no\x18\x02 \x01(\tR\atradeNo\x12!\n" +
 	"\ftotal_amount\x18\x03 \x01(\tR\vtotalAmount\x12!\n" +
 	"\ftrade_status\x18\x04 \x01(\tR\vtradeStatus\x12\x19\n" +
 	"\bpay_time\x18\x05 \x01(\tR\apayTime\x12\x18\n" +
-	"\asubject\x18\x06 \x01(\tR\asubject2\xe7\x03\n" +
+	"\asubject\x18\x06 \x01(\tR\asubject\x12\x18\n" +
+	"\achannel\x18\a \x01(\tR\achannel\".\n" +
+	"\x11SandboxPayRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x7f\n" +
+	"\x0fSandboxPayReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12>\n" +
+	"\fpayment_info\x18\x03 \x01(\v2\x1b.api.payment.v1.PaymentInfoR\vpaymentInfo2\xc4\a\n" +
 	"\aPayment\x12x\n" +
 	"\rCreatePayment\x12$.api.payment.v1.CreatePaymentRequest\x1a\".api.payment.v1.CreatePaymentReply\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/payment/create\x12x\n" +
 	"\rPaymentNotify\x12$.api.payment.v1.PaymentNotifyRequest\x1a\".api.payment.v1.PaymentNotifyReply\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/payment/notify\x12q\n" +
 	"\fQueryPayment\x12#.api.payment.v1.QueryPaymentRequest\x1a!.api.payment.v1.QueryPaymentReply\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/payment/query\x12u\n" +
-	"\rPaymentReturn\x12$.api.payment.v1.PaymentReturnRequest\x1a\".api.payment.v1.PaymentReturnReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/payment/returnB!Z\x1fkratos_client/api/payment/v1;v1b\x06proto3"
+	"\rPaymentReturn\x12$.api.payment.v1.PaymentReturnRequest\x1a\".api.payment.v1.PaymentReturnReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/payment/return\x12x\n" +
+	"\rRequestRefund\x12$.api.payment.v1.RequestRefundRequest\x1a\".api.payment.v1.RequestRefundReply\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/payment/refund\x12p\n" +
+	"\vListRefunds\x12\".api.payment.v1.ListRefundsRequest\x1a .api.payment.v1.ListRefundsReply\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/payment/refunds\x12|\n" +
+	"\fRefundNotify\x12#.api.payment.v1.RefundNotifyRequest\x1a!.api.payment.v1.RefundNotifyReply\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/payment/refund/notify\x12q\n" +
+	"\n" +
+	"SandboxPay\x12!.api.payment.v1.SandboxPayRequest\x1a\x1f.api.payment.v1.SandboxPayReply\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/payment/sandbox/payB!Z\x1fkratos_client/api/payment/v1;v1b\x06proto3"
 
 var (
 	file_payment_v1_payment_proto_rawDescOnce sync.Once
@@ -639,7 +1358,7 @@ func file_payment_v1_payment_proto_rawDescGZIP() []byte {
 	return file_payment_v1_payment_proto_rawDescData
 }
 
-var file_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_payment_v1_payment_proto_goTypes = []any{
 	(*CreatePaymentRequest)(nil), // 0: api.payment.v1.CreatePaymentRequest
 	(*CreatePaymentReply)(nil),   // 1: api.payment.v1.CreatePaymentReply
@@ -649,27 +1368,52 @@ var file_payment_v1_payment_proto_goTypes = []any{
 	(*QueryPaymentReply)(nil),    // 5: api.payment.v1.QueryPaymentReply
 	(*PaymentReturnRequest)(nil), // 6: api.payment.v1.PaymentReturnRequest
 	(*PaymentReturnReply)(nil),   // 7: api.payment.v1.PaymentReturnReply
-	(*PaymentInfo)(nil),          // 8: api.payment.v1.PaymentInfo
-	nil,                          // 9: api.payment.v1.PaymentNotifyRequest.ParamsEntry
-	nil,                          // 10: api.payment.v1.PaymentReturnRequest.ParamsEntry
+	(*RequestRefundRequest)(nil), // 8: api.payment.v1.RequestRefundRequest
+	(*RequestRefundReply)(nil),   // 9: api.payment.v1.RequestRefundReply
+	(*ListRefundsRequest)(nil),   // 10: api.payment.v1.ListRefundsRequest
+	(*ListRefundsReply)(nil),     // 11: api.payment.v1.ListRefundsReply
+	(*RefundNotifyRequest)(nil),  // 12: api.payment.v1.RefundNotifyRequest
+	(*RefundNotifyReply)(nil),    // 13: api.payment.v1.RefundNotifyReply
+	(*RefundItem)(nil),           // 14: api.payment.v1.RefundItem
+	(*RefundInfo)(nil),           // 15: api.payment.v1.RefundInfo
+	(*PaymentInfo)(nil),          // 16: api.payment.v1.PaymentInfo
+	(*SandboxPayRequest)(nil),    // 17: api.payment.v1.SandboxPayRequest
+	(*SandboxPayReply)(nil),      // 18: api.payment.v1.SandboxPayReply
+	nil,                          // 19: api.payment.v1.PaymentNotifyRequest.ParamsEntry
+	nil,                          // 20: api.payment.v1.PaymentReturnRequest.ParamsEntry
+	nil,                          // 21: api.payment.v1.RefundNotifyRequest.ParamsEntry
 }
 var file_payment_v1_payment_proto_depIdxs = []int32{
-	9,  // 0: api.payment.v1.PaymentNotifyRequest.params:type_name -> api.payment.v1.PaymentNotifyRequest.ParamsEntry
-	8,  // 1: api.payment.v1.QueryPaymentReply.payment_info:type_name -> api.payment.v1.PaymentInfo
-	10, // 2: api.payment.v1.PaymentReturnRequest.params:type_name -> api.payment.v1.PaymentReturnRequest.ParamsEntry
-	0,  // 3: api.payment.v1.Payment.CreatePayment:input_type -> api.payment.v1.CreatePaymentRequest
-	2,  // 4: api.payment.v1.Payment.PaymentNotify:input_type -> api.payment.v1.PaymentNotifyRequest
-	4,  // 5: api.payment.v1.Payment.QueryPayment:input_type -> api.payment.v1.QueryPaymentRequest
-	6,  // 6: api.payment.v1.Payment.PaymentReturn:input_type -> api.payment.v1.PaymentReturnRequest
-	1,  // 7: api.payment.v1.Payment.CreatePayment:output_type -> api.payment.v1.CreatePaymentReply
-	3,  // 8: api.payment.v1.Payment.PaymentNotify:output_type -> api.payment.v1.PaymentNotifyReply
-	5,  // 9: api.payment.v1.Payment.QueryPayment:output_type -> api.payment.v1.QueryPaymentReply
-	7,  // 10: api.payment.v1.Payment.PaymentReturn:output_type -> api.payment.v1.PaymentReturnReply
-	7,  // [7:11] is the sub-list for method output_type
-	3,  // [3:7] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	19, // 0: api.payment.v1.PaymentNotifyRequest.params:type_name -> api.payment.v1.PaymentNotifyRequest.ParamsEntry
+	16, // 1: api.payment.v1.QueryPaymentReply.payment_info:type_name -> api.payment.v1.PaymentInfo
+	20, // 2: api.payment.v1.PaymentReturnRequest.params:type_name -> api.payment.v1.PaymentReturnRequest.ParamsEntry
+	14, // 3: api.payment.v1.RequestRefundRequest.items:type_name -> api.payment.v1.RefundItem
+	15, // 4: api.payment.v1.RequestRefundReply.refund_info:type_name -> api.payment.v1.RefundInfo
+	15, // 5: api.payment.v1.ListRefundsReply.refunds:type_name -> api.payment.v1.RefundInfo
+	21, // 6: api.payment.v1.RefundNotifyRequest.params:type_name -> api.payment.v1.RefundNotifyRequest.ParamsEntry
+	14, // 7: api.payment.v1.RefundInfo.items:type_name -> api.payment.v1.RefundItem
+	16, // 8: api.payment.v1.SandboxPayReply.payment_info:type_name -> api.payment.v1.PaymentInfo
+	0,  // 9: api.payment.v1.Payment.CreatePayment:input_type -> api.payment.v1.CreatePaymentRequest
+	2,  // 10: api.payment.v1.Payment.PaymentNotify:input_type -> api.payment.v1.PaymentNotifyRequest
+	4,  // 11: api.payment.v1.Payment.QueryPayment:input_type -> api.payment.v1.QueryPaymentRequest
+	6,  // 12: api.payment.v1.Payment.PaymentReturn:input_type -> api.payment.v1.PaymentReturnRequest
+	8,  // 13: api.payment.v1.Payment.RequestRefund:input_type -> api.payment.v1.RequestRefundRequest
+	10, // 14: api.payment.v1.Payment.ListRefunds:input_type -> api.payment.v1.ListRefundsRequest
+	12, // 15: api.payment.v1.Payment.RefundNotify:input_type -> api.payment.v1.RefundNotifyRequest
+	17, // 16: api.payment.v1.Payment.SandboxPay:input_type -> api.payment.v1.SandboxPayRequest
+	1,  // 17: api.payment.v1.Payment.CreatePayment:output_type -> api.payment.v1.CreatePaymentReply
+	3,  // 18: api.payment.v1.Payment.PaymentNotify:output_type -> api.payment.v1.PaymentNotifyReply
+	5,  // 19: api.payment.v1.Payment.QueryPayment:output_type -> api.payment.v1.QueryPaymentReply
+	7,  // 20: api.payment.v1.Payment.PaymentReturn:output_type -> api.payment.v1.PaymentReturnReply
+	9,  // 21: api.payment.v1.Payment.RequestRefund:output_type -> api.payment.v1.RequestRefundReply
+	11, // 22: api.payment.v1.Payment.ListRefunds:output_type -> api.payment.v1.ListRefundsReply
+	13, // 23: api.payment.v1.Payment.RefundNotify:output_type -> api.payment.v1.RefundNotifyReply
+	18, // 24: api.payment.v1.Payment.SandboxPay:output_type -> api.payment.v1.SandboxPayReply
+	17, // [17:25] is the sub-list for method output_type
+	9,  // [9:17] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_payment_v1_payment_proto_init() }
@@ -683,7 +1427,7 @@ func file_payment_v1_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_v1_payment_proto_rawDesc), len(file_payment_v1_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,10 @@ const (
 	Payment_PaymentNotify_FullMethodName = "/api.payment.v1.Payment/PaymentNotify"
 	Payment_QueryPayment_FullMethodName  = "/api.payment.v1.Payment/QueryPayment"
 	Payment_PaymentReturn_FullMethodName = "/api.payment.v1.Payment/PaymentReturn"
+	Payment_RequestRefund_FullMethodName = "/api.payment.v1.Payment/RequestRefund"
+	Payment_ListRefunds_FullMethodName   = "/api.payment.v1.Payment/ListRefunds"
+	Payment_RefundNotify_FullMethodName  = "/api.payment.v1.Payment/RefundNotify"
+	Payment_SandboxPay_FullMethodName    = "/api.payment.v1.Payment/SandboxPay"
 )
 
 // PaymentClient is the client API for Payment service.
@@ -39,6 +43,14 @@ type PaymentClient interface {
 	QueryPayment(ctx context.Context, in *QueryPaymentRequest, opts ...grpc.CallOption) (*QueryPaymentReply, error)
 	// 支付返回页面
 	PaymentReturn(ctx context.Context, in *PaymentReturnRequest, opts ...grpc.CallOption) (*PaymentReturnReply, error)
+	// 申请退款，按订单项部分退款，提交后等待后台审核
+	RequestRefund(ctx context.Context, in *RequestRefundRequest, opts ...grpc.CallOption) (*RequestRefundReply, error)
+	// 查询订单的退款单
+	ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsReply, error)
+	// 退款回调通知
+	RefundNotify(ctx context.Context, in *RefundNotifyRequest, opts ...grpc.CallOption) (*RefundNotifyReply, error)
+	// 沙箱模拟支付，访问沙箱渠道返回的支付链接即完成支付
+	SandboxPay(ctx context.Context, in *SandboxPayRequest, opts ...grpc.CallOption) (*SandboxPayReply, error)
 }
 
 type paymentClient struct {
@@ -89,6 +101,46 @@ func (c *paymentClient) PaymentReturn(ctx context.Context, in *PaymentReturnRequ
 	return out, nil
 }
 
+func (c *paymentClient) RequestRefund(ctx context.Context, in *RequestRefundRequest, opts ...grpc.CallOption) (*RequestRefundReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestRefundReply)
+	err := c.cc.Invoke(ctx, Payment_RequestRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefundsReply)
+	err := c.cc.Invoke(ctx, Payment_ListRefunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) RefundNotify(ctx context.Context, in *RefundNotifyRequest, opts ...grpc.CallOption) (*RefundNotifyReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundNotifyReply)
+	err := c.cc.Invoke(ctx, Payment_RefundNotify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) SandboxPay(ctx context.Context, in *SandboxPayRequest, opts ...grpc.CallOption) (*SandboxPayReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SandboxPayReply)
+	err := c.cc.Invoke(ctx, Payment_SandboxPay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServer is the server API for Payment service.
 // All implementations must embed UnimplementedPaymentServer
 // for forward compatibility.
@@ -103,6 +155,14 @@ type PaymentServer interface {
 	QueryPayment(context.Context, *QueryPaymentRequest) (*QueryPaymentReply, error)
 	// 支付返回页面
 	PaymentReturn(context.Context, *PaymentReturnRequest) (*PaymentReturnReply, error)
+	// 申请退款，按订单项部分退款，提交后等待后台审核
+	RequestRefund(context.Context, *RequestRefundRequest) (*RequestRefundReply, error)
+	// 查询订单的退款单
+	ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsReply, error)
+	// 退款回调通知
+	RefundNotify(context.Context, *RefundNotifyRequest) (*RefundNotifyReply, error)
+	// 沙箱模拟支付，访问沙箱渠道返回的支付链接即完成支付
+	SandboxPay(context.Context, *SandboxPayRequest) (*SandboxPayReply, error)
 	mustEmbedUnimplementedPaymentServer()
 }
 
@@ -125,6 +185,18 @@ func (UnimplementedPaymentServer) QueryPayment(context.Context, *QueryPaymentReq
 func (UnimplementedPaymentServer) PaymentReturn(context.Context, *PaymentReturnRequest) (*PaymentReturnReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PaymentReturn not implemented")
 }
+func (UnimplementedPaymentServer) RequestRefund(context.Context, *RequestRefundRequest) (*RequestRefundReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestRefund not implemented")
+}
+func (UnimplementedPaymentServer) ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefunds not implemented")
+}
+func (UnimplementedPaymentServer) RefundNotify(context.Context, *RefundNotifyRequest) (*RefundNotifyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundNotify not implemented")
+}
+func (UnimplementedPaymentServer) SandboxPay(context.Context, *SandboxPayRequest) (*SandboxPayReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxPay not implemented")
+}
 func (UnimplementedPaymentServer) mustEmbedUnimplementedPaymentServer() {}
 func (UnimplementedPaymentServer) testEmbeddedByValue()                 {}
 
@@ -218,6 +290,78 @@ func _Payment_PaymentReturn_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Payment_RequestRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).RequestRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_RequestRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).RequestRefund(ctx, req.(*RequestRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_ListRefunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).ListRefunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_ListRefunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).ListRefunds(ctx, req.(*ListRefundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_RefundNotify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundNotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).RefundNotify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_RefundNotify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).RefundNotify(ctx, req.(*RefundNotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_SandboxPay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SandboxPayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).SandboxPay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_SandboxPay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).SandboxPay(ctx, req.(*SandboxPayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Payment_ServiceDesc is the grpc.ServiceDesc for Payment service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +385,22 @@ var Payment_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PaymentReturn",
 			Handler:    _Payment_PaymentReturn_Handler,
 		},
+		{
+			MethodName: "RequestRefund",
+			Handler:    _Payment_RequestRefund_Handler,
+		},
+		{
+			MethodName: "ListRefunds",
+			Handler:    _Payment_ListRefunds_Handler,
+		},
+		{
+			MethodName: "RefundNotify",
+			Handler:    _Payment_RefundNotify_Handler,
+		},
+		{
+			MethodName: "SandboxPay",
+			Handler:    _Payment_SandboxPay_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "payment/v1/payment.proto",
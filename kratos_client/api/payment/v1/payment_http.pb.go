@@ -20,19 +20,31 @@ var _ = binding.EncodeURL
 const _ = http.SupportPackageIsVersion1
 
 const OperationPaymentCreatePayment = "/api.payment.v1.Payment/CreatePayment"
+const OperationPaymentListRefunds = "/api.payment.v1.Payment/ListRefunds"
 const OperationPaymentPaymentNotify = "/api.payment.v1.Payment/PaymentNotify"
 const OperationPaymentPaymentReturn = "/api.payment.v1.Payment/PaymentReturn"
 const OperationPaymentQueryPayment = "/api.payment.v1.Payment/QueryPayment"
+const OperationPaymentRefundNotify = "/api.payment.v1.Payment/RefundNotify"
+const OperationPaymentRequestRefund = "/api.payment.v1.Payment/RequestRefund"
+const OperationPaymentSandboxPay = "/api.payment.v1.Payment/SandboxPay"
 
 type PaymentHTTPServer interface {
 	// CreatePayment 创建支付订单
 	CreatePayment(context.Context, *CreatePaymentRequest) (*CreatePaymentReply, error)
+	// ListRefunds 查询订单的退款单
+	ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsReply, error)
 	// PaymentNotify 支付回调通知
 	PaymentNotify(context.Context, *PaymentNotifyRequest) (*PaymentNotifyReply, error)
 	// PaymentReturn 支付返回页面
 	PaymentReturn(context.Context, *PaymentReturnRequest) (*PaymentReturnReply, error)
 	// QueryPayment 支付结果查询
 	QueryPayment(context.Context, *QueryPaymentRequest) (*QueryPaymentReply, error)
+	// RefundNotify 退款回调通知
+	RefundNotify(context.Context, *RefundNotifyRequest) (*RefundNotifyReply, error)
+	// RequestRefund 申请退款，按订单项部分退款，提交后等待后台审核
+	RequestRefund(context.Context, *RequestRefundRequest) (*RequestRefundReply, error)
+	// SandboxPay 沙箱模拟支付，访问沙箱渠道返回的支付链接即完成支付
+	SandboxPay(context.Context, *SandboxPayRequest) (*SandboxPayReply, error)
 }
 
 func RegisterPaymentHTTPServer(s *http.Server, srv PaymentHTTPServer) {
@@ -41,6 +53,10 @@ func RegisterPaymentHTTPServer(s *http.Server, srv PaymentHTTPServer) {
 	r.POST("/v1/payment/notify", _Payment_PaymentNotify0_HTTP_Handler(srv))
 	r.GET("/v1/payment/query", _Payment_QueryPayment0_HTTP_Handler(srv))
 	r.GET("/v1/payment/return", _Payment_PaymentReturn0_HTTP_Handler(srv))
+	r.POST("/v1/payment/refund", _Payment_RequestRefund0_HTTP_Handler(srv))
+	r.GET("/v1/payment/refunds", _Payment_ListRefunds0_HTTP_Handler(srv))
+	r.POST("/v1/payment/refund/notify", _Payment_RefundNotify0_HTTP_Handler(srv))
+	r.GET("/v1/payment/sandbox/pay", _Payment_SandboxPay0_HTTP_Handler(srv))
 }
 
 func _Payment_CreatePayment0_HTTP_Handler(srv PaymentHTTPServer) func(ctx http.Context) error {
@@ -125,11 +141,97 @@ func _Payment_PaymentReturn0_HTTP_Handler(srv PaymentHTTPServer) func(ctx http.C
 	}
 }
 
+func _Payment_RequestRefund0_HTTP_Handler(srv PaymentHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RequestRefundRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPaymentRequestRefund)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RequestRefund(ctx, req.(*RequestRefundRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RequestRefundReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Payment_ListRefunds0_HTTP_Handler(srv PaymentHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListRefundsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPaymentListRefunds)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListRefunds(ctx, req.(*ListRefundsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListRefundsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Payment_RefundNotify0_HTTP_Handler(srv PaymentHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RefundNotifyRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPaymentRefundNotify)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RefundNotify(ctx, req.(*RefundNotifyRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RefundNotifyReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Payment_SandboxPay0_HTTP_Handler(srv PaymentHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SandboxPayRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPaymentSandboxPay)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SandboxPay(ctx, req.(*SandboxPayRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SandboxPayReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type PaymentHTTPClient interface {
 	CreatePayment(ctx context.Context, req *CreatePaymentRequest, opts ...http.CallOption) (rsp *CreatePaymentReply, err error)
+	ListRefunds(ctx context.Context, req *ListRefundsRequest, opts ...http.CallOption) (rsp *ListRefundsReply, err error)
 	PaymentNotify(ctx context.Context, req *PaymentNotifyRequest, opts ...http.CallOption) (rsp *PaymentNotifyReply, err error)
 	PaymentReturn(ctx context.Context, req *PaymentReturnRequest, opts ...http.CallOption) (rsp *PaymentReturnReply, err error)
 	QueryPayment(ctx context.Context, req *QueryPaymentRequest, opts ...http.CallOption) (rsp *QueryPaymentReply, err error)
+	RefundNotify(ctx context.Context, req *RefundNotifyRequest, opts ...http.CallOption) (rsp *RefundNotifyReply, err error)
+	RequestRefund(ctx context.Context, req *RequestRefundRequest, opts ...http.CallOption) (rsp *RequestRefundReply, err error)
+	SandboxPay(ctx context.Context, req *SandboxPayRequest, opts ...http.CallOption) (rsp *SandboxPayReply, err error)
 }
 
 type PaymentHTTPClientImpl struct {
@@ -153,6 +255,19 @@ func (c *PaymentHTTPClientImpl) CreatePayment(ctx context.Context, in *CreatePay
 	return &out, nil
 }
 
+func (c *PaymentHTTPClientImpl) ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...http.CallOption) (*ListRefundsReply, error) {
+	var out ListRefundsReply
+	pattern := "/v1/payment/refunds"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationPaymentListRefunds))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PaymentHTTPClientImpl) PaymentNotify(ctx context.Context, in *PaymentNotifyRequest, opts ...http.CallOption) (*PaymentNotifyReply, error) {
 	var out PaymentNotifyReply
 	pattern := "/v1/payment/notify"
@@ -191,3 +306,42 @@ func (c *PaymentHTTPClientImpl) QueryPayment(ctx context.Context, in *QueryPayme
 	}
 	return &out, nil
 }
+
+func (c *PaymentHTTPClientImpl) RefundNotify(ctx context.Context, in *RefundNotifyRequest, opts ...http.CallOption) (*RefundNotifyReply, error) {
+	var out RefundNotifyReply
+	pattern := "/v1/payment/refund/notify"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPaymentRefundNotify))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PaymentHTTPClientImpl) RequestRefund(ctx context.Context, in *RequestRefundRequest, opts ...http.CallOption) (*RequestRefundReply, error) {
+	var out RequestRefundReply
+	pattern := "/v1/payment/refund"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPaymentRequestRefund))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PaymentHTTPClientImpl) SandboxPay(ctx context.Context, in *SandboxPayRequest, opts ...http.CallOption) (*SandboxPayReply, error) {
+	var out SandboxPayReply
+	pattern := "/v1/payment/sandbox/pay"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationPaymentSandboxPay))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	v1 "kratos_client/api/drug/v1"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -31,6 +32,7 @@ type ListPrescriptionsRequest struct {
 	EndDate          string                 `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                            // 结束日期
 	Page             int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize         int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Token            string                 `protobuf:"bytes,7,opt,name=token,proto3" json:"token,omitempty"` // 药师token
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -107,6 +109,13 @@ func (x *ListPrescriptionsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListPrescriptionsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 // 获取处方列表响应
 type ListPrescriptionsReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -167,6 +176,7 @@ type ListPatientPrescriptionsRequest struct {
 	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Token         string                 `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"` // 患者本人token
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,6 +239,13 @@ func (x *ListPatientPrescriptionsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListPatientPrescriptionsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 // 获取患者处方列表响应
 type ListPatientPrescriptionsReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -289,6 +306,7 @@ type ListDoctorPrescriptionsRequest struct {
 	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Token         string                 `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"` // 医生本人token
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -351,6 +369,13 @@ func (x *ListDoctorPrescriptionsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListDoctorPrescriptionsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 // 获取医生处方列表响应
 type ListDoctorPrescriptionsReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -408,6 +433,7 @@ func (x *ListDoctorPrescriptionsReply) GetTotal() int64 {
 type GetPrescriptionDetailRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	PrescriptionId uint64                 `protobuf:"varint,1,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	Token          string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // 处方患者、开方医生或药师的token
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -449,6 +475,13 @@ func (x *GetPrescriptionDetailRequest) GetPrescriptionId() uint64 {
 	return 0
 }
 
+func (x *GetPrescriptionDetailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 // 获取处方详情响应
 type GetPrescriptionDetailReply struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
@@ -521,12 +554,16 @@ type Prescription struct {
 	CreatedAt        string                 `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt        string                 `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	// 扩展信息
-	DoctorName    string `protobuf:"bytes,16,opt,name=doctor_name,json=doctorName,proto3" json:"doctor_name,omitempty"`
-	PatientName   string `protobuf:"bytes,17,opt,name=patient_name,json=patientName,proto3" json:"patient_name,omitempty"`
-	AuditorName   string `protobuf:"bytes,18,opt,name=auditor_name,json=auditorName,proto3" json:"auditor_name,omitempty"`
-	MedicineCount int32  `protobuf:"varint,19,opt,name=medicine_count,json=medicineCount,proto3" json:"medicine_count,omitempty"` // 药品种类数量
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	DoctorName     string   `protobuf:"bytes,16,opt,name=doctor_name,json=doctorName,proto3" json:"doctor_name,omitempty"`
+	PatientName    string   `protobuf:"bytes,17,opt,name=patient_name,json=patientName,proto3" json:"patient_name,omitempty"`
+	AuditorName    string   `protobuf:"bytes,18,opt,name=auditor_name,json=auditorName,proto3" json:"auditor_name,omitempty"`
+	MedicineCount  int32    `protobuf:"varint,19,opt,name=medicine_count,json=medicineCount,proto3" json:"medicine_count,omitempty"`   // 药品种类数量
+	ConsultationNo string   `protobuf:"bytes,20,opt,name=consultation_no,json=consultationNo,proto3" json:"consultation_no,omitempty"` // 关联问诊单号
+	OrderNo        string   `protobuf:"bytes,21,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`                      // 处方下单生成的订单号
+	ExpiresAt      string   `protobuf:"bytes,22,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                // 处方有效期截止时间
+	Populations    []string `protobuf:"bytes,23,rep,name=populations,proto3" json:"populations,omitempty"`                             // 患者所属人群
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Prescription) Reset() {
@@ -692,6 +729,34 @@ func (x *Prescription) GetMedicineCount() int32 {
 	return 0
 }
 
+func (x *Prescription) GetConsultationNo() string {
+	if x != nil {
+		return x.ConsultationNo
+	}
+	return ""
+}
+
+func (x *Prescription) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *Prescription) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Prescription) GetPopulations() []string {
+	if x != nil {
+		return x.Populations
+	}
+	return nil
+}
+
 // 处方药品明细
 type PrescriptionMedicine struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -858,99 +923,966 @@ func (x *PrescriptionMedicine) GetManufacturer() string {
 	return ""
 }
 
-var File_prescription_v1_prescription_proto protoreflect.FileDescriptor
+// 开具处方请求
+type IssuePrescriptionRequest struct {
+	state            protoimpl.MessageState       `protogen:"open.v1"`
+	Token            string                       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                               // 医生token
+	ConsultationNo   string                       `protobuf:"bytes,2,opt,name=consultation_no,json=consultationNo,proto3" json:"consultation_no,omitempty"`       // 问诊单号，处方患者取自问诊单
+	PrescriptionType string                       `protobuf:"bytes,3,opt,name=prescription_type,json=prescriptionType,proto3" json:"prescription_type,omitempty"` // 西药、中药、中西药，默认西药
+	UsageInstruction string                       `protobuf:"bytes,4,opt,name=usage_instruction,json=usageInstruction,proto3" json:"usage_instruction,omitempty"` // 用药说明
+	Medicines        []*IssuePrescriptionMedicine `protobuf:"bytes,5,rep,name=medicines,proto3" json:"medicines,omitempty"`
+	Populations      []string                     `protobuf:"bytes,6,rep,name=populations,proto3" json:"populations,omitempty"` // 患者所属人群：pregnancy孕妇 lactation哺乳期 child儿童 elderly老人
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_prescription_v1_prescription_proto_rawDesc = "" +
-	"\n" +
-	"\"prescription/v1/prescription.proto\x12\x13api.prescription.v1\x1a\x1cgoogle/api/annotations.proto\"\xca\x01\n" +
-	"\x18ListPrescriptionsRequest\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12+\n" +
-	"\x11prescription_type\x18\x02 \x01(\tR\x10prescriptionType\x12\x1d\n" +
-	"\n" +
-	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x12\n" +
-	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"w\n" +
-	"\x16ListPrescriptionsReply\x12G\n" +
-	"\rprescriptions\x18\x01 \x03(\v2!.api.prescription.v1.PrescriptionR\rprescriptions\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"\x89\x01\n" +
-	"\x1fListPatientPrescriptionsRequest\x12\x1d\n" +
-	"\n" +
-	"patient_id\x18\x01 \x01(\x04R\tpatientId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"~\n" +
-	"\x1dListPatientPrescriptionsReply\x12G\n" +
-	"\rprescriptions\x18\x01 \x03(\v2!.api.prescription.v1.PrescriptionR\rprescriptions\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"\x86\x01\n" +
-	"\x1eListDoctorPrescriptionsRequest\x12\x1b\n" +
-	"\tdoctor_id\x18\x01 \x01(\x04R\bdoctorId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"}\n" +
-	"\x1cListDoctorPrescriptionsReply\x12G\n" +
-	"\rprescriptions\x18\x01 \x03(\v2!.api.prescription.v1.PrescriptionR\rprescriptions\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"G\n" +
-	"\x1cGetPrescriptionDetailRequest\x12'\n" +
-	"\x0fprescription_id\x18\x01 \x01(\x04R\x0eprescriptionId\"\xac\x01\n" +
-	"\x1aGetPrescriptionDetailReply\x12E\n" +
-	"\fprescription\x18\x01 \x01(\v2!.api.prescription.v1.PrescriptionR\fprescription\x12G\n" +
-	"\tmedicines\x18\x02 \x03(\v2).api.prescription.v1.PrescriptionMedicineR\tmedicines\"\x9c\x05\n" +
-	"\fPrescription\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\x12'\n" +
-	"\x0fprescription_no\x18\x02 \x01(\tR\x0eprescriptionNo\x12\x1b\n" +
-	"\tdoctor_id\x18\x03 \x01(\x04R\bdoctorId\x12\x1d\n" +
-	"\n" +
-	"patient_id\x18\x04 \x01(\x04R\tpatientId\x12*\n" +
-	"\x11medical_record_id\x18\x05 \x01(\x04R\x0fmedicalRecordId\x12+\n" +
-	"\x11prescription_date\x18\x06 \x01(\tR\x10prescriptionDate\x12!\n" +
-	"\ftotal_amount\x18\a \x01(\tR\vtotalAmount\x12+\n" +
-	"\x11prescription_type\x18\b \x01(\tR\x10prescriptionType\x12+\n" +
-	"\x11usage_instruction\x18\t \x01(\tR\x10usageInstruction\x12\x16\n" +
-	"\x06status\x18\n" +
-	" \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"auditor_id\x18\v \x01(\x04R\tauditorId\x12\x1d\n" +
-	"\n" +
-	"audit_time\x18\f \x01(\tR\tauditTime\x12\x1f\n" +
-	"\vaudit_notes\x18\r \x01(\tR\n" +
-	"auditNotes\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x0f \x01(\tR\tupdatedAt\x12\x1f\n" +
-	"\vdoctor_name\x18\x10 \x01(\tR\n" +
-	"doctorName\x12!\n" +
-	"\fpatient_name\x18\x11 \x01(\tR\vpatientName\x12!\n" +
-	"\fauditor_name\x18\x12 \x01(\tR\vauditorName\x12%\n" +
-	"\x0emedicine_count\x18\x13 \x01(\x05R\rmedicineCount\"\xf8\x03\n" +
-	"\x14PrescriptionMedicine\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\x12'\n" +
-	"\x0fprescription_id\x18\x02 \x01(\x04R\x0eprescriptionId\x12\x1f\n" +
-	"\vmedicine_id\x18\x03 \x01(\x04R\n" +
-	"medicineId\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\tR\bquantity\x12\x12\n" +
-	"\x04unit\x18\x05 \x01(\tR\x04unit\x12\x1d\n" +
-	"\n" +
-	"unit_price\x18\x06 \x01(\tR\tunitPrice\x12\x1f\n" +
-	"\vtotal_price\x18\a \x01(\tR\n" +
-	"totalPrice\x12\x16\n" +
-	"\x06dosage\x18\b \x01(\tR\x06dosage\x12\x1c\n" +
-	"\tfrequency\x18\t \x01(\tR\tfrequency\x12\x1a\n" +
-	"\bduration\x18\n" +
-	" \x01(\tR\bduration\x12!\n" +
-	"\fusage_method\x18\v \x01(\tR\vusageMethod\x12\x14\n" +
-	"\x05notes\x18\f \x01(\tR\x05notes\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\r \x01(\tR\tcreatedAt\x12#\n" +
-	"\rmedicine_name\x18\x0e \x01(\tR\fmedicineName\x12#\n" +
-	"\rmedicine_spec\x18\x0f \x01(\tR\fmedicineSpec\x12\"\n" +
-	"\fmanufacturer\x18\x10 \x01(\tR\fmanufacturer2\xc8\x05\n" +
+func (x *IssuePrescriptionRequest) Reset() {
+	*x = IssuePrescriptionRequest{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssuePrescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuePrescriptionRequest) ProtoMessage() {}
+
+func (x *IssuePrescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuePrescriptionRequest.ProtoReflect.Descriptor instead.
+func (*IssuePrescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IssuePrescriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionRequest) GetConsultationNo() string {
+	if x != nil {
+		return x.ConsultationNo
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionRequest) GetPrescriptionType() string {
+	if x != nil {
+		return x.PrescriptionType
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionRequest) GetUsageInstruction() string {
+	if x != nil {
+		return x.UsageInstruction
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionRequest) GetMedicines() []*IssuePrescriptionMedicine {
+	if x != nil {
+		return x.Medicines
+	}
+	return nil
+}
+
+func (x *IssuePrescriptionRequest) GetPopulations() []string {
+	if x != nil {
+		return x.Populations
+	}
+	return nil
+}
+
+// 开具处方的药品明细，单价按药品当前售价计算
+type IssuePrescriptionMedicine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MedicineId    int64                  `protobuf:"varint,1,opt,name=medicine_id,json=medicineId,proto3" json:"medicine_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	Dosage        string                 `protobuf:"bytes,4,opt,name=dosage,proto3" json:"dosage,omitempty"`
+	Frequency     string                 `protobuf:"bytes,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Duration      string                 `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	UsageMethod   string                 `protobuf:"bytes,7,opt,name=usage_method,json=usageMethod,proto3" json:"usage_method,omitempty"`
+	Notes         string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssuePrescriptionMedicine) Reset() {
+	*x = IssuePrescriptionMedicine{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssuePrescriptionMedicine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuePrescriptionMedicine) ProtoMessage() {}
+
+func (x *IssuePrescriptionMedicine) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuePrescriptionMedicine.ProtoReflect.Descriptor instead.
+func (*IssuePrescriptionMedicine) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IssuePrescriptionMedicine) GetMedicineId() int64 {
+	if x != nil {
+		return x.MedicineId
+	}
+	return 0
+}
+
+func (x *IssuePrescriptionMedicine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *IssuePrescriptionMedicine) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionMedicine) GetDosage() string {
+	if x != nil {
+		return x.Dosage
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionMedicine) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionMedicine) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionMedicine) GetUsageMethod() string {
+	if x != nil {
+		return x.UsageMethod
+	}
+	return ""
+}
+
+func (x *IssuePrescriptionMedicine) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+// 撤销处方请求
+type CancelPrescriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 医生token
+	PrescriptionId uint64                 `protobuf:"varint,2,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelPrescriptionRequest) Reset() {
+	*x = CancelPrescriptionRequest{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPrescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPrescriptionRequest) ProtoMessage() {}
+
+func (x *CancelPrescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPrescriptionRequest.ProtoReflect.Descriptor instead.
+func (*CancelPrescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelPrescriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CancelPrescriptionRequest) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+// 处方操作响应
+type PrescriptionDetailReply struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Code          int32                   `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Prescription  *Prescription           `protobuf:"bytes,3,opt,name=prescription,proto3" json:"prescription,omitempty"`
+	Medicines     []*PrescriptionMedicine `protobuf:"bytes,4,rep,name=medicines,proto3" json:"medicines,omitempty"`
+	DrugWarnings  []*v1.DrugWarning       `protobuf:"bytes,5,rep,name=drug_warnings,json=drugWarnings,proto3" json:"drug_warnings,omitempty"` // 开具处方时不拦截的用药提示
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrescriptionDetailReply) Reset() {
+	*x = PrescriptionDetailReply{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrescriptionDetailReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrescriptionDetailReply) ProtoMessage() {}
+
+func (x *PrescriptionDetailReply) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrescriptionDetailReply.ProtoReflect.Descriptor instead.
+func (*PrescriptionDetailReply) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PrescriptionDetailReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PrescriptionDetailReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PrescriptionDetailReply) GetPrescription() *Prescription {
+	if x != nil {
+		return x.Prescription
+	}
+	return nil
+}
+
+func (x *PrescriptionDetailReply) GetMedicines() []*PrescriptionMedicine {
+	if x != nil {
+		return x.Medicines
+	}
+	return nil
+}
+
+func (x *PrescriptionDetailReply) GetDrugWarnings() []*v1.DrugWarning {
+	if x != nil {
+		return x.DrugWarnings
+	}
+	return nil
+}
+
+// 药师登录请求
+type PharmacistLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PharmacistLoginRequest) Reset() {
+	*x = PharmacistLoginRequest{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PharmacistLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PharmacistLoginRequest) ProtoMessage() {}
+
+func (x *PharmacistLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PharmacistLoginRequest.ProtoReflect.Descriptor instead.
+func (*PharmacistLoginRequest) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PharmacistLoginRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PharmacistLoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// 药师登录响应
+type PharmacistLoginReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	PharmacistId  int64                  `protobuf:"varint,4,opt,name=pharmacist_id,json=pharmacistId,proto3" json:"pharmacist_id,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PharmacistLoginReply) Reset() {
+	*x = PharmacistLoginReply{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PharmacistLoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PharmacistLoginReply) ProtoMessage() {}
+
+func (x *PharmacistLoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PharmacistLoginReply.ProtoReflect.Descriptor instead.
+func (*PharmacistLoginReply) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PharmacistLoginReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PharmacistLoginReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PharmacistLoginReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PharmacistLoginReply) GetPharmacistId() int64 {
+	if x != nil {
+		return x.PharmacistId
+	}
+	return 0
+}
+
+func (x *PharmacistLoginReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 审核处方请求
+type AuditPrescriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 药师token
+	PrescriptionId uint64                 `protobuf:"varint,2,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	Approved       bool                   `protobuf:"varint,3,opt,name=approved,proto3" json:"approved,omitempty"` // true通过，false驳回
+	Notes          string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`        // 审核意见，驳回时必填
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditPrescriptionRequest) Reset() {
+	*x = AuditPrescriptionRequest{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditPrescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditPrescriptionRequest) ProtoMessage() {}
+
+func (x *AuditPrescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditPrescriptionRequest.ProtoReflect.Descriptor instead.
+func (*AuditPrescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AuditPrescriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AuditPrescriptionRequest) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+func (x *AuditPrescriptionRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *AuditPrescriptionRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+// 处方下单请求
+type OrderPrescriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 患者token
+	PrescriptionId uint64                 `protobuf:"varint,2,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	AddressId      int32                  `protobuf:"varint,3,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"` // 收货地址ID，为0时使用默认地址
+	UserCouponId   int64                  `protobuf:"varint,4,opt,name=user_coupon_id,json=userCouponId,proto3" json:"user_coupon_id,omitempty"`
+	Remark         string                 `protobuf:"bytes,5,opt,name=remark,proto3" json:"remark,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderPrescriptionRequest) Reset() {
+	*x = OrderPrescriptionRequest{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderPrescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderPrescriptionRequest) ProtoMessage() {}
+
+func (x *OrderPrescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderPrescriptionRequest.ProtoReflect.Descriptor instead.
+func (*OrderPrescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrderPrescriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *OrderPrescriptionRequest) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+func (x *OrderPrescriptionRequest) GetAddressId() int32 {
+	if x != nil {
+		return x.AddressId
+	}
+	return 0
+}
+
+func (x *OrderPrescriptionRequest) GetUserCouponId() int64 {
+	if x != nil {
+		return x.UserCouponId
+	}
+	return 0
+}
+
+func (x *OrderPrescriptionRequest) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+// 处方下单响应
+type OrderPrescriptionReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OrderNo       string                 `protobuf:"bytes,3,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	TotalAmount   string                 `protobuf:"bytes,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderPrescriptionReply) Reset() {
+	*x = OrderPrescriptionReply{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderPrescriptionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderPrescriptionReply) ProtoMessage() {}
+
+func (x *OrderPrescriptionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderPrescriptionReply.ProtoReflect.Descriptor instead.
+func (*OrderPrescriptionReply) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OrderPrescriptionReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *OrderPrescriptionReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OrderPrescriptionReply) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *OrderPrescriptionReply) GetTotalAmount() string {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return ""
+}
+
+func (x *OrderPrescriptionReply) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// 处方用药检查请求
+type CheckPrescriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 医生、药师或患者token
+	PrescriptionId uint64                 `protobuf:"varint,2,opt,name=prescription_id,json=prescriptionId,proto3" json:"prescription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckPrescriptionRequest) Reset() {
+	*x = CheckPrescriptionRequest{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPrescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPrescriptionRequest) ProtoMessage() {}
+
+func (x *CheckPrescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPrescriptionRequest.ProtoReflect.Descriptor instead.
+func (*CheckPrescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CheckPrescriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CheckPrescriptionRequest) GetPrescriptionId() uint64 {
+	if x != nil {
+		return x.PrescriptionId
+	}
+	return 0
+}
+
+// 处方用药检查响应
+type CheckPrescriptionReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Blocked       bool                   `protobuf:"varint,3,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Warnings      []*v1.DrugWarning      `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPrescriptionReply) Reset() {
+	*x = CheckPrescriptionReply{}
+	mi := &file_prescription_v1_prescription_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPrescriptionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPrescriptionReply) ProtoMessage() {}
+
+func (x *CheckPrescriptionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_prescription_v1_prescription_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPrescriptionReply.ProtoReflect.Descriptor instead.
+func (*CheckPrescriptionReply) Descriptor() ([]byte, []int) {
+	return file_prescription_v1_prescription_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CheckPrescriptionReply) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CheckPrescriptionReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckPrescriptionReply) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *CheckPrescriptionReply) GetWarnings() []*v1.DrugWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+var File_prescription_v1_prescription_proto protoreflect.FileDescriptor
+
+const file_prescription_v1_prescription_proto_rawDesc = "" +
+	"\n" +
+	"\"prescription/v1/prescription.proto\x12\x13api.prescription.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x12drug/v1/drug.proto\"\xe0\x01\n" +
+	"\x18ListPrescriptionsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12+\n" +
+	"\x11prescription_type\x18\x02 \x01(\tR\x10prescriptionType\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05token\x18\a \x01(\tR\x05token\"w\n" +
+	"\x16ListPrescriptionsReply\x12G\n" +
+	"\rprescriptions\x18\x01 \x03(\v2!.api.prescription.v1.PrescriptionR\rprescriptions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x9f\x01\n" +
+	"\x1fListPatientPrescriptionsRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\x04R\tpatientId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\"~\n" +
+	"\x1dListPatientPrescriptionsReply\x12G\n" +
+	"\rprescriptions\x18\x01 \x03(\v2!.api.prescription.v1.PrescriptionR\rprescriptions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x9c\x01\n" +
+	"\x1eListDoctorPrescriptionsRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\x04R\bdoctorId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\"}\n" +
+	"\x1cListDoctorPrescriptionsReply\x12G\n" +
+	"\rprescriptions\x18\x01 \x03(\v2!.api.prescription.v1.PrescriptionR\rprescriptions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"]\n" +
+	"\x1cGetPrescriptionDetailRequest\x12'\n" +
+	"\x0fprescription_id\x18\x01 \x01(\x04R\x0eprescriptionId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\xac\x01\n" +
+	"\x1aGetPrescriptionDetailReply\x12E\n" +
+	"\fprescription\x18\x01 \x01(\v2!.api.prescription.v1.PrescriptionR\fprescription\x12G\n" +
+	"\tmedicines\x18\x02 \x03(\v2).api.prescription.v1.PrescriptionMedicineR\tmedicines\"\xa1\x06\n" +
+	"\fPrescription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12'\n" +
+	"\x0fprescription_no\x18\x02 \x01(\tR\x0eprescriptionNo\x12\x1b\n" +
+	"\tdoctor_id\x18\x03 \x01(\x04R\bdoctorId\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x04 \x01(\x04R\tpatientId\x12*\n" +
+	"\x11medical_record_id\x18\x05 \x01(\x04R\x0fmedicalRecordId\x12+\n" +
+	"\x11prescription_date\x18\x06 \x01(\tR\x10prescriptionDate\x12!\n" +
+	"\ftotal_amount\x18\a \x01(\tR\vtotalAmount\x12+\n" +
+	"\x11prescription_type\x18\b \x01(\tR\x10prescriptionType\x12+\n" +
+	"\x11usage_instruction\x18\t \x01(\tR\x10usageInstruction\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"auditor_id\x18\v \x01(\x04R\tauditorId\x12\x1d\n" +
+	"\n" +
+	"audit_time\x18\f \x01(\tR\tauditTime\x12\x1f\n" +
+	"\vaudit_notes\x18\r \x01(\tR\n" +
+	"auditNotes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vdoctor_name\x18\x10 \x01(\tR\n" +
+	"doctorName\x12!\n" +
+	"\fpatient_name\x18\x11 \x01(\tR\vpatientName\x12!\n" +
+	"\fauditor_name\x18\x12 \x01(\tR\vauditorName\x12%\n" +
+	"\x0emedicine_count\x18\x13 \x01(\x05R\rmedicineCount\x12'\n" +
+	"\x0fconsultation_no\x18\x14 \x01(\tR\x0econsultationNo\x12\x19\n" +
+	"\border_no\x18\x15 \x01(\tR\aorderNo\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x16 \x01(\tR\texpiresAt\x12 \n" +
+	"\vpopulations\x18\x17 \x03(\tR\vpopulations\"\xf8\x03\n" +
+	"\x14PrescriptionMedicine\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12'\n" +
+	"\x0fprescription_id\x18\x02 \x01(\x04R\x0eprescriptionId\x12\x1f\n" +
+	"\vmedicine_id\x18\x03 \x01(\x04R\n" +
+	"medicineId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\tR\tunitPrice\x12\x1f\n" +
+	"\vtotal_price\x18\a \x01(\tR\n" +
+	"totalPrice\x12\x16\n" +
+	"\x06dosage\x18\b \x01(\tR\x06dosage\x12\x1c\n" +
+	"\tfrequency\x18\t \x01(\tR\tfrequency\x12\x1a\n" +
+	"\bduration\x18\n" +
+	" \x01(\tR\bduration\x12!\n" +
+	"\fusage_method\x18\v \x01(\tR\vusageMethod\x12\x14\n" +
+	"\x05notes\x18\f \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\tR\tcreatedAt\x12#\n" +
+	"\rmedicine_name\x18\x0e \x01(\tR\fmedicineName\x12#\n" +
+	"\rmedicine_spec\x18\x0f \x01(\tR\fmedicineSpec\x12\"\n" +
+	"\fmanufacturer\x18\x10 \x01(\tR\fmanufacturer\"\xa3\x02\n" +
+	"\x18IssuePrescriptionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
+	"\x0fconsultation_no\x18\x02 \x01(\tR\x0econsultationNo\x12+\n" +
+	"\x11prescription_type\x18\x03 \x01(\tR\x10prescriptionType\x12+\n" +
+	"\x11usage_instruction\x18\x04 \x01(\tR\x10usageInstruction\x12L\n" +
+	"\tmedicines\x18\x05 \x03(\v2..api.prescription.v1.IssuePrescriptionMedicineR\tmedicines\x12 \n" +
+	"\vpopulations\x18\x06 \x03(\tR\vpopulations\"\xf7\x01\n" +
+	"\x19IssuePrescriptionMedicine\x12\x1f\n" +
+	"\vmedicine_id\x18\x01 \x01(\x03R\n" +
+	"medicineId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12\x16\n" +
+	"\x06dosage\x18\x04 \x01(\tR\x06dosage\x12\x1c\n" +
+	"\tfrequency\x18\x05 \x01(\tR\tfrequency\x12\x1a\n" +
+	"\bduration\x18\x06 \x01(\tR\bduration\x12!\n" +
+	"\fusage_method\x18\a \x01(\tR\vusageMethod\x12\x14\n" +
+	"\x05notes\x18\b \x01(\tR\x05notes\"Z\n" +
+	"\x19CancelPrescriptionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
+	"\x0fprescription_id\x18\x02 \x01(\x04R\x0eprescriptionId\"\x92\x02\n" +
+	"\x17PrescriptionDetailReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12E\n" +
+	"\fprescription\x18\x03 \x01(\v2!.api.prescription.v1.PrescriptionR\fprescription\x12G\n" +
+	"\tmedicines\x18\x04 \x03(\v2).api.prescription.v1.PrescriptionMedicineR\tmedicines\x129\n" +
+	"\rdrug_warnings\x18\x05 \x03(\v2\x14.drug.v1.DrugWarningR\fdrugWarnings\"J\n" +
+	"\x16PharmacistLoginRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x93\x01\n" +
+	"\x14PharmacistLoginReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12#\n" +
+	"\rpharmacist_id\x18\x04 \x01(\x03R\fpharmacistId\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"\x8b\x01\n" +
+	"\x18AuditPrescriptionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
+	"\x0fprescription_id\x18\x02 \x01(\x04R\x0eprescriptionId\x12\x1a\n" +
+	"\bapproved\x18\x03 \x01(\bR\bapproved\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\"\xb6\x01\n" +
+	"\x18OrderPrescriptionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
+	"\x0fprescription_id\x18\x02 \x01(\x04R\x0eprescriptionId\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x03 \x01(\x05R\taddressId\x12$\n" +
+	"\x0euser_coupon_id\x18\x04 \x01(\x03R\fuserCouponId\x12\x16\n" +
+	"\x06remark\x18\x05 \x01(\tR\x06remark\"\x9c\x01\n" +
+	"\x16OrderPrescriptionReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\border_no\x18\x03 \x01(\tR\aorderNo\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\tR\vtotalAmount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"Y\n" +
+	"\x18CheckPrescriptionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
+	"\x0fprescription_id\x18\x02 \x01(\x04R\x0eprescriptionId\"\x92\x01\n" +
+	"\x16CheckPrescriptionReply\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\ablocked\x18\x03 \x01(\bR\ablocked\x120\n" +
+	"\bwarnings\x18\x04 \x03(\v2\x14.drug.v1.DrugWarningR\bwarnings2\xa7\r\n" +
 	"\x13PrescriptionService\x12\x8e\x01\n" +
 	"\x11ListPrescriptions\x12-.api.prescription.v1.ListPrescriptionsRequest\x1a+.api.prescription.v1.ListPrescriptionsReply\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/prescriptions\x12\xb9\x01\n" +
 	"\x18ListPatientPrescriptions\x124.api.prescription.v1.ListPatientPrescriptionsRequest\x1a2.api.prescription.v1.ListPatientPrescriptionsReply\"3\x82\xd3\xe4\x93\x02-\x12+/api/v1/patients/{patient_id}/prescriptions\x12\xb4\x01\n" +
 	"\x17ListDoctorPrescriptions\x123.api.prescription.v1.ListDoctorPrescriptionsRequest\x1a1.api.prescription.v1.ListDoctorPrescriptionsReply\"1\x82\xd3\xe4\x93\x02+\x12)/api/v1/doctors/{doctor_id}/prescriptions\x12\xac\x01\n" +
-	"\x15GetPrescriptionDetail\x121.api.prescription.v1.GetPrescriptionDetailRequest\x1a/.api.prescription.v1.GetPrescriptionDetailReply\"/\x82\xd3\xe4\x93\x02)\x12'/api/v1/prescriptions/{prescription_id}B&Z$kratos_client/api/prescription/v1;v1b\x06proto3"
+	"\x15GetPrescriptionDetail\x121.api.prescription.v1.GetPrescriptionDetailRequest\x1a/.api.prescription.v1.GetPrescriptionDetailReply\"/\x82\xd3\xe4\x93\x02)\x12'/api/v1/prescriptions/{prescription_id}\x12\x98\x01\n" +
+	"\x11IssuePrescription\x12-.api.prescription.v1.IssuePrescriptionRequest\x1a,.api.prescription.v1.PrescriptionDetailReply\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/prescriptions/issue\x12\xad\x01\n" +
+	"\x12CancelPrescription\x12..api.prescription.v1.CancelPrescriptionRequest\x1a,.api.prescription.v1.PrescriptionDetailReply\"9\x82\xd3\xe4\x93\x023:\x01*\"./api/v1/prescriptions/{prescription_id}/cancel\x12\x8f\x01\n" +
+	"\x0fPharmacistLogin\x12+.api.prescription.v1.PharmacistLoginRequest\x1a).api.prescription.v1.PharmacistLoginReply\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/pharmacists/login\x12\xaa\x01\n" +
+	"\x11AuditPrescription\x12-.api.prescription.v1.AuditPrescriptionRequest\x1a,.api.prescription.v1.PrescriptionDetailReply\"8\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/prescriptions/{prescription_id}/audit\x12\xa9\x01\n" +
+	"\x11OrderPrescription\x12-.api.prescription.v1.OrderPrescriptionRequest\x1a+.api.prescription.v1.OrderPrescriptionReply\"8\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/prescriptions/{prescription_id}/order\x12\xa6\x01\n" +
+	"\x11CheckPrescription\x12-.api.prescription.v1.CheckPrescriptionRequest\x1a+.api.prescription.v1.CheckPrescriptionReply\"5\x82\xd3\xe4\x93\x02/\x12-/api/v1/prescriptions/{prescription_id}/checkB&Z$kratos_client/api/prescription/v1;v1b\x06proto3"
 
 var (
 	file_prescription_v1_prescription_proto_rawDescOnce sync.Once
@@ -964,7 +1896,7 @@ func file_prescription_v1_prescription_proto_rawDescGZIP() []byte {
 	return file_prescription_v1_prescription_proto_rawDescData
 }
 
-var file_prescription_v1_prescription_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_prescription_v1_prescription_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_prescription_v1_prescription_proto_goTypes = []any{
 	(*ListPrescriptionsRequest)(nil),        // 0: api.prescription.v1.ListPrescriptionsRequest
 	(*ListPrescriptionsReply)(nil),          // 1: api.prescription.v1.ListPrescriptionsReply
@@ -976,26 +1908,55 @@ var file_prescription_v1_prescription_proto_goTypes = []any{
 	(*GetPrescriptionDetailReply)(nil),      // 7: api.prescription.v1.GetPrescriptionDetailReply
 	(*Prescription)(nil),                    // 8: api.prescription.v1.Prescription
 	(*PrescriptionMedicine)(nil),            // 9: api.prescription.v1.PrescriptionMedicine
+	(*IssuePrescriptionRequest)(nil),        // 10: api.prescription.v1.IssuePrescriptionRequest
+	(*IssuePrescriptionMedicine)(nil),       // 11: api.prescription.v1.IssuePrescriptionMedicine
+	(*CancelPrescriptionRequest)(nil),       // 12: api.prescription.v1.CancelPrescriptionRequest
+	(*PrescriptionDetailReply)(nil),         // 13: api.prescription.v1.PrescriptionDetailReply
+	(*PharmacistLoginRequest)(nil),          // 14: api.prescription.v1.PharmacistLoginRequest
+	(*PharmacistLoginReply)(nil),            // 15: api.prescription.v1.PharmacistLoginReply
+	(*AuditPrescriptionRequest)(nil),        // 16: api.prescription.v1.AuditPrescriptionRequest
+	(*OrderPrescriptionRequest)(nil),        // 17: api.prescription.v1.OrderPrescriptionRequest
+	(*OrderPrescriptionReply)(nil),          // 18: api.prescription.v1.OrderPrescriptionReply
+	(*CheckPrescriptionRequest)(nil),        // 19: api.prescription.v1.CheckPrescriptionRequest
+	(*CheckPrescriptionReply)(nil),          // 20: api.prescription.v1.CheckPrescriptionReply
+	(*v1.DrugWarning)(nil),                  // 21: drug.v1.DrugWarning
 }
 var file_prescription_v1_prescription_proto_depIdxs = []int32{
-	8, // 0: api.prescription.v1.ListPrescriptionsReply.prescriptions:type_name -> api.prescription.v1.Prescription
-	8, // 1: api.prescription.v1.ListPatientPrescriptionsReply.prescriptions:type_name -> api.prescription.v1.Prescription
-	8, // 2: api.prescription.v1.ListDoctorPrescriptionsReply.prescriptions:type_name -> api.prescription.v1.Prescription
-	8, // 3: api.prescription.v1.GetPrescriptionDetailReply.prescription:type_name -> api.prescription.v1.Prescription
-	9, // 4: api.prescription.v1.GetPrescriptionDetailReply.medicines:type_name -> api.prescription.v1.PrescriptionMedicine
-	0, // 5: api.prescription.v1.PrescriptionService.ListPrescriptions:input_type -> api.prescription.v1.ListPrescriptionsRequest
-	2, // 6: api.prescription.v1.PrescriptionService.ListPatientPrescriptions:input_type -> api.prescription.v1.ListPatientPrescriptionsRequest
-	4, // 7: api.prescription.v1.PrescriptionService.ListDoctorPrescriptions:input_type -> api.prescription.v1.ListDoctorPrescriptionsRequest
-	6, // 8: api.prescription.v1.PrescriptionService.GetPrescriptionDetail:input_type -> api.prescription.v1.GetPrescriptionDetailRequest
-	1, // 9: api.prescription.v1.PrescriptionService.ListPrescriptions:output_type -> api.prescription.v1.ListPrescriptionsReply
-	3, // 10: api.prescription.v1.PrescriptionService.ListPatientPrescriptions:output_type -> api.prescription.v1.ListPatientPrescriptionsReply
-	5, // 11: api.prescription.v1.PrescriptionService.ListDoctorPrescriptions:output_type -> api.prescription.v1.ListDoctorPrescriptionsReply
-	7, // 12: api.prescription.v1.PrescriptionService.GetPrescriptionDetail:output_type -> api.prescription.v1.GetPrescriptionDetailReply
-	9, // [9:13] is the sub-list for method output_type
-	5, // [5:9] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	8,  // 0: api.prescription.v1.ListPrescriptionsReply.prescriptions:type_name -> api.prescription.v1.Prescription
+	8,  // 1: api.prescription.v1.ListPatientPrescriptionsReply.prescriptions:type_name -> api.prescription.v1.Prescription
+	8,  // 2: api.prescription.v1.ListDoctorPrescriptionsReply.prescriptions:type_name -> api.prescription.v1.Prescription
+	8,  // 3: api.prescription.v1.GetPrescriptionDetailReply.prescription:type_name -> api.prescription.v1.Prescription
+	9,  // 4: api.prescription.v1.GetPrescriptionDetailReply.medicines:type_name -> api.prescription.v1.PrescriptionMedicine
+	11, // 5: api.prescription.v1.IssuePrescriptionRequest.medicines:type_name -> api.prescription.v1.IssuePrescriptionMedicine
+	8,  // 6: api.prescription.v1.PrescriptionDetailReply.prescription:type_name -> api.prescription.v1.Prescription
+	9,  // 7: api.prescription.v1.PrescriptionDetailReply.medicines:type_name -> api.prescription.v1.PrescriptionMedicine
+	21, // 8: api.prescription.v1.PrescriptionDetailReply.drug_warnings:type_name -> drug.v1.DrugWarning
+	21, // 9: api.prescription.v1.CheckPrescriptionReply.warnings:type_name -> drug.v1.DrugWarning
+	0,  // 10: api.prescription.v1.PrescriptionService.ListPrescriptions:input_type -> api.prescription.v1.ListPrescriptionsRequest
+	2,  // 11: api.prescription.v1.PrescriptionService.ListPatientPrescriptions:input_type -> api.prescription.v1.ListPatientPrescriptionsRequest
+	4,  // 12: api.prescription.v1.PrescriptionService.ListDoctorPrescriptions:input_type -> api.prescription.v1.ListDoctorPrescriptionsRequest
+	6,  // 13: api.prescription.v1.PrescriptionService.GetPrescriptionDetail:input_type -> api.prescription.v1.GetPrescriptionDetailRequest
+	10, // 14: api.prescription.v1.PrescriptionService.IssuePrescription:input_type -> api.prescription.v1.IssuePrescriptionRequest
+	12, // 15: api.prescription.v1.PrescriptionService.CancelPrescription:input_type -> api.prescription.v1.CancelPrescriptionRequest
+	14, // 16: api.prescription.v1.PrescriptionService.PharmacistLogin:input_type -> api.prescription.v1.PharmacistLoginRequest
+	16, // 17: api.prescription.v1.PrescriptionService.AuditPrescription:input_type -> api.prescription.v1.AuditPrescriptionRequest
+	17, // 18: api.prescription.v1.PrescriptionService.OrderPrescription:input_type -> api.prescription.v1.OrderPrescriptionRequest
+	19, // 19: api.prescription.v1.PrescriptionService.CheckPrescription:input_type -> api.prescription.v1.CheckPrescriptionRequest
+	1,  // 20: api.prescription.v1.PrescriptionService.ListPrescriptions:output_type -> api.prescription.v1.ListPrescriptionsReply
+	3,  // 21: api.prescription.v1.PrescriptionService.ListPatientPrescriptions:output_type -> api.prescription.v1.ListPatientPrescriptionsReply
+	5,  // 22: api.prescription.v1.PrescriptionService.ListDoctorPrescriptions:output_type -> api.prescription.v1.ListDoctorPrescriptionsReply
+	7,  // 23: api.prescription.v1.PrescriptionService.GetPrescriptionDetail:output_type -> api.prescription.v1.GetPrescriptionDetailReply
+	13, // 24: api.prescription.v1.PrescriptionService.IssuePrescription:output_type -> api.prescription.v1.PrescriptionDetailReply
+	13, // 25: api.prescription.v1.PrescriptionService.CancelPrescription:output_type -> api.prescription.v1.PrescriptionDetailReply
+	15, // 26: api.prescription.v1.PrescriptionService.PharmacistLogin:output_type -> api.prescription.v1.PharmacistLoginReply
+	13, // 27: api.prescription.v1.PrescriptionService.AuditPrescription:output_type -> api.prescription.v1.PrescriptionDetailReply
+	18, // 28: api.prescription.v1.PrescriptionService.OrderPrescription:output_type -> api.prescription.v1.OrderPrescriptionReply
+	20, // 29: api.prescription.v1.PrescriptionService.CheckPrescription:output_type -> api.prescription.v1.CheckPrescriptionReply
+	20, // [20:30] is the sub-list for method output_type
+	10, // [10:20] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_prescription_v1_prescription_proto_init() }
@@ -1009,7 +1970,7 @@ func file_prescription_v1_prescription_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prescription_v1_prescription_proto_rawDesc), len(file_prescription_v1_prescription_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
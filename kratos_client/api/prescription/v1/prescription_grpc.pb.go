@@ -23,6 +23,12 @@ const (
 	PrescriptionService_ListPatientPrescriptions_FullMethodName = "/api.prescription.v1.PrescriptionService/ListPatientPrescriptions"
 	PrescriptionService_ListDoctorPrescriptions_FullMethodName  = "/api.prescription.v1.PrescriptionService/ListDoctorPrescriptions"
 	PrescriptionService_GetPrescriptionDetail_FullMethodName    = "/api.prescription.v1.PrescriptionService/GetPrescriptionDetail"
+	PrescriptionService_IssuePrescription_FullMethodName        = "/api.prescription.v1.PrescriptionService/IssuePrescription"
+	PrescriptionService_CancelPrescription_FullMethodName       = "/api.prescription.v1.PrescriptionService/CancelPrescription"
+	PrescriptionService_PharmacistLogin_FullMethodName          = "/api.prescription.v1.PrescriptionService/PharmacistLogin"
+	PrescriptionService_AuditPrescription_FullMethodName        = "/api.prescription.v1.PrescriptionService/AuditPrescription"
+	PrescriptionService_OrderPrescription_FullMethodName        = "/api.prescription.v1.PrescriptionService/OrderPrescription"
+	PrescriptionService_CheckPrescription_FullMethodName        = "/api.prescription.v1.PrescriptionService/CheckPrescription"
 )
 
 // PrescriptionServiceClient is the client API for PrescriptionService service.
@@ -39,6 +45,18 @@ type PrescriptionServiceClient interface {
 	ListDoctorPrescriptions(ctx context.Context, in *ListDoctorPrescriptionsRequest, opts ...grpc.CallOption) (*ListDoctorPrescriptionsReply, error)
 	// 获取处方详情
 	GetPrescriptionDetail(ctx context.Context, in *GetPrescriptionDetailRequest, opts ...grpc.CallOption) (*GetPrescriptionDetailReply, error)
+	// 医生为接诊的问诊开具处方
+	IssuePrescription(ctx context.Context, in *IssuePrescriptionRequest, opts ...grpc.CallOption) (*PrescriptionDetailReply, error)
+	// 医生撤销未审核的处方
+	CancelPrescription(ctx context.Context, in *CancelPrescriptionRequest, opts ...grpc.CallOption) (*PrescriptionDetailReply, error)
+	// 药师登录
+	PharmacistLogin(ctx context.Context, in *PharmacistLoginRequest, opts ...grpc.CallOption) (*PharmacistLoginReply, error)
+	// 药师审核处方：通过或驳回
+	AuditPrescription(ctx context.Context, in *AuditPrescriptionRequest, opts ...grpc.CallOption) (*PrescriptionDetailReply, error)
+	// 患者将审核通过的处方一键下单
+	OrderPrescription(ctx context.Context, in *OrderPrescriptionRequest, opts ...grpc.CallOption) (*OrderPrescriptionReply, error)
+	// 按处方药品和患者人群检查用药，供药师审核时参考
+	CheckPrescription(ctx context.Context, in *CheckPrescriptionRequest, opts ...grpc.CallOption) (*CheckPrescriptionReply, error)
 }
 
 type prescriptionServiceClient struct {
@@ -89,6 +107,66 @@ func (c *prescriptionServiceClient) GetPrescriptionDetail(ctx context.Context, i
 	return out, nil
 }
 
+func (c *prescriptionServiceClient) IssuePrescription(ctx context.Context, in *IssuePrescriptionRequest, opts ...grpc.CallOption) (*PrescriptionDetailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrescriptionDetailReply)
+	err := c.cc.Invoke(ctx, PrescriptionService_IssuePrescription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prescriptionServiceClient) CancelPrescription(ctx context.Context, in *CancelPrescriptionRequest, opts ...grpc.CallOption) (*PrescriptionDetailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrescriptionDetailReply)
+	err := c.cc.Invoke(ctx, PrescriptionService_CancelPrescription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prescriptionServiceClient) PharmacistLogin(ctx context.Context, in *PharmacistLoginRequest, opts ...grpc.CallOption) (*PharmacistLoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PharmacistLoginReply)
+	err := c.cc.Invoke(ctx, PrescriptionService_PharmacistLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prescriptionServiceClient) AuditPrescription(ctx context.Context, in *AuditPrescriptionRequest, opts ...grpc.CallOption) (*PrescriptionDetailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrescriptionDetailReply)
+	err := c.cc.Invoke(ctx, PrescriptionService_AuditPrescription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prescriptionServiceClient) OrderPrescription(ctx context.Context, in *OrderPrescriptionRequest, opts ...grpc.CallOption) (*OrderPrescriptionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderPrescriptionReply)
+	err := c.cc.Invoke(ctx, PrescriptionService_OrderPrescription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prescriptionServiceClient) CheckPrescription(ctx context.Context, in *CheckPrescriptionRequest, opts ...grpc.CallOption) (*CheckPrescriptionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPrescriptionReply)
+	err := c.cc.Invoke(ctx, PrescriptionService_CheckPrescription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PrescriptionServiceServer is the server API for PrescriptionService service.
 // All implementations must embed UnimplementedPrescriptionServiceServer
 // for forward compatibility.
@@ -103,6 +181,18 @@ type PrescriptionServiceServer interface {
 	ListDoctorPrescriptions(context.Context, *ListDoctorPrescriptionsRequest) (*ListDoctorPrescriptionsReply, error)
 	// 获取处方详情
 	GetPrescriptionDetail(context.Context, *GetPrescriptionDetailRequest) (*GetPrescriptionDetailReply, error)
+	// 医生为接诊的问诊开具处方
+	IssuePrescription(context.Context, *IssuePrescriptionRequest) (*PrescriptionDetailReply, error)
+	// 医生撤销未审核的处方
+	CancelPrescription(context.Context, *CancelPrescriptionRequest) (*PrescriptionDetailReply, error)
+	// 药师登录
+	PharmacistLogin(context.Context, *PharmacistLoginRequest) (*PharmacistLoginReply, error)
+	// 药师审核处方：通过或驳回
+	AuditPrescription(context.Context, *AuditPrescriptionRequest) (*PrescriptionDetailReply, error)
+	// 患者将审核通过的处方一键下单
+	OrderPrescription(context.Context, *OrderPrescriptionRequest) (*OrderPrescriptionReply, error)
+	// 按处方药品和患者人群检查用药，供药师审核时参考
+	CheckPrescription(context.Context, *CheckPrescriptionRequest) (*CheckPrescriptionReply, error)
 	mustEmbedUnimplementedPrescriptionServiceServer()
 }
 
@@ -125,6 +215,24 @@ func (UnimplementedPrescriptionServiceServer) ListDoctorPrescriptions(context.Co
 func (UnimplementedPrescriptionServiceServer) GetPrescriptionDetail(context.Context, *GetPrescriptionDetailRequest) (*GetPrescriptionDetailReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPrescriptionDetail not implemented")
 }
+func (UnimplementedPrescriptionServiceServer) IssuePrescription(context.Context, *IssuePrescriptionRequest) (*PrescriptionDetailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssuePrescription not implemented")
+}
+func (UnimplementedPrescriptionServiceServer) CancelPrescription(context.Context, *CancelPrescriptionRequest) (*PrescriptionDetailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPrescription not implemented")
+}
+func (UnimplementedPrescriptionServiceServer) PharmacistLogin(context.Context, *PharmacistLoginRequest) (*PharmacistLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PharmacistLogin not implemented")
+}
+func (UnimplementedPrescriptionServiceServer) AuditPrescription(context.Context, *AuditPrescriptionRequest) (*PrescriptionDetailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditPrescription not implemented")
+}
+func (UnimplementedPrescriptionServiceServer) OrderPrescription(context.Context, *OrderPrescriptionRequest) (*OrderPrescriptionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OrderPrescription not implemented")
+}
+func (UnimplementedPrescriptionServiceServer) CheckPrescription(context.Context, *CheckPrescriptionRequest) (*CheckPrescriptionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPrescription not implemented")
+}
 func (UnimplementedPrescriptionServiceServer) mustEmbedUnimplementedPrescriptionServiceServer() {}
 func (UnimplementedPrescriptionServiceServer) testEmbeddedByValue()                             {}
 
@@ -218,6 +326,114 @@ func _PrescriptionService_GetPrescriptionDetail_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PrescriptionService_IssuePrescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuePrescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrescriptionServiceServer).IssuePrescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrescriptionService_IssuePrescription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrescriptionServiceServer).IssuePrescription(ctx, req.(*IssuePrescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrescriptionService_CancelPrescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPrescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrescriptionServiceServer).CancelPrescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrescriptionService_CancelPrescription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrescriptionServiceServer).CancelPrescription(ctx, req.(*CancelPrescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrescriptionService_PharmacistLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PharmacistLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrescriptionServiceServer).PharmacistLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrescriptionService_PharmacistLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrescriptionServiceServer).PharmacistLogin(ctx, req.(*PharmacistLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrescriptionService_AuditPrescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditPrescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrescriptionServiceServer).AuditPrescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrescriptionService_AuditPrescription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrescriptionServiceServer).AuditPrescription(ctx, req.(*AuditPrescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrescriptionService_OrderPrescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderPrescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrescriptionServiceServer).OrderPrescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrescriptionService_OrderPrescription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrescriptionServiceServer).OrderPrescription(ctx, req.(*OrderPrescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrescriptionService_CheckPrescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPrescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrescriptionServiceServer).CheckPrescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrescriptionService_CheckPrescription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrescriptionServiceServer).CheckPrescription(ctx, req.(*CheckPrescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PrescriptionService_ServiceDesc is the grpc.ServiceDesc for PrescriptionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +457,30 @@ var PrescriptionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPrescriptionDetail",
 			Handler:    _PrescriptionService_GetPrescriptionDetail_Handler,
 		},
+		{
+			MethodName: "IssuePrescription",
+			Handler:    _PrescriptionService_IssuePrescription_Handler,
+		},
+		{
+			MethodName: "CancelPrescription",
+			Handler:    _PrescriptionService_CancelPrescription_Handler,
+		},
+		{
+			MethodName: "PharmacistLogin",
+			Handler:    _PrescriptionService_PharmacistLogin_Handler,
+		},
+		{
+			MethodName: "AuditPrescription",
+			Handler:    _PrescriptionService_AuditPrescription_Handler,
+		},
+		{
+			MethodName: "OrderPrescription",
+			Handler:    _PrescriptionService_OrderPrescription_Handler,
+		},
+		{
+			MethodName: "CheckPrescription",
+			Handler:    _PrescriptionService_CheckPrescription_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "prescription/v1/prescription.proto",
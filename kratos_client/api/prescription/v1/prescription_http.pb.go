@@ -19,20 +19,38 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationPrescriptionServiceAuditPrescription = "/api.prescription.v1.PrescriptionService/AuditPrescription"
+const OperationPrescriptionServiceCancelPrescription = "/api.prescription.v1.PrescriptionService/CancelPrescription"
+const OperationPrescriptionServiceCheckPrescription = "/api.prescription.v1.PrescriptionService/CheckPrescription"
 const OperationPrescriptionServiceGetPrescriptionDetail = "/api.prescription.v1.PrescriptionService/GetPrescriptionDetail"
+const OperationPrescriptionServiceIssuePrescription = "/api.prescription.v1.PrescriptionService/IssuePrescription"
 const OperationPrescriptionServiceListDoctorPrescriptions = "/api.prescription.v1.PrescriptionService/ListDoctorPrescriptions"
 const OperationPrescriptionServiceListPatientPrescriptions = "/api.prescription.v1.PrescriptionService/ListPatientPrescriptions"
 const OperationPrescriptionServiceListPrescriptions = "/api.prescription.v1.PrescriptionService/ListPrescriptions"
+const OperationPrescriptionServiceOrderPrescription = "/api.prescription.v1.PrescriptionService/OrderPrescription"
+const OperationPrescriptionServicePharmacistLogin = "/api.prescription.v1.PrescriptionService/PharmacistLogin"
 
 type PrescriptionServiceHTTPServer interface {
+	// AuditPrescription 药师审核处方：通过或驳回
+	AuditPrescription(context.Context, *AuditPrescriptionRequest) (*PrescriptionDetailReply, error)
+	// CancelPrescription 医生撤销未审核的处方
+	CancelPrescription(context.Context, *CancelPrescriptionRequest) (*PrescriptionDetailReply, error)
+	// CheckPrescription 按处方药品和患者人群检查用药，供药师审核时参考
+	CheckPrescription(context.Context, *CheckPrescriptionRequest) (*CheckPrescriptionReply, error)
 	// GetPrescriptionDetail 获取处方详情
 	GetPrescriptionDetail(context.Context, *GetPrescriptionDetailRequest) (*GetPrescriptionDetailReply, error)
+	// IssuePrescription 医生为接诊的问诊开具处方
+	IssuePrescription(context.Context, *IssuePrescriptionRequest) (*PrescriptionDetailReply, error)
 	// ListDoctorPrescriptions 获取医生处方列表
 	ListDoctorPrescriptions(context.Context, *ListDoctorPrescriptionsRequest) (*ListDoctorPrescriptionsReply, error)
 	// ListPatientPrescriptions 获取患者处方列表
 	ListPatientPrescriptions(context.Context, *ListPatientPrescriptionsRequest) (*ListPatientPrescriptionsReply, error)
 	// ListPrescriptions 获取处方列表
 	ListPrescriptions(context.Context, *ListPrescriptionsRequest) (*ListPrescriptionsReply, error)
+	// OrderPrescription 患者将审核通过的处方一键下单
+	OrderPrescription(context.Context, *OrderPrescriptionRequest) (*OrderPrescriptionReply, error)
+	// PharmacistLogin 药师登录
+	PharmacistLogin(context.Context, *PharmacistLoginRequest) (*PharmacistLoginReply, error)
 }
 
 func RegisterPrescriptionServiceHTTPServer(s *http.Server, srv PrescriptionServiceHTTPServer) {
@@ -41,6 +59,12 @@ func RegisterPrescriptionServiceHTTPServer(s *http.Server, srv PrescriptionServi
 	r.GET("/api/v1/patients/{patient_id}/prescriptions", _PrescriptionService_ListPatientPrescriptions0_HTTP_Handler(srv))
 	r.GET("/api/v1/doctors/{doctor_id}/prescriptions", _PrescriptionService_ListDoctorPrescriptions0_HTTP_Handler(srv))
 	r.GET("/api/v1/prescriptions/{prescription_id}", _PrescriptionService_GetPrescriptionDetail0_HTTP_Handler(srv))
+	r.POST("/api/v1/prescriptions/issue", _PrescriptionService_IssuePrescription0_HTTP_Handler(srv))
+	r.POST("/api/v1/prescriptions/{prescription_id}/cancel", _PrescriptionService_CancelPrescription0_HTTP_Handler(srv))
+	r.POST("/api/v1/pharmacists/login", _PrescriptionService_PharmacistLogin0_HTTP_Handler(srv))
+	r.POST("/api/v1/prescriptions/{prescription_id}/audit", _PrescriptionService_AuditPrescription0_HTTP_Handler(srv))
+	r.POST("/api/v1/prescriptions/{prescription_id}/order", _PrescriptionService_OrderPrescription0_HTTP_Handler(srv))
+	r.GET("/api/v1/prescriptions/{prescription_id}/check", _PrescriptionService_CheckPrescription0_HTTP_Handler(srv))
 }
 
 func _PrescriptionService_ListPrescriptions1_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
@@ -128,11 +152,158 @@ func _PrescriptionService_GetPrescriptionDetail0_HTTP_Handler(srv PrescriptionSe
 	}
 }
 
+func _PrescriptionService_IssuePrescription0_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in IssuePrescriptionRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPrescriptionServiceIssuePrescription)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.IssuePrescription(ctx, req.(*IssuePrescriptionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PrescriptionDetailReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PrescriptionService_CancelPrescription0_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CancelPrescriptionRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPrescriptionServiceCancelPrescription)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CancelPrescription(ctx, req.(*CancelPrescriptionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PrescriptionDetailReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PrescriptionService_PharmacistLogin0_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PharmacistLoginRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPrescriptionServicePharmacistLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PharmacistLogin(ctx, req.(*PharmacistLoginRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PharmacistLoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PrescriptionService_AuditPrescription0_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AuditPrescriptionRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPrescriptionServiceAuditPrescription)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AuditPrescription(ctx, req.(*AuditPrescriptionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PrescriptionDetailReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PrescriptionService_OrderPrescription0_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in OrderPrescriptionRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPrescriptionServiceOrderPrescription)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.OrderPrescription(ctx, req.(*OrderPrescriptionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*OrderPrescriptionReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PrescriptionService_CheckPrescription0_HTTP_Handler(srv PrescriptionServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CheckPrescriptionRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPrescriptionServiceCheckPrescription)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CheckPrescription(ctx, req.(*CheckPrescriptionRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CheckPrescriptionReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type PrescriptionServiceHTTPClient interface {
+	AuditPrescription(ctx context.Context, req *AuditPrescriptionRequest, opts ...http.CallOption) (rsp *PrescriptionDetailReply, err error)
+	CancelPrescription(ctx context.Context, req *CancelPrescriptionRequest, opts ...http.CallOption) (rsp *PrescriptionDetailReply, err error)
+	CheckPrescription(ctx context.Context, req *CheckPrescriptionRequest, opts ...http.CallOption) (rsp *CheckPrescriptionReply, err error)
 	GetPrescriptionDetail(ctx context.Context, req *GetPrescriptionDetailRequest, opts ...http.CallOption) (rsp *GetPrescriptionDetailReply, err error)
+	IssuePrescription(ctx context.Context, req *IssuePrescriptionRequest, opts ...http.CallOption) (rsp *PrescriptionDetailReply, err error)
 	ListDoctorPrescriptions(ctx context.Context, req *ListDoctorPrescriptionsRequest, opts ...http.CallOption) (rsp *ListDoctorPrescriptionsReply, err error)
 	ListPatientPrescriptions(ctx context.Context, req *ListPatientPrescriptionsRequest, opts ...http.CallOption) (rsp *ListPatientPrescriptionsReply, err error)
 	ListPrescriptions(ctx context.Context, req *ListPrescriptionsRequest, opts ...http.CallOption) (rsp *ListPrescriptionsReply, err error)
+	OrderPrescription(ctx context.Context, req *OrderPrescriptionRequest, opts ...http.CallOption) (rsp *OrderPrescriptionReply, err error)
+	PharmacistLogin(ctx context.Context, req *PharmacistLoginRequest, opts ...http.CallOption) (rsp *PharmacistLoginReply, err error)
 }
 
 type PrescriptionServiceHTTPClientImpl struct {
@@ -143,6 +314,45 @@ func NewPrescriptionServiceHTTPClient(client *http.Client) PrescriptionServiceHT
 	return &PrescriptionServiceHTTPClientImpl{client}
 }
 
+func (c *PrescriptionServiceHTTPClientImpl) AuditPrescription(ctx context.Context, in *AuditPrescriptionRequest, opts ...http.CallOption) (*PrescriptionDetailReply, error) {
+	var out PrescriptionDetailReply
+	pattern := "/api/v1/prescriptions/{prescription_id}/audit"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPrescriptionServiceAuditPrescription))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PrescriptionServiceHTTPClientImpl) CancelPrescription(ctx context.Context, in *CancelPrescriptionRequest, opts ...http.CallOption) (*PrescriptionDetailReply, error) {
+	var out PrescriptionDetailReply
+	pattern := "/api/v1/prescriptions/{prescription_id}/cancel"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPrescriptionServiceCancelPrescription))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PrescriptionServiceHTTPClientImpl) CheckPrescription(ctx context.Context, in *CheckPrescriptionRequest, opts ...http.CallOption) (*CheckPrescriptionReply, error) {
+	var out CheckPrescriptionReply
+	pattern := "/api/v1/prescriptions/{prescription_id}/check"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationPrescriptionServiceCheckPrescription))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PrescriptionServiceHTTPClientImpl) GetPrescriptionDetail(ctx context.Context, in *GetPrescriptionDetailRequest, opts ...http.CallOption) (*GetPrescriptionDetailReply, error) {
 	var out GetPrescriptionDetailReply
 	pattern := "/api/v1/prescriptions/{prescription_id}"
@@ -156,6 +366,19 @@ func (c *PrescriptionServiceHTTPClientImpl) GetPrescriptionDetail(ctx context.Co
 	return &out, nil
 }
 
+func (c *PrescriptionServiceHTTPClientImpl) IssuePrescription(ctx context.Context, in *IssuePrescriptionRequest, opts ...http.CallOption) (*PrescriptionDetailReply, error) {
+	var out PrescriptionDetailReply
+	pattern := "/api/v1/prescriptions/issue"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPrescriptionServiceIssuePrescription))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PrescriptionServiceHTTPClientImpl) ListDoctorPrescriptions(ctx context.Context, in *ListDoctorPrescriptionsRequest, opts ...http.CallOption) (*ListDoctorPrescriptionsReply, error) {
 	var out ListDoctorPrescriptionsReply
 	pattern := "/api/v1/doctors/{doctor_id}/prescriptions"
@@ -194,3 +417,29 @@ func (c *PrescriptionServiceHTTPClientImpl) ListPrescriptions(ctx context.Contex
 	}
 	return &out, nil
 }
+
+func (c *PrescriptionServiceHTTPClientImpl) OrderPrescription(ctx context.Context, in *OrderPrescriptionRequest, opts ...http.CallOption) (*OrderPrescriptionReply, error) {
+	var out OrderPrescriptionReply
+	pattern := "/api/v1/prescriptions/{prescription_id}/order"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPrescriptionServiceOrderPrescription))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PrescriptionServiceHTTPClientImpl) PharmacistLogin(ctx context.Context, in *PharmacistLoginRequest, opts ...http.CallOption) (*PharmacistLoginReply, error) {
+	var out PharmacistLoginReply
+	pattern := "/api/v1/pharmacists/login"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPrescriptionServicePharmacistLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
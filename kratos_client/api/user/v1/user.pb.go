@@ -425,6 +425,7 @@ func (x *UpdateMobileReply) GetMessage() string {
 // The request message containing the user's name.
 type SelectTheCityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	CityId        int32                  `protobuf:"varint,1,opt,name=cityId,proto3" json:"cityId,omitempty"` // 可选，传入时保存为用户所选城市
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -459,13 +460,21 @@ func (*SelectTheCityRequest) Descriptor() ([]byte, []int) {
 	return file_user_v1_user_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *SelectTheCityRequest) GetCityId() int32 {
+	if x != nil {
+		return x.CityId
+	}
+	return 0
+}
+
 // The response message containing the greetings
 type SelectTheCityReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	CityList      []*CityList            `protobuf:"bytes,2,rep,name=cityList,proto3" json:"cityList,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Message        string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	CityList       []*CityList            `protobuf:"bytes,2,rep,name=cityList,proto3" json:"cityList,omitempty"`
+	SelectedCityId int32                  `protobuf:"varint,3,opt,name=selectedCityId,proto3" json:"selectedCityId,omitempty"` // 登录用户已选择的城市，未选择时为0
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SelectTheCityReply) Reset() {
@@ -512,6 +521,13 @@ func (x *SelectTheCityReply) GetCityList() []*CityList {
 	return nil
 }
 
+func (x *SelectTheCityReply) GetSelectedCityId() int32 {
+	if x != nil {
+		return x.SelectedCityId
+	}
+	return 0
+}
+
 type CityList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CityName      string                 `protobuf:"bytes,1,opt,name=cityName,proto3" json:"cityName,omitempty"`
@@ -1374,11 +1390,13 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\vsendSmsCode\x18\x02 \x01(\tR\vsendSmsCode\x12\x1c\n" +
 	"\tnewMobile\x18\x03 \x01(\tR\tnewMobile\"-\n" +
 	"\x11UpdateMobileReply\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\x16\n" +
-	"\x14SelectTheCityRequest\"]\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\".\n" +
+	"\x14SelectTheCityRequest\x12\x16\n" +
+	"\x06cityId\x18\x01 \x01(\x05R\x06cityId\"\x85\x01\n" +
 	"\x12SelectTheCityReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12-\n" +
-	"\bcityList\x18\x02 \x03(\v2\x11.user.v1.CityListR\bcityList\">\n" +
+	"\bcityList\x18\x02 \x03(\v2\x11.user.v1.CityListR\bcityList\x12&\n" +
+	"\x0eselectedCityId\x18\x03 \x01(\x05R\x0eselectedCityId\">\n" +
 	"\bCityList\x12\x1a\n" +
 	"\bcityName\x18\x01 \x01(\tR\bcityName\x12\x16\n" +
 	"\x06cityId\x18\x02 \x01(\x05R\x06cityId\":\n" +
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"kratos_client/internal/conf"
+	"kratos_client/internal/server"
 	"os"
 
 	"github.com/go-kratos/kratos/v2"
@@ -31,7 +32,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs/config.yaml", "config path, eg: -conf config.yaml")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, es *server.OrderExpiryServer, rs *server.RefundServer, cs *server.ConsultationServer, ds *server.DrugIndexServer) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -41,11 +42,23 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			gs,
 			hs,
+			es,
+			rs,
+			cs,
+			ds,
 		),
 	)
 }
 
 func main() {
+	// 子命令：kratos_client reconcile -conf config.yaml -file bill.csv
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		os.Exit(runReconcile(os.Args[2:]))
+	}
+	// 子命令：kratos_client reindex -conf config.yaml
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		os.Exit(runReindex(os.Args[2:]))
+	}
 
 	flag.Parse()
 	logger := log.With(log.NewStdLogger(os.Stdout),
@@ -57,12 +70,28 @@ func main() {
 		"trace.id", tracing.TraceID(),
 		"span.id", tracing.SpanID(),
 	)
+	bc, closeConfig := loadBootstrap(flagconf)
+	defer closeConfig()
+
+	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Order, bc.Alert, bc.Payment, bc.Consultation, bc.Inventory, logger)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	// start and wait for stop signal
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// 加载配置文件
+func loadBootstrap(path string) (*conf.Bootstrap, func()) {
 	c := config.New(
 		config.WithSource(
-			file.NewSource(flagconf),
+			file.NewSource(path),
 		),
 	)
-	defer c.Close()
 
 	if err := c.Load(); err != nil {
 		panic(err)
@@ -72,15 +101,5 @@ func main() {
 	if err := c.Scan(&bc); err != nil {
 		panic(err)
 	}
-
-	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
-	if err != nil {
-		panic(err)
-	}
-	defer cleanup()
-
-	// start and wait for stop signal
-	if err := app.Run(); err != nil {
-		panic(err)
-	}
+	return &bc, func() { c.Close() }
 }
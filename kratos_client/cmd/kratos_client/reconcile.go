@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"kratos_client/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 对账子命令，退出码：0 无差异或差异均已修复，1 存在未修复的差异，2 执行失败
+func runReconcile(args []string) int {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	confPath := fs.String("conf", "../../configs/config.yaml", "config path, eg: -conf config.yaml")
+	billPath := fs.String("file", "", "gateway statement file, eg: -file 20881234_20240101_业务明细.csv")
+	dryRun := fs.Bool("dry-run", false, "report differences without healing orders")
+	fs.Parse(args)
+
+	if *billPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: kratos_client reconcile -conf config.yaml -file bill.csv [-dry-run]")
+		return 2
+	}
+
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	bc, closeConfig := loadBootstrap(*confPath)
+	defer closeConfig()
+
+	uc, cleanup, err := wireReconcile(bc.Data, bc.Alert, bc.Payment, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "初始化失败: %v\n", err)
+		return 2
+	}
+	defer cleanup()
+
+	bill, err := os.Open(*billPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "打开账单失败: %v\n", err)
+		return 2
+	}
+	defer bill.Close()
+
+	report, err := uc.Reconcile(context.Background(), bill, biz.ReconcileOptions{DryRun: *dryRun})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "对账失败: %v\n", err)
+		return 2
+	}
+
+	printReconcileReport(report)
+	for _, issue := range report.Issues {
+		if !issue.Healed {
+			return 1
+		}
+	}
+	return 0
+}
+
+// 输出对账报告
+func printReconcileReport(report *biz.ReconcileReport) {
+	fmt.Printf("渠道: %s  账期: %s ~ %s  试运行: %v\n", report.Channel,
+		report.StartTime.Format("2006-01-02 15:04:05"), report.EndTime.Format("2006-01-02 15:04:05"), report.DryRun)
+	fmt.Printf("支付交易: %d  一致: %d  差异: %d  已修复: %d  跳过: %d\n",
+		report.PaymentCount, report.MatchedCount, len(report.Issues), report.HealedCount, report.SkippedCount)
+	if len(report.Issues) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nTYPE\tORDER_ID\tTRADE_NO\tBUSINESS_ID\tSTATEMENT\tLOCAL\tHEALED\tDETAIL")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%v\t%s\n", issue.Type, issue.OrderID, issue.TradeNo,
+			issue.BusinessID, issue.StatementAmount, issue.LocalAmount, issue.Healed, issue.Detail)
+	}
+	w.Flush()
+}
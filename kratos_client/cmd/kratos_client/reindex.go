@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 重建药品搜索索引子命令：写入新索引后切换别名，搜索不中断；退出码 0 成功，2 执行失败
+func runReindex(args []string) int {
+	fs := flag.NewFlagSet("reindex", flag.ExitOnError)
+	confPath := fs.String("conf", "../../configs/config.yaml", "config path, eg: -conf config.yaml")
+	batchSize := fs.Int("batch", 500, "drugs per bulk request")
+	fs.Parse(args)
+
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	bc, closeConfig := loadBootstrap(*confPath)
+	defer closeConfig()
+
+	uc, cleanup, err := wireReindex(bc.Data, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "初始化失败: %v\n", err)
+		return 2
+	}
+	defer cleanup()
+
+	report, err := uc.Reindex(context.Background(), *batchSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "重建索引失败: %v\n", err)
+		return 2
+	}
+	fmt.Printf("新索引: %s  写入: %d  跳过已删除: %d  补同步: %d  已删除旧索引: %v\n",
+		report.Index, report.Indexed, report.Skipped, report.CaughtUp, report.OldIndices)
+	return 0
+}
@@ -18,6 +18,16 @@ import (
 )
 
 // wireApp init kratos application.
-func wireApp(*conf.Server, *conf.Data, log.Logger) (*kratos.App, func(), error) {
+func wireApp(*conf.Server, *conf.Data, *conf.Order, *conf.Alert, *conf.Payment, *conf.Consultation, *conf.Inventory, log.Logger) (*kratos.App, func(), error) {
 	panic(wire.Build(server.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, newApp))
 }
+
+// wireReconcile init reconcile usecase for the reconcile command.
+func wireReconcile(*conf.Data, *conf.Alert, *conf.Payment, log.Logger) (*biz.ReconcileUsecase, func(), error) {
+	panic(wire.Build(data.ProviderSet, biz.ProviderSet))
+}
+
+// wireReindex init drug index usecase for the reindex command.
+func wireReindex(*conf.Data, log.Logger) (*biz.DrugIndexUsecase, func(), error) {
+	panic(wire.Build(data.ProviderSet, biz.ProviderSet))
+}
@@ -23,7 +23,7 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, order *conf.Order, alert *conf.Alert, payment *conf.Payment, consultation *conf.Consultation, inventory *conf.Inventory, logger log.Logger) (*kratos.App, func(), error) {
 	db, err := data.NewDb(confData)
 	if err != nil {
 		return nil, nil, err
@@ -33,39 +33,132 @@ func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*
 		return nil, nil, err
 	}
 	doctorsRepo := data.NewDoctorsRepo(dataData, logger)
-	doctorsService := biz.NewDoctorsUsecase(doctorsRepo, logger)
+	loginAttemptRepo := data.NewLoginAttemptRepo(dataData, logger)
+	loginThrottle := biz.NewLoginThrottle(loginAttemptRepo, logger)
+	doctorsService := biz.NewDoctorsUsecase(doctorsRepo, loginThrottle, logger)
 	serviceDoctorsService := service.NewDoctorsService(doctorsService, dataData)
 	drugRepo := data.NewDrugRepo(dataData, logger)
-	drugService := biz.NewDrugService(drugRepo, logger)
-	serviceDrugService := service.NewDrugService(drugService, dataData)
+	drugSearchRepo := data.NewDrugSearchRepo(dataData, logger)
+	drugService := biz.NewDrugService(drugRepo, drugSearchRepo, logger)
+	drugInventoryRepo := data.NewDrugInventoryRepo(dataData, logger)
+	inventoryAlertRepo := data.NewInventoryAlertRepo(dataData, logger)
+	inventoryAlertSink := data.NewInventoryAlertSink(alert, logger)
+	inventoryUsecase := biz.NewInventoryUsecase(drugInventoryRepo, drugRepo, inventoryAlertRepo, inventoryAlertSink, logger)
+	interactionRepo := data.NewInteractionRepo(dataData, logger)
+	interactionUsecase := biz.NewInteractionUsecase(interactionRepo, drugRepo, logger)
+	hotSearchRepo := data.NewHotSearchRepo(dataData, logger)
+	userRepo := data.NewUserRepo(dataData, logger)
+	hotSearchUsecase := biz.NewHotSearchUsecase(hotSearchRepo, userRepo, logger)
+	symptomRepo := data.NewSymptomRepo(dataData, logger)
+	symptomUsecase := biz.NewSymptomUsecase(symptomRepo, interactionRepo, logger)
+	serviceDrugService := service.NewDrugService(drugService, inventoryUsecase, interactionUsecase, hotSearchUsecase, symptomUsecase, dataData, inventory)
 	estimateRepo := data.NewEstimateRepo(dataData, logger)
 	estimateService := biz.NewEstimateService(estimateRepo, logger)
 	serviceEstimateService := service.NewEstimateService(estimateService, dataData)
 	cartRepo := data.NewCartRepo(dataData, logger)
-	cartService := biz.NewCartService(cartRepo, logger)
+	cartService := biz.NewCartService(cartRepo, interactionUsecase, logger)
 	serviceCartService := service.NewCartService(cartService, dataData)
 	orderRepo := data.NewOrderRepo(dataData, logger)
-	drugInventoryRepo := data.NewDrugInventoryRepo(dataData, logger)
-	orderUsecase := biz.NewOrderUsecase(orderRepo, drugRepo, drugInventoryRepo, logger)
-	orderService := service.NewOrderService(orderUsecase, logger)
 	couponRepo := data.NewCouponRepo(dataData, logger)
 	couponUsecase := biz.NewCouponUsecase(couponRepo, drugRepo, logger)
-	couponService := service.NewCouponService(couponUsecase, logger)
+	idempotencyRepo := data.NewIdempotencyRepo(confData, dataData, logger)
+	idempotencyUsecase := biz.NewIdempotencyUsecase(idempotencyRepo, logger)
 	prescriptionRepo := data.NewPrescriptionRepo(dataData, logger)
-	prescriptionUsecase := biz.NewPrescriptionUsecase(prescriptionRepo, logger)
-	prescriptionService := service.NewPrescriptionService(prescriptionUsecase, logger)
+	paymentRepo := data.NewPaymentRepo(dataData, logger)
+	paymentGateways := data.NewPaymentGateways(payment, logger)
+	paymentUsecase := biz.NewPaymentUsecase(paymentRepo, idempotencyUsecase, paymentGateways, logger)
+	orderUsecase := biz.NewOrderUsecase(orderRepo, drugRepo, drugInventoryRepo, inventoryUsecase, couponUsecase, idempotencyUsecase, prescriptionRepo, interactionUsecase, paymentUsecase, logger)
+	orderService := service.NewOrderService(orderUsecase, order, logger)
+	couponService := service.NewCouponService(couponUsecase, logger)
+	pharmacistRepo := data.NewPharmacistRepo(dataData, logger)
+	consultationRepo := data.NewConsultationRepo(dataData, logger)
+	cityRepo := data.NewCityRepo(dataData, logger)
+	prescriptionUsecase := biz.NewPrescriptionUsecase(prescriptionRepo, pharmacistRepo, consultationRepo, drugRepo, cityRepo, orderUsecase, interactionUsecase, logger)
+	pharmacistUsecase := biz.NewPharmacistUsecase(pharmacistRepo, loginThrottle, logger)
+	prescriptionService := service.NewPrescriptionService(prescriptionUsecase, pharmacistUsecase, logger)
 	grpcServer := server.NewGRPCServer(confServer, serviceDoctorsService, serviceDrugService, serviceEstimateService, serviceCartService, orderService, couponService, prescriptionService, logger)
-	userRepo := data.NewUserRepo(dataData, logger)
 	userService := biz.NewUserUsecase(userRepo, logger)
-	cityRepo := data.NewCityRepo(dataData, logger)
 	cityService := biz.NewCityUsecase(cityRepo, logger)
 	serviceUserService := service.NewUserService(userService, dataData, cityService)
-	paymentRepo := data.NewPaymentRepo(dataData, logger)
-	paymentUsecase := biz.NewPaymentUsecase(paymentRepo, logger)
-	paymentService := service.NewPaymentService(paymentUsecase, dataData)
-	httpServer := server.NewHTTPServer(confServer, serviceDoctorsService, serviceDrugService, serviceEstimateService, serviceUserService, serviceCartService, paymentService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	refundRepo := data.NewRefundRepo(dataData, logger)
+	refundUsecase := biz.NewRefundUsecase(refundRepo, orderRepo, paymentRepo, drugInventoryRepo, orderUsecase, inventoryUsecase, paymentGateways, logger)
+	paymentService := service.NewPaymentService(paymentUsecase, refundUsecase, orderUsecase, dataData, logger)
+	statementParser := data.NewStatementParser()
+	reconcileUsecase := biz.NewReconcileUsecase(statementParser, paymentRepo, orderRepo, orderUsecase, logger)
+	reconcileService := service.NewReconcileService(reconcileUsecase, payment)
+	chatRepo := data.NewChatRepo(dataData, logger)
+	chatMediaChecker := data.NewChatMediaChecker()
+	chatUsecase := biz.NewChatUsecase(chatRepo, chatMediaChecker, prescriptionRepo, orderRepo, logger)
+	broker := data.NewChatBroker(dataData, logger)
+	chatService := service.NewChatService(chatUsecase, broker, logger)
+	chatPusher := data.NewChatPusher()
+	consultationUsecase := biz.NewConsultationUsecase(consultationRepo, doctorsRepo, paymentUsecase, refundUsecase, chatUsecase, chatPusher, idempotencyUsecase, logger)
+	consultationService := service.NewConsultationService(consultationUsecase, consultation, logger)
+	scheduleRepo := data.NewScheduleRepo(dataData, logger)
+	scheduleUsecase := biz.NewScheduleUsecase(scheduleRepo, doctorsRepo, logger)
+	scheduleService := service.NewScheduleService(scheduleUsecase, logger)
+	doctorWorkbenchService := service.NewDoctorWorkbenchService(doctorsService, consultationUsecase, prescriptionUsecase, logger)
+	httpServer := server.NewHTTPServer(confServer, serviceDoctorsService, serviceDrugService, serviceEstimateService, serviceUserService, serviceCartService, paymentService, reconcileService, chatService, consultationService, scheduleService, doctorWorkbenchService, prescriptionService, logger)
+	leaseRepo := data.NewLeaseRepo(dataData, logger)
+	orderExpiryServer := server.NewOrderExpiryServer(order, orderUsecase, leaseRepo, logger)
+	refundServer := server.NewRefundServer(order, refundUsecase, leaseRepo, logger)
+	consultationServer := server.NewConsultationServer(consultation, consultationUsecase, leaseRepo, logger)
+	drugIndexRepo := data.NewDrugIndexRepo(dataData, logger)
+	drugIndexUsecase := biz.NewDrugIndexUsecase(drugIndexRepo, logger)
+	drugIndexServer := server.NewDrugIndexServer(confData, drugIndexUsecase, leaseRepo, logger)
+	app := newApp(logger, grpcServer, httpServer, orderExpiryServer, refundServer, consultationServer, drugIndexServer)
 	return app, func() {
 		cleanup()
 	}, nil
 }
+
+// wireReconcile init reconcile usecase for the reconcile command.
+func wireReconcile(confData *conf.Data, alert *conf.Alert, payment *conf.Payment, logger log.Logger) (*biz.ReconcileUsecase, func(), error) {
+	statementParser := data.NewStatementParser()
+	db, err := data.NewDb(confData)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataData, cleanup, err := data.NewData(confData, logger, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	paymentRepo := data.NewPaymentRepo(dataData, logger)
+	orderRepo := data.NewOrderRepo(dataData, logger)
+	drugRepo := data.NewDrugRepo(dataData, logger)
+	drugInventoryRepo := data.NewDrugInventoryRepo(dataData, logger)
+	inventoryAlertRepo := data.NewInventoryAlertRepo(dataData, logger)
+	inventoryAlertSink := data.NewInventoryAlertSink(alert, logger)
+	inventoryUsecase := biz.NewInventoryUsecase(drugInventoryRepo, drugRepo, inventoryAlertRepo, inventoryAlertSink, logger)
+	couponRepo := data.NewCouponRepo(dataData, logger)
+	couponUsecase := biz.NewCouponUsecase(couponRepo, drugRepo, logger)
+	idempotencyRepo := data.NewIdempotencyRepo(confData, dataData, logger)
+	idempotencyUsecase := biz.NewIdempotencyUsecase(idempotencyRepo, logger)
+	prescriptionRepo := data.NewPrescriptionRepo(dataData, logger)
+	interactionRepo := data.NewInteractionRepo(dataData, logger)
+	interactionUsecase := biz.NewInteractionUsecase(interactionRepo, drugRepo, logger)
+	paymentGateways := data.NewPaymentGateways(payment, logger)
+	paymentUsecase := biz.NewPaymentUsecase(paymentRepo, idempotencyUsecase, paymentGateways, logger)
+	orderUsecase := biz.NewOrderUsecase(orderRepo, drugRepo, drugInventoryRepo, inventoryUsecase, couponUsecase, idempotencyUsecase, prescriptionRepo, interactionUsecase, paymentUsecase, logger)
+	reconcileUsecase := biz.NewReconcileUsecase(statementParser, paymentRepo, orderRepo, orderUsecase, logger)
+	return reconcileUsecase, func() {
+		cleanup()
+	}, nil
+}
+
+// wireReindex init drug index usecase for the reindex command.
+func wireReindex(confData *conf.Data, logger log.Logger) (*biz.DrugIndexUsecase, func(), error) {
+	db, err := data.NewDb(confData)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataData, cleanup, err := data.NewData(confData, logger, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	drugIndexRepo := data.NewDrugIndexRepo(dataData, logger)
+	drugIndexUsecase := biz.NewDrugIndexUsecase(drugIndexRepo, logger)
+	return drugIndexUsecase, func() {
+		cleanup()
+	}, nil
+}
@@ -42,6 +42,11 @@ func JWTMiddleware() func(http.Handler) http.Handler {
 				http.Error(w, "Token中缺少用户ID", http.StatusUnauthorized)
 				return
 			}
+			// 医生、药师ID与患者ID分别编号，只接受患者token
+			if TokenRole(token) != RolePatient {
+				http.Error(w, "请使用患者账号登录", http.StatusUnauthorized)
+				return
+			}
 
 			// 将用户ID存入请求上下文
 			ctx := context.WithValue(r.Context(), "user_id", userID)
@@ -121,7 +121,9 @@ func CreateAlipayOrder(orderID, subject, totalAmount, userID string) (string, er
 // 验证支付回调
 func VerifyAlipayCallback(params map[string]string) (bool, error) {
 	if alipayClient == nil {
-		return false, fmt.Errorf("支付宝客户端未初始化")
+		if err := InitAlipayClient(defaultConfig); err != nil {
+			return false, err
+		}
 	}
 
 	// 转换参数格式
@@ -142,7 +144,9 @@ func VerifyAlipayCallback(params map[string]string) (bool, error) {
 // 查询支付状态
 func QueryAlipayOrder(orderID string) (*alipay.TradeQueryRsp, error) {
 	if alipayClient == nil {
-		return nil, fmt.Errorf("支付宝客户端未初始化")
+		if err := InitAlipayClient(defaultConfig); err != nil {
+			return nil, err
+		}
 	}
 
 	var p = alipay.TradeQuery{}
@@ -186,3 +190,54 @@ func ParseAlipayCallback(params map[string]string) *PaymentResult {
 
 	return result
 }
+
+// 退款结果结构
+type RefundResult struct {
+	RefundID   string `json:"refund_id"`   // 退款请求号
+	TradeNo    string `json:"trade_no"`    // 支付宝交易号
+	RefundFee  string `json:"refund_fee"`  // 交易累计退款金额
+	FundChange bool   `json:"fund_change"` // 本次请求是否发生资金变化，重复请求为false
+	RefundTime string `json:"refund_time"` // 退款时间
+}
+
+// 申请退款，refundID作为退款请求号，同一退款请求重复提交不会重复退款
+func RefundAlipayOrder(orderID, tradeNo, refundID, refundAmount, refundReason string) (*RefundResult, error) {
+	if alipayClient == nil {
+		if err := InitAlipayClient(defaultConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	var p = alipay.TradeRefund{}
+	p.OutTradeNo = orderID
+	p.TradeNo = tradeNo
+	p.RefundAmount = refundAmount
+	p.RefundReason = refundReason
+	p.OutRequestNo = refundID
+
+	rsp, err := alipayClient.TradeRefund(context.Background(), p)
+	if err != nil {
+		return nil, fmt.Errorf("申请退款失败: %v", err)
+	}
+	if rsp.IsFailure() {
+		return nil, fmt.Errorf("申请退款失败: %s %s", rsp.Msg, rsp.SubMsg)
+	}
+
+	log.Printf("申请退款成功: orderID=%s, refundID=%s, amount=%s", orderID, refundID, refundAmount)
+	return &RefundResult{
+		RefundID:   refundID,
+		TradeNo:    rsp.TradeNo,
+		RefundFee:  rsp.RefundFee,
+		FundChange: rsp.FundChange == "Y",
+	}, nil
+}
+
+// 解析退款通知，退款成功时支付宝在交易通知中携带退款请求号
+func ParseAlipayRefundNotify(params map[string]string) *RefundResult {
+	return &RefundResult{
+		RefundID:   params["out_biz_no"],
+		TradeNo:    params["trade_no"],
+		RefundFee:  params["refund_fee"],
+		RefundTime: params["gmt_refund"],
+	}
+}
@@ -0,0 +1,132 @@
+package comment
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 聊天事件的跨节点广播，多副本部署时接收方可能连接在其他节点上
+type ChatBroker interface {
+	// 广播事件，所有节点（包括本节点）的订阅者都会收到
+	Publish(ctx context.Context, payload []byte) error
+	// 订阅事件，返回时订阅已生效，ctx 取消后退出
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// 用户在线状态存储，多副本部署时共享心跳记录
+type PresenceStore interface {
+	// 记录用户心跳时间
+	Touch(ctx context.Context, userID string, at time.Time) error
+	// 用户最后心跳时间，没有记录时返回零值
+	LastSeen(ctx context.Context, userID string) (time.Time, error)
+	// 最后心跳不早于 since 的用户
+	OnlineSince(ctx context.Context, since time.Time) ([]string, error)
+	// 移除用户心跳记录
+	Remove(ctx context.Context, userIDs ...string) error
+	// 清理早于 before 的心跳记录，返回被清理的用户
+	Expire(ctx context.Context, before time.Time) ([]string, error)
+}
+
+// 跨节点代理，同时承担聊天事件广播和在线状态共享
+type Broker interface {
+	ChatBroker
+	PresenceStore
+}
+
+// 进程内代理，未配置Redis时使用，只能在本进程内投递
+type MemoryBroker struct {
+	handlers   map[int]func([]byte)
+	nextID     int
+	heartbeats map[string]time.Time
+	mutex      sync.RWMutex
+}
+
+// 默认代理，由默认聊天管理器和心跳管理器共用
+var defaultBroker = NewMemoryBroker()
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		handlers:   make(map[int]func([]byte)),
+		heartbeats: make(map[string]time.Time),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, payload []byte) error {
+	b.mutex.RLock()
+	handlers := make([]func([]byte), 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, handler func([]byte)) error {
+	b.mutex.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mutex.Lock()
+		delete(b.handlers, id)
+		b.mutex.Unlock()
+	}()
+	return nil
+}
+
+func (b *MemoryBroker) Touch(ctx context.Context, userID string, at time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.heartbeats[userID] = at
+	return nil
+}
+
+func (b *MemoryBroker) LastSeen(ctx context.Context, userID string) (time.Time, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.heartbeats[userID], nil
+}
+
+func (b *MemoryBroker) OnlineSince(ctx context.Context, since time.Time) ([]string, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	var users []string
+	for userID, lastHeartbeat := range b.heartbeats {
+		if !lastHeartbeat.Before(since) {
+			users = append(users, userID)
+		}
+	}
+	return users, nil
+}
+
+func (b *MemoryBroker) Remove(ctx context.Context, userIDs ...string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, userID := range userIDs {
+		delete(b.heartbeats, userID)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Expire(ctx context.Context, before time.Time) ([]string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var expired []string
+	for userID, lastHeartbeat := range b.heartbeats {
+		if lastHeartbeat.Before(before) {
+			expired = append(expired, userID)
+			delete(b.heartbeats, userID)
+		}
+	}
+	return expired, nil
+}
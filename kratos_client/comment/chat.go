@@ -1,11 +1,16 @@
 package comment
 
 import (
-	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,48 +26,202 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// WebSocket事件类型，聊天内容使用消息类型本身（如 text）
+const (
+	ChatEventSent      = "sent"      // 服务端 -> 发送方：消息已落库，回传消息ID
+	ChatEventAck       = "ack"       // 接收方 -> 服务端：确认收到 IDs 中的消息
+	ChatEventDelivered = "delivered" // 服务端 -> 发送方：IDs 中的消息已送达
+	ChatEventRead      = "read"      // 接收方 -> 服务端：已读到 ID；服务端 -> 发送方：对方已读到 ID
+	ChatEventError     = "error"     // 服务端 -> 客户端：处理失败
+)
+
+const (
+	chatWriteWait  = 10 * time.Second
+	chatPongWait   = 60 * time.Second
+	chatPingPeriod = 54 * time.Second
+)
+
 // 聊天消息结构
 type ChatMessage struct {
-	Type      string    `json:"type"`
-	Content   string    `json:"content"`
-	FromID    int32     `json:"from_id"`
-	ToID      int32     `json:"to_id"`
-	FromName  string    `json:"from_name"`
-	Timestamp time.Time `json:"timestamp"`
-	RoomID    string    `json:"room_id"`
+	ID          int64           `json:"id,omitempty"` // 持久化后的消息ID
+	IDs         []int64         `json:"ids,omitempty"`
+	ClientMsgID string          `json:"client_msg_id,omitempty"` // 客户端生成的消息标识，用于匹配 sent 回执
+	Type        string          `json:"type"`
+	Content     string          `json:"content"`
+	FromRole    string          `json:"from_role"` // 发送方角色，以token为准
+	FromID      int32           `json:"from_id"`
+	ToRole      string          `json:"to_role"` // 接收方角色，未填写时取连接的默认会话对象的角色
+	ToID        int32           `json:"to_id"`
+	FromName    string          `json:"from_name"`
+	Timestamp   time.Time       `json:"timestamp"`
+	RoomID      string          `json:"room_id"`
+	Payload     json.RawMessage `json:"payload,omitempty"` // 图片、语音、处方、订单等消息的结构化数据
+}
+
+func (m *ChatMessage) from() ChatPeer {
+	return ChatPeer{Role: m.FromRole, ID: m.FromID}
+}
+
+func (m *ChatMessage) to() ChatPeer {
+	return ChatPeer{Role: m.ToRole, ID: m.ToID}
+}
+
+// 聊天用户，患者、医生和药师的ID各自编号，须按角色和ID共同区分
+type ChatPeer struct {
+	Role string `json:"role"`
+	ID   int32  `json:"id"`
+}
+
+// 在线状态的用户标识，格式 {角色}:{ID}
+func (p ChatPeer) PresenceKey() string {
+	return p.Role + ":" + strconv.Itoa(int(p.ID))
 }
 
-// 客户端连接结构
+// 是否为token签发的角色
+func validChatRole(role string) bool {
+	return role == RolePatient || role == RoleDoctor || role == RolePharmacist
+}
+
+// 未指定对方角色时的默认值：患者默认与医生聊天，医生和药师默认与患者聊天
+func defaultChatPeerRole(role string) string {
+	if role == RolePatient {
+		return RoleDoctor
+	}
+	return RolePatient
+}
+
+// 聊天消息持久化接口，由业务层在启动时注册；未注册时消息只做在线转发
+type ChatMessageStore interface {
+	// 保存消息，回填消息ID，返回错误时消息不投递
+	SaveChatMessage(ctx context.Context, message *ChatMessage) error
+	// 发给用户且尚未送达的消息，连接建立时补发
+	PendingChatMessages(ctx context.Context, user ChatPeer) ([]ChatMessage, error)
+	// 接收方确认收到，返回本次新确认的消息
+	AckChatMessages(ctx context.Context, user ChatPeer, ids []int64) ([]ChatMessage, error)
+	// 接收方已读到 upToID，返回实际已读到的消息ID
+	ReadChatMessages(ctx context.Context, user, peer ChatPeer, upToID int64) (int64, error)
+}
+
+// 客户端连接结构，同一用户可以有多个连接（网页、手机）
 type Client struct {
-	ID     int32
-	Name   string
-	Role   string // "doctor" 或 "patient"
-	Conn   *websocket.Conn
-	Send   chan ChatMessage
-	RoomID string
+	ID      int32
+	Name    string
+	Role    string // token中的角色：patient、doctor 或 pharmacist
+	Conn    *websocket.Conn
+	Send    chan ChatMessage
+	RoomID  string   // 连接时指定的默认会话，消息未带 to_id 时发给该会话的对方
+	Target  ChatPeer // 默认会话对象，未指定 target_id 时只有角色
+	connID  string   // 连接标识，跨节点推送时用于排除发起推送的连接
+	manager *ChatManager
+}
+
+func (c *Client) peer() ChatPeer {
+	return ChatPeer{Role: c.Role, ID: c.ID}
+}
+
+// 节点间广播的投递事件，各节点只推送给本节点上的连接
+type chatDelivery struct {
+	Role    string      `json:"role"`
+	UserID  int32       `json:"user_id"`
+	Except  string      `json:"except,omitempty"` // 不推送的连接
+	Message ChatMessage `json:"message"`
 }
 
-// 一对一聊天管理器
+// 一对一聊天管理器，本节点的连接保存在内存中，推送经代理广播到所有节点
 type ChatManager struct {
-	clients    map[string]*Client // key: roomID_userID
+	nodeID     string
+	connSeq    uint64
+	clients    map[ChatPeer]map[*Client]struct{} // key: 用户角色和ID
 	register   chan *Client
 	unregister chan *Client
-	message    chan ChatMessage
+	store      ChatMessageStore
+	broker     ChatBroker
+	cancel     context.CancelFunc // 退出当前代理的订阅
+	presence   *HeartbeatManager
 	mutex      sync.RWMutex
 }
 
-// 全局聊天管理器实例
-var chatManager *ChatManager
+// 全局聊天管理器实例，默认使用进程内代理，启动时通过 SetChatBroker 切换
+var chatManager = NewChatManager(defaultBroker, heartbeatManager)
 
-// 初始化聊天管理器
-func init() {
-	chatManager = &ChatManager{
-		clients:    make(map[string]*Client),
+// 创建聊天管理器并订阅代理
+func NewChatManager(broker ChatBroker, presence *HeartbeatManager) *ChatManager {
+	cm := &ChatManager{
+		nodeID:     newChatNodeID(),
+		clients:    make(map[ChatPeer]map[*Client]struct{}),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
-		message:    make(chan ChatMessage),
+		presence:   presence,
+	}
+	if err := cm.SetBroker(broker); err != nil {
+		log.Printf("订阅聊天代理失败: %v", err)
+	}
+	go cm.run()
+	return cm
+}
+
+// 节点标识，同一进程内的多个管理器也互不相同
+func newChatNodeID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// 注册聊天消息持久化实现
+func SetChatMessageStore(store ChatMessageStore) {
+	chatManager.SetStore(store)
+}
+
+// 切换全局聊天管理器和心跳管理器的跨节点代理
+func SetChatBroker(broker Broker) error {
+	if err := chatManager.SetBroker(broker); err != nil {
+		return err
+	}
+	heartbeatManager.SetStore(broker)
+	return nil
+}
+
+// 注册聊天消息持久化实现
+func (cm *ChatManager) SetStore(store ChatMessageStore) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	cm.store = store
+}
+
+// 切换跨节点代理，先订阅新代理再退出旧订阅
+func (cm *ChatManager) SetBroker(broker ChatBroker) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := broker.Subscribe(ctx, cm.receive); err != nil {
+		cancel()
+		return err
+	}
+
+	cm.mutex.Lock()
+	previous := cm.cancel
+	cm.broker, cm.cancel = broker, cancel
+	cm.mutex.Unlock()
+	if previous != nil {
+		previous()
+	}
+	return nil
+}
+
+// 退出代理订阅，本节点不再接收其他节点的推送
+func (cm *ChatManager) Close() {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	if cm.cancel != nil {
+		cm.cancel()
+		cm.cancel = nil
 	}
-	go chatManager.run()
+}
+
+func (cm *ChatManager) getStore() ChatMessageStore {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return cm.store
 }
 
 // 运行聊天管理器
@@ -74,9 +233,6 @@ func (cm *ChatManager) run() {
 
 		case client := <-cm.unregister:
 			cm.unregisterClient(client)
-
-		case message := <-cm.message:
-			cm.handleMessage(message)
 		}
 	}
 }
@@ -86,10 +242,13 @@ func (cm *ChatManager) registerClient(client *Client) {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
-	clientKey := client.RoomID + "_" + strconv.Itoa(int(client.ID))
-	cm.clients[clientKey] = client
+	peer := client.peer()
+	if cm.clients[peer] == nil {
+		cm.clients[peer] = make(map[*Client]struct{})
+	}
+	cm.clients[peer][client] = struct{}{}
 
-	log.Printf("客户端 %d (%s) 连接到房间 %s", client.ID, client.Name, client.RoomID)
+	log.Printf("客户端 %s (%s) 已连接，当前设备数 %d", peer.PresenceKey(), client.Name, len(cm.clients[peer]))
 }
 
 // 注销客户端
@@ -97,120 +256,271 @@ func (cm *ChatManager) unregisterClient(client *Client) {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
-	clientKey := client.RoomID + "_" + strconv.Itoa(int(client.ID))
-	if _, exists := cm.clients[clientKey]; exists {
-		delete(cm.clients, clientKey)
-		close(client.Send)
-		log.Printf("客户端 %d (%s) 断开连接", client.ID, client.Name)
+	peer := client.peer()
+	conns := cm.clients[peer]
+	if _, exists := conns[client]; !exists {
+		return
+	}
+	delete(conns, client)
+	if len(conns) == 0 {
+		delete(cm.clients, peer)
 	}
+	close(client.Send)
+	log.Printf("客户端 %s (%s) 断开连接", peer.PresenceKey(), client.Name)
 }
 
-// 处理消息（一对一发送）
-func (cm *ChatManager) handleMessage(message ChatMessage) {
+// 推送给用户在所有节点上的连接，except 为发起推送的连接
+func (cm *ChatManager) deliver(user ChatPeer, message ChatMessage, except *Client) {
+	delivery := chatDelivery{Role: user.Role, UserID: user.ID, Message: message}
+	if except != nil {
+		delivery.Except = except.connID
+	}
+
+	cm.mutex.RLock()
+	broker := cm.broker
+	cm.mutex.RUnlock()
+
+	payload, err := json.Marshal(delivery)
+	if err == nil {
+		err = broker.Publish(context.Background(), payload)
+	}
+	if err != nil {
+		// 广播失败时至少推送给本节点的连接，其他节点上的接收方在重连后补发
+		log.Printf("广播聊天事件失败: %v", err)
+		cm.deliverLocal(delivery)
+	}
+}
+
+// 处理代理广播的投递事件
+func (cm *ChatManager) receive(payload []byte) {
+	var delivery chatDelivery
+	if err := json.Unmarshal(payload, &delivery); err != nil {
+		log.Printf("解析聊天事件失败: %v", err)
+		return
+	}
+	cm.deliverLocal(delivery)
+}
+
+// 推送给用户在本节点上的连接
+func (cm *ChatManager) deliverLocal(delivery chatDelivery) {
 	cm.mutex.RLock()
 	defer cm.mutex.RUnlock()
 
-	// 找到目标用户的连接
-	targetClientKey := message.RoomID + "_" + strconv.Itoa(int(message.ToID))
-	if targetClient, exists := cm.clients[targetClientKey]; exists {
+	for client := range cm.clients[ChatPeer{Role: delivery.Role, ID: delivery.UserID}] {
+		if delivery.Except != "" && client.connID == delivery.Except {
+			continue
+		}
+		select {
+		case client.Send <- delivery.Message:
+		default:
+			// 发送队列已满，关闭慢连接，读协程退出时注销；未确认的消息在重连后补发
+			log.Printf("客户端 %s 发送队列已满，断开连接", client.peer().PresenceKey())
+			client.Conn.Close()
+		}
+	}
+}
+
+// 持久化消息后推送给接收方的所有设备，并同步到发送方的其他设备
+func (cm *ChatManager) dispatch(message *ChatMessage, from *Client) error {
+	if store := cm.getStore(); store != nil {
+		if err := store.SaveChatMessage(context.Background(), message); err != nil {
+			return err
+		}
+	}
+	cm.deliver(message.to(), *message, nil)
+	cm.deliver(message.from(), *message, from)
+	return nil
+}
+
+// 补发离线期间未送达的消息，客户端确认后才标记送达
+func (cm *ChatManager) replay(client *Client) {
+	store := cm.getStore()
+	if store == nil {
+		return
+	}
+	messages, err := store.PendingChatMessages(context.Background(), client.peer())
+	if err != nil {
+		log.Printf("查询未送达消息失败: %v", err)
+		return
+	}
+	for _, message := range messages {
 		select {
-		case targetClient.Send <- message:
-			log.Printf("消息从 %d 发送到 %d: %s", message.FromID, message.ToID, message.Content)
+		case client.Send <- message:
 		default:
-			// 如果发送失败，关闭连接
-			close(targetClient.Send)
-			delete(cm.clients, targetClientKey)
+			// 队列放满后停止补发，剩余消息在下次连接时补发
+			return
 		}
 	}
 }
 
-// 生成房间ID（基于两个用户ID）
-func generateRoomID(userID1, userID2 int32) string {
-	if userID1 > userID2 {
-		userID1, userID2 = userID2, userID1
-	}
-	return "room_" + strconv.Itoa(int(userID1)) + "_" + strconv.Itoa(int(userID2))
-}
-
-// WebSocket连接处理器
-func HandleWebSocket(w http.ResponseWriter, r *http.Request, ctx kratoshttp.Context) {
-    // 从查询参数获取用户信息
-    userName := r.URL.Query().Get("user_name")
-    userRole := r.URL.Query().Get("user_role") // "doctor" 或 "patient"
-    targetIDStr := r.URL.Query().Get("target_id")
-    token := r.URL.Query().Get("token")
-
-    // 校验必要参数
-    if userName == "" || targetIDStr == "" || token == "" {
-        http.Error(w, "缺少必要参数", http.StatusBadRequest)
-        return
-    }
-
-    // 简单的token验证（实际应用中应该验证JWT）
-    if token == "" {
-        http.Error(w, "无效的token", http.StatusUnauthorized)
-        return
-    }
-
-    // 从token中提取用户ID（这里简化处理，实际应该解析JWT）
-    // 假设token格式为 "token_1755161387423"，我们提取数字部分作为用户ID
-    var userID int
-    if len(token) > 6 && token[:6] == "token_" {
-        if id, err := strconv.Atoi(token[6:]); err == nil {
-            userID = id % 10000 // 取模避免ID过大
-        }
-    }
-    
-    // 如果无法从token提取ID，使用默认值
-    if userID == 0 {
-        userID = 1
-    }
-
-    targetID, err := strconv.Atoi(targetIDStr)
-    if err != nil {
-        http.Error(w, "无效的目标用户ID", http.StatusBadRequest)
-        return
-    }
-
-    // 升级HTTP连接为WebSocket
-    conn, err := upgrader.Upgrade(w, r, nil)
-    if err != nil {
-        log.Printf("WebSocket升级失败: %v", err)
-        return
-    }
-
-    // 生成房间ID
-    roomID := generateRoomID(int32(userID), int32(targetID))
-
-    // 创建客户端
-    client := &Client{
-        ID:     int32(userID),
-        Name:   userName,
-        Role:   userRole,
-        Conn:   conn,
-        Send:   make(chan ChatMessage, 256),
-        RoomID: roomID,
-    }
-
-    // 注册客户端
-    chatManager.register <- client
-
-    // 启动读写协程
-    go client.writePump()
-    go client.readPump(int32(targetID))
-}
-
-// 读取消息
-func (c *Client) readPump(targetID int32) {
+// 处理接收方的送达确认，通知发送方
+func (cm *ChatManager) ack(client *Client, ids []int64) error {
+	store := cm.getStore()
+	if store == nil || len(ids) == 0 {
+		return nil
+	}
+	messages, err := store.AckChatMessages(context.Background(), client.peer(), ids)
+	if err != nil {
+		return err
+	}
+
+	// 按发送方归集回执
+	receipts := make(map[ChatPeer][]int64)
+	for _, message := range messages {
+		receipts[message.from()] = append(receipts[message.from()], message.ID)
+	}
+	for sender, acked := range receipts {
+		cm.deliver(sender, ChatMessage{
+			Type:      ChatEventDelivered,
+			IDs:       acked,
+			FromRole:  client.Role,
+			FromID:    client.ID,
+			ToRole:    sender.Role,
+			ToID:      sender.ID,
+			Timestamp: time.Now(),
+			RoomID:    generateRoomID(client.peer(), sender),
+		}, nil)
+	}
+	return nil
+}
+
+// 处理接收方的已读回执，通知发送方
+func (cm *ChatManager) read(client *Client, peer ChatPeer, upToID int64) error {
+	readID := upToID
+	if store := cm.getStore(); store != nil {
+		var err error
+		if readID, err = store.ReadChatMessages(context.Background(), client.peer(), peer, upToID); err != nil {
+			return err
+		}
+	}
+	if readID > 0 {
+		cm.NotifyRead(client.peer(), peer, readID)
+	}
+	return nil
+}
+
+// 生成房间ID，格式 room_{角色}_{ID}_{角色}_{ID}，先按角色再按ID排序，与业务层的房间号一致
+func generateRoomID(user1, user2 ChatPeer) string {
+	if user2.Role < user1.Role || (user2.Role == user1.Role && user2.ID < user1.ID) {
+		user1, user2 = user2, user1
+	}
+	return "room_" + user1.Role + "_" + strconv.Itoa(int(user1.ID)) + "_" + user2.Role + "_" + strconv.Itoa(int(user2.ID))
+}
+
+// 解析房间ID中的两个用户
+func parseRoomID(roomID string) ([2]ChatPeer, bool) {
+	var peers [2]ChatPeer
+	if !strings.HasPrefix(roomID, "room_") {
+		return peers, false
+	}
+	parts := strings.Split(strings.TrimPrefix(roomID, "room_"), "_")
+	if len(parts) != 4 {
+		return peers, false
+	}
+	for i := range peers {
+		id, err := strconv.Atoi(parts[2*i+1])
+		if err != nil || !validChatRole(parts[2*i]) {
+			return peers, false
+		}
+		peers[i] = ChatPeer{Role: parts[2*i], ID: int32(id)}
+	}
+	return peers, true
+}
+
+// 从请求中取出JWT，浏览器WebSocket无法设置请求头，支持查询参数 token
+func chatToken(r *http.Request) string {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// WebSocket连接处理器，路由 /ws/chat?token=...&target_id=...&target_role=...
+func HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	chatManager.HandleWebSocket(w, r)
+}
+
+// 建立本节点上的WebSocket连接
+func (cm *ChatManager) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	claims, errMsg := GetToken(chatToken(r))
+	if claims == nil || errMsg != "" {
+		http.Error(w, "无效的token", http.StatusUnauthorized)
+		return
+	}
+	userID, ok := claims["user"].(float64)
+	if !ok || userID <= 0 {
+		http.Error(w, "token中缺少用户ID", http.StatusUnauthorized)
+		return
+	}
+	// 角色以token为准，医生和患者的ID可能相同
+	role := TokenRole(claims)
+	if !validChatRole(role) {
+		http.Error(w, "token中的角色无效", http.StatusUnauthorized)
+		return
+	}
+
+	// target_id 可选，指定默认会话；target_role 未填写时患者默认为医生，医生和药师默认为患者
+	var targetID int
+	if targetIDStr := r.URL.Query().Get("target_id"); targetIDStr != "" {
+		var err error
+		if targetID, err = strconv.Atoi(targetIDStr); err != nil || targetID <= 0 {
+			http.Error(w, "无效的目标用户ID", http.StatusBadRequest)
+			return
+		}
+	}
+	targetRole := r.URL.Query().Get("target_role")
+	if targetRole == "" {
+		targetRole = defaultChatPeerRole(role)
+	}
+	if !validChatRole(targetRole) {
+		http.Error(w, "无效的目标用户角色", http.StatusBadRequest)
+		return
+	}
+
+	// 升级HTTP连接为WebSocket
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("WebSocket升级失败: %v", err)
+		return
+	}
+
+	client := &Client{
+		ID:      int32(userID),
+		Name:    r.URL.Query().Get("user_name"),
+		Role:    role,
+		Conn:    conn,
+		Send:    make(chan ChatMessage, 256),
+		Target:  ChatPeer{Role: targetRole, ID: int32(targetID)},
+		connID:  cm.nodeID + "-" + strconv.FormatUint(atomic.AddUint64(&cm.connSeq, 1), 10),
+		manager: cm,
+	}
+	if client.Target.ID > 0 {
+		client.RoomID = generateRoomID(client.peer(), client.Target)
+	}
+
+	// 注册客户端
+	cm.register <- client
+	cm.presence.UpdateHeartbeat(client.peer().PresenceKey())
+
+	// 启动读写协程
+	go client.writePump()
+	go client.readPump()
+}
+
+// 读取消息，发送队列只在本协程退出时关闭，本协程内可以安全地写入发送队列
+func (c *Client) readPump() {
 	defer func() {
-		chatManager.unregister <- c
+		c.manager.unregister <- c
 		c.Conn.Close()
 	}()
 
+	c.manager.replay(c)
+
 	// 设置读取超时
-	c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	c.Conn.SetReadDeadline(time.Now().Add(chatPongWait))
 	c.Conn.SetPongHandler(func(string) error {
-		c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		c.Conn.SetReadDeadline(time.Now().Add(chatPongWait))
+		c.manager.presence.UpdateHeartbeat(c.peer().PresenceKey())
 		return nil
 	})
 
@@ -224,21 +534,94 @@ func (c *Client) readPump(targetID int32) {
 			break
 		}
 
-		// 设置消息信息
-		message.FromID = c.ID
-		message.ToID = targetID
-		message.FromName = c.Name
-		message.Timestamp = time.Now()
-		message.RoomID = c.RoomID
+		if message.ToID == 0 {
+			message.ToID = c.Target.ID
+		}
+		if message.ToRole == "" {
+			message.ToRole = c.Target.Role
+		}
+		switch message.Type {
+		case ChatEventAck:
+			ids := message.IDs
+			if message.ID > 0 {
+				ids = append(ids, message.ID)
+			}
+			if err := c.manager.ack(c, ids); err != nil {
+				log.Printf("处理送达确认失败: %v", err)
+				c.sendError(message.ClientMsgID, "送达确认失败")
+			}
+		case ChatEventRead:
+			if message.ToID <= 0 || !validChatRole(message.ToRole) {
+				c.sendError(message.ClientMsgID, "缺少会话对象")
+				continue
+			}
+			if err := c.manager.read(c, message.to(), message.ID); err != nil {
+				log.Printf("处理已读回执失败: %v", err)
+				c.sendError(message.ClientMsgID, "已读回执失败")
+			}
+		case ChatEventSent, ChatEventDelivered, ChatEventError:
+			// 服务端事件，客户端不应发送
+			c.sendError(message.ClientMsgID, "不支持的消息类型")
+		default:
+			if message.ToID <= 0 || !validChatRole(message.ToRole) {
+				c.sendError(message.ClientMsgID, "缺少接收者")
+				continue
+			}
+			// 发送方身份以token为准
+			message.ID = 0
+			message.IDs = nil
+			message.FromRole = c.Role
+			message.FromID = c.ID
+			message.FromName = c.Name
+			message.Timestamp = time.Now()
+			message.RoomID = generateRoomID(c.peer(), message.to())
+			if message.Type == "" {
+				message.Type = "text"
+			}
+
+			// 落库后发送消息给目标用户
+			if err := c.manager.dispatch(&message, c); err != nil {
+				log.Printf("保存聊天消息失败: %v", err)
+				c.sendError(message.ClientMsgID, "消息发送失败")
+				continue
+			}
+			c.enqueue(ChatMessage{
+				ID:          message.ID,
+				ClientMsgID: message.ClientMsgID,
+				Type:        ChatEventSent,
+				FromRole:    message.FromRole,
+				FromID:      message.FromID,
+				ToRole:      message.ToRole,
+				ToID:        message.ToID,
+				Timestamp:   message.Timestamp,
+				RoomID:      message.RoomID,
+			})
+		}
+	}
+}
 
-		// 发送消息给目标用户
-		chatManager.message <- message
+// 放入本连接的发送队列，连接只允许单协程写入，经写协程发出
+func (c *Client) enqueue(message ChatMessage) {
+	select {
+	case c.Send <- message:
+	default:
 	}
 }
 
+// 向本连接返回错误
+func (c *Client) sendError(clientMsgID, content string) {
+	c.enqueue(ChatMessage{
+		ClientMsgID: clientMsgID,
+		Type:        ChatEventError,
+		Content:     content,
+		Timestamp:   time.Now(),
+		RoomID:      c.RoomID,
+	})
+}
+
 // 写入消息
 func (c *Client) writePump() {
-	ticker := time.NewTicker(54 * time.Second)
+	ticker := time.NewTicker(chatPingPeriod)
 	defer func() {
 		ticker.Stop()
 		c.Conn.Close()
@@ -247,7 +630,7 @@ func (c *Client) writePump() {
 	for {
 		select {
 		case message, ok := <-c.Send:
-			c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			c.Conn.SetWriteDeadline(time.Now().Add(chatWriteWait))
 			if !ok {
 				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
@@ -259,7 +642,7 @@ func (c *Client) writePump() {
 			}
 
 		case <-ticker.C:
-			c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			c.Conn.SetWriteDeadline(time.Now().Add(chatWriteWait))
 			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
@@ -267,39 +650,84 @@ func (c *Client) writePump() {
 	}
 }
 
-// 获取房间中的在线用户
+// 获取房间中连接在本节点上的在线用户
 func GetRoomUsers(roomID string) []map[string]interface{} {
-	chatManager.mutex.RLock()
-	defer chatManager.mutex.RUnlock()
+	return chatManager.RoomUsers(roomID)
+}
+
+// 获取房间中连接在本节点上的在线用户，房间号中的两个用户各返回一条
+func (cm *ChatManager) RoomUsers(roomID string) []map[string]interface{} {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
 
 	users := make([]map[string]interface{}, 0)
-	for _, client := range chatManager.clients {
-		if client.RoomID == roomID {
+	peers, ok := parseRoomID(roomID)
+	if !ok {
+		return users
+	}
+	for _, peer := range peers {
+		conns := cm.clients[peer]
+		for client := range conns {
 			users = append(users, map[string]interface{}{
-				"id":   client.ID,
-				"name": client.Name,
-				"role": client.Role,
+				"id":      client.ID,
+				"name":    client.Name,
+				"role":    client.Role,
+				"devices": len(conns),
 			})
+			break
 		}
 	}
 
 	return users
 }
 
-// 发送消息到特定用户
-func SendMessageToUser(roomID string, fromID, toID int32, fromName, content string) error {
+// 发送消息到特定用户，经持久化后推送
+func SendMessageToUser(from, to ChatPeer, fromName, content string) error {
 	message := ChatMessage{
 		Type:      "text",
 		Content:   content,
-		FromID:    fromID,
-		ToID:      toID,
+		FromRole:  from.Role,
+		FromID:    from.ID,
+		ToRole:    to.Role,
+		ToID:      to.ID,
 		FromName:  fromName,
 		Timestamp: time.Now(),
-		RoomID:    roomID,
+		RoomID:    generateRoomID(from, to),
 	}
 
-	chatManager.message <- message
-	return nil
+	return chatManager.dispatch(&message, nil)
+}
+
+// 推送已落库的消息，用于HTTP接口保存的消息
+func DeliverChatMessage(message ChatMessage) {
+	chatManager.DeliverMessage(message)
+}
+
+// 通知发送方对方已读到 readID
+func NotifyChatRead(reader, peer ChatPeer, readID int64) {
+	chatManager.NotifyRead(reader, peer, readID)
+}
+
+// 推送已落库的消息给收发双方的所有设备
+func (cm *ChatManager) DeliverMessage(message ChatMessage) {
+	cm.deliver(message.to(), message, nil)
+	cm.deliver(message.from(), message, nil)
+}
+
+// 通知发送方对方已读到 readID，同时同步给读者的其他设备
+func (cm *ChatManager) NotifyRead(reader, peer ChatPeer, readID int64) {
+	receipt := ChatMessage{
+		ID:        readID,
+		Type:      ChatEventRead,
+		FromRole:  reader.Role,
+		FromID:    reader.ID,
+		ToRole:    peer.Role,
+		ToID:      peer.ID,
+		Timestamp: time.Now(),
+		RoomID:    generateRoomID(reader, peer),
+	}
+	cm.deliver(peer, receipt, nil)
+	cm.deliver(reader, receipt, nil)
 }
 
 // 简化的WebSocket测试处理器（用于调试）
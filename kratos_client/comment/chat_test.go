@@ -0,0 +1,224 @@
+package comment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 内存实现的消息存储，delivered 为空即离线队列
+type memoryChatStore struct {
+	mu        sync.Mutex
+	messages  []ChatMessage
+	delivered map[int64]bool
+	replayed  int // 补发查询次数
+}
+
+func (s *memoryChatStore) SaveChatMessage(ctx context.Context, message *ChatMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	message.ID = int64(len(s.messages) + 1)
+	s.messages = append(s.messages, *message)
+	return nil
+}
+
+func (s *memoryChatStore) PendingChatMessages(ctx context.Context, user ChatPeer) ([]ChatMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replayed++
+	var pending []ChatMessage
+	for _, message := range s.messages {
+		if message.to() == user && !s.delivered[message.ID] {
+			pending = append(pending, message)
+		}
+	}
+	return pending, nil
+}
+
+func (s *memoryChatStore) AckChatMessages(ctx context.Context, user ChatPeer, ids []int64) ([]ChatMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var acked []ChatMessage
+	for _, id := range ids {
+		if id <= 0 || int(id) > len(s.messages) {
+			continue
+		}
+		message := s.messages[id-1]
+		if message.to() == user && !s.delivered[id] {
+			s.delivered[id] = true
+			acked = append(acked, message)
+		}
+	}
+	return acked, nil
+}
+
+func (s *memoryChatStore) ReadChatMessages(ctx context.Context, user, peer ChatPeer, upToID int64) (int64, error) {
+	return upToID, nil
+}
+
+// 按角色签发token，query 为附加的查询参数
+func dialChat(t *testing.T, server *httptest.Server, user ChatPeer, query ...string) *websocket.Conn {
+	t.Helper()
+	handlers := map[string]func(int32) (string, error){
+		RolePatient:    TokenHandler,
+		RoleDoctor:     DoctorTokenHandler,
+		RolePharmacist: PharmacistTokenHandler,
+	}
+	token, err := handlers[user.Role](user.ID)
+	if err != nil {
+		t.Fatalf("TokenHandler failed: %v", err)
+	}
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?token=" + token
+	for _, q := range query {
+		url += "&" + q
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readChat(t *testing.T, conn *websocket.Conn) ChatMessage {
+	t.Helper()
+	var message ChatMessage
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	return message
+}
+
+// 等待连接注册完成，注册经管理器协程异步处理
+func waitOnline(t *testing.T, cm *ChatManager, user ChatPeer, count int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		cm.mutex.RLock()
+		n := len(cm.clients[user])
+		cm.mutex.RUnlock()
+		if n == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d connections for user %s", count, user.PresenceKey())
+}
+
+// 等待连接建立时的补发查询完成，之后发送的消息只会在线推送
+func waitReplayed(t *testing.T, s *memoryChatStore, count int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		s.mu.Lock()
+		n := s.replayed
+		s.mu.Unlock()
+		if n >= count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d replay queries", count)
+}
+
+// 测试token认证、离线补发、多设备推送和送达回执
+func TestChatWebSocket(t *testing.T) {
+	store := &memoryChatStore{delivered: map[int64]bool{}}
+	SetChatMessageStore(store)
+	defer SetChatMessageStore(nil)
+	server := httptest.NewServer(http.HandlerFunc(HandleWebSocket))
+	defer server.Close()
+
+	if _, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?token=token_7", nil); err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for invalid token, got %v", err)
+	}
+
+	doctor, patient := ChatPeer{Role: RoleDoctor, ID: 1007}, ChatPeer{Role: RolePatient, ID: 1003}
+	patientConn := dialChat(t, server, patient)
+	waitOnline(t, chatManager, patient, 1)
+
+	// 医生离线时消息进入离线队列
+	patientConn.WriteJSON(ChatMessage{Type: "text", Content: "医生您好", ToID: doctor.ID, ClientMsgID: "c-1"})
+	sent := readChat(t, patientConn)
+	if sent.Type != ChatEventSent || sent.ClientMsgID != "c-1" || sent.ID != 1 {
+		t.Fatalf("Unexpected sent receipt %+v", sent)
+	}
+
+	// 医生网页端上线后收到补发
+	web := dialChat(t, server, doctor)
+	replayed := readChat(t, web)
+	if replayed.ID != 1 || replayed.from() != patient || replayed.Content != "医生您好" {
+		t.Fatalf("Unexpected replayed message %+v", replayed)
+	}
+	phone := dialChat(t, server, doctor)
+	readChat(t, phone)
+	waitOnline(t, chatManager, doctor, 2)
+
+	// 确认送达后通知发送方，重复确认不再通知
+	web.WriteJSON(ChatMessage{Type: ChatEventAck, IDs: []int64{1}})
+	phone.WriteJSON(ChatMessage{Type: ChatEventAck, IDs: []int64{1}})
+	delivered := readChat(t, patientConn)
+	if delivered.Type != ChatEventDelivered || len(delivered.IDs) != 1 || delivered.IDs[0] != 1 {
+		t.Fatalf("Unexpected delivered receipt %+v", delivered)
+	}
+
+	// 在线消息推送到接收方所有设备，并同步到发送方的其他设备
+	web.WriteJSON(ChatMessage{Type: "text", Content: "请描述症状", ToID: patient.ID, FromID: 1, FromRole: RolePatient})
+	if got := readChat(t, patientConn); got.Content != "请描述症状" || got.from() != doctor {
+		t.Errorf("Unexpected message to patient %+v", got)
+	}
+	if got := readChat(t, phone); got.Content != "请描述症状" || got.ID != 2 {
+		t.Errorf("Unexpected message synced to phone %+v", got)
+	}
+	if got := readChat(t, web); got.Type != ChatEventSent || got.ID != 2 {
+		t.Errorf("Unexpected sent receipt %+v", got)
+	}
+}
+
+// 测试医生和患者ID相同时仍是不同的用户，角色只取自token
+func TestChatWebSocketRoles(t *testing.T) {
+	store := &memoryChatStore{delivered: map[int64]bool{}}
+	cm := NewChatManager(NewMemoryBroker(), NewHeartbeatManager(NewMemoryBroker()))
+	cm.SetStore(store)
+	t.Cleanup(cm.Close)
+	server := httptest.NewServer(http.HandlerFunc(cm.HandleWebSocket))
+	defer server.Close()
+
+	doctor, patient := ChatPeer{Role: RoleDoctor, ID: 5}, ChatPeer{Role: RolePatient, ID: 5}
+	other := ChatPeer{Role: RolePatient, ID: 6}
+	// 查询参数中的角色不生效
+	patientConn := dialChat(t, server, patient, "user_role=doctor")
+	doctorConn := dialChat(t, server, doctor)
+	otherConn := dialChat(t, server, other, "target_id=5")
+	waitOnline(t, cm, patient, 1)
+	waitOnline(t, cm, doctor, 1)
+	waitOnline(t, cm, other, 1)
+	waitReplayed(t, store, 3)
+
+	// 发给医生5的消息不会推送给患者5
+	otherConn.WriteJSON(ChatMessage{Type: "text", Content: "医生您好", ClientMsgID: "c-1"})
+	if got := readChat(t, otherConn); got.Type != ChatEventSent || got.RoomID != "room_doctor_5_patient_6" {
+		t.Fatalf("Unexpected sent receipt %+v", got)
+	}
+	if got := readChat(t, doctorConn); got.Content != "医生您好" || got.from() != other || got.to() != doctor {
+		t.Fatalf("Unexpected message to doctor %+v", got)
+	}
+
+	// 医生5可以给患者5发消息，两人的房间与各自和他人的房间不同
+	doctorConn.WriteJSON(ChatMessage{Type: "text", Content: "请描述症状", ToID: 5})
+	got := readChat(t, patientConn)
+	if got.Content != "请描述症状" || got.from() != doctor || got.to() != patient || got.RoomID != "room_doctor_5_patient_5" {
+		t.Fatalf("Unexpected message to patient %+v", got)
+	}
+	if rooms := cm.RoomUsers("room_doctor_5_patient_5"); len(rooms) != 2 {
+		t.Errorf("Expected 2 online users in room, got %d", len(rooms))
+	}
+	if !cm.presence.IsUserOnline(doctor.PresenceKey()) || !cm.presence.IsUserOnline(patient.PresenceKey()) {
+		t.Errorf("Expected doctor and patient online separately")
+	}
+}
@@ -1,6 +1,7 @@
 package comment
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"sync"
@@ -10,8 +11,8 @@ import (
 // Kiro修改：添加心跳检测功能
 // HeartbeatManager 心跳管理器
 type HeartbeatManager struct {
-	// Kiro修改：存储用户最后心跳时间
-	userHeartbeats map[string]time.Time
+	// 心跳记录存储，多节点部署时通过Redis共享
+	store PresenceStore
 	// Kiro修改：读写锁保护并发访问
 	mutex sync.RWMutex
 	// Kiro修改：心跳超时时间（默认30秒）
@@ -21,34 +22,49 @@ type HeartbeatManager struct {
 }
 
 // Kiro修改：全局心跳管理器实例
-var heartbeatManager *HeartbeatManager
+var heartbeatManager = NewHeartbeatManager(defaultBroker)
 
-// Kiro修改：初始化心跳管理器
-func init() {
-	heartbeatManager = &HeartbeatManager{
-		userHeartbeats:  make(map[string]time.Time),
+// 创建心跳管理器并启动后台清理协程
+func NewHeartbeatManager(store PresenceStore) *HeartbeatManager {
+	hm := &HeartbeatManager{
+		store:           store,
 		timeout:         30 * time.Second, // 30秒超时
 		cleanupInterval: 10 * time.Second, // 10秒清理一次
 	}
-	// Kiro修改：启动后台清理协程
-	go heartbeatManager.startCleanupRoutine()
+	go hm.startCleanupRoutine()
+	return hm
 }
 
-// Kiro修改：更新用户心跳时间
-func (hm *HeartbeatManager) UpdateHeartbeat(userID string) {
+// 切换心跳记录存储
+func (hm *HeartbeatManager) SetStore(store PresenceStore) {
 	hm.mutex.Lock()
 	defer hm.mutex.Unlock()
-	hm.userHeartbeats[userID] = time.Now()
+	hm.store = store
+}
+
+func (hm *HeartbeatManager) getStore() PresenceStore {
+	hm.mutex.RLock()
+	defer hm.mutex.RUnlock()
+	return hm.store
+}
+
+// Kiro修改：更新用户心跳时间
+func (hm *HeartbeatManager) UpdateHeartbeat(userID string) {
+	if err := hm.getStore().Touch(context.Background(), userID, time.Now()); err != nil {
+		log.Printf("更新用户 %s 心跳失败: %v", userID, err)
+		return
+	}
 	log.Printf("Kiro修改：用户 %s 心跳更新", userID)
 }
 
 // Kiro修改：检查用户是否在线
 func (hm *HeartbeatManager) IsUserOnline(userID string) bool {
-	hm.mutex.RLock()
-	defer hm.mutex.RUnlock()
-	
-	lastHeartbeat, exists := hm.userHeartbeats[userID]
-	if !exists {
+	lastHeartbeat, err := hm.getStore().LastSeen(context.Background(), userID)
+	if err != nil {
+		log.Printf("查询用户 %s 心跳失败: %v", userID, err)
+		return false
+	}
+	if lastHeartbeat.IsZero() {
 		return false
 	}
 	
@@ -58,16 +74,10 @@ func (hm *HeartbeatManager) IsUserOnline(userID string) bool {
 
 // Kiro修改：获取所有在线用户
 func (hm *HeartbeatManager) GetOnlineUsers() []string {
-	hm.mutex.RLock()
-	defer hm.mutex.RUnlock()
-	
-	var onlineUsers []string
-	now := time.Now()
-	
-	for userID, lastHeartbeat := range hm.userHeartbeats {
-		if now.Sub(lastHeartbeat) <= hm.timeout {
-			onlineUsers = append(onlineUsers, userID)
-		}
+	onlineUsers, err := hm.getStore().OnlineSince(context.Background(), time.Now().Add(-hm.timeout))
+	if err != nil {
+		log.Printf("查询在线用户失败: %v", err)
+		return nil
 	}
 	
 	log.Printf("Kiro修改：当前在线用户数量: %d", len(onlineUsers))
@@ -76,9 +86,10 @@ func (hm *HeartbeatManager) GetOnlineUsers() []string {
 
 // Kiro修改：移除用户心跳记录
 func (hm *HeartbeatManager) RemoveUser(userID string) {
-	hm.mutex.Lock()
-	defer hm.mutex.Unlock()
-	delete(hm.userHeartbeats, userID)
+	if err := hm.getStore().Remove(context.Background(), userID); err != nil {
+		log.Printf("移除用户 %s 心跳记录失败: %v", userID, err)
+		return
+	}
 	log.Printf("Kiro修改：用户 %s 心跳记录已移除", userID)
 }
 
@@ -97,21 +108,13 @@ func (hm *HeartbeatManager) startCleanupRoutine() {
 
 // Kiro修改：清理过期的心跳记录
 func (hm *HeartbeatManager) cleanupExpiredHeartbeats() {
-	hm.mutex.Lock()
-	defer hm.mutex.Unlock()
-	
-	now := time.Now()
-	expiredUsers := make([]string, 0)
-	
-	for userID, lastHeartbeat := range hm.userHeartbeats {
-		if now.Sub(lastHeartbeat) > hm.timeout {
-			expiredUsers = append(expiredUsers, userID)
-		}
+	expiredUsers, err := hm.getStore().Expire(context.Background(), time.Now().Add(-hm.timeout))
+	if err != nil {
+		log.Printf("清理过期心跳记录失败: %v", err)
+		return
 	}
 	
-	// Kiro修改：删除过期用户
 	for _, userID := range expiredUsers {
-		delete(hm.userHeartbeats, userID)
 		log.Printf("Kiro修改：用户 %s 心跳超时，已标记为离线", userID)
 	}
 	
@@ -122,16 +125,18 @@ func (hm *HeartbeatManager) cleanupExpiredHeartbeats() {
 
 // Kiro修改：获取用户在线状态详情
 func (hm *HeartbeatManager) GetUserStatus(userID string) map[string]interface{} {
-	hm.mutex.RLock()
-	defer hm.mutex.RUnlock()
-	
 	status := map[string]interface{}{
 		"user_id": userID,
 		"online":  false,
 		"last_heartbeat": nil,
 	}
 	
-	if lastHeartbeat, exists := hm.userHeartbeats[userID]; exists {
+	lastHeartbeat, err := hm.getStore().LastSeen(context.Background(), userID)
+	if err != nil {
+		log.Printf("查询用户 %s 心跳失败: %v", userID, err)
+		return status
+	}
+	if !lastHeartbeat.IsZero() {
 		status["online"] = time.Since(lastHeartbeat) <= hm.timeout
 		status["last_heartbeat"] = lastHeartbeat.Format("2006-01-02 15:04:05")
 	}
@@ -12,9 +12,31 @@ const (
 	App_Key = "token"
 )
 
+// token中的角色，患者、医生和药师的ID各自独立编号，须按角色区分
+const (
+	RolePatient    = "patient"
+	RoleDoctor     = "doctor"
+	RolePharmacist = "pharmacist"
+)
+
 func TokenHandler(id int32) (string, error) {
+	return roleTokenHandler(id, RolePatient)
+}
+
+// 签发医生端token
+func DoctorTokenHandler(id int32) (string, error) {
+	return roleTokenHandler(id, RoleDoctor)
+}
+
+// 签发药师端token
+func PharmacistTokenHandler(id int32) (string, error) {
+	return roleTokenHandler(id, RolePharmacist)
+}
+
+func roleTokenHandler(id int32, role string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user": id,
+		"role": role,
 		"exp":  time.Now().Add(time.Hour * time.Duration(10)).Unix(),
 		"iat":  time.Now().Unix(),
 	})
@@ -22,6 +44,14 @@ func TokenHandler(id int32) (string, error) {
 	return tokenString, err
 }
 
+// token中的角色，未携带角色的旧token视为患者
+func TokenRole(claims jwt.MapClaims) string {
+	if role, ok := claims["role"].(string); ok && role != "" {
+		return role
+	}
+	return RolePatient
+}
+
 func GetToken(tokenString string) (jwt.MapClaims, string) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) { return []byte(App_Key), nil })
 	if token != nil && token.Valid {
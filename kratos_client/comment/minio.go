@@ -7,6 +7,7 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"net/http"
+	"strings"
 
 	"mime"
 	"path/filepath"
@@ -57,3 +58,10 @@ func Upload(ctx context.Context, c *http.Request) (err error, url string) {
 
 	return nil, Url
 }
+
+// 是否为 Upload 返回的文件地址，地址可以带 http(s) 协议头
+func IsUploadedURL(url string) bool {
+	url = strings.TrimPrefix(strings.TrimPrefix(url, "http://"), "https://")
+	name := strings.TrimPrefix(url, fmt.Sprintf("%s/%s/", MinioEndpoint, BucketName))
+	return name != url && name != "" && !strings.Contains(name, "/")
+}
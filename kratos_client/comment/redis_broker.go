@@ -0,0 +1,106 @@
+package comment
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	redisChatChannel = "chat:events"   // 聊天事件广播频道
+	redisPresenceKey = "chat:presence" // 在线状态有序集合，分值为最后心跳的毫秒时间戳
+)
+
+// 基于Redis发布订阅的代理，多个副本订阅同一频道，各自推送给本节点上的连接
+type RedisBroker struct {
+	rdb *redis.Client
+}
+
+func NewRedisBroker(rdb *redis.Client) *RedisBroker {
+	return &RedisBroker{rdb: rdb}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, payload []byte) error {
+	return b.rdb.Publish(ctx, redisChatChannel, payload).Err()
+}
+
+// 订阅确认后返回，断线后由客户端自动重新订阅
+func (b *RedisBroker) Subscribe(ctx context.Context, handler func([]byte)) error {
+	pubsub := b.rdb.Subscribe(ctx, redisChatChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	messages := pubsub.Channel()
+	go func() {
+		defer pubsub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					log.Printf("Redis聊天订阅已关闭")
+					return
+				}
+				handler([]byte(message.Payload))
+			}
+		}
+	}()
+	return nil
+}
+
+func (b *RedisBroker) Touch(ctx context.Context, userID string, at time.Time) error {
+	return b.rdb.ZAdd(ctx, redisPresenceKey, &redis.Z{
+		Score:  float64(at.UnixMilli()),
+		Member: userID,
+	}).Err()
+}
+
+func (b *RedisBroker) LastSeen(ctx context.Context, userID string) (time.Time, error) {
+	score, err := b.rdb.ZScore(ctx, redisPresenceKey, userID).Result()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(score)), nil
+}
+
+func (b *RedisBroker) OnlineSince(ctx context.Context, since time.Time) ([]string, error) {
+	return b.rdb.ZRangeByScore(ctx, redisPresenceKey, &redis.ZRangeBy{
+		Min: strconv.FormatInt(since.UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+}
+
+func (b *RedisBroker) Remove(ctx context.Context, userIDs ...string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		members[i] = userID
+	}
+	return b.rdb.ZRem(ctx, redisPresenceKey, members...).Err()
+}
+
+// 多个节点同时清理时，同一用户可能被多个节点各返回一次
+func (b *RedisBroker) Expire(ctx context.Context, before time.Time) ([]string, error) {
+	expired, err := b.rdb.ZRangeByScore(ctx, redisPresenceKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(before.UnixMilli(), 10),
+	}).Result()
+	if err != nil || len(expired) == 0 {
+		return nil, err
+	}
+	if err := b.Remove(ctx, expired...); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
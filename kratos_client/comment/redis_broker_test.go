@@ -0,0 +1,99 @@
+package comment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/websocket"
+)
+
+// 模拟两个副本共用一个Redis，连接分布在不同节点上
+func TestRedisBrokerAcrossNodes(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := &memoryChatStore{delivered: map[int64]bool{}}
+
+	newNode := func() (*ChatManager, *HeartbeatManager, *httptest.Server) {
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { rdb.Close() })
+		broker := NewRedisBroker(rdb)
+		presence := NewHeartbeatManager(broker)
+		cm := NewChatManager(broker, presence)
+		cm.SetStore(store)
+		t.Cleanup(cm.Close)
+		server := httptest.NewServer(http.HandlerFunc(cm.HandleWebSocket))
+		t.Cleanup(server.Close)
+		return cm, presence, server
+	}
+	nodeA, presenceA, serverA := newNode()
+	nodeB, presenceB, serverB := newNode()
+
+	doctor, patient := ChatPeer{Role: RoleDoctor, ID: 2007}, ChatPeer{Role: RolePatient, ID: 2003}
+	patientConn := dialChat(t, serverA, patient)
+	phone := dialChat(t, serverA, doctor)
+	web := dialChat(t, serverB, doctor)
+	waitOnline(t, nodeA, patient, 1)
+	waitOnline(t, nodeA, doctor, 1)
+	waitOnline(t, nodeB, doctor, 1)
+	waitReplayed(t, store, 3)
+
+	// 患者在A节点发送，医生在两个节点上的设备都能收到
+	patientConn.WriteJSON(ChatMessage{Type: "text", Content: "医生您好", ToID: doctor.ID, ClientMsgID: "c-1"})
+	if got := readChat(t, patientConn); got.Type != ChatEventSent || got.ID != 1 {
+		t.Fatalf("Unexpected sent receipt %+v", got)
+	}
+	for name, conn := range map[string]*websocket.Conn{"web": web, "phone": phone} {
+		if got := readChat(t, conn); got.ID != 1 || got.from() != patient || got.Content != "医生您好" {
+			t.Errorf("Unexpected message on %s %+v", name, got)
+		}
+	}
+
+	// B节点的送达确认回到A节点的发送方
+	web.WriteJSON(ChatMessage{Type: ChatEventAck, IDs: []int64{1}})
+	if got := readChat(t, patientConn); got.Type != ChatEventDelivered || len(got.IDs) != 1 || got.IDs[0] != 1 {
+		t.Fatalf("Unexpected delivered receipt %+v", got)
+	}
+
+	// B节点发出的消息推送到A节点的接收方，并同步到发送方在A节点的设备，不回推发起的连接
+	web.WriteJSON(ChatMessage{Type: "text", Content: "请描述症状", ToID: patient.ID})
+	if got := readChat(t, patientConn); got.Content != "请描述症状" || got.from() != doctor {
+		t.Errorf("Unexpected message to patient %+v", got)
+	}
+	if got := readChat(t, phone); got.Content != "请描述症状" || got.ID != 2 {
+		t.Errorf("Unexpected message synced to phone %+v", got)
+	}
+	if got := readChat(t, web); got.Type != ChatEventSent || got.ID != 2 {
+		t.Errorf("Expected only sent receipt on origin connection, got %+v", got)
+	}
+
+	// 在线状态在节点间共享
+	presenceA.UpdateHeartbeat("3001")
+	if !presenceB.IsUserOnline("3001") {
+		t.Errorf("Expected user online on other node")
+	}
+	if status := presenceB.GetUserStatus("3001"); status["online"] != true {
+		t.Errorf("Unexpected status %v", status)
+	}
+	found := false
+	for _, userID := range presenceB.GetOnlineUsers() {
+		found = found || userID == "3001"
+	}
+	if !found {
+		t.Errorf("Expected 3001 in online users")
+	}
+	presenceB.RemoveUser("3001")
+	if presenceA.IsUserOnline("3001") {
+		t.Errorf("Expected user offline after removal on other node")
+	}
+
+	// 心跳超时后由任一节点清理
+	presenceA.getStore().Touch(context.Background(), "3002", time.Now().Add(-time.Minute))
+	presenceB.cleanupExpiredHeartbeats()
+	if presenceA.IsUserOnline("3002") {
+		t.Errorf("Expected expired heartbeat cleaned up")
+	}
+}
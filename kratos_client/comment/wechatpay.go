@@ -0,0 +1,402 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 微信支付APIv3配置
+type WechatPayConfig struct {
+	AppID             string        // 应用ID
+	MchID             string        // 商户号
+	SerialNo          string        // 商户API证书序列号
+	PrivateKey        string        // 商户API私钥(PEM)
+	APIv3Key          string        // APIv3密钥
+	PlatformPublicKey string        // 微信支付公钥(PEM)
+	PlatformSerial    string        // 微信支付公钥ID，为空时不校验
+	NotifyURL         string        // 支付通知地址
+	RefundNotifyURL   string        // 退款通知地址
+	BaseURL           string        // 接口地址
+	Timeout           time.Duration // 请求超时
+}
+
+// 微信支付交易状态
+const (
+	WechatTradeStateSuccess = "SUCCESS"  // 支付成功
+	WechatTradeStateRefund  = "REFUND"   // 转入退款
+	WechatTradeStateNotPay  = "NOTPAY"   // 未支付
+	WechatTradeStateClosed  = "CLOSED"   // 已关闭
+	WechatTradeStateRevoked = "REVOKED"  // 已撤销
+	WechatTradeStatePayErr  = "PAYERROR" // 支付失败
+)
+
+// 微信支付退款状态
+const (
+	WechatRefundStatusSuccess    = "SUCCESS"    // 退款成功
+	WechatRefundStatusClosed     = "CLOSED"     // 退款关闭
+	WechatRefundStatusProcessing = "PROCESSING" // 退款处理中
+	WechatRefundStatusAbnormal   = "ABNORMAL"   // 退款异常
+)
+
+// 通知签名时间戳允许的偏差
+const wechatNotifyMaxSkew = 5 * time.Minute
+
+// 微信支付订单金额，单位分
+type WechatAmount struct {
+	Total       int64  `json:"total,omitempty"`
+	PayerTotal  int64  `json:"payer_total,omitempty"`
+	Refund      int64  `json:"refund,omitempty"`
+	PayerRefund int64  `json:"payer_refund,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+}
+
+// 微信支付交易
+type WechatTransaction struct {
+	OutTradeNo    string       `json:"out_trade_no"`
+	TransactionID string       `json:"transaction_id"`
+	TradeState    string       `json:"trade_state"`
+	SuccessTime   string       `json:"success_time"`
+	Amount        WechatAmount `json:"amount"`
+}
+
+// 微信支付退款
+type WechatRefund struct {
+	OutTradeNo   string       `json:"out_trade_no"`
+	OutRefundNo  string       `json:"out_refund_no"`
+	RefundID     string       `json:"refund_id"`
+	Status       string       `json:"status"`        // 退款接口应答中的状态
+	RefundStatus string       `json:"refund_status"` // 退款通知中的状态
+	SuccessTime  string       `json:"success_time"`
+	Amount       WechatAmount `json:"amount"`
+}
+
+// 微信支付通知
+type WechatNotify struct {
+	ID           string `json:"id"`
+	EventType    string `json:"event_type"`
+	ResourceType string `json:"resource_type"`
+	Resource     struct {
+		Algorithm      string `json:"algorithm"`
+		Ciphertext     string `json:"ciphertext"`
+		AssociatedData string `json:"associated_data"`
+		Nonce          string `json:"nonce"`
+	} `json:"resource"`
+}
+
+// 微信支付接口错误
+type WechatPayError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *WechatPayError) Error() string {
+	return fmt.Sprintf("微信支付接口错误: status=%d, code=%s, message=%s", e.StatusCode, e.Code, e.Message)
+}
+
+// 微信支付APIv3客户端
+type WechatPayClient struct {
+	config      WechatPayConfig
+	privateKey  *rsa.PrivateKey
+	platformKey *rsa.PublicKey
+	client      *http.Client
+}
+
+// 创建微信支付客户端
+func NewWechatPayClient(config WechatPayConfig) (*WechatPayClient, error) {
+	if config.MchID == "" || config.SerialNo == "" || config.AppID == "" {
+		return nil, fmt.Errorf("微信支付缺少appid、商户号或证书序列号")
+	}
+	if len(config.APIv3Key) != 32 {
+		return nil, fmt.Errorf("微信支付APIv3密钥长度必须为32字节")
+	}
+	privateKey, err := parseRSAPrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("加载商户私钥失败: %v", err)
+	}
+	platformKey, err := parseRSAPublicKey(config.PlatformPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("加载微信支付公钥失败: %v", err)
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.mch.weixin.qq.com"
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &WechatPayClient{
+		config:      config,
+		privateKey:  privateKey,
+		platformKey: platformKey,
+		client:      &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Native下单，返回二维码链接
+func (c *WechatPayClient) NativePay(ctx context.Context, outTradeNo, description string, total int64) (string, error) {
+	body := map[string]interface{}{
+		"appid":        c.config.AppID,
+		"mchid":        c.config.MchID,
+		"description":  truncateRunes(description, 127),
+		"out_trade_no": outTradeNo,
+		"notify_url":   c.config.NotifyURL,
+		"amount":       WechatAmount{Total: total, Currency: "CNY"},
+	}
+	var rsp struct {
+		CodeURL string `json:"code_url"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/v3/pay/transactions/native", body, &rsp); err != nil {
+		return "", err
+	}
+	return rsp.CodeURL, nil
+}
+
+// 按商户订单号查询交易，交易不存在时返回nil
+func (c *WechatPayClient) QueryOrder(ctx context.Context, outTradeNo string) (*WechatTransaction, error) {
+	path := "/v3/pay/transactions/out-trade-no/" + url.PathEscape(outTradeNo) + "?mchid=" + url.QueryEscape(c.config.MchID)
+	var transaction WechatTransaction
+	if err := c.do(ctx, http.MethodGet, path, nil, &transaction); err != nil {
+		if e, ok := err.(*WechatPayError); ok && e.Code == "ORDER_NOT_EXIST" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// 申请退款，outRefundNo作为商户退款单号，同一退款单重复提交不会重复退款
+func (c *WechatPayClient) Refund(ctx context.Context, outTradeNo, outRefundNo, reason string, refund, total int64) (*WechatRefund, error) {
+	body := map[string]interface{}{
+		"out_trade_no":  outTradeNo,
+		"out_refund_no": outRefundNo,
+		"reason":        truncateRunes(reason, 80),
+		"notify_url":    c.config.RefundNotifyURL,
+		"amount":        WechatAmount{Refund: refund, Total: total, Currency: "CNY"},
+	}
+	var rsp WechatRefund
+	if err := c.do(ctx, http.MethodPost, "/v3/refund/domestic/refunds", body, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// 验证通知签名并解密通知内容到v，返回通知事件类型
+func (c *WechatPayClient) ParseNotify(header http.Header, body []byte, v interface{}) (string, error) {
+	if err := c.verify(header, body, true); err != nil {
+		return "", err
+	}
+
+	var notify WechatNotify
+	if err := json.Unmarshal(body, &notify); err != nil {
+		return "", fmt.Errorf("解析通知失败: %v", err)
+	}
+	plaintext, err := DecryptWechatResource(c.config.APIv3Key, notify.Resource.AssociatedData, notify.Resource.Nonce, notify.Resource.Ciphertext)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(plaintext, v); err != nil {
+		return "", fmt.Errorf("解析通知资源失败: %v", err)
+	}
+	return notify.EventType, nil
+}
+
+// 解密通知资源，算法为AEAD_AES_256_GCM
+func DecryptWechatResource(apiV3Key, associatedData, nonce, ciphertext string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("通知密文格式错误: %v", err)
+	}
+	block, err := aes.NewCipher([]byte(apiV3Key))
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("通知nonce长度错误")
+	}
+	plaintext, err := gcm.Open(nil, []byte(nonce), data, []byte(associatedData))
+	if err != nil {
+		return nil, fmt.Errorf("解密通知失败: %v", err)
+	}
+	return plaintext, nil
+}
+
+// 发起请求并校验应答签名
+func (c *WechatPayClient) do(ctx context.Context, method, path string, body interface{}, v interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.config.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	authorization, err := c.authorization(method, path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求微信支付失败: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取微信支付应答失败: %v", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		e := &WechatPayError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(data, e)
+		return e
+	}
+	if err := c.verify(resp.Header, data, false); err != nil {
+		return fmt.Errorf("微信支付应答验签失败: %v", err)
+	}
+	if v != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("解析微信支付应答失败: %v", err)
+		}
+	}
+	return nil
+}
+
+// 生成请求签名头
+func (c *WechatPayClient) authorization(method, path string, body []byte) (string, error) {
+	nonce, err := randomNonce()
+	if err != nil {
+		return "", err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	message := method + "\n" + path + "\n" + timestamp + "\n" + nonce + "\n" + string(body) + "\n"
+
+	digest := sha256.Sum256([]byte(message))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("请求签名失败: %v", err)
+	}
+	return fmt.Sprintf(`WECHATPAY2-SHA256-RSA2048 mchid="%s",nonce_str="%s",signature="%s",timestamp="%s",serial_no="%s"`,
+		c.config.MchID, nonce, base64.StdEncoding.EncodeToString(signature), timestamp, c.config.SerialNo), nil
+}
+
+// 校验应答或通知的签名，通知额外校验时间戳防重放
+func (c *WechatPayClient) verify(header http.Header, body []byte, checkTimestamp bool) error {
+	timestamp := header.Get("Wechatpay-Timestamp")
+	nonce := header.Get("Wechatpay-Nonce")
+	signature := header.Get("Wechatpay-Signature")
+	serial := header.Get("Wechatpay-Serial")
+	if timestamp == "" || nonce == "" || signature == "" {
+		return fmt.Errorf("缺少签名头")
+	}
+	if c.config.PlatformSerial != "" && serial != c.config.PlatformSerial {
+		return fmt.Errorf("微信支付公钥ID不匹配: %s", serial)
+	}
+	if checkTimestamp {
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("签名时间戳格式错误")
+		}
+		if skew := time.Since(time.Unix(ts, 0)); skew > wechatNotifyMaxSkew || skew < -wechatNotifyMaxSkew {
+			return fmt.Errorf("签名时间戳已过期")
+		}
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("签名格式错误")
+	}
+	digest := sha256.Sum256([]byte(timestamp + "\n" + nonce + "\n" + string(body) + "\n"))
+	if err := rsa.VerifyPKCS1v15(c.platformKey, crypto.SHA256, digest[:], sig); err != nil {
+		return fmt.Errorf("签名不匹配")
+	}
+	return nil
+}
+
+func parseRSAPrivateKey(key string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("私钥不是PEM格式")
+	}
+	if pk, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if rsaKey, ok := pk.(*rsa.PrivateKey); ok {
+			return rsaKey, nil
+		}
+		return nil, fmt.Errorf("私钥不是RSA密钥")
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func parseRSAPublicKey(key string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("公钥不是PEM格式")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if rsaKey, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+		return nil, fmt.Errorf("证书公钥不是RSA密钥")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("公钥不是RSA密钥")
+	}
+	return rsaKey, nil
+}
+
+func randomNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -3,4 +3,4 @@ package biz
 import "github.com/google/wire"
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewUserUsecase, NewCityUsecase, NewDoctorsUsecase, NewDrugService, NewEstimateService, NewCartService, NewOrderUsecase, NewPaymentUsecase, NewCouponUsecase, NewPrescriptionUsecase)
+var ProviderSet = wire.NewSet(NewUserUsecase, NewCityUsecase, NewDoctorsUsecase, NewDrugService, NewDrugIndexUsecase, NewHotSearchUsecase, NewSymptomUsecase, NewEstimateService, NewCartService, NewOrderUsecase, NewInventoryUsecase, NewPaymentUsecase, NewRefundUsecase, NewCouponUsecase, NewPrescriptionUsecase, NewPharmacistUsecase, NewLoginThrottle, NewInteractionUsecase, NewIdempotencyUsecase, NewReconcileUsecase, NewChatUsecase, NewConsultationUsecase, NewScheduleUsecase)
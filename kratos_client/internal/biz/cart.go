@@ -43,15 +43,17 @@ type CartRepo interface {
 
 // 购物车服务
 type CartService struct {
-	repo CartRepo
-	log  *log.Helper
+	repo          CartRepo
+	interactionUc *InteractionUsecase
+	log           *log.Helper
 }
 
 // 创建购物车服务
-func NewCartService(repo CartRepo, logger log.Logger) *CartService {
+func NewCartService(repo CartRepo, interactionUc *InteractionUsecase, logger log.Logger) *CartService {
 	return &CartService{
-		repo: repo,
-		log:  log.NewHelper(logger),
+		repo:          repo,
+		interactionUc: interactionUc,
+		log:           log.NewHelper(logger),
 	}
 }
 
@@ -106,6 +108,19 @@ func (cs *CartService) ListCart(ctx context.Context, userID int64) ([]*CartItem,
 	return cs.repo.ListCart(ctx, userID)
 }
 
+// 检查购物车中药品的相互作用和对患者所属人群的禁忌，结账前提示用户
+func (cs *CartService) CheckCart(ctx context.Context, userID int64, populations []string) (*DrugCheckResult, error) {
+	items, err := cs.repo.ListCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	drugIDs := make([]int64, len(items))
+	for i, item := range items {
+		drugIDs[i] = item.DrugID
+	}
+	return cs.interactionUc.Check(ctx, drugIDs, populations)
+}
+
 // 生成购物车Redis键
 func GenerateCartKey(userID int64) string {
 	return "cart:user:" + strconv.FormatInt(userID, 10)
@@ -0,0 +1,425 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 聊天消息类型
+const (
+	ChatMessageTypeText = "text" // 文本
+)
+
+// 聊天参与方角色，患者、医生和药师的ID各自独立编号，须按角色和ID共同区分
+const (
+	ChatRolePatient    = "patient"
+	ChatRoleDoctor     = "doctor"
+	ChatRolePharmacist = "pharmacist"
+)
+
+// 聊天消息投递状态
+const (
+	ChatMessageStatusSent      = "sent"      // 已发送，接收方尚未确认收到
+	ChatMessageStatusDelivered = "delivered" // 接收方任一设备已确认收到
+	ChatMessageStatusRead      = "read"      // 接收方已读
+)
+
+const (
+	chatMessageMaxLength   = 1000 // 消息内容最大字符数
+	chatLastMessageLength  = 50   // 房间最后一条消息摘要的最大字符数
+	chatHistoryDefaultSize = 20
+	chatHistoryMaxSize     = 100
+	chatPendingMaxSize     = 200 // 重连时补发的未送达消息上限，更早的消息通过历史记录接口获取
+)
+
+// 聊天参与方，同一ID在不同角色下是不同的用户
+type ChatUser struct {
+	Role string `json:"role"`
+	ID   int32  `json:"id"`
+}
+
+// 角色和ID是否有效
+func (u ChatUser) Valid() bool {
+	switch u.Role {
+	case ChatRolePatient, ChatRoleDoctor, ChatRolePharmacist:
+		return u.ID > 0
+	}
+	return false
+}
+
+// 先按角色再按ID排序，决定房间中的用户1和用户2
+func (u ChatUser) less(other ChatUser) bool {
+	if u.Role != other.Role {
+		return u.Role < other.Role
+	}
+	return u.ID < other.ID
+}
+
+// 未指定对方角色时的默认值：患者默认与医生聊天，医生和药师默认与患者聊天
+func DefaultChatPeerRole(role string) string {
+	if role == ChatRolePatient {
+		return ChatRoleDoctor
+	}
+	return ChatRolePatient
+}
+
+// 聊天房间模型，两个用户之间唯一
+type ChatRoom struct {
+	ID              int32     `json:"id"`
+	RoomID          string    `json:"room_id"` // 房间号，格式 room_{角色}_{ID}_{角色}_{ID}，按角色、ID排序
+	RoomName        string    `json:"room_name"`
+	User1Role       string    `json:"user1_role"`
+	User1ID         int32     `json:"user1_id"` // 排序在前的用户
+	User2Role       string    `json:"user2_role"`
+	User2ID         int32     `json:"user2_id"` // 排序在后的用户
+	User1Unread     int32     `json:"user1_unread"`
+	User2Unread     int32     `json:"user2_unread"`
+	LastMessage     string    `json:"last_message"`
+	LastMessageTime time.Time `json:"last_message_time"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (r *ChatRoom) user1() ChatUser {
+	return ChatUser{Role: r.User1Role, ID: r.User1ID}
+}
+
+func (r *ChatRoom) user2() ChatUser {
+	return ChatUser{Role: r.User2Role, ID: r.User2ID}
+}
+
+// 对方用户
+func (r *ChatRoom) Target(user ChatUser) ChatUser {
+	if r.user1() == user {
+		return r.user2()
+	}
+	return r.user1()
+}
+
+// 用户是否为房间的一方
+func (r *ChatRoom) HasUser(user ChatUser) bool {
+	return r.user1() == user || r.user2() == user
+}
+
+// 用户的未读消息数
+func (r *ChatRoom) UnreadCount(user ChatUser) int32 {
+	if r.user1() == user {
+		return r.User1Unread
+	}
+	return r.User2Unread
+}
+
+// 聊天消息模型
+type ChatMessage struct {
+	ID          int64      `json:"id"`
+	RoomID      int32      `json:"room_id"` // 所属房间的主键
+	FromRole    string     `json:"from_role"`
+	FromID      int32      `json:"from_id"`
+	ToRole      string     `json:"to_role"`
+	ToID        int32      `json:"to_id"`
+	Content     string     `json:"content"`
+	MessageType string     `json:"message_type"`
+	Payload     string     `json:"payload"` // 非文本消息的结构化数据，JSON格式
+	DeliveredAt *time.Time `json:"delivered_at"`
+	ReadAt      *time.Time `json:"read_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// 发送方
+func (m *ChatMessage) From() ChatUser {
+	return ChatUser{Role: m.FromRole, ID: m.FromID}
+}
+
+// 接收方
+func (m *ChatMessage) To() ChatUser {
+	return ChatUser{Role: m.ToRole, ID: m.ToID}
+}
+
+// 消息投递状态
+func (m *ChatMessage) Status() string {
+	switch {
+	case m.ReadAt != nil:
+		return ChatMessageStatusRead
+	case m.DeliveredAt != nil:
+		return ChatMessageStatusDelivered
+	default:
+		return ChatMessageStatusSent
+	}
+}
+
+// 聊天历史分页结果
+type ChatHistory struct {
+	Messages   []*ChatMessage // 按发送时间正序
+	Total      int64          // 房间消息总数
+	NextCursor int64          // 下一页游标
+	HasMore    bool           // 是否还有更早的消息
+}
+
+// 聊天仓储接口
+type ChatRepo interface {
+	// 获取两个用户之间的房间，不存在时创建
+	GetOrCreateRoom(ctx context.Context, room *ChatRoom) (*ChatRoom, error)
+	// 根据房间号查询房间
+	GetRoomByRoomID(ctx context.Context, roomID string) (*ChatRoom, error)
+	// 查询用户参与的房间，按最后消息时间倒序
+	ListUserRooms(ctx context.Context, user ChatUser) ([]*ChatRoom, error)
+	// 保存消息，同时更新房间最后一条消息并累加接收方未读数
+	SaveMessage(ctx context.Context, room *ChatRoom, message *ChatMessage) error
+	// 按游标倒序查询房间消息，cursor 为 0 时从最新一条开始，offset 仅在 cursor 为 0 时生效
+	ListMessages(ctx context.Context, roomID int32, cursor int64, offset, limit int) ([]*ChatMessage, error)
+	// 统计房间消息数
+	CountMessages(ctx context.Context, roomID int32) (int64, error)
+	// 查询发给用户且尚未送达的消息，按发送顺序
+	ListUndeliveredMessages(ctx context.Context, to ChatUser, limit int) ([]*ChatMessage, error)
+	// 将发给用户的消息标记为已送达，返回本次新标记的消息
+	MarkMessagesDelivered(ctx context.Context, to ChatUser, ids []int64, deliveredAt time.Time) ([]*ChatMessage, error)
+	// 将房间内发给用户且ID不大于 upToID 的消息标记为已读，清零用户的未读数，返回已读到的最大消息ID
+	MarkMessagesRead(ctx context.Context, roomID int32, user ChatUser, upToID int64, readAt time.Time) (int64, error)
+}
+
+// 聊天用例
+type ChatUsecase struct {
+	repo             ChatRepo
+	media            ChatMediaChecker
+	prescriptionRepo PrescriptionRepo
+	orderRepo        OrderRepo
+	log              *log.Helper
+}
+
+// 创建聊天用例
+func NewChatUsecase(repo ChatRepo, media ChatMediaChecker, prescriptionRepo PrescriptionRepo, orderRepo OrderRepo, logger log.Logger) *ChatUsecase {
+	return &ChatUsecase{
+		repo:             repo,
+		media:            media,
+		prescriptionRepo: prescriptionRepo,
+		orderRepo:        orderRepo,
+		log:              log.NewHelper(logger),
+	}
+}
+
+// 生成房间号，同一对用户始终得到相同的房间号
+func ChatRoomID(user1, user2 ChatUser) string {
+	if user2.less(user1) {
+		user1, user2 = user2, user1
+	}
+	return fmt.Sprintf("room_%s_%d_%s_%d", user1.Role, user1.ID, user2.Role, user2.ID)
+}
+
+// 发送消息：按类型校验后落库，并更新房间摘要和接收方未读数
+func (uc *ChatUsecase) SendMessage(ctx context.Context, req *SendChatMessageRequest) (*ChatMessage, *ChatRoom, error) {
+	if !req.From.Valid() || !req.To.Valid() {
+		return nil, nil, fmt.Errorf("发送者和接收者不能为空")
+	}
+	if req.From == req.To {
+		return nil, nil, fmt.Errorf("不能给自己发送消息")
+	}
+	if req.MessageType == "" {
+		req.MessageType = ChatMessageTypeText
+	}
+
+	content, payload := req.Content, ""
+	if req.MessageType == ChatMessageTypeText {
+		if content == "" {
+			return nil, nil, fmt.Errorf("消息内容不能为空")
+		}
+		if utf8.RuneCountInString(content) > chatMessageMaxLength {
+			return nil, nil, fmt.Errorf("消息内容不能超过%d个字符", chatMessageMaxLength)
+		}
+	} else {
+		var err error
+		if content, payload, err = uc.buildPayload(ctx, req); err != nil {
+			return nil, nil, err
+		}
+	}
+	return uc.saveMessage(ctx, req.From, req.To, req.MessageType, content, payload)
+}
+
+// 发送系统通知，如问诊开始、结束，from 为触发通知的一方
+func (uc *ChatUsecase) SendSystemNotice(ctx context.Context, from, to ChatUser, event, text string) (*ChatMessage, *ChatRoom, error) {
+	if !from.Valid() || !to.Valid() || from == to {
+		return nil, nil, fmt.Errorf("通知双方无效")
+	}
+	if event == "" || text == "" {
+		return nil, nil, fmt.Errorf("通知事件和内容不能为空")
+	}
+	if utf8.RuneCountInString(text) > chatSystemTextLength {
+		return nil, nil, fmt.Errorf("通知内容不能超过%d个字符", chatSystemTextLength)
+	}
+	content, payload, err := encodeChatPayload(text, ChatSystemPayload{Event: event, Text: text})
+	if err != nil {
+		return nil, nil, err
+	}
+	return uc.saveMessage(ctx, from, to, ChatMessageTypeSystem, content, payload)
+}
+
+// 落库消息
+func (uc *ChatUsecase) saveMessage(ctx context.Context, from, to ChatUser, messageType, content, payload string) (*ChatMessage, *ChatRoom, error) {
+	room, err := uc.getOrCreateRoom(ctx, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	message := &ChatMessage{
+		RoomID:      room.ID,
+		FromRole:    from.Role,
+		FromID:      from.ID,
+		ToRole:      to.Role,
+		ToID:        to.ID,
+		Content:     content,
+		MessageType: messageType,
+		Payload:     payload,
+		CreatedAt:   time.Now(),
+	}
+	room.LastMessage = chatMessageDigest(content)
+	room.LastMessageTime = message.CreatedAt
+	if err := uc.repo.SaveMessage(ctx, room, message); err != nil {
+		return nil, nil, fmt.Errorf("保存聊天消息失败: %v", err)
+	}
+	return message, room, nil
+}
+
+// 按游标分页查询两个用户之间的聊天记录
+func (uc *ChatUsecase) GetHistory(ctx context.Context, user, target ChatUser, cursor int64, page, pageSize int32) (*ChatHistory, *ChatRoom, error) {
+	if !user.Valid() || !target.Valid() {
+		return nil, nil, fmt.Errorf("用户ID不能为空")
+	}
+	if pageSize <= 0 {
+		pageSize = chatHistoryDefaultSize
+	}
+	if pageSize > chatHistoryMaxSize {
+		pageSize = chatHistoryMaxSize
+	}
+
+	history := &ChatHistory{Messages: []*ChatMessage{}}
+	room, err := uc.repo.GetRoomByRoomID(ctx, ChatRoomID(user, target))
+	if err != nil {
+		return nil, nil, fmt.Errorf("查询聊天房间失败: %v", err)
+	}
+	if room == nil {
+		return history, nil, nil
+	}
+	if !room.HasUser(user) {
+		return nil, nil, fmt.Errorf("无权查看该会话")
+	}
+
+	offset := 0
+	if cursor == 0 && page > 1 {
+		offset = int((page - 1) * pageSize)
+	}
+	// 多取一条判断是否还有更早的消息
+	messages, err := uc.repo.ListMessages(ctx, room.ID, cursor, offset, int(pageSize)+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("查询聊天记录失败: %v", err)
+	}
+	if len(messages) > int(pageSize) {
+		history.HasMore = true
+		messages = messages[:pageSize]
+	}
+	if len(messages) > 0 {
+		history.NextCursor = messages[len(messages)-1].ID
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	history.Messages = messages
+
+	if history.Total, err = uc.repo.CountMessages(ctx, room.ID); err != nil {
+		return nil, nil, fmt.Errorf("统计聊天记录失败: %v", err)
+	}
+	return history, room, nil
+}
+
+// 查询用户的聊天房间
+func (uc *ChatUsecase) ListRooms(ctx context.Context, user ChatUser) ([]*ChatRoom, error) {
+	if !user.Valid() {
+		return nil, fmt.Errorf("用户ID不能为空")
+	}
+	rooms, err := uc.repo.ListUserRooms(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("查询聊天房间失败: %v", err)
+	}
+	return rooms, nil
+}
+
+// 将用户与对方的会话标记为已读，upToID 为 0 时标记全部消息，返回已读到的最大消息ID
+func (uc *ChatUsecase) MarkRead(ctx context.Context, user, target ChatUser, upToID int64) (int64, error) {
+	if !user.Valid() || !target.Valid() {
+		return 0, fmt.Errorf("用户ID不能为空")
+	}
+	room, err := uc.repo.GetRoomByRoomID(ctx, ChatRoomID(user, target))
+	if err != nil {
+		return 0, fmt.Errorf("查询聊天房间失败: %v", err)
+	}
+	if room == nil {
+		return 0, nil
+	}
+	if !room.HasUser(user) {
+		return 0, fmt.Errorf("无权操作该会话")
+	}
+	readID, err := uc.repo.MarkMessagesRead(ctx, room.ID, user, upToID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("标记已读失败: %v", err)
+	}
+	return readID, nil
+}
+
+// 查询发给用户且尚未送达的消息，用于重连后补发
+func (uc *ChatUsecase) PendingMessages(ctx context.Context, user ChatUser) ([]*ChatMessage, error) {
+	messages, err := uc.repo.ListUndeliveredMessages(ctx, user, chatPendingMaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询未送达消息失败: %v", err)
+	}
+	return messages, nil
+}
+
+// 接收方确认收到消息，只能确认发给自己的消息，重复确认的消息不再返回
+func (uc *ChatUsecase) AckDelivered(ctx context.Context, user ChatUser, ids []int64) ([]*ChatMessage, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	messages, err := uc.repo.MarkMessagesDelivered(ctx, user, ids, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("确认消息送达失败: %v", err)
+	}
+	return messages, nil
+}
+
+// 获取或创建两个用户之间的房间
+func (uc *ChatUsecase) getOrCreateRoom(ctx context.Context, user1, user2 ChatUser) (*ChatRoom, error) {
+	roomID := ChatRoomID(user1, user2)
+	room, err := uc.repo.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("查询聊天房间失败: %v", err)
+	}
+	if room != nil {
+		return room, nil
+	}
+
+	if user2.less(user1) {
+		user1, user2 = user2, user1
+	}
+	room, err = uc.repo.GetOrCreateRoom(ctx, &ChatRoom{
+		RoomID:    roomID,
+		RoomName:  roomID,
+		User1Role: user1.Role,
+		User1ID:   user1.ID,
+		User2Role: user2.Role,
+		User2ID:   user2.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建聊天房间失败: %v", err)
+	}
+	return room, nil
+}
+
+// 截取消息摘要，房间表只保存最后一条消息的前若干字符
+func chatMessageDigest(content string) string {
+	if utf8.RuneCountInString(content) <= chatLastMessageLength {
+		return content
+	}
+	return string([]rune(content)[:chatLastMessageLength])
+}
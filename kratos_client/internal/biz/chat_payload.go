@@ -0,0 +1,233 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// 富消息类型，消息内容保存摘要，结构化数据以JSON保存在 Payload 中
+const (
+	ChatMessageTypeImage        = "image"        // 图片
+	ChatMessageTypeVoice        = "voice"        // 语音
+	ChatMessageTypePrescription = "prescription" // 处方卡片
+	ChatMessageTypeOrder        = "order"        // 订单卡片
+	ChatMessageTypeSystem       = "system"       // 系统通知，只能由服务端发送
+)
+
+// 系统通知事件
+const (
+	ChatSystemEventConsultationStarted = "consultation_started" // 问诊开始
+	ChatSystemEventConsultationEnded   = "consultation_ended"   // 问诊结束
+)
+
+const (
+	chatVoiceMaxDuration = 60 // 语音最长秒数
+	chatSystemTextLength = 200
+)
+
+var (
+	chatImageExts = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true}
+	chatVoiceExts = map[string]bool{".mp3": true, ".amr": true, ".m4a": true, ".aac": true, ".wav": true, ".ogg": true}
+)
+
+// 图片消息
+type ChatImagePayload struct {
+	URL    string `json:"url"`
+	Width  int32  `json:"width,omitempty"`
+	Height int32  `json:"height,omitempty"`
+	Size   int64  `json:"size,omitempty"` // 字节数
+}
+
+// 语音消息
+type ChatVoicePayload struct {
+	URL      string `json:"url"`
+	Duration int32  `json:"duration"` // 秒
+}
+
+// 处方卡片，发送时按处方生成快照
+type ChatPrescriptionPayload struct {
+	PrescriptionID   uint64 `json:"prescription_id"`
+	PrescriptionNo   string `json:"prescription_no"`
+	PrescriptionType string `json:"prescription_type"`
+	Status           string `json:"status"`
+	TotalAmount      string `json:"total_amount"`
+	MedicineCount    int32  `json:"medicine_count"`
+	PrescriptionDate string `json:"prescription_date"`
+}
+
+// 订单卡片，发送时按订单生成快照
+type ChatOrderPayload struct {
+	OrderNo     string `json:"order_no"`
+	Status      string `json:"status"`
+	TotalAmount string `json:"total_amount"`
+	ItemCount   int32  `json:"item_count"`
+	Title       string `json:"title"` // 首个药品名称
+}
+
+// 系统通知
+type ChatSystemPayload struct {
+	Event string `json:"event"`
+	Text  string `json:"text"`
+}
+
+// 聊天图片、语音的存储校验，只接受经上传接口保存的文件
+type ChatMediaChecker interface {
+	IsUploaded(url string) bool
+}
+
+// 发送消息请求，非文本消息的结构化数据放在 Payload 中
+type SendChatMessageRequest struct {
+	From        ChatUser        `json:"from"`
+	To          ChatUser        `json:"to"`
+	MessageType string          `json:"message_type"`
+	Content     string          `json:"content"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// 解析消息的结构化数据
+func (m *ChatMessage) DecodePayload(v interface{}) error {
+	if m.Payload == "" {
+		return fmt.Errorf("消息没有结构化数据")
+	}
+	return json.Unmarshal([]byte(m.Payload), v)
+}
+
+// 按消息类型校验结构化数据，返回消息摘要和规范化后的JSON
+func (uc *ChatUsecase) buildPayload(ctx context.Context, req *SendChatMessageRequest) (string, string, error) {
+	switch req.MessageType {
+	case ChatMessageTypeImage:
+		var payload ChatImagePayload
+		if err := decodeChatPayload(req.Payload, &payload); err != nil {
+			return "", "", err
+		}
+		if err := uc.checkMedia(payload.URL, chatImageExts); err != nil {
+			return "", "", err
+		}
+		if payload.Width < 0 || payload.Height < 0 || payload.Size < 0 {
+			return "", "", fmt.Errorf("图片尺寸无效")
+		}
+		return encodeChatPayload("[图片]", payload)
+
+	case ChatMessageTypeVoice:
+		var payload ChatVoicePayload
+		if err := decodeChatPayload(req.Payload, &payload); err != nil {
+			return "", "", err
+		}
+		if err := uc.checkMedia(payload.URL, chatVoiceExts); err != nil {
+			return "", "", err
+		}
+		if payload.Duration <= 0 || payload.Duration > chatVoiceMaxDuration {
+			return "", "", fmt.Errorf("语音时长须在1到%d秒之间", chatVoiceMaxDuration)
+		}
+		return encodeChatPayload(fmt.Sprintf("[语音] %d\"", payload.Duration), payload)
+
+	case ChatMessageTypePrescription:
+		var payload ChatPrescriptionPayload
+		if err := decodeChatPayload(req.Payload, &payload); err != nil {
+			return "", "", err
+		}
+		if payload.PrescriptionID == 0 {
+			return "", "", fmt.Errorf("处方ID不能为空")
+		}
+		prescription, err := uc.prescriptionRepo.GetPrescriptionByID(ctx, payload.PrescriptionID)
+		if err != nil {
+			return "", "", fmt.Errorf("查询处方失败: %v", err)
+		}
+		if prescription == nil {
+			return "", "", fmt.Errorf("处方不存在")
+		}
+		// 处方只能在开方医生和对应患者之间发送
+		doctor := ChatUser{Role: ChatRoleDoctor, ID: int32(prescription.DoctorID)}
+		patient := ChatUser{Role: ChatRolePatient, ID: int32(prescription.PatientID)}
+		if !(req.From == doctor && req.To == patient) && !(req.From == patient && req.To == doctor) {
+			return "", "", fmt.Errorf("处方不属于当前会话")
+		}
+		payload = ChatPrescriptionPayload{
+			PrescriptionID:   prescription.ID,
+			PrescriptionNo:   prescription.PrescriptionNo,
+			PrescriptionType: prescription.PrescriptionType,
+			Status:           prescription.Status,
+			TotalAmount:      prescription.TotalAmount.StringFixed(2),
+			MedicineCount:    prescription.MedicineCount,
+			PrescriptionDate: prescription.PrescriptionDate.Format(time.DateOnly),
+		}
+		return encodeChatPayload("[处方] "+prescription.PrescriptionNo, payload)
+
+	case ChatMessageTypeOrder:
+		var payload ChatOrderPayload
+		if err := decodeChatPayload(req.Payload, &payload); err != nil {
+			return "", "", err
+		}
+		if payload.OrderNo == "" {
+			return "", "", fmt.Errorf("订单号不能为空")
+		}
+		order, err := uc.orderRepo.GetOrderByOrderNo(ctx, payload.OrderNo)
+		if err != nil {
+			return "", "", fmt.Errorf("查询订单失败: %v", err)
+		}
+		if order == nil {
+			return "", "", fmt.Errorf("订单不存在")
+		}
+		// 订单须属于会话中的患者一方
+		owner := ChatUser{Role: ChatRolePatient, ID: int32(order.UserID)}
+		if req.From != owner && req.To != owner {
+			return "", "", fmt.Errorf("订单不属于当前会话")
+		}
+		items, err := uc.orderRepo.GetOrderItems(ctx, int64(order.ID))
+		if err != nil {
+			return "", "", fmt.Errorf("查询订单明细失败: %v", err)
+		}
+		payload = ChatOrderPayload{
+			OrderNo:     order.OrderNo,
+			Status:      order.Status,
+			TotalAmount: order.TotalAmount.StringFixed(2),
+			ItemCount:   int32(len(items)),
+		}
+		if len(items) > 0 {
+			payload.Title = items[0].DrugName
+		}
+		return encodeChatPayload("[订单] "+order.OrderNo, payload)
+
+	case ChatMessageTypeSystem:
+		return "", "", fmt.Errorf("系统消息不能由用户发送")
+
+	default:
+		return "", "", fmt.Errorf("不支持的消息类型: %s", req.MessageType)
+	}
+}
+
+// 校验媒体文件地址和扩展名
+func (uc *ChatUsecase) checkMedia(url string, exts map[string]bool) error {
+	if url == "" {
+		return fmt.Errorf("文件地址不能为空")
+	}
+	if !exts[strings.ToLower(path.Ext(url))] {
+		return fmt.Errorf("不支持的文件格式")
+	}
+	if uc.media != nil && !uc.media.IsUploaded(url) {
+		return fmt.Errorf("文件须通过上传接口上传")
+	}
+	return nil
+}
+
+func decodeChatPayload(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 {
+		return fmt.Errorf("消息数据不能为空")
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("消息数据格式错误: %v", err)
+	}
+	return nil
+}
+
+func encodeChatPayload(content string, payload interface{}) (string, string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", "", fmt.Errorf("序列化消息数据失败: %v", err)
+	}
+	return content, string(data), nil
+}
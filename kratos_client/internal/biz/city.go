@@ -38,6 +38,7 @@ func (m *MtAddress) TableName() string {
 type CityRepo interface {
 	Find(ctx context.Context, m *MtCity) (*[]MtCity, error)
 	LikeFind(ctx context.Context, m *MtCity) (*[]MtCity, error)
+	GetCity(ctx context.Context, code int32) (*MtCity, error)
 	Create(ctx context.Context, req *MtAddress) (*MtAddress, error)
 	GetAddressList(ctx context.Context, userId int32) (*[]MtAddress, error)
 	UpdateAddress(ctx context.Context, req *MtAddress) (*MtAddress, error)
@@ -64,6 +65,12 @@ func (m *CityService) LikeFind(ctx context.Context, req *MtCity) (*[]MtCity, err
 	return m.repo.LikeFind(ctx, req)
 }
 
+// 按行政区划代码查询城市，不存在时返回nil
+func (m *CityService) GetCity(ctx context.Context, code int32) (*MtCity, error) {
+	m.log.WithContext(ctx).Infof("GetCity code: %d", code)
+	return m.repo.GetCity(ctx, code)
+}
+
 func (m *CityService) Create(ctx context.Context, req *MtAddress) (*MtAddress, error) {
 	m.log.WithContext(ctx).Infof("MtAddress %+v", req)
 	
@@ -0,0 +1,598 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+)
+
+// 问诊状态
+// 待支付 -> 待接诊(支付成功) -> 问诊中(医生接诊) -> 已结束(医生结束或到时自动结束)
+// 待接诊超时或患者取消时整单退款，待支付时患者可取消
+const (
+	ConsultationStatusPendingPayment = "pending_payment" // 待支付
+	ConsultationStatusWaiting        = "waiting"         // 已支付，待医生接诊
+	ConsultationStatusInProgress     = "in_progress"     // 问诊中
+	ConsultationStatusClosed         = "closed"          // 已结束
+	ConsultationStatusRefunded       = "refunded"        // 已退款，退款单由退款任务提交支付渠道
+	ConsultationStatusCancelled      = "cancelled"       // 已取消
+)
+
+// 问诊查询角色
+const (
+	ConsultationRolePatient = "patient"
+	ConsultationRoleDoctor  = "doctor"
+)
+
+const (
+	consultationDescriptionLength = 500
+	consultationDiagnosisLength   = 1000
+	consultationRatingLength      = 255
+	doctorStatusEnabled           = "1"
+)
+
+// 问诊单
+type Consultation struct {
+	ID             int64           `json:"id"`
+	ConsultationNo string          `json:"consultation_no"`  // 问诊单号，即支付单的业务ID
+	PatientID      int32           `json:"patient_id"`       // 患者用户ID
+	DoctorID       int32           `json:"doctor_id"`        // 医生ID
+	DoctorName     string          `json:"doctor_name"`      // 医生姓名
+	Fee            decimal.Decimal `json:"fee"`              // 问诊费
+	Description    string          `json:"description"`      // 病情描述
+	Status         string          `json:"status"`           // 问诊状态
+	PaymentOrderID string          `json:"payment_order_id"` // 支付单号
+	RefundID       string          `json:"refund_id"`        // 退款单号
+	Diagnosis      string          `json:"diagnosis"`        // 诊断小结
+	Advice         string          `json:"advice"`           // 医嘱
+	CloseReason    string          `json:"close_reason"`     // 结束、取消或退款原因
+	PaidAt         time.Time       `json:"paid_at"`          // 支付时间
+	StartedAt      time.Time       `json:"started_at"`       // 接诊时间
+	EndsAt         time.Time       `json:"ends_at"`          // 会话截止时间
+	ClosedAt       time.Time       `json:"closed_at"`        // 结束时间
+	Rating         int32           `json:"rating"`           // 患者评分1-5，0表示未评价
+	RatingComment  string          `json:"rating_comment"`   // 患者评价
+	RatedAt        time.Time       `json:"rated_at"`         // 评价时间
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// 问诊状态变更，零值字段不更新
+type ConsultationUpdate struct {
+	Status      string
+	RefundID    string
+	Diagnosis   string
+	Advice      string
+	CloseReason string
+	PaidAt      time.Time
+	StartedAt   time.Time
+	EndsAt      time.Time
+	ClosedAt    time.Time
+}
+
+// 问诊列表查询条件，PatientID 和 DoctorID 至少一个不为0
+type ConsultationFilter struct {
+	PatientID int32
+	DoctorID  int32
+	Status    string
+	Page      int32
+	PageSize  int32
+}
+
+// 发起问诊请求
+type CreateConsultationRequest struct {
+	PatientID      int32  `json:"patient_id"`
+	DoctorID       int32  `json:"doctor_id"`
+	Description    string `json:"description"`
+	PayType        string `json:"pay_type"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// 问诊仓储接口
+type ConsultationRepo interface {
+	CreateConsultation(ctx context.Context, consultation *Consultation) error
+	GetConsultation(ctx context.Context, consultationNo string) (*Consultation, error)
+	ListConsultations(ctx context.Context, filter *ConsultationFilter) ([]*Consultation, int64, error)
+	// 患者与医生之间待接诊或问诊中的问诊
+	GetActiveConsultation(ctx context.Context, patientID, doctorID int32) (*Consultation, error)
+	// 仅当问诊当前状态为fromStatus时才更新，否则返回ErrConsultationStatusConflict
+	UpdateConsultationStatus(ctx context.Context, consultationNo, fromStatus string, update *ConsultationUpdate) error
+	// 支付时间早于paidBefore仍未接诊的问诊，按支付时间正序
+	ListUnansweredConsultations(ctx context.Context, paidBefore time.Time, limit int) ([]*Consultation, error)
+	// 会话截止时间早于endsBefore仍在问诊中的问诊，按截止时间正序
+	ListOverdueConsultations(ctx context.Context, endsBefore time.Time, limit int) ([]*Consultation, error)
+	// 仅当问诊已结束且未评价时写入评分，并在同一事务内累计到医生评分；否则返回ErrConsultationStatusConflict
+	RateConsultation(ctx context.Context, consultation *Consultation, rating int32, comment string, ratedAt time.Time) error
+}
+
+// 把服务端生成的聊天消息推送给双方在线的连接
+type ChatPusher interface {
+	PushChatMessage(message *ChatMessage)
+}
+
+// 问诊用例
+type ConsultationUsecase struct {
+	repo          ConsultationRepo
+	doctorsRepo   DoctorsRepo
+	paymentUc     *PaymentUsecase
+	refundUc      *RefundUsecase
+	chatUc        *ChatUsecase
+	pusher        ChatPusher
+	idempotencyUc *IdempotencyUsecase
+	log           *log.Helper
+}
+
+// 创建问诊用例，并注册为问诊支付单的支付成功处理
+func NewConsultationUsecase(
+	repo ConsultationRepo,
+	doctorsRepo DoctorsRepo,
+	paymentUc *PaymentUsecase,
+	refundUc *RefundUsecase,
+	chatUc *ChatUsecase,
+	pusher ChatPusher,
+	idempotencyUc *IdempotencyUsecase,
+	logger log.Logger,
+) *ConsultationUsecase {
+	uc := &ConsultationUsecase{
+		repo:          repo,
+		doctorsRepo:   doctorsRepo,
+		paymentUc:     paymentUc,
+		refundUc:      refundUc,
+		chatUc:        chatUc,
+		pusher:        pusher,
+		idempotencyUc: idempotencyUc,
+		log:           log.NewHelper(logger),
+	}
+	paymentUc.RegisterPaidHandler(OrderTypeConsultation, uc)
+	return uc
+}
+
+// 发起问诊：按医生的问诊费创建支付单，支付成功后进入待接诊
+// 携带幂等键的重复请求返回首次创建的问诊单
+func (uc *ConsultationUsecase) CreateConsultation(ctx context.Context, req *CreateConsultationRequest) (*Consultation, *PaymentOrder, error) {
+	if req.DoctorID <= 0 {
+		return nil, nil, fmt.Errorf("请选择医生")
+	}
+	if req.PatientID == req.DoctorID {
+		return nil, nil, fmt.Errorf("不能向自己发起问诊")
+	}
+	if req.Description == "" {
+		return nil, nil, fmt.Errorf("请填写病情描述")
+	}
+	if utf8.RuneCountInString(req.Description) > consultationDescriptionLength {
+		return nil, nil, fmt.Errorf("病情描述不能超过%d个字符", consultationDescriptionLength)
+	}
+
+	fingerprint := RequestFingerprint([]interface{}{req.DoctorID, req.Description, req.PayType})
+	consultationNo, replayed, err := uc.idempotencyUc.Execute(ctx, IdempotencyScopeCreateConsultation, int64(req.PatientID), req.IdempotencyKey, fingerprint,
+		func(ctx context.Context) (string, error) {
+			consultation, err := uc.createConsultation(ctx, req)
+			if err != nil {
+				return "", err
+			}
+			return consultation.ConsultationNo, nil
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	if replayed {
+		uc.log.Infof("重复的发起问诊请求，返回已有问诊: consultationNo=%s", consultationNo)
+	}
+
+	consultation, err := uc.getConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment, err := uc.paymentUc.GetPaymentOrder(ctx, consultation.PaymentOrderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("查询支付单失败: %v", err)
+	}
+	return consultation, payment, nil
+}
+
+func (uc *ConsultationUsecase) createConsultation(ctx context.Context, req *CreateConsultationRequest) (*Consultation, error) {
+	doctor, err := uc.doctorsRepo.FindByID(ctx, req.DoctorID)
+	if err != nil || doctor == nil {
+		return nil, fmt.Errorf("医生不存在: %d", req.DoctorID)
+	}
+	if doctor.Status != doctorStatusEnabled {
+		return nil, fmt.Errorf("医生暂不接诊")
+	}
+	if !doctor.ConsultationFee.IsPositive() {
+		return nil, fmt.Errorf("医生未开通在线问诊")
+	}
+
+	active, err := uc.repo.GetActiveConsultation(ctx, req.PatientID, req.DoctorID)
+	if err != nil {
+		return nil, fmt.Errorf("查询进行中的问诊失败: %v", err)
+	}
+	if active != nil {
+		return nil, fmt.Errorf("与该医生已有进行中的问诊: %s", active.ConsultationNo)
+	}
+
+	now := time.Now()
+	consultationNo := fmt.Sprintf("CONSULT_%d_%d", req.PatientID, now.UnixNano())
+	fee := doctor.ConsultationFee.StringFixed(2)
+	payment, err := uc.paymentUc.CreatePaymentOrder(ctx, req.PatientID, req.PayType, "图文问诊-"+doctor.Name, fee,
+		OrderTypeConsultation, consultationNo, req.Description, "")
+	if err != nil {
+		return nil, err
+	}
+
+	consultation := &Consultation{
+		ConsultationNo: consultationNo,
+		PatientID:      req.PatientID,
+		DoctorID:       req.DoctorID,
+		DoctorName:     doctor.Name,
+		Fee:            doctor.ConsultationFee.Round(2),
+		Description:    req.Description,
+		Status:         ConsultationStatusPendingPayment,
+		PaymentOrderID: payment.OrderID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := uc.repo.CreateConsultation(ctx, consultation); err != nil {
+		uc.log.Errorf("创建问诊失败: consultationNo=%s, error=%v", consultationNo, err)
+		return nil, err
+	}
+
+	uc.log.Infof("发起问诊成功: consultationNo=%s, patientID=%d, doctorID=%d, fee=%s", consultationNo, req.PatientID, req.DoctorID, fee)
+	return consultation, nil
+}
+
+// 问诊支付单支付成功：待支付的问诊进入待接诊，已取消的问诊直接退款
+func (uc *ConsultationUsecase) OnPaymentPaid(ctx context.Context, payment *PaymentOrder) error {
+	consultation, err := uc.getConsultation(ctx, payment.BusinessID)
+	if err != nil {
+		return err
+	}
+	if consultation.PaymentOrderID != payment.OrderID {
+		return fmt.Errorf("支付单与问诊不匹配: consultationNo=%s, orderID=%s", consultation.ConsultationNo, payment.OrderID)
+	}
+
+	switch consultation.Status {
+	case ConsultationStatusPendingPayment:
+		paidAt := payment.PayTime
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+		err := uc.repo.UpdateConsultationStatus(ctx, consultation.ConsultationNo, ConsultationStatusPendingPayment, &ConsultationUpdate{
+			Status: ConsultationStatusWaiting,
+			PaidAt: paidAt,
+		})
+		if errors.Is(err, ErrConsultationStatusConflict) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		uc.log.Infof("问诊支付成功，等待医生接诊: consultationNo=%s", consultation.ConsultationNo)
+		return nil
+
+	case ConsultationStatusCancelled:
+		// 取消后才完成支付，原路退回
+		return uc.refund(ctx, consultation, payment, ConsultationStatusCancelled, "问诊已取消，支付后自动退款")
+	}
+	return nil
+}
+
+// 查询问诊，仅问诊双方可查看。待支付时向支付渠道同步支付结果，补偿丢失的支付通知
+func (uc *ConsultationUsecase) GetConsultation(ctx context.Context, userID int32, consultationNo string) (*Consultation, error) {
+	consultation, err := uc.getConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, err
+	}
+	if consultation.PatientID != userID && consultation.DoctorID != userID {
+		return nil, fmt.Errorf("无权查看此问诊")
+	}
+	if consultation.Status != ConsultationStatusPendingPayment {
+		return consultation, nil
+	}
+
+	payment, err := uc.paymentUc.SyncPaymentOrder(ctx, consultation.PaymentOrderID)
+	if err != nil {
+		uc.log.Warnf("同步问诊支付结果失败: consultationNo=%s, error=%v", consultationNo, err)
+		return consultation, nil
+	}
+	// 支付单已支付但业务处理失败过，在此补偿
+	if payment != nil && payment.Status == PaymentStatusPaid {
+		if err := uc.OnPaymentPaid(ctx, payment); err != nil {
+			uc.log.Errorf("补偿问诊支付结果失败: consultationNo=%s, error=%v", consultationNo, err)
+		}
+		return uc.getConsultation(ctx, consultationNo)
+	}
+	return consultation, nil
+}
+
+// 查询问诊列表，role 为 doctor 时查询医生接诊的问诊
+func (uc *ConsultationUsecase) ListConsultations(ctx context.Context, userID int32, role, status string, page, pageSize int32) ([]*Consultation, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 50 {
+		pageSize = 20
+	}
+	filter := &ConsultationFilter{Status: status, Page: page, PageSize: pageSize}
+	switch role {
+	case "", ConsultationRolePatient:
+		filter.PatientID = userID
+	case ConsultationRoleDoctor:
+		filter.DoctorID = userID
+	default:
+		return nil, 0, fmt.Errorf("不支持的查询角色: %s", role)
+	}
+	return uc.repo.ListConsultations(ctx, filter)
+}
+
+// 患者取消问诊：待支付时直接取消，待接诊时整单退款
+func (uc *ConsultationUsecase) CancelConsultation(ctx context.Context, patientID int32, consultationNo string) (*Consultation, error) {
+	consultation, err := uc.getConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, err
+	}
+	if consultation.PatientID != patientID {
+		return nil, fmt.Errorf("无权操作此问诊")
+	}
+
+	switch consultation.Status {
+	case ConsultationStatusPendingPayment:
+		err = uc.repo.UpdateConsultationStatus(ctx, consultationNo, ConsultationStatusPendingPayment, &ConsultationUpdate{
+			Status:      ConsultationStatusCancelled,
+			CloseReason: "患者取消",
+			ClosedAt:    time.Now(),
+		})
+	case ConsultationStatusWaiting:
+		var payment *PaymentOrder
+		payment, err = uc.paidPayment(ctx, consultation)
+		if err == nil {
+			err = uc.refund(ctx, consultation, payment, ConsultationStatusWaiting, "患者在医生接诊前取消")
+		}
+	default:
+		return nil, fmt.Errorf("问诊当前状态不能取消: %s", consultation.Status)
+	}
+	if err != nil {
+		if errors.Is(err, ErrConsultationStatusConflict) {
+			return nil, fmt.Errorf("问诊状态已变更，请刷新后重试")
+		}
+		return nil, err
+	}
+
+	uc.log.Infof("患者取消问诊: consultationNo=%s", consultationNo)
+	return uc.getConsultation(ctx, consultationNo)
+}
+
+// 医生接诊，会话在 duration 后到期
+func (uc *ConsultationUsecase) AcceptConsultation(ctx context.Context, doctorID int32, consultationNo string, duration time.Duration) (*Consultation, error) {
+	consultation, err := uc.getConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, err
+	}
+	if consultation.DoctorID != doctorID {
+		return nil, fmt.Errorf("无权操作此问诊")
+	}
+	if consultation.Status != ConsultationStatusWaiting {
+		return nil, fmt.Errorf("问诊当前状态不能接诊: %s", consultation.Status)
+	}
+
+	now := time.Now()
+	err = uc.repo.UpdateConsultationStatus(ctx, consultationNo, ConsultationStatusWaiting, &ConsultationUpdate{
+		Status:    ConsultationStatusInProgress,
+		StartedAt: now,
+		EndsAt:    now.Add(duration),
+	})
+	if err != nil {
+		if errors.Is(err, ErrConsultationStatusConflict) {
+			return nil, fmt.Errorf("问诊状态已变更，请刷新后重试")
+		}
+		return nil, err
+	}
+
+	uc.notify(ctx, consultation, ChatSystemEventConsultationStarted,
+		fmt.Sprintf("%s医生已接诊，本次问诊将于%s结束", consultation.DoctorName, now.Add(duration).Format("15:04")))
+	uc.log.Infof("医生接诊: consultationNo=%s, doctorID=%d", consultationNo, doctorID)
+	return uc.getConsultation(ctx, consultationNo)
+}
+
+// 医生结束问诊并填写诊断小结
+func (uc *ConsultationUsecase) CloseConsultation(ctx context.Context, doctorID int32, consultationNo, diagnosis, advice string) (*Consultation, error) {
+	if diagnosis == "" {
+		return nil, fmt.Errorf("请填写诊断小结")
+	}
+	if utf8.RuneCountInString(diagnosis) > consultationDiagnosisLength || utf8.RuneCountInString(advice) > consultationDiagnosisLength {
+		return nil, fmt.Errorf("诊断小结和医嘱不能超过%d个字符", consultationDiagnosisLength)
+	}
+
+	consultation, err := uc.getConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, err
+	}
+	if consultation.DoctorID != doctorID {
+		return nil, fmt.Errorf("无权操作此问诊")
+	}
+	if consultation.Status != ConsultationStatusInProgress {
+		return nil, fmt.Errorf("问诊当前状态不能结束: %s", consultation.Status)
+	}
+
+	err = uc.repo.UpdateConsultationStatus(ctx, consultationNo, ConsultationStatusInProgress, &ConsultationUpdate{
+		Status:      ConsultationStatusClosed,
+		Diagnosis:   diagnosis,
+		Advice:      advice,
+		CloseReason: "医生结束问诊",
+		ClosedAt:    time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, ErrConsultationStatusConflict) {
+			return nil, fmt.Errorf("问诊状态已变更，请刷新后重试")
+		}
+		return nil, err
+	}
+
+	uc.countConsultation(ctx, consultation)
+	uc.notify(ctx, consultation, ChatSystemEventConsultationEnded, "问诊已结束，诊断小结："+diagnosis)
+	uc.log.Infof("医生结束问诊: consultationNo=%s, doctorID=%d", consultationNo, doctorID)
+	return uc.getConsultation(ctx, consultationNo)
+}
+
+// 患者评价已结束的问诊，每次问诊只能评价一次
+func (uc *ConsultationUsecase) RateConsultation(ctx context.Context, patientID int32, consultationNo string, rating int32, comment string) (*Consultation, error) {
+	if rating < 1 || rating > 5 {
+		return nil, fmt.Errorf("评分须在1到5之间")
+	}
+	if utf8.RuneCountInString(comment) > consultationRatingLength {
+		return nil, fmt.Errorf("评价不能超过%d个字符", consultationRatingLength)
+	}
+
+	consultation, err := uc.getConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, err
+	}
+	if consultation.PatientID != patientID {
+		return nil, fmt.Errorf("无权操作此问诊")
+	}
+	if consultation.Status != ConsultationStatusClosed {
+		return nil, fmt.Errorf("问诊结束后才能评价")
+	}
+	if consultation.Rating > 0 {
+		return nil, fmt.Errorf("已评价过此问诊")
+	}
+
+	if err := uc.repo.RateConsultation(ctx, consultation, rating, comment, time.Now()); err != nil {
+		if errors.Is(err, ErrConsultationStatusConflict) {
+			return nil, fmt.Errorf("已评价过此问诊")
+		}
+		uc.log.Errorf("评价问诊失败: consultationNo=%s, error=%v", consultationNo, err)
+		return nil, err
+	}
+	uc.log.Infof("患者评价问诊: consultationNo=%s, doctorID=%d, rating=%d", consultationNo, consultation.DoctorID, rating)
+	return uc.getConsultation(ctx, consultationNo)
+}
+
+// 处理超时的问诊：支付后超过 answerTimeout 未接诊的整单退款，会话到期的自动结束，返回处理数
+func (uc *ConsultationUsecase) ExpireConsultations(ctx context.Context, answerTimeout time.Duration, limit int) (int, error) {
+	now := time.Now()
+	unanswered, err := uc.repo.ListUnansweredConsultations(ctx, now.Add(-answerTimeout), limit)
+	if err != nil {
+		return 0, fmt.Errorf("查询超时未接诊的问诊失败: %v", err)
+	}
+
+	processed := 0
+	for _, consultation := range unanswered {
+		payment, err := uc.paidPayment(ctx, consultation)
+		if err == nil {
+			err = uc.refund(ctx, consultation, payment, ConsultationStatusWaiting, "医生超时未接诊，自动退款")
+		}
+		if err != nil {
+			if !errors.Is(err, ErrConsultationStatusConflict) {
+				uc.log.Errorf("超时未接诊问诊退款失败: consultationNo=%s, error=%v", consultation.ConsultationNo, err)
+			}
+			continue
+		}
+		processed++
+	}
+
+	overdue, err := uc.repo.ListOverdueConsultations(ctx, now, limit)
+	if err != nil {
+		return processed, fmt.Errorf("查询到期的问诊失败: %v", err)
+	}
+	for _, consultation := range overdue {
+		err := uc.repo.UpdateConsultationStatus(ctx, consultation.ConsultationNo, ConsultationStatusInProgress, &ConsultationUpdate{
+			Status:      ConsultationStatusClosed,
+			CloseReason: "问诊时间已到，自动结束",
+			ClosedAt:    now,
+		})
+		if err != nil {
+			if !errors.Is(err, ErrConsultationStatusConflict) {
+				uc.log.Errorf("自动结束问诊失败: consultationNo=%s, error=%v", consultation.ConsultationNo, err)
+			}
+			continue
+		}
+		uc.countConsultation(ctx, consultation)
+		uc.notify(ctx, consultation, ChatSystemEventConsultationEnded, "问诊时间已到，本次问诊已结束")
+		processed++
+	}
+
+	if processed > 0 {
+		uc.log.Infof("处理超时问诊完成: count=%d", processed)
+	}
+	return processed, nil
+}
+
+// 问诊状态从 fromStatus 流转为已退款，并为支付单创建整单退款单
+func (uc *ConsultationUsecase) refund(ctx context.Context, consultation *Consultation, payment *PaymentOrder, fromStatus, reason string) error {
+	// 先流转状态，并发处理时只有一方能发起退款
+	err := uc.repo.UpdateConsultationStatus(ctx, consultation.ConsultationNo, fromStatus, &ConsultationUpdate{
+		Status:      ConsultationStatusRefunded,
+		RefundID:    "REFUND_" + payment.OrderID,
+		CloseReason: reason,
+		ClosedAt:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.refundUc.RefundPayment(ctx, payment, reason); err != nil {
+		// 退款单创建失败时退回原状态，等待下一轮处理
+		if revertErr := uc.repo.UpdateConsultationStatus(ctx, consultation.ConsultationNo, ConsultationStatusRefunded, &ConsultationUpdate{Status: fromStatus}); revertErr != nil {
+			uc.log.Errorf("问诊状态回退失败: consultationNo=%s, error=%v", consultation.ConsultationNo, revertErr)
+		}
+		return err
+	}
+
+	if fromStatus == ConsultationStatusWaiting {
+		uc.notify(ctx, consultation, ChatSystemEventConsultationEnded, reason+"，问诊费将原路退回")
+	}
+	uc.log.Infof("问诊退款: consultationNo=%s, reason=%s", consultation.ConsultationNo, reason)
+	return nil
+}
+
+// 问诊对应的已支付支付单
+func (uc *ConsultationUsecase) paidPayment(ctx context.Context, consultation *Consultation) (*PaymentOrder, error) {
+	payment, err := uc.paymentUc.GetPaymentOrder(ctx, consultation.PaymentOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("查询支付单失败: %v", err)
+	}
+	if payment == nil || payment.Status != PaymentStatusPaid {
+		return nil, fmt.Errorf("问诊没有已支付的支付单: %s", consultation.ConsultationNo)
+	}
+	return payment, nil
+}
+
+// 问诊结束后累加医生的问诊量，问诊量只用于展示和排序，失败不影响问诊状态
+func (uc *ConsultationUsecase) countConsultation(ctx context.Context, consultation *Consultation) {
+	if err := uc.doctorsRepo.IncrConsultationCount(ctx, consultation.DoctorID); err != nil {
+		uc.log.Errorf("累加医生问诊量失败: consultationNo=%s, doctorID=%d, error=%v", consultation.ConsultationNo, consultation.DoctorID, err)
+	}
+}
+
+// 以医生名义向患者发送问诊系统通知，通知失败不影响问诊状态
+func (uc *ConsultationUsecase) notify(ctx context.Context, consultation *Consultation, event, text string) {
+	if utf8.RuneCountInString(text) > chatSystemTextLength {
+		text = string([]rune(text)[:chatSystemTextLength])
+	}
+	doctor := ChatUser{Role: ChatRoleDoctor, ID: consultation.DoctorID}
+	patient := ChatUser{Role: ChatRolePatient, ID: consultation.PatientID}
+	message, _, err := uc.chatUc.SendSystemNotice(ctx, doctor, patient, event, text)
+	if err != nil {
+		uc.log.Errorf("发送问诊通知失败: consultationNo=%s, event=%s, error=%v", consultation.ConsultationNo, event, err)
+		return
+	}
+	if uc.pusher != nil {
+		uc.pusher.PushChatMessage(message)
+	}
+}
+
+func (uc *ConsultationUsecase) getConsultation(ctx context.Context, consultationNo string) (*Consultation, error) {
+	consultation, err := uc.repo.GetConsultation(ctx, consultationNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询问诊失败: %v", err)
+	}
+	if consultation == nil {
+		return nil, fmt.Errorf("问诊不存在: %s", consultationNo)
+	}
+	return consultation, nil
+}
@@ -9,6 +9,18 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// 用户优惠券使用状态
+const (
+	UserCouponUnused int32 = 0 // 未使用
+	UserCouponUsed   int32 = 1 // 已使用
+)
+
+// 订单优惠券核销状态
+const (
+	OrderCouponUsed     int32 = 1 // 已使用
+	OrderCouponReturned int32 = 2 // 已退回（订单取消或超时关闭）
+)
+
 // 优惠券模型
 type MtDiscount struct {
 	ID             int32           `json:"id"`
@@ -41,6 +53,7 @@ type MtDiscountUser struct {
 	ID         int32 `json:"id"`
 	DiscountID int32 `json:"discount_id"`
 	UserID     int32 `json:"user_id"`
+	Status     int32 `json:"status"` // 使用状态
 }
 
 // 订单优惠券使用记录模型
@@ -52,6 +65,8 @@ type MtOrderCoupon struct {
 	UserID         int32           `json:"user_id"`
 	DiscountAmount decimal.Decimal `json:"discount_amount"`
 	UseTime        time.Time       `json:"use_time"`
+	Status         int32           `json:"status"`      // 核销状态
+	ReturnTime     *time.Time      `json:"return_time"` // 退回时间
 }
 
 // 优惠券详情
@@ -104,7 +119,10 @@ type CouponRepo interface {
 	ListUserCoupons(ctx context.Context, userID int32, page, pageSize int32) ([]*UserCouponDetail, int64, error)
 	ClaimCoupon(ctx context.Context, couponID int32, userID int32) (*MtDiscountUser, error)
 	GetUserCoupon(ctx context.Context, discountUserID int32) (*MtDiscountUser, error)
+	// 仅当用户优惠券未使用时核销，否则返回ErrCouponAlreadyUsed；同步增加优惠券已使用数量
 	UseCoupon(ctx context.Context, orderNo string, discountUserID int32, discountAmount decimal.Decimal) error
+	// 退回订单核销的优惠券，订单未使用优惠券或已退回时返回nil
+	ReturnCoupon(ctx context.Context, orderNo string) (*MtOrderCoupon, error)
 	CheckCouponUsed(ctx context.Context, discountUserID int32) (bool, error)
 	
 	// 统计
@@ -346,6 +364,62 @@ func (uc *CouponUsecase) UseCoupon(ctx context.Context, orderNo string, discount
 	return uc.couponRepo.UseCoupon(ctx, orderNo, discountUserID, discountAmount)
 }
 
+// 退回订单使用的优惠券
+func (uc *CouponUsecase) ReturnCoupon(ctx context.Context, orderNo string) error {
+	orderCoupon, err := uc.couponRepo.ReturnCoupon(ctx, orderNo)
+	if err != nil {
+		uc.log.Errorf("退回优惠券失败: orderNo=%s, error=%v", orderNo, err)
+		return err
+	}
+	if orderCoupon != nil {
+		uc.log.Infof("退回优惠券成功: orderNo=%s, discountUserID=%d", orderNo, orderCoupon.DiscountUserID)
+	}
+	return nil
+}
+
+// 校验用户优惠券能否用于订单，返回优惠金额（不超过订单金额）
+func (uc *CouponUsecase) CheckOrderCoupon(ctx context.Context, userID int64, discountUserID int32, items []*CouponCalculateItem, totalAmount decimal.Decimal, storeID int32) (decimal.Decimal, error) {
+	discountUser, err := uc.couponRepo.GetUserCoupon(ctx, discountUserID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("获取用户优惠券失败: %v", err)
+	}
+	if discountUser == nil || int64(discountUser.UserID) != userID {
+		return decimal.Zero, fmt.Errorf("优惠券不存在")
+	}
+	if discountUser.Status == UserCouponUsed {
+		return decimal.Zero, fmt.Errorf("%w: 优惠券已使用", ErrCouponAlreadyUsed)
+	}
+
+	coupon, err := uc.couponRepo.GetCouponByID(ctx, discountUser.DiscountID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("获取优惠券详情失败: %v", err)
+	}
+	if coupon == nil {
+		return decimal.Zero, fmt.Errorf("优惠券不存在")
+	}
+
+	now := time.Now()
+	if now.Before(coupon.StartTime) || now.After(coupon.EndTime) {
+		return decimal.Zero, fmt.Errorf("优惠券不在有效期内")
+	}
+	if coupon.StoreID > 0 && coupon.StoreID != storeID {
+		return decimal.Zero, fmt.Errorf("优惠券不适用于当前店铺")
+	}
+	if totalAmount.LessThan(coupon.MinOrderAmount) {
+		return decimal.Zero, fmt.Errorf("订单金额不满足优惠券使用条件，需满%s元", coupon.MinOrderAmount.String())
+	}
+
+	canUseForItems, err := uc.checkCouponItemRules(ctx, coupon.ID, items)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("检查优惠券商品规则失败: %v", err)
+	}
+	if !canUseForItems {
+		return decimal.Zero, fmt.Errorf("优惠券不适用于当前商品")
+	}
+
+	return decimal.Min(coupon.DiscountAmount, totalAmount), nil
+}
+
 // 获取用户优惠券
 func (uc *CouponUsecase) GetUserCoupon(ctx context.Context, discountUserID int32) (*MtDiscountUser, error) {
 	return uc.couponRepo.GetUserCoupon(ctx, discountUserID)
@@ -2,54 +2,171 @@ package biz
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+	"golang.org/x/crypto/bcrypt"
 	"time"
 )
 
 type MtDoctors struct {
-	Id            uint64    `gorm:"column:id;type:bigint UNSIGNED;comment:主键索引;primaryKey;" json:"id"`                           // 主键索引
-	DoctorCode    string    `gorm:"column:doctor_code;type:varchar(32);comment:医生编码;not null;" json:"doctor_code"`               // 医生编码
-	Name          string    `gorm:"column:name;type:varchar(50);comment:医生姓名;not null;" json:"name"`                             // 医生姓名
-	Gender        string    `gorm:"column:gender;type:varchar(20);comment:性别：1-男，2-女;" json:"gender"`                            // 性别：1-男，2-女
-	BirthDate     time.Time `gorm:"column:birth_date;type:date;comment:出生日期;" json:"birth_date"`                                 // 出生日期
-	Phone         string    `gorm:"column:phone;type:varchar(20);comment:手机号码;not null;" json:"phone"`                           // 手机号码
-	Email         string    `gorm:"column:email;type:varchar(100);comment:邮箱地址;" json:"email"`                                   // 邮箱地址
-	Avatar        string    `gorm:"column:avatar;type:varchar(255);comment:头像URL;" json:"avatar"`                                  // 头像URL
-	LicenseNumber string    `gorm:"column:license_number;type:varchar(50);comment:执业医师资格证号;not null;" json:"license_number"` // 执业医师资格证号
-	DepartmentId  int32     `gorm:"column:department_id;type:mediumint;comment:科室ID;" json:"department_id"`                        // 科室ID
-	HospitalId    int32     `gorm:"column:hospital_id;type:mediumint;comment:医院ID;" json:"hospital_id"`                            // 医院ID
-	Title         string    `gorm:"column:title;type:varchar(50);comment:职称;" json:"title"`                                        // 职称
-	Speciality    string    `gorm:"column:speciality;type:varchar(200);comment:专业特长;" json:"speciality"`                         // 专业特长
-	PracticeScope string    `gorm:"column:practice_scope;type:varchar(500);comment:执业范围;" json:"practice_scope"`                 // 执业范围
-	PasswordHash  string    `gorm:"column:password_hash;type:varchar(255);comment:密码哈希;not null;" json:"password_hash"`          // 密码哈希
-	Salt          string    `gorm:"column:salt;type:varchar(32);comment:密码盐值;not null;" json:"salt"`                             // 密码盐值
-	Status        string    `gorm:"column:status;type:varchar(20);comment:状态：0-禁用，1-启用，2-待审核;" json:"status"`               // 状态：0-禁用，1-启用，2-待审核
-	LastLoginTime time.Time `gorm:"column:last_login_time;type:datetime(3);comment:最后登录时间;" json:"last_login_time"`            // 最后登录时间
-	LastLoginIp   string    `gorm:"column:last_login_ip;type:varchar(45);comment:最后登录IP;" json:"last_login_ip"`                  // 最后登录IP
-	CreatedAt     time.Time `gorm:"column:created_at;type:datetime(3);" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"column:updated_at;type:datetime(3);" json:"updated_at"`
-	DeletedAt     time.Time `gorm:"column:deleted_at;type:datetime(3);" json:"deleted_at"`
-	CreatedBy     uint64    `gorm:"column:created_by;type:bigint UNSIGNED;comment:创建者;" json:"created_by"` // 创建者
-	UpdatedBy     uint64    `gorm:"column:updated_by;type:bigint UNSIGNED;comment:更新者;" json:"updated_by"` // 更新者
-	DeletedBy     uint64    `gorm:"column:deleted_by;type:bigint UNSIGNED;comment:删除者;" json:"deleted_by"` // 删除者
+	Id                uint64          `gorm:"column:id;type:bigint UNSIGNED;comment:主键索引;primaryKey;" json:"id"`                                   // 主键索引
+	DoctorCode        string          `gorm:"column:doctor_code;type:varchar(32);comment:医生编码;not null;" json:"doctor_code"`                       // 医生编码
+	Name              string          `gorm:"column:name;type:varchar(50);comment:医生姓名;not null;" json:"name"`                                     // 医生姓名
+	Gender            string          `gorm:"column:gender;type:varchar(20);comment:性别：1-男，2-女;" json:"gender"`                                    // 性别：1-男，2-女
+	BirthDate         time.Time       `gorm:"column:birth_date;type:date;comment:出生日期;" json:"birth_date"`                                         // 出生日期
+	Phone             string          `gorm:"column:phone;type:varchar(20);comment:手机号码;not null;" json:"phone"`                                   // 手机号码
+	Email             string          `gorm:"column:email;type:varchar(100);comment:邮箱地址;" json:"email"`                                           // 邮箱地址
+	Avatar            string          `gorm:"column:avatar;type:varchar(255);comment:头像URL;" json:"avatar"`                                        // 头像URL
+	LicenseNumber     string          `gorm:"column:license_number;type:varchar(50);comment:执业医师资格证号;not null;" json:"license_number"`             // 执业医师资格证号
+	DepartmentId      int32           `gorm:"column:department_id;type:mediumint;comment:科室ID;" json:"department_id"`                              // 科室ID
+	HospitalId        int32           `gorm:"column:hospital_id;type:mediumint;comment:医院ID;" json:"hospital_id"`                                  // 医院ID
+	Title             string          `gorm:"column:title;type:varchar(50);comment:职称;" json:"title"`                                              // 职称
+	Speciality        string          `gorm:"column:speciality;type:varchar(200);comment:专业特长;" json:"speciality"`                                 // 专业特长
+	PracticeScope     string          `gorm:"column:practice_scope;type:varchar(500);comment:执业范围;" json:"practice_scope"`                         // 执业范围
+	PasswordHash      string          `gorm:"column:password_hash;type:varchar(255);comment:密码哈希;not null;" json:"password_hash"`                  // 密码哈希
+	Salt              string          `gorm:"column:salt;type:varchar(32);comment:密码盐值;not null;" json:"salt"`                                     // 密码盐值
+	Status            string          `gorm:"column:status;type:varchar(20);comment:状态：0-禁用，1-启用，2-待审核;" json:"status"`                            // 状态：0-禁用，1-启用，2-待审核
+	ConsultationFee   decimal.Decimal `gorm:"column:consultation_fee;type:decimal(10,2);default:0;comment:图文问诊费用，0表示未开通;" json:"consultation_fee"` // 图文问诊费用，0表示未开通
+	Rating            decimal.Decimal `gorm:"column:rating;type:decimal(3,2);default:0;comment:患者评分均值;" json:"rating"`                             // 患者评分均值
+	RatingCount       int32           `gorm:"column:rating_count;type:int;default:0;comment:评分人数;" json:"rating_count"`                            // 评分人数
+	ConsultationCount int32           `gorm:"column:consultation_count;type:int;default:0;comment:已完成问诊量;" json:"consultation_count"`              // 已完成问诊量
+	LastLoginTime     time.Time       `gorm:"column:last_login_time;type:datetime(3);comment:最后登录时间;" json:"last_login_time"`                      // 最后登录时间
+	LastLoginIp       string          `gorm:"column:last_login_ip;type:varchar(45);comment:最后登录IP;" json:"last_login_ip"`                          // 最后登录IP
+	CreatedAt         time.Time       `gorm:"column:created_at;type:datetime(3);" json:"created_at"`
+	UpdatedAt         time.Time       `gorm:"column:updated_at;type:datetime(3);" json:"updated_at"`
+	DeletedAt         time.Time       `gorm:"column:deleted_at;type:datetime(3);" json:"deleted_at"`
+	CreatedBy         uint64          `gorm:"column:created_by;type:bigint UNSIGNED;comment:创建者;" json:"created_by"` // 创建者
+	UpdatedBy         uint64          `gorm:"column:updated_by;type:bigint UNSIGNED;comment:更新者;" json:"updated_by"` // 更新者
+	DeletedBy         uint64          `gorm:"column:deleted_by;type:bigint UNSIGNED;comment:删除者;" json:"deleted_by"` // 删除者
 }
 
 func (m *MtDoctors) TableName() string {
 	return "mt_doctors"
 }
 
+// 医生列表排序方式
+const (
+	DoctorSortRating        = "rating"        // 按评分从高到低
+	DoctorSortConsultations = "consultations" // 按问诊量从高到低
+)
+
+// 医生列表查询条件，零值字段不参与筛选
+type DoctorFilter struct {
+	HospitalID   int32
+	DepartmentID int32
+	Title        string
+	Speciality   string // 按专业特长模糊匹配
+	SortBy       string
+	Page         int32
+	PageSize     int32
+}
+
+// 面向患者展示的医生信息，关联医院和科室
+type DoctorProfile struct {
+	ID                int32
+	DoctorCode        string
+	Name              string
+	Gender            string
+	Avatar            string
+	Title             string
+	Speciality        string
+	PracticeScope     string
+	HospitalID        int32
+	HospitalName      string
+	HospitalLevel     string
+	HospitalAddress   string
+	DepartmentID      int32
+	DepartmentName    string
+	ConsultationFee   decimal.Decimal
+	Rating            decimal.Decimal
+	RatingCount       int32
+	ConsultationCount int32
+}
+
+// 医生资质审核状态
+const (
+	DoctorApprovalRejected = "0"
+	DoctorApprovalApproved = "1"
+	DoctorApprovalPending  = "2"
+)
+
+// 医生端可自行修改的资料，空字段保持不变，修改后重新进入资质审核
+type DoctorProfileUpdate struct {
+	Gender        string
+	Avatar        string
+	Email         string
+	Title         string
+	Speciality    string
+	PracticeScope string
+}
+
+// 医生的患者档案 - 对应 mt_doctor_patients 表，关联患者昵称和头像
+type DoctorPatient struct {
+	PatientID        int32
+	NickName         string
+	Avatar           string
+	RelationshipType string
+	Tags             string
+	Notes            string
+	FirstVisitTime   time.Time
+	LastVisitTime    time.Time
+	VisitCount       int32
+}
+
 type DoctorsRepo interface {
 	DoctorsFind(ctx context.Context, m *MtDoctors) (*[]MtDoctors, error)
 	FindByID(ctx context.Context, id int32) (*MtDoctors, error)
+	// 分页查询启用且审核通过的医生
+	ListApprovedDoctors(ctx context.Context, filter *DoctorFilter) ([]*DoctorProfile, int64, error)
+	// 查询启用且审核通过的医生，不存在时返回nil
+	GetApprovedDoctor(ctx context.Context, id int32) (*DoctorProfile, error)
+	// 累加医生已完成的问诊量
+	IncrConsultationCount(ctx context.Context, id int32) error
+	// 按手机号或医生编码查询医生，不存在时返回nil
+	FindByAccount(ctx context.Context, account string) (*MtDoctors, error)
+	// 记录最后登录时间和IP
+	UpdateLastLogin(ctx context.Context, id int32, at time.Time, ip string) error
+	// 更新登录密码哈希，bcrypt哈希自带盐值，同时清空盐值
+	UpdatePasswordHash(ctx context.Context, id int32, hash string) error
+	// 分页查询医生的患者档案，按最后就诊时间倒序
+	ListDoctorPatients(ctx context.Context, doctorID int32, page, pageSize int32) ([]*DoctorPatient, int64, error)
+	// 医生最近一次资质审核的状态，没有审核记录时返回空
+	LatestApprovalStatus(ctx context.Context, doctorID int32) (string, error)
+	// 保存医生资料并提交资质审核，已有待审核记录时更新提交时间
+	SubmitProfileForReview(ctx context.Context, doctor *MtDoctors, update *DoctorProfileUpdate, submittedAt time.Time) error
+}
+
+// 医生、药师登录密码哈希，使用bcrypt，管理端重置密码时使用相同算法
+func DoctorPasswordHash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// 校验登录密码，legacy 表示匹配的是旧的 sha256(盐值+密码) 哈希，登录成功后应改存bcrypt
+func checkPasswordHash(password, hash, salt string) (ok, legacy bool) {
+	if strings.HasPrefix(hash, "$2") {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil, false
+	}
+	sum := sha256.Sum256([]byte(salt + password))
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(hash)) == 1, true
 }
 
 type DoctorsService struct {
-	repo DoctorsRepo
-	log  *log.Helper
+	repo     DoctorsRepo
+	throttle *LoginThrottle
+	log      *log.Helper
 }
 
-func NewDoctorsUsecase(repo DoctorsRepo, logger log.Logger) *DoctorsService {
-	return &DoctorsService{repo: repo, log: log.NewHelper(logger)}
+func NewDoctorsUsecase(repo DoctorsRepo, throttle *LoginThrottle, logger log.Logger) *DoctorsService {
+	return &DoctorsService{repo: repo, throttle: throttle, log: log.NewHelper(logger)}
 }
 
 func (m *DoctorsService) DoctorsFind(ctx context.Context, req *MtDoctors) (*[]MtDoctors, error) {
@@ -61,3 +178,137 @@ func (m *DoctorsService) FindByID(ctx context.Context, id int32) (*MtDoctors, er
 	m.log.WithContext(ctx).Infof("FindByID doctor %d", id)
 	return m.repo.FindByID(ctx, id)
 }
+
+// 分页查询可问诊的医生
+func (m *DoctorsService) ListDoctors(ctx context.Context, filter *DoctorFilter) ([]*DoctorProfile, int64, error) {
+	switch filter.SortBy {
+	case "", DoctorSortRating, DoctorSortConsultations:
+	default:
+		return nil, 0, fmt.Errorf("不支持的排序方式: %s", filter.SortBy)
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+	return m.repo.ListApprovedDoctors(ctx, filter)
+}
+
+// 查询医生详情，未启用或未审核通过的医生视为不存在
+func (m *DoctorsService) GetDoctor(ctx context.Context, id int32) (*DoctorProfile, error) {
+	doctor, err := m.repo.GetApprovedDoctor(ctx, id)
+	if err != nil {
+		m.log.WithContext(ctx).Errorf("查询医生详情失败: id=%d, error=%v", id, err)
+		return nil, fmt.Errorf("查询医生详情失败")
+	}
+	if doctor == nil {
+		return nil, fmt.Errorf("医生不存在: %d", id)
+	}
+	return doctor, nil
+}
+
+// 医生使用手机号或医生编码加密码登录，连续失败达到上限后锁定账号
+func (m *DoctorsService) Login(ctx context.Context, account, password, ip string) (*MtDoctors, error) {
+	account = strings.TrimSpace(account)
+	if account == "" || password == "" {
+		return nil, fmt.Errorf("请输入账号和密码")
+	}
+	if err := m.throttle.Check(ctx, LoginScopeDoctor, account); err != nil {
+		m.log.WithContext(ctx).Warnf("医生登录已锁定: account=%s, ip=%s", account, ip)
+		return nil, err
+	}
+	doctor, err := m.repo.FindByAccount(ctx, account)
+	if err != nil {
+		m.log.WithContext(ctx).Errorf("查询医生账号失败: account=%s, error=%v", account, err)
+		return nil, fmt.Errorf("登录失败")
+	}
+	// 未设置密码的账号不能登录
+	if doctor == nil || doctor.PasswordHash == "" {
+		m.throttle.Fail(ctx, LoginScopeDoctor, account)
+		return nil, ErrDoctorLoginFailed
+	}
+	ok, legacy := checkPasswordHash(password, doctor.PasswordHash, doctor.Salt)
+	if !ok {
+		m.log.WithContext(ctx).Warnf("医生登录密码错误: doctorId=%d, ip=%s", doctor.Id, ip)
+		m.throttle.Fail(ctx, LoginScopeDoctor, account)
+		return nil, ErrDoctorLoginFailed
+	}
+	m.throttle.Succeed(ctx, LoginScopeDoctor, account)
+	if legacy {
+		m.upgradePasswordHash(ctx, doctor, password)
+	}
+
+	now := time.Now()
+	if err := m.repo.UpdateLastLogin(ctx, int32(doctor.Id), now, ip); err != nil {
+		m.log.WithContext(ctx).Warnf("记录医生登录信息失败: doctorId=%d, error=%v", doctor.Id, err)
+	}
+	doctor.LastLoginTime = now
+	doctor.LastLoginIp = ip
+	return doctor, nil
+}
+
+// 旧的 sha256 哈希在登录成功后改存bcrypt，失败不影响登录
+func (m *DoctorsService) upgradePasswordHash(ctx context.Context, doctor *MtDoctors, password string) {
+	hash, err := DoctorPasswordHash(password)
+	if err == nil {
+		err = m.repo.UpdatePasswordHash(ctx, int32(doctor.Id), hash)
+	}
+	if err != nil {
+		m.log.WithContext(ctx).Warnf("升级医生密码哈希失败: doctorId=%d, error=%v", doctor.Id, err)
+		return
+	}
+	doctor.PasswordHash, doctor.Salt = hash, ""
+}
+
+// 查询医生自己的资料和最近一次资质审核状态
+func (m *DoctorsService) GetOwnProfile(ctx context.Context, doctorID int32) (*MtDoctors, string, error) {
+	doctor, err := m.repo.FindByID(ctx, doctorID)
+	if err != nil {
+		m.log.WithContext(ctx).Errorf("查询医生资料失败: doctorId=%d, error=%v", doctorID, err)
+		return nil, "", fmt.Errorf("医生不存在: %d", doctorID)
+	}
+	status, err := m.repo.LatestApprovalStatus(ctx, doctorID)
+	if err != nil {
+		m.log.WithContext(ctx).Errorf("查询医生审核状态失败: doctorId=%d, error=%v", doctorID, err)
+		return nil, "", fmt.Errorf("查询医生资料失败")
+	}
+	return doctor, status, nil
+}
+
+// 医生修改资料，修改后重新提交资质审核，审核通过前不在医生列表中展示
+func (m *DoctorsService) UpdateProfile(ctx context.Context, doctorID int32, update *DoctorProfileUpdate) (*MtDoctors, string, error) {
+	if update.Gender != "" && update.Gender != "1" && update.Gender != "2" {
+		return nil, "", fmt.Errorf("性别参数错误: %s", update.Gender)
+	}
+	if len([]rune(update.Speciality)) > 200 {
+		return nil, "", fmt.Errorf("专业特长不能超过200个字符")
+	}
+	if len([]rune(update.PracticeScope)) > 500 {
+		return nil, "", fmt.Errorf("执业范围不能超过500个字符")
+	}
+	if *update == (DoctorProfileUpdate{}) {
+		return nil, "", fmt.Errorf("没有需要修改的资料")
+	}
+	doctor, err := m.repo.FindByID(ctx, doctorID)
+	if err != nil {
+		m.log.WithContext(ctx).Errorf("查询医生资料失败: doctorId=%d, error=%v", doctorID, err)
+		return nil, "", fmt.Errorf("医生不存在: %d", doctorID)
+	}
+	if err := m.repo.SubmitProfileForReview(ctx, doctor, update, time.Now()); err != nil {
+		m.log.WithContext(ctx).Errorf("提交医生资料审核失败: doctorId=%d, error=%v", doctorID, err)
+		return nil, "", fmt.Errorf("提交资料审核失败")
+	}
+	return m.GetOwnProfile(ctx, doctorID)
+}
+
+// 分页查询医生的患者
+func (m *DoctorsService) ListPatients(ctx context.Context, doctorID int32, page, pageSize int32) ([]*DoctorPatient, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 50 {
+		pageSize = 20
+	}
+	return m.repo.ListDoctorPatients(ctx, doctorID, page, pageSize)
+}
@@ -2,7 +2,9 @@ package biz
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-kratos/kratos/v2/log"
+	"strings"
 	"time"
 )
 
@@ -28,6 +30,7 @@ type MtDrug struct {
 	UpdatedBy        uint64    `gorm:"column:updated_by;type:bigint UNSIGNED;comment:更新者;" json:"updated_by"`    // 更新者
 	DeletedBy        uint64    `gorm:"column:deleted_by;type:bigint UNSIGNED;comment:删除者;" json:"deleted_by"`    // 删除者
 	DrugClassify     int16     `gorm:"column:drug_classify;type:smallint;comment:药品分类id;" json:"drug_classify"` // 药品分类id
+	IsPrescription   bool      `gorm:"column:is_prescription;type:tinyint(1);default:0;comment:是否处方药;" json:"is_prescription"` // 是否处方药，须凭处方下单
 }
 
 type MtGuide struct {
@@ -83,9 +86,14 @@ type DrugDocument struct {
 	FirstCategoryID  int32     `json:"first_category_id"`
 	SecondCategoryID int32     `json:"second_category_id"`
 	Price            float64   `json:"price"`
+	SalesVolume      float64   `json:"sales_volume"`
 	Inventory        int64     `json:"inventory"`
 	Manufacturer     string    `json:"manufacturer"`
+	ExhibitionID     int32     `json:"exhibition_id"`
 	Keywords         []string  `json:"keywords"`        // 搜索关键词
+	Synonyms         []string  `json:"synonyms"`        // 通用名和商品名
+	NamePinyin       []string  `json:"name_pinyin"`     // 药品名称和同义名的拼音全拼
+	NameInitials     []string  `json:"name_initials"`   // 药品名称和同义名的拼音首字母
 	Symptoms         []string  `json:"symptoms"`        // 适应症状
 	DrugStoreID      int32     `json:"drug_store_id"`   // 药店ID
 	IsPrescription   bool      `json:"is_prescription"` // 是否处方药
@@ -93,6 +101,29 @@ type DrugDocument struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 }
 
+// 由药品构建搜索文档
+func NewDrugDocument(drug *MtDrug) *DrugDocument {
+	entry := NewDrugNameEntry(drug, nil)
+	return &DrugDocument{
+		ID:               int64(drug.Id),
+		DrugName:         drug.DrugName,
+		Specification:    drug.Specification,
+		FirstCategoryID:  drug.FristCategoryId,
+		SecondCategoryID: drug.SecondCategoryId,
+		Price:            float64(drug.Price),
+		SalesVolume:      float64(drug.SalesVolume),
+		Inventory:        int64(drug.Inventory),
+		Manufacturer:     drug.Manufacturer,
+		ExhibitionID:     int32(drug.ExhibitionId),
+		DrugStoreID:      int32(drug.DrugStore),
+		IsPrescription:   drug.IsPrescription,
+		NamePinyin:       entry.Pinyin,
+		NameInitials:     entry.Initials,
+		CreatedAt:        drug.CreatedAt,
+		UpdatedAt:        drug.UpdatedAt,
+	}
+}
+
 // 处方药模型
 
 func (m *MtPrescription) TableName() string {
@@ -106,12 +137,12 @@ type MtDrugInventory struct {
 	ID             int64           `gorm:"column:id;type:bigint;primaryKey;autoIncrement;" json:"id"`
 	DrugID         int64           `gorm:"column:drug_id;type:bigint;comment:药品ID;not null;" json:"drug_id"`
 	DrugStoreID    int32           `gorm:"column:drug_store_id;type:int;comment:药店ID;not null;" json:"drug_store_id"`
-	Quantity       int64           `gorm:"column:quantity;type:bigint;comment:库存数量;not null;default:0;" json:"quantity"`
+	Quantity       int64           `gorm:"column:quantity;type:bigint;comment:在库数量(含已预留);not null;default:0;" json:"quantity"`
 	ReservedQty    int64           `gorm:"column:reserved_qty;type:bigint;comment:预留库存;not null;default:0;" json:"reserved_qty"`
 	AlertThreshold int64           `gorm:"column:alert_threshold;type:bigint;comment:预警阈值;not null;default:10;" json:"alert_threshold"`
 	Price          float64         `gorm:"column:price;type:decimal(10,2);comment:药店特定价格;not null;" json:"price"`
 	Status         InventoryStatus `gorm:"column:status;type:tinyint;comment:库存状态;not null;default:0;" json:"status"`
-	UpdatedAt      time.Time       `gorm:"column:updated_at;type:datetime(3);not null;default:CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3);" json:"updated_at"`
+	UpdatedAt      time.Time       `gorm:"column:updated_at;type:datetime(3);not null;autoUpdateTime;" json:"updated_at"`
 }
 
 func (m *MtDrugInventory) TableName() string {
@@ -145,11 +176,39 @@ type PriceRange struct {
 	Max float64 `json:"max"`
 }
 
+// 搜索排序方式，为空时有关键词按相关度排序，否则按药品ID排序
+const (
+	SearchSortPriceAsc  = "price_asc"
+	SearchSortPriceDesc = "price_desc"
+	SearchSortSalesDesc = "sales_desc"
+)
+
+// 分面最多返回的分类数和厂家数
+const (
+	SearchCategoryFacetSize     = 20
+	SearchManufacturerFacetSize = 10
+)
+
+// 价格分面区间，左闭右开，Max为0表示不设上限
+type PriceBucket struct {
+	Range string
+	Min   float64
+	Max   float64
+}
+
+var SearchPriceBuckets = []PriceBucket{
+	{Range: "0-20", Min: 0, Max: 20},
+	{Range: "20-50", Min: 20, Max: 50},
+	{Range: "50-100", Min: 50, Max: 100},
+	{Range: "100以上", Min: 100},
+}
+
 // 搜索响应结构
 type SearchResponse struct {
-	Total  int64         `json:"total"`
-	Drugs  []*DrugInfo   `json:"drugs"`
-	Facets *SearchFacets `json:"facets"`
+	Total            int64         `json:"total"`
+	Drugs            []*DrugInfo   `json:"drugs"`
+	Facets           *SearchFacets `json:"facets"`
+	CorrectedKeyword string        `json:"corrected_keyword"` // 原关键词无结果时纠错后实际搜索的关键词
 }
 
 type DrugInfo struct {
@@ -157,9 +216,11 @@ type DrugInfo struct {
 	DrugName       string  `json:"drug_name"`
 	Specification  string  `json:"specification"`
 	Price          float64 `json:"price"`
+	SalesVolume    float64 `json:"sales_volume"`
 	Inventory      int64   `json:"inventory"`
 	Manufacturer   string  `json:"manufacturer"`
 	IsPrescription bool    `json:"is_prescription"`
+	ExhibitionID   int32   `json:"exhibition_id"`
 }
 
 type SearchFacets struct {
@@ -193,14 +254,22 @@ type HotItem struct {
 
 type SearchType int
 
+// 药品搜索仓储接口，配置Elasticsearch时查询索引，否则查询数据库
+type DrugSearchRepo interface {
+	Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error)
+	// 未删除药品的名称词典，用于联想和纠错
+	ListDrugNames(ctx context.Context) ([]*DrugNameEntry, error)
+}
+
 type DrugService struct {
-	repo DrugRepo
-	log  *log.Helper
+	repo       DrugRepo
+	searchRepo DrugSearchRepo
+	log        *log.Helper
 }
 
 // NewContentUsecase new a Content usecase.
-func NewDrugService(repo DrugRepo, logger log.Logger) *DrugService {
-	return &DrugService{repo: repo, log: log.NewHelper(logger)}
+func NewDrugService(repo DrugRepo, searchRepo DrugSearchRepo, logger log.Logger) *DrugService {
+	return &DrugService{repo: repo, searchRepo: searchRepo, log: log.NewHelper(logger)}
 }
 
 func (uc *DrugService) ListDrug(ctx context.Context, fristCategoryId int32, secondCategoryId int32, keyword string) ([]*MtDrug, error) {
@@ -221,47 +290,93 @@ func (uc *DrugService) GetGuide(ctx context.Context, id int32) (*MtGuide, error)
 	return uc.repo.GetGuide(ctx, id)
 }
 
-// 简化的搜索方法
-func (uc *DrugService) SearchDrugs(ctx context.Context, keyword string, categoryId int32, page, size int) ([]*MtDrug, int64, error) {
-	uc.log.WithContext(ctx).Infof("SearchDrugs: keyword=%s, categoryId=%d", keyword, categoryId)
-
-	// 如果没有关键词，使用原有的ListDrug方法
-	if keyword == "" {
-		drugs, err := uc.repo.ListDrug(ctx, categoryId, 0, "")
-		if err != nil {
-			return nil, 0, err
+// 按关键词、分类、价格、药店、库存和处方药条件搜索药品，并返回分面统计
+func (uc *DrugService) SearchDrugs(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	req.Keyword = strings.TrimSpace(req.Keyword)
+	switch req.SortBy {
+	case "", SearchSortPriceAsc, SearchSortPriceDesc, SearchSortSalesDesc:
+	default:
+		return nil, fmt.Errorf("不支持的排序方式: %s", req.SortBy)
+	}
+	if req.PriceRange != nil {
+		if req.PriceRange.Min < 0 || req.PriceRange.Max < 0 ||
+			(req.PriceRange.Max > 0 && req.PriceRange.Min > req.PriceRange.Max) {
+			return nil, fmt.Errorf("价格区间不合法: %v-%v", req.PriceRange.Min, req.PriceRange.Max)
 		}
-		return drugs, int64(len(drugs)), nil
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Size <= 0 {
+		req.Size = 20
+	}
+	if req.Size > 100 {
+		req.Size = 100
+	}
+	uc.log.WithContext(ctx).Infof("SearchDrugs: keyword=%s, categoryId=%d, storeId=%d, sortBy=%s", req.Keyword, req.CategoryID, req.DrugStoreID, req.SortBy)
+	resp, err := uc.searchRepo.Search(ctx, req)
+	if err != nil || resp.Total > 0 || req.Keyword == "" {
+		return resp, err
 	}
 
-	// 使用药品名称进行搜索
-	drugs, err := uc.repo.ListDrug(ctx, categoryId, 0, keyword)
+	// 无结果时按最接近的药品名称纠错后重新搜索，纠错失败不影响原结果
+	corrected := uc.correctKeyword(ctx, req.Keyword, req.IncludePrescription)
+	if corrected == "" {
+		return resp, nil
+	}
+	retry := *req
+	retry.Keyword = corrected
+	retryResp, err := uc.searchRepo.Search(ctx, &retry)
 	if err != nil {
-		return nil, 0, err
+		uc.log.WithContext(ctx).Warnf("纠错后重新搜索失败: keyword=%s, corrected=%s, error=%v", req.Keyword, corrected, err)
+		return resp, nil
 	}
+	if retryResp.Total == 0 {
+		return resp, nil
+	}
+	retryResp.CorrectedKeyword = corrected
+	return retryResp, nil
+}
 
-	// 简单分页
-	total := int64(len(drugs))
-	start := (page - 1) * size
-	end := start + size
+func (uc *DrugService) correctKeyword(ctx context.Context, keyword string, includePrescription bool) string {
+	entries, err := uc.searchRepo.ListDrugNames(ctx)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("读取药品名称词典失败: %v", err)
+		return ""
+	}
+	return CorrectDrugKeyword(keyword, filterDrugNames(entries, includePrescription))
+}
 
-	if start >= len(drugs) {
-		return []*MtDrug{}, total, nil
+// 按关键词联想药品名称，支持拼音全拼、首字母和通用名、商品名
+func (uc *DrugService) SuggestDrugs(ctx context.Context, keyword string, includePrescription bool, limit int) ([]*DrugSuggestion, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return []*DrugSuggestion{}, nil
 	}
-	if end > len(drugs) {
-		end = len(drugs)
+	if limit <= 0 {
+		limit = defaultDrugSuggestLimit
 	}
-
-	return drugs[start:end], total, nil
+	if limit > maxDrugSuggestLimit {
+		limit = maxDrugSuggestLimit
+	}
+	entries, err := uc.searchRepo.ListDrugNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("SuggestDrugs: keyword=%s, limit=%d", keyword, limit)
+	return SuggestDrugNames(keyword, filterDrugNames(entries, includePrescription), limit), nil
 }
 
-// 获取热门关键词（简化版）
-func (uc *DrugService) GetHotKeywords(ctx context.Context, limit int) ([]string, error) {
-	uc.log.WithContext(ctx).Infof("GetHotKeywords: limit=%d", limit)
-	// 返回一些默认的热门关键词
-	keywords := []string{"感冒药", "止痛药", "消炎药", "维生素", "降压药", "胃药", "咳嗽药", "退烧药"}
-	if limit > 0 && limit < len(keywords) {
-		keywords = keywords[:limit]
+// 不含处方药时剔除处方药，与搜索的默认过滤一致
+func filterDrugNames(entries []*DrugNameEntry, includePrescription bool) []*DrugNameEntry {
+	if includePrescription {
+		return entries
+	}
+	filtered := make([]*DrugNameEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsPrescription {
+			filtered = append(filtered, entry)
+		}
 	}
-	return keywords, nil
+	return filtered
 }
@@ -0,0 +1,339 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 已同步任务的保留时长，重建索引时据此补齐重建期间的变更
+const drugIndexTaskRetention = 7 * 24 * time.Hour
+
+// 药品索引同步任务，后台维护药品和库存变动时写入，同步任务消费后写入Elasticsearch
+type DrugIndexTask struct {
+	ID        int64     `json:"id"`
+	DrugID    int64     `json:"drug_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 构建索引文档所需的药品、说明书和用药指导，说明书和用药指导可能为空
+type DrugIndexSource struct {
+	Drug    *MtDrug
+	Explain *MtExplain
+	Guide   *MtGuide
+}
+
+// 重建索引结果
+type ReindexReport struct {
+	Index      string   `json:"index"`       // 新索引名
+	Indexed    int      `json:"indexed"`     // 写入的药品数
+	Skipped    int      `json:"skipped"`     // 已删除而跳过的药品数
+	CaughtUp   int      `json:"caught_up"`   // 重建期间有变更而补同步的药品数
+	OldIndices []string `json:"old_indices"` // 切换前别名指向的索引，已删除
+}
+
+// 药品索引仓储接口
+type DrugIndexRepo interface {
+	// 是否配置了Elasticsearch
+	Enabled() bool
+	ListPendingTasks(ctx context.Context, limit int) ([]*DrugIndexTask, error)
+	MarkTasksProcessed(ctx context.Context, ids []int64) error
+	PurgeProcessedTasks(ctx context.Context, before time.Time) (int64, error)
+	// 给定时间之后有变更的药品ID
+	ListChangedDrugIDs(ctx context.Context, since time.Time) ([]int64, error)
+	// 按药品ID升序分批读取，afterID 为上一批最后一个药品ID
+	ListIndexSources(ctx context.Context, afterID int64, limit int) ([]*DrugIndexSource, error)
+	GetIndexSources(ctx context.Context, drugIDs []int64) ([]*DrugIndexSource, error)
+	// index 为空时写入别名指向的当前索引
+	IndexDocuments(ctx context.Context, index string, docs []*DrugDocument) error
+	DeleteDocuments(ctx context.Context, index string, drugIDs []int64) error
+	// 按映射创建新索引并返回索引名
+	CreateIndex(ctx context.Context) (string, error)
+	// 刷新新索引后原子地将别名切换过去，返回原来指向的索引
+	SwitchAlias(ctx context.Context, index string) ([]string, error)
+	DeleteIndices(ctx context.Context, indices []string) error
+}
+
+// 药品索引用例，增量同步和全量重建
+type DrugIndexUsecase struct {
+	repo DrugIndexRepo
+	log  *log.Helper
+}
+
+// 创建药品索引用例
+func NewDrugIndexUsecase(repo DrugIndexRepo, logger log.Logger) *DrugIndexUsecase {
+	return &DrugIndexUsecase{
+		repo: repo,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 是否配置了Elasticsearch
+func (uc *DrugIndexUsecase) Enabled() bool {
+	return uc.repo.Enabled()
+}
+
+// 将给定药品同步到当前索引，已删除或不存在的药品从索引中移除
+func (uc *DrugIndexUsecase) SyncDrugs(ctx context.Context, drugIDs []int64) error {
+	ids := uniqueDrugIDs(drugIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+	sources, err := uc.repo.GetIndexSources(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("读取药品失败: %v", err)
+	}
+	docs, deleted := splitIndexSources(sources)
+	found := make(map[int64]bool, len(sources))
+	for _, source := range sources {
+		found[int64(source.Drug.Id)] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			deleted = append(deleted, id)
+		}
+	}
+
+	if len(docs) > 0 {
+		if err := uc.repo.IndexDocuments(ctx, "", docs); err != nil {
+			return fmt.Errorf("写入药品索引失败: %v", err)
+		}
+	}
+	if len(deleted) > 0 {
+		if err := uc.repo.DeleteDocuments(ctx, "", deleted); err != nil {
+			return fmt.Errorf("删除药品索引失败: %v", err)
+		}
+	}
+	return nil
+}
+
+// 消费一批同步任务，同一药品的多条任务合并同步，返回处理的任务数
+func (uc *DrugIndexUsecase) ProcessTasks(ctx context.Context, limit int) (int, error) {
+	tasks, err := uc.repo.ListPendingTasks(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("查询索引同步任务失败: %v", err)
+	}
+	if len(tasks) > 0 {
+		taskIDs := make([]int64, len(tasks))
+		drugIDs := make([]int64, len(tasks))
+		for i, task := range tasks {
+			taskIDs[i] = task.ID
+			drugIDs[i] = task.DrugID
+		}
+		if err := uc.SyncDrugs(ctx, drugIDs); err != nil {
+			return 0, err
+		}
+		if err := uc.repo.MarkTasksProcessed(ctx, taskIDs); err != nil {
+			return 0, fmt.Errorf("标记索引同步任务失败: %v", err)
+		}
+		uc.log.WithContext(ctx).Infof("药品索引增量同步: tasks=%d, drugs=%d", len(tasks), len(uniqueDrugIDs(drugIDs)))
+	}
+
+	if _, err := uc.repo.PurgeProcessedTasks(ctx, time.Now().Add(-drugIndexTaskRetention)); err != nil {
+		uc.log.WithContext(ctx).Warnf("清理索引同步任务失败: %v", err)
+	}
+	return len(tasks), nil
+}
+
+// 全量重建索引：写入新索引后切换别名，再补同步重建期间变更的药品，最后删除旧索引
+func (uc *DrugIndexUsecase) Reindex(ctx context.Context, batchSize int) (*ReindexReport, error) {
+	if !uc.repo.Enabled() {
+		return nil, ErrSearchIndexUnavailable
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	// 留出时钟偏差余量，多补同步的药品只是重复写入
+	startedAt := time.Now().Add(-time.Minute)
+
+	index, err := uc.repo.CreateIndex(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("创建索引失败: %v", err)
+	}
+	report := &ReindexReport{Index: index}
+
+	var afterID int64
+	for {
+		sources, err := uc.repo.ListIndexSources(ctx, afterID, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("读取药品失败: %v", err)
+		}
+		if len(sources) == 0 {
+			break
+		}
+		docs, deleted := splitIndexSources(sources)
+		if len(docs) > 0 {
+			if err := uc.repo.IndexDocuments(ctx, index, docs); err != nil {
+				return report, fmt.Errorf("写入索引 %s 失败: %v", index, err)
+			}
+		}
+		report.Indexed += len(docs)
+		report.Skipped += len(deleted)
+		afterID = int64(sources[len(sources)-1].Drug.Id)
+	}
+
+	report.OldIndices, err = uc.repo.SwitchAlias(ctx, index)
+	if err != nil {
+		return report, fmt.Errorf("切换索引别名失败: %v", err)
+	}
+
+	// 重建期间增量同步写入的是旧索引，切换后补写到新索引
+	changed, err := uc.repo.ListChangedDrugIDs(ctx, startedAt)
+	if err != nil {
+		return report, fmt.Errorf("查询重建期间变更的药品失败: %v", err)
+	}
+	if err := uc.SyncDrugs(ctx, changed); err != nil {
+		return report, err
+	}
+	report.CaughtUp = len(changed)
+
+	if len(report.OldIndices) > 0 {
+		if err := uc.repo.DeleteIndices(ctx, report.OldIndices); err != nil {
+			uc.log.WithContext(ctx).Warnf("删除旧索引 %v 失败: %v", report.OldIndices, err)
+		}
+	}
+	uc.log.WithContext(ctx).Infof("药品索引重建完成: %+v", report)
+	return report, nil
+}
+
+// 拆分为待写入的文档和已删除的药品ID
+func splitIndexSources(sources []*DrugIndexSource) ([]*DrugDocument, []int64) {
+	var docs []*DrugDocument
+	var deleted []int64
+	for _, source := range sources {
+		if !source.Drug.DeletedAt.IsZero() {
+			deleted = append(deleted, int64(source.Drug.Id))
+			continue
+		}
+		docs = append(docs, BuildDrugDocument(source))
+	}
+	return docs, deleted
+}
+
+// 由药品及其说明书、用药指导构建搜索文档
+// 关键词取通用名、商品名和功能主治中的短语，症状取功能主治中出现的常见症状
+// 通用名和商品名同时作为同义名，与药品名称一起生成拼音
+func BuildDrugDocument(source *DrugIndexSource) *DrugDocument {
+	doc := NewDrugDocument(source.Drug)
+	entry := NewDrugNameEntry(source.Drug, source.Explain)
+	doc.Synonyms = entry.Synonyms
+	doc.NamePinyin = entry.Pinyin
+	doc.NameInitials = entry.Initials
+	var texts, names []string
+	if source.Explain != nil {
+		texts = append(texts, source.Explain.Function)
+		names = append(names, source.Explain.CommonName, source.Explain.GoodsName)
+	}
+	if source.Guide != nil {
+		texts = append(texts, source.Guide.MajorFunction)
+	}
+	keywords, symptoms := ExtractDrugTerms(texts...)
+	doc.Keywords = mergeTerms(names, keywords)
+	doc.Symptoms = symptoms
+	return doc
+}
+
+// 常见症状词表，按词长降序匹配，避免“头痛”先于“偏头痛”命中
+var drugSymptomTerms = sortByLength([]string{
+	"感冒", "发热", "发烧", "头痛", "偏头痛", "头晕", "咳嗽", "咳痰", "痰多", "鼻塞", "流涕", "打喷嚏",
+	"咽痛", "咽喉肿痛", "咽干", "喉咙痛", "声音嘶哑", "肌肉酸痛", "关节痛", "牙痛", "痛经", "腰痛",
+	"四肢酸痛", "恶寒", "乏力", "失眠", "心悸", "胸闷", "气喘", "哮喘", "腹痛", "腹泻", "腹胀",
+	"便秘", "恶心", "呕吐", "消化不良", "食欲不振", "胃痛", "胃酸", "烧心", "口腔溃疡", "皮疹",
+	"湿疹", "瘙痒", "过敏", "荨麻疹", "痤疮", "脚气", "真菌感染", "烫伤", "扭伤", "跌打损伤",
+	"高血压", "高血脂", "糖尿病", "贫血", "上火", "口干", "口苦", "目赤", "眼干", "尿频",
+})
+
+// 功能主治中的引导词和结尾词，拆分短语时去掉
+var (
+	drugTermPrefixes = []string{"用于治疗", "适用于", "用于", "治疗", "主治", "缓解", "改善", "预防", "减轻"}
+	drugTermSuffixes = []string{"等症状", "等症", "等"}
+	drugTermSplitter = strings.NewReplacer(
+		"，", "|", "。", "|", "、", "|", "；", "|", "：", "|", "（", "|", "）", "|",
+		",", "|", ".", "|", ";", "|", ":", "|", "(", "|", ")", "|", " ", "|",
+		"引起的", "|", "所致的", "|", "导致的", "|", "及", "|", "或", "|", "伴有", "|",
+	)
+)
+
+// 从功能主治文本中提取关键词短语和症状，结果去重并按出现顺序排列
+func ExtractDrugTerms(texts ...string) (keywords []string, symptoms []string) {
+	seenSymptom := make(map[string]bool)
+	seenKeyword := make(map[string]bool)
+	for _, text := range texts {
+		var hits []string
+		for _, symptom := range drugSymptomTerms {
+			if strings.Contains(text, symptom) && !seenSymptom[symptom] && !coveredBy(symptom, hits) && !coveredBy(symptom, symptoms) {
+				seenSymptom[symptom] = true
+				hits = append(hits, symptom)
+			}
+		}
+		sort.SliceStable(hits, func(i, j int) bool {
+			return strings.Index(text, hits[i]) < strings.Index(text, hits[j])
+		})
+		symptoms = append(symptoms, hits...)
+		for _, phrase := range strings.Split(drugTermSplitter.Replace(text), "|") {
+			phrase = trimDrugTerm(phrase)
+			length := utf8.RuneCountInString(phrase)
+			if length < 2 || length > 10 || seenKeyword[phrase] || seenSymptom[phrase] {
+				continue
+			}
+			seenKeyword[phrase] = true
+			keywords = append(keywords, phrase)
+		}
+	}
+	return keywords, symptoms
+}
+
+func trimDrugTerm(phrase string) string {
+	phrase = strings.TrimSpace(phrase)
+	for _, prefix := range drugTermPrefixes {
+		if strings.HasPrefix(phrase, prefix) {
+			phrase = strings.TrimPrefix(phrase, prefix)
+			break
+		}
+	}
+	for _, suffix := range drugTermSuffixes {
+		if strings.HasSuffix(phrase, suffix) {
+			phrase = strings.TrimSuffix(phrase, suffix)
+			break
+		}
+	}
+	return strings.TrimSpace(phrase)
+}
+
+// 已命中的较长症状包含该症状时不再重复收录
+func coveredBy(term string, found []string) bool {
+	for _, f := range found {
+		if strings.Contains(f, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeTerms(groups ...[]string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, group := range groups {
+		for _, term := range group {
+			term = strings.TrimSpace(term)
+			if term == "" || seen[term] {
+				continue
+			}
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
+
+func sortByLength(terms []string) []string {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return utf8.RuneCountInString(terms[i]) > utf8.RuneCountInString(terms[j])
+	})
+	return terms
+}
@@ -0,0 +1,34 @@
+package biz
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 测试从功能主治中提取关键词和症状
+func TestExtractDrugTerms(t *testing.T) {
+	keywords, symptoms := ExtractDrugTerms(
+		"解热镇痛。用于感冒引起的头痛，发热，鼻塞，流涕，咽痛等。",
+		"清热解毒，缓解四肢酸痛；偏头痛",
+	)
+	wantKeywords := []string{"解热镇痛", "清热解毒"}
+	if !reflect.DeepEqual(keywords, wantKeywords) {
+		t.Errorf("Expected keywords %v, got %v", wantKeywords, keywords)
+	}
+	// 按出现顺序排列，偏头痛不因已有头痛而丢失
+	wantSymptoms := []string{"感冒", "头痛", "发热", "鼻塞", "流涕", "咽痛", "四肢酸痛", "偏头痛"}
+	if !reflect.DeepEqual(symptoms, wantSymptoms) {
+		t.Errorf("Expected symptoms %v, got %v", wantSymptoms, symptoms)
+	}
+
+	doc := BuildDrugDocument(&DrugIndexSource{
+		Drug:    &MtDrug{Id: 1, DrugName: "感冒灵颗粒"},
+		Explain: &MtExplain{CommonName: "感冒灵颗粒", GoodsName: "999感冒灵", Function: "用于感冒引起的头痛"},
+	})
+	if want := []string{"感冒灵颗粒", "999感冒灵"}; !reflect.DeepEqual(doc.Keywords, want) {
+		t.Errorf("Expected keywords %v, got %v", want, doc.Keywords)
+	}
+	if want := []string{"感冒", "头痛"}; !reflect.DeepEqual(doc.Symptoms, want) {
+		t.Errorf("Expected symptoms %v, got %v", want, doc.Symptoms)
+	}
+}
@@ -0,0 +1,336 @@
+package biz
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// 联想词来源
+const (
+	DrugSuggestSourceName    = "drug_name" // 药品名称
+	DrugSuggestSourceSynonym = "synonym"   // 通用名或商品名
+)
+
+// 联想词数量默认值和上限
+const (
+	defaultDrugSuggestLimit = 10
+	maxDrugSuggestLimit     = 20
+)
+
+var drugPinyinArgs = pinyin.NewArgs()
+
+// 文本的拼音全拼和首字母，汉字取不带声调的拼音（多音字取常用读音），字母数字转小写保留，其他字符忽略
+func DrugNamePinyin(text string) (full, initials string) {
+	var fullBuf, initialsBuf strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			syllables := pinyin.SinglePinyin(r, drugPinyinArgs)
+			if len(syllables) == 0 || syllables[0] == "" {
+				continue
+			}
+			fullBuf.WriteString(syllables[0])
+			initialsBuf.WriteByte(syllables[0][0])
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			fullBuf.WriteRune(r)
+			initialsBuf.WriteRune(r)
+		}
+	}
+	return fullBuf.String(), initialsBuf.String()
+}
+
+// 解析后的搜索关键词
+type DrugKeyword struct {
+	Text     string // 小写关键词，子串匹配药品名称、通用名和商品名
+	Pinyin   string // 子串匹配拼音全拼，中文关键词取其拼音以容忍同音错字，字母关键词原样使用
+	Initials string // 子串匹配拼音首字母，仅字母关键词
+}
+
+// 解析搜索关键词
+// 两个字以上的中文关键词同时按拼音匹配；两个字母以上的字母关键词按拼音全拼和首字母匹配，如 ganmao、gmkl
+func ParseDrugKeyword(keyword string) DrugKeyword {
+	kw := DrugKeyword{Text: strings.ToLower(strings.TrimSpace(keyword))}
+	compact := strings.Join(strings.Fields(kw.Text), "")
+	var han, letters, others int
+	for _, r := range compact {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			letters++
+		case r < unicode.MaxASCII && unicode.IsDigit(r):
+		default:
+			others++
+		}
+	}
+	switch {
+	case han >= 2:
+		kw.Pinyin, _ = DrugNamePinyin(compact)
+	case han == 0 && others == 0 && letters >= 2:
+		kw.Pinyin = compact
+		kw.Initials = compact
+	}
+	return kw
+}
+
+// 药品名称词典条目，用于拼音、同义名匹配，联想和纠错
+type DrugNameEntry struct {
+	DrugID         int64
+	Name           string
+	Synonyms       []string // 说明书中的通用名和商品名，已去掉与药品名称相同的
+	SalesVolume    float64
+	IsPrescription bool
+	Pinyin         []string // 药品名称和同义名的拼音全拼，与 Names 一一对应
+	Initials       []string // 药品名称和同义名的拼音首字母，与 Names 一一对应
+}
+
+// 由药品及其说明书构建词典条目，说明书可能为空
+func NewDrugNameEntry(drug *MtDrug, explain *MtExplain) *DrugNameEntry {
+	entry := &DrugNameEntry{
+		DrugID:         int64(drug.Id),
+		Name:           strings.TrimSpace(drug.DrugName),
+		SalesVolume:    float64(drug.SalesVolume),
+		IsPrescription: drug.IsPrescription,
+	}
+	if explain != nil {
+		entry.Synonyms = DrugNameSynonyms(entry.Name, explain.CommonName, explain.GoodsName)
+	}
+	for _, name := range entry.Names() {
+		full, initials := DrugNamePinyin(name)
+		entry.Pinyin = append(entry.Pinyin, full)
+		entry.Initials = append(entry.Initials, initials)
+	}
+	return entry
+}
+
+// 药品名称之外的同义名，去空白、去重
+func DrugNameSynonyms(name string, candidates ...string) []string {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(name)): true}
+	var synonyms []string
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		key := strings.ToLower(candidate)
+		if candidate == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		synonyms = append(synonyms, candidate)
+	}
+	return synonyms
+}
+
+// 药品名称在前，其后是同义名
+func (e *DrugNameEntry) Names() []string {
+	return append([]string{e.Name}, e.Synonyms...)
+}
+
+// 关键词是否命中药品名称、同义名或其拼音
+func (e *DrugNameEntry) Matches(kw DrugKeyword) bool {
+	for i, name := range e.Names() {
+		if e.matchRank(i, name, kw) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// 名称的命中程度：0为前缀命中，1为包含，-1为未命中
+func (e *DrugNameEntry) matchRank(i int, name string, kw DrugKeyword) int {
+	if kw.Text == "" {
+		return -1
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(lower, kw.Text),
+		kw.Pinyin != "" && strings.HasPrefix(e.Pinyin[i], kw.Pinyin),
+		kw.Initials != "" && strings.HasPrefix(e.Initials[i], kw.Initials):
+		return 0
+	case strings.Contains(lower, kw.Text),
+		kw.Pinyin != "" && strings.Contains(e.Pinyin[i], kw.Pinyin),
+		kw.Initials != "" && strings.Contains(e.Initials[i], kw.Initials):
+		return 1
+	}
+	return -1
+}
+
+// 命中关键词的药品ID
+func MatchDrugNames(keyword string, entries []*DrugNameEntry) []int64 {
+	kw := ParseDrugKeyword(keyword)
+	var ids []int64
+	for _, entry := range entries {
+		if entry.Matches(kw) {
+			ids = append(ids, entry.DrugID)
+		}
+	}
+	return ids
+}
+
+// 搜索联想词
+type DrugSuggestion struct {
+	Text   string `json:"text"`
+	DrugID int64  `json:"drug_id"`
+	Source string `json:"source"`
+}
+
+// 按关键词联想药品名称和同义名：前缀命中优先，其次销量高、名称短的
+// 同一名称只保留排序最靠前的一条
+func SuggestDrugNames(keyword string, entries []*DrugNameEntry, limit int) []*DrugSuggestion {
+	kw := ParseDrugKeyword(keyword)
+	type candidate struct {
+		suggestion *DrugSuggestion
+		rank       int
+		sales      float64
+	}
+	var candidates []candidate
+	for _, entry := range entries {
+		for i, name := range entry.Names() {
+			rank := entry.matchRank(i, name, kw)
+			if rank < 0 {
+				continue
+			}
+			source := DrugSuggestSourceName
+			if i > 0 {
+				source = DrugSuggestSourceSynonym
+			}
+			candidates = append(candidates, candidate{
+				suggestion: &DrugSuggestion{Text: name, DrugID: entry.DrugID, Source: source},
+				rank:       rank,
+				sales:      entry.SalesVolume,
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.sales != b.sales {
+			return a.sales > b.sales
+		}
+		la, lb := len([]rune(a.suggestion.Text)), len([]rune(b.suggestion.Text))
+		if la != lb {
+			return la < lb
+		}
+		return a.suggestion.Text < b.suggestion.Text
+	})
+
+	seen := make(map[string]bool)
+	suggestions := make([]*DrugSuggestion, 0, limit)
+	for _, c := range candidates {
+		key := strings.ToLower(c.suggestion.Text)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		suggestions = append(suggestions, c.suggestion)
+		if len(suggestions) >= limit {
+			break
+		}
+	}
+	return suggestions
+}
+
+// 搜索无结果时按编辑距离找最接近的药品名称或同义名，用于纠错后重新搜索
+// 中文关键词3个字以上才纠错，拼音4个字母以上才纠错；较长的关键词允许2处错误
+// 距离相同时取销量高、名称短的；找不到返回空
+func CorrectDrugKeyword(keyword string, entries []*DrugNameEntry) string {
+	kw := ParseDrugKeyword(keyword)
+	text := []rune(strings.Join(strings.Fields(kw.Text), ""))
+	textLimit := -1
+	if han := countHan(text); han >= 3 {
+		textLimit = 1
+		if han >= 6 {
+			textLimit = 2
+		}
+	}
+	// 中文关键词的拼音与中文同样要求3个字以上
+	pinyinLimit := -1
+	if n := len(kw.Pinyin); n >= 4 && (kw.Initials != "" || textLimit >= 0) {
+		pinyinLimit = 1
+		if n >= 8 {
+			pinyinLimit = 2
+		}
+	}
+	if textLimit < 0 && pinyinLimit < 0 {
+		return ""
+	}
+
+	var (
+		best      string
+		bestDist  = -1
+		bestSales float64
+	)
+	for _, entry := range entries {
+		for i, name := range entry.Names() {
+			dist := -1
+			if textLimit >= 0 {
+				if d := substringDistance(text, []rune(strings.ToLower(name))); d <= textLimit {
+					dist = d
+				}
+			}
+			if pinyinLimit >= 0 {
+				if d := substringDistance([]rune(kw.Pinyin), []rune(entry.Pinyin[i])); d <= pinyinLimit && (dist < 0 || d < dist) {
+					dist = d
+				}
+			}
+			// 距离为0说明已经命中，无结果是其他筛选条件导致的，不纠错
+			if dist == 0 {
+				return ""
+			}
+			if dist < 0 {
+				continue
+			}
+			better := bestDist < 0 || dist < bestDist
+			if !better && dist == bestDist {
+				if entry.SalesVolume != bestSales {
+					better = entry.SalesVolume > bestSales
+				} else if len([]rune(name)) != len([]rune(best)) {
+					better = len([]rune(name)) < len([]rune(best))
+				} else {
+					better = name < best
+				}
+			}
+			if better {
+				best, bestDist, bestSales = name, dist, entry.SalesVolume
+			}
+		}
+	}
+	return best
+}
+
+// 关键词与文本任一子串的最小编辑距离，关键词可以出现在文本任意位置
+func substringDistance(pattern, text []rune) int {
+	prev := make([]int, len(text)+1)
+	cur := make([]int, len(text)+1)
+	for i := 1; i <= len(pattern); i++ {
+		cur[0] = i
+		for j := 1; j <= len(text); j++ {
+			cost := 1
+			if pattern[i-1] == text[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j-1]+cost, prev[j]+1, cur[j-1]+1)
+		}
+		prev, cur = cur, prev
+	}
+	best := prev[0]
+	for _, d := range prev[1:] {
+		if d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func countHan(text []rune) int {
+	n := 0
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			n++
+		}
+	}
+	return n
+}
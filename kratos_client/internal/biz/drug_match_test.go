@@ -0,0 +1,67 @@
+package biz
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 测试拼音生成和关键词解析
+func TestParseDrugKeyword(t *testing.T) {
+	full, initials := DrugNamePinyin("999感冒灵（颗粒）")
+	if full != "999ganmaolingkeli" || initials != "999gmlkl" {
+		t.Errorf("Unexpected pinyin %s %s", full, initials)
+	}
+
+	cases := []struct {
+		keyword string
+		want    DrugKeyword
+	}{
+		{" GanMao ", DrugKeyword{Text: "ganmao", Pinyin: "ganmao", Initials: "ganmao"}},
+		{"感帽", DrugKeyword{Text: "感帽", Pinyin: "ganmao"}},
+		{"感", DrugKeyword{Text: "感"}},
+		{"c", DrugKeyword{Text: "c"}},
+		{"100%", DrugKeyword{Text: "100%"}},
+	}
+	for _, tc := range cases {
+		if got := ParseDrugKeyword(tc.keyword); got != tc.want {
+			t.Errorf("%q: expected %+v, got %+v", tc.keyword, tc.want, got)
+		}
+	}
+}
+
+// 测试联想排序和纠错
+func TestSuggestAndCorrectDrugNames(t *testing.T) {
+	entries := []*DrugNameEntry{
+		NewDrugNameEntry(&MtDrug{Id: 1, DrugName: "复方感冒灵片", SalesVolume: 200}, nil),
+		NewDrugNameEntry(&MtDrug{Id: 2, DrugName: "感冒灵颗粒", SalesVolume: 100}, &MtExplain{CommonName: "感冒灵颗粒", GoodsName: "999感冒灵"}),
+		NewDrugNameEntry(&MtDrug{Id: 3, DrugName: "板蓝根颗粒", SalesVolume: 50}, nil),
+	}
+	if want := []string{"999感冒灵"}; !reflect.DeepEqual(entries[1].Synonyms, want) {
+		t.Errorf("Expected synonyms %v, got %v", want, entries[1].Synonyms)
+	}
+	if ids := MatchDrugNames("gml", entries); !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", ids)
+	}
+
+	// 前缀命中优先于销量更高的包含命中
+	var texts []string
+	for _, suggestion := range SuggestDrugNames("ganmao", entries, 10) {
+		texts = append(texts, suggestion.Text)
+	}
+	if want := []string{"感冒灵颗粒", "复方感冒灵片", "999感冒灵"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("Expected suggestions %v, got %v", want, texts)
+	}
+
+	for keyword, want := range map[string]string{
+		"感冒零颗":      "",      // 同音已直接命中，无需纠错
+		"板兰根颗料":     "板蓝根颗粒", // 一处错字
+		"banlangan": "板蓝根颗粒",
+		"感冒":        "",       // 过短不纠错
+		"感冒宁":       "复方感冒灵片", // 距离相同取销量高的
+		"止咳糖浆":      "",       // 差异过大
+	} {
+		if got := CorrectDrugKeyword(keyword, entries); got != want {
+			t.Errorf("%s: expected correction %q, got %q", keyword, want, got)
+		}
+	}
+}
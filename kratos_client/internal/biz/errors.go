@@ -5,10 +5,76 @@ import "errors"
 var (
 	// ErrInsufficientInventory 库存不足错误
 	ErrInsufficientInventory = errors.New("insufficient inventory")
+
+	// ErrInvalidStockQuantity 预留、出库或释放的数量不是正数
+	ErrInvalidStockQuantity = errors.New("stock quantity must be positive")
 	
 	// ErrCartItemNotFound 购物车项目不存在错误
 	ErrCartItemNotFound = errors.New("cart item not found")
 	
 	// ErrDrugNotFound 药品不存在错误
 	ErrDrugNotFound = errors.New("drug not found")
-)
\ No newline at end of file
+
+	// ErrInvalidOrderStatusTransition 非法的订单状态流转
+	ErrInvalidOrderStatusTransition = errors.New("invalid order status transition")
+
+	// ErrOrderStatusConflict 订单状态已被并发修改
+	ErrOrderStatusConflict = errors.New("order status changed concurrently")
+
+	// ErrCouponAlreadyUsed 优惠券已被使用（含并发核销）
+	ErrCouponAlreadyUsed = errors.New("coupon already used")
+
+	// ErrIdempotencyKeyReused 幂等键已用于参数不同的请求
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different request")
+
+	// ErrIdempotentRequestInProgress 相同幂等键的首次请求仍在处理中
+	ErrIdempotentRequestInProgress = errors.New("idempotent request in progress")
+
+	// ErrRefundQuantityExceeded 退款数量超过订单项可退数量
+	ErrRefundQuantityExceeded = errors.New("refund quantity exceeded")
+
+	// ErrRefundStatusConflict 退款单状态已被并发修改
+	ErrRefundStatusConflict = errors.New("refund status changed concurrently")
+
+	// ErrPaymentGatewayUnavailable 支付方式或支付渠道未启用
+	ErrPaymentGatewayUnavailable = errors.New("payment gateway unavailable")
+
+	// ErrInvalidGatewayNotify 支付渠道通知签名校验失败或内容不合法
+	ErrInvalidGatewayNotify = errors.New("invalid gateway notify")
+
+	// ErrConsultationStatusConflict 问诊状态已被并发修改
+	ErrConsultationStatusConflict = errors.New("consultation status changed concurrently")
+
+	// ErrSlotUnavailable 号源时段已约满或已停诊
+	ErrSlotUnavailable = errors.New("schedule slot unavailable")
+
+	// ErrAppointmentDuplicated 同一患者重复预约同一时段
+	ErrAppointmentDuplicated = errors.New("appointment duplicated")
+
+	// ErrAppointmentStatusConflict 预约状态已被并发修改
+	ErrAppointmentStatusConflict = errors.New("appointment status changed concurrently")
+
+	// ErrDoctorLoginFailed 医生账号不存在或密码错误
+	ErrDoctorLoginFailed = errors.New("doctor account or password incorrect")
+
+	// ErrInvalidPrescriptionStatusTransition 非法的处方状态流转
+	ErrInvalidPrescriptionStatusTransition = errors.New("invalid prescription status transition")
+
+	// ErrPrescriptionStatusConflict 处方状态已被并发修改
+	ErrPrescriptionStatusConflict = errors.New("prescription status changed concurrently")
+
+	// ErrPharmacistLoginFailed 药师账号不存在、已停用或密码错误
+	ErrPharmacistLoginFailed = errors.New("pharmacist account or password incorrect")
+
+	// ErrLoginLocked 登录失败次数过多，账号暂时锁定
+	ErrLoginLocked = errors.New("too many failed login attempts")
+
+	// ErrPrescriptionRequired 处方药下单缺少有效处方或处方未覆盖所购药品
+	ErrPrescriptionRequired = errors.New("valid prescription required")
+
+	// ErrDrugCheckBlocked 药品之间存在严重相互作用或对患者所属人群禁用
+	ErrDrugCheckBlocked = errors.New("drug interaction or contraindication blocked")
+
+	// ErrSearchIndexUnavailable 未配置Elasticsearch或连接失败，无法维护搜索索引
+	ErrSearchIndexUnavailable = errors.New("search index unavailable")
+)
@@ -0,0 +1,239 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 热门搜索统计窗口，按小时滑动
+type HotSearchWindow string
+
+const (
+	HotSearchWindowDay  HotSearchWindow = "day"  // 最近24小时
+	HotSearchWindowWeek HotSearchWindow = "week" // 最近7天
+)
+
+const (
+	// 超过该长度的搜索词不计入热搜，多为粘贴的整段文字
+	HotSearchTermMaxLen = 20
+	// 排行时取出的候选词数量，屏蔽和分类后再截取
+	HotSearchCandidateSize = 200
+	// 按小时统计的明细保留时长，比最长窗口多一天
+	HotSearchRetention = 8 * 24 * time.Hour
+
+	defaultHotSearchLimit = 10
+	maxHotSearchLimit     = 50
+)
+
+// 窗口时长
+func (w HotSearchWindow) Span() time.Duration {
+	if w == HotSearchWindowWeek {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// 热度半衰期，搜索每过一个半衰期权重减半
+func (w HotSearchWindow) HalfLife() time.Duration {
+	if w == HotSearchWindowWeek {
+		return 48 * time.Hour
+	}
+	return 6 * time.Hour
+}
+
+// 窗口内的小时统计桶，从当前小时往前排列
+func (w HotSearchWindow) Buckets(now time.Time) []time.Time {
+	current := HotSearchBucket(now)
+	n := int(w.Span() / time.Hour)
+	buckets := make([]time.Time, 0, n)
+	for i := 0; i < n; i++ {
+		buckets = append(buckets, current.Add(-time.Duration(i)*time.Hour))
+	}
+	return buckets
+}
+
+// 统计桶的热度权重，当前小时为1，按半衰期指数衰减
+func (w HotSearchWindow) Weight(bucket, now time.Time) float64 {
+	age := HotSearchBucket(now).Sub(bucket)
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, age.Hours()/w.HalfLife().Hours())
+}
+
+// 搜索时间所在的小时统计桶
+func HotSearchBucket(t time.Time) time.Time {
+	return t.Truncate(time.Hour)
+}
+
+// 按热度降序、次数降序、内容升序排列
+func SortHotItems(items []*HotItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Content < items[j].Content
+	})
+}
+
+// 热门搜索结果
+type HotSearch struct {
+	Window    HotSearchWindow
+	CityID    int32 // 0表示全国榜单
+	Keywords  []*HotItem
+	Symptoms  []*HotItem
+	Questions []*HotItem
+}
+
+// 热门搜索仓储接口，配置Redis时使用有序集合，否则使用数据库按小时汇总
+type HotSearchRepo interface {
+	// 记录一次搜索，同时计入全国和所在城市
+	Record(ctx context.Context, term string, cityID int32, at time.Time) error
+	// 按窗口内的衰减热度取排名靠前的搜索词，cityID为0时取全国
+	Top(ctx context.Context, window HotSearchWindow, cityID int32, now time.Time, limit int) ([]*HotItem, error)
+	// 管理后台维护的屏蔽词
+	ListBlockedTerms(ctx context.Context) ([]string, error)
+}
+
+type HotSearchUsecase struct {
+	repo     HotSearchRepo
+	userRepo UserRepo
+	log      *log.Helper
+}
+
+func NewHotSearchUsecase(repo HotSearchRepo, userRepo UserRepo, logger log.Logger) *HotSearchUsecase {
+	return &HotSearchUsecase{repo: repo, userRepo: userRepo, log: log.NewHelper(logger)}
+}
+
+// 确定热搜所属城市：请求指定的城市优先，其次是登录用户选择的城市
+func (uc *HotSearchUsecase) ResolveCity(ctx context.Context, userID int32, cityID int32) int32 {
+	if cityID > 0 || userID <= 0 {
+		return cityID
+	}
+	selected, err := uc.userRepo.GetCityID(ctx, userID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("查询用户所选城市失败: userId=%d, error=%v", userID, err)
+		return 0
+	}
+	return selected
+}
+
+// 记录一次搜索，空词和过长的词不计入
+func (uc *HotSearchUsecase) Record(ctx context.Context, keyword string, cityID int32) error {
+	term := normalizeHotSearchTerm(keyword)
+	if term == "" {
+		return nil
+	}
+	return uc.repo.Record(ctx, term, cityID, time.Now())
+}
+
+// 获取热门搜索：关键词和问句分开排行，症状由搜索词中出现的常见症状汇总
+// 城市榜单还没有数据时返回全国榜单
+func (uc *HotSearchUsecase) GetHotSearch(ctx context.Context, window HotSearchWindow, cityID int32, limit int) (*HotSearch, error) {
+	if window == "" {
+		window = HotSearchWindowDay
+	}
+	if window != HotSearchWindowDay && window != HotSearchWindowWeek {
+		return nil, fmt.Errorf("不支持的统计窗口: %s", window)
+	}
+	if limit <= 0 {
+		limit = defaultHotSearchLimit
+	}
+	if limit > maxHotSearchLimit {
+		limit = maxHotSearchLimit
+	}
+
+	now := time.Now()
+	candidates, err := uc.repo.Top(ctx, window, cityID, now, HotSearchCandidateSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 && cityID > 0 {
+		cityID = 0
+		if candidates, err = uc.repo.Top(ctx, window, 0, now, HotSearchCandidateSize); err != nil {
+			return nil, err
+		}
+	}
+	blocked, err := uc.repo.ListBlockedTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("GetHotSearch: window=%s, cityId=%d, candidates=%d", window, cityID, len(candidates))
+
+	result := &HotSearch{Window: window, CityID: cityID}
+	symptoms := make(map[string]*HotItem)
+	for _, item := range candidates {
+		if isBlockedHotSearchTerm(item.Content, blocked) {
+			continue
+		}
+		if isHotSearchQuestion(item.Content) {
+			result.Questions = append(result.Questions, item)
+		} else {
+			result.Keywords = append(result.Keywords, item)
+		}
+		_, hits := ExtractDrugTerms(item.Content)
+		for _, symptom := range hits {
+			if symptoms[symptom] == nil {
+				symptoms[symptom] = &HotItem{Content: symptom}
+				result.Symptoms = append(result.Symptoms, symptoms[symptom])
+			}
+			symptoms[symptom].Count += item.Count
+			symptoms[symptom].Score += item.Score
+		}
+	}
+	SortHotItems(result.Symptoms)
+
+	result.Keywords = limitHotItems(result.Keywords, limit)
+	result.Symptoms = limitHotItems(result.Symptoms, limit)
+	result.Questions = limitHotItems(result.Questions, limit)
+	return result, nil
+}
+
+// 搜索词归一化：合并空白并转小写，过长的返回空
+func normalizeHotSearchTerm(keyword string) string {
+	term := strings.ToLower(strings.Join(strings.Fields(keyword), " "))
+	if utf8.RuneCountInString(term) > HotSearchTermMaxLen {
+		return ""
+	}
+	return term
+}
+
+// 包含任一屏蔽词的搜索词不展示
+func isBlockedHotSearchTerm(term string, blocked []string) bool {
+	for _, word := range blocked {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" && strings.Contains(term, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// 问句形式的搜索词
+var hotSearchQuestionMarkers = []string{"?", "？", "吗", "怎么", "怎样", "什么", "哪些", "哪种", "如何", "能不能", "可以吃", "为什么"}
+
+func isHotSearchQuestion(term string) bool {
+	for _, marker := range hotSearchQuestionMarkers {
+		if strings.Contains(term, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func limitHotItems(items []*HotItem, limit int) []*HotItem {
+	if len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
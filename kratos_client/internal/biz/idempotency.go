@@ -0,0 +1,124 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 幂等作用域，同一用户在不同接口上可使用相同的幂等键
+const (
+	IdempotencyScopeCreateOrder        = "create_order"
+	IdempotencyScopeCreatePayment      = "create_payment"
+	IdempotencyScopeCreateConsultation = "create_consultation"
+)
+
+// 幂等键最大长度
+const MaxIdempotencyKeyLength = 64
+
+// 幂等请求处理状态
+const (
+	IdempotencyProcessing = "processing" // 首次请求处理中
+	IdempotencyCompleted  = "completed"  // 已处理完成，可直接返回结果
+)
+
+// 幂等记录
+type IdempotencyRecord struct {
+	Scope       string    `json:"scope"`
+	UserID      int64     `json:"user_id"`
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"` // 请求参数摘要，防止同一个键被用于不同的请求
+	Status      string    `json:"status"`
+	ResourceID  string    `json:"resource_id"` // 首次请求创建的资源编号，如订单号
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// 幂等记录仓储接口，记录在配置的有效期后自动失效
+type IdempotencyRepo interface {
+	// 占用幂等键，占用成功返回nil；键已被占用时返回已有记录
+	Acquire(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+	// 标记处理完成并保存结果
+	Complete(ctx context.Context, scope string, userID int64, key, resourceID string) error
+	// 处理失败时释放幂等键，允许客户端用同一个键重试
+	Release(ctx context.Context, scope string, userID int64, key string) error
+}
+
+// 幂等用例
+type IdempotencyUsecase struct {
+	repo IdempotencyRepo
+	log  *log.Helper
+}
+
+// 创建幂等用例
+func NewIdempotencyUsecase(repo IdempotencyRepo, logger log.Logger) *IdempotencyUsecase {
+	return &IdempotencyUsecase{
+		repo: repo,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 按幂等键执行fn，fn返回创建的资源编号；重复的键直接返回首次结果，replayed为true
+// key为空时不做幂等控制
+func (uc *IdempotencyUsecase) Execute(ctx context.Context, scope string, userID int64, key, fingerprint string,
+	fn func(ctx context.Context) (string, error)) (resourceID string, replayed bool, err error) {
+	if key == "" {
+		resourceID, err = fn(ctx)
+		return resourceID, false, err
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return "", false, fmt.Errorf("幂等键长度不能超过%d", MaxIdempotencyKeyLength)
+	}
+
+	existing, err := uc.repo.Acquire(ctx, &IdempotencyRecord{
+		Scope:       scope,
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      IdempotencyProcessing,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		uc.log.Errorf("占用幂等键失败: scope=%s, userID=%d, key=%s, error=%v", scope, userID, key, err)
+		return "", false, fmt.Errorf("幂等校验失败: %v", err)
+	}
+
+	if existing != nil {
+		if existing.Fingerprint != fingerprint {
+			return "", false, fmt.Errorf("%w: key=%s", ErrIdempotencyKeyReused, key)
+		}
+		if existing.Status != IdempotencyCompleted {
+			return "", false, fmt.Errorf("%w: key=%s", ErrIdempotentRequestInProgress, key)
+		}
+		uc.log.Infof("重复请求直接返回首次结果: scope=%s, userID=%d, key=%s, resourceID=%s", scope, userID, key, existing.ResourceID)
+		return existing.ResourceID, true, nil
+	}
+
+	resourceID, err = fn(ctx)
+	if err != nil {
+		if releaseErr := uc.repo.Release(ctx, scope, userID, key); releaseErr != nil {
+			uc.log.Errorf("释放幂等键失败: scope=%s, userID=%d, key=%s, error=%v", scope, userID, key, releaseErr)
+		}
+		return "", false, err
+	}
+
+	// 业务已成功，保存结果失败只记录日志，重试时会得到处理中的提示直到记录过期
+	if err := uc.repo.Complete(ctx, scope, userID, key, resourceID); err != nil {
+		uc.log.Errorf("保存幂等结果失败: scope=%s, userID=%d, key=%s, resourceID=%s, error=%v", scope, userID, key, resourceID, err)
+	}
+	return resourceID, false, nil
+}
+
+// 计算请求参数摘要
+func RequestFingerprint(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,239 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 药物相互作用严重程度，严重的相互作用拦截，其余仅提示
+const (
+	InteractionSeverityMinor    = "minor"    // 轻微
+	InteractionSeverityModerate = "moderate" // 中度
+	InteractionSeverityMajor    = "major"    // 严重
+)
+
+// 禁忌人群
+const (
+	PopulationPregnancy = "pregnancy" // 孕妇
+	PopulationLactation = "lactation" // 哺乳期
+	PopulationChild     = "child"     // 儿童
+	PopulationElderly   = "elderly"   // 老人
+)
+
+// 禁忌级别，禁用拦截，慎用仅提示
+const (
+	ContraindicationCaution   = "caution"   // 慎用
+	ContraindicationForbidden = "forbidden" // 禁用
+)
+
+// 用药提示类型
+const (
+	DrugWarningInteraction      = "interaction"      // 药物相互作用
+	DrugWarningContraindication = "contraindication" // 人群禁忌
+)
+
+var populationText = map[string]string{
+	PopulationPregnancy: "孕妇",
+	PopulationLactation: "哺乳期",
+	PopulationChild:     "儿童",
+	PopulationElderly:   "老人",
+}
+
+// 药物相互作用，DrugAID 小于 DrugBID
+type DrugInteraction struct {
+	ID          int64  `json:"id"`
+	DrugAID     int64  `json:"drug_a_id"`
+	DrugBID     int64  `json:"drug_b_id"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Advice      string `json:"advice"`
+}
+
+// 药品的人群禁忌
+type DrugContraindication struct {
+	ID          int64  `json:"id"`
+	DrugID      int64  `json:"drug_id"`
+	Population  string `json:"population"`
+	Level       string `json:"level"`
+	Description string `json:"description"`
+}
+
+// 用药提示，Blocking 为 true 时不允许继续开方或下单
+type DrugWarning struct {
+	Type        string   `json:"type"`
+	Level       string   `json:"level"`
+	Blocking    bool     `json:"blocking"`
+	DrugIDs     []int64  `json:"drug_ids"`
+	DrugNames   []string `json:"drug_names"`
+	Population  string   `json:"population"`
+	Description string   `json:"description"`
+	Advice      string   `json:"advice"`
+}
+
+// 用药检查结果
+type DrugCheckResult struct {
+	Warnings []*DrugWarning `json:"warnings"`
+	Blocked  bool           `json:"blocked"`
+}
+
+// 存在拦截级别的提示时返回 ErrDrugCheckBlocked
+func (r *DrugCheckResult) Err() error {
+	for _, warning := range r.Warnings {
+		if warning.Blocking {
+			return fmt.Errorf("%w: %s", ErrDrugCheckBlocked, warning.Message())
+		}
+	}
+	return nil
+}
+
+// 提示文案
+func (w *DrugWarning) Message() string {
+	names := strings.Join(w.DrugNames, "与")
+	if w.Type == DrugWarningContraindication {
+		action := "慎用"
+		if w.Level == ContraindicationForbidden {
+			action = "禁用"
+		}
+		return fmt.Sprintf("%s%s%s %s", populationText[w.Population], action, names, w.Description)
+	}
+	return fmt.Sprintf("%s存在相互作用 %s", names, w.Description)
+}
+
+// 用药知识库仓储接口
+type InteractionRepo interface {
+	// 查询给定药品两两之间的相互作用
+	ListInteractions(ctx context.Context, drugIDs []int64) ([]*DrugInteraction, error)
+	// 查询给定药品对给定人群的禁忌
+	ListContraindications(ctx context.Context, drugIDs []int64, populations []string) ([]*DrugContraindication, error)
+}
+
+// 用药检查用例，在购物车、处方和订单上检查药物相互作用和人群禁忌
+type InteractionUsecase struct {
+	repo     InteractionRepo
+	drugRepo DrugRepo
+	log      *log.Helper
+}
+
+// 创建用药检查用例
+func NewInteractionUsecase(repo InteractionRepo, drugRepo DrugRepo, logger log.Logger) *InteractionUsecase {
+	return &InteractionUsecase{
+		repo:     repo,
+		drugRepo: drugRepo,
+		log:      log.NewHelper(logger),
+	}
+}
+
+// 校验并去重人群
+func NormalizePopulations(populations []string) ([]string, error) {
+	seen := make(map[string]bool, len(populations))
+	result := make([]string, 0, len(populations))
+	for _, population := range populations {
+		population = strings.TrimSpace(population)
+		if population == "" || seen[population] {
+			continue
+		}
+		if _, ok := populationText[population]; !ok {
+			return nil, fmt.Errorf("不支持的人群: %s", population)
+		}
+		seen[population] = true
+		result = append(result, population)
+	}
+	return result, nil
+}
+
+// 检查药品之间的相互作用以及对患者所属人群的禁忌
+func (uc *InteractionUsecase) Check(ctx context.Context, drugIDs []int64, populations []string) (*DrugCheckResult, error) {
+	populations, err := NormalizePopulations(populations)
+	if err != nil {
+		return nil, err
+	}
+	ids := uniqueDrugIDs(drugIDs)
+	result := &DrugCheckResult{Warnings: []*DrugWarning{}}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var interactions []*DrugInteraction
+	if len(ids) > 1 {
+		interactions, err = uc.repo.ListInteractions(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("查询药物相互作用失败: %v", err)
+		}
+	}
+	var contraindications []*DrugContraindication
+	if len(populations) > 0 {
+		contraindications, err = uc.repo.ListContraindications(ctx, ids, populations)
+		if err != nil {
+			return nil, fmt.Errorf("查询用药禁忌失败: %v", err)
+		}
+	}
+
+	names := make(map[int64]string)
+	drugName := func(id int64) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		name := fmt.Sprintf("药品%d", id)
+		if drug, err := uc.drugRepo.GetDrug(ctx, int32(id)); err == nil && drug != nil {
+			name = drug.DrugName
+		}
+		names[id] = name
+		return name
+	}
+
+	for _, interaction := range interactions {
+		result.Warnings = append(result.Warnings, &DrugWarning{
+			Type:        DrugWarningInteraction,
+			Level:       interaction.Severity,
+			Blocking:    interaction.Severity == InteractionSeverityMajor,
+			DrugIDs:     []int64{interaction.DrugAID, interaction.DrugBID},
+			DrugNames:   []string{drugName(interaction.DrugAID), drugName(interaction.DrugBID)},
+			Description: interaction.Description,
+			Advice:      interaction.Advice,
+		})
+	}
+	for _, contraindication := range contraindications {
+		result.Warnings = append(result.Warnings, &DrugWarning{
+			Type:        DrugWarningContraindication,
+			Level:       contraindication.Level,
+			Blocking:    contraindication.Level == ContraindicationForbidden,
+			DrugIDs:     []int64{contraindication.DrugID},
+			DrugNames:   []string{drugName(contraindication.DrugID)},
+			Population:  contraindication.Population,
+			Description: contraindication.Description,
+		})
+	}
+
+	// 拦截的提示排在前面
+	sort.SliceStable(result.Warnings, func(i, j int) bool {
+		return result.Warnings[i].Blocking && !result.Warnings[j].Blocking
+	})
+	for _, warning := range result.Warnings {
+		if warning.Blocking {
+			result.Blocked = true
+			break
+		}
+	}
+	if len(result.Warnings) > 0 {
+		uc.log.WithContext(ctx).Infof("用药检查: drugIDs=%v, populations=%v, warnings=%d, blocked=%v", ids, populations, len(result.Warnings), result.Blocked)
+	}
+	return result, nil
+}
+
+func uniqueDrugIDs(drugIDs []int64) []int64 {
+	seen := make(map[int64]bool, len(drugIDs))
+	ids := make([]int64, 0, len(drugIDs))
+	for _, id := range drugIDs {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,95 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 库存流水类型
+const (
+	StockMovementReserve = "reserve" // 下单预留
+	StockMovementCommit  = "commit"  // 支付出库
+	StockMovementRelease = "release" // 取消释放
+	StockMovementAdjust  = "adjust"  // 盘点调整
+	StockMovementRestock = "restock" // 退款入库
+)
+
+// 库存流水模型，只追加不修改
+type MtStockMovement struct {
+	ID            int64     `gorm:"column:id;type:bigint;primaryKey;autoIncrement;" json:"id"`
+	DrugID        int64     `gorm:"column:drug_id;type:bigint;comment:药品ID;not null;" json:"drug_id"`
+	DrugStoreID   int32     `gorm:"column:drug_store_id;type:int;comment:药店ID;not null;" json:"drug_store_id"`
+	MovementType  string    `gorm:"column:movement_type;type:varchar(20);comment:流水类型;not null;" json:"movement_type"`
+	QuantityDiff  int64     `gorm:"column:quantity_diff;type:bigint;comment:在库数量变化;not null;default:0;" json:"quantity_diff"`
+	ReservedDiff  int64     `gorm:"column:reserved_diff;type:bigint;comment:预留数量变化;not null;default:0;" json:"reserved_diff"`
+	QuantityAfter int64     `gorm:"column:quantity_after;type:bigint;comment:变动后在库数量;not null;" json:"quantity_after"`
+	ReservedAfter int64     `gorm:"column:reserved_after;type:bigint;comment:变动后预留数量;not null;" json:"reserved_after"`
+	RefNo         string    `gorm:"column:ref_no;type:varchar(50);comment:关联单号;" json:"ref_no"`
+	Remark        string    `gorm:"column:remark;type:varchar(200);comment:备注;" json:"remark"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:datetime(3);comment:发生时间;not null;" json:"created_at"`
+}
+
+func (m *MtStockMovement) TableName() string {
+	return "mt_stock_movement"
+}
+
+// 可售库存 = 在库数量 - 已预留数量
+func (m *MtDrugInventory) Available() int64 {
+	return m.Quantity - m.ReservedQty
+}
+
+// 库存用例
+type InventoryUsecase struct {
+	repo      DrugInventoryRepo
+	drugRepo  DrugRepo
+	alertRepo InventoryAlertRepo
+	sink      InventoryAlertSink
+	log       *log.Helper
+}
+
+// 创建库存用例
+func NewInventoryUsecase(repo DrugInventoryRepo, drugRepo DrugRepo, alertRepo InventoryAlertRepo, sink InventoryAlertSink, logger log.Logger) *InventoryUsecase {
+	return &InventoryUsecase{
+		repo:      repo,
+		drugRepo:  drugRepo,
+		alertRepo: alertRepo,
+		sink:      sink,
+		log:       log.NewHelper(logger),
+	}
+}
+
+// 查询药店库存
+func (uc *InventoryUsecase) GetInventory(ctx context.Context, drugID int64, drugStoreID int32) (*MtDrugInventory, error) {
+	inventory, err := uc.repo.GetInventory(ctx, drugID, drugStoreID)
+	if err != nil {
+		uc.log.Errorf("查询库存失败: drugID=%d, storeID=%d, error=%v", drugID, drugStoreID, err)
+		return nil, err
+	}
+	if inventory == nil {
+		return nil, fmt.Errorf("库存记录不存在: drugID=%d, storeID=%d", drugID, drugStoreID)
+	}
+	return inventory, nil
+}
+
+// 盘点调整在库数量，不能低于已预留数量
+func (uc *InventoryUsecase) UpdateInventory(ctx context.Context, drugID int64, drugStoreID int32, quantity int64, remark string) (*MtDrugInventory, error) {
+	if quantity < 0 {
+		return nil, fmt.Errorf("库存数量不能为负数: %d", quantity)
+	}
+	if remark == "" {
+		remark = "盘点调整"
+	}
+
+	inventory, err := uc.repo.AdjustInventory(ctx, drugID, drugStoreID, quantity, remark)
+	if err != nil {
+		uc.log.Errorf("调整库存失败: drugID=%d, storeID=%d, quantity=%d, error=%v", drugID, drugStoreID, quantity, err)
+		return nil, err
+	}
+
+	uc.log.Infof("调整库存成功: drugID=%d, storeID=%d, quantity=%d, reserved=%d", drugID, drugStoreID, inventory.Quantity, inventory.ReservedQty)
+	uc.checkStockLevel(ctx, inventory)
+	return inventory, nil
+}
@@ -0,0 +1,132 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// 药店库存状态
+const (
+	InventoryStatusNormal     InventoryStatus = 0 // 正常
+	InventoryStatusLowStock   InventoryStatus = 1 // 低于预警阈值
+	InventoryStatusOutOfStock InventoryStatus = 2 // 无可售库存
+)
+
+// 库存告警处理状态
+const (
+	InventoryAlertOpen         = 0 // 待处理
+	InventoryAlertAcknowledged = 1 // 已确认
+)
+
+// 库存告警模型
+type MtInventoryAlert struct {
+	ID             int64           `gorm:"column:id;type:bigint;primaryKey;autoIncrement;" json:"id"`
+	InventoryID    int64           `gorm:"column:inventory_id;type:bigint;comment:药店库存ID;not null;" json:"inventory_id"`
+	DrugID         int64           `gorm:"column:drug_id;type:bigint;comment:药品ID;not null;" json:"drug_id"`
+	DrugName       string          `gorm:"column:drug_name;type:varchar(50);comment:药品名称;" json:"drug_name"`
+	DrugStoreID    int32           `gorm:"column:drug_store_id;type:int;comment:药店ID;not null;" json:"drug_store_id"`
+	Level          InventoryStatus `gorm:"column:level;type:tinyint;comment:告警级别 1-库存不足 2-缺货;not null;" json:"level"`
+	AvailableQty   int64           `gorm:"column:available_qty;type:bigint;comment:触发时可售数量;not null;" json:"available_qty"`
+	AlertThreshold int64           `gorm:"column:alert_threshold;type:bigint;comment:预警阈值;not null;" json:"alert_threshold"`
+	Status         int32           `gorm:"column:status;type:tinyint;comment:处理状态 0-待处理 1-已确认;not null;default:0;" json:"status"`
+	AckBy          string          `gorm:"column:ack_by;type:varchar(50);comment:确认人;" json:"ack_by"`
+	AckAt          *time.Time      `gorm:"column:ack_at;type:datetime(3);comment:确认时间;" json:"ack_at"`
+	CreatedAt      time.Time       `gorm:"column:created_at;type:datetime(3);comment:告警时间;not null;" json:"created_at"`
+}
+
+func (m *MtInventoryAlert) TableName() string {
+	return "mt_inventory_alert"
+}
+
+// 库存告警仓储接口
+type InventoryAlertRepo interface {
+	// 以当前状态为条件更新库存状态，返回是否更新成功；alert非空时同一事务内写入告警
+	ChangeInventoryStatus(ctx context.Context, inventoryID int64, from, to InventoryStatus, alert *MtInventoryAlert) (bool, error)
+}
+
+// 库存告警通知渠道
+type InventoryAlertSink interface {
+	Notify(ctx context.Context, alert *MtInventoryAlert) error
+}
+
+// 根据可售数量和预警阈值计算库存状态
+func StockLevel(inventory *MtDrugInventory) InventoryStatus {
+	available := inventory.Available()
+	switch {
+	case available <= 0:
+		return InventoryStatusOutOfStock
+	case available < inventory.AlertThreshold:
+		return InventoryStatusLowStock
+	default:
+		return InventoryStatusNormal
+	}
+}
+
+// 库存告警级别描述
+func InventoryStatusText(status InventoryStatus) string {
+	switch status {
+	case InventoryStatusLowStock:
+		return "库存不足"
+	case InventoryStatusOutOfStock:
+		return "缺货"
+	default:
+		return "正常"
+	}
+}
+
+// 检查药品各药店库存水位：恶化时抬升库存状态、记录告警并通知，恢复时回落状态
+func (uc *InventoryUsecase) CheckStockAlerts(ctx context.Context, drugIDs ...int64) {
+	for _, drugID := range drugIDs {
+		inventories, err := uc.repo.ListInventoriesByDrug(ctx, drugID)
+		if err != nil {
+			uc.log.Errorf("查询药品库存失败: drugID=%d, error=%v", drugID, err)
+			continue
+		}
+		for _, inventory := range inventories {
+			uc.checkStockLevel(ctx, inventory)
+		}
+	}
+}
+
+func (uc *InventoryUsecase) checkStockLevel(ctx context.Context, inventory *MtDrugInventory) {
+	level := StockLevel(inventory)
+	if level == inventory.Status {
+		return
+	}
+
+	// 库存恢复只回落状态，不产生告警
+	var alert *MtInventoryAlert
+	if level > inventory.Status {
+		drugName := ""
+		if drug, err := uc.drugRepo.GetDrug(ctx, int32(inventory.DrugID)); err == nil && drug != nil {
+			drugName = drug.DrugName
+		}
+		alert = &MtInventoryAlert{
+			InventoryID:    inventory.ID,
+			DrugID:         inventory.DrugID,
+			DrugName:       drugName,
+			DrugStoreID:    inventory.DrugStoreID,
+			Level:          level,
+			AvailableQty:   inventory.Available(),
+			AlertThreshold: inventory.AlertThreshold,
+			Status:         InventoryAlertOpen,
+			CreatedAt:      time.Now(),
+		}
+	}
+
+	// 并发请求只有一个能完成状态切换，保证同一次跌破只告警一次
+	changed, err := uc.alertRepo.ChangeInventoryStatus(ctx, inventory.ID, inventory.Status, level, alert)
+	if err != nil {
+		uc.log.Errorf("更新库存状态失败: inventoryID=%d, error=%v", inventory.ID, err)
+		return
+	}
+	if !changed || alert == nil {
+		return
+	}
+
+	uc.log.Warnf("库存告警: drugID=%d, storeID=%d, level=%s, available=%d, threshold=%d",
+		alert.DrugID, alert.DrugStoreID, InventoryStatusText(level), alert.AvailableQty, alert.AlertThreshold)
+	if err := uc.sink.Notify(ctx, alert); err != nil {
+		uc.log.Errorf("发送库存告警失败: alertID=%d, error=%v", alert.ID, err)
+	}
+}
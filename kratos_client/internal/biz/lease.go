@@ -0,0 +1,14 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// 任务租约仓储接口，多副本部署时保证同一后台任务同一时刻只有一个实例在执行
+type LeaseRepo interface {
+	// 尝试获取或续期租约，租约被其他持有者占用且未过期时返回false
+	TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// 主动释放租约，仅当前持有者可释放
+	Release(ctx context.Context, name, holder string) error
+}
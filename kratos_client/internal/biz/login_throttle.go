@@ -0,0 +1,72 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 登录失败计数的作用域，医生和药师账号分别计数
+const (
+	LoginScopeDoctor     = "doctor"
+	LoginScopePharmacist = "pharmacist"
+)
+
+const (
+	loginMaxFailures = 5                // 窗口期内允许的失败次数，达到后锁定
+	loginFailWindow  = 15 * time.Minute // 自首次失败起计算，到期后计数清零、解除锁定
+)
+
+// 登录失败计数仓储，计数在窗口期后自动清零
+type LoginAttemptRepo interface {
+	// 窗口期内的失败次数
+	Failures(ctx context.Context, scope, account string) (int64, error)
+	// 记录一次失败，返回窗口期内的失败次数
+	RecordFailure(ctx context.Context, scope, account string, window time.Duration) (int64, error)
+	// 登录成功后清零
+	Reset(ctx context.Context, scope, account string) error
+}
+
+// 登录限流，同一账号连续失败达到上限后在窗口期内拒绝登录，锁定期间不再校验密码
+type LoginThrottle struct {
+	repo LoginAttemptRepo
+	log  *log.Helper
+}
+
+// 创建登录限流
+func NewLoginThrottle(repo LoginAttemptRepo, logger log.Logger) *LoginThrottle {
+	return &LoginThrottle{repo: repo, log: log.NewHelper(logger)}
+}
+
+// 账号已锁定时返回 ErrLoginLocked；计数查询失败时放行，避免计数存储故障导致无法登录
+func (t *LoginThrottle) Check(ctx context.Context, scope, account string) error {
+	failures, err := t.repo.Failures(ctx, scope, account)
+	if err != nil {
+		t.log.WithContext(ctx).Errorf("查询登录失败次数失败: scope=%s, account=%s, error=%v", scope, account, err)
+		return nil
+	}
+	if failures >= loginMaxFailures {
+		return ErrLoginLocked
+	}
+	return nil
+}
+
+// 记录一次登录失败
+func (t *LoginThrottle) Fail(ctx context.Context, scope, account string) {
+	failures, err := t.repo.RecordFailure(ctx, scope, account, loginFailWindow)
+	if err != nil {
+		t.log.WithContext(ctx).Errorf("记录登录失败次数失败: scope=%s, account=%s, error=%v", scope, account, err)
+		return
+	}
+	if failures == loginMaxFailures {
+		t.log.WithContext(ctx).Warnf("登录失败次数达到上限，锁定%s: scope=%s, account=%s", loginFailWindow, scope, account)
+	}
+}
+
+// 登录成功后清零失败次数
+func (t *LoginThrottle) Succeed(ctx context.Context, scope, account string) {
+	if err := t.repo.Reset(ctx, scope, account); err != nil {
+		t.log.WithContext(ctx).Errorf("清零登录失败次数失败: scope=%s, account=%s, error=%v", scope, account, err)
+	}
+}
@@ -2,6 +2,7 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,7 +10,35 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// 订单状态
+const (
+	OrderStatusPending   = "1" // 待支付
+	OrderStatusPaid      = "2" // 已支付
+	OrderStatusPreparing = "3" // 配药中
+	OrderStatusShipped   = "4" // 已发货
+	OrderStatusCompleted = "5" // 已完成
+	OrderStatusCancelled = "6" // 已取消
+	OrderStatusRefunded  = "7" // 已退款
+)
 
+// 状态流转操作人类型
+const (
+	OrderOperatorUser   = "user"   // 患者
+	OrderOperatorDoctor = "doctor" // 医生
+	OrderOperatorAdmin  = "admin"  // 后台客服/管理员
+	OrderOperatorSystem = "system" // 系统自动处理
+)
+
+// 订单状态机：当前状态 -> 允许流转到的目标状态
+var orderStatusTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusPreparing, OrderStatusRefunded},
+	OrderStatusPreparing: {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusCompleted, OrderStatusRefunded},
+	OrderStatusCompleted: {OrderStatusRefunded},
+	OrderStatusCancelled: {},
+	OrderStatusRefunded:  {},
+}
 
 // 订单主表模型
 type MtOrder struct {
@@ -34,19 +63,47 @@ type MtOrder struct {
 	DiscountAmount   decimal.Decimal `json:"discount_amount"`   // 优惠金额
 	OriginalAmount   decimal.Decimal `json:"original_amount"`   // 原始金额
 	Remark           string          `json:"remark"`            // 备注
+	PrescriptionID   uint64          `json:"prescription_id"`   // 关联处方ID，0表示未关联
+	CreatedAt        time.Time       `json:"created_at"`        // 下单时间
+
+	// 下单时的用药提示，不落库
+	DrugWarnings []*DrugWarning `json:"drug_warnings,omitempty"`
 }
 
+// 订单状态流转日志模型
+type MtOrderStatusLog struct {
+	ID           int64     `json:"id"`
+	OrderID      int64     `json:"order_id"`      // 订单ID
+	OrderNo      string    `json:"order_no"`      // 订单编号
+	FromStatus   string    `json:"from_status"`   // 原状态，新建订单时为空
+	ToStatus     string    `json:"to_status"`     // 目标状态
+	OperatorType string    `json:"operator_type"` // 操作人类型
+	OperatorID   int64     `json:"operator_id"`   // 操作人ID，系统操作为0
+	Reason       string    `json:"reason"`        // 流转原因
+	CreatedAt    time.Time `json:"created_at"`    // 流转时间
+}
+
+// 状态流转操作人
+type OrderOperator struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+// 系统操作人
+var SystemOperator = &OrderOperator{Type: OrderOperatorSystem}
+
 // 订单项模型
 type MtOrderItem struct {
-	ID        int64           `json:"id"`
-	OrderID   int64           `json:"order_id"`   // 订单ID
-	DrugID    int64           `json:"drug_id"`    // 药品ID
-	DrugName  string          `json:"drug_name"`  // 药品名称
-	DrugSpec  string          `json:"drug_spec"`  // 药品规格
-	Quantity  int32           `json:"quantity"`   // 数量
-	Price     decimal.Decimal `json:"price"`      // 单价
-	Subtotal  decimal.Decimal `json:"subtotal"`   // 小计
-	CreatedAt time.Time       `json:"created_at"` // 创建时间
+	ID          int64           `json:"id"`
+	OrderID     int64           `json:"order_id"`     // 订单ID
+	DrugID      int64           `json:"drug_id"`      // 药品ID
+	DrugName    string          `json:"drug_name"`    // 药品名称
+	DrugSpec    string          `json:"drug_spec"`    // 药品规格
+	Quantity    int32           `json:"quantity"`     // 数量
+	RefundedQty int32           `json:"refunded_qty"` // 已申请退款数量（不含被驳回和退款失败的）
+	Price       decimal.Decimal `json:"price"`        // 单价
+	Subtotal    decimal.Decimal `json:"subtotal"`     // 小计
+	CreatedAt   time.Time       `json:"created_at"`   // 创建时间
 }
 
 // 创建订单请求
@@ -61,6 +118,13 @@ type CreateOrderRequest struct {
 	Items         []*CreateOrderItem   `json:"items" validate:"required,min=1"`
 	UserCouponID  *int64               `json:"user_coupon_id"` // 使用的优惠券ID
 	Remark        string               `json:"remark"`
+	// 关联的处方ID，订单含处方药时必填
+	PrescriptionID uint64 `json:"prescription_id"`
+	// 用药人所属人群，用于检查用药禁忌
+	Populations []string `json:"populations"`
+
+	// 客户端生成的幂等键，同一用户重复提交时返回首次创建的订单
+	IdempotencyKey string `json:"-"`
 }
 
 // 创建订单项请求
@@ -97,26 +161,40 @@ type OrderRepo interface {
 	CreateOrder(ctx context.Context, order *MtOrder) error
 	GetOrderByID(ctx context.Context, id int64) (*MtOrder, error)
 	GetOrderByOrderNo(ctx context.Context, orderNo string) (*MtOrder, error)
-	UpdateOrderStatus(ctx context.Context, orderNo string, status string, timestamp time.Time) error
+	// 仅当订单当前状态为fromStatus时才更新，否则返回ErrOrderStatusConflict
+	UpdateOrderStatus(ctx context.Context, orderNo string, fromStatus, toStatus string, timestamp time.Time) error
 	UpdateOrderPayment(ctx context.Context, orderNo string, payType string, payTime time.Time) error
 	ListUserOrders(ctx context.Context, userID int64, page, pageSize int32) ([]*MtOrder, int64, error)
 	ListOrdersByStatus(ctx context.Context, status string, page, pageSize int32) ([]*MtOrder, int64, error)
 	CountOrdersByStatus(ctx context.Context, status string) (int64, error)
+	// 查询下单时间早于before的待支付订单，按下单时间正序
+	ListPendingOrdersBefore(ctx context.Context, before time.Time, limit int) ([]*MtOrder, error)
 
 	// 订单项操作
 	CreateOrderItems(ctx context.Context, items []*MtOrderItem) error
 	GetOrderItems(ctx context.Context, orderID int64) ([]*MtOrderItem, error)
 
+	// 状态流转日志
+	CreateOrderStatusLog(ctx context.Context, statusLog *MtOrderStatusLog) error
+	ListOrderStatusLogs(ctx context.Context, orderNo string) ([]*MtOrderStatusLog, error)
+
 	// 事务支持
 	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
-// 药品库存仓储接口
+// 药品库存仓储接口，预留、出库、释放均作用于药品所属药店的库存记录并写入库存流水
 type DrugInventoryRepo interface {
 	CheckInventory(ctx context.Context, drugID int64, quantity int32) (bool, error)
-	ReserveInventory(ctx context.Context, drugID int64, quantity int32) error
-	ReduceInventory(ctx context.Context, drugID int64, quantity int32) error
-	ReleaseReservedInventory(ctx context.Context, drugID int64, quantity int32) error
+	ReserveInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error
+	ReduceInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error
+	ReleaseReservedInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error
+	// 退款退回在库数量
+	RestockInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error
+
+	// 药店库存查询与盘点
+	GetInventory(ctx context.Context, drugID int64, drugStoreID int32) (*MtDrugInventory, error)
+	ListInventoriesByDrug(ctx context.Context, drugID int64) ([]*MtDrugInventory, error)
+	AdjustInventory(ctx context.Context, drugID int64, drugStoreID int32, quantity int64, remark string) (*MtDrugInventory, error)
 }
 
 // 订单用例
@@ -124,34 +202,90 @@ type OrderUsecase struct {
 	orderRepo     OrderRepo
 	drugRepo      DrugRepo
 	inventoryRepo DrugInventoryRepo
-	log           *log.Helper
+	inventoryUc   *InventoryUsecase
+	couponUc         *CouponUsecase
+	idempotencyUc    *IdempotencyUsecase
+	prescriptionRepo PrescriptionRepo
+	interactionUc    *InteractionUsecase
+	paymentUc        *PaymentUsecase
+	log              *log.Helper
 }
 
-// 创建订单用例
+// 创建订单用例，并注册为药品订单支付单的支付成功处理
 func NewOrderUsecase(
 	orderRepo OrderRepo,
 	drugRepo DrugRepo,
 	inventoryRepo DrugInventoryRepo,
+	inventoryUc *InventoryUsecase,
+	couponUc *CouponUsecase,
+	idempotencyUc *IdempotencyUsecase,
+	prescriptionRepo PrescriptionRepo,
+	interactionUc *InteractionUsecase,
+	paymentUc *PaymentUsecase,
 	logger log.Logger,
 ) *OrderUsecase {
-	return &OrderUsecase{
-		orderRepo:     orderRepo,
-		drugRepo:      drugRepo,
-		inventoryRepo: inventoryRepo,
-		log:           log.NewHelper(logger),
+	uc := &OrderUsecase{
+		orderRepo:        orderRepo,
+		drugRepo:         drugRepo,
+		inventoryRepo:    inventoryRepo,
+		inventoryUc:      inventoryUc,
+		couponUc:         couponUc,
+		idempotencyUc:    idempotencyUc,
+		prescriptionRepo: prescriptionRepo,
+		interactionUc:    interactionUc,
+		paymentUc:        paymentUc,
+		log:              log.NewHelper(logger),
 	}
+	paymentUc.RegisterPaidHandler(OrderTypeDrug, uc)
+	return uc
 }
 
-// 创建订单
+// 创建订单，携带幂等键的重复请求返回首次创建的订单
 func (uc *OrderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*MtOrder, error) {
+	var created *MtOrder
+	orderNo, replayed, err := uc.idempotencyUc.Execute(ctx, IdempotencyScopeCreateOrder, req.UserID, req.IdempotencyKey, RequestFingerprint(req),
+		func(ctx context.Context) (string, error) {
+			order, err := uc.createOrder(ctx, req)
+			if err != nil {
+				return "", err
+			}
+			created = order
+			return order.OrderNo, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if !replayed {
+		return created, nil
+	}
+
+	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, orderNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询订单失败: %v", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("订单不存在: %s", orderNo)
+	}
+	return order, nil
+}
+
+func (uc *OrderUsecase) createOrder(ctx context.Context, req *CreateOrderRequest) (*MtOrder, error) {
 	// 生成订单号
 	orderNo := fmt.Sprintf("ORD_%d_%d", req.UserID, time.Now().UnixNano())
 
 	// 验证药品信息并计算总金额
 	var totalAmount decimal.Decimal
 	var orderItems []*MtOrderItem
+	var couponItems []*CouponCalculateItem
+	// 订单中的处方药及数量
+	rxItems := make(map[int64]int32)
+	storeID := int32(-1)
 
 	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: 购买数量必须大于0, drugID=%d", ErrInvalidStockQuantity, item.DrugID)
+		}
+
 		// 获取药品信息
 		drug, err := uc.drugRepo.GetDrug(ctx, int32(item.DrugID))
 		if err != nil {
@@ -168,6 +302,12 @@ func (uc *OrderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest
 		if !hasStock {
 			return nil, fmt.Errorf("药品库存不足: %s", drug.DrugName)
 		}
+		if drug.IsPrescription {
+			if req.PrescriptionID == 0 {
+				return nil, fmt.Errorf("%w: %s为处方药，请凭审核通过的处方购买", ErrPrescriptionRequired, drug.DrugName)
+			}
+			rxItems[item.DrugID] += item.Quantity
+		}
 
 		// 计算小计
 		price := decimal.NewFromFloat32(drug.Price)
@@ -185,25 +325,76 @@ func (uc *OrderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest
 			Subtotal: subtotal,
 		}
 		orderItems = append(orderItems, orderItem)
+		couponItems = append(couponItems, &CouponCalculateItem{
+			DrugID:   item.DrugID,
+			Quantity: item.Quantity,
+			Price:    price,
+		})
+
+		// 跨店铺订单不能使用店铺券
+		if storeID == -1 {
+			storeID = int32(drug.DrugStore)
+		} else if storeID != int32(drug.DrugStore) {
+			storeID = 0
+		}
+	}
+
+	// 检查药物相互作用和用药禁忌，严重的拦截下单
+	drugCheck, err := uc.interactionUc.Check(ctx, orderItemDrugIDs(orderItems), req.Populations)
+	if err != nil {
+		return nil, err
+	}
+	if err := drugCheck.Err(); err != nil {
+		uc.log.Warnf("用药检查未通过: userID=%d, error=%v", req.UserID, err)
+		return nil, err
+	}
+
+	// 校验处方，实际占用在事务内完成
+	if req.PrescriptionID != 0 {
+		if err := uc.checkPrescription(ctx, req.UserID, req.PrescriptionID, rxItems); err != nil {
+			uc.log.Warnf("处方不可用: userID=%d, prescriptionID=%d, error=%v", req.UserID, req.PrescriptionID, err)
+			return nil, err
+		}
+	}
+
+	// 校验优惠券并计算优惠金额，实际核销在事务内完成
+	originalAmount := totalAmount
+	discountAmount := decimal.Zero
+	var discountUserID int32
+	if req.UserCouponID != nil && *req.UserCouponID > 0 {
+		discountUserID = int32(*req.UserCouponID)
+		var err error
+		discountAmount, err = uc.couponUc.CheckOrderCoupon(ctx, req.UserID, discountUserID, couponItems, totalAmount, storeID)
+		if err != nil {
+			uc.log.Warnf("优惠券不可用: userID=%d, userCouponID=%d, error=%v", req.UserID, discountUserID, err)
+			return nil, err
+		}
+		totalAmount = totalAmount.Sub(discountAmount)
 	}
 
 	// 创建订单
 	order := &MtOrder{
-		OrderNo:       orderNo,
-		UserID:        req.UserID,
-		UserName:      req.UserName,
-		UserPhone:     req.UserPhone,
-		DoctorID:      req.DoctorID,
-		DoctorName:    req.DoctorName,
-		AddressID:     req.AddressID,
-		AddressDetail: req.AddressDetail,
-		TotalAmount:   totalAmount,
-		Status:        "1", // 待支付
-		Remark:        req.Remark,
+		OrderNo:        orderNo,
+		UserID:         req.UserID,
+		UserName:       req.UserName,
+		UserPhone:      req.UserPhone,
+		DoctorID:       req.DoctorID,
+		DoctorName:     req.DoctorName,
+		AddressID:      req.AddressID,
+		AddressDetail:  req.AddressDetail,
+		TotalAmount:    totalAmount,
+		Status:         OrderStatusPending,
+		OriginalAmount: originalAmount,
+		DiscountAmount: discountAmount,
+		Remark:         req.Remark,
+		PrescriptionID: req.PrescriptionID,
+	}
+	if discountUserID > 0 {
+		order.UserCouponID = req.UserCouponID
 	}
 
 	// 使用事务创建订单和订单项
-	err := uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
+	err = uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
 		// 创建订单
 		if err := uc.orderRepo.CreateOrder(ctx, order); err != nil {
 			return fmt.Errorf("创建订单失败: %v", err)
@@ -219,13 +410,41 @@ func (uc *OrderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest
 			return fmt.Errorf("创建订单项失败: %v", err)
 		}
 
+		// 记录初始状态
+		if err := uc.orderRepo.CreateOrderStatusLog(ctx, &MtOrderStatusLog{
+			OrderID:      int64(order.ID),
+			OrderNo:      orderNo,
+			ToStatus:     OrderStatusPending,
+			OperatorType: OrderOperatorUser,
+			OperatorID:   req.UserID,
+			Reason:       "创建订单",
+			CreatedAt:    time.Now(),
+		}); err != nil {
+			return fmt.Errorf("记录订单状态日志失败: %v", err)
+		}
+
 		// 预留库存
 		for _, item := range req.Items {
-			if err := uc.inventoryRepo.ReserveInventory(ctx, item.DrugID, item.Quantity); err != nil {
+			if err := uc.inventoryRepo.ReserveInventory(ctx, item.DrugID, item.Quantity, orderNo); err != nil {
 				return fmt.Errorf("预留库存失败: %v", err)
 			}
 		}
 
+		// 核销优惠券
+		if discountUserID > 0 {
+			if err := uc.couponUc.UseCoupon(ctx, orderNo, discountUserID, discountAmount); err != nil {
+				return fmt.Errorf("使用优惠券失败: %w", err)
+			}
+		}
+
+		// 以已审核为条件占用处方，同一处方只能被一个订单使用
+		if req.PrescriptionID != 0 {
+			if err := uc.prescriptionRepo.UpdatePrescriptionStatus(ctx, req.PrescriptionID, PrescriptionStatusAudited,
+				&PrescriptionUpdate{Status: PrescriptionStatusOrdered, OrderNo: orderNo}); err != nil {
+				return fmt.Errorf("占用处方失败: %w", err)
+			}
+		}
+
 		return nil
 	})
 
@@ -234,7 +453,10 @@ func (uc *OrderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest
 		return nil, err
 	}
 
-	uc.log.Infof("创建订单成功: orderNo=%s, userID=%d, amount=%s", orderNo, req.UserID, totalAmount.String())
+	uc.inventoryUc.CheckStockAlerts(ctx, orderItemDrugIDs(orderItems)...)
+
+	order.DrugWarnings = drugCheck.Warnings
+	uc.log.Infof("创建订单成功: orderNo=%s, userID=%d, amount=%s, discount=%s", orderNo, req.UserID, totalAmount.String(), discountAmount.String())
 	return order, nil
 }
 
@@ -250,8 +472,8 @@ func (uc *OrderUsecase) ProcessPayment(ctx context.Context, orderNo string, paym
 		return fmt.Errorf("订单不存在: %s", orderNo)
 	}
 
-	if order.Status != "1" { // 待支付
-		return fmt.Errorf("订单状态不允许支付: %s", order.Status)
+	if !CanTransitOrderStatus(order.Status, OrderStatusPaid) {
+		return fmt.Errorf("%w: 订单状态不允许支付: %s", ErrInvalidOrderStatusTransition, order.Status)
 	}
 
 	// 验证支付金额
@@ -261,6 +483,7 @@ func (uc *OrderUsecase) ProcessPayment(ctx context.Context, orderNo string, paym
 	}
 
 	// 使用事务处理支付
+	var orderItems []*MtOrderItem
 	err = uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
 		// 更新订单支付信息
 		if err := uc.orderRepo.UpdateOrderPayment(ctx, orderNo, paymentInfo.PayType, paymentInfo.PaymentTime); err != nil {
@@ -268,22 +491,33 @@ func (uc *OrderUsecase) ProcessPayment(ctx context.Context, orderNo string, paym
 		}
 
 		// 更新订单状态为已支付
-		if err := uc.orderRepo.UpdateOrderStatus(ctx, orderNo, "2", paymentInfo.PaymentTime); err != nil { // 已支付
-			return fmt.Errorf("更新订单状态失败: %v", err)
+		payer := &OrderOperator{Type: OrderOperatorUser, ID: order.UserID}
+		reason := fmt.Sprintf("支付成功: payType=%s, tradeNo=%s", paymentInfo.PayType, paymentInfo.TradeNo)
+		if err := uc.transitStatus(ctx, order, OrderStatusPaid, payer, reason, paymentInfo.PaymentTime); err != nil {
+			return err
 		}
 
 		// 获取订单项并减少库存
-		orderItems, err := uc.orderRepo.GetOrderItems(ctx, int64(order.ID))
+		var err error
+		orderItems, err = uc.orderRepo.GetOrderItems(ctx, int64(order.ID))
 		if err != nil {
 			return fmt.Errorf("获取订单项失败: %v", err)
 		}
 
 		for _, item := range orderItems {
-			if err := uc.inventoryRepo.ReduceInventory(ctx, item.DrugID, item.Quantity); err != nil {
+			if err := uc.inventoryRepo.ReduceInventory(ctx, item.DrugID, item.Quantity, orderNo); err != nil {
 				return fmt.Errorf("减少库存失败: drugID=%d, error=%v", item.DrugID, err)
 			}
 		}
 
+		// 订单支付后处方已使用
+		if order.PrescriptionID != 0 {
+			if err := uc.prescriptionRepo.UpdatePrescriptionStatus(ctx, order.PrescriptionID, PrescriptionStatusOrdered,
+				&PrescriptionUpdate{Status: PrescriptionStatusUsed}); err != nil {
+				return fmt.Errorf("核销处方失败: %w", err)
+			}
+		}
+
 		return nil
 	})
 
@@ -292,13 +526,66 @@ func (uc *OrderUsecase) ProcessPayment(ctx context.Context, orderNo string, paym
 		return err
 	}
 
+	uc.inventoryUc.CheckStockAlerts(ctx, orderItemDrugIDs(orderItems)...)
+
 	uc.log.Infof("处理支付成功: orderNo=%s, amount=%s", orderNo, paymentInfo.Amount.String())
 	return nil
 }
 
+// 药品订单支付成功：按支付单的金额、交易号标记订单已支付，扣减库存并核销处方；重复通知直接忽略
+func (uc *OrderUsecase) OnPaymentPaid(ctx context.Context, payment *PaymentOrder) error {
+	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, payment.BusinessID)
+	if err != nil {
+		return fmt.Errorf("查询订单失败: %v", err)
+	}
+	if order == nil {
+		return fmt.Errorf("订单不存在: %s", payment.BusinessID)
+	}
+	if order.UserID != int64(payment.UserID) {
+		return fmt.Errorf("支付单与订单不匹配: orderNo=%s, orderID=%s", order.OrderNo, payment.OrderID)
+	}
+
+	switch order.Status {
+	case OrderStatusPending:
+	case OrderStatusCancelled:
+		// 取消后才完成支付，由对账任务列出后人工退款
+		uc.log.Errorf("订单已取消但支付成功，需人工退款: orderNo=%s, orderID=%s, tradeNo=%s", order.OrderNo, payment.OrderID, payment.TradeNo)
+		return nil
+	default:
+		uc.log.Infof("订单已支付，忽略重复通知: orderNo=%s, status=%s", order.OrderNo, order.Status)
+		return nil
+	}
+
+	amount, err := decimal.NewFromString(payment.TotalAmount)
+	if err != nil {
+		return fmt.Errorf("支付金额格式错误: %s", payment.TotalAmount)
+	}
+	payType := order.PayType
+	if payType == "" {
+		payType = channelPayType(payment.Channel)
+	}
+	paidAt := payment.PayTime
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+	err = uc.ProcessPayment(ctx, order.OrderNo, &PaymentInfo{
+		PayType:     payType,
+		Amount:      amount,
+		TradeNo:     payment.TradeNo,
+		PaymentTime: paidAt,
+	})
+	// 并发的通知已完成处理
+	if errors.Is(err, ErrOrderStatusConflict) {
+		return nil
+	}
+	return err
+}
+
 // 更新订单状态
-func (uc *OrderUsecase) UpdateOrderStatus(ctx context.Context, orderNo string, status string) error {
-	// 验证状态转换的合法性
+func (uc *OrderUsecase) UpdateOrderStatus(ctx context.Context, orderNo string, status string, operator *OrderOperator, reason string) error {
+	if operator == nil {
+		operator = SystemOperator
+	}
 	currentOrder, err := uc.orderRepo.GetOrderByOrderNo(ctx, orderNo)
 	if err != nil {
 		return fmt.Errorf("查询订单失败: %v", err)
@@ -308,25 +595,33 @@ func (uc *OrderUsecase) UpdateOrderStatus(ctx context.Context, orderNo string, s
 		return fmt.Errorf("订单不存在: %s", orderNo)
 	}
 
-	// 验证状态转换
-	if !uc.isValidStatusTransition(currentOrder.Status, status) {
-		return fmt.Errorf("无效的状态转换: %s -> %s", currentOrder.Status, status)
+	// 支付和取消涉及库存处理，必须走对应流程
+	switch status {
+	case OrderStatusPaid:
+		return fmt.Errorf("%w: 支付请通过支付流程处理", ErrInvalidOrderStatusTransition)
+	case OrderStatusRefunded:
+		return fmt.Errorf("%w: 退款请通过退款流程处理", ErrInvalidOrderStatusTransition)
+	case OrderStatusCancelled:
+		return uc.CancelOrder(ctx, orderNo, operator, reason)
 	}
 
-	// 更新状态
-	timestamp := time.Now()
-	err = uc.orderRepo.UpdateOrderStatus(ctx, orderNo, status, timestamp)
+	err = uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
+		return uc.transitStatus(ctx, currentOrder, status, operator, reason, time.Now())
+	})
 	if err != nil {
 		uc.log.Errorf("更新订单状态失败: orderNo=%s, status=%s, error=%v", orderNo, status, err)
 		return err
 	}
 
-	uc.log.Infof("更新订单状态成功: orderNo=%s, status=%s", orderNo, status)
+	uc.log.Infof("更新订单状态成功: orderNo=%s, %s -> %s, operator=%s:%d", orderNo, currentOrder.Status, status, operator.Type, operator.ID)
 	return nil
 }
 
 // 取消订单
-func (uc *OrderUsecase) CancelOrder(ctx context.Context, orderNo string, reason string) error {
+func (uc *OrderUsecase) CancelOrder(ctx context.Context, orderNo string, operator *OrderOperator, reason string) error {
+	if operator == nil {
+		operator = SystemOperator
+	}
 	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, orderNo)
 	if err != nil {
 		return fmt.Errorf("查询订单失败: %v", err)
@@ -336,30 +631,45 @@ func (uc *OrderUsecase) CancelOrder(ctx context.Context, orderNo string, reason
 		return fmt.Errorf("订单不存在: %s", orderNo)
 	}
 
-	// 只有待支付状态的订单可以取消
-	if order.Status != "1" { // 待支付
-		return fmt.Errorf("订单状态不允许取消: %s", order.Status)
+	if !CanTransitOrderStatus(order.Status, OrderStatusCancelled) {
+		return fmt.Errorf("%w: 订单状态不允许取消: %s", ErrInvalidOrderStatusTransition, order.Status)
 	}
 
 	// 使用事务取消订单
+	var orderItems []*MtOrderItem
 	err = uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
 		// 更新订单状态为已取消
-		if err := uc.orderRepo.UpdateOrderStatus(ctx, orderNo, "6", time.Now()); err != nil { // 已取消
-			return fmt.Errorf("更新订单状态失败: %v", err)
+		if err := uc.transitStatus(ctx, order, OrderStatusCancelled, operator, reason, time.Now()); err != nil {
+			return err
 		}
 
 		// 释放预留库存
-		orderItems, err := uc.orderRepo.GetOrderItems(ctx, int64(order.ID))
+		var err error
+		orderItems, err = uc.orderRepo.GetOrderItems(ctx, int64(order.ID))
 		if err != nil {
 			return fmt.Errorf("获取订单项失败: %v", err)
 		}
 
 		for _, item := range orderItems {
-			if err := uc.inventoryRepo.ReleaseReservedInventory(ctx, item.DrugID, item.Quantity); err != nil {
+			if err := uc.inventoryRepo.ReleaseReservedInventory(ctx, item.DrugID, item.Quantity, orderNo); err != nil {
 				return fmt.Errorf("释放预留库存失败: drugID=%d, error=%v", item.DrugID, err)
 			}
 		}
 
+		// 退回优惠券
+		if order.UserCouponID != nil {
+			if err := uc.couponUc.ReturnCoupon(ctx, orderNo); err != nil {
+				return fmt.Errorf("退回优惠券失败: %v", err)
+			}
+		}
+
+		// 释放处方，患者可以重新下单
+		if order.PrescriptionID != 0 {
+			if err := uc.prescriptionRepo.ReleasePrescription(ctx, order.PrescriptionID, orderNo); err != nil {
+				return fmt.Errorf("释放处方失败: %v", err)
+			}
+		}
+
 		return nil
 	})
 
@@ -368,10 +678,67 @@ func (uc *OrderUsecase) CancelOrder(ctx context.Context, orderNo string, reason
 		return err
 	}
 
-	uc.log.Infof("取消订单成功: orderNo=%s, reason=%s", orderNo, reason)
+	uc.inventoryUc.CheckStockAlerts(ctx, orderItemDrugIDs(orderItems)...)
+
+	uc.log.Infof("取消订单成功: orderNo=%s, operator=%s:%d, reason=%s", orderNo, operator.Type, operator.ID, reason)
 	return nil
 }
 
+// 关闭下单超过ttl仍未支付的订单并释放预留库存，返回实际关闭的订单数
+// 关闭前向渠道同步支付结果，支付通知丢失的订单同步后变为已支付，不再关闭
+func (uc *OrderUsecase) ExpirePendingOrders(ctx context.Context, ttl time.Duration, limit int) (int, error) {
+	orders, err := uc.orderRepo.ListPendingOrdersBefore(ctx, time.Now().Add(-ttl), limit)
+	if err != nil {
+		uc.log.Errorf("查询超时未支付订单失败: %v", err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, order := range orders {
+		paid, err := uc.paymentUc.SyncBusinessPaid(ctx, OrderTypeDrug, order.OrderNo)
+		if err != nil {
+			uc.log.Errorf("同步订单支付结果失败，暂不关闭: orderNo=%s, error=%v", order.OrderNo, err)
+			continue
+		}
+		if paid {
+			uc.log.Infof("订单已在渠道支付，跳过超时关闭: orderNo=%s", order.OrderNo)
+			continue
+		}
+		err = uc.CancelOrder(ctx, order.OrderNo, SystemOperator, "超时未支付自动取消")
+		if err != nil {
+			// 订单已被支付、取消或其他实例抢先处理，跳过即可
+			if errors.Is(err, ErrOrderStatusConflict) || errors.Is(err, ErrInvalidOrderStatusTransition) {
+				uc.log.Infof("订单状态已变更，跳过超时关闭: orderNo=%s", order.OrderNo)
+				continue
+			}
+			uc.log.Errorf("超时关闭订单失败: orderNo=%s, error=%v", order.OrderNo, err)
+			continue
+		}
+		expired++
+	}
+
+	if expired > 0 {
+		uc.log.Infof("超时未支付订单关闭完成: scanned=%d, expired=%d", len(orders), expired)
+	}
+	return expired, nil
+}
+
+// 查询用户待支付的订单，用于创建支付单时确定应付金额；订单不存在或不属于该用户时返回nil
+func (uc *OrderUsecase) GetPayableOrder(ctx context.Context, userID int64, orderNo string) (*MtOrder, error) {
+	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, orderNo)
+	if err != nil {
+		uc.log.Errorf("查询订单失败: orderNo=%s, error=%v", orderNo, err)
+		return nil, err
+	}
+	if order == nil || order.UserID != userID {
+		return nil, nil
+	}
+	if order.Status != OrderStatusPending {
+		return nil, fmt.Errorf("%w: 订单状态不允许支付: %s", ErrInvalidOrderStatusTransition, order.Status)
+	}
+	return order, nil
+}
+
 // 获取订单详情
 func (uc *OrderUsecase) GetOrder(ctx context.Context, orderNo string) (*OrderDetail, error) {
 	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, orderNo)
@@ -457,8 +824,8 @@ func (uc *OrderUsecase) GetOrderStats(ctx context.Context) (map[string]int64, er
 	stats := make(map[string]int64)
 	
 	// 统计各状态订单数量
-	statuses := []string{"1", "2", "3", "4", "5", "6"} // 待支付、已支付、配药中、已发货、已完成、已取消
-	statusNames := []string{"pending", "paid", "preparing", "shipped", "completed", "cancelled"}
+	statuses := []string{OrderStatusPending, OrderStatusPaid, OrderStatusPreparing, OrderStatusShipped, OrderStatusCompleted, OrderStatusCancelled, OrderStatusRefunded}
+	statusNames := []string{"pending", "paid", "preparing", "shipped", "completed", "cancelled", "refunded"}
 	
 	for i, status := range statuses {
 		count, err := uc.orderRepo.CountOrdersByStatus(ctx, status)
@@ -472,11 +839,95 @@ func (uc *OrderUsecase) GetOrderStats(ctx context.Context) (map[string]int64, er
 	return stats, nil
 }
 
-// 验证状态转换是否合法 - 简化版本，具体业务规则由后台管理系统维护
-func (uc *OrderUsecase) isValidStatusTransition(from, to string) bool {
-	// 简单验证：不允许从完成或取消状态转换
-	if from == "5" || from == "6" { // 已完成或已取消
-		return false
+// 查询订单状态流转记录
+func (uc *OrderUsecase) ListOrderStatusLogs(ctx context.Context, orderNo string) ([]*MtOrderStatusLog, error) {
+	logs, err := uc.orderRepo.ListOrderStatusLogs(ctx, orderNo)
+	if err != nil {
+		uc.log.Errorf("查询订单状态流转记录失败: orderNo=%s, error=%v", orderNo, err)
+		return nil, err
+	}
+	return logs, nil
+}
+
+// 执行状态流转并记录日志，需在事务中调用
+func (uc *OrderUsecase) transitStatus(ctx context.Context, order *MtOrder, to string, operator *OrderOperator, reason string, timestamp time.Time) error {
+	if !CanTransitOrderStatus(order.Status, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidOrderStatusTransition, order.Status, to)
+	}
+	if operator == nil {
+		operator = SystemOperator
+	}
+
+	// 以当前状态为条件更新，防止并发流转覆盖
+	if err := uc.orderRepo.UpdateOrderStatus(ctx, order.OrderNo, order.Status, to, timestamp); err != nil {
+		return fmt.Errorf("更新订单状态失败: %w", err)
+	}
+
+	statusLog := &MtOrderStatusLog{
+		OrderID:      int64(order.ID),
+		OrderNo:      order.OrderNo,
+		FromStatus:   order.Status,
+		ToStatus:     to,
+		OperatorType: operator.Type,
+		OperatorID:   operator.ID,
+		Reason:       reason,
+		CreatedAt:    timestamp,
+	}
+	if err := uc.orderRepo.CreateOrderStatusLog(ctx, statusLog); err != nil {
+		return fmt.Errorf("记录订单状态日志失败: %v", err)
 	}
-	return true
-}
\ No newline at end of file
+
+	order.Status = to
+	return nil
+}
+
+// 校验处方属于患者本人、审核通过、未过期且未使用，并覆盖订单中的处方药及数量
+func (uc *OrderUsecase) checkPrescription(ctx context.Context, userID int64, prescriptionID uint64, rxItems map[int64]int32) error {
+	prescription, err := uc.prescriptionRepo.GetPrescriptionByID(ctx, prescriptionID)
+	if err != nil {
+		return fmt.Errorf("查询处方失败: %v", err)
+	}
+	if prescription == nil || prescription.PatientID != uint64(userID) {
+		return fmt.Errorf("%w: 处方不存在", ErrPrescriptionRequired)
+	}
+	if prescription.Status != PrescriptionStatusAudited {
+		return fmt.Errorf("%w: 处方%s当前状态为%s，不能用于下单", ErrPrescriptionRequired, prescription.PrescriptionNo, prescription.Status)
+	}
+	if prescription.ExpiresAt.IsZero() || !time.Now().Before(prescription.ExpiresAt) {
+		return fmt.Errorf("%w: 处方%s已过期", ErrPrescriptionRequired, prescription.PrescriptionNo)
+	}
+
+	medicines, err := uc.prescriptionRepo.GetPrescriptionMedicines(ctx, prescriptionID)
+	if err != nil {
+		return fmt.Errorf("查询处方药品失败: %v", err)
+	}
+	prescribed := make(map[int64]decimal.Decimal, len(medicines))
+	for _, medicine := range medicines {
+		prescribed[int64(medicine.MedicineID)] = prescribed[int64(medicine.MedicineID)].Add(medicine.Quantity)
+	}
+	for drugID, quantity := range rxItems {
+		if prescribed[drugID].LessThan(decimal.NewFromInt32(quantity)) {
+			return fmt.Errorf("%w: 处方未开具药品%d或数量不足", ErrPrescriptionRequired, drugID)
+		}
+	}
+	return nil
+}
+
+// 校验订单状态流转是否合法
+func CanTransitOrderStatus(from, to string) bool {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// 订单项涉及的药品ID
+func orderItemDrugIDs(items []*MtOrderItem) []int64 {
+	drugIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		drugIDs = append(drugIDs, item.DrugID)
+	}
+	return drugIDs
+}
@@ -0,0 +1,37 @@
+package biz
+
+import "testing"
+
+// 测试订单状态机流转规则
+func TestCanTransitOrderStatus(t *testing.T) {
+	testCases := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{OrderStatusPending, OrderStatusPaid, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPending, OrderStatusShipped, false}, // 待支付不能直接发货
+		{OrderStatusPending, OrderStatusCompleted, false},
+		{OrderStatusPaid, OrderStatusPreparing, true},
+		{OrderStatusPaid, OrderStatusCancelled, false},
+		{OrderStatusPreparing, OrderStatusShipped, true},
+		{OrderStatusShipped, OrderStatusCompleted, true},
+		{OrderStatusShipped, OrderStatusPreparing, false}, // 不允许回退
+		{OrderStatusCompleted, OrderStatusCancelled, false},
+		{OrderStatusCancelled, OrderStatusPending, false},
+		{OrderStatusPaid, OrderStatusRefunded, true},
+		{OrderStatusCompleted, OrderStatusRefunded, true},
+		{OrderStatusPending, OrderStatusRefunded, false}, // 未支付不能退款
+		{OrderStatusRefunded, OrderStatusCompleted, false},
+		{OrderStatusPending, OrderStatusPending, false},
+		{"", OrderStatusPaid, false},
+		{OrderStatusPaid, "9", false},
+	}
+
+	for _, tc := range testCases {
+		if got := CanTransitOrderStatus(tc.from, tc.to); got != tc.expected {
+			t.Errorf("CanTransitOrderStatus(%q, %q) = %v, expected %v", tc.from, tc.to, got, tc.expected)
+		}
+	}
+}
@@ -33,8 +33,9 @@ type PaymentOrder struct {
 	Description  string    `json:"description"`   // 订单描述
 	TotalAmount  string    `json:"total_amount"`  // 支付金额
 	Status       string    `json:"status"`        // 支付状态
+	Channel      string    `json:"channel"`       // 支付渠道
 	PaymentURL   string    `json:"payment_url"`   // 支付链接
-	TradeNo      string    `json:"trade_no"`      // 支付宝交易号
+	TradeNo      string    `json:"trade_no"`      // 渠道交易号
 	PayTime      time.Time `json:"pay_time"`      // 支付时间
 	CreatedAt    time.Time `json:"created_at"`    // 创建时间
 	UpdatedAt    time.Time `json:"updated_at"`    // 更新时间
@@ -42,14 +43,23 @@ type PaymentOrder struct {
 
 // 退款记录模型
 type RefundRecord struct {
-	ID           int64     `json:"id"`
-	OrderID      string    `json:"order_id"`      // 订单号
-	RefundID     string    `json:"refund_id"`     // 退款单号
-	RefundAmount string    `json:"refund_amount"` // 退款金额
-	RefundReason string    `json:"refund_reason"` // 退款原因
-	RefundStatus string    `json:"refund_status"` // 退款状态
-	RefundTime   time.Time `json:"refund_time"`   // 退款时间
-	CreatedAt    time.Time `json:"created_at"`    // 创建时间
+	ID              int64         `json:"id"`
+	OrderID         string        `json:"order_id"`          // 支付订单号
+	OrderNo         string        `json:"order_no"`          // 药品订单号
+	UserID          int64         `json:"user_id"`           // 申请人
+	RefundID        string        `json:"refund_id"`         // 退款单号
+	RefundAmount    string        `json:"refund_amount"`     // 退款金额
+	RefundReason    string        `json:"refund_reason"`     // 退款原因
+	RefundStatus    string        `json:"refund_status"`     // 退款状态
+	AuditBy         int64         `json:"audit_by"`          // 审核人
+	AuditTime       *time.Time    `json:"audit_time"`        // 审核时间
+	AuditRemark     string        `json:"audit_remark"`      // 审核备注
+	GatewayRefundNo string        `json:"gateway_refund_no"` // 渠道退款流水号
+	FailReason      string        `json:"fail_reason"`       // 失败原因
+	RefundTime      time.Time     `json:"refund_time"`       // 退款时间
+	Items           []*RefundItem `json:"items"`             // 退款明细
+	CreatedAt       time.Time     `json:"created_at"`        // 创建时间
+	UpdatedAt       time.Time     `json:"updated_at"`        // 更新时间
 }
 
 
@@ -60,35 +70,110 @@ type PaymentRepo interface {
 	CreatePaymentOrder(ctx context.Context, order *PaymentOrder) error
 	// 根据订单号查询支付订单
 	GetPaymentOrderByOrderID(ctx context.Context, orderID string) (*PaymentOrder, error)
+	// 根据业务ID查询已支付的支付订单
+	GetPaidPaymentOrderByBusinessID(ctx context.Context, orderType, businessID string) (*PaymentOrder, error)
+	// 根据业务ID查询待支付的支付订单
+	ListPendingPaymentOrdersByBusinessID(ctx context.Context, orderType, businessID string) ([]*PaymentOrder, error)
 	// 根据用户ID查询支付订单列表
 	GetPaymentOrdersByUserID(ctx context.Context, userID int32, page, pageSize int32) ([]*PaymentOrder, int64, error)
 	// 更新支付订单状态
 	UpdatePaymentOrderStatus(ctx context.Context, orderID, status, tradeNo string, payTime time.Time) error
+	// 根据订单号批量查询支付订单
+	GetPaymentOrdersByOrderIDs(ctx context.Context, orderIDs []string) ([]*PaymentOrder, error)
+	// 查询渠道在支付时间范围内已支付的支付订单，含已退款
+	ListPaidPaymentOrders(ctx context.Context, channel string, start, end time.Time) ([]*PaymentOrder, error)
 	
 	// 退款记录相关
 	CreateRefundRecord(ctx context.Context, record *RefundRecord) error
 	GetRefundRecordsByOrderID(ctx context.Context, orderID string) ([]*RefundRecord, error)
 }
 
+// 支付成功后的业务处理，按订单类型注册到支付用例
+type PaymentPaidHandler interface {
+	OnPaymentPaid(ctx context.Context, order *PaymentOrder) error
+}
+
 // 支付用例
 type PaymentUsecase struct {
-	repo PaymentRepo
-	log  *log.Helper
+	repo          PaymentRepo
+	idempotencyUc *IdempotencyUsecase
+	gateways      *PaymentGateways
+	paidHandlers  map[string]PaymentPaidHandler
+	log           *log.Helper
 }
 
 // 创建支付用例
-func NewPaymentUsecase(repo PaymentRepo, logger log.Logger) *PaymentUsecase {
+func NewPaymentUsecase(repo PaymentRepo, idempotencyUc *IdempotencyUsecase, gateways *PaymentGateways, logger log.Logger) *PaymentUsecase {
 	return &PaymentUsecase{
-		repo: repo,
-		log:  log.NewHelper(logger),
+		repo:          repo,
+		idempotencyUc: idempotencyUc,
+		gateways:      gateways,
+		paidHandlers:  make(map[string]PaymentPaidHandler),
+		log:           log.NewHelper(logger),
+	}
+}
+
+// 注册订单类型的支付成功处理，在应用启动时调用
+func (uc *PaymentUsecase) RegisterPaidHandler(orderType string, handler PaymentPaidHandler) {
+	uc.paidHandlers[orderType] = handler
+}
+
+// 创建支付订单，按支付方式选择渠道下单，携带幂等键的重复请求返回首次创建的支付订单
+func (uc *PaymentUsecase) CreatePaymentOrder(ctx context.Context, userID int32, payType, subject, totalAmount, orderType, businessID, description, idempotencyKey string) (*PaymentOrder, error) {
+	if payType == "" {
+		payType = PayTypeAlipay
+	}
+	gateway, err := uc.gateways.ByPayType(payType)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := RequestFingerprint([]string{payType, subject, totalAmount, orderType, businessID, description})
+
+	var created *PaymentOrder
+	orderID, replayed, err := uc.idempotencyUc.Execute(ctx, IdempotencyScopeCreatePayment, int64(userID), idempotencyKey, fingerprint,
+		func(ctx context.Context) (string, error) {
+			order, err := uc.createPaymentOrder(ctx, gateway, userID, subject, totalAmount, orderType, businessID, description)
+			if err != nil {
+				return "", err
+			}
+			created = order
+			return order.OrderID, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if !replayed {
+		return created, nil
+	}
+
+	order, err := uc.repo.GetPaymentOrderByOrderID(ctx, orderID)
+	if err != nil {
+		uc.log.Errorf("查询支付订单失败: orderID=%s, error=%v", orderID, err)
+		return nil, err
+	}
+	if order == nil {
+		return nil, fmt.Errorf("支付订单不存在: %s", orderID)
 	}
+	return order, nil
 }
 
-// 创建支付订单
-func (uc *PaymentUsecase) CreatePaymentOrder(ctx context.Context, userID int32, subject, totalAmount, orderType, businessID, description string) (*PaymentOrder, error) {
+func (uc *PaymentUsecase) createPaymentOrder(ctx context.Context, gateway PaymentGateway, userID int32, subject, totalAmount, orderType, businessID, description string) (*PaymentOrder, error) {
 	// 生成订单号
 	orderID := fmt.Sprintf("PAY_%d_%d", userID, time.Now().UnixNano())
 
+	// 渠道下单，失败时不落库
+	payResult, err := gateway.CreatePayment(ctx, &GatewayPayRequest{
+		OrderID:     orderID,
+		UserID:      userID,
+		Subject:     subject,
+		Description: description,
+		Amount:      totalAmount,
+	})
+	if err != nil {
+		uc.log.Errorf("渠道下单失败: channel=%s, orderID=%s, error=%v", gateway.Channel(), orderID, err)
+		return nil, fmt.Errorf("创建支付链接失败: %v", err)
+	}
+
 	// 创建支付订单
 	order := &PaymentOrder{
 		OrderID:     orderID,
@@ -99,18 +184,20 @@ func (uc *PaymentUsecase) CreatePaymentOrder(ctx context.Context, userID int32,
 		Description: description,
 		TotalAmount: totalAmount,
 		Status:      PaymentStatusPending,
+		Channel:     gateway.Channel(),
+		PaymentURL:  payResult.PaymentURL,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
 	// 保存到数据库
-	err := uc.repo.CreatePaymentOrder(ctx, order)
+	err = uc.repo.CreatePaymentOrder(ctx, order)
 	if err != nil {
 		uc.log.Errorf("创建支付订单失败: %v", err)
 		return nil, err
 	}
 
-	uc.log.Infof("创建支付订单成功: orderID=%s, userID=%d, amount=%s", orderID, userID, totalAmount)
+	uc.log.Infof("创建支付订单成功: orderID=%s, userID=%d, amount=%s, channel=%s", orderID, userID, totalAmount, order.Channel)
 	return order, nil
 }
 
@@ -153,16 +240,127 @@ func (uc *PaymentUsecase) UpdatePaymentOrderStatus(ctx context.Context, orderID,
 	return nil
 }
 
-// 处理支付成功回调
+// 处理支付渠道的支付通知，签名不合法时返回ErrInvalidGatewayNotify
+func (uc *PaymentUsecase) HandlePaymentNotify(ctx context.Context, channel string, notify *GatewayNotify) error {
+	gateway, err := uc.gateways.ByChannel(channel)
+	if err != nil {
+		return err
+	}
+	result, err := gateway.ParsePaymentNotify(ctx, notify)
+	if err != nil {
+		return fmt.Errorf("解析支付通知失败: %w", err)
+	}
+	if result.Status != PaymentStatusPaid {
+		uc.log.Infof("支付通知未支付成功: channel=%s, orderID=%s, status=%s", channel, result.OrderID, result.Status)
+		return nil
+	}
+	return uc.handleGatewayPaid(ctx, channel, result)
+}
+
+// 查询支付订单，待支付时向渠道查询并同步支付结果，用于补偿丢失的支付通知
+func (uc *PaymentUsecase) SyncPaymentOrder(ctx context.Context, orderID string) (*PaymentOrder, error) {
+	order, err := uc.GetPaymentOrder(ctx, orderID)
+	if err != nil || order == nil || order.Status != PaymentStatusPending {
+		return order, err
+	}
+
+	gateway, err := uc.gateways.ByChannel(order.Channel)
+	if err != nil {
+		return nil, err
+	}
+	result, err := gateway.QueryPayment(ctx, orderID)
+	if err != nil {
+		// 渠道查询失败不影响返回本地状态
+		uc.log.Warnf("查询渠道支付结果失败: channel=%s, orderID=%s, error=%v", gateway.Channel(), orderID, err)
+		return order, nil
+	}
+	if result.Status != PaymentStatusPaid {
+		return order, nil
+	}
+	if err := uc.handleGatewayPaid(ctx, gateway.Channel(), result); err != nil {
+		return nil, err
+	}
+	return uc.GetPaymentOrder(ctx, orderID)
+}
+
+// 向渠道同步业务单下待支付的支付单，返回是否已有支付成功的支付单
+func (uc *PaymentUsecase) SyncBusinessPaid(ctx context.Context, orderType, businessID string) (bool, error) {
+	orders, err := uc.repo.ListPendingPaymentOrdersByBusinessID(ctx, orderType, businessID)
+	if err != nil {
+		return false, err
+	}
+	for _, order := range orders {
+		synced, err := uc.SyncPaymentOrder(ctx, order.OrderID)
+		if err != nil {
+			return false, err
+		}
+		if synced.Status == PaymentStatusPaid || synced.Status == PaymentStatusRefunded {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 模拟用户完成支付，仅沙箱渠道支持，模拟通知与真实通知走同一条验签和处理链路
+func (uc *PaymentUsecase) SimulatePayment(ctx context.Context, orderID string) (*PaymentOrder, error) {
+	order, err := uc.GetPaymentOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, fmt.Errorf("支付订单不存在: %s", orderID)
+	}
+
+	gateway, err := uc.gateways.ByChannel(order.Channel)
+	if err != nil {
+		return nil, err
+	}
+	simulator, ok := gateway.(PaymentSimulator)
+	if !ok {
+		return nil, fmt.Errorf("%w: 渠道不支持模拟支付 channel=%s", ErrPaymentGatewayUnavailable, gateway.Channel())
+	}
+	notify, err := simulator.SimulatePay(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.HandlePaymentNotify(ctx, gateway.Channel(), notify); err != nil {
+		return nil, err
+	}
+	return uc.GetPaymentOrder(ctx, orderID)
+}
+
+func (uc *PaymentUsecase) handleGatewayPaid(ctx context.Context, channel string, result *GatewayPaymentResult) error {
+	order, err := uc.repo.GetPaymentOrderByOrderID(ctx, result.OrderID)
+	if err != nil {
+		return fmt.Errorf("查询订单失败: %v", err)
+	}
+	if order == nil {
+		return fmt.Errorf("支付订单不存在: %s", result.OrderID)
+	}
+	// 只接受下单渠道的支付结果
+	if order.Channel != "" && order.Channel != channel {
+		return fmt.Errorf("%w: 支付渠道不匹配, expected=%s, actual=%s", ErrInvalidGatewayNotify, order.Channel, channel)
+	}
+	return uc.HandlePaymentSuccess(ctx, result.OrderID, result.TradeNo, result.Amount)
+}
+
+// 处理支付成功回调，重复通知直接忽略
 func (uc *PaymentUsecase) HandlePaymentSuccess(ctx context.Context, orderID, tradeNo, totalAmount string) error {
 	// 查询订单
 	order, err := uc.repo.GetPaymentOrderByOrderID(ctx, orderID)
 	if err != nil {
 		return fmt.Errorf("查询订单失败: %v", err)
 	}
+	if order == nil {
+		return fmt.Errorf("支付订单不存在: %s", orderID)
+	}
+	if order.Status == PaymentStatusPaid || order.Status == PaymentStatusRefunded {
+		uc.log.Infof("支付订单已处理，忽略重复通知: orderID=%s, status=%s", orderID, order.Status)
+		return nil
+	}
 
 	// 验证金额
-	if order.TotalAmount != totalAmount {
+	if !amountEqual(order.TotalAmount, totalAmount) {
 		return fmt.Errorf("订单金额不匹配: expected=%s, actual=%s", order.TotalAmount, totalAmount)
 	}
 
@@ -177,11 +375,19 @@ func (uc *PaymentUsecase) HandlePaymentSuccess(ctx context.Context, orderID, tra
 	case OrderTypeDrug:
 		// 处理药品订单支付成功逻辑
 		uc.log.Infof("药品订单支付成功: orderID=%s, businessID=%s", orderID, order.BusinessID)
-		// TODO: 调用药品订单服务更新状态
 	case OrderTypeConsultation:
 		// 处理咨询订单支付成功逻辑
 		uc.log.Infof("咨询订单支付成功: orderID=%s, businessID=%s", orderID, order.BusinessID)
-		// TODO: 调用咨询订单服务更新状态
+	}
+
+	// 支付单已标记为已支付，业务处理失败时由业务方查询时补偿
+	if handler, ok := uc.paidHandlers[order.OrderType]; ok {
+		order.Status = PaymentStatusPaid
+		order.TradeNo = tradeNo
+		if err := handler.OnPaymentPaid(ctx, order); err != nil {
+			uc.log.Errorf("支付成功业务处理失败: orderID=%s, orderType=%s, error=%v", orderID, order.OrderType, err)
+			return err
+		}
 	}
 
 	return nil
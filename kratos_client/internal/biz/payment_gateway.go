@@ -0,0 +1,116 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 支付渠道
+const (
+	PaymentChannelAlipay  = "alipay"  // 支付宝
+	PaymentChannelWechat  = "wechat"  // 微信支付
+	PaymentChannelSandbox = "sandbox" // 进程内模拟渠道
+)
+
+// 支付方式，与 MtOrder.PayType 取值一致
+const (
+	PayTypeWechat   = "1" // 微信
+	PayTypeAlipay   = "2" // 支付宝
+	PayTypeBankCard = "3" // 银行卡
+)
+
+// 支付渠道下单请求
+type GatewayPayRequest struct {
+	OrderID     string // 支付订单号，作为渠道侧的商户订单号
+	UserID      int32  // 用户ID
+	Subject     string // 商品标题
+	Description string // 订单描述
+	Amount      string // 支付金额，单位元
+}
+
+// 支付渠道下单结果
+type GatewayPayResult struct {
+	PaymentURL string // 支付链接，微信为扫码支付的二维码链接
+}
+
+// 支付渠道异步通知的原始内容，表单类通知使用Params，JSON类通知使用Header和Body
+type GatewayNotify struct {
+	Params map[string]string
+	Header map[string]string
+	Body   []byte
+}
+
+// 支付渠道的支付结果
+type GatewayPaymentResult struct {
+	OrderID string    // 支付订单号
+	TradeNo string    // 渠道交易号
+	Status  string    // PaymentStatusPaid、PaymentStatusPending 或 PaymentStatusCancelled
+	Amount  string    // 实际支付金额，单位元
+	PayTime time.Time // 支付时间
+}
+
+// 支付渠道接口
+type PaymentGateway interface {
+	// 渠道名称，记录在支付单上，查询、通知和退款时据此找回渠道
+	Channel() string
+	// 渠道下单，返回支付链接
+	CreatePayment(ctx context.Context, req *GatewayPayRequest) (*GatewayPayResult, error)
+	// 校验签名并解析渠道的支付异步通知，签名不合法时返回ErrInvalidGatewayNotify
+	ParsePaymentNotify(ctx context.Context, notify *GatewayNotify) (*GatewayPaymentResult, error)
+	// 主动查询支付结果，渠道侧尚无交易时返回 PaymentStatusPending
+	QueryPayment(ctx context.Context, orderID string) (*GatewayPaymentResult, error)
+	// 发起退款，渠道受理但未出结果时返回 RefundStatusProcessing
+	Refund(ctx context.Context, req *GatewayRefundRequest) (*GatewayRefundResult, error)
+	// 校验签名并解析渠道的退款异步通知
+	ParseRefundNotify(ctx context.Context, notify *GatewayNotify) (*GatewayRefundResult, error)
+}
+
+// 支付渠道模拟器，沙箱渠道实现，用于离线模拟用户完成支付
+type PaymentSimulator interface {
+	// 将交易标记为已支付，返回渠道签名的支付通知
+	SimulatePay(ctx context.Context, orderID string) (*GatewayNotify, error)
+}
+
+// 已启用的支付渠道
+type PaymentGateways struct {
+	channels map[string]PaymentGateway
+	payTypes map[string]PaymentGateway
+}
+
+// 创建支付渠道集合
+func NewPaymentGatewaySet() *PaymentGateways {
+	return &PaymentGateways{
+		channels: make(map[string]PaymentGateway),
+		payTypes: make(map[string]PaymentGateway),
+	}
+}
+
+// 注册支付渠道及其承接的支付方式，同一支付方式后注册的覆盖先注册的
+func (g *PaymentGateways) Register(gateway PaymentGateway, payTypes ...string) {
+	g.channels[gateway.Channel()] = gateway
+	for _, payType := range payTypes {
+		g.payTypes[payType] = gateway
+	}
+}
+
+// 按支付方式选择渠道
+func (g *PaymentGateways) ByPayType(payType string) (PaymentGateway, error) {
+	gateway, ok := g.payTypes[payType]
+	if !ok {
+		return nil, fmt.Errorf("%w: payType=%s", ErrPaymentGatewayUnavailable, payType)
+	}
+	return gateway, nil
+}
+
+// 按渠道名称查找渠道，渠道为空的历史支付单均为支付宝
+func (g *PaymentGateways) ByChannel(channel string) (PaymentGateway, error) {
+	if channel == "" {
+		channel = PaymentChannelAlipay
+	}
+	gateway, ok := g.channels[channel]
+	if !ok {
+		return nil, fmt.Errorf("%w: channel=%s", ErrPaymentGatewayUnavailable, channel)
+	}
+	return gateway, nil
+}
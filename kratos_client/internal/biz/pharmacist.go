@@ -0,0 +1,94 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 药师账号状态
+const (
+	PharmacistStatusDisabled int32 = 0
+	PharmacistStatusEnabled  int32 = 1
+)
+
+// 药师账号，负责审核医生开具的处方
+type Pharmacist struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Phone         string    `json:"phone"`
+	LicenseNo     string    `json:"license_no"`
+	PasswordHash  string    `json:"-"`
+	Salt          string    `json:"-"`
+	Status        int32     `json:"status"`
+	LastLoginTime time.Time `json:"last_login_time"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// 药师仓储接口，账号不存在时返回nil
+type PharmacistRepo interface {
+	FindByID(ctx context.Context, id int64) (*Pharmacist, error)
+	FindByPhone(ctx context.Context, phone string) (*Pharmacist, error)
+	UpdateLastLogin(ctx context.Context, id int64, at time.Time) error
+	// 更新登录密码哈希，bcrypt哈希自带盐值，同时清空盐值
+	UpdatePasswordHash(ctx context.Context, id int64, hash string) error
+}
+
+// 药师用例
+type PharmacistUsecase struct {
+	repo     PharmacistRepo
+	throttle *LoginThrottle
+	log      *log.Helper
+}
+
+// 创建药师用例
+func NewPharmacistUsecase(repo PharmacistRepo, throttle *LoginThrottle, logger log.Logger) *PharmacistUsecase {
+	return &PharmacistUsecase{repo: repo, throttle: throttle, log: log.NewHelper(logger)}
+}
+
+// 药师使用手机号加密码登录，密码与医生账号使用相同的bcrypt哈希，连续失败达到上限后锁定账号
+func (uc *PharmacistUsecase) Login(ctx context.Context, phone, password string) (*Pharmacist, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" || password == "" {
+		return nil, fmt.Errorf("请输入账号和密码")
+	}
+	if err := uc.throttle.Check(ctx, LoginScopePharmacist, phone); err != nil {
+		uc.log.WithContext(ctx).Warnf("药师登录已锁定: phone=%s", phone)
+		return nil, err
+	}
+	pharmacist, err := uc.repo.FindByPhone(ctx, phone)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("查询药师账号失败: phone=%s, error=%v", phone, err)
+		return nil, fmt.Errorf("登录失败")
+	}
+	if pharmacist == nil || pharmacist.PasswordHash == "" || pharmacist.Status != PharmacistStatusEnabled {
+		uc.throttle.Fail(ctx, LoginScopePharmacist, phone)
+		return nil, ErrPharmacistLoginFailed
+	}
+	ok, legacy := checkPasswordHash(password, pharmacist.PasswordHash, pharmacist.Salt)
+	if !ok {
+		uc.log.WithContext(ctx).Warnf("药师登录密码错误: pharmacistId=%d", pharmacist.ID)
+		uc.throttle.Fail(ctx, LoginScopePharmacist, phone)
+		return nil, ErrPharmacistLoginFailed
+	}
+	uc.throttle.Succeed(ctx, LoginScopePharmacist, phone)
+	if legacy {
+		// 旧的 sha256 哈希改存bcrypt，失败不影响登录
+		if hash, err := DoctorPasswordHash(password); err != nil {
+			uc.log.WithContext(ctx).Warnf("生成药师密码哈希失败: pharmacistId=%d, error=%v", pharmacist.ID, err)
+		} else if err := uc.repo.UpdatePasswordHash(ctx, pharmacist.ID, hash); err != nil {
+			uc.log.WithContext(ctx).Warnf("升级药师密码哈希失败: pharmacistId=%d, error=%v", pharmacist.ID, err)
+		}
+	}
+
+	now := time.Now()
+	if err := uc.repo.UpdateLastLogin(ctx, pharmacist.ID, now); err != nil {
+		uc.log.WithContext(ctx).Warnf("记录药师登录信息失败: pharmacistId=%d, error=%v", pharmacist.ID, err)
+	}
+	pharmacist.LastLoginTime = now
+	return pharmacist, nil
+}
@@ -2,7 +2,9 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -24,6 +26,10 @@ type MtPrescription struct {
 	AuditorID         *uint64         `json:"auditor_id"`
 	AuditTime         *time.Time      `json:"audit_time"`
 	AuditNotes        string          `json:"audit_notes"`
+	ConsultationNo    string          `json:"consultation_no"` // 关联问诊单号
+	OrderNo           string          `json:"order_no"`        // 处方下单生成的订单号
+	ExpiresAt         time.Time       `json:"expires_at"`      // 有效期截止时间，过期后不能再用于下单
+	Populations       []string        `json:"populations"`     // 患者所属人群，用于检查用药禁忌
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
 	
@@ -60,6 +66,7 @@ type MtPrescriptionMedicine struct {
 type PrescriptionDetail struct {
 	Prescription *MtPrescription            `json:"prescription"`
 	Medicines    []*MtPrescriptionMedicine  `json:"medicines"`
+	DrugWarnings []*DrugWarning             `json:"drug_warnings,omitempty"` // 开具时的用药提示
 }
 
 // 处方列表查询请求
@@ -88,6 +95,37 @@ type ListDoctorPrescriptionsRequest struct {
 	PageSize int32  `json:"page_size"`
 }
 
+// 开具处方请求
+type IssuePrescriptionRequest struct {
+	DoctorID         int32                        `json:"doctor_id"`
+	ConsultationNo   string                       `json:"consultation_no"`
+	PrescriptionType string                       `json:"prescription_type"`
+	UsageInstruction string                       `json:"usage_instruction"`
+	Medicines        []*IssuePrescriptionMedicine `json:"medicines"`
+	Populations      []string                     `json:"populations"` // 患者所属人群：pregnancy/lactation/child/elderly
+}
+
+// 开具处方的药品明细，单价按药品当前售价计算
+type IssuePrescriptionMedicine struct {
+	MedicineID  int64  `json:"medicine_id"`
+	Quantity    int32  `json:"quantity"`
+	Unit        string `json:"unit"`
+	Dosage      string `json:"dosage"`
+	Frequency   string `json:"frequency"`
+	Duration    string `json:"duration"`
+	UsageMethod string `json:"usage_method"`
+	Notes       string `json:"notes"`
+}
+
+// 处方状态变更，零值字段不更新
+type PrescriptionUpdate struct {
+	Status     string
+	AuditorID  uint64
+	AuditTime  time.Time
+	AuditNotes string
+	OrderNo    string
+}
+
 // 处方仓储接口
 type PrescriptionRepo interface {
 	// 处方CRUD
@@ -95,7 +133,13 @@ type PrescriptionRepo interface {
 	ListPatientPrescriptions(ctx context.Context, req *ListPatientPrescriptionsRequest) ([]*MtPrescription, int64, error)
 	ListDoctorPrescriptions(ctx context.Context, req *ListDoctorPrescriptionsRequest) ([]*MtPrescription, int64, error)
 	GetPrescriptionByID(ctx context.Context, id uint64) (*MtPrescription, error)
-	
+	// 在同一事务内创建处方和药品明细
+	CreatePrescription(ctx context.Context, prescription *MtPrescription, medicines []*MtPrescriptionMedicine) error
+	// 仅当处方当前状态为fromStatus时才更新，否则返回ErrPrescriptionStatusConflict，可在订单事务中调用
+	UpdatePrescriptionStatus(ctx context.Context, id uint64, fromStatus string, update *PrescriptionUpdate) error
+	// 仅当处方仍被该订单占用时恢复为已审核并清空订单号，否则返回ErrPrescriptionStatusConflict
+	ReleasePrescription(ctx context.Context, id uint64, orderNo string) error
+
 	// 处方药品明细
 	GetPrescriptionMedicines(ctx context.Context, prescriptionID uint64) ([]*MtPrescriptionMedicine, error)
 }
@@ -103,13 +147,34 @@ type PrescriptionRepo interface {
 // 处方用例
 type PrescriptionUsecase struct {
 	prescriptionRepo PrescriptionRepo
+	pharmacistRepo   PharmacistRepo
+	consultationRepo ConsultationRepo
+	drugRepo         DrugRepo
+	cityRepo         CityRepo
+	orderUc          *OrderUsecase
+	interactionUc    *InteractionUsecase
 	log              *log.Helper
 }
 
 // 创建处方用例
-func NewPrescriptionUsecase(prescriptionRepo PrescriptionRepo, logger log.Logger) *PrescriptionUsecase {
+func NewPrescriptionUsecase(
+	prescriptionRepo PrescriptionRepo,
+	pharmacistRepo PharmacistRepo,
+	consultationRepo ConsultationRepo,
+	drugRepo DrugRepo,
+	cityRepo CityRepo,
+	orderUc *OrderUsecase,
+	interactionUc *InteractionUsecase,
+	logger log.Logger,
+) *PrescriptionUsecase {
 	return &PrescriptionUsecase{
 		prescriptionRepo: prescriptionRepo,
+		pharmacistRepo:   pharmacistRepo,
+		consultationRepo: consultationRepo,
+		drugRepo:         drugRepo,
+		cityRepo:         cityRepo,
+		orderUc:          orderUc,
+		interactionUc:    interactionUc,
 		log:              log.NewHelper(logger),
 	}
 }
@@ -173,14 +238,350 @@ func (uc *PrescriptionUsecase) GetPrescriptionDetail(ctx context.Context, prescr
 	}, nil
 }
 
+// 医生为自己接诊的问诊开具处方，处方患者取自问诊单
+func (uc *PrescriptionUsecase) IssuePrescription(ctx context.Context, req *IssuePrescriptionRequest) (*PrescriptionDetail, error) {
+	if req.ConsultationNo == "" {
+		return nil, fmt.Errorf("请选择问诊单")
+	}
+	if len(req.Medicines) == 0 {
+		return nil, fmt.Errorf("请添加处方药品")
+	}
+	prescriptionType := req.PrescriptionType
+	if prescriptionType == "" {
+		prescriptionType = PrescriptionTypeWestern
+	}
+	switch prescriptionType {
+	case PrescriptionTypeWestern, PrescriptionTypeChinese, PrescriptionTypeMixed:
+	default:
+		return nil, fmt.Errorf("不支持的处方类型: %s", prescriptionType)
+	}
+
+	consultation, err := uc.consultationRepo.GetConsultation(ctx, req.ConsultationNo)
+	if err != nil {
+		uc.log.Errorf("查询问诊失败: consultationNo=%s, error=%v", req.ConsultationNo, err)
+		return nil, fmt.Errorf("查询问诊失败")
+	}
+	if consultation == nil || consultation.DoctorID != req.DoctorID {
+		return nil, fmt.Errorf("问诊不存在: %s", req.ConsultationNo)
+	}
+	if consultation.Status != ConsultationStatusInProgress && consultation.Status != ConsultationStatusClosed {
+		return nil, fmt.Errorf("问诊未接诊，不能开具处方")
+	}
+
+	// 单价取药品当前售价，与下单时的计价方式一致
+	var totalAmount decimal.Decimal
+	medicines := make([]*MtPrescriptionMedicine, 0, len(req.Medicines))
+	seen := make(map[int64]bool, len(req.Medicines))
+	for _, item := range req.Medicines {
+		if item.MedicineID <= 0 || item.Quantity <= 0 {
+			return nil, fmt.Errorf("处方药品或数量不合法")
+		}
+		if seen[item.MedicineID] {
+			return nil, fmt.Errorf("处方药品重复: %d", item.MedicineID)
+		}
+		seen[item.MedicineID] = true
+		if strings.TrimSpace(item.Unit) == "" {
+			return nil, fmt.Errorf("请填写药品单位")
+		}
+
+		drug, err := uc.drugRepo.GetDrug(ctx, int32(item.MedicineID))
+		if err != nil || drug == nil {
+			return nil, fmt.Errorf("药品不存在: %d", item.MedicineID)
+		}
+		unitPrice := decimal.NewFromFloat32(drug.Price).Round(2)
+		quantity := decimal.NewFromInt32(item.Quantity)
+		totalPrice := unitPrice.Mul(quantity)
+		totalAmount = totalAmount.Add(totalPrice)
+		medicines = append(medicines, &MtPrescriptionMedicine{
+			MedicineID:  uint64(item.MedicineID),
+			Quantity:    quantity,
+			Unit:        strings.TrimSpace(item.Unit),
+			UnitPrice:   unitPrice,
+			TotalPrice:  totalPrice,
+			Dosage:      item.Dosage,
+			Frequency:   item.Frequency,
+			Duration:    item.Duration,
+			UsageMethod: item.UsageMethod,
+			Notes:       item.Notes,
+		})
+	}
+
+	// 严重相互作用或对患者人群禁用的药品不能开具
+	drugIDs := make([]int64, len(medicines))
+	for i, medicine := range medicines {
+		drugIDs[i] = int64(medicine.MedicineID)
+	}
+	drugCheck, err := uc.interactionUc.Check(ctx, drugIDs, req.Populations)
+	if err != nil {
+		return nil, err
+	}
+	if err := drugCheck.Err(); err != nil {
+		return nil, err
+	}
+	populations, _ := NormalizePopulations(req.Populations)
+
+	now := time.Now()
+	prescription := &MtPrescription{
+		PrescriptionNo:   fmt.Sprintf("RX%s%09d", now.Format("20060102150405"), now.Nanosecond()),
+		DoctorID:         uint64(req.DoctorID),
+		PatientID:        uint64(consultation.PatientID),
+		PrescriptionDate: now,
+		TotalAmount:      totalAmount,
+		PrescriptionType: prescriptionType,
+		UsageInstruction: req.UsageInstruction,
+		Status:           PrescriptionStatusIssued,
+		ConsultationNo:   consultation.ConsultationNo,
+		ExpiresAt:        now.Add(PrescriptionValidity),
+		Populations:      populations,
+	}
+	if err := uc.prescriptionRepo.CreatePrescription(ctx, prescription, medicines); err != nil {
+		uc.log.Errorf("开具处方失败: doctorId=%d, consultationNo=%s, error=%v", req.DoctorID, req.ConsultationNo, err)
+		return nil, fmt.Errorf("开具处方失败")
+	}
+	uc.log.Infof("医生开具处方: prescriptionNo=%s, doctorId=%d, patientId=%d", prescription.PrescriptionNo, req.DoctorID, consultation.PatientID)
+
+	detail, err := uc.GetPrescriptionDetail(ctx, prescription.ID)
+	if err != nil {
+		return nil, err
+	}
+	detail.DrugWarnings = drugCheck.Warnings
+	return detail, nil
+}
+
+// 按处方药品和患者人群检查用药，供药师审核时参考
+func (uc *PrescriptionUsecase) CheckPrescription(ctx context.Context, prescription *MtPrescription) (*DrugCheckResult, error) {
+	medicines, err := uc.prescriptionRepo.GetPrescriptionMedicines(ctx, prescription.ID)
+	if err != nil {
+		return nil, fmt.Errorf("查询处方药品失败: %v", err)
+	}
+	drugIDs := make([]int64, len(medicines))
+	for i, medicine := range medicines {
+		drugIDs[i] = int64(medicine.MedicineID)
+	}
+	return uc.interactionUc.Check(ctx, drugIDs, prescription.Populations)
+}
+
+// 医生撤销自己开具且尚未审核的处方
+func (uc *PrescriptionUsecase) CancelPrescription(ctx context.Context, doctorID int32, prescriptionID uint64) (*MtPrescription, error) {
+	prescription, err := uc.getPrescription(ctx, prescriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if prescription.DoctorID != uint64(doctorID) {
+		return nil, fmt.Errorf("处方不存在: ID=%d", prescriptionID)
+	}
+	if err := uc.transitStatus(ctx, prescription, PrescriptionStatusCancelled, &PrescriptionUpdate{}); err != nil {
+		return nil, err
+	}
+	return uc.getPrescription(ctx, prescriptionID)
+}
+
+// 药师审核处方，驳回时必须填写审核意见
+func (uc *PrescriptionUsecase) AuditPrescription(ctx context.Context, pharmacistID int64, prescriptionID uint64, approved bool, notes string) (*MtPrescription, error) {
+	notes = strings.TrimSpace(notes)
+	if !approved && notes == "" {
+		return nil, fmt.Errorf("驳回处方须填写审核意见")
+	}
+	if len([]rune(notes)) > prescriptionAuditNotesLength {
+		return nil, fmt.Errorf("审核意见不能超过%d字", prescriptionAuditNotesLength)
+	}
+
+	// token有效期内药师可能已被停用
+	pharmacist, err := uc.pharmacistRepo.FindByID(ctx, pharmacistID)
+	if err != nil {
+		uc.log.Errorf("查询药师失败: pharmacistId=%d, error=%v", pharmacistID, err)
+		return nil, fmt.Errorf("查询药师失败")
+	}
+	if pharmacist == nil || pharmacist.Status != PharmacistStatusEnabled {
+		return nil, fmt.Errorf("药师账号不可用")
+	}
+
+	prescription, err := uc.getPrescription(ctx, prescriptionID)
+	if err != nil {
+		return nil, err
+	}
+	to := PrescriptionStatusAudited
+	if !approved {
+		to = PrescriptionStatusRejected
+	} else {
+		// 知识库在开具后可能更新，审核通过前重新检查
+		drugCheck, err := uc.CheckPrescription(ctx, prescription)
+		if err != nil {
+			return nil, err
+		}
+		if err := drugCheck.Err(); err != nil {
+			return nil, err
+		}
+	}
+	update := &PrescriptionUpdate{
+		AuditorID:  uint64(pharmacistID),
+		AuditTime:  time.Now(),
+		AuditNotes: notes,
+	}
+	if err := uc.transitStatus(ctx, prescription, to, update); err != nil {
+		return nil, err
+	}
+	uc.log.Infof("药师审核处方: prescriptionNo=%s, pharmacistId=%d, status=%s", prescription.PrescriptionNo, pharmacistID, to)
+	return uc.getPrescription(ctx, prescriptionID)
+}
+
+// 处方下单请求
+type PrescriptionOrderRequest struct {
+	PatientID      int32  `json:"patient_id"`
+	PrescriptionID uint64 `json:"prescription_id"`
+	AddressID      int32  `json:"address_id"` // 为0时使用默认地址
+	UserCouponID   *int64 `json:"user_coupon_id"`
+	Remark         string `json:"remark"`
+}
+
+// 患者将审核通过的处方按明细一键下单，同一处方只能下单一次
+// 订单在创建事务内占用处方，重复提交返回处方已关联的订单
+func (uc *PrescriptionUsecase) CreateOrderFromPrescription(ctx context.Context, req *PrescriptionOrderRequest) (*MtOrder, error) {
+	prescription, err := uc.getPrescription(ctx, req.PrescriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if prescription.PatientID != uint64(req.PatientID) {
+		return nil, fmt.Errorf("处方不存在: ID=%d", req.PrescriptionID)
+	}
+	if prescription.Status == PrescriptionStatusOrdered || prescription.Status == PrescriptionStatusUsed {
+		return uc.prescriptionOrder(ctx, prescription)
+	}
+	if prescription.Status != PrescriptionStatusAudited {
+		return nil, fmt.Errorf("%w: 处方未通过药师审核: %s", ErrInvalidPrescriptionStatusTransition, prescription.Status)
+	}
+
+	address, err := uc.orderAddress(ctx, req.PatientID, req.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	medicines, err := uc.prescriptionRepo.GetPrescriptionMedicines(ctx, prescription.ID)
+	if err != nil {
+		return nil, fmt.Errorf("查询处方药品失败: %v", err)
+	}
+	items := make([]*CreateOrderItem, len(medicines))
+	for i, medicine := range medicines {
+		items[i] = &CreateOrderItem{
+			DrugID:   int64(medicine.MedicineID),
+			Quantity: int32(medicine.Quantity.IntPart()),
+		}
+	}
+
+	remark := "处方单号: " + prescription.PrescriptionNo
+	if req.Remark != "" {
+		remark += "; " + req.Remark
+	}
+	order, err := uc.orderUc.CreateOrder(ctx, &CreateOrderRequest{
+		UserID:         int64(req.PatientID),
+		UserName:       address.Consignee,
+		UserPhone:      address.Mobile,
+		DoctorID:       int64(prescription.DoctorID),
+		DoctorName:     prescription.DoctorName,
+		AddressID:      int64(address.Id),
+		AddressDetail:  address.ShippingAddress + address.DoorplateFloor,
+		Items:          items,
+		UserCouponID:   req.UserCouponID,
+		Remark:         remark,
+		PrescriptionID: prescription.ID,
+		Populations:    prescription.Populations,
+	})
+	if errors.Is(err, ErrPrescriptionStatusConflict) {
+		// 并发的重复请求已经占用了处方
+		if latest, getErr := uc.getPrescription(ctx, prescription.ID); getErr == nil && latest.Status == PrescriptionStatusOrdered {
+			return uc.prescriptionOrder(ctx, latest)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	uc.log.Infof("处方下单: prescriptionNo=%s, orderNo=%s", prescription.PrescriptionNo, order.OrderNo)
+	return order, nil
+}
+
+// 已下单处方对应的订单
+func (uc *PrescriptionUsecase) prescriptionOrder(ctx context.Context, prescription *MtPrescription) (*MtOrder, error) {
+	detail, err := uc.orderUc.GetOrder(ctx, prescription.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+	return detail.Order, nil
+}
+
+// 下单收货地址，未指定时使用默认地址
+func (uc *PrescriptionUsecase) orderAddress(ctx context.Context, patientID, addressID int32) (*MtAddress, error) {
+	addresses, err := uc.cityRepo.GetAddressList(ctx, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("查询收货地址失败: %v", err)
+	}
+	if addresses != nil {
+		// 地址列表按默认地址优先排序
+		for i := range *addresses {
+			if address := &(*addresses)[i]; addressID == 0 || address.Id == addressID {
+				return address, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("请选择收货地址")
+}
+
+func (uc *PrescriptionUsecase) getPrescription(ctx context.Context, prescriptionID uint64) (*MtPrescription, error) {
+	prescription, err := uc.prescriptionRepo.GetPrescriptionByID(ctx, prescriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("查询处方失败: %v", err)
+	}
+	if prescription == nil {
+		return nil, fmt.Errorf("处方不存在: ID=%d", prescriptionID)
+	}
+	return prescription, nil
+}
+
+// 以当前状态为条件流转处方状态，防止并发审核或重复下单
+func (uc *PrescriptionUsecase) transitStatus(ctx context.Context, prescription *MtPrescription, to string, update *PrescriptionUpdate) error {
+	if !CanTransitPrescriptionStatus(prescription.Status, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidPrescriptionStatusTransition, prescription.Status, to)
+	}
+	update.Status = to
+	if err := uc.prescriptionRepo.UpdatePrescriptionStatus(ctx, prescription.ID, prescription.Status, update); err != nil {
+		return fmt.Errorf("更新处方状态失败: %w", err)
+	}
+	prescription.Status = to
+	return nil
+}
+
+// 校验处方状态流转是否合法
+func CanTransitPrescriptionStatus(from, to string) bool {
+	for _, next := range prescriptionStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+const prescriptionAuditNotesLength = 500
+
+// 处方自开具起的有效期
+const PrescriptionValidity = 3 * 24 * time.Hour
+
 // 处方状态常量
 const (
 	PrescriptionStatusCancelled = "已取消"
 	PrescriptionStatusIssued    = "已开具"
 	PrescriptionStatusAudited   = "已审核"
 	PrescriptionStatusDispensed = "已发药"
+	PrescriptionStatusRejected  = "已驳回" // 药师审核驳回
+	PrescriptionStatusOrdered   = "已下单" // 已被待支付订单占用
+	PrescriptionStatusUsed      = "已使用" // 关联订单已支付，处方不能再次使用
 )
 
+// 处方状态机：医生开具 -> 药师审核通过或驳回，未审核前医生可撤销 -> 审核通过后下单占用
+// -> 订单支付后已使用，订单取消或超时关闭时恢复为已审核
+var prescriptionStatusTransitions = map[string][]string{
+	PrescriptionStatusIssued:  {PrescriptionStatusAudited, PrescriptionStatusRejected, PrescriptionStatusCancelled},
+	PrescriptionStatusAudited: {PrescriptionStatusOrdered},
+	PrescriptionStatusOrdered: {PrescriptionStatusUsed, PrescriptionStatusAudited},
+}
+
 // 处方类型常量
 const (
 	PrescriptionTypeWestern  = "西药"
@@ -199,6 +600,12 @@ func GetPrescriptionStatusText(status string) string {
 		return "已审核"
 	case PrescriptionStatusDispensed:
 		return "已发药"
+	case PrescriptionStatusRejected:
+		return "已驳回"
+	case PrescriptionStatusOrdered:
+		return "已下单"
+	case PrescriptionStatusUsed:
+		return "已使用"
 	default:
 		return "未知状态"
 	}
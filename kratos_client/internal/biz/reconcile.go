@@ -0,0 +1,338 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+)
+
+// 对账单交易类型
+const (
+	StatementTypePayment = "payment" // 支付
+	StatementTypeRefund  = "refund"  // 退款
+)
+
+// 对账差异类型
+const (
+	ReconcileIssueMissingLocal   = "missing_local"   // 渠道有交易，本地无支付单
+	ReconcileIssueMissingRemote  = "missing_remote"  // 本地已支付，渠道账单无此交易
+	ReconcileIssueDuplicate      = "duplicate"       // 账单中交易重复，或同一支付单在渠道有多笔交易
+	ReconcileIssueAmountMismatch = "amount_mismatch" // 渠道金额与支付单或订单金额不一致
+	ReconcileIssueUnpaidLocal    = "unpaid_local"    // 渠道已支付，本地支付单或订单未标记支付
+)
+
+// 对账单中的一笔交易
+type StatementRecord struct {
+	TradeNo   string          // 渠道交易号
+	OrderID   string          // 商户订单号，即支付订单号
+	Type      string          // StatementTypePayment 或 StatementTypeRefund
+	Amount    decimal.Decimal // 交易金额，单位元
+	RefundID  string          // 退款请求号，仅退款交易
+	TradeTime time.Time       // 完成时间
+}
+
+// 渠道对账单
+type Statement struct {
+	Channel   string             // 支付渠道
+	StartTime time.Time          // 账单起始时间
+	EndTime   time.Time          // 账单终止时间，不含
+	Records   []*StatementRecord // 交易明细
+}
+
+// 对账单解析接口，每个支付渠道的账单格式各自实现
+type StatementParser interface {
+	// 账单所属的支付渠道
+	Channel() string
+	// 解析账单文件
+	Parse(r io.Reader) (*Statement, error)
+}
+
+// 对账选项
+type ReconcileOptions struct {
+	DryRun bool // 只报告差异，不修复订单
+}
+
+// 对账差异
+type ReconcileIssue struct {
+	Type            string `json:"type"`             // 差异类型
+	OrderID         string `json:"order_id"`         // 支付订单号
+	TradeNo         string `json:"trade_no"`         // 渠道交易号
+	BusinessID      string `json:"business_id"`      // 业务订单号
+	StatementAmount string `json:"statement_amount"` // 渠道金额
+	LocalAmount     string `json:"local_amount"`     // 本地金额
+	Detail          string `json:"detail"`           // 差异说明
+	Healed          bool   `json:"healed"`           // 是否已自动修复
+}
+
+// 对账报告
+type ReconcileReport struct {
+	Channel      string            `json:"channel"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      time.Time         `json:"end_time"`
+	DryRun       bool              `json:"dry_run"`
+	PaymentCount int               `json:"payment_count"` // 账单支付交易数
+	SkippedCount int               `json:"skipped_count"` // 跳过的非支付交易数
+	MatchedCount int               `json:"matched_count"` // 一致的交易数
+	HealedCount  int               `json:"healed_count"`  // 自动修复数
+	Issues       []*ReconcileIssue `json:"issues"`        // 差异明细
+}
+
+// 对账用例
+type ReconcileUsecase struct {
+	parser      StatementParser
+	paymentRepo PaymentRepo
+	orderRepo   OrderRepo
+	orderUc     *OrderUsecase
+	log         *log.Helper
+}
+
+// 创建对账用例
+func NewReconcileUsecase(parser StatementParser, paymentRepo PaymentRepo, orderRepo OrderRepo, orderUc *OrderUsecase, logger log.Logger) *ReconcileUsecase {
+	return &ReconcileUsecase{
+		parser:      parser,
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		orderUc:     orderUc,
+		log:         log.NewHelper(logger),
+	}
+}
+
+// 解析渠道账单并与支付单、药品订单核对，渠道已支付但本地未标记支付的订单自动补记支付
+func (uc *ReconcileUsecase) Reconcile(ctx context.Context, r io.Reader, opts ReconcileOptions) (*ReconcileReport, error) {
+	statement, err := uc.parser.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("解析对账单失败: %v", err)
+	}
+
+	report := &ReconcileReport{
+		Channel:   statement.Channel,
+		StartTime: statement.StartTime,
+		EndTime:   statement.EndTime,
+		DryRun:    opts.DryRun,
+	}
+
+	// 按支付单号归集支付交易，同一交易号重复出现只核对一次
+	trades := make(map[string][]*StatementRecord)
+	seen := make(map[string]bool)
+	var orderIDs []string
+	for _, record := range statement.Records {
+		if record.Type != StatementTypePayment {
+			report.SkippedCount++
+			continue
+		}
+		report.PaymentCount++
+		if seen[record.TradeNo] {
+			report.Issues = append(report.Issues, &ReconcileIssue{
+				Type:            ReconcileIssueDuplicate,
+				OrderID:         record.OrderID,
+				TradeNo:         record.TradeNo,
+				StatementAmount: record.Amount.StringFixed(2),
+				Detail:          "账单中交易号重复",
+			})
+			continue
+		}
+		seen[record.TradeNo] = true
+		if _, ok := trades[record.OrderID]; !ok {
+			orderIDs = append(orderIDs, record.OrderID)
+		}
+		trades[record.OrderID] = append(trades[record.OrderID], record)
+	}
+
+	payments, err := uc.paymentRepo.GetPaymentOrdersByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("查询支付单失败: %v", err)
+	}
+	paymentMap := make(map[string]*PaymentOrder, len(payments))
+	for _, payment := range payments {
+		paymentMap[payment.OrderID] = payment
+	}
+
+	for _, orderID := range orderIDs {
+		records := trades[orderID]
+		payment := paymentMap[orderID]
+		if payment == nil {
+			for _, record := range records {
+				report.Issues = append(report.Issues, &ReconcileIssue{
+					Type:            ReconcileIssueMissingLocal,
+					OrderID:         record.OrderID,
+					TradeNo:         record.TradeNo,
+					StatementAmount: record.Amount.StringFixed(2),
+					Detail:          "本地无此支付单",
+				})
+			}
+			continue
+		}
+
+		// 同一支付单在渠道有多笔交易，以与支付单交易号一致的一笔为准，其余为重复支付
+		record := records[0]
+		if len(records) > 1 {
+			for _, r := range records {
+				if r.TradeNo == payment.TradeNo {
+					record = r
+				}
+			}
+			for _, r := range records {
+				if r == record {
+					continue
+				}
+				report.Issues = append(report.Issues, &ReconcileIssue{
+					Type:            ReconcileIssueDuplicate,
+					OrderID:         orderID,
+					TradeNo:         r.TradeNo,
+					BusinessID:      payment.BusinessID,
+					StatementAmount: r.Amount.StringFixed(2),
+					LocalAmount:     payment.TotalAmount,
+					Detail:          "同一支付单重复支付，需人工退款",
+				})
+			}
+		}
+
+		if issue := uc.reconcilePayment(ctx, payment, record, opts); issue != nil {
+			report.Issues = append(report.Issues, issue)
+			if issue.Healed {
+				report.HealedCount++
+			}
+		} else {
+			report.MatchedCount++
+		}
+	}
+
+	// 账单时间范围内本地已支付但渠道无交易的支付单
+	if !statement.StartTime.IsZero() && !statement.EndTime.IsZero() {
+		paid, err := uc.paymentRepo.ListPaidPaymentOrders(ctx, statement.Channel, statement.StartTime, statement.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("查询已支付支付单失败: %v", err)
+		}
+		for _, payment := range paid {
+			if _, ok := trades[payment.OrderID]; ok {
+				continue
+			}
+			report.Issues = append(report.Issues, &ReconcileIssue{
+				Type:        ReconcileIssueMissingRemote,
+				OrderID:     payment.OrderID,
+				TradeNo:     payment.TradeNo,
+				BusinessID:  payment.BusinessID,
+				LocalAmount: payment.TotalAmount,
+				Detail:      "渠道账单无此交易",
+			})
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Type < report.Issues[j].Type
+	})
+
+	uc.log.Infof("对账完成: channel=%s, payments=%d, matched=%d, issues=%d, healed=%d, dryRun=%v",
+		report.Channel, report.PaymentCount, report.MatchedCount, len(report.Issues), report.HealedCount, opts.DryRun)
+	return report, nil
+}
+
+// 核对单笔支付，一致时返回nil
+func (uc *ReconcileUsecase) reconcilePayment(ctx context.Context, payment *PaymentOrder, record *StatementRecord, opts ReconcileOptions) *ReconcileIssue {
+	issue := &ReconcileIssue{
+		OrderID:         payment.OrderID,
+		TradeNo:         record.TradeNo,
+		BusinessID:      payment.BusinessID,
+		StatementAmount: record.Amount.StringFixed(2),
+		LocalAmount:     payment.TotalAmount,
+	}
+
+	localAmount, err := decimal.NewFromString(payment.TotalAmount)
+	if err != nil || !localAmount.Equal(record.Amount) {
+		issue.Type = ReconcileIssueAmountMismatch
+		issue.Detail = "渠道金额与支付单金额不一致"
+		return issue
+	}
+
+	paymentUnpaid := payment.Status != PaymentStatusPaid && payment.Status != PaymentStatusRefunded
+
+	var order *MtOrder
+	if payment.OrderType == OrderTypeDrug {
+		order, err = uc.orderRepo.GetOrderByOrderNo(ctx, payment.BusinessID)
+		if err != nil {
+			issue.Type = ReconcileIssueUnpaidLocal
+			issue.Detail = fmt.Sprintf("查询药品订单失败: %v", err)
+			return issue
+		}
+		if order != nil && !order.TotalAmount.Equal(record.Amount) {
+			issue.Type = ReconcileIssueAmountMismatch
+			issue.LocalAmount = order.TotalAmount.StringFixed(2)
+			issue.Detail = "渠道金额与药品订单金额不一致"
+			return issue
+		}
+	}
+	orderUnpaid := order != nil && order.Status == OrderStatusPending
+
+	// 订单已取消而渠道已扣款，不能补记支付，只能退款
+	if order != nil && order.Status == OrderStatusCancelled && payment.Status != PaymentStatusRefunded {
+		issue.Type = ReconcileIssueUnpaidLocal
+		issue.Detail = "订单已取消但渠道已支付，需人工退款"
+		return issue
+	}
+	if !paymentUnpaid && !orderUnpaid {
+		return nil
+	}
+
+	issue.Type = ReconcileIssueUnpaidLocal
+	if opts.DryRun {
+		issue.Detail = "渠道已支付，本地未标记支付"
+		return issue
+	}
+
+	if err := uc.heal(ctx, payment, order, record, paymentUnpaid, orderUnpaid); err != nil {
+		uc.log.Errorf("对账修复失败: orderID=%s, error=%v", payment.OrderID, err)
+		issue.Detail = fmt.Sprintf("渠道已支付，本地未标记支付，自动修复失败: %v", err)
+		return issue
+	}
+	issue.Healed = true
+	issue.Detail = "渠道已支付，本地未标记支付，已自动补记"
+	return issue
+}
+
+// 补记支付：先完成药品订单支付，再标记支付单，订单处理失败时支付单保持原状态等待下次对账
+func (uc *ReconcileUsecase) heal(ctx context.Context, payment *PaymentOrder, order *MtOrder, record *StatementRecord, paymentUnpaid, orderUnpaid bool) error {
+	payTime := record.TradeTime
+	if payTime.IsZero() {
+		payTime = time.Now()
+	}
+
+	if orderUnpaid {
+		payType := order.PayType
+		if payType == "" {
+			payType = channelPayType(payment.Channel)
+		}
+		err := uc.orderUc.ProcessPayment(ctx, order.OrderNo, &PaymentInfo{
+			PayType:     payType,
+			Amount:      record.Amount,
+			TradeNo:     record.TradeNo,
+			PaymentTime: payTime,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if paymentUnpaid {
+		if err := uc.paymentRepo.UpdatePaymentOrderStatus(ctx, payment.OrderID, PaymentStatusPaid, record.TradeNo, payTime); err != nil {
+			return err
+		}
+	}
+
+	uc.log.Infof("对账补记支付: orderID=%s, businessID=%s, tradeNo=%s", payment.OrderID, payment.BusinessID, record.TradeNo)
+	return nil
+}
+
+// 支付渠道对应的支付方式
+func channelPayType(channel string) string {
+	switch channel {
+	case PaymentChannelWechat:
+		return PayTypeWechat
+	default:
+		return PayTypeAlipay
+	}
+}
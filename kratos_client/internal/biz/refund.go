@@ -0,0 +1,596 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+)
+
+// 退款单状态
+const (
+	RefundStatusRequested  = "requested"  // 已申请，待后台审核
+	RefundStatusApproved   = "approved"   // 审核通过，待发起渠道退款
+	RefundStatusRejected   = "rejected"   // 审核驳回
+	RefundStatusProcessing = "processing" // 已提交支付渠道，等待结果
+	RefundStatusSucceeded  = "succeeded"  // 退款成功
+	RefundStatusFailed     = "failed"     // 退款失败
+)
+
+// 退款明细，按订单项记录本次退款的数量和金额
+type RefundItem struct {
+	ID          int64           `json:"id"`
+	RefundID    string          `json:"refund_id"`     // 退款单号
+	OrderItemID int64           `json:"order_item_id"` // 订单项ID
+	DrugID      int64           `json:"drug_id"`       // 药品ID
+	DrugName    string          `json:"drug_name"`     // 药品名称
+	Quantity    int32           `json:"quantity"`      // 退款数量
+	Amount      decimal.Decimal `json:"amount"`        // 退款金额
+	CreatedAt   time.Time       `json:"created_at"`    // 创建时间
+}
+
+// 申请退款请求
+type RefundRequest struct {
+	OrderNo string               `json:"order_no"`
+	UserID  int64                `json:"user_id"`
+	Items   []*RefundItemRequest `json:"items"`
+	Reason  string               `json:"reason"`
+}
+
+// 申请退款的订单项
+type RefundItemRequest struct {
+	OrderItemID int64 `json:"order_item_id"`
+	Quantity    int32 `json:"quantity"`
+}
+
+// 支付渠道退款请求
+type GatewayRefundRequest struct {
+	RefundID       string // 退款单号，作为渠道侧的退款请求号，重复提交不会重复退款
+	PaymentOrderID string // 支付订单号
+	TradeNo        string // 渠道交易号
+	Amount         string // 退款金额
+	TotalAmount    string // 原支付金额
+	Reason         string // 退款原因
+}
+
+// 支付渠道退款结果
+type GatewayRefundResult struct {
+	RefundID        string    // 退款单号
+	GatewayRefundNo string    // 渠道退款流水号
+	Status          string    // RefundStatusSucceeded、RefundStatusFailed 或 RefundStatusProcessing
+	Amount          string    // 退款金额
+	FailReason      string    // 失败原因
+	RefundTime      time.Time // 退款时间
+}
+
+// 退款单状态变更
+type RefundStatusUpdate struct {
+	Status          string
+	GatewayRefundNo string
+	FailReason      string
+	RefundTime      time.Time
+}
+
+// 退款仓储接口，查询退款单时一并返回退款明细
+type RefundRepo interface {
+	CreateRefund(ctx context.Context, record *RefundRecord) error
+	GetRefund(ctx context.Context, refundID string) (*RefundRecord, error)
+	ListRefundsByOrderNo(ctx context.Context, orderNo string) ([]*RefundRecord, error)
+	// 按申请时间正序查询指定状态的退款单
+	ListRefundsByStatus(ctx context.Context, status string, limit int) ([]*RefundRecord, error)
+	// 仅当退款单当前状态为fromStatus时才更新，否则返回ErrRefundStatusConflict
+	UpdateRefundStatus(ctx context.Context, refundID, fromStatus string, update *RefundStatusUpdate) error
+
+	// 占用订单项可退数量，超出时返回ErrRefundQuantityExceeded
+	ReserveRefundQuantity(ctx context.Context, orderItemID int64, quantity int32) error
+	// 退款被驳回或失败时归还可退数量
+	ReleaseRefundQuantity(ctx context.Context, orderItemID int64, quantity int32) error
+}
+
+// 退款用例
+type RefundUsecase struct {
+	repo          RefundRepo
+	orderRepo     OrderRepo
+	paymentRepo   PaymentRepo
+	inventoryRepo DrugInventoryRepo
+	orderUc       *OrderUsecase
+	inventoryUc   *InventoryUsecase
+	gateways      *PaymentGateways
+	log           *log.Helper
+}
+
+// 创建退款用例
+func NewRefundUsecase(
+	repo RefundRepo,
+	orderRepo OrderRepo,
+	paymentRepo PaymentRepo,
+	inventoryRepo DrugInventoryRepo,
+	orderUc *OrderUsecase,
+	inventoryUc *InventoryUsecase,
+	gateways *PaymentGateways,
+	logger log.Logger,
+) *RefundUsecase {
+	return &RefundUsecase{
+		repo:          repo,
+		orderRepo:     orderRepo,
+		paymentRepo:   paymentRepo,
+		inventoryRepo: inventoryRepo,
+		orderUc:       orderUc,
+		inventoryUc:   inventoryUc,
+		gateways:      gateways,
+		log:           log.NewHelper(logger),
+	}
+}
+
+// 申请退款，按订单项部分退款，退款单创建后等待后台审核
+func (uc *RefundUsecase) RequestRefund(ctx context.Context, req *RefundRequest) (*RefundRecord, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("请选择退款的药品")
+	}
+	if req.Reason == "" {
+		return nil, fmt.Errorf("请填写退款原因")
+	}
+
+	// 合并同一订单项的退款数量
+	quantities := make(map[int64]int32)
+	var itemIDs []int64
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("退款数量必须大于0: orderItemID=%d", item.OrderItemID)
+		}
+		if _, ok := quantities[item.OrderItemID]; !ok {
+			itemIDs = append(itemIDs, item.OrderItemID)
+		}
+		quantities[item.OrderItemID] += item.Quantity
+	}
+
+	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, req.OrderNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询订单失败: %v", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("订单不存在: %s", req.OrderNo)
+	}
+	if order.UserID != req.UserID {
+		return nil, fmt.Errorf("无权操作此订单")
+	}
+	if !CanTransitOrderStatus(order.Status, OrderStatusRefunded) {
+		return nil, fmt.Errorf("%w: 订单状态不允许退款: %s", ErrInvalidOrderStatusTransition, order.Status)
+	}
+
+	// 原路退回，需要找到订单对应的已支付支付单
+	payment, err := uc.paymentRepo.GetPaidPaymentOrderByBusinessID(ctx, OrderTypeDrug, order.OrderNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询支付单失败: %v", err)
+	}
+	if payment == nil {
+		return nil, fmt.Errorf("订单没有已支付的支付单，无法原路退款: %s", order.OrderNo)
+	}
+
+	orderItems, err := uc.orderRepo.GetOrderItems(ctx, int64(order.ID))
+	if err != nil {
+		return nil, fmt.Errorf("获取订单项失败: %v", err)
+	}
+	itemMap := make(map[int64]*MtOrderItem, len(orderItems))
+	for _, item := range orderItems {
+		itemMap[item.ID] = item
+	}
+
+	now := time.Now()
+	refund := &RefundRecord{
+		OrderID:      payment.OrderID,
+		OrderNo:      order.OrderNo,
+		UserID:       order.UserID,
+		RefundID:     fmt.Sprintf("REFUND_%d_%d", order.UserID, now.UnixNano()),
+		RefundReason: req.Reason,
+		RefundStatus: RefundStatusRequested,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	// 使用了优惠券的订单按实付比例退款
+	ratio := decimal.NewFromInt(1)
+	if order.OriginalAmount.IsPositive() {
+		ratio = order.TotalAmount.Div(order.OriginalAmount)
+	}
+
+	refundAmount := decimal.Zero
+	for _, id := range itemIDs {
+		item, ok := itemMap[id]
+		if !ok {
+			return nil, fmt.Errorf("订单项不属于该订单: orderItemID=%d", id)
+		}
+		amount := item.Price.Mul(decimal.NewFromInt32(quantities[id])).Mul(ratio).Round(2)
+		refund.Items = append(refund.Items, &RefundItem{
+			RefundID:    refund.RefundID,
+			OrderItemID: item.ID,
+			DrugID:      item.DrugID,
+			DrugName:    item.DrugName,
+			Quantity:    quantities[id],
+			Amount:      amount,
+			CreatedAt:   now,
+		})
+		refundAmount = refundAmount.Add(amount)
+	}
+
+	err = uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
+		// 以可退数量为条件占用，并发申请不会超退
+		for _, item := range refund.Items {
+			if err := uc.repo.ReserveRefundQuantity(ctx, item.OrderItemID, item.Quantity); err != nil {
+				return fmt.Errorf("占用可退数量失败: orderItemID=%d, error=%w", item.OrderItemID, err)
+			}
+		}
+
+		// 本次退完剩余全部药品时，退款金额取实付金额减去其他退款单金额，避免按比例取整产生差额
+		fullyRefunded, err := uc.allItemsRefunded(ctx, int64(order.ID), func(item *MtOrderItem) int32 { return item.RefundedQty })
+		if err != nil {
+			return err
+		}
+		if fullyRefunded {
+			others, err := uc.activeRefundAmount(ctx, order.OrderNo)
+			if err != nil {
+				return err
+			}
+			remaining := order.TotalAmount.Sub(others)
+			last := refund.Items[len(refund.Items)-1]
+			last.Amount = last.Amount.Add(remaining.Sub(refundAmount))
+			refundAmount = remaining
+		}
+		if !refundAmount.IsPositive() {
+			return fmt.Errorf("退款金额必须大于0")
+		}
+		refund.RefundAmount = refundAmount.StringFixed(2)
+
+		return uc.repo.CreateRefund(ctx, refund)
+	})
+	if err != nil {
+		uc.log.Errorf("申请退款失败: orderNo=%s, error=%v", req.OrderNo, err)
+		return nil, err
+	}
+
+	uc.log.Infof("申请退款成功: refundID=%s, orderNo=%s, amount=%s", refund.RefundID, order.OrderNo, refund.RefundAmount)
+	return refund, nil
+}
+
+// 整单退回非药品支付单，如医生未接诊的问诊费。退款单直接审核通过，由退款任务提交支付渠道
+// 退款单号由支付单号生成，同一支付单重复调用返回已有的退款单
+func (uc *RefundUsecase) RefundPayment(ctx context.Context, payment *PaymentOrder, reason string) (*RefundRecord, error) {
+	if payment.OrderType == OrderTypeDrug {
+		return nil, fmt.Errorf("药品订单须按订单项申请退款")
+	}
+	if payment.Status != PaymentStatusPaid {
+		return nil, fmt.Errorf("支付单未支付，无法退款: %s", payment.OrderID)
+	}
+
+	refundID := "REFUND_" + payment.OrderID
+	existing, err := uc.repo.GetRefund(ctx, refundID)
+	if err != nil {
+		return nil, fmt.Errorf("查询退款单失败: %v", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	now := time.Now()
+	refund := &RefundRecord{
+		OrderID:      payment.OrderID,
+		UserID:       int64(payment.UserID),
+		RefundID:     refundID,
+		RefundAmount: payment.TotalAmount,
+		RefundReason: reason,
+		RefundStatus: RefundStatusApproved,
+		AuditTime:    &now,
+		AuditRemark:  reason,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := uc.repo.CreateRefund(ctx, refund); err != nil {
+		uc.log.Errorf("创建退款单失败: orderID=%s, error=%v", payment.OrderID, err)
+		return nil, err
+	}
+
+	uc.log.Infof("支付单整单退款: refundID=%s, orderID=%s, amount=%s", refundID, payment.OrderID, refund.RefundAmount)
+	return refund, nil
+}
+
+// 查询订单的退款单
+func (uc *RefundUsecase) ListOrderRefunds(ctx context.Context, orderNo string, userID int64) ([]*RefundRecord, error) {
+	order, err := uc.orderRepo.GetOrderByOrderNo(ctx, orderNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询订单失败: %v", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("订单不存在: %s", orderNo)
+	}
+	if order.UserID != userID {
+		return nil, fmt.Errorf("无权查看此订单")
+	}
+
+	refunds, err := uc.repo.ListRefundsByOrderNo(ctx, orderNo)
+	if err != nil {
+		uc.log.Errorf("查询退款单失败: orderNo=%s, error=%v", orderNo, err)
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// 对审核通过的退款单发起渠道退款，返回本轮提交的退款单数量
+func (uc *RefundUsecase) ProcessApprovedRefunds(ctx context.Context, limit int) (int, error) {
+	refunds, err := uc.repo.ListRefundsByStatus(ctx, RefundStatusApproved, limit)
+	if err != nil {
+		return 0, fmt.Errorf("查询待退款单失败: %v", err)
+	}
+
+	processed := 0
+	for _, refund := range refunds {
+		if err := uc.processRefund(ctx, refund); err != nil {
+			uc.log.Errorf("发起退款失败: refundID=%s, error=%v", refund.RefundID, err)
+			continue
+		}
+		processed++
+	}
+
+	if processed > 0 {
+		uc.log.Infof("发起退款完成: count=%d", processed)
+	}
+	return processed, nil
+}
+
+func (uc *RefundUsecase) processRefund(ctx context.Context, refund *RefundRecord) error {
+	// 先标记为处理中，多实例或重复扫描时只有一个能提交到渠道
+	if err := uc.repo.UpdateRefundStatus(ctx, refund.RefundID, RefundStatusApproved, &RefundStatusUpdate{Status: RefundStatusProcessing}); err != nil {
+		if errors.Is(err, ErrRefundStatusConflict) {
+			return nil
+		}
+		return err
+	}
+
+	payment, err := uc.paymentRepo.GetPaymentOrderByOrderID(ctx, refund.OrderID)
+	if err != nil {
+		return fmt.Errorf("查询支付单失败: %v", err)
+	}
+	if payment == nil {
+		return uc.failRefund(ctx, refund.RefundID, "支付单不存在")
+	}
+
+	gateway, err := uc.gateways.ByChannel(payment.Channel)
+	if err != nil {
+		return uc.failRefund(ctx, refund.RefundID, "支付渠道未启用: "+payment.Channel)
+	}
+
+	result, err := gateway.Refund(ctx, &GatewayRefundRequest{
+		RefundID:       refund.RefundID,
+		PaymentOrderID: payment.OrderID,
+		TradeNo:        payment.TradeNo,
+		Amount:         refund.RefundAmount,
+		TotalAmount:    payment.TotalAmount,
+		Reason:         refund.RefundReason,
+	})
+	if err != nil {
+		// 渠道调用异常时结果未知，退回审核通过状态等待下一轮重试，渠道按退款单号去重
+		if revertErr := uc.repo.UpdateRefundStatus(ctx, refund.RefundID, RefundStatusProcessing, &RefundStatusUpdate{Status: RefundStatusApproved}); revertErr != nil {
+			uc.log.Errorf("退款单重置为待退款失败: refundID=%s, error=%v", refund.RefundID, revertErr)
+		}
+		return fmt.Errorf("调用支付渠道退款失败: %v", err)
+	}
+
+	switch result.Status {
+	case RefundStatusSucceeded:
+		return uc.completeRefund(ctx, refund.RefundID, result)
+	case RefundStatusFailed:
+		return uc.failRefund(ctx, refund.RefundID, result.FailReason)
+	default:
+		// 渠道已受理，等待退款通知
+		uc.log.Infof("退款已提交支付渠道: refundID=%s", refund.RefundID)
+		return nil
+	}
+}
+
+// 处理支付渠道的退款通知
+func (uc *RefundUsecase) HandleRefundNotify(ctx context.Context, channel string, notify *GatewayNotify) error {
+	gateway, err := uc.gateways.ByChannel(channel)
+	if err != nil {
+		return err
+	}
+	result, err := gateway.ParseRefundNotify(ctx, notify)
+	if err != nil {
+		return fmt.Errorf("解析退款通知失败: %v", err)
+	}
+
+	refund, err := uc.repo.GetRefund(ctx, result.RefundID)
+	if err != nil {
+		return fmt.Errorf("查询退款单失败: %v", err)
+	}
+	if refund == nil {
+		return fmt.Errorf("退款单不存在: %s", result.RefundID)
+	}
+	if result.Amount != "" && !amountEqual(result.Amount, refund.RefundAmount) {
+		return fmt.Errorf("退款金额不匹配: expected=%s, actual=%s", refund.RefundAmount, result.Amount)
+	}
+
+	switch result.Status {
+	case RefundStatusSucceeded:
+		return uc.completeRefund(ctx, refund.RefundID, result)
+	case RefundStatusFailed:
+		return uc.failRefund(ctx, refund.RefundID, result.FailReason)
+	}
+	return nil
+}
+
+// 退款成功：退回库存，订单全部退完时流转为已退款。重复通知时状态条件不满足，直接忽略
+func (uc *RefundUsecase) completeRefund(ctx context.Context, refundID string, result *GatewayRefundResult) error {
+	refundTime := result.RefundTime
+	if refundTime.IsZero() {
+		refundTime = time.Now()
+	}
+
+	var refund *RefundRecord
+	err := uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateRefundStatus(ctx, refundID, RefundStatusProcessing, &RefundStatusUpdate{
+			Status:          RefundStatusSucceeded,
+			GatewayRefundNo: result.GatewayRefundNo,
+			RefundTime:      refundTime,
+		}); err != nil {
+			return err
+		}
+
+		var err error
+		refund, err = uc.repo.GetRefund(ctx, refundID)
+		if err != nil {
+			return fmt.Errorf("查询退款单失败: %v", err)
+		}
+
+		for _, item := range refund.Items {
+			if err := uc.inventoryRepo.RestockInventory(ctx, item.DrugID, item.Quantity, refundID); err != nil {
+				return fmt.Errorf("退款入库失败: drugID=%d, error=%v", item.DrugID, err)
+			}
+		}
+
+		// 问诊等非药品支付单整单退款，没有药品订单
+		if refund.OrderNo == "" {
+			return uc.paymentRepo.UpdatePaymentOrderStatus(ctx, refund.OrderID, PaymentStatusRefunded, "", time.Time{})
+		}
+
+		order, err := uc.orderRepo.GetOrderByOrderNo(ctx, refund.OrderNo)
+		if err != nil {
+			return fmt.Errorf("查询订单失败: %v", err)
+		}
+		if order == nil {
+			return fmt.Errorf("订单不存在: %s", refund.OrderNo)
+		}
+
+		succeeded, err := uc.succeededQuantities(ctx, refund.OrderNo)
+		if err != nil {
+			return err
+		}
+		fullyRefunded, err := uc.allItemsRefunded(ctx, int64(order.ID), func(item *MtOrderItem) int32 { return succeeded[item.ID] })
+		if err != nil {
+			return err
+		}
+		if !fullyRefunded {
+			return nil
+		}
+
+		reason := fmt.Sprintf("全部退款成功: refundID=%s", refundID)
+		if err := uc.orderUc.transitStatus(ctx, order, OrderStatusRefunded, SystemOperator, reason, refundTime); err != nil {
+			return err
+		}
+		return uc.paymentRepo.UpdatePaymentOrderStatus(ctx, refund.OrderID, PaymentStatusRefunded, "", time.Time{})
+	})
+	if err != nil {
+		if errors.Is(err, ErrRefundStatusConflict) {
+			uc.log.Infof("退款单已处理，忽略重复结果: refundID=%s", refundID)
+			return nil
+		}
+		uc.log.Errorf("处理退款成功失败: refundID=%s, error=%v", refundID, err)
+		return err
+	}
+
+	drugIDs := make([]int64, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		drugIDs = append(drugIDs, item.DrugID)
+	}
+	uc.inventoryUc.CheckStockAlerts(ctx, drugIDs...)
+
+	uc.log.Infof("退款成功: refundID=%s, orderNo=%s, amount=%s", refundID, refund.OrderNo, refund.RefundAmount)
+	return nil
+}
+
+// 退款失败：归还订单项可退数量，用户可重新申请
+func (uc *RefundUsecase) failRefund(ctx context.Context, refundID, reason string) error {
+	if reason == "" {
+		reason = "支付渠道退款失败"
+	}
+
+	err := uc.orderRepo.WithTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateRefundStatus(ctx, refundID, RefundStatusProcessing, &RefundStatusUpdate{
+			Status:     RefundStatusFailed,
+			FailReason: reason,
+		}); err != nil {
+			return err
+		}
+
+		refund, err := uc.repo.GetRefund(ctx, refundID)
+		if err != nil {
+			return fmt.Errorf("查询退款单失败: %v", err)
+		}
+		for _, item := range refund.Items {
+			if err := uc.repo.ReleaseRefundQuantity(ctx, item.OrderItemID, item.Quantity); err != nil {
+				return fmt.Errorf("归还可退数量失败: orderItemID=%d, error=%v", item.OrderItemID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrRefundStatusConflict) {
+			uc.log.Infof("退款单已处理，忽略重复结果: refundID=%s", refundID)
+			return nil
+		}
+		uc.log.Errorf("处理退款失败结果失败: refundID=%s, error=%v", refundID, err)
+		return err
+	}
+
+	uc.log.Warnf("退款失败: refundID=%s, reason=%s", refundID, reason)
+	return nil
+}
+
+// 订单每一项的数量是否都已退完
+func (uc *RefundUsecase) allItemsRefunded(ctx context.Context, orderID int64, refunded func(item *MtOrderItem) int32) (bool, error) {
+	items, err := uc.orderRepo.GetOrderItems(ctx, orderID)
+	if err != nil {
+		return false, fmt.Errorf("获取订单项失败: %v", err)
+	}
+	for _, item := range items {
+		if refunded(item) < item.Quantity {
+			return false, nil
+		}
+	}
+	return len(items) > 0, nil
+}
+
+// 订单各订单项已退款成功的数量
+func (uc *RefundUsecase) succeededQuantities(ctx context.Context, orderNo string) (map[int64]int32, error) {
+	refunds, err := uc.repo.ListRefundsByOrderNo(ctx, orderNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询退款单失败: %v", err)
+	}
+	quantities := make(map[int64]int32)
+	for _, refund := range refunds {
+		if refund.RefundStatus != RefundStatusSucceeded {
+			continue
+		}
+		for _, item := range refund.Items {
+			quantities[item.OrderItemID] += item.Quantity
+		}
+	}
+	return quantities, nil
+}
+
+// 订单未被驳回或失败的退款单金额合计
+func (uc *RefundUsecase) activeRefundAmount(ctx context.Context, orderNo string) (decimal.Decimal, error) {
+	refunds, err := uc.repo.ListRefundsByOrderNo(ctx, orderNo)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("查询退款单失败: %v", err)
+	}
+	total := decimal.Zero
+	for _, refund := range refunds {
+		if refund.RefundStatus == RefundStatusRejected || refund.RefundStatus == RefundStatusFailed {
+			continue
+		}
+		amount, err := decimal.NewFromString(refund.RefundAmount)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("退款金额格式错误: refundID=%s, amount=%s", refund.RefundID, refund.RefundAmount)
+		}
+		total = total.Add(amount)
+	}
+	return total, nil
+}
+
+func amountEqual(a, b string) bool {
+	da, errA := decimal.NewFromString(a)
+	db, errB := decimal.NewFromString(b)
+	return errA == nil && errB == nil && da.Equal(db)
+}
@@ -0,0 +1,405 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 排班例外类型
+const (
+	ScheduleExceptionOff   = "off"   // 停诊，未填时间表示全天停诊
+	ScheduleExceptionExtra = "extra" // 加诊
+)
+
+// 号源时段状态
+const (
+	ScheduleSlotOpen   = "open"   // 可预约
+	ScheduleSlotClosed = "closed" // 已停诊
+)
+
+// 预约状态
+const (
+	AppointmentStatusBooked    = "booked"    // 已预约
+	AppointmentStatusCancelled = "cancelled" // 已取消
+)
+
+const (
+	scheduleDateLayout  = "2006-01-02"
+	scheduleClockLayout = "15:04"
+	defaultSlotDays     = 7
+	maxSlotDays         = 14
+)
+
+// 医生每周排班模板
+type DoctorSchedule struct {
+	ID          int32  `json:"id"`
+	DoctorID    int32  `json:"doctor_id"`
+	Weekday     int32  `json:"weekday"`      // 1-7表示周一到周日
+	StartTime   string `json:"start_time"`   // 出诊开始时间，格式15:04
+	EndTime     string `json:"end_time"`     // 出诊结束时间
+	SlotMinutes int32  `json:"slot_minutes"` // 每个时段的分钟数
+	Capacity    int32  `json:"capacity"`     // 每个时段的号源数
+}
+
+// 医生排班例外，停诊时段内不生成号源，加诊时段额外生成号源
+type ScheduleException struct {
+	ID          int32  `json:"id"`
+	DoctorID    int32  `json:"doctor_id"`
+	Date        string `json:"date"`       // 日期，格式2006-01-02
+	Type        string `json:"type"`       // off、extra
+	StartTime   string `json:"start_time"` // 停诊时为空表示全天
+	EndTime     string `json:"end_time"`
+	SlotMinutes int32  `json:"slot_minutes"`
+	Capacity    int32  `json:"capacity"`
+	Reason      string `json:"reason"`
+}
+
+// 号源时段
+type ScheduleSlot struct {
+	ID        int64  `json:"id"`
+	DoctorID  int32  `json:"doctor_id"`
+	SlotDate  string `json:"slot_date"`  // 日期，格式2006-01-02
+	StartTime string `json:"start_time"` // 开始时间，格式15:04
+	EndTime   string `json:"end_time"`   // 结束时间
+	Capacity  int32  `json:"capacity"`   // 号源数
+	Booked    int32  `json:"booked"`     // 已预约数
+	Status    string `json:"status"`     // open、closed
+}
+
+// 时段开始时间
+func (s *ScheduleSlot) StartAt() (time.Time, error) {
+	return time.ParseInLocation(scheduleDateLayout+" "+scheduleClockLayout, s.SlotDate+" "+s.StartTime, time.Local)
+}
+
+// 预约单
+type Appointment struct {
+	ID            int64     `json:"id"`
+	AppointmentNo string    `json:"appointment_no"` // 预约单号
+	SlotID        int64     `json:"slot_id"`        // 时段ID
+	DoctorID      int32     `json:"doctor_id"`      // 医生ID
+	DoctorName    string    `json:"doctor_name"`    // 医生姓名
+	PatientID     int32     `json:"patient_id"`     // 患者用户ID
+	SlotDate      string    `json:"slot_date"`      // 就诊日期
+	StartTime     string    `json:"start_time"`     // 就诊开始时间
+	EndTime       string    `json:"end_time"`       // 就诊结束时间
+	Status        string    `json:"status"`         // booked、cancelled
+	CancelReason  string    `json:"cancel_reason"`  // 取消原因
+	CancelledAt   time.Time `json:"cancelled_at"`   // 取消时间
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// 排班与预约仓储接口
+type ScheduleRepo interface {
+	// 医生启用中的每周排班模板
+	ListSchedules(ctx context.Context, doctorID int32) ([]*DoctorSchedule, error)
+	// 医生在[fromDate, toDate]内的排班例外
+	ListExceptions(ctx context.Context, doctorID int32, fromDate, toDate string) ([]*ScheduleException, error)
+	// 批量创建时段，医生同一日期同一开始时间已存在的时段忽略
+	CreateSlots(ctx context.Context, slots []*ScheduleSlot) error
+	// 医生在[fromDate, toDate]内的时段，按日期和开始时间正序
+	ListSlots(ctx context.Context, doctorID int32, fromDate, toDate string) ([]*ScheduleSlot, error)
+	GetSlot(ctx context.Context, slotID int64) (*ScheduleSlot, error)
+	// 以时段可预约且未约满为条件占用一个号源，否则返回ErrSlotUnavailable
+	ReserveSlot(ctx context.Context, slotID int64) error
+	// 归还一个号源
+	ReleaseSlot(ctx context.Context, slotID int64) error
+	// 创建预约，同一患者已预约该时段时返回ErrAppointmentDuplicated
+	CreateAppointment(ctx context.Context, appointment *Appointment) error
+	GetAppointment(ctx context.Context, appointmentNo string) (*Appointment, error)
+	// 分页查询患者的预约，按就诊时间倒序
+	ListAppointments(ctx context.Context, patientID int32, status string, page, pageSize int32) ([]*Appointment, int64, error)
+	// 仅当预约为已预约时取消，否则返回ErrAppointmentStatusConflict
+	CancelAppointment(ctx context.Context, appointmentNo, reason string, cancelledAt time.Time) error
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// 排班与预约用例
+type ScheduleUsecase struct {
+	repo        ScheduleRepo
+	doctorsRepo DoctorsRepo
+	log         *log.Helper
+}
+
+// 创建排班与预约用例
+func NewScheduleUsecase(repo ScheduleRepo, doctorsRepo DoctorsRepo, logger log.Logger) *ScheduleUsecase {
+	return &ScheduleUsecase{
+		repo:        repo,
+		doctorsRepo: doctorsRepo,
+		log:         log.NewHelper(logger),
+	}
+}
+
+// 查询医生自startDate起days天内可预约的时段，尚未生成的时段按排班生成
+// startDate为空或早于今天时从今天开始，已开始的时段不返回
+func (uc *ScheduleUsecase) ListDoctorSlots(ctx context.Context, doctorID int32, startDate string, days int32) ([]*ScheduleSlot, error) {
+	doctor, err := uc.doctorsRepo.FindByID(ctx, doctorID)
+	if err != nil || doctor == nil {
+		return nil, fmt.Errorf("医生不存在: %d", doctorID)
+	}
+	if doctor.Status != doctorStatusEnabled {
+		return nil, fmt.Errorf("医生暂不出诊")
+	}
+
+	now := time.Now()
+	from := dateOf(now)
+	if startDate != "" {
+		parsed, err := time.ParseInLocation(scheduleDateLayout, startDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("日期格式错误: %s", startDate)
+		}
+		if parsed.After(from) {
+			from = parsed
+		}
+	}
+	if days <= 0 {
+		days = defaultSlotDays
+	}
+	if days > maxSlotDays {
+		days = maxSlotDays
+	}
+	to := from.AddDate(0, 0, int(days)-1)
+
+	if _, err := uc.GenerateSlots(ctx, doctorID, from, to); err != nil {
+		return nil, err
+	}
+	slots, err := uc.repo.ListSlots(ctx, doctorID, from.Format(scheduleDateLayout), to.Format(scheduleDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("查询号源失败: %v", err)
+	}
+	result := make([]*ScheduleSlot, 0, len(slots))
+	for _, slot := range slots {
+		if startAt, err := slot.StartAt(); err == nil && startAt.After(now) {
+			result = append(result, slot)
+		}
+	}
+	return result, nil
+}
+
+// 按每周排班模板和例外生成医生在[from, to]内的时段，返回按排班应有的时段数
+// 已生成的时段不会重复生成，也不会按新的排班修改
+func (uc *ScheduleUsecase) GenerateSlots(ctx context.Context, doctorID int32, from, to time.Time) (int, error) {
+	schedules, err := uc.repo.ListSchedules(ctx, doctorID)
+	if err != nil {
+		return 0, fmt.Errorf("查询排班失败: %v", err)
+	}
+	exceptions, err := uc.repo.ListExceptions(ctx, doctorID, from.Format(scheduleDateLayout), to.Format(scheduleDateLayout))
+	if err != nil {
+		return 0, fmt.Errorf("查询排班例外失败: %v", err)
+	}
+
+	var slots []*ScheduleSlot
+	for day := dateOf(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		slots = append(slots, uc.daySlots(doctorID, day, schedules, exceptions)...)
+	}
+	if len(slots) == 0 {
+		return 0, nil
+	}
+	if err := uc.repo.CreateSlots(ctx, slots); err != nil {
+		return 0, fmt.Errorf("生成号源失败: %v", err)
+	}
+	return len(slots), nil
+}
+
+// 一个医生一天的时段：当天星期的排班模板和加诊，去掉与停诊重叠的时段
+func (uc *ScheduleUsecase) daySlots(doctorID int32, day time.Time, schedules []*DoctorSchedule, exceptions []*ScheduleException) []*ScheduleSlot {
+	date := day.Format(scheduleDateLayout)
+	weekday := int32(day.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+
+	var offs, extras []*ScheduleException
+	for _, exception := range exceptions {
+		if exception.Date != date {
+			continue
+		}
+		switch exception.Type {
+		case ScheduleExceptionOff:
+			if exception.StartTime == "" {
+				return nil
+			}
+			offs = append(offs, exception)
+		case ScheduleExceptionExtra:
+			extras = append(extras, exception)
+		}
+	}
+
+	var slots []*ScheduleSlot
+	add := func(startTime, endTime string, slotMinutes, capacity int32) {
+		start, errStart := parseClock(startTime)
+		end, errEnd := parseClock(endTime)
+		if errStart != nil || errEnd != nil || slotMinutes <= 0 || capacity <= 0 {
+			uc.log.Warnf("跳过无效的排班: doctorID=%d, date=%s, %s-%s", doctorID, date, startTime, endTime)
+			return
+		}
+		for t := start; t+int(slotMinutes) <= end; t += int(slotMinutes) {
+			slotEnd := t + int(slotMinutes)
+			if overlapsOff(t, slotEnd, offs) {
+				continue
+			}
+			slots = append(slots, &ScheduleSlot{
+				DoctorID:  doctorID,
+				SlotDate:  date,
+				StartTime: formatClock(t),
+				EndTime:   formatClock(slotEnd),
+				Capacity:  capacity,
+				Status:    ScheduleSlotOpen,
+			})
+		}
+	}
+	for _, schedule := range schedules {
+		if schedule.Weekday == weekday {
+			add(schedule.StartTime, schedule.EndTime, schedule.SlotMinutes, schedule.Capacity)
+		}
+	}
+	for _, extra := range extras {
+		add(extra.StartTime, extra.EndTime, extra.SlotMinutes, extra.Capacity)
+	}
+	return slots
+}
+
+// 预约号源：在同一事务内占用号源并创建预约，号源已满或重复预约时整体回滚
+func (uc *ScheduleUsecase) BookAppointment(ctx context.Context, patientID int32, slotID int64) (*Appointment, error) {
+	slot, err := uc.repo.GetSlot(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("查询号源失败: %v", err)
+	}
+	if slot == nil {
+		return nil, fmt.Errorf("号源不存在: %d", slotID)
+	}
+	if slot.DoctorID == patientID {
+		return nil, fmt.Errorf("不能预约自己的号源")
+	}
+	startAt, err := slot.StartAt()
+	if err != nil {
+		return nil, fmt.Errorf("号源时间错误: %v", err)
+	}
+	now := time.Now()
+	if !startAt.After(now) {
+		return nil, fmt.Errorf("该时段已开始，不能预约")
+	}
+
+	doctorName := ""
+	if doctor, err := uc.doctorsRepo.FindByID(ctx, slot.DoctorID); err == nil && doctor != nil {
+		doctorName = doctor.Name
+	}
+	appointment := &Appointment{
+		AppointmentNo: fmt.Sprintf("APPT_%d_%d", patientID, now.UnixNano()),
+		SlotID:        slot.ID,
+		DoctorID:      slot.DoctorID,
+		DoctorName:    doctorName,
+		PatientID:     patientID,
+		SlotDate:      slot.SlotDate,
+		StartTime:     slot.StartTime,
+		EndTime:       slot.EndTime,
+		Status:        AppointmentStatusBooked,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	err = uc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.ReserveSlot(ctx, slot.ID); err != nil {
+			return err
+		}
+		return uc.repo.CreateAppointment(ctx, appointment)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrSlotUnavailable) && !errors.Is(err, ErrAppointmentDuplicated) {
+			uc.log.Errorf("预约失败: slotID=%d, patientID=%d, error=%v", slotID, patientID, err)
+		}
+		return nil, err
+	}
+
+	uc.log.Infof("预约成功: appointmentNo=%s, slotID=%d, patientID=%d", appointment.AppointmentNo, slotID, patientID)
+	return appointment, nil
+}
+
+// 患者在就诊开始前取消预约并归还号源
+func (uc *ScheduleUsecase) CancelAppointment(ctx context.Context, patientID int32, appointmentNo, reason string) (*Appointment, error) {
+	appointment, err := uc.repo.GetAppointment(ctx, appointmentNo)
+	if err != nil {
+		return nil, fmt.Errorf("查询预约失败: %v", err)
+	}
+	if appointment == nil || appointment.PatientID != patientID {
+		return nil, fmt.Errorf("预约不存在: %s", appointmentNo)
+	}
+	if appointment.Status != AppointmentStatusBooked {
+		return nil, fmt.Errorf("预约已取消")
+	}
+	slot := &ScheduleSlot{SlotDate: appointment.SlotDate, StartTime: appointment.StartTime}
+	if startAt, err := slot.StartAt(); err != nil || !startAt.After(time.Now()) {
+		return nil, fmt.Errorf("就诊已开始，不能取消")
+	}
+	if reason == "" {
+		reason = "患者取消"
+	}
+
+	now := time.Now()
+	err = uc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CancelAppointment(ctx, appointmentNo, reason, now); err != nil {
+			return err
+		}
+		return uc.repo.ReleaseSlot(ctx, appointment.SlotID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrAppointmentStatusConflict) {
+			return nil, fmt.Errorf("预约已取消")
+		}
+		uc.log.Errorf("取消预约失败: appointmentNo=%s, error=%v", appointmentNo, err)
+		return nil, err
+	}
+
+	appointment.Status = AppointmentStatusCancelled
+	appointment.CancelReason = reason
+	appointment.CancelledAt = now
+	appointment.UpdatedAt = now
+	uc.log.Infof("取消预约成功: appointmentNo=%s, patientID=%d", appointmentNo, patientID)
+	return appointment, nil
+}
+
+// 分页查询患者的预约
+func (uc *ScheduleUsecase) ListAppointments(ctx context.Context, patientID int32, status string, page, pageSize int32) ([]*Appointment, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	return uc.repo.ListAppointments(ctx, patientID, status, page, pageSize)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// 把15:04格式的时间转换为当天的分钟数
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse(scheduleClockLayout, clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// 时段[start, end)是否与任一停诊时间重叠
+func overlapsOff(start, end int, offs []*ScheduleException) bool {
+	for _, off := range offs {
+		offStart, errStart := parseClock(off.StartTime)
+		offEnd, errEnd := parseClock(off.EndTime)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		if start < offEnd && offStart < end {
+			return true
+		}
+	}
+	return false
+}
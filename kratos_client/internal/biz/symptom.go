@@ -0,0 +1,484 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 一次最多按几个症状推荐
+const SymptomRecommendMaxSymptoms = 5
+
+// 推荐药品数量默认值和上限，候选药品数量上限
+const (
+	defaultSymptomRecommendLimit = 10
+	maxSymptomRecommendLimit     = 30
+	symptomCandidateLimit        = 500
+)
+
+// 症状命中药品的得分：功能主治命中适应症关键词得分高于仅分类命中
+const (
+	symptomScoreIndication = 3
+	symptomScoreCategory   = 1
+)
+
+// 功能主治摘录的最大长度
+const symptomIndicationExcerptLen = 60
+
+// 问诊入口，症状推荐的响应中始终返回
+const (
+	ConsultDoctorText = "用药仅供参考，症状持续或加重请及时咨询医生"
+	DoctorListPath    = "/v1/DoctorsList"
+)
+
+// 管理后台维护的症状分类
+type Symptom struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Aliases      []string `json:"aliases"`       // 同义说法，如发烧、发热
+	Indications  []string `json:"indications"`   // 功能主治中的适应症关键词，如解热、退热
+	CategoryIDs  []int32  `json:"category_ids"`  // 对症的药品一级分类
+	DepartmentID int32    `json:"department_id"` // 建议就诊科室，用于问诊入口
+	Advice       string   `json:"advice"`        // 就医提示，如持续高热超过3天请及时就医
+}
+
+// 匹配功能主治的词：症状名称、同义说法和适应症关键词
+func (s *Symptom) Terms() []string {
+	return mergeTerms([]string{s.Name}, s.Aliases, s.Indications)
+}
+
+// 是否为该症状的名称或同义说法
+func (s *Symptom) Is(text string) bool {
+	if strings.EqualFold(s.Name, text) {
+		return true
+	}
+	for _, alias := range s.Aliases {
+		if strings.EqualFold(alias, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// 症状推荐请求
+type SymptomRecommendRequest struct {
+	Symptoms    []string `json:"symptoms"`
+	Populations []string `json:"populations"` // 用药人所属人群，禁用的药品不推荐
+	Limit       int      `json:"limit"`
+}
+
+// 识别出的症状，Curated 为 false 表示不在症状分类中，仅按原文匹配功能主治
+type MatchedSymptom struct {
+	Name         string `json:"name"`
+	Curated      bool   `json:"curated"`
+	DepartmentID int32  `json:"department_id"`
+	Advice       string `json:"advice"`
+}
+
+// 推荐的药品
+type SymptomDrug struct {
+	Drug            *DrugInfo      `json:"drug"`
+	Score           int            `json:"score"`
+	MatchedSymptoms []string       `json:"matched_symptoms"`
+	Indication      string         `json:"indication"` // 命中的功能主治摘录
+	Warnings        []*DrugWarning `json:"warnings"`   // 人群禁忌提示
+}
+
+// 问诊入口，跳转医生列表，DepartmentID 为0时不按科室筛选
+type ConsultDoctorAction struct {
+	Text         string `json:"text"`
+	Path         string `json:"path"`
+	DepartmentID int32  `json:"department_id"`
+}
+
+// 症状推荐结果
+type SymptomRecommendation struct {
+	Symptoms []*MatchedSymptom   `json:"symptoms"`
+	Drugs    []*SymptomDrug      `json:"drugs"`
+	Consult  ConsultDoctorAction `json:"consult"`
+}
+
+// 症状推荐仓储接口
+type SymptomRepo interface {
+	// 启用的症状分类，按排序值升序
+	ListSymptoms(ctx context.Context) ([]*Symptom, error)
+	// 属于给定分类、或说明书及用药指导功能主治包含任一关键词的未删除非处方药，按销量降序最多 limit 个
+	ListSymptomCandidates(ctx context.Context, terms []string, categoryIDs []int32, limit int) ([]*DrugIndexSource, error)
+}
+
+// 症状推荐用例，按症状分类和功能主治推荐非处方药
+type SymptomUsecase struct {
+	repo            SymptomRepo
+	interactionRepo InteractionRepo
+	log             *log.Helper
+}
+
+// 创建症状推荐用例
+func NewSymptomUsecase(repo SymptomRepo, interactionRepo InteractionRepo, logger log.Logger) *SymptomUsecase {
+	return &SymptomUsecase{
+		repo:            repo,
+		interactionRepo: interactionRepo,
+		log:             log.NewHelper(logger),
+	}
+}
+
+// 按症状推荐非处方药
+// 功能主治命中症状的排在仅分类命中的前面，覆盖症状多的优先，其次有货、销量高的优先
+// 指定了用药人群时不推荐对其禁用的药品，提示只返回这些人群的；未指定时返回全部人群的禁忌提示
+func (uc *SymptomUsecase) Recommend(ctx context.Context, req *SymptomRecommendRequest) (*SymptomRecommendation, error) {
+	texts := normalizeSymptomTexts(req.Symptoms)
+	if len(texts) == 0 {
+		return nil, errors.New("请选择症状")
+	}
+	if len(texts) > SymptomRecommendMaxSymptoms {
+		return nil, fmt.Errorf("一次最多选择%d个症状", SymptomRecommendMaxSymptoms)
+	}
+	populations, err := NormalizePopulations(req.Populations)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSymptomRecommendLimit
+	}
+	if limit > maxSymptomRecommendLimit {
+		limit = maxSymptomRecommendLimit
+	}
+
+	taxonomy, err := uc.repo.ListSymptoms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询症状分类失败: %v", err)
+	}
+	symptoms := resolveSymptoms(texts, taxonomy)
+	result := &SymptomRecommendation{
+		Symptoms: make([]*MatchedSymptom, 0, len(symptoms)),
+		Drugs:    []*SymptomDrug{},
+		Consult:  ConsultDoctorAction{Text: ConsultDoctorText, Path: DoctorListPath},
+	}
+	var terms []string
+	var categoryIDs []int32
+	for _, symptom := range symptoms {
+		result.Symptoms = append(result.Symptoms, &MatchedSymptom{
+			Name:         symptom.Name,
+			Curated:      symptom.ID > 0,
+			DepartmentID: symptom.DepartmentID,
+			Advice:       symptom.Advice,
+		})
+		if result.Consult.DepartmentID == 0 {
+			result.Consult.DepartmentID = symptom.DepartmentID
+		}
+		terms = mergeTerms(terms, symptom.Terms())
+		categoryIDs = append(categoryIDs, symptom.CategoryIDs...)
+	}
+
+	sources, err := uc.repo.ListSymptomCandidates(ctx, terms, categoryIDs, symptomCandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("查询推荐药品失败: %v", err)
+	}
+	var ranked []*SymptomDrug
+	var matched []*DrugIndexSource
+	for _, source := range sources {
+		if drug := scoreSymptomDrug(source, symptoms); drug != nil {
+			ranked = append(ranked, drug)
+			matched = append(matched, source)
+		}
+	}
+	sortSymptomDrugs(ranked)
+
+	warnings, err := uc.populationWarnings(ctx, matched, populations)
+	if err != nil {
+		return nil, err
+	}
+	for _, drug := range ranked {
+		drug.Warnings = warnings[drug.Drug.ID]
+		if drug.Warnings == nil {
+			drug.Warnings = []*DrugWarning{}
+		}
+		if len(populations) > 0 && hasForbiddenWarning(drug.Warnings) {
+			continue
+		}
+		result.Drugs = append(result.Drugs, drug)
+		if len(result.Drugs) >= limit {
+			break
+		}
+	}
+	uc.log.WithContext(ctx).Infof("Recommend: symptoms=%v, populations=%v, candidates=%d, drugs=%d", texts, populations, len(sources), len(result.Drugs))
+	return result, nil
+}
+
+// 人群禁忌提示：知识库中维护的优先，知识库没有的再从说明书禁忌和用药指导特殊人群中识别
+func (uc *SymptomUsecase) populationWarnings(ctx context.Context, sources []*DrugIndexSource, populations []string) (map[int64][]*DrugWarning, error) {
+	if len(populations) == 0 {
+		populations = allPopulations()
+	}
+	ids := make([]int64, 0, len(sources))
+	for _, source := range sources {
+		ids = append(ids, int64(source.Drug.Id))
+	}
+	warnings := make(map[int64][]*DrugWarning)
+	if len(ids) == 0 {
+		return warnings, nil
+	}
+	contraindications, err := uc.interactionRepo.ListContraindications(ctx, ids, populations)
+	if err != nil {
+		return nil, fmt.Errorf("查询用药禁忌失败: %v", err)
+	}
+
+	names := make(map[int64]string, len(sources))
+	for _, source := range sources {
+		names[int64(source.Drug.Id)] = source.Drug.DrugName
+	}
+	curated := make(map[string]bool, len(contraindications))
+	for _, contraindication := range contraindications {
+		curated[fmt.Sprintf("%d:%s", contraindication.DrugID, contraindication.Population)] = true
+		warnings[contraindication.DrugID] = append(warnings[contraindication.DrugID], &DrugWarning{
+			Type:        DrugWarningContraindication,
+			Level:       contraindication.Level,
+			DrugIDs:     []int64{contraindication.DrugID},
+			DrugNames:   []string{names[contraindication.DrugID]},
+			Population:  contraindication.Population,
+			Description: contraindication.Description,
+		})
+	}
+	for _, source := range sources {
+		id := int64(source.Drug.Id)
+		for _, label := range LabelContraindications(source) {
+			if curated[fmt.Sprintf("%d:%s", id, label.Population)] || !containsString(populations, label.Population) {
+				continue
+			}
+			warnings[id] = append(warnings[id], &DrugWarning{
+				Type:        DrugWarningContraindication,
+				Level:       label.Level,
+				DrugIDs:     []int64{id},
+				DrugNames:   []string{source.Drug.DrugName},
+				Population:  label.Population,
+				Description: label.Description,
+			})
+		}
+	}
+	// 禁用排在慎用前面
+	for _, list := range warnings {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].Level == ContraindicationForbidden && list[j].Level != ContraindicationForbidden
+		})
+	}
+	return warnings, nil
+}
+
+// 说明书禁忌、用药指导禁忌和特殊人群中提到的人群：句中含“禁”为禁用，否则为慎用
+var populationLabelWords = []struct {
+	population string
+	words      []string
+}{
+	{PopulationPregnancy, []string{"孕妇", "妊娠"}},
+	{PopulationLactation, []string{"哺乳"}},
+	{PopulationChild, []string{"儿童", "小儿", "婴幼儿", "婴儿"}},
+	{PopulationElderly, []string{"老年", "老人"}},
+}
+
+// 从说明书和用药指导文本中识别的人群禁忌，每个人群取第一句提及的
+func LabelContraindications(source *DrugIndexSource) []*DrugContraindication {
+	var texts []string
+	if source.Explain != nil {
+		texts = append(texts, source.Explain.Taboos)
+	}
+	if source.Guide != nil {
+		texts = append(texts, source.Guide.Taboos, source.Guide.SpecialCrowd)
+	}
+	var result []*DrugContraindication
+	found := make(map[string]bool)
+	for _, text := range texts {
+		for _, sentence := range splitSentences(text) {
+			for _, item := range populationLabelWords {
+				if found[item.population] || !containsAny(sentence, item.words) {
+					continue
+				}
+				found[item.population] = true
+				level := ContraindicationCaution
+				if strings.Contains(sentence, "禁") {
+					level = ContraindicationForbidden
+				}
+				result = append(result, &DrugContraindication{
+					Population:  item.population,
+					Level:       level,
+					Description: sentence,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// 合并空白、去重，忽略空症状
+func normalizeSymptomTexts(symptoms []string) []string {
+	seen := make(map[string]bool, len(symptoms))
+	result := make([]string, 0, len(symptoms))
+	for _, symptom := range symptoms {
+		symptom = strings.Join(strings.Fields(symptom), "")
+		if symptom == "" || seen[symptom] {
+			continue
+		}
+		seen[symptom] = true
+		result = append(result, symptom)
+	}
+	return result
+}
+
+// 按名称或同义说法对应到症状分类，对应不上的按原文匹配功能主治
+// 不同说法对应到同一症状时只保留一个
+func resolveSymptoms(texts []string, taxonomy []*Symptom) []*Symptom {
+	var result []*Symptom
+	seen := make(map[int64]bool)
+	for _, text := range texts {
+		var matched *Symptom
+		for _, symptom := range taxonomy {
+			if symptom.Is(text) {
+				matched = symptom
+				break
+			}
+		}
+		if matched == nil {
+			result = append(result, &Symptom{Name: text})
+			continue
+		}
+		if !seen[matched.ID] {
+			seen[matched.ID] = true
+			result = append(result, matched)
+		}
+	}
+	return result
+}
+
+// 计算药品对各症状的得分，一个症状都没命中的返回空
+func scoreSymptomDrug(source *DrugIndexSource, symptoms []*Symptom) *SymptomDrug {
+	var texts []string
+	if source.Explain != nil {
+		texts = append(texts, source.Explain.Function)
+	}
+	if source.Guide != nil {
+		texts = append(texts, source.Guide.MajorFunction)
+	}
+	drug := &SymptomDrug{Drug: drugInfo(source.Drug)}
+	for _, symptom := range symptoms {
+		hit := false
+		if excerpt := indicationExcerpt(texts, symptom.Terms()); excerpt != "" {
+			drug.Score += symptomScoreIndication
+			if drug.Indication == "" {
+				drug.Indication = excerpt
+			}
+			hit = true
+		}
+		if containsInt32(symptom.CategoryIDs, source.Drug.FristCategoryId) {
+			drug.Score += symptomScoreCategory
+			hit = true
+		}
+		if hit {
+			drug.MatchedSymptoms = append(drug.MatchedSymptoms, symptom.Name)
+		}
+	}
+	if len(drug.MatchedSymptoms) == 0 {
+		return nil
+	}
+	return drug
+}
+
+func sortSymptomDrugs(drugs []*SymptomDrug) {
+	sort.SliceStable(drugs, func(i, j int) bool {
+		a, b := drugs[i], drugs[j]
+		if len(a.MatchedSymptoms) != len(b.MatchedSymptoms) {
+			return len(a.MatchedSymptoms) > len(b.MatchedSymptoms)
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if inStockA, inStockB := a.Drug.Inventory > 0, b.Drug.Inventory > 0; inStockA != inStockB {
+			return inStockA
+		}
+		if a.Drug.SalesVolume != b.Drug.SalesVolume {
+			return a.Drug.SalesVolume > b.Drug.SalesVolume
+		}
+		return a.Drug.ID < b.Drug.ID
+	})
+}
+
+// 包含关键词的第一句功能主治，过长时截断
+func indicationExcerpt(texts []string, terms []string) string {
+	for _, text := range texts {
+		for _, sentence := range splitSentences(text) {
+			if !containsAny(sentence, terms) {
+				continue
+			}
+			if utf8.RuneCountInString(sentence) > symptomIndicationExcerptLen {
+				sentence = string([]rune(sentence)[:symptomIndicationExcerptLen]) + "…"
+			}
+			return sentence
+		}
+	}
+	return ""
+}
+
+// 按句号、分号等切分句子
+func splitSentences(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune("。；;！!？?\n", r)
+	})
+}
+
+func drugInfo(drug *MtDrug) *DrugInfo {
+	return &DrugInfo{
+		ID:             int64(drug.Id),
+		DrugName:       drug.DrugName,
+		Specification:  drug.Specification,
+		Price:          float64(drug.Price),
+		SalesVolume:    float64(drug.SalesVolume),
+		Inventory:      int64(drug.Inventory),
+		Manufacturer:   drug.Manufacturer,
+		IsPrescription: drug.IsPrescription,
+		ExhibitionID:   int32(drug.ExhibitionId),
+	}
+}
+
+func hasForbiddenWarning(warnings []*DrugWarning) bool {
+	for _, warning := range warnings {
+		if warning.Level == ContraindicationForbidden {
+			return true
+		}
+	}
+	return false
+}
+
+func allPopulations() []string {
+	return []string{PopulationPregnancy, PopulationLactation, PopulationChild, PopulationElderly}
+}
+
+func containsAny(text string, words []string) bool {
+	for _, word := range words {
+		if word != "" && strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt32(values []int32, value int32) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
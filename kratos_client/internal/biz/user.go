@@ -10,6 +10,7 @@ type MtUser struct {
 	NickName string `gorm:"column:nick_name;type:varchar(20);comment:昵称;" json:"nick_name"` // 昵称
 	Mobile   string `gorm:"column:mobile;type:char(11);comment:手机号;" json:"mobile"`         // 手机号
 	Avatar   string `gorm:"column:avatar;type:varchar(255);comment:头像;" json:"avatar"`      // 头像
+	CityId   int32  `gorm:"column:city_id;type:int;comment:所选城市;" json:"city_id"`           // 所选城市，用于城市热搜
 }
 
 func (u *MtUser) TableName() string {
@@ -21,6 +22,9 @@ type UserRepo interface {
 	Find(context.Context, *MtUser) (*MtUser, error)
 	Update(context.Context, *MtUser) (*MtUser, error)
 	FindId(context.Context, *MtUser) (*MtUser, error)
+	// 用户所选城市，用户不存在或未选择时返回0
+	GetCityID(ctx context.Context, userID int32) (int32, error)
+	UpdateCityID(ctx context.Context, userID int32, cityID int32) error
 }
 
 type UserService struct {
@@ -54,3 +58,14 @@ func (s *UserService) Update(ctx context.Context, req *MtUser) (*MtUser, error)
 	s.log.WithContext(ctx).Infof("Update user %+v", req)
 	return s.repo.Update(ctx, req)
 }
+
+// 保存用户选择的城市
+func (s *UserService) SelectCity(ctx context.Context, userID int32, cityID int32) error {
+	s.log.WithContext(ctx).Infof("Select city userId=%d, cityId=%d", userID, cityID)
+	return s.repo.UpdateCityID(ctx, userID, cityID)
+}
+
+// 获取用户选择的城市，未选择时返回0
+func (s *UserService) GetSelectedCity(ctx context.Context, userID int32) (int32, error) {
+	return s.repo.GetCityID(ctx, userID)
+}
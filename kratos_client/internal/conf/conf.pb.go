@@ -26,6 +26,11 @@ type Bootstrap struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Order         *Order                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Alert         *Alert                 `protobuf:"bytes,4,opt,name=alert,proto3" json:"alert,omitempty"`
+	Payment       *Payment               `protobuf:"bytes,5,opt,name=payment,proto3" json:"payment,omitempty"`
+	Consultation  *Consultation          `protobuf:"bytes,6,opt,name=consultation,proto3" json:"consultation,omitempty"`
+	Inventory     *Inventory             `protobuf:"bytes,7,opt,name=inventory,proto3" json:"inventory,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,6 +79,41 @@ func (x *Bootstrap) GetData() *Data {
 	return nil
 }
 
+func (x *Bootstrap) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetAlert() *Alert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetConsultation() *Consultation {
+	if x != nil {
+		return x.Consultation
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
@@ -131,6 +171,7 @@ type Data struct {
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
 	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
 	Elasticsearch *Data_Elasticsearch    `protobuf:"bytes,3,opt,name=elasticsearch,proto3" json:"elasticsearch,omitempty"`
+	Idempotency   *Data_Idempotency      `protobuf:"bytes,4,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,6 +227,307 @@ func (x *Data) GetElasticsearch() *Data_Elasticsearch {
 	return nil
 }
 
+func (x *Data) GetIdempotency() *Data_Idempotency {
+	if x != nil {
+		return x.Idempotency
+	}
+	return nil
+}
+
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expiry        *Order_Expiry          `protobuf:"bytes,1,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Refund        *Order_Refund          `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"`
+	AdminToken    string                 `protobuf:"bytes,3,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"` // 订单状态流转记录查询令牌，请求头 X-Admin-Token，为空时关闭该接口
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_internal_conf_conf_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Order) GetExpiry() *Order_Expiry {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *Order) GetRefund() *Order_Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *Order) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sinks         []string               `protobuf:"bytes,1,rep,name=sinks,proto3" json:"sinks,omitempty"` // 启用的通知渠道: log、webhook、email，默认log
+	Webhook       *Alert_Webhook         `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Email         *Alert_Email           `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_internal_conf_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Alert) GetSinks() []string {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
+}
+
+func (x *Alert) GetWebhook() *Alert_Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *Alert) GetEmail() *Alert_Email {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wechat        *Payment_Wechat        `protobuf:"bytes,1,opt,name=wechat,proto3" json:"wechat,omitempty"`
+	Sandbox       *Payment_Sandbox       `protobuf:"bytes,2,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+	Reconcile     *Payment_Reconcile     `protobuf:"bytes,3,opt,name=reconcile,proto3" json:"reconcile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_internal_conf_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Payment) GetWechat() *Payment_Wechat {
+	if x != nil {
+		return x.Wechat
+	}
+	return nil
+}
+
+func (x *Payment) GetSandbox() *Payment_Sandbox {
+	if x != nil {
+		return x.Sandbox
+	}
+	return nil
+}
+
+func (x *Payment) GetReconcile() *Payment_Reconcile {
+	if x != nil {
+		return x.Reconcile
+	}
+	return nil
+}
+
+// 在线问诊
+type Consultation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerTimeout *durationpb.Duration   `protobuf:"bytes,1,opt,name=answer_timeout,json=answerTimeout,proto3" json:"answer_timeout,omitempty"` // 支付后医生未接诊自动退款的时长，默认30分钟
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`                                // 接诊后会话时长，到期自动结束，默认30分钟
+	Interval      *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`                                // 超时扫描间隔，默认1分钟
+	BatchSize     int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`            // 单次扫描处理的问诊数，默认100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Consultation) Reset() {
+	*x = Consultation{}
+	mi := &file_internal_conf_conf_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consultation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consultation) ProtoMessage() {}
+
+func (x *Consultation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consultation.ProtoReflect.Descriptor instead.
+func (*Consultation) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Consultation) GetAnswerTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.AnswerTimeout
+	}
+	return nil
+}
+
+func (x *Consultation) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Consultation) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Consultation) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// 库存管理
+type Inventory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"` // 盘点调整库存接口令牌，请求头 X-Admin-Token，为空时关闭该接口
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Inventory) Reset() {
+	*x = Inventory{}
+	mi := &file_internal_conf_conf_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inventory) ProtoMessage() {}
+
+func (x *Inventory) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
+func (*Inventory) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Inventory) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
 type Server_HTTP struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
@@ -197,7 +539,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_internal_conf_conf_proto_msgTypes[3]
+	mi := &file_internal_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -209,7 +551,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[3]
+	mi := &file_internal_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,7 +599,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_internal_conf_conf_proto_msgTypes[4]
+	mi := &file_internal_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -269,7 +611,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[4]
+	mi := &file_internal_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +658,7 @@ type Data_Database struct {
 
 func (x *Data_Database) Reset() {
 	*x = Data_Database{}
-	mi := &file_internal_conf_conf_proto_msgTypes[5]
+	mi := &file_internal_conf_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -328,7 +670,7 @@ func (x *Data_Database) String() string {
 func (*Data_Database) ProtoMessage() {}
 
 func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[5]
+	mi := &file_internal_conf_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +713,7 @@ type Data_Redis struct {
 
 func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_internal_conf_conf_proto_msgTypes[6]
+	mi := &file_internal_conf_conf_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -383,7 +725,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[6]
+	mi := &file_internal_conf_conf_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,13 +783,15 @@ type Data_Elasticsearch struct {
 	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	MaxRetries    int32                  `protobuf:"varint,5,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	SyncInterval  *durationpb.Duration   `protobuf:"bytes,6,opt,name=sync_interval,json=syncInterval,proto3" json:"sync_interval,omitempty"`       // 药品索引增量同步间隔，默认10秒
+	SyncBatchSize int32                  `protobuf:"varint,7,opt,name=sync_batch_size,json=syncBatchSize,proto3" json:"sync_batch_size,omitempty"` // 单次同步的任务数，默认200
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Data_Elasticsearch) Reset() {
 	*x = Data_Elasticsearch{}
-	mi := &file_internal_conf_conf_proto_msgTypes[7]
+	mi := &file_internal_conf_conf_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -459,7 +803,7 @@ func (x *Data_Elasticsearch) String() string {
 func (*Data_Elasticsearch) ProtoMessage() {}
 
 func (x *Data_Elasticsearch) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[7]
+	mi := &file_internal_conf_conf_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,16 +854,539 @@ func (x *Data_Elasticsearch) GetMaxRetries() int32 {
 	return 0
 }
 
-var File_internal_conf_conf_proto protoreflect.FileDescriptor
+func (x *Data_Elasticsearch) GetSyncInterval() *durationpb.Duration {
+	if x != nil {
+		return x.SyncInterval
+	}
+	return nil
+}
 
-const file_internal_conf_conf_proto_rawDesc = "" +
-	"\n" +
-	"\x18internal/conf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"]\n" +
-	"\tBootstrap\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
-	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\"\xb8\x02\n" +
-	"\x06Server\x12+\n" +
+func (x *Data_Elasticsearch) GetSyncBatchSize() int32 {
+	if x != nil {
+		return x.SyncBatchSize
+	}
+	return 0
+}
+
+// 幂等键去重存储，配置Redis时使用Redis，否则使用数据库表
+type Data_Idempotency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"` // 幂等记录保留时长，默认24小时
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Idempotency) Reset() {
+	*x = Data_Idempotency{}
+	mi := &file_internal_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Idempotency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Idempotency) ProtoMessage() {}
+
+func (x *Data_Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Idempotency.ProtoReflect.Descriptor instead.
+func (*Data_Idempotency) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Data_Idempotency) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// 超时未支付订单自动关闭
+type Order_Expiry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`                               // 待支付订单保留时长，默认30分钟
+	Interval      *durationpb.Duration   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`                     // 扫描间隔，默认1分钟
+	BatchSize     int32                  `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // 单次扫描处理的订单数，默认100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order_Expiry) Reset() {
+	*x = Order_Expiry{}
+	mi := &file_internal_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order_Expiry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order_Expiry) ProtoMessage() {}
+
+func (x *Order_Expiry) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order_Expiry.ProtoReflect.Descriptor instead.
+func (*Order_Expiry) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Order_Expiry) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *Order_Expiry) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Order_Expiry) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// 审核通过的退款单提交支付渠道
+type Order_Refund struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`                     // 扫描间隔，默认30秒
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // 单次提交的退款单数，默认50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order_Refund) Reset() {
+	*x = Order_Refund{}
+	mi := &file_internal_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order_Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order_Refund) ProtoMessage() {}
+
+func (x *Order_Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order_Refund.ProtoReflect.Descriptor instead.
+func (*Order_Refund) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Order_Refund) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Order_Refund) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// 通用Webhook，告警以JSON形式POST到url
+type Alert_Webhook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert_Webhook) Reset() {
+	*x = Alert_Webhook{}
+	mi := &file_internal_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert_Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert_Webhook) ProtoMessage() {}
+
+func (x *Alert_Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert_Webhook.ProtoReflect.Descriptor instead.
+func (*Alert_Webhook) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Alert_Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Alert_Webhook) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// 通过后台邮件插件(/email/sendEmail)发送告警邮件
+type Alert_Email struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // 后台地址，如 http://127.0.0.1:8888
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`       // 后台x-token，需具备email/sendEmail接口权限
+	To            []string               `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`             // 收件人
+	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert_Email) Reset() {
+	*x = Alert_Email{}
+	mi := &file_internal_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert_Email) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert_Email) ProtoMessage() {}
+
+func (x *Alert_Email) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert_Email.ProtoReflect.Descriptor instead.
+func (*Alert_Email) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Alert_Email) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Alert_Email) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Alert_Email) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Alert_Email) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// 微信支付APIv3，商户号为空时不启用
+type Payment_Wechat struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AppId             string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	MchId             string                 `protobuf:"bytes,2,opt,name=mch_id,json=mchId,proto3" json:"mch_id,omitempty"`                                       // 商户号
+	SerialNo          string                 `protobuf:"bytes,3,opt,name=serial_no,json=serialNo,proto3" json:"serial_no,omitempty"`                              // 商户API证书序列号
+	PrivateKey        string                 `protobuf:"bytes,4,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`                        // 商户API私钥(PEM)
+	ApiV3Key          string                 `protobuf:"bytes,5,opt,name=api_v3_key,json=apiV3Key,proto3" json:"api_v3_key,omitempty"`                            // APIv3密钥，用于解密通知
+	PlatformPublicKey string                 `protobuf:"bytes,6,opt,name=platform_public_key,json=platformPublicKey,proto3" json:"platform_public_key,omitempty"` // 微信支付公钥(PEM)，用于验证应答和通知签名
+	PlatformSerial    string                 `protobuf:"bytes,7,opt,name=platform_serial,json=platformSerial,proto3" json:"platform_serial,omitempty"`            // 微信支付公钥ID
+	NotifyUrl         string                 `protobuf:"bytes,8,opt,name=notify_url,json=notifyUrl,proto3" json:"notify_url,omitempty"`                           // 支付通知地址，如 https://example.com/v1/payment/notify/wechat
+	RefundNotifyUrl   string                 `protobuf:"bytes,9,opt,name=refund_notify_url,json=refundNotifyUrl,proto3" json:"refund_notify_url,omitempty"`       // 退款通知地址，如 https://example.com/v1/payment/refund/notify/wechat
+	BaseUrl           string                 `protobuf:"bytes,10,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`                                // 接口地址，默认 https://api.mch.weixin.qq.com
+	Timeout           *durationpb.Duration   `protobuf:"bytes,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Payment_Wechat) Reset() {
+	*x = Payment_Wechat{}
+	mi := &file_internal_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment_Wechat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment_Wechat) ProtoMessage() {}
+
+func (x *Payment_Wechat) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment_Wechat.ProtoReflect.Descriptor instead.
+func (*Payment_Wechat) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *Payment_Wechat) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetMchId() string {
+	if x != nil {
+		return x.MchId
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetSerialNo() string {
+	if x != nil {
+		return x.SerialNo
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetApiV3Key() string {
+	if x != nil {
+		return x.ApiV3Key
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetPlatformPublicKey() string {
+	if x != nil {
+		return x.PlatformPublicKey
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetPlatformSerial() string {
+	if x != nil {
+		return x.PlatformSerial
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetNotifyUrl() string {
+	if x != nil {
+		return x.NotifyUrl
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetRefundNotifyUrl() string {
+	if x != nil {
+		return x.RefundNotifyUrl
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *Payment_Wechat) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// 进程内模拟渠道
+type Payment_Sandbox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // 开启后所有支付方式都走模拟渠道，仅用于开发联调
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`    // 通知签名密钥，为空时启动时随机生成
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment_Sandbox) Reset() {
+	*x = Payment_Sandbox{}
+	mi := &file_internal_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment_Sandbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment_Sandbox) ProtoMessage() {}
+
+func (x *Payment_Sandbox) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment_Sandbox.ProtoReflect.Descriptor instead.
+func (*Payment_Sandbox) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *Payment_Sandbox) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Payment_Sandbox) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// 渠道账单对账
+type Payment_Reconcile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"` // 对账管理接口令牌，请求头 X-Admin-Token，为空时关闭管理接口
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment_Reconcile) Reset() {
+	*x = Payment_Reconcile{}
+	mi := &file_internal_conf_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment_Reconcile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment_Reconcile) ProtoMessage() {}
+
+func (x *Payment_Reconcile) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment_Reconcile.ProtoReflect.Descriptor instead.
+func (*Payment_Reconcile) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{5, 2}
+}
+
+func (x *Payment_Reconcile) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+var File_internal_conf_conf_proto protoreflect.FileDescriptor
+
+const file_internal_conf_conf_proto_rawDesc = "" +
+	"\n" +
+	"\x18internal/conf/conf.proto\x12\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xd1\x02\n" +
+	"\tBootstrap\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12'\n" +
+	"\x05order\x18\x03 \x01(\v2\x11.kratos.api.OrderR\x05order\x12'\n" +
+	"\x05alert\x18\x04 \x01(\v2\x11.kratos.api.AlertR\x05alert\x12-\n" +
+	"\apayment\x18\x05 \x01(\v2\x13.kratos.api.PaymentR\apayment\x12<\n" +
+	"\fconsultation\x18\x06 \x01(\v2\x18.kratos.api.ConsultationR\fconsultation\x123\n" +
+	"\tinventory\x18\a \x01(\v2\x15.kratos.api.InventoryR\tinventory\"\xb8\x02\n" +
+	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
 	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
 	"\x04HTTP\x12\x18\n" +
@@ -529,11 +1396,12 @@ const file_internal_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xfd\x04\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xe1\x06\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
 	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12D\n" +
-	"\relasticsearch\x18\x03 \x01(\v2\x1e.kratos.api.Data.ElasticsearchR\relasticsearch\x1a:\n" +
+	"\relasticsearch\x18\x03 \x01(\v2\x1e.kratos.api.Data.ElasticsearchR\relasticsearch\x12>\n" +
+	"\vidempotency\x18\x04 \x01(\v2\x1c.kratos.api.Data.IdempotencyR\vidempotency\x1a:\n" +
 	"\bDatabase\x12\x16\n" +
 	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xcf\x01\n" +
@@ -542,14 +1410,79 @@ const file_internal_conf_conf_proto_rawDesc = "" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x1a\n" +
 	"\bpassword\x18\x03 \x01(\tR\bpassword\x12<\n" +
 	"\fread_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x1a\xbb\x01\n" +
+	"\rwrite_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x1a\xa3\x02\n" +
 	"\rElasticsearch\x12\x1c\n" +
 	"\taddresses\x18\x01 \x03(\tR\taddresses\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x03 \x01(\tR\bpassword\x123\n" +
 	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x1f\n" +
 	"\vmax_retries\x18\x05 \x01(\x05R\n" +
-	"maxRetriesB\"Z kratos_client/internal/conf;confb\x06proto3"
+	"maxRetries\x12>\n" +
+	"\rsync_interval\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fsyncInterval\x12&\n" +
+	"\x0fsync_batch_size\x18\a \x01(\x05R\rsyncBatchSize\x1a:\n" +
+	"\vIdempotency\x12+\n" +
+	"\x03ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"\xfa\x02\n" +
+	"\x05Order\x120\n" +
+	"\x06expiry\x18\x01 \x01(\v2\x18.kratos.api.Order.ExpiryR\x06expiry\x120\n" +
+	"\x06refund\x18\x02 \x01(\v2\x18.kratos.api.Order.RefundR\x06refund\x12\x1f\n" +
+	"\vadmin_token\x18\x03 \x01(\tR\n" +
+	"adminToken\x1a\x8b\x01\n" +
+	"\x06Expiry\x12+\n" +
+	"\x03ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\x1a^\n" +
+	"\x06Refund\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\"\xd3\x02\n" +
+	"\x05Alert\x12\x14\n" +
+	"\x05sinks\x18\x01 \x03(\tR\x05sinks\x123\n" +
+	"\awebhook\x18\x02 \x01(\v2\x19.kratos.api.Alert.WebhookR\awebhook\x12-\n" +
+	"\x05email\x18\x03 \x01(\v2\x17.kratos.api.Alert.EmailR\x05email\x1aP\n" +
+	"\aWebhook\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a~\n" +
+	"\x05Email\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02to\x18\x03 \x03(\tR\x02to\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xa5\x05\n" +
+	"\aPayment\x122\n" +
+	"\x06wechat\x18\x01 \x01(\v2\x1a.kratos.api.Payment.WechatR\x06wechat\x125\n" +
+	"\asandbox\x18\x02 \x01(\v2\x1b.kratos.api.Payment.SandboxR\asandbox\x12;\n" +
+	"\treconcile\x18\x03 \x01(\v2\x1d.kratos.api.Payment.ReconcileR\treconcile\x1a\x86\x03\n" +
+	"\x06Wechat\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x15\n" +
+	"\x06mch_id\x18\x02 \x01(\tR\x05mchId\x12\x1b\n" +
+	"\tserial_no\x18\x03 \x01(\tR\bserialNo\x12\x1f\n" +
+	"\vprivate_key\x18\x04 \x01(\tR\n" +
+	"privateKey\x12\x1c\n" +
+	"\n" +
+	"api_v3_key\x18\x05 \x01(\tR\bapiV3Key\x12.\n" +
+	"\x13platform_public_key\x18\x06 \x01(\tR\x11platformPublicKey\x12'\n" +
+	"\x0fplatform_serial\x18\a \x01(\tR\x0eplatformSerial\x12\x1d\n" +
+	"\n" +
+	"notify_url\x18\b \x01(\tR\tnotifyUrl\x12*\n" +
+	"\x11refund_notify_url\x18\t \x01(\tR\x0frefundNotifyUrl\x12\x19\n" +
+	"\bbase_url\x18\n" +
+	" \x01(\tR\abaseUrl\x123\n" +
+	"\atimeout\x18\v \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a;\n" +
+	"\aSandbox\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x1a,\n" +
+	"\tReconcile\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\"\xdd\x01\n" +
+	"\fConsultation\x12@\n" +
+	"\x0eanswer_timeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\ranswerTimeout\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x125\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\",\n" +
+	"\tInventory\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminTokenB\"Z kratos_client/internal/conf;confb\x06proto3"
 
 var (
 	file_internal_conf_conf_proto_rawDescOnce sync.Once
@@ -563,36 +1496,73 @@ func file_internal_conf_conf_proto_rawDescGZIP() []byte {
 	return file_internal_conf_conf_proto_rawDescData
 }
 
-var file_internal_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_internal_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_internal_conf_conf_proto_goTypes = []any{
 	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
 	(*Server)(nil),              // 1: kratos.api.Server
 	(*Data)(nil),                // 2: kratos.api.Data
-	(*Server_HTTP)(nil),         // 3: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 4: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 5: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 6: kratos.api.Data.Redis
-	(*Data_Elasticsearch)(nil),  // 7: kratos.api.Data.Elasticsearch
-	(*durationpb.Duration)(nil), // 8: google.protobuf.Duration
+	(*Order)(nil),               // 3: kratos.api.Order
+	(*Alert)(nil),               // 4: kratos.api.Alert
+	(*Payment)(nil),             // 5: kratos.api.Payment
+	(*Consultation)(nil),        // 6: kratos.api.Consultation
+	(*Inventory)(nil),           // 7: kratos.api.Inventory
+	(*Server_HTTP)(nil),         // 8: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),         // 9: kratos.api.Server.GRPC
+	(*Data_Database)(nil),       // 10: kratos.api.Data.Database
+	(*Data_Redis)(nil),          // 11: kratos.api.Data.Redis
+	(*Data_Elasticsearch)(nil),  // 12: kratos.api.Data.Elasticsearch
+	(*Data_Idempotency)(nil),    // 13: kratos.api.Data.Idempotency
+	(*Order_Expiry)(nil),        // 14: kratos.api.Order.Expiry
+	(*Order_Refund)(nil),        // 15: kratos.api.Order.Refund
+	(*Alert_Webhook)(nil),       // 16: kratos.api.Alert.Webhook
+	(*Alert_Email)(nil),         // 17: kratos.api.Alert.Email
+	(*Payment_Wechat)(nil),      // 18: kratos.api.Payment.Wechat
+	(*Payment_Sandbox)(nil),     // 19: kratos.api.Payment.Sandbox
+	(*Payment_Reconcile)(nil),   // 20: kratos.api.Payment.Reconcile
+	(*durationpb.Duration)(nil), // 21: google.protobuf.Duration
 }
 var file_internal_conf_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	3,  // 2: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	4,  // 3: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	5,  // 4: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	6,  // 5: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	7,  // 6: kratos.api.Data.elasticsearch:type_name -> kratos.api.Data.Elasticsearch
-	8,  // 7: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	8,  // 8: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	8,  // 9: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	8,  // 10: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	8,  // 11: kratos.api.Data.Elasticsearch.timeout:type_name -> google.protobuf.Duration
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	3,  // 2: kratos.api.Bootstrap.order:type_name -> kratos.api.Order
+	4,  // 3: kratos.api.Bootstrap.alert:type_name -> kratos.api.Alert
+	5,  // 4: kratos.api.Bootstrap.payment:type_name -> kratos.api.Payment
+	6,  // 5: kratos.api.Bootstrap.consultation:type_name -> kratos.api.Consultation
+	7,  // 6: kratos.api.Bootstrap.inventory:type_name -> kratos.api.Inventory
+	8,  // 7: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	9,  // 8: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	10, // 9: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	11, // 10: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	12, // 11: kratos.api.Data.elasticsearch:type_name -> kratos.api.Data.Elasticsearch
+	13, // 12: kratos.api.Data.idempotency:type_name -> kratos.api.Data.Idempotency
+	14, // 13: kratos.api.Order.expiry:type_name -> kratos.api.Order.Expiry
+	15, // 14: kratos.api.Order.refund:type_name -> kratos.api.Order.Refund
+	16, // 15: kratos.api.Alert.webhook:type_name -> kratos.api.Alert.Webhook
+	17, // 16: kratos.api.Alert.email:type_name -> kratos.api.Alert.Email
+	18, // 17: kratos.api.Payment.wechat:type_name -> kratos.api.Payment.Wechat
+	19, // 18: kratos.api.Payment.sandbox:type_name -> kratos.api.Payment.Sandbox
+	20, // 19: kratos.api.Payment.reconcile:type_name -> kratos.api.Payment.Reconcile
+	21, // 20: kratos.api.Consultation.answer_timeout:type_name -> google.protobuf.Duration
+	21, // 21: kratos.api.Consultation.duration:type_name -> google.protobuf.Duration
+	21, // 22: kratos.api.Consultation.interval:type_name -> google.protobuf.Duration
+	21, // 23: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	21, // 24: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	21, // 25: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	21, // 26: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	21, // 27: kratos.api.Data.Elasticsearch.timeout:type_name -> google.protobuf.Duration
+	21, // 28: kratos.api.Data.Elasticsearch.sync_interval:type_name -> google.protobuf.Duration
+	21, // 29: kratos.api.Data.Idempotency.ttl:type_name -> google.protobuf.Duration
+	21, // 30: kratos.api.Order.Expiry.ttl:type_name -> google.protobuf.Duration
+	21, // 31: kratos.api.Order.Expiry.interval:type_name -> google.protobuf.Duration
+	21, // 32: kratos.api.Order.Refund.interval:type_name -> google.protobuf.Duration
+	21, // 33: kratos.api.Alert.Webhook.timeout:type_name -> google.protobuf.Duration
+	21, // 34: kratos.api.Alert.Email.timeout:type_name -> google.protobuf.Duration
+	21, // 35: kratos.api.Payment.Wechat.timeout:type_name -> google.protobuf.Duration
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_internal_conf_conf_proto_init() }
@@ -606,7 +1576,7 @@ func file_internal_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_conf_conf_proto_rawDesc), len(file_internal_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
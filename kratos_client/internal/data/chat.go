@@ -0,0 +1,384 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+)
+
+// 聊天消息数据模型，与后台 medicine.MtChatMessage 共用 mt_chat_message 表
+type MtChatMessage struct {
+	ID          int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	FromRole    string         `gorm:"column:from_role;size:20;not null;default:''" json:"from_role"`
+	FromID      int32          `gorm:"column:from_id;not null" json:"from_id"`
+	ToRole      string         `gorm:"column:to_role;size:20;not null;default:''" json:"to_role"`
+	ToID        int32          `gorm:"column:to_id;index;not null" json:"to_id"`
+	Content     string         `gorm:"column:content;type:text" json:"content"`
+	MessageType string         `gorm:"column:message_type;size:20" json:"message_type"`
+	Payload     string         `gorm:"column:payload;type:text" json:"payload"`
+	RoomID      int32          `gorm:"column:room_id;index;not null" json:"room_id"`
+	DeliveredAt *time.Time     `gorm:"column:delivered_at" json:"delivered_at"`
+	ReadAt      *time.Time     `gorm:"column:read_at" json:"read_at"`
+}
+
+func (MtChatMessage) TableName() string {
+	return "mt_chat_message"
+}
+
+// 聊天房间数据模型，与后台 medicine.MtChatRoom 共用 mt_char_room 表
+type MtChatRoom struct {
+	ID              int32     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	RoomID          string    `gorm:"column:room_id;size:50;uniqueIndex" json:"room_id"`
+	RoomName        string    `gorm:"column:room_name;size:50" json:"room_name"`
+	User1Role       string    `gorm:"column:user1_role;size:20;not null;default:''" json:"user1_role"`
+	User1ID         int32     `gorm:"column:user1_id;index;not null;default:0" json:"user1_id"`
+	User2Role       string    `gorm:"column:user2_role;size:20;not null;default:''" json:"user2_role"`
+	User2ID         int32     `gorm:"column:user2_id;index;not null;default:0" json:"user2_id"`
+	User1Unread     int32     `gorm:"column:user1_unread;not null;default:0" json:"user1_unread"`
+	User2Unread     int32     `gorm:"column:user2_unread;not null;default:0" json:"user2_unread"`
+	LastMessage     string    `gorm:"column:last_message;size:50" json:"last_message"`
+	LastMessageTime time.Time `gorm:"column:last_message_time" json:"last_message_time"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (MtChatRoom) TableName() string {
+	return "mt_char_room"
+}
+
+// 聊天仓储实现
+type chatRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建聊天仓储
+func NewChatRepo(data *Data, logger log.Logger) biz.ChatRepo {
+	return &chatRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 聊天跨节点代理，配置Redis时经Redis发布订阅在副本间推送消息、共享在线状态，否则只在本进程内推送
+func NewChatBroker(data *Data, logger log.Logger) comment.Broker {
+	if data.RDb != nil {
+		return comment.NewRedisBroker(data.RDb)
+	}
+	log.NewHelper(logger).Warn("Redis未配置，聊天消息只能推送给本节点的连接")
+	return comment.NewMemoryBroker()
+}
+
+// 聊天图片、语音须由上传接口保存在MinIO的存储桶中
+type minioChatMediaChecker struct{}
+
+func NewChatMediaChecker() biz.ChatMediaChecker {
+	return minioChatMediaChecker{}
+}
+
+func (minioChatMediaChecker) IsUploaded(url string) bool {
+	return comment.IsUploadedURL(url)
+}
+
+// 服务端生成的消息经聊天管理器推送，多副本时由代理转发到接收方所在节点
+type websocketChatPusher struct{}
+
+func NewChatPusher() biz.ChatPusher {
+	return websocketChatPusher{}
+}
+
+func (websocketChatPusher) PushChatMessage(message *biz.ChatMessage) {
+	comment.DeliverChatMessage(comment.ChatMessage{
+		ID:        message.ID,
+		Type:      message.MessageType,
+		Content:   message.Content,
+		FromRole:  message.FromRole,
+		FromID:    message.FromID,
+		ToRole:    message.ToRole,
+		ToID:      message.ToID,
+		Timestamp: message.CreatedAt,
+		RoomID:    biz.ChatRoomID(message.From(), message.To()),
+		Payload:   json.RawMessage(message.Payload),
+	})
+}
+
+// 获取数据库连接，在事务中时使用事务连接
+func (r *chatRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
+}
+
+func toBizChatRoom(room *MtChatRoom) *biz.ChatRoom {
+	return &biz.ChatRoom{
+		ID:              room.ID,
+		RoomID:          room.RoomID,
+		RoomName:        room.RoomName,
+		User1Role:       room.User1Role,
+		User1ID:         room.User1ID,
+		User2Role:       room.User2Role,
+		User2ID:         room.User2ID,
+		User1Unread:     room.User1Unread,
+		User2Unread:     room.User2Unread,
+		LastMessage:     room.LastMessage,
+		LastMessageTime: room.LastMessageTime,
+		CreatedAt:       room.CreatedAt,
+		UpdatedAt:       room.UpdatedAt,
+	}
+}
+
+func toBizChatMessage(message *MtChatMessage) *biz.ChatMessage {
+	return &biz.ChatMessage{
+		ID:          message.ID,
+		RoomID:      message.RoomID,
+		FromRole:    message.FromRole,
+		FromID:      message.FromID,
+		ToRole:      message.ToRole,
+		ToID:        message.ToID,
+		Content:     message.Content,
+		MessageType: message.MessageType,
+		Payload:     message.Payload,
+		DeliveredAt: message.DeliveredAt,
+		ReadAt:      message.ReadAt,
+		CreatedAt:   message.CreatedAt,
+	}
+}
+
+// 获取两个用户之间的房间，不存在时创建；并发创建时以房间号唯一索引去重
+func (r *chatRepo) GetOrCreateRoom(ctx context.Context, room *biz.ChatRoom) (*biz.ChatRoom, error) {
+	now := time.Now()
+	record := &MtChatRoom{
+		RoomID:          room.RoomID,
+		RoomName:        room.RoomName,
+		User1Role:       room.User1Role,
+		User1ID:         room.User1ID,
+		User2Role:       room.User2Role,
+		User2ID:         room.User2ID,
+		LastMessageTime: now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record).Error; err != nil {
+		r.log.Errorf("创建聊天房间失败: %v", err)
+		return nil, err
+	}
+
+	var created MtChatRoom
+	if err := r.getDB(ctx).Where("room_id = ?", room.RoomID).First(&created).Error; err != nil {
+		r.log.Errorf("查询聊天房间失败: %v", err)
+		return nil, err
+	}
+	return toBizChatRoom(&created), nil
+}
+
+// 根据房间号查询房间
+func (r *chatRepo) GetRoomByRoomID(ctx context.Context, roomID string) (*biz.ChatRoom, error) {
+	var room MtChatRoom
+	err := r.getDB(ctx).Where("room_id = ?", roomID).First(&room).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.Errorf("查询聊天房间失败: %v", err)
+		return nil, err
+	}
+	return toBizChatRoom(&room), nil
+}
+
+// 查询用户参与的房间，按最后消息时间倒序
+func (r *chatRepo) ListUserRooms(ctx context.Context, user biz.ChatUser) ([]*biz.ChatRoom, error) {
+	var rooms []MtChatRoom
+	err := r.getDB(ctx).
+		Where("(user1_id = ? AND user1_role = ?) OR (user2_id = ? AND user2_role = ?)", user.ID, user.Role, user.ID, user.Role).
+		Order("last_message_time DESC").
+		Order("id DESC").
+		Find(&rooms).Error
+	if err != nil {
+		r.log.Errorf("查询用户聊天房间失败: %v", err)
+		return nil, err
+	}
+
+	result := make([]*biz.ChatRoom, len(rooms))
+	for i := range rooms {
+		result[i] = toBizChatRoom(&rooms[i])
+	}
+	return result, nil
+}
+
+// 保存消息，同时更新房间最后一条消息并累加接收方未读数
+func (r *chatRepo) SaveMessage(ctx context.Context, room *biz.ChatRoom, message *biz.ChatMessage) error {
+	unreadColumn := "user2_unread"
+	if message.ToID == room.User1ID && message.ToRole == room.User1Role {
+		unreadColumn = "user1_unread"
+	}
+
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		record := &MtChatMessage{
+			FromRole:    message.FromRole,
+			FromID:      message.FromID,
+			ToRole:      message.ToRole,
+			ToID:        message.ToID,
+			Content:     message.Content,
+			MessageType: message.MessageType,
+			Payload:     message.Payload,
+			RoomID:      room.ID,
+			CreatedAt:   message.CreatedAt,
+			UpdatedAt:   message.CreatedAt,
+		}
+		if err := tx.Create(record).Error; err != nil {
+			r.log.Errorf("保存聊天消息失败: %v", err)
+			return err
+		}
+		message.ID = record.ID
+
+		err := tx.Model(&MtChatRoom{}).Where("id = ?", room.ID).Updates(map[string]interface{}{
+			"last_message":      room.LastMessage,
+			"last_message_time": room.LastMessageTime,
+			unreadColumn:        gorm.Expr(unreadColumn + " + 1"),
+			"updated_at":        time.Now(),
+		}).Error
+		if err != nil {
+			r.log.Errorf("更新聊天房间失败: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// 按游标倒序查询房间消息
+func (r *chatRepo) ListMessages(ctx context.Context, roomID int32, cursor int64, offset, limit int) ([]*biz.ChatMessage, error) {
+	db := r.getDB(ctx).Where("room_id = ?", roomID)
+	if cursor > 0 {
+		db = db.Where("id < ?", cursor)
+	} else if offset > 0 {
+		db = db.Offset(offset)
+	}
+
+	var messages []MtChatMessage
+	if err := db.Order("id DESC").Limit(limit).Find(&messages).Error; err != nil {
+		r.log.Errorf("查询聊天记录失败: %v", err)
+		return nil, err
+	}
+
+	result := make([]*biz.ChatMessage, len(messages))
+	for i := range messages {
+		result[i] = toBizChatMessage(&messages[i])
+	}
+	return result, nil
+}
+
+// 统计房间消息数
+func (r *chatRepo) CountMessages(ctx context.Context, roomID int32) (int64, error) {
+	var total int64
+	if err := r.getDB(ctx).Model(&MtChatMessage{}).Where("room_id = ?", roomID).Count(&total).Error; err != nil {
+		r.log.Errorf("统计聊天记录失败: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// 查询发给用户且尚未送达的消息，按发送顺序
+func (r *chatRepo) ListUndeliveredMessages(ctx context.Context, to biz.ChatUser, limit int) ([]*biz.ChatMessage, error) {
+	var messages []MtChatMessage
+	err := r.getDB(ctx).
+		Where("to_id = ? AND to_role = ? AND delivered_at IS NULL", to.ID, to.Role).
+		Order("id").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		r.log.Errorf("查询未送达消息失败: %v", err)
+		return nil, err
+	}
+
+	result := make([]*biz.ChatMessage, len(messages))
+	for i := range messages {
+		result[i] = toBizChatMessage(&messages[i])
+	}
+	return result, nil
+}
+
+// 将发给用户的消息标记为已送达，返回本次新标记的消息
+func (r *chatRepo) MarkMessagesDelivered(ctx context.Context, to biz.ChatUser, ids []int64, deliveredAt time.Time) ([]*biz.ChatMessage, error) {
+	var messages []MtChatMessage
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id IN ? AND to_id = ? AND to_role = ? AND delivered_at IS NULL", ids, to.ID, to.Role).Order("id").Find(&messages).Error; err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		pending := make([]int64, len(messages))
+		for i := range messages {
+			pending[i] = messages[i].ID
+			messages[i].DeliveredAt = &deliveredAt
+		}
+		return tx.Model(&MtChatMessage{}).
+			Where("id IN ? AND delivered_at IS NULL", pending).
+			Update("delivered_at", deliveredAt).Error
+	})
+	if err != nil {
+		r.log.Errorf("标记消息送达失败: %v", err)
+		return nil, err
+	}
+
+	result := make([]*biz.ChatMessage, len(messages))
+	for i := range messages {
+		result[i] = toBizChatMessage(&messages[i])
+	}
+	return result, nil
+}
+
+// 将房间内发给用户且ID不大于 upToID 的消息标记为已读，未读数按剩余未读消息重算
+func (r *chatRepo) MarkMessagesRead(ctx context.Context, roomID int32, user biz.ChatUser, upToID int64, readAt time.Time) (int64, error) {
+	var readID int64
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var room MtChatRoom
+		if err := tx.Where("id = ?", roomID).First(&room).Error; err != nil {
+			return err
+		}
+
+		unread := tx.Model(&MtChatMessage{}).Where("room_id = ? AND to_id = ? AND to_role = ? AND read_at IS NULL", roomID, user.ID, user.Role)
+		if upToID > 0 {
+			unread = unread.Where("id <= ?", upToID)
+		}
+		if err := unread.Select("COALESCE(MAX(id), 0)").Scan(&readID).Error; err != nil {
+			return err
+		}
+		if readID > 0 {
+			err := tx.Model(&MtChatMessage{}).
+				Where("room_id = ? AND to_id = ? AND to_role = ? AND read_at IS NULL AND id <= ?", roomID, user.ID, user.Role, readID).
+				Updates(map[string]interface{}{
+					"read_at":      readAt,
+					"delivered_at": gorm.Expr("COALESCE(delivered_at, ?)", readAt),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var remaining int64
+		if err := tx.Model(&MtChatMessage{}).Where("room_id = ? AND to_id = ? AND to_role = ? AND read_at IS NULL", roomID, user.ID, user.Role).Count(&remaining).Error; err != nil {
+			return err
+		}
+		unreadColumn := "user2_unread"
+		if room.User1ID == user.ID && room.User1Role == user.Role {
+			unreadColumn = "user1_unread"
+		}
+		return tx.Model(&MtChatRoom{}).Where("id = ?", roomID).Update(unreadColumn, remaining).Error
+	})
+	if err != nil {
+		r.log.Errorf("标记消息已读失败: %v", err)
+		return 0, err
+	}
+	return readID, nil
+}
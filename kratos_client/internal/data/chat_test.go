@@ -0,0 +1,296 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTestChatUsecase(d *Data) *biz.ChatUsecase {
+	logger := newTestLogger()
+	return biz.NewChatUsecase(NewChatRepo(d, logger), NewChatMediaChecker(), NewPrescriptionRepo(d, logger), NewOrderRepo(d, logger), logger)
+}
+
+// 测试聊天消息落库、房间摘要、未读数和游标分页
+func TestChatPersistence(t *testing.T) {
+	d := newTestData(t, &MtChatMessage{}, &MtChatRoom{})
+	uc := newTestChatUsecase(d)
+	ctx := context.Background()
+	doctor, patient := biz.ChatUser{Role: biz.ChatRoleDoctor, ID: 7}, biz.ChatUser{Role: biz.ChatRolePatient, ID: 3}
+
+	for i := 1; i <= 5; i++ {
+		if _, _, err := uc.SendMessage(ctx, &biz.SendChatMessageRequest{From: patient, To: doctor, Content: fmt.Sprintf("患者消息%d", i)}); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+	if _, _, err := uc.SendMessage(ctx, &biz.SendChatMessageRequest{From: doctor, To: patient, Content: "请描述一下症状", MessageType: biz.ChatMessageTypeText}); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if _, _, err := uc.SendMessage(ctx, &biz.SendChatMessageRequest{From: doctor, To: doctor, Content: "自言自语"}); err == nil {
+		t.Errorf("Expected error when sending to self")
+	}
+
+	// 双方共用一个房间，各自的未读数只统计对方发来的消息
+	rooms, err := uc.ListRooms(ctx, doctor)
+	if err != nil || len(rooms) != 1 {
+		t.Fatalf("Expected 1 room, got %d, %v", len(rooms), err)
+	}
+	room := rooms[0]
+	if room.RoomID != "room_doctor_7_patient_3" || room.Target(doctor) != patient {
+		t.Errorf("Unexpected room %s target %+v", room.RoomID, room.Target(doctor))
+	}
+	if room.LastMessage != "请描述一下症状" {
+		t.Errorf("Unexpected last message %q", room.LastMessage)
+	}
+	if room.UnreadCount(doctor) != 5 || room.UnreadCount(patient) != 1 {
+		t.Errorf("Expected unread doctor=5 patient=1, got %d %d", room.UnreadCount(doctor), room.UnreadCount(patient))
+	}
+
+	// 游标分页，每页按时间正序
+	first, _, err := uc.GetHistory(ctx, doctor, patient, 0, 0, 4)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if first.Total != 6 || len(first.Messages) != 4 || !first.HasMore {
+		t.Fatalf("Unexpected first page total=%d len=%d hasMore=%v", first.Total, len(first.Messages), first.HasMore)
+	}
+	if first.Messages[3].Content != "请描述一下症状" || first.NextCursor != first.Messages[0].ID {
+		t.Errorf("Unexpected first page order %q cursor %d", first.Messages[3].Content, first.NextCursor)
+	}
+	second, _, err := uc.GetHistory(ctx, patient, doctor, first.NextCursor, 0, 4)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(second.Messages) != 2 || second.HasMore || second.Messages[0].Content != "患者消息1" {
+		t.Errorf("Unexpected second page len=%d hasMore=%v", len(second.Messages), second.HasMore)
+	}
+
+	// 标记已读只清零自己的未读数
+	if _, err := uc.MarkRead(ctx, doctor, patient, 0); err != nil {
+		t.Fatalf("MarkRead failed: %v", err)
+	}
+	rooms, _ = uc.ListRooms(ctx, patient)
+	if rooms[0].UnreadCount(doctor) != 0 || rooms[0].UnreadCount(patient) != 1 {
+		t.Errorf("Expected unread doctor=0 patient=1, got %d %d", rooms[0].UnreadCount(doctor), rooms[0].UnreadCount(patient))
+	}
+
+	// 没有聊过的用户返回空记录
+	empty, _, err := uc.GetHistory(ctx, doctor, biz.ChatUser{Role: biz.ChatRolePatient, ID: 99}, 0, 0, 10)
+	if err != nil || len(empty.Messages) != 0 {
+		t.Errorf("Expected empty history, got %v, %v", empty, err)
+	}
+
+	// 与医生ID相同的患者是另一个用户，使用单独的房间和未读数
+	namesake := biz.ChatUser{Role: biz.ChatRolePatient, ID: doctor.ID}
+	if _, _, err := uc.SendMessage(ctx, &biz.SendChatMessageRequest{From: namesake, To: doctor, Content: "同号患者"}); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if rooms, _ := uc.ListRooms(ctx, doctor); len(rooms) != 2 || rooms[0].RoomID != "room_doctor_7_patient_7" || rooms[0].UnreadCount(doctor) != 1 {
+		t.Errorf("Expected separate room for namesake patient, got %d rooms", len(rooms))
+	}
+	if rooms, _ := uc.ListRooms(ctx, namesake); len(rooms) != 1 || rooms[0].UnreadCount(namesake) != 0 {
+		t.Errorf("Expected namesake patient to see only own room, got %d rooms", len(rooms))
+	}
+	if _, _, err := uc.GetHistory(ctx, patient, namesake, 0, 0, 10); err != nil {
+		t.Errorf("GetHistory failed: %v", err)
+	}
+}
+
+// 测试离线消息补发、送达确认和已读回执
+func TestChatDeliveryReceipts(t *testing.T) {
+	d := newTestData(t, &MtChatMessage{}, &MtChatRoom{})
+	uc := newTestChatUsecase(d)
+	ctx := context.Background()
+	doctor, patient := biz.ChatUser{Role: biz.ChatRoleDoctor, ID: 7}, biz.ChatUser{Role: biz.ChatRolePatient, ID: 3}
+
+	var ids []int64
+	for i := 1; i <= 3; i++ {
+		message, _, err := uc.SendMessage(ctx, &biz.SendChatMessageRequest{From: patient, To: doctor, Content: fmt.Sprintf("离线消息%d", i)})
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		ids = append(ids, message.ID)
+	}
+
+	pending, err := uc.PendingMessages(ctx, doctor)
+	if err != nil || len(pending) != 3 || pending[0].ID != ids[0] {
+		t.Fatalf("Expected 3 pending messages in order, got %d, %v", len(pending), err)
+	}
+	if pending[0].Status() != biz.ChatMessageStatusSent {
+		t.Errorf("Expected sent status, got %s", pending[0].Status())
+	}
+
+	// 发送方不能确认自己发出的消息
+	if acked, _ := uc.AckDelivered(ctx, patient, ids); len(acked) != 0 {
+		t.Errorf("Sender should not ack own messages, got %d", len(acked))
+	}
+	acked, err := uc.AckDelivered(ctx, doctor, ids[:2])
+	if err != nil || len(acked) != 2 || acked[0].Status() != biz.ChatMessageStatusDelivered {
+		t.Fatalf("Expected 2 delivered, got %d, %v", len(acked), err)
+	}
+	// 多设备重复确认不再返回
+	if acked, _ := uc.AckDelivered(ctx, doctor, ids[:2]); len(acked) != 0 {
+		t.Errorf("Expected repeated ack ignored, got %d", len(acked))
+	}
+	if pending, _ := uc.PendingMessages(ctx, doctor); len(pending) != 1 || pending[0].ID != ids[2] {
+		t.Errorf("Expected only last message pending, got %d", len(pending))
+	}
+
+	// 已读到第二条，未读数按剩余未读消息重算
+	readID, err := uc.MarkRead(ctx, doctor, patient, ids[1])
+	if err != nil || readID != ids[1] {
+		t.Fatalf("Expected read up to %d, got %d, %v", ids[1], readID, err)
+	}
+	rooms, _ := uc.ListRooms(ctx, doctor)
+	if rooms[0].UnreadCount(doctor) != 1 {
+		t.Errorf("Expected 1 unread, got %d", rooms[0].UnreadCount(doctor))
+	}
+	history, _, _ := uc.GetHistory(ctx, patient, doctor, 0, 0, 10)
+	statuses := []string{history.Messages[0].Status(), history.Messages[1].Status(), history.Messages[2].Status()}
+	if statuses[0] != biz.ChatMessageStatusRead || statuses[1] != biz.ChatMessageStatusRead || statuses[2] != biz.ChatMessageStatusSent {
+		t.Errorf("Unexpected statuses %v", statuses)
+	}
+
+	// 全部已读时未送达的消息一并视为送达
+	if readID, _ := uc.MarkRead(ctx, doctor, patient, 0); readID != ids[2] {
+		t.Errorf("Expected read up to %d, got %d", ids[2], readID)
+	}
+	if pending, _ := uc.PendingMessages(ctx, doctor); len(pending) != 0 {
+		t.Errorf("Expected no pending after read, got %d", len(pending))
+	}
+}
+
+// 测试图片、语音、处方卡片、订单卡片和系统通知的校验与存储
+func TestChatRichMessages(t *testing.T) {
+	d := newOrderTestData(t, &MtChatMessage{}, &MtChatRoom{}, &MtPrescription{}, &MtPrescriptionMedicine{})
+	uc := newTestChatUsecase(d)
+	ctx := context.Background()
+	doctor, patient := biz.ChatUser{Role: biz.ChatRoleDoctor, ID: 7}, biz.ChatUser{Role: biz.ChatRolePatient, ID: 1001}
+	order := createTestOrder(t, newTestOrderUsecase(d), 2)
+
+	prescription := &MtPrescription{
+		PrescriptionNo:   "RX20240101001",
+		DoctorID:         uint64(doctor.ID),
+		PatientID:        uint64(patient.ID),
+		PrescriptionDate: time.Now(),
+		TotalAmount:      decimal.NewFromFloat(36.8),
+		PrescriptionType: "西药",
+		Status:           "已开具",
+	}
+	if err := d.Db.Create(prescription).Error; err != nil {
+		t.Fatalf("创建测试处方失败: %v", err)
+	}
+	other := &MtPrescription{PrescriptionNo: "RX20240101002", DoctorID: 8, PatientID: uint64(patient.ID), PrescriptionDate: time.Now()}
+	if err := d.Db.Create(other).Error; err != nil {
+		t.Fatalf("创建测试处方失败: %v", err)
+	}
+
+	imageURL := fmt.Sprintf("%s/%s/tongue.jpg", comment.MinioEndpoint, comment.BucketName)
+	send := func(from, to biz.ChatUser, messageType string, payload string) (*biz.ChatMessage, error) {
+		message, _, err := uc.SendMessage(ctx, &biz.SendChatMessageRequest{
+			From: from, To: to, MessageType: messageType, Payload: json.RawMessage(payload),
+		})
+		return message, err
+	}
+
+	invalid := []struct {
+		name        string
+		from        biz.ChatUser
+		messageType string
+		payload     string
+	}{
+		{"图片缺少地址", patient, biz.ChatMessageTypeImage, `{}`},
+		{"图片格式不支持", patient, biz.ChatMessageTypeImage, `{"url":"` + strings.TrimSuffix(imageURL, ".jpg") + `.exe"}`},
+		{"图片非上传地址", patient, biz.ChatMessageTypeImage, `{"url":"http://example.com/a.jpg"}`},
+		{"语音过长", patient, biz.ChatMessageTypeVoice, `{"url":"` + strings.TrimSuffix(imageURL, ".jpg") + `.amr","duration":61}`},
+		{"处方不存在", doctor, biz.ChatMessageTypePrescription, `{"prescription_id":999}`},
+		{"处方不属于会话", doctor, biz.ChatMessageTypePrescription, fmt.Sprintf(`{"prescription_id":%d}`, other.ID)},
+		{"订单不存在", patient, biz.ChatMessageTypeOrder, `{"order_no":"NOPE"}`},
+		{"用户发送系统消息", doctor, biz.ChatMessageTypeSystem, `{"event":"consultation_ended","text":"问诊已结束"}`},
+		{"未知类型", patient, "video", `{}`},
+		{"数据格式错误", patient, biz.ChatMessageTypeImage, `[1]`},
+	}
+	for _, tc := range invalid {
+		to := doctor
+		if tc.from == doctor {
+			to = patient
+		}
+		if _, err := send(tc.from, to, tc.messageType, tc.payload); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+	// ID相同但角色不符的一方不能发送处方和订单卡片
+	pharmacist := biz.ChatUser{Role: biz.ChatRolePharmacist, ID: doctor.ID}
+	if _, err := send(pharmacist, patient, biz.ChatMessageTypePrescription, fmt.Sprintf(`{"prescription_id":%d}`, prescription.ID)); err == nil {
+		t.Errorf("Expected prescription rejected for pharmacist with doctor's ID")
+	}
+	namesake := biz.ChatUser{Role: biz.ChatRoleDoctor, ID: patient.ID}
+	if _, err := send(namesake, pharmacist, biz.ChatMessageTypeOrder, `{"order_no":"`+order.OrderNo+`"}`); err == nil {
+		t.Errorf("Expected order rejected for doctor with patient's ID")
+	}
+
+	image, err := send(patient, doctor, biz.ChatMessageTypeImage, `{"url":"http://`+imageURL+`","width":800,"height":600,"size":20480}`)
+	if err != nil {
+		t.Fatalf("Send image failed: %v", err)
+	}
+	if image.Content != "[图片]" {
+		t.Errorf("Unexpected image digest %q", image.Content)
+	}
+	if _, err := send(patient, doctor, biz.ChatMessageTypeVoice, `{"url":"`+strings.TrimSuffix(imageURL, ".jpg")+`.amr","duration":12}`); err != nil {
+		t.Fatalf("Send voice failed: %v", err)
+	}
+	// 卡片只需要传ID，其余字段按处方、订单生成快照，客户端传入的字段被忽略
+	card, err := send(doctor, patient, biz.ChatMessageTypePrescription, fmt.Sprintf(`{"prescription_id":%d,"status":"伪造"}`, prescription.ID))
+	if err != nil {
+		t.Fatalf("Send prescription failed: %v", err)
+	}
+	if card.Content != "[处方] RX20240101001" {
+		t.Errorf("Unexpected prescription digest %q", card.Content)
+	}
+	if _, err := send(patient, doctor, biz.ChatMessageTypeOrder, `{"order_no":"`+order.OrderNo+`"}`); err != nil {
+		t.Fatalf("Send order failed: %v", err)
+	}
+	if _, _, err := uc.SendSystemNotice(ctx, doctor, patient, biz.ChatSystemEventConsultationEnded, "问诊已结束"); err != nil {
+		t.Fatalf("SendSystemNotice failed: %v", err)
+	}
+
+	history, _, err := uc.GetHistory(ctx, patient, doctor, 0, 0, 10)
+	if err != nil || len(history.Messages) != 5 {
+		t.Fatalf("Expected 5 messages, got %d, %v", len(history.Messages), err)
+	}
+	var imagePayload biz.ChatImagePayload
+	if err := history.Messages[0].DecodePayload(&imagePayload); err != nil || imagePayload.Width != 800 || imagePayload.Size != 20480 {
+		t.Errorf("Unexpected image payload %+v, %v", imagePayload, err)
+	}
+	var voicePayload biz.ChatVoicePayload
+	if err := history.Messages[1].DecodePayload(&voicePayload); err != nil || voicePayload.Duration != 12 {
+		t.Errorf("Unexpected voice payload %+v, %v", voicePayload, err)
+	}
+	var prescriptionPayload biz.ChatPrescriptionPayload
+	if err := history.Messages[2].DecodePayload(&prescriptionPayload); err != nil ||
+		prescriptionPayload.Status != "已开具" || prescriptionPayload.TotalAmount != "36.80" || prescriptionPayload.PrescriptionNo != "RX20240101001" {
+		t.Errorf("Unexpected prescription payload %+v, %v", prescriptionPayload, err)
+	}
+	var orderPayload biz.ChatOrderPayload
+	if err := history.Messages[3].DecodePayload(&orderPayload); err != nil ||
+		orderPayload.OrderNo != order.OrderNo || orderPayload.ItemCount != 1 || orderPayload.Title != "感冒灵颗粒" || orderPayload.TotalAmount != "25.00" {
+		t.Errorf("Unexpected order payload %+v, %v", orderPayload, err)
+	}
+	var systemPayload biz.ChatSystemPayload
+	if err := history.Messages[4].DecodePayload(&systemPayload); err != nil || systemPayload.Event != biz.ChatSystemEventConsultationEnded {
+		t.Errorf("Unexpected system payload %+v, %v", systemPayload, err)
+	}
+
+	rooms, _ := uc.ListRooms(ctx, doctor)
+	if rooms[0].LastMessage != "问诊已结束" {
+		t.Errorf("Unexpected last message %q", rooms[0].LastMessage)
+	}
+}
@@ -36,6 +36,17 @@ func (c *cityRepo) LikeFind(ctx context.Context, m *biz.MtCity) (*[]biz.MtCity,
 	return &mList, nil
 }
 
+func (c *cityRepo) GetCity(ctx context.Context, code int32) (*biz.MtCity, error) {
+	var cities []biz.MtCity
+	if err := c.data.Db.Where("code = ?", code).Limit(1).Find(&cities).Error; err != nil {
+		return nil, err
+	}
+	if len(cities) == 0 {
+		return nil, nil
+	}
+	return &cities[0], nil
+}
+
 func (c *cityRepo) Create(ctx context.Context, m *biz.MtAddress) (*biz.MtAddress, error) {
 	err := c.data.Db.Create(m).Error
 	if err != nil {
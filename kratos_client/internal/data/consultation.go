@@ -0,0 +1,293 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+)
+
+// 问诊数据模型 - 对应 mt_consultation 表
+type MtConsultation struct {
+	ID             int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ConsultationNo string          `gorm:"column:consultation_no;size:64;not null;uniqueIndex:idx_consultation_no" json:"consultation_no"`
+	PatientID      int32           `gorm:"column:patient_id;not null;index:idx_consultation_patient" json:"patient_id"`
+	DoctorID       int32           `gorm:"column:doctor_id;not null;index:idx_consultation_doctor" json:"doctor_id"`
+	DoctorName     string          `gorm:"column:doctor_name;size:50" json:"doctor_name"`
+	Fee            decimal.Decimal `gorm:"column:fee;type:decimal(10,2);not null" json:"fee"`
+	Description    string          `gorm:"column:description;size:500" json:"description"`
+	Status         string          `gorm:"column:status;size:32;not null;index:idx_consultation_status" json:"status"`
+	PaymentOrderID string          `gorm:"column:payment_order_id;size:64" json:"payment_order_id"`
+	RefundID       string          `gorm:"column:refund_id;size:64" json:"refund_id"`
+	Diagnosis      string          `gorm:"column:diagnosis;type:text" json:"diagnosis"`
+	Advice         string          `gorm:"column:advice;type:text" json:"advice"`
+	CloseReason    string          `gorm:"column:close_reason;size:255" json:"close_reason"`
+	PaidAt         *time.Time      `gorm:"column:paid_at" json:"paid_at"`
+	StartedAt      *time.Time      `gorm:"column:started_at" json:"started_at"`
+	EndsAt         *time.Time      `gorm:"column:ends_at" json:"ends_at"`
+	ClosedAt       *time.Time      `gorm:"column:closed_at" json:"closed_at"`
+	Rating         int32           `gorm:"column:rating;not null;default:0" json:"rating"`
+	RatingComment  string          `gorm:"column:rating_comment;size:255" json:"rating_comment"`
+	RatedAt        *time.Time      `gorm:"column:rated_at" json:"rated_at"`
+	CreatedAt      time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtConsultation) TableName() string {
+	return "mt_consultation"
+}
+
+// 问诊仓储实现
+type consultationRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建问诊仓储
+func NewConsultationRepo(data *Data, logger log.Logger) biz.ConsultationRepo {
+	return &consultationRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 获取数据库连接，在事务中时使用事务连接
+func (r *consultationRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
+}
+
+func toBizConsultation(do *MtConsultation) *biz.Consultation {
+	consultation := &biz.Consultation{
+		ID:             do.ID,
+		ConsultationNo: do.ConsultationNo,
+		PatientID:      do.PatientID,
+		DoctorID:       do.DoctorID,
+		DoctorName:     do.DoctorName,
+		Fee:            do.Fee,
+		Description:    do.Description,
+		Status:         do.Status,
+		PaymentOrderID: do.PaymentOrderID,
+		RefundID:       do.RefundID,
+		Diagnosis:      do.Diagnosis,
+		Advice:         do.Advice,
+		CloseReason:    do.CloseReason,
+		Rating:         do.Rating,
+		RatingComment:  do.RatingComment,
+		CreatedAt:      do.CreatedAt,
+		UpdatedAt:      do.UpdatedAt,
+	}
+	if do.PaidAt != nil {
+		consultation.PaidAt = *do.PaidAt
+	}
+	if do.StartedAt != nil {
+		consultation.StartedAt = *do.StartedAt
+	}
+	if do.EndsAt != nil {
+		consultation.EndsAt = *do.EndsAt
+	}
+	if do.ClosedAt != nil {
+		consultation.ClosedAt = *do.ClosedAt
+	}
+	if do.RatedAt != nil {
+		consultation.RatedAt = *do.RatedAt
+	}
+	return consultation
+}
+
+func toBizConsultations(records []MtConsultation) []*biz.Consultation {
+	result := make([]*biz.Consultation, len(records))
+	for i := range records {
+		result[i] = toBizConsultation(&records[i])
+	}
+	return result
+}
+
+// 创建问诊
+func (r *consultationRepo) CreateConsultation(ctx context.Context, consultation *biz.Consultation) error {
+	do := &MtConsultation{
+		ConsultationNo: consultation.ConsultationNo,
+		PatientID:      consultation.PatientID,
+		DoctorID:       consultation.DoctorID,
+		DoctorName:     consultation.DoctorName,
+		Fee:            consultation.Fee,
+		Description:    consultation.Description,
+		Status:         consultation.Status,
+		PaymentOrderID: consultation.PaymentOrderID,
+		CreatedAt:      consultation.CreatedAt,
+		UpdatedAt:      consultation.UpdatedAt,
+	}
+	if err := r.getDB(ctx).Create(do).Error; err != nil {
+		r.log.Errorf("创建问诊失败: %v", err)
+		return err
+	}
+	consultation.ID = do.ID
+	return nil
+}
+
+// 根据问诊单号查询问诊
+func (r *consultationRepo) GetConsultation(ctx context.Context, consultationNo string) (*biz.Consultation, error) {
+	var do MtConsultation
+	err := r.getDB(ctx).Where("consultation_no = ?", consultationNo).First(&do).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.log.Errorf("查询问诊失败: %v", err)
+		return nil, err
+	}
+	return toBizConsultation(&do), nil
+}
+
+// 分页查询问诊，按创建时间倒序
+func (r *consultationRepo) ListConsultations(ctx context.Context, filter *biz.ConsultationFilter) ([]*biz.Consultation, int64, error) {
+	query := r.getDB(ctx).Model(&MtConsultation{})
+	if filter.PatientID > 0 {
+		query = query.Where("patient_id = ?", filter.PatientID)
+	}
+	if filter.DoctorID > 0 {
+		query = query.Where("doctor_id = ?", filter.DoctorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.log.Errorf("统计问诊数量失败: %v", err)
+		return nil, 0, err
+	}
+
+	var records []MtConsultation
+	offset := int((filter.Page - 1) * filter.PageSize)
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(int(filter.PageSize)).Find(&records).Error; err != nil {
+		r.log.Errorf("查询问诊列表失败: %v", err)
+		return nil, 0, err
+	}
+	return toBizConsultations(records), total, nil
+}
+
+// 查询患者与医生之间待接诊或问诊中的问诊
+func (r *consultationRepo) GetActiveConsultation(ctx context.Context, patientID, doctorID int32) (*biz.Consultation, error) {
+	var records []MtConsultation
+	err := r.getDB(ctx).
+		Where("patient_id = ? AND doctor_id = ? AND status IN ?", patientID, doctorID,
+			[]string{biz.ConsultationStatusWaiting, biz.ConsultationStatusInProgress}).
+		Order("id DESC").Limit(1).Find(&records).Error
+	if err != nil {
+		r.log.Errorf("查询进行中的问诊失败: %v", err)
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return toBizConsultation(&records[0]), nil
+}
+
+// 以当前状态为条件更新问诊状态
+func (r *consultationRepo) UpdateConsultationStatus(ctx context.Context, consultationNo, fromStatus string, update *biz.ConsultationUpdate) error {
+	updates := map[string]interface{}{
+		"status":     update.Status,
+		"updated_at": time.Now(),
+	}
+	if update.RefundID != "" {
+		updates["refund_id"] = update.RefundID
+	}
+	if update.Diagnosis != "" {
+		updates["diagnosis"] = update.Diagnosis
+	}
+	if update.Advice != "" {
+		updates["advice"] = update.Advice
+	}
+	if update.CloseReason != "" {
+		updates["close_reason"] = update.CloseReason
+	}
+	if !update.PaidAt.IsZero() {
+		updates["paid_at"] = update.PaidAt
+	}
+	if !update.StartedAt.IsZero() {
+		updates["started_at"] = update.StartedAt
+	}
+	if !update.EndsAt.IsZero() {
+		updates["ends_at"] = update.EndsAt
+	}
+	if !update.ClosedAt.IsZero() {
+		updates["closed_at"] = update.ClosedAt
+	}
+
+	result := r.getDB(ctx).Model(&MtConsultation{}).
+		Where("consultation_no = ? AND status = ?", consultationNo, fromStatus).
+		Updates(updates)
+	if result.Error != nil {
+		r.log.Errorf("更新问诊状态失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: consultationNo=%s, from=%s, to=%s", biz.ErrConsultationStatusConflict, consultationNo, fromStatus, update.Status)
+	}
+	return nil
+}
+
+// 查询支付后超时未接诊的问诊
+func (r *consultationRepo) ListUnansweredConsultations(ctx context.Context, paidBefore time.Time, limit int) ([]*biz.Consultation, error) {
+	var records []MtConsultation
+	err := r.getDB(ctx).
+		Where("status = ? AND paid_at < ?", biz.ConsultationStatusWaiting, paidBefore).
+		Order("paid_at ASC").Limit(limit).Find(&records).Error
+	if err != nil {
+		r.log.Errorf("查询超时未接诊的问诊失败: %v", err)
+		return nil, err
+	}
+	return toBizConsultations(records), nil
+}
+
+// 查询会话已到期的问诊
+func (r *consultationRepo) ListOverdueConsultations(ctx context.Context, endsBefore time.Time, limit int) ([]*biz.Consultation, error) {
+	var records []MtConsultation
+	err := r.getDB(ctx).
+		Where("status = ? AND ends_at < ?", biz.ConsultationStatusInProgress, endsBefore).
+		Order("ends_at ASC").Limit(limit).Find(&records).Error
+	if err != nil {
+		r.log.Errorf("查询到期的问诊失败: %v", err)
+		return nil, err
+	}
+	return toBizConsultations(records), nil
+}
+
+// 写入问诊评分并累计到医生的评分均值，两者在同一事务内完成
+func (r *consultationRepo) RateConsultation(ctx context.Context, consultation *biz.Consultation, rating int32, comment string, ratedAt time.Time) error {
+	return r.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&MtConsultation{}).
+			Where("consultation_no = ? AND status = ? AND rating = 0", consultation.ConsultationNo, biz.ConsultationStatusClosed).
+			Updates(map[string]interface{}{
+				"rating":         rating,
+				"rating_comment": comment,
+				"rated_at":       ratedAt,
+				"updated_at":     ratedAt,
+			})
+		if result.Error != nil {
+			r.log.Errorf("写入问诊评分失败: %v", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: consultationNo=%s 已评价或未结束", biz.ErrConsultationStatusConflict, consultation.ConsultationNo)
+		}
+
+		// 先按旧的评分人数计算新均值，再累加人数
+		err := tx.Exec("UPDATE mt_doctors SET rating = (rating * rating_count + ?) / (rating_count + 1), rating_count = rating_count + 1 WHERE id = ?",
+			rating, consultation.DoctorID).Error
+		if err != nil {
+			r.log.Errorf("累计医生评分失败: %v", err)
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,236 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+
+	"github.com/shopspring/decimal"
+)
+
+const testDoctorID, testPatientID int32 = 7, 1001
+
+type consultationTestEnv struct {
+	d         *Data
+	uc        *biz.ConsultationUsecase
+	paymentUc *biz.PaymentUsecase
+	refundUc  *biz.RefundUsecase
+	chatUc    *biz.ChatUsecase
+}
+
+// 沙箱渠道下的问诊用例，测试医生ID为7，问诊费30元，extra 为额外需要迁移的表
+func newConsultationTestEnv(t *testing.T, extra ...interface{}) *consultationTestEnv {
+	t.Helper()
+	d := newOrderTestData(t, append([]interface{}{&biz.MtDoctors{}, &MtConsultation{}, &MtChatMessage{}, &MtChatRoom{}}, extra...)...)
+	doctor := &biz.MtDoctors{Id: uint64(testDoctorID), Name: "张医生", Status: "1", ConsultationFee: decimal.NewFromInt(30)}
+	if err := d.Db.Create(doctor).Error; err != nil {
+		t.Fatalf("创建测试医生失败: %v", err)
+	}
+
+	logger := newTestLogger()
+	gateways := NewPaymentGateways(&conf.Payment{Sandbox: &conf.Payment_Sandbox{Enabled: true, Secret: "test"}}, logger)
+	idempotencyUc := biz.NewIdempotencyUsecase(NewIdempotencyRepo(nil, d, logger), logger)
+	paymentUc := biz.NewPaymentUsecase(NewPaymentRepo(d, logger), idempotencyUc, gateways, logger)
+	orderUc := newTestOrderUsecase(d)
+	inventoryRepo := NewDrugInventoryRepo(d, logger)
+	inventoryUc := biz.NewInventoryUsecase(inventoryRepo, NewDrugRepo(d, logger), NewInventoryAlertRepo(d, logger), NewInventoryAlertSink(nil, logger), logger)
+	refundUc := biz.NewRefundUsecase(NewRefundRepo(d, logger), NewOrderRepo(d, logger), NewPaymentRepo(d, logger),
+		inventoryRepo, orderUc, inventoryUc, gateways, logger)
+	chatUc := newTestChatUsecase(d)
+	uc := biz.NewConsultationUsecase(NewConsultationRepo(d, logger), NewDoctorsRepo(d, logger), paymentUc, refundUc, chatUc, nil, idempotencyUc, logger)
+	return &consultationTestEnv{d: d, uc: uc, paymentUc: paymentUc, refundUc: refundUc, chatUc: chatUc}
+}
+
+// 发起问诊并完成支付，返回待接诊的问诊
+func (e *consultationTestEnv) paidConsultation(t *testing.T, description string) *biz.Consultation {
+	t.Helper()
+	ctx := context.Background()
+	consultation, payment, err := e.uc.CreateConsultation(ctx, &biz.CreateConsultationRequest{
+		PatientID: testPatientID, DoctorID: testDoctorID, Description: description,
+	})
+	if err != nil {
+		t.Fatalf("CreateConsultation failed: %v", err)
+	}
+	if _, err := e.paymentUc.SimulatePayment(ctx, payment.OrderID); err != nil {
+		t.Fatalf("SimulatePayment failed: %v", err)
+	}
+	paid, err := e.uc.GetConsultation(ctx, testPatientID, consultation.ConsultationNo)
+	if err != nil {
+		t.Fatalf("GetConsultation failed: %v", err)
+	}
+	if paid.Status != biz.ConsultationStatusWaiting || paid.PaidAt.IsZero() {
+		t.Fatalf("Expected waiting after pay, got %s", paid.Status)
+	}
+	return paid
+}
+
+func (e *consultationTestEnv) lastNotice(t *testing.T) biz.ChatSystemPayload {
+	t.Helper()
+	patient := biz.ChatUser{Role: biz.ChatRolePatient, ID: testPatientID}
+	doctor := biz.ChatUser{Role: biz.ChatRoleDoctor, ID: testDoctorID}
+	history, _, err := e.chatUc.GetHistory(context.Background(), patient, doctor, 0, 1, 1)
+	if err != nil || len(history.Messages) == 0 {
+		t.Fatalf("Expected chat notice, got %v", err)
+	}
+	var payload biz.ChatSystemPayload
+	message := history.Messages[len(history.Messages)-1]
+	if message.MessageType != biz.ChatMessageTypeSystem || message.From() != doctor || message.To() != patient || message.DecodePayload(&payload) != nil {
+		t.Fatalf("Unexpected notice %+v", message)
+	}
+	return payload
+}
+
+// 测试发起、支付、接诊、结束的完整问诊流程
+func TestConsultationLifecycle(t *testing.T) {
+	env := newConsultationTestEnv(t)
+	ctx := context.Background()
+
+	// 携带幂等键的重复请求返回同一问诊
+	req := &biz.CreateConsultationRequest{PatientID: testPatientID, DoctorID: testDoctorID, Description: "咳嗽三天", IdempotencyKey: "consult-1"}
+	consultation, payment, err := env.uc.CreateConsultation(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateConsultation failed: %v", err)
+	}
+	if consultation.Status != biz.ConsultationStatusPendingPayment || payment.TotalAmount != "30.00" || payment.OrderType != biz.OrderTypeConsultation {
+		t.Fatalf("Unexpected consultation %+v, payment %+v", consultation, payment)
+	}
+	replayed, _, err := env.uc.CreateConsultation(ctx, req)
+	if err != nil || replayed.ConsultationNo != consultation.ConsultationNo {
+		t.Fatalf("Expected replayed consultation %s, got %v, %v", consultation.ConsultationNo, replayed, err)
+	}
+
+	// 支付通知驱动问诊进入待接诊
+	if _, err := env.paymentUc.SimulatePayment(ctx, payment.OrderID); err != nil {
+		t.Fatalf("SimulatePayment failed: %v", err)
+	}
+	waiting, err := env.uc.GetConsultation(ctx, testDoctorID, consultation.ConsultationNo)
+	if err != nil || waiting.Status != biz.ConsultationStatusWaiting {
+		t.Fatalf("Expected waiting, got %v, %v", waiting, err)
+	}
+	if _, err := env.uc.GetConsultation(ctx, 2002, consultation.ConsultationNo); err == nil {
+		t.Errorf("Expected other user denied")
+	}
+	if _, _, err := env.uc.CreateConsultation(ctx, &biz.CreateConsultationRequest{PatientID: testPatientID, DoctorID: testDoctorID, Description: "又咳嗽了"}); err == nil {
+		t.Errorf("Expected active consultation to block a new one")
+	}
+
+	// 只有问诊的医生能接诊，接诊后发送开始通知
+	if _, err := env.uc.AcceptConsultation(ctx, 8, consultation.ConsultationNo, time.Hour); err == nil {
+		t.Errorf("Expected other doctor denied")
+	}
+	started, err := env.uc.AcceptConsultation(ctx, testDoctorID, consultation.ConsultationNo, time.Hour)
+	if err != nil {
+		t.Fatalf("AcceptConsultation failed: %v", err)
+	}
+	if started.Status != biz.ConsultationStatusInProgress || started.EndsAt.Sub(started.StartedAt) != time.Hour {
+		t.Fatalf("Unexpected started consultation %+v", started)
+	}
+	if notice := env.lastNotice(t); notice.Event != biz.ChatSystemEventConsultationStarted {
+		t.Errorf("Expected started notice, got %+v", notice)
+	}
+
+	// 结束问诊须填写诊断小结
+	if _, err := env.uc.CloseConsultation(ctx, testDoctorID, consultation.ConsultationNo, "", ""); err == nil {
+		t.Errorf("Expected diagnosis required")
+	}
+	closed, err := env.uc.CloseConsultation(ctx, testDoctorID, consultation.ConsultationNo, "急性支气管炎", "多饮水，按时服药")
+	if err != nil {
+		t.Fatalf("CloseConsultation failed: %v", err)
+	}
+	if closed.Status != biz.ConsultationStatusClosed || closed.Diagnosis != "急性支气管炎" || closed.ClosedAt.IsZero() {
+		t.Fatalf("Unexpected closed consultation %+v", closed)
+	}
+	if notice := env.lastNotice(t); notice.Event != biz.ChatSystemEventConsultationEnded || !strings.Contains(notice.Text, "急性支气管炎") {
+		t.Errorf("Expected ended notice with diagnosis, got %+v", notice)
+	}
+
+	list, total, err := env.uc.ListConsultations(ctx, testDoctorID, biz.ConsultationRoleDoctor, "", 1, 10)
+	if err != nil || total != 1 || list[0].ConsultationNo != consultation.ConsultationNo {
+		t.Errorf("Unexpected doctor list %v, %d, %v", list, total, err)
+	}
+
+	// 结束后患者评分一次，医生评分和问诊量随之更新
+	if _, err := env.uc.RateConsultation(ctx, testDoctorID, consultation.ConsultationNo, 5, ""); err == nil {
+		t.Errorf("Expected doctor rating denied")
+	}
+	rated, err := env.uc.RateConsultation(ctx, testPatientID, consultation.ConsultationNo, 4, "回复很及时")
+	if err != nil || rated.Rating != 4 || rated.RatedAt.IsZero() {
+		t.Fatalf("Expected rated 4, got %v, %v", rated, err)
+	}
+	if _, err := env.uc.RateConsultation(ctx, testPatientID, consultation.ConsultationNo, 5, ""); err == nil {
+		t.Errorf("Expected repeated rating rejected")
+	}
+	var doctor biz.MtDoctors
+	env.d.Db.First(&doctor, testDoctorID)
+	if doctor.Rating.StringFixed(2) != "4.00" || doctor.RatingCount != 1 || doctor.ConsultationCount != 1 {
+		t.Errorf("Unexpected doctor rating %s, %d, %d", doctor.Rating, doctor.RatingCount, doctor.ConsultationCount)
+	}
+}
+
+// 测试超时未接诊自动退款、会话到期自动结束
+func TestExpireConsultations(t *testing.T) {
+	env := newConsultationTestEnv(t)
+	ctx := context.Background()
+
+	unanswered := env.paidConsultation(t, "发烧")
+	if err := env.d.Db.Model(&MtConsultation{}).Where("consultation_no = ?", unanswered.ConsultationNo).
+		Update("paid_at", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("调整支付时间失败: %v", err)
+	}
+	processed, err := env.uc.ExpireConsultations(ctx, 30*time.Minute, 10)
+	if err != nil || processed != 1 {
+		t.Fatalf("Expected 1 processed, got %d, %v", processed, err)
+	}
+	refunded, _ := env.uc.GetConsultation(ctx, testPatientID, unanswered.ConsultationNo)
+	if refunded.Status != biz.ConsultationStatusRefunded || refunded.RefundID == "" {
+		t.Fatalf("Expected refunded, got %+v", refunded)
+	}
+	if notice := env.lastNotice(t); notice.Event != biz.ChatSystemEventConsultationEnded {
+		t.Errorf("Expected ended notice, got %+v", notice)
+	}
+
+	// 退款任务把整单退款提交渠道，支付单流转为已退款
+	if n, err := env.refundUc.ProcessApprovedRefunds(ctx, 10); err != nil || n != 1 {
+		t.Fatalf("Expected 1 refund processed, got %d, %v", n, err)
+	}
+	if status := refundStatus(t, env.d, refunded.RefundID); status != biz.RefundStatusSucceeded {
+		t.Errorf("Expected refund succeeded, got %s", status)
+	}
+	payment, _ := env.paymentUc.GetPaymentOrder(ctx, refunded.PaymentOrderID)
+	if payment.Status != biz.PaymentStatusRefunded {
+		t.Errorf("Expected payment refunded, got %s", payment.Status)
+	}
+
+	// 会话到期自动结束，未到期的不受影响
+	overdue := env.paidConsultation(t, "头痛")
+	if _, err := env.uc.AcceptConsultation(ctx, testDoctorID, overdue.ConsultationNo, -time.Minute); err != nil {
+		t.Fatalf("AcceptConsultation failed: %v", err)
+	}
+	if processed, err := env.uc.ExpireConsultations(ctx, 30*time.Minute, 10); err != nil || processed != 1 {
+		t.Fatalf("Expected 1 processed, got %d, %v", processed, err)
+	}
+	closed, _ := env.uc.GetConsultation(ctx, testPatientID, overdue.ConsultationNo)
+	if closed.Status != biz.ConsultationStatusClosed || closed.Diagnosis != "" {
+		t.Errorf("Expected auto closed, got %+v", closed)
+	}
+
+	// 待支付时取消，取消后才完成支付的原路退回
+	pending, payment, err := env.uc.CreateConsultation(ctx, &biz.CreateConsultationRequest{PatientID: testPatientID, DoctorID: testDoctorID, Description: "咽痛"})
+	if err != nil {
+		t.Fatalf("CreateConsultation failed: %v", err)
+	}
+	if _, err := env.uc.CancelConsultation(ctx, testPatientID, pending.ConsultationNo); err != nil {
+		t.Fatalf("CancelConsultation failed: %v", err)
+	}
+	if _, err := env.paymentUc.SimulatePayment(ctx, payment.OrderID); err != nil {
+		t.Fatalf("SimulatePayment failed: %v", err)
+	}
+	cancelled, _ := env.uc.GetConsultation(ctx, testPatientID, pending.ConsultationNo)
+	if cancelled.Status != biz.ConsultationStatusRefunded || refundStatus(t, env.d, cancelled.RefundID) != biz.RefundStatusApproved {
+		t.Errorf("Expected paid-after-cancel consultation refunded, got %+v", cancelled)
+	}
+}
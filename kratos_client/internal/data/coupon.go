@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -22,6 +23,7 @@ type MtDiscount struct {
 	EndTime        time.Time       `gorm:"column:end_time" json:"end_time"`
 	MaxIssue       int32           `gorm:"column:max_issue" json:"max_issue"`
 	MaxPerUser     int32           `gorm:"column:max_per_user;default:1" json:"max_per_user"`
+	UsedCount      int32           `gorm:"column:used_count;default:0;not null" json:"used_count"`
 }
 
 func (MtDiscount) TableName() string {
@@ -48,6 +50,7 @@ type MtDiscountUser struct {
 	ID         int32 `gorm:"primaryKey;autoIncrement" json:"id"`
 	DiscountID int32 `gorm:"column:discount_id;default:0;not null" json:"discount_id"`
 	UserID     int32 `gorm:"column:user_id;default:0;not null" json:"user_id"`
+	Status     int32 `gorm:"column:status;default:0;not null" json:"status"`
 }
 
 func (MtDiscountUser) TableName() string {
@@ -63,6 +66,8 @@ type MtOrderCoupon struct {
 	UserID         int32           `gorm:"column:user_id;not null" json:"user_id"`
 	DiscountAmount decimal.Decimal `gorm:"column:discount_amount;type:decimal(10,2);not null" json:"discount_amount"`
 	UseTime        time.Time       `gorm:"column:use_time;autoCreateTime" json:"use_time"`
+	Status         int32           `gorm:"column:status;default:1;not null" json:"status"`
+	ReturnTime     *time.Time      `gorm:"column:return_time" json:"return_time"`
 }
 
 func (MtOrderCoupon) TableName() string {
@@ -83,6 +88,22 @@ func NewCouponRepo(data *Data, logger log.Logger) biz.CouponRepo {
 	}
 }
 
+// 获取数据库连接（支持事务）
+func (r *couponRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
+}
+
+// 在事务中执行，已处于事务中时直接复用
+func (r *couponRepo) inTx(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return fn(tx)
+	}
+	return r.data.Db.WithContext(ctx).Transaction(fn)
+}
+
 // 转换数据模型到业务模型
 func (r *couponRepo) toBizDiscount(do *MtDiscount) *biz.MtDiscount {
 	return &biz.MtDiscount{
@@ -96,6 +117,7 @@ func (r *couponRepo) toBizDiscount(do *MtDiscount) *biz.MtDiscount {
 		EndTime:        do.EndTime,
 		MaxIssue:       do.MaxIssue,
 		MaxPerUser:     do.MaxPerUser,
+		UsedCount:      do.UsedCount,
 	}
 }
 
@@ -118,6 +140,7 @@ func (r *couponRepo) toBizDiscountUser(do *MtDiscountUser) *biz.MtDiscountUser {
 		ID:         do.ID,
 		DiscountID: do.DiscountID,
 		UserID:     do.UserID,
+		Status:     do.Status,
 	}
 }
 
@@ -219,11 +242,7 @@ func (r *couponRepo) ListUserCoupons(ctx context.Context, userID int32, page, pa
 		}
 
 		// 检查是否已使用
-		isUsed, err := r.CheckCouponUsed(ctx, discountUser.ID)
-		if err != nil {
-			r.log.Errorf("检查优惠券使用状态失败: %v", err)
-			isUsed = false
-		}
+		isUsed := discountUser.Status == biz.UserCouponUsed
 
 		// 检查是否已过期
 		isExpired := time.Now().After(coupon.EndTime)
@@ -257,7 +276,7 @@ func (r *couponRepo) ClaimCoupon(ctx context.Context, couponID int32, userID int
 // 获取用户优惠券
 func (r *couponRepo) GetUserCoupon(ctx context.Context, discountUserID int32) (*biz.MtDiscountUser, error) {
 	var discountUser MtDiscountUser
-	if err := r.data.Db.WithContext(ctx).Where("id = ?", discountUserID).First(&discountUser).Error; err != nil {
+	if err := r.getDB(ctx).Where("id = ?", discountUserID).First(&discountUser).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -269,35 +288,118 @@ func (r *couponRepo) GetUserCoupon(ctx context.Context, discountUserID int32) (*
 }
 
 // 使用优惠券
+// 以未使用状态为条件更新用户优惠券，同一张券并发核销时只有一个订单能成功
 func (r *couponRepo) UseCoupon(ctx context.Context, orderNo string, discountUserID int32, discountAmount decimal.Decimal) error {
-	// 获取用户优惠券关联信息
-	discountUser, err := r.GetUserCoupon(ctx, discountUserID)
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		result := db.Model(&MtDiscountUser{}).
+			Where("id = ? AND status = ?", discountUserID, biz.UserCouponUnused).
+			Update("status", biz.UserCouponUsed)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: discountUserID=%d", biz.ErrCouponAlreadyUsed, discountUserID)
+		}
+
+		var discountUser MtDiscountUser
+		if err := db.Where("id = ?", discountUserID).First(&discountUser).Error; err != nil {
+			return err
+		}
+
+		// 创建使用记录
+		orderCoupon := &MtOrderCoupon{
+			OrderNo:        orderNo,
+			DiscountUserID: discountUserID,
+			DiscountID:     discountUser.DiscountID,
+			UserID:         discountUser.UserID,
+			DiscountAmount: discountAmount,
+			UseTime:        time.Now(),
+			Status:         biz.OrderCouponUsed,
+		}
+		if err := db.Create(orderCoupon).Error; err != nil {
+			return err
+		}
+
+		return db.Model(&MtDiscount{}).
+			Where("id = ?", discountUser.DiscountID).
+			UpdateColumn("used_count", gorm.Expr("used_count + 1")).Error
+	})
 	if err != nil {
+		r.log.Errorf("核销优惠券失败: orderNo=%s, discountUserID=%d, error=%v", orderNo, discountUserID, err)
 		return err
 	}
 
-	// 创建使用记录
-	orderCoupon := &MtOrderCoupon{
-		OrderNo:        orderNo,
-		DiscountUserID: discountUserID,
-		DiscountID:     discountUser.DiscountID,
-		UserID:         discountUser.UserID,
-		DiscountAmount: discountAmount,
-		UseTime:        time.Now(),
-	}
+	return nil
+}
 
-	if err := r.data.Db.WithContext(ctx).Create(orderCoupon).Error; err != nil {
-		r.log.Errorf("创建优惠券使用记录失败: %v", err)
-		return err
+// 退回订单使用的优惠券
+func (r *couponRepo) ReturnCoupon(ctx context.Context, orderNo string) (*biz.MtOrderCoupon, error) {
+	var returned *biz.MtOrderCoupon
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		var orderCoupon MtOrderCoupon
+		result := db.Where("order_no = ? AND status = ?", orderNo, biz.OrderCouponUsed).First(&orderCoupon)
+		if result.Error != nil {
+			if result.Error == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return result.Error
+		}
+
+		// 以已使用状态为条件更新，重复退回时不会重复扣减
+		now := time.Now()
+		update := db.Model(&MtOrderCoupon{}).
+			Where("id = ? AND status = ?", orderCoupon.ID, biz.OrderCouponUsed).
+			Updates(map[string]interface{}{"status": biz.OrderCouponReturned, "return_time": now})
+		if update.Error != nil {
+			return update.Error
+		}
+		if update.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := db.Model(&MtDiscountUser{}).
+			Where("id = ? AND status = ?", orderCoupon.DiscountUserID, biz.UserCouponUsed).
+			Update("status", biz.UserCouponUnused).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&MtDiscount{}).
+			Where("id = ? AND used_count > 0", orderCoupon.DiscountID).
+			UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+			return err
+		}
+
+		orderCoupon.Status = biz.OrderCouponReturned
+		orderCoupon.ReturnTime = &now
+		returned = r.toBizOrderCoupon(&orderCoupon)
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("退回优惠券失败: orderNo=%s, error=%v", orderNo, err)
+		return nil, err
 	}
 
-	return nil
+	return returned, nil
+}
+
+// 转换订单优惠券使用记录
+func (r *couponRepo) toBizOrderCoupon(do *MtOrderCoupon) *biz.MtOrderCoupon {
+	return &biz.MtOrderCoupon{
+		ID:             do.ID,
+		OrderNo:        do.OrderNo,
+		DiscountUserID: do.DiscountUserID,
+		DiscountID:     do.DiscountID,
+		UserID:         do.UserID,
+		DiscountAmount: do.DiscountAmount,
+		UseTime:        do.UseTime,
+		Status:         do.Status,
+		ReturnTime:     do.ReturnTime,
+	}
 }
 
 // 检查优惠券是否已使用
 func (r *couponRepo) CheckCouponUsed(ctx context.Context, discountUserID int32) (bool, error) {
 	var count int64
-	if err := r.data.Db.WithContext(ctx).Model(&MtOrderCoupon{}).Where("discount_user_id = ?", discountUserID).Count(&count).Error; err != nil {
+	if err := r.data.Db.WithContext(ctx).Model(&MtDiscountUser{}).Where("id = ? AND status = ?", discountUserID, biz.UserCouponUsed).Count(&count).Error; err != nil {
 		r.log.Errorf("检查优惠券使用状态失败: %v", err)
 		return false, err
 	}
@@ -308,7 +410,7 @@ func (r *couponRepo) CheckCouponUsed(ctx context.Context, discountUserID int32)
 // 获取优惠券统计
 func (r *couponRepo) GetCouponStats(ctx context.Context, couponID int32) (issued int32, used int32, err error) {
 	var issuedCount int64
-	var usedCount int64
+	var coupon MtDiscount
 
 	// 统计已发放数量
 	if err := r.data.Db.WithContext(ctx).Model(&MtDiscountUser{}).Where("discount_id = ?", couponID).Count(&issuedCount).Error; err != nil {
@@ -316,13 +418,13 @@ func (r *couponRepo) GetCouponStats(ctx context.Context, couponID int32) (issued
 		return 0, 0, err
 	}
 
-	// 统计已使用数量
-	if err := r.data.Db.WithContext(ctx).Model(&MtOrderCoupon{}).Where("discount_id = ?", couponID).Count(&usedCount).Error; err != nil {
+	// 已使用数量随核销/退回同步维护
+	if err := r.data.Db.WithContext(ctx).Select("id", "used_count").Where("id = ?", couponID).First(&coupon).Error; err != nil && err != gorm.ErrRecordNotFound {
 		r.log.Errorf("统计优惠券使用数量失败: %v", err)
 		return 0, 0, err
 	}
 
-	return int32(issuedCount), int32(usedCount), nil
+	return int32(issuedCount), coupon.UsedCount, nil
 }
 
 // 检查用户优惠券领取限制
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"kratos_client/internal/biz"
+)
+
+// 创建满20减5的优惠券并发放给测试用户，返回用户优惠券ID
+func seedUserCoupon(t *testing.T, d *Data) int64 {
+	t.Helper()
+	coupon := &MtDiscount{
+		DiscountName:   "满20减5",
+		Classify:       "platform",
+		DiscountAmount: decimal.NewFromInt(5),
+		MinOrderAmount: decimal.NewFromInt(20),
+		StartTime:      time.Now().Add(-time.Hour),
+		EndTime:        time.Now().Add(time.Hour),
+		MaxPerUser:     1,
+	}
+	if err := d.Db.Create(coupon).Error; err != nil {
+		t.Fatalf("创建测试优惠券失败: %v", err)
+	}
+	discountUser := &MtDiscountUser{DiscountID: coupon.ID, UserID: 1001}
+	if err := d.Db.Create(discountUser).Error; err != nil {
+		t.Fatalf("发放测试优惠券失败: %v", err)
+	}
+	return int64(discountUser.ID)
+}
+
+func createCouponOrder(uc *biz.OrderUsecase, userCouponID int64, quantity int32) (*biz.MtOrder, error) {
+	return uc.CreateOrder(context.Background(), &biz.CreateOrderRequest{
+		UserID:        1001,
+		UserName:      "测试用户",
+		UserPhone:     "13800138000",
+		AddressID:     1,
+		AddressDetail: "测试地址",
+		Items:         []*biz.CreateOrderItem{{DrugID: 1, Quantity: quantity}},
+		UserCouponID:  &userCouponID,
+	})
+}
+
+// 校验用户优惠券状态和优惠券已使用数量
+func assertCouponUsage(t *testing.T, d *Data, userCouponID int64, wantStatus, wantUsed int32) {
+	t.Helper()
+	var discountUser MtDiscountUser
+	if err := d.Db.First(&discountUser, userCouponID).Error; err != nil {
+		t.Fatalf("查询用户优惠券失败: %v", err)
+	}
+	var coupon MtDiscount
+	if err := d.Db.First(&coupon, discountUser.DiscountID).Error; err != nil {
+		t.Fatalf("查询优惠券失败: %v", err)
+	}
+	if discountUser.Status != wantStatus || coupon.UsedCount != wantUsed {
+		t.Errorf("Expected coupon status %d used_count %d, got status %d used_count %d",
+			wantStatus, wantUsed, discountUser.Status, coupon.UsedCount)
+	}
+}
+
+// 测试下单核销优惠券，取消和超时关闭后退回
+func TestCreateOrderWithCoupon(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+	ctx := context.Background()
+	userCouponID := seedUserCoupon(t, d)
+
+	// 2 x 12.5 = 25，满20减5
+	order, err := createCouponOrder(uc, userCouponID, 2)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if !order.OriginalAmount.Equal(decimal.NewFromInt(25)) || !order.DiscountAmount.Equal(decimal.NewFromInt(5)) ||
+		!order.TotalAmount.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("Unexpected amounts: original=%s discount=%s total=%s", order.OriginalAmount, order.DiscountAmount, order.TotalAmount)
+	}
+	assertCouponUsage(t, d, userCouponID, biz.UserCouponUsed, 1)
+
+	// 已使用的券不能再次下单
+	if _, err := createCouponOrder(uc, userCouponID, 2); !errors.Is(err, biz.ErrCouponAlreadyUsed) {
+		t.Fatalf("Expected ErrCouponAlreadyUsed, got %v", err)
+	}
+
+	// 取消后退回；未传操作人按系统处理
+	if err := uc.CancelOrder(ctx, order.OrderNo, nil, "测试取消"); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	assertCouponUsage(t, d, userCouponID, biz.UserCouponUnused, 0)
+	var record MtOrderCoupon
+	if err := d.Db.Where("order_no = ?", order.OrderNo).First(&record).Error; err != nil {
+		t.Fatalf("查询核销记录失败: %v", err)
+	}
+	if record.Status != biz.OrderCouponReturned || record.ReturnTime == nil {
+		t.Errorf("Expected returned record, got %+v", record)
+	}
+
+	// 退回后可再次使用，超时关闭同样退回
+	order, err = createCouponOrder(uc, userCouponID, 2)
+	if err != nil {
+		t.Fatalf("CreateOrder with returned coupon failed: %v", err)
+	}
+	assertCouponUsage(t, d, userCouponID, biz.UserCouponUsed, 1)
+	ageOrder(t, d, order.OrderNo, time.Hour)
+	if _, err := uc.ExpirePendingOrders(ctx, 30*time.Minute, 10); err != nil {
+		t.Fatalf("ExpirePendingOrders failed: %v", err)
+	}
+	assertCouponUsage(t, d, userCouponID, biz.UserCouponUnused, 0)
+
+	// 未达门槛不可用
+	if _, err := createCouponOrder(uc, userCouponID, 1); err == nil {
+		t.Error("Expected min order amount error")
+	}
+}
+
+// 测试同一张券并发下单只有一单核销成功，失败订单整体回滚
+func TestConcurrentCouponRedemption(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+	userCouponID := seedUserCoupon(t, d)
+
+	const workers = 5
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := createCouponOrder(uc, userCouponID, 2); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			} else if !errors.Is(err, biz.ErrCouponAlreadyUsed) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("Expected exactly one redemption, got %d", succeeded)
+	}
+	assertCouponUsage(t, d, userCouponID, biz.UserCouponUsed, 1)
+
+	var orders, records int64
+	d.Db.Model(&MtOrder{}).Count(&orders)
+	d.Db.Model(&MtOrderCoupon{}).Count(&records)
+	if orders != 1 || records != 1 {
+		t.Errorf("Expected 1 order and 1 coupon record, got %d orders %d records", orders, records)
+	}
+	if got := drugInventory(t, d); got != 98 {
+		t.Errorf("Expected inventory 98, got %d", got)
+	}
+}
@@ -19,7 +19,7 @@ import (
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewDb, NewUserRepo, NewCityRepo, NewDoctorsRepo, NewDrugRepo, NewEstimateRepo, NewCartRepo, NewOrderRepo, NewDrugInventoryRepo, NewCouponRepo, NewPaymentRepo, NewPrescriptionRepo)
+var ProviderSet = wire.NewSet(NewData, NewDb, NewUserRepo, NewCityRepo, NewDoctorsRepo, NewDrugRepo, NewDrugSearchRepo, NewDrugIndexRepo, NewHotSearchRepo, NewSymptomRepo, NewEstimateRepo, NewCartRepo, NewOrderRepo, NewDrugInventoryRepo, NewInventoryAlertRepo, NewInventoryAlertSink, NewLeaseRepo, NewIdempotencyRepo, NewLoginAttemptRepo, NewCouponRepo, NewPaymentRepo, NewRefundRepo, NewPaymentGateways, NewStatementParser, NewPrescriptionRepo, NewPharmacistRepo, NewInteractionRepo, NewChatRepo, NewChatBroker, NewChatMediaChecker, NewChatPusher, NewConsultationRepo, NewScheduleRepo)
 
 // Data .
 type Data struct {
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 创建基于SQLite临时库的测试数据源，并迁移给定的表
+func newTestData(t *testing.T, models ...interface{}) *Data {
+	t.Helper()
+
+	// immediate事务在BEGIN时即获取写锁，模拟MySQL行锁下的串行写入
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=5000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开测试数据库失败: %v", err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("迁移测试表失败: %v", err)
+	}
+	// SQLite驱动只把声明为datetime的列解析成时间，且只有integer主键才能自增，
+	// 迁移后按MySQL语义修正这两处声明并重建空表
+	var tables []struct {
+		Name string
+		SQL  string
+	}
+	if err := db.Raw("SELECT name, sql FROM sqlite_master WHERE type = 'table' AND (sql LIKE '%datetime(3)%' OR sql LIKE '%`id` bigint,%')").Scan(&tables).Error; err != nil {
+		t.Fatalf("读取测试表结构失败: %v", err)
+	}
+	for _, table := range tables {
+		// 删表会一并删除索引，先记下建索引语句
+		var indexes []string
+		if err := db.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table.Name).Scan(&indexes).Error; err != nil {
+			t.Fatalf("读取测试表索引失败: %v", err)
+		}
+		if err := db.Exec("DROP TABLE `" + table.Name + "`").Error; err != nil {
+			t.Fatalf("删除测试表失败: %v", err)
+		}
+		ddl := strings.ReplaceAll(table.SQL, "datetime(3)", "datetime")
+		if strings.Contains(ddl, "`id` bigint,") {
+			ddl = strings.Replace(ddl, "`id` bigint,", "`id` integer PRIMARY KEY AUTOINCREMENT,", 1)
+			ddl = strings.Replace(ddl, ",PRIMARY KEY (`id`)", "", 1)
+		}
+		if err := db.Exec(ddl).Error; err != nil {
+			t.Fatalf("重建测试表失败: %v", err)
+		}
+		for _, index := range indexes {
+			if err := db.Exec(index).Error; err != nil {
+				t.Fatalf("重建测试表索引失败: %v", err)
+			}
+		}
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return &Data{Db: db}
+}
+
+// 测试用日志，丢弃所有输出
+func newTestLogger() log.Logger {
+	return log.NewStdLogger(io.Discard)
+}
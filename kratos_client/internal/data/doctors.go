@@ -2,8 +2,11 @@ package data
 
 import (
 	"context"
+	"errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
 	"kratos_client/internal/biz"
+	"time"
 )
 
 type doctorsRepo struct {
@@ -34,3 +37,292 @@ func (c *doctorsRepo) FindByID(ctx context.Context, id int32) (*biz.MtDoctors, e
 	}
 	return &doctor, nil
 }
+
+// 医院 - 对应 mt_hospitals 表，由管理端维护，这里只读取展示用的字段
+type MtHospital struct {
+	ID        int32          `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"column:name;size:100" json:"name"`
+	Level     string         `gorm:"column:level;size:20" json:"level"`
+	Address   string         `gorm:"column:address;size:200" json:"address"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
+}
+
+// 表名
+func (MtHospital) TableName() string {
+	return "mt_hospitals"
+}
+
+// 科室 - 对应 mt_departments 表，由管理端维护
+type MtDepartment struct {
+	ID         int32          `gorm:"primaryKey" json:"id"`
+	HospitalID int32          `gorm:"column:hospital_id" json:"hospital_id"`
+	Name       string         `gorm:"column:name;size:100" json:"name"`
+	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
+}
+
+// 表名
+func (MtDepartment) TableName() string {
+	return "mt_departments"
+}
+
+// 医生资质审核记录 - 对应 mt_doctor_approval 表，医生提交资料时写入待审核记录，由管理端审核
+type MtDoctorApproval struct {
+	ID             int32          `gorm:"primaryKey" json:"id"`
+	DoctorID       int32          `gorm:"column:doctor_id;index:idx_doctor_approval_doctor" json:"doctor_id"`
+	DoctorName     string         `gorm:"column:doctor_name;size:50" json:"doctor_name"`
+	ApprovalStatus string         `gorm:"column:approval_status;size:20;index:idx_doctor_approval_doctor" json:"approval_status"`
+	SubmitTime     *time.Time     `gorm:"column:submit_time" json:"submit_time"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
+}
+
+// 表名
+func (MtDoctorApproval) TableName() string {
+	return "mt_doctor_approval"
+}
+
+// 医生的患者档案 - 对应 mt_doctor_patients 表
+type MtDoctorPatient struct {
+	ID               int32          `gorm:"primaryKey" json:"id"`
+	DoctorID         int32          `gorm:"column:doctor_id;index" json:"doctor_id"`
+	PatientID        int32          `gorm:"column:patient_id" json:"patient_id"`
+	RelationshipType string         `gorm:"column:relationship_type;size:20" json:"relationship_type"`
+	Tags             string         `gorm:"column:tags;size:200" json:"tags"`
+	Notes            string         `gorm:"column:notes" json:"notes"`
+	FirstVisitTime   *time.Time     `gorm:"column:first_visit_time" json:"first_visit_time"`
+	LastVisitTime    *time.Time     `gorm:"column:last_visit_time" json:"last_visit_time"`
+	VisitCount       int32          `gorm:"column:visit_count" json:"visit_count"`
+	DeletedAt        gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
+}
+
+// 表名
+func (MtDoctorPatient) TableName() string {
+	return "mt_doctor_patients"
+}
+
+const doctorStatusEnabled = "1"
+
+// 医生列表行，关联医院和科室名称
+type doctorProfileRow struct {
+	biz.MtDoctors
+	HospitalName    string
+	HospitalLevel   string
+	HospitalAddress string
+	DepartmentName  string
+}
+
+func toBizDoctorProfile(row *doctorProfileRow) *biz.DoctorProfile {
+	return &biz.DoctorProfile{
+		ID:                int32(row.Id),
+		DoctorCode:        row.DoctorCode,
+		Name:              row.Name,
+		Gender:            row.Gender,
+		Avatar:            row.Avatar,
+		Title:             row.Title,
+		Speciality:        row.Speciality,
+		PracticeScope:     row.PracticeScope,
+		HospitalID:        row.HospitalId,
+		HospitalName:      row.HospitalName,
+		HospitalLevel:     row.HospitalLevel,
+		HospitalAddress:   row.HospitalAddress,
+		DepartmentID:      row.DepartmentId,
+		DepartmentName:    row.DepartmentName,
+		ConsultationFee:   row.ConsultationFee,
+		Rating:            row.Rating,
+		RatingCount:       row.RatingCount,
+		ConsultationCount: row.ConsultationCount,
+	}
+}
+
+// 启用、未删除且最近一次资质审核通过的医生，关联医院和科室
+func (c *doctorsRepo) approvedDoctors(ctx context.Context) *gorm.DB {
+	return c.data.Db.WithContext(ctx).Table("mt_doctors AS d").
+		Select("d.*, h.name AS hospital_name, h.level AS hospital_level, h.address AS hospital_address, dept.name AS department_name").
+		Joins("LEFT JOIN mt_hospitals AS h ON h.id = d.hospital_id AND h.deleted_at IS NULL").
+		Joins("LEFT JOIN mt_departments AS dept ON dept.id = d.department_id AND dept.deleted_at IS NULL").
+		Where("d.status = ? AND d.deleted_at IS NULL", doctorStatusEnabled).
+		// 以最近一次审核为准，资料修改后重新提交的待审核记录会让医生暂不展示
+		Where(`EXISTS (SELECT 1 FROM mt_doctor_approval AS a WHERE a.doctor_id = d.id AND a.approval_status = ? AND a.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM mt_doctor_approval AS b WHERE b.doctor_id = a.doctor_id AND b.id > a.id AND b.deleted_at IS NULL))`,
+			biz.DoctorApprovalApproved)
+}
+
+// 分页查询启用且审核通过的医生
+func (c *doctorsRepo) ListApprovedDoctors(ctx context.Context, filter *biz.DoctorFilter) ([]*biz.DoctorProfile, int64, error) {
+	query := c.approvedDoctors(ctx)
+	if filter.HospitalID > 0 {
+		query = query.Where("d.hospital_id = ?", filter.HospitalID)
+	}
+	if filter.DepartmentID > 0 {
+		query = query.Where("d.department_id = ?", filter.DepartmentID)
+	}
+	if filter.Title != "" {
+		query = query.Where("d.title = ?", filter.Title)
+	}
+	if filter.Speciality != "" {
+		query = query.Where("d.speciality LIKE ?", "%"+filter.Speciality+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.log.Errorf("统计医生数量失败: %v", err)
+		return nil, 0, err
+	}
+
+	switch filter.SortBy {
+	case biz.DoctorSortRating:
+		query = query.Order("d.rating DESC, d.rating_count DESC, d.id ASC")
+	case biz.DoctorSortConsultations:
+		query = query.Order("d.consultation_count DESC, d.id ASC")
+	default:
+		query = query.Order("d.id ASC")
+	}
+
+	var rows []doctorProfileRow
+	offset := int((filter.Page - 1) * filter.PageSize)
+	if err := query.Offset(offset).Limit(int(filter.PageSize)).Scan(&rows).Error; err != nil {
+		c.log.Errorf("查询医生列表失败: %v", err)
+		return nil, 0, err
+	}
+	result := make([]*biz.DoctorProfile, len(rows))
+	for i := range rows {
+		result[i] = toBizDoctorProfile(&rows[i])
+	}
+	return result, total, nil
+}
+
+// 查询启用且审核通过的医生，不存在时返回nil
+func (c *doctorsRepo) GetApprovedDoctor(ctx context.Context, id int32) (*biz.DoctorProfile, error) {
+	var rows []doctorProfileRow
+	if err := c.approvedDoctors(ctx).Where("d.id = ?", id).Limit(1).Scan(&rows).Error; err != nil {
+		c.log.Errorf("查询医生详情失败: %v", err)
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return toBizDoctorProfile(&rows[0]), nil
+}
+
+// 累加医生已完成的问诊量
+func (c *doctorsRepo) IncrConsultationCount(ctx context.Context, id int32) error {
+	return c.data.Db.WithContext(ctx).Model(&biz.MtDoctors{}).Where("id = ?", id).
+		UpdateColumn("consultation_count", gorm.Expr("consultation_count + 1")).Error
+}
+
+// 按手机号或医生编码查询医生，不存在时返回nil
+func (c *doctorsRepo) FindByAccount(ctx context.Context, account string) (*biz.MtDoctors, error) {
+	var doctor biz.MtDoctors
+	err := c.data.Db.WithContext(ctx).Where("(phone = ? OR doctor_code = ?) AND deleted_at IS NULL", account, account).
+		First(&doctor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &doctor, nil
+}
+
+// 记录最后登录时间和IP
+func (c *doctorsRepo) UpdateLastLogin(ctx context.Context, id int32, at time.Time, ip string) error {
+	return c.data.Db.WithContext(ctx).Model(&biz.MtDoctors{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"last_login_time": at, "last_login_ip": ip}).Error
+}
+
+// 更新登录密码哈希，同时清空盐值
+func (c *doctorsRepo) UpdatePasswordHash(ctx context.Context, id int32, hash string) error {
+	return c.data.Db.WithContext(ctx).Model(&biz.MtDoctors{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"password_hash": hash, "salt": ""}).Error
+}
+
+// 分页查询医生的患者档案，关联患者昵称和头像
+func (c *doctorsRepo) ListDoctorPatients(ctx context.Context, doctorID int32, page, pageSize int32) ([]*biz.DoctorPatient, int64, error) {
+	query := c.data.Db.WithContext(ctx).Table("mt_doctor_patients AS p").
+		Where("p.doctor_id = ? AND p.deleted_at IS NULL", doctorID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		MtDoctorPatient
+		NickName string
+		Avatar   string
+	}
+	err := query.Select("p.*, u.nick_name, u.avatar").
+		Joins("LEFT JOIN mt_user AS u ON u.id = p.patient_id").
+		Order("p.last_visit_time DESC, p.id DESC").
+		Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]*biz.DoctorPatient, len(rows))
+	for i, row := range rows {
+		result[i] = &biz.DoctorPatient{
+			PatientID:        row.PatientID,
+			NickName:         row.NickName,
+			Avatar:           row.Avatar,
+			RelationshipType: row.RelationshipType,
+			Tags:             row.Tags,
+			Notes:            row.Notes,
+			VisitCount:       row.VisitCount,
+		}
+		if row.FirstVisitTime != nil {
+			result[i].FirstVisitTime = *row.FirstVisitTime
+		}
+		if row.LastVisitTime != nil {
+			result[i].LastVisitTime = *row.LastVisitTime
+		}
+	}
+	return result, total, nil
+}
+
+// 医生最近一次资质审核的状态，没有审核记录时返回空
+func (c *doctorsRepo) LatestApprovalStatus(ctx context.Context, doctorID int32) (string, error) {
+	var approvals []MtDoctorApproval
+	err := c.data.Db.WithContext(ctx).Where("doctor_id = ?", doctorID).Order("id DESC").Limit(1).Find(&approvals).Error
+	if err != nil || len(approvals) == 0 {
+		return "", err
+	}
+	return approvals[0].ApprovalStatus, nil
+}
+
+// 保存医生资料并提交资质审核，已有待审核记录时只更新提交时间，避免审核队列中重复
+func (c *doctorsRepo) SubmitProfileForReview(ctx context.Context, doctor *biz.MtDoctors, update *biz.DoctorProfileUpdate, submittedAt time.Time) error {
+	fields := map[string]interface{}{"updated_at": submittedAt}
+	for column, value := range map[string]string{
+		"gender":         update.Gender,
+		"avatar":         update.Avatar,
+		"email":          update.Email,
+		"title":          update.Title,
+		"speciality":     update.Speciality,
+		"practice_scope": update.PracticeScope,
+	} {
+		if value != "" {
+			fields[column] = value
+		}
+	}
+
+	return c.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&biz.MtDoctors{}).Where("id = ?", doctor.Id).UpdateColumns(fields).Error; err != nil {
+			return err
+		}
+		var latest []MtDoctorApproval
+		if err := tx.Where("doctor_id = ?", doctor.Id).Order("id DESC").Limit(1).Find(&latest).Error; err != nil {
+			return err
+		}
+		if len(latest) > 0 && latest[0].ApprovalStatus == biz.DoctorApprovalPending {
+			return tx.Model(&MtDoctorApproval{}).Where("id = ?", latest[0].ID).
+				Updates(map[string]interface{}{"doctor_name": doctor.Name, "submit_time": submittedAt}).Error
+		}
+		return tx.Create(&MtDoctorApproval{
+			DoctorID:       int32(doctor.Id),
+			DoctorName:     doctor.Name,
+			ApprovalStatus: biz.DoctorApprovalPending,
+			SubmitTime:     &submittedAt,
+		}).Error
+	})
+}
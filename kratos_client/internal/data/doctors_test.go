@@ -0,0 +1,202 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+
+	"github.com/shopspring/decimal"
+)
+
+// 测试医生列表只返回启用且审核通过的医生，并关联医院科室、按条件筛选排序
+func TestListApprovedDoctors(t *testing.T) {
+	d := newTestData(t, &biz.MtDoctors{}, &MtHospital{}, &MtDepartment{}, &MtDoctorApproval{})
+	ctx := context.Background()
+	if err := d.Db.Create(&MtHospital{ID: 1, Name: "市第一人民医院", Level: "三甲", Address: "人民路1号"}).Error; err != nil {
+		t.Fatalf("创建测试医院失败: %v", err)
+	}
+	if err := d.Db.Create(&[]MtDepartment{{ID: 1, HospitalID: 1, Name: "呼吸内科"}, {ID: 2, HospitalID: 1, Name: "儿科"}}).Error; err != nil {
+		t.Fatalf("创建测试科室失败: %v", err)
+	}
+	doctors := []biz.MtDoctors{
+		{Id: 1, Name: "张医生", Status: "1", HospitalId: 1, DepartmentId: 1, Title: "主任医师", Speciality: "慢性咳嗽、哮喘", Rating: decimal.RequireFromString("4.50"), RatingCount: 10, ConsultationCount: 30},
+		{Id: 2, Name: "李医生", Status: "1", HospitalId: 1, DepartmentId: 1, Title: "主治医师", Speciality: "肺炎", Rating: decimal.RequireFromString("4.90"), RatingCount: 5, ConsultationCount: 80},
+		{Id: 3, Name: "王医生", Status: "1", HospitalId: 1, DepartmentId: 2, Title: "主任医师", Speciality: "小儿哮喘", Rating: decimal.RequireFromString("4.70"), RatingCount: 8, ConsultationCount: 10},
+		{Id: 4, Name: "赵医生", Status: "0", HospitalId: 1, DepartmentId: 1, Title: "主任医师"},
+		{Id: 5, Name: "钱医生", Status: "1", HospitalId: 1, DepartmentId: 1, Title: "主任医师"},
+	}
+	if err := d.Db.Omit("DeletedAt").Create(&doctors).Error; err != nil {
+		t.Fatalf("创建测试医生失败: %v", err)
+	}
+	// 钱医生审核未通过
+	approvals := []MtDoctorApproval{
+		{DoctorID: 1, ApprovalStatus: "1"}, {DoctorID: 2, ApprovalStatus: "1"}, {DoctorID: 3, ApprovalStatus: "1"},
+		{DoctorID: 4, ApprovalStatus: "1"}, {DoctorID: 5, ApprovalStatus: "2"},
+	}
+	if err := d.Db.Create(&approvals).Error; err != nil {
+		t.Fatalf("创建审核记录失败: %v", err)
+	}
+	uc := newTestDoctorsUsecase(d)
+
+	cases := []struct {
+		name   string
+		filter biz.DoctorFilter
+		want   []int32
+	}{
+		{"默认按ID", biz.DoctorFilter{}, []int32{1, 2, 3}},
+		{"按评分", biz.DoctorFilter{SortBy: biz.DoctorSortRating}, []int32{2, 3, 1}},
+		{"按问诊量", biz.DoctorFilter{SortBy: biz.DoctorSortConsultations}, []int32{2, 1, 3}},
+		{"按科室", biz.DoctorFilter{DepartmentID: 1}, []int32{1, 2}},
+		{"按职称和专长", biz.DoctorFilter{Title: "主任医师", Speciality: "哮喘"}, []int32{1, 3}},
+		{"分页", biz.DoctorFilter{SortBy: biz.DoctorSortRating, Page: 2, PageSize: 2}, []int32{1}},
+	}
+	for _, c := range cases {
+		filter := c.filter
+		list, total, err := uc.ListDoctors(ctx, &filter)
+		if err != nil {
+			t.Fatalf("%s: ListDoctors failed: %v", c.name, err)
+		}
+		var got []int32
+		for _, doctor := range list {
+			got = append(got, doctor.ID)
+		}
+		if len(got) != len(c.want) || (c.filter.Page == 0 && total != int64(len(c.want))) {
+			t.Errorf("%s: expected %v, got %v (total %d)", c.name, c.want, got, total)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+				break
+			}
+		}
+	}
+	if _, _, err := uc.ListDoctors(ctx, &biz.DoctorFilter{SortBy: "price"}); err == nil {
+		t.Errorf("Expected unsupported sort rejected")
+	}
+
+	detail, err := uc.GetDoctor(ctx, 3)
+	if err != nil || detail.HospitalName != "市第一人民医院" || detail.HospitalLevel != "三甲" || detail.DepartmentName != "儿科" {
+		t.Fatalf("Unexpected doctor detail %+v, %v", detail, err)
+	}
+	if _, err := uc.GetDoctor(ctx, 5); err == nil {
+		t.Errorf("Expected unapproved doctor hidden")
+	}
+}
+
+func newTestDoctorsUsecase(d *Data) *biz.DoctorsService {
+	logger := newTestLogger()
+	return biz.NewDoctorsUsecase(NewDoctorsRepo(d, logger), biz.NewLoginThrottle(NewLoginAttemptRepo(d, logger), logger), logger)
+}
+
+// 生成bcrypt密码哈希
+func testPasswordHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := biz.DoctorPasswordHash(password)
+	if err != nil {
+		t.Fatalf("生成密码哈希失败: %v", err)
+	}
+	return hash
+}
+
+// 旧版加盐 sha256 密码哈希
+func legacyPasswordHash(password, salt string) string {
+	sum := sha256.Sum256([]byte(salt + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// 测试医生密码登录，修改资料后重新进入审核队列、审核通过前不在列表展示
+func TestDoctorLoginAndProfileReview(t *testing.T) {
+	d := newTestData(t, &biz.MtDoctors{}, &MtHospital{}, &MtDepartment{}, &MtDoctorApproval{}, &MtDoctorPatient{}, &biz.MtUser{})
+	ctx := context.Background()
+	doctor := &biz.MtDoctors{Id: 1, DoctorCode: "D001", Name: "张医生", Phone: "13800000001", Status: "1", Title: "主治医师",
+		Salt: "s1", PasswordHash: legacyPasswordHash("secret", "s1")}
+	if err := d.Db.Omit("DeletedAt").Create(doctor).Error; err != nil {
+		t.Fatalf("创建测试医生失败: %v", err)
+	}
+	if err := d.Db.Create(&MtDoctorApproval{DoctorID: 1, ApprovalStatus: biz.DoctorApprovalApproved}).Error; err != nil {
+		t.Fatalf("创建审核记录失败: %v", err)
+	}
+	uc := newTestDoctorsUsecase(d)
+
+	if _, err := uc.Login(ctx, "13800000001", "wrong", "10.0.0.1"); !errors.Is(err, biz.ErrDoctorLoginFailed) {
+		t.Errorf("Expected login failed, got %v", err)
+	}
+	if _, err := uc.Login(ctx, "D404", "secret", "10.0.0.1"); !errors.Is(err, biz.ErrDoctorLoginFailed) {
+		t.Errorf("Expected unknown account failed, got %v", err)
+	}
+	for _, account := range []string{"13800000001", "D001"} {
+		if logged, err := uc.Login(ctx, account, "secret", "10.0.0.1"); err != nil || logged.Id != 1 {
+			t.Fatalf("Login with %s failed: %v", account, err)
+		}
+	}
+	var saved biz.MtDoctors
+	d.Db.First(&saved, 1)
+	if saved.LastLoginIp != "10.0.0.1" || saved.LastLoginTime.IsZero() {
+		t.Errorf("Expected last login recorded, got %s %v", saved.LastLoginIp, saved.LastLoginTime)
+	}
+	// 旧的 sha256 哈希登录成功后改存bcrypt
+	if !strings.HasPrefix(saved.PasswordHash, "$2") || saved.Salt != "" {
+		t.Errorf("Expected password hash upgraded to bcrypt, got %s salt %q", saved.PasswordHash, saved.Salt)
+	}
+	if _, err := uc.Login(ctx, "D001", "secret", "10.0.0.1"); err != nil {
+		t.Fatalf("Login after upgrade failed: %v", err)
+	}
+
+	// 连续失败5次后锁定，正确密码也无法登录
+	for i := 0; i < 5; i++ {
+		if _, err := uc.Login(ctx, "13800000001", "wrong", "10.0.0.2"); !errors.Is(err, biz.ErrDoctorLoginFailed) {
+			t.Fatalf("Attempt %d: expected login failed, got %v", i+1, err)
+		}
+	}
+	if _, err := uc.Login(ctx, "13800000001", "secret", "10.0.0.2"); !errors.Is(err, biz.ErrLoginLocked) {
+		t.Errorf("Expected login locked, got %v", err)
+	}
+
+	// 修改资料后进入待审核，重复修改不重复排队
+	for i := 0; i < 2; i++ {
+		profile, status, err := uc.UpdateProfile(ctx, 1, &biz.DoctorProfileUpdate{Title: "副主任医师", Speciality: "哮喘"})
+		if err != nil || profile.Title != "副主任医师" || profile.Name != "张医生" || status != biz.DoctorApprovalPending {
+			t.Fatalf("Unexpected profile %+v, %s, %v", profile, status, err)
+		}
+	}
+	var pending int64
+	d.Db.Model(&MtDoctorApproval{}).Where("doctor_id = 1 AND approval_status = ?", biz.DoctorApprovalPending).Count(&pending)
+	if pending != 1 {
+		t.Errorf("Expected 1 pending approval, got %d", pending)
+	}
+	if _, err := uc.GetDoctor(ctx, 1); err == nil {
+		t.Errorf("Expected doctor hidden while pending review")
+	}
+	d.Db.Model(&MtDoctorApproval{}).Where("approval_status = ?", biz.DoctorApprovalPending).Update("approval_status", biz.DoctorApprovalApproved)
+	if detail, err := uc.GetDoctor(ctx, 1); err != nil || detail.Speciality != "哮喘" {
+		t.Errorf("Expected approved profile listed, got %+v, %v", detail, err)
+	}
+	if _, _, err := uc.UpdateProfile(ctx, 1, &biz.DoctorProfileUpdate{}); err == nil {
+		t.Errorf("Expected empty update rejected")
+	}
+
+	// 患者档案按最后就诊时间倒序，关联患者昵称
+	if err := d.Db.Create(&[]biz.MtUser{{Id: 1001, NickName: "小王"}, {Id: 1002, NickName: "小李"}}).Error; err != nil {
+		t.Fatalf("创建测试用户失败: %v", err)
+	}
+	earlier, later := time.Now().Add(-48*time.Hour), time.Now().Add(-time.Hour)
+	patients := []MtDoctorPatient{
+		{DoctorID: 1, PatientID: 1001, LastVisitTime: &earlier, VisitCount: 1},
+		{DoctorID: 1, PatientID: 1002, LastVisitTime: &later, VisitCount: 3},
+		{DoctorID: 2, PatientID: 1001, LastVisitTime: &later, VisitCount: 1},
+	}
+	if err := d.Db.Create(&patients).Error; err != nil {
+		t.Fatalf("创建患者档案失败: %v", err)
+	}
+	list, total, err := uc.ListPatients(ctx, 1, 1, 10)
+	if err != nil || total != 2 || len(list) != 2 || list[0].NickName != "小李" || list[0].VisitCount != 3 {
+		t.Errorf("Unexpected patients %+v, %d, %v", list, total, err)
+	}
+}
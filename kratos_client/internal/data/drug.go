@@ -7,14 +7,16 @@ import (
 )
 
 type drugRepo struct {
-	data *Data
-	log  *log.Helper
+	data  *Data
+	names *drugNameDictionary
+	log   *log.Helper
 }
 
 func NewDrugRepo(data *Data, logger log.Logger) biz.DrugRepo {
 	return &drugRepo{
-		data: data,
-		log:  log.NewHelper(logger),
+		data:  data,
+		names: newDrugNameDictionary(data),
+		log:   log.NewHelper(logger),
 	}
 }
 
@@ -23,7 +25,12 @@ func (r *drugRepo) ListDrug(ctx context.Context, fristCategoryId int32, secondCa
 	var err error
 	db := r.data.Db.Model(&biz.MtDrug{})
 	if keyword != "" {
-		db = db.Where("drug_name like ?", "%"+keyword+"%")
+		// 同时按拼音全拼、首字母和通用名、商品名匹配
+		ids, err := r.names.MatchIDs(ctx, keyword)
+		if err != nil {
+			r.log.Warnf("读取药品名称词典失败，仅按名称匹配: %v", err)
+		}
+		db = db.Where(keywordCondition(r.data.Db, keyword, ids))
 	}
 	if secondCategoryId > 0 {
 		db = db.Where("second_category_id = ?", secondCategoryId)
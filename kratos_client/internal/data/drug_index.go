@@ -0,0 +1,343 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+)
+
+// 药品索引同步任务，与药品或库存变更在同一事务内写入，后台同步任务消费
+type MtDrugIndexTask struct {
+	ID          int64      `gorm:"column:id;type:bigint;primaryKey;autoIncrement"`
+	DrugID      int64      `gorm:"column:drug_id;type:bigint;not null;index:idx_drug_index_task_drug"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:datetime(3);not null;index:idx_drug_index_task_created"`
+	ProcessedAt *time.Time `gorm:"column:processed_at;type:datetime(3);index:idx_drug_index_task_processed"`
+}
+
+func (MtDrugIndexTask) TableName() string {
+	return "mt_drug_index_task"
+}
+
+// 在给定事务内登记药品索引同步任务
+func enqueueDrugIndexTask(db *gorm.DB, drugIDs ...int64) error {
+	if len(drugIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	tasks := make([]*MtDrugIndexTask, len(drugIDs))
+	for i, id := range drugIDs {
+		tasks[i] = &MtDrugIndexTask{DrugID: id, CreatedAt: now}
+	}
+	return db.Create(&tasks).Error
+}
+
+type drugIndexRepo struct {
+	data  *Data
+	alias string
+	log   *log.Helper
+}
+
+// 创建药品索引仓储
+func NewDrugIndexRepo(data *Data, logger log.Logger) biz.DrugIndexRepo {
+	return &drugIndexRepo{
+		data:  data,
+		alias: DrugIndexAlias,
+		log:   log.NewHelper(logger),
+	}
+}
+
+func (r *drugIndexRepo) Enabled() bool {
+	return r.data.Es != nil
+}
+
+func (r *drugIndexRepo) ListPendingTasks(ctx context.Context, limit int) ([]*biz.DrugIndexTask, error) {
+	var rows []*MtDrugIndexTask
+	err := r.data.Db.WithContext(ctx).
+		Where("processed_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*biz.DrugIndexTask, len(rows))
+	for i, row := range rows {
+		tasks[i] = &biz.DrugIndexTask{ID: row.ID, DrugID: row.DrugID, CreatedAt: row.CreatedAt}
+	}
+	return tasks, nil
+}
+
+func (r *drugIndexRepo) MarkTasksProcessed(ctx context.Context, ids []int64) error {
+	return r.data.Db.WithContext(ctx).
+		Model(&MtDrugIndexTask{}).
+		Where("id IN ?", ids).
+		Update("processed_at", time.Now()).Error
+}
+
+func (r *drugIndexRepo) PurgeProcessedTasks(ctx context.Context, before time.Time) (int64, error) {
+	result := r.data.Db.WithContext(ctx).
+		Where("processed_at IS NOT NULL AND processed_at < ?", before).
+		Delete(&MtDrugIndexTask{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *drugIndexRepo) ListChangedDrugIDs(ctx context.Context, since time.Time) ([]int64, error) {
+	var ids []int64
+	err := r.data.Db.WithContext(ctx).
+		Model(&MtDrugIndexTask{}).
+		Where("created_at >= ?", since).
+		Distinct().
+		Order("drug_id ASC").
+		Pluck("drug_id", &ids).Error
+	return ids, err
+}
+
+func (r *drugIndexRepo) ListIndexSources(ctx context.Context, afterID int64, limit int) ([]*biz.DrugIndexSource, error) {
+	var drugs []*biz.MtDrug
+	err := r.data.Db.WithContext(ctx).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&drugs).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.loadIndexSources(ctx, drugs)
+}
+
+func (r *drugIndexRepo) GetIndexSources(ctx context.Context, drugIDs []int64) ([]*biz.DrugIndexSource, error) {
+	var drugs []*biz.MtDrug
+	if err := r.data.Db.WithContext(ctx).Where("id IN ?", drugIDs).Order("id ASC").Find(&drugs).Error; err != nil {
+		return nil, err
+	}
+	return r.loadIndexSources(ctx, drugs)
+}
+
+// 批量补齐药品的说明书和用药指导
+func (r *drugIndexRepo) loadIndexSources(ctx context.Context, drugs []*biz.MtDrug) ([]*biz.DrugIndexSource, error) {
+	if len(drugs) == 0 {
+		return nil, nil
+	}
+	var explainIDs, guideIDs []int32
+	for _, drug := range drugs {
+		if drug.Explain > 0 {
+			explainIDs = append(explainIDs, int32(drug.Explain))
+		}
+		if drug.Guide > 0 {
+			guideIDs = append(guideIDs, int32(drug.Guide))
+		}
+	}
+
+	explains := make(map[int32]*biz.MtExplain)
+	if len(explainIDs) > 0 {
+		var rows []*biz.MtExplain
+		if err := r.data.Db.WithContext(ctx).Where("id IN ?", explainIDs).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			explains[row.Id] = row
+		}
+	}
+	guides := make(map[int32]*biz.MtGuide)
+	if len(guideIDs) > 0 {
+		var rows []*biz.MtGuide
+		if err := r.data.Db.WithContext(ctx).Where("id IN ?", guideIDs).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			guides[row.Id] = row
+		}
+	}
+
+	sources := make([]*biz.DrugIndexSource, len(drugs))
+	for i, drug := range drugs {
+		sources[i] = &biz.DrugIndexSource{
+			Drug:    drug,
+			Explain: explains[int32(drug.Explain)],
+			Guide:   guides[int32(drug.Guide)],
+		}
+	}
+	return sources, nil
+}
+
+type esBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func (r *drugIndexRepo) IndexDocuments(ctx context.Context, index string, docs []*biz.DrugDocument) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range docs {
+		enc.Encode(map[string]interface{}{"index": map[string]interface{}{"_id": strconv.FormatInt(doc.ID, 10)}})
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+	return r.bulk(ctx, index, &body)
+}
+
+func (r *drugIndexRepo) DeleteDocuments(ctx context.Context, index string, drugIDs []int64) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, id := range drugIDs {
+		enc.Encode(map[string]interface{}{"delete": map[string]interface{}{"_id": strconv.FormatInt(id, 10)}})
+	}
+	return r.bulk(ctx, index, &body)
+}
+
+// 执行批量写入，删除不存在的文档不视为失败
+// 写别名时要求别名已存在，避免首次重建前自动创建出动态映射的同名索引
+func (r *drugIndexRepo) bulk(ctx context.Context, index string, body *bytes.Buffer) error {
+	es := r.data.Es
+	opts := []func(*esapi.BulkRequest){es.Bulk.WithContext(ctx), es.Bulk.WithIndex(index)}
+	if index == "" {
+		opts = []func(*esapi.BulkRequest){es.Bulk.WithContext(ctx), es.Bulk.WithIndex(r.alias), es.Bulk.WithRequireAlias(true)}
+	}
+	res, err := es.Bulk(body, opts...)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch返回错误: %s", res.String())
+	}
+
+	var result esBulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("解析批量写入结果失败: %v", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for action, detail := range item {
+			if action == "delete" && detail.Status == http.StatusNotFound {
+				continue
+			}
+			if detail.Error != nil {
+				return fmt.Errorf("药品 %s 写入索引失败: %s %s", detail.ID, detail.Error.Type, detail.Error.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *drugIndexRepo) CreateIndex(ctx context.Context) (string, error) {
+	// 索引名带毫秒时间戳，别名切换前新旧索引并存
+	now := time.Now()
+	index := fmt.Sprintf("%s_%s%03d", r.alias, now.Format("20060102150405"), now.Nanosecond()/int(time.Millisecond))
+	es := r.data.Es
+	res, err := es.Indices.Create(index,
+		es.Indices.Create.WithContext(ctx),
+		es.Indices.Create.WithBody(strings.NewReader(DrugIndexMapping)))
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return "", fmt.Errorf("elasticsearch返回错误: %s", res.String())
+	}
+	return index, nil
+}
+
+func (r *drugIndexRepo) SwitchAlias(ctx context.Context, index string) ([]string, error) {
+	es := r.data.Es
+	res, err := es.Indices.Refresh(es.Indices.Refresh.WithContext(ctx), es.Indices.Refresh.WithIndex(index))
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("刷新索引失败: %s", res.String())
+	}
+
+	old, legacy, err := r.aliasTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var actions []interface{}
+	for _, name := range old {
+		actions = append(actions, map[string]interface{}{"remove": map[string]interface{}{"index": name, "alias": r.alias}})
+	}
+	// 早期直接以别名为名建的索引，切换时一并删除，否则别名无法创建
+	if legacy {
+		actions = append(actions, map[string]interface{}{"remove_index": map[string]interface{}{"index": r.alias}})
+	}
+	actions = append(actions, map[string]interface{}{"add": map[string]interface{}{"index": index, "alias": r.alias}})
+
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(map[string]interface{}{"actions": actions}); err != nil {
+		return nil, err
+	}
+	res, err = es.Indices.UpdateAliases(&body, es.Indices.UpdateAliases.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch返回错误: %s", res.String())
+	}
+	return old, nil
+}
+
+// 查询别名当前指向的索引；别名不存在但有同名索引时 legacy 为 true
+func (r *drugIndexRepo) aliasTargets(ctx context.Context) (old []string, legacy bool, err error) {
+	es := r.data.Es
+	res, err := es.Indices.GetAlias(es.Indices.GetAlias.WithContext(ctx), es.Indices.GetAlias.WithName(r.alias))
+	if err != nil {
+		return nil, false, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		exists, err := es.Indices.Exists([]string{r.alias}, es.Indices.Exists.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		exists.Body.Close()
+		return nil, exists.StatusCode == http.StatusOK, nil
+	}
+	if res.IsError() {
+		return nil, false, fmt.Errorf("elasticsearch返回错误: %s", res.String())
+	}
+	var targets map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&targets); err != nil {
+		return nil, false, err
+	}
+	for name := range targets {
+		old = append(old, name)
+	}
+	sort.Strings(old)
+	return old, false, nil
+}
+
+func (r *drugIndexRepo) DeleteIndices(ctx context.Context, indices []string) error {
+	es := r.data.Es
+	res, err := es.Indices.Delete(indices, es.Indices.Delete.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch返回错误: %s", res.String())
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+func countPendingIndexTasks(t *testing.T, d *Data) int64 {
+	t.Helper()
+	var count int64
+	if err := d.Db.Model(&MtDrugIndexTask{}).Where("processed_at IS NULL").Count(&count).Error; err != nil {
+		t.Fatalf("统计索引同步任务失败: %v", err)
+	}
+	return count
+}
+
+func TestDrugReindexAndIncrementalSync(t *testing.T) {
+	d := newOrderTestData(t, &biz.MtExplain{}, &biz.MtGuide{})
+	logger := newTestLogger()
+	ctx := context.Background()
+
+	if _, err := biz.NewDrugIndexUsecase(NewDrugIndexRepo(d, logger), logger).Reindex(ctx, 100); !errors.Is(err, biz.ErrSearchIndexUnavailable) {
+		t.Fatalf("Expected ErrSearchIndexUnavailable without elasticsearch, got %v", err)
+	}
+
+	es, fake := newFakeElasticsearch(t)
+	d.Es = es
+	uc := biz.NewDrugIndexUsecase(NewDrugIndexRepo(d, logger), logger)
+
+	if err := d.Db.Create(&biz.MtExplain{Id: 1, CommonName: "感冒灵颗粒", GoodsName: "999感冒灵", Function: "解热镇痛。用于感冒引起的头痛，发热，鼻塞，流涕，咽痛等。"}).Error; err != nil {
+		t.Fatalf("创建说明书失败: %v", err)
+	}
+	if err := d.Db.Create(&biz.MtGuide{Id: 1, MajorFunction: "清热解毒，缓解四肢酸痛"}).Error; err != nil {
+		t.Fatalf("创建用药指导失败: %v", err)
+	}
+	if err := d.Db.Model(&biz.MtDrug{}).Where("id = ?", 1).Updates(map[string]interface{}{"explain": 1, "guide": 1}).Error; err != nil {
+		t.Fatalf("关联说明书失败: %v", err)
+	}
+	if err := d.Db.Create(&biz.MtDrug{Id: 2, DrugName: "已下架药品", DrugStore: 1, Price: 9, DeletedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("创建已删除药品失败: %v", err)
+	}
+
+	// 首次重建前别名不存在，增量同步失败且任务保留，也不会自动建出同名索引
+	if err := enqueueDrugIndexTask(d.Db, 1); err != nil {
+		t.Fatalf("登记索引同步任务失败: %v", err)
+	}
+	if _, err := uc.ProcessTasks(ctx, 10); err == nil {
+		t.Fatal("Expected sync to fail before the alias exists")
+	}
+	if fake.hasIndex(DrugIndexAlias) || countPendingIndexTasks(t, d) != 1 {
+		t.Fatalf("Expected task kept pending without creating an index")
+	}
+
+	report, err := uc.Reindex(ctx, 1)
+	if err != nil {
+		t.Fatalf("Reindex failed: %v", err)
+	}
+	if report.Indexed != 1 || report.Skipped != 1 || report.CaughtUp != 1 || len(report.OldIndices) != 0 {
+		t.Errorf("Unexpected reindex report %+v", report)
+	}
+	if target := fake.aliasTarget(DrugIndexAlias); target != report.Index {
+		t.Fatalf("Expected alias on %s, got %s", report.Index, target)
+	}
+	doc := fake.document(DrugIndexAlias, 1)
+	if doc == nil || fake.document(DrugIndexAlias, 2) != nil {
+		t.Fatalf("Expected only the live drug indexed, got %v", doc)
+	}
+	wantKeywords := []interface{}{"感冒灵颗粒", "999感冒灵", "解热镇痛", "清热解毒"}
+	if !reflect.DeepEqual(doc["keywords"], wantKeywords) {
+		t.Errorf("Expected keywords %v, got %v", wantKeywords, doc["keywords"])
+	}
+	wantSymptoms := []interface{}{"感冒", "头痛", "发热", "鼻塞", "流涕", "咽痛", "四肢酸痛"}
+	if !reflect.DeepEqual(doc["symptoms"], wantSymptoms) {
+		t.Errorf("Expected symptoms %v, got %v", wantSymptoms, doc["symptoms"])
+	}
+
+	// 别名建好后积压的任务正常消费
+	if n, err := uc.ProcessTasks(ctx, 10); err != nil || n != 1 {
+		t.Fatalf("Expected 1 task processed, got %d, %v", n, err)
+	}
+
+	// 库存变动在同一事务内登记任务，增量同步后搜索即可看到
+	if _, err := NewDrugInventoryRepo(d, logger).AdjustInventory(ctx, 1, 1, 40, "盘点"); err != nil {
+		t.Fatalf("AdjustInventory failed: %v", err)
+	}
+	if err := d.Db.Model(&biz.MtDrug{}).Where("id = ?", 1).Update("price", 18).Error; err != nil {
+		t.Fatalf("修改价格失败: %v", err)
+	}
+	if countPendingIndexTasks(t, d) != 1 {
+		t.Fatalf("Expected inventory change to enqueue an index task")
+	}
+	if _, err := uc.ProcessTasks(ctx, 10); err != nil {
+		t.Fatalf("ProcessTasks failed: %v", err)
+	}
+	resp, err := NewDrugSearchRepo(d, logger).Search(ctx, &biz.SearchRequest{Keyword: "感冒", OnlyInStock: true, Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.Total != 1 || resp.Drugs[0].Price != 18 || resp.Drugs[0].Inventory != 40 {
+		t.Errorf("Expected updated drug in search, got %+v", resp.Drugs)
+	}
+
+	// 删除药品后从索引移除
+	if err := d.Db.Model(&biz.MtDrug{}).Where("id = ?", 1).Update("deleted_at", time.Now()).Error; err != nil {
+		t.Fatalf("删除药品失败: %v", err)
+	}
+	if err := enqueueDrugIndexTask(d.Db, 1); err != nil {
+		t.Fatalf("登记索引同步任务失败: %v", err)
+	}
+	if _, err := uc.ProcessTasks(ctx, 10); err != nil {
+		t.Fatalf("ProcessTasks failed: %v", err)
+	}
+	if fake.document(DrugIndexAlias, 1) != nil {
+		t.Error("Expected deleted drug removed from index")
+	}
+
+	// 再次重建切换到新索引并删除旧索引
+	second, err := uc.Reindex(ctx, 100)
+	if err != nil {
+		t.Fatalf("Reindex failed: %v", err)
+	}
+	if second.Index == report.Index || !reflect.DeepEqual(second.OldIndices, []string{report.Index}) || fake.hasIndex(report.Index) {
+		t.Errorf("Expected old index %s replaced and deleted, got %+v", report.Index, second)
+	}
+	if fake.aliasTarget(DrugIndexAlias) != second.Index {
+		t.Errorf("Expected alias on %s", second.Index)
+	}
+}
+
+func TestDrugReindexReplacesLegacyIndex(t *testing.T) {
+	d := newOrderTestData(t, &biz.MtExplain{}, &biz.MtGuide{})
+	es, fake := newFakeElasticsearch(t)
+	d.Es = es
+	logger := newTestLogger()
+
+	// 早期直接以别名为名建的索引
+	res, err := es.Indices.Create(DrugIndexAlias)
+	if err != nil {
+		t.Fatalf("创建索引失败: %v", err)
+	}
+	res.Body.Close()
+
+	report, err := biz.NewDrugIndexUsecase(NewDrugIndexRepo(d, logger), logger).Reindex(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("Reindex failed: %v", err)
+	}
+	if fake.hasIndex(DrugIndexAlias) || fake.aliasTarget(DrugIndexAlias) != report.Index || report.Indexed != 1 {
+		t.Errorf("Expected legacy index replaced by alias on %s, got %+v", report.Index, report)
+	}
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+// 药品名称词典的刷新间隔，后台改名后最多延迟该时长生效
+const drugNameDictionaryTTL = time.Minute
+
+// 药品名称词典，缓存未删除药品的名称、通用名、商品名及其拼音
+// 数据库没有拼音列，拼音和同义名匹配在内存中完成后以药品ID参与查询
+type drugNameDictionary struct {
+	data *Data
+
+	mu       sync.Mutex
+	entries  []*biz.DrugNameEntry
+	loadedAt time.Time
+}
+
+func newDrugNameDictionary(data *Data) *drugNameDictionary {
+	return &drugNameDictionary{data: data}
+}
+
+func (d *drugNameDictionary) Entries(ctx context.Context) ([]*biz.DrugNameEntry, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.entries != nil && time.Since(d.loadedAt) < drugNameDictionaryTTL {
+		return d.entries, nil
+	}
+	entries, err := d.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.entries, d.loadedAt = entries, time.Now()
+	return entries, nil
+}
+
+func (d *drugNameDictionary) load(ctx context.Context) ([]*biz.DrugNameEntry, error) {
+	var drugs []*biz.MtDrug
+	if err := d.data.Db.WithContext(ctx).
+		Select("id", "drug_name", "explain", "sales_volume", "is_prescription", "deleted_at").
+		Order("id ASC").
+		Find(&drugs).Error; err != nil {
+		return nil, err
+	}
+	explainIDs := make([]int16, 0, len(drugs))
+	for _, drug := range drugs {
+		if drug.Explain > 0 {
+			explainIDs = append(explainIDs, drug.Explain)
+		}
+	}
+	explains := make(map[int32]*biz.MtExplain)
+	if len(explainIDs) > 0 {
+		var rows []*biz.MtExplain
+		if err := d.data.Db.WithContext(ctx).
+			Select("id", "common_name", "goods_name").
+			Where("id IN ?", explainIDs).
+			Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			explains[row.Id] = row
+		}
+	}
+
+	entries := make([]*biz.DrugNameEntry, 0, len(drugs))
+	for _, drug := range drugs {
+		if !drug.DeletedAt.IsZero() {
+			continue
+		}
+		entries = append(entries, biz.NewDrugNameEntry(drug, explains[int32(drug.Explain)]))
+	}
+	return entries, nil
+}
+
+// 按拼音和同义名命中关键词的药品ID，与名称子串匹配取并集使用
+func (d *drugNameDictionary) MatchIDs(ctx context.Context, keyword string) ([]int64, error) {
+	entries, err := d.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return biz.MatchDrugNames(keyword, entries), nil
+}
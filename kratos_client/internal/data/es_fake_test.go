@@ -0,0 +1,437 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"kratos_client/internal/biz"
+)
+
+// 进程内模拟的Elasticsearch，只实现药品索引和搜索用到的接口：
+// 建删索引、别名查询与切换、bulk 写入删除、刷新，以及搜索的查询子集
+// （match_phrase、term、range 过滤，字段排序，from/size 分页，terms 和 range 聚合）
+type fakeElasticsearch struct {
+	mu      sync.Mutex
+	indices map[string]map[string]map[string]interface{} // 索引名 -> 文档ID -> 文档
+	aliases map[string]string                            // 别名 -> 索引名
+}
+
+func newFakeElasticsearch(t *testing.T) (*elasticsearch.Client, *fakeElasticsearch) {
+	t.Helper()
+	fake := &fakeElasticsearch{
+		indices: make(map[string]map[string]map[string]interface{}),
+		aliases: make(map[string]string),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		status, body := fake.handle(r)
+		w.WriteHeader(status)
+		if r.Method != http.MethodHead {
+			json.NewEncoder(w).Encode(body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	es, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("创建Elasticsearch客户端失败: %v", err)
+	}
+	return es, fake
+}
+
+// 直接写入一个索引并把别名指向它
+func (f *fakeElasticsearch) seed(index, alias string, docs []*biz.DrugDocument) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.indices[index] = make(map[string]map[string]interface{})
+	for _, doc := range docs {
+		f.indices[index][strconv.FormatInt(doc.ID, 10)] = toFakeSource(doc)
+	}
+	f.aliases[alias] = index
+}
+
+// 别名指向的索引名
+func (f *fakeElasticsearch) aliasTarget(alias string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.aliases[alias]
+}
+
+func (f *fakeElasticsearch) hasIndex(index string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.indices[index]
+	return ok
+}
+
+// 按别名或索引名读取文档，不存在时返回nil
+func (f *fakeElasticsearch) document(target string, id int64) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, _ := f.resolve(target)
+	return f.indices[index][strconv.FormatInt(id, 10)]
+}
+
+func (f *fakeElasticsearch) resolve(target string) (string, bool) {
+	if index, ok := f.aliases[target]; ok {
+		return index, true
+	}
+	_, ok := f.indices[target]
+	return target, ok
+}
+
+func notFound(kind string) (int, interface{}) {
+	return http.StatusNotFound, map[string]interface{}{"error": map[string]interface{}{"type": kind}, "status": 404}
+}
+
+func (f *fakeElasticsearch) handle(r *http.Request) (int, interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(parts) == 2 && parts[0] == "_alias" && r.Method == http.MethodGet:
+		index, ok := f.aliases[parts[1]]
+		if !ok {
+			return notFound("aliases_not_found_exception")
+		}
+		return http.StatusOK, map[string]interface{}{index: map[string]interface{}{"aliases": map[string]interface{}{parts[1]: map[string]interface{}{}}}}
+	case len(parts) == 1 && parts[0] == "_aliases" && r.Method == http.MethodPost:
+		var body struct {
+			Actions []map[string]map[string]string `json:"actions"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		for _, action := range body.Actions {
+			for op, args := range action {
+				switch op {
+				case "remove":
+					delete(f.aliases, args["alias"])
+				case "remove_index":
+					delete(f.indices, args["index"])
+				case "add":
+					f.aliases[args["alias"]] = args["index"]
+				}
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"acknowledged": true}
+	case len(parts) == 1 && r.Method == http.MethodHead:
+		if _, ok := f.resolve(parts[0]); !ok {
+			return notFound("index_not_found_exception")
+		}
+		return http.StatusOK, nil
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		if _, ok := f.resolve(parts[0]); ok {
+			return http.StatusBadRequest, map[string]interface{}{"error": map[string]interface{}{"type": "resource_already_exists_exception"}}
+		}
+		f.indices[parts[0]] = make(map[string]map[string]interface{})
+		return http.StatusOK, map[string]interface{}{"acknowledged": true, "index": parts[0]}
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		for _, index := range strings.Split(parts[0], ",") {
+			if _, ok := f.indices[index]; !ok {
+				return notFound("index_not_found_exception")
+			}
+			delete(f.indices, index)
+		}
+		return http.StatusOK, map[string]interface{}{"acknowledged": true}
+	case len(parts) == 2 && parts[1] == "_refresh":
+		if _, ok := f.resolve(parts[0]); !ok {
+			return notFound("index_not_found_exception")
+		}
+		return http.StatusOK, map[string]interface{}{}
+	case len(parts) == 2 && parts[1] == "_bulk":
+		return f.bulk(parts[0], r.URL.Query().Get("require_alias") == "true", r)
+	case len(parts) == 2 && parts[1] == "_search":
+		index, ok := f.resolve(parts[0])
+		if !ok {
+			return notFound("index_not_found_exception")
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return http.StatusBadRequest, map[string]interface{}{"error": map[string]interface{}{"type": "parse_exception"}}
+		}
+		var sources []map[string]interface{}
+		for _, source := range f.indices[index] {
+			sources = append(sources, source)
+		}
+		sort.Slice(sources, func(i, j int) bool { return fakeCompare(sources[i]["id"], sources[j]["id"]) < 0 })
+		return http.StatusOK, fakeSearch(sources, body)
+	}
+	return http.StatusBadRequest, map[string]interface{}{"error": map[string]interface{}{"type": "unsupported", "reason": r.Method + " " + r.URL.Path}}
+}
+
+// bulk 支持 index 和 delete；require_alias 时目标不是别名则逐条返回 index_not_found_exception
+func (f *fakeElasticsearch) bulk(target string, requireAlias bool, r *http.Request) (int, interface{}) {
+	index, isAlias := f.aliases[target]
+	if !isAlias {
+		index = target
+	}
+	var items []interface{}
+	hasErrors := false
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var action map[string]map[string]string
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil || len(action) == 0 {
+			continue
+		}
+		for op, meta := range action {
+			id := meta["_id"]
+			result := map[string]interface{}{"_id": id, "status": http.StatusOK}
+			var source map[string]interface{}
+			if op == "index" {
+				scanner.Scan()
+				json.Unmarshal(scanner.Bytes(), &source)
+			}
+			_, exists := f.indices[index]
+			switch {
+			case requireAlias && !isAlias && op != "delete":
+				result["status"] = http.StatusNotFound
+				result["error"] = map[string]interface{}{"type": "index_not_found_exception", "reason": fmt.Sprintf("no such index [%s] and [require_alias] request flag is [true]", target)}
+			case op == "delete" && (!exists || f.indices[index][id] == nil):
+				result["status"] = http.StatusNotFound
+			case op == "delete":
+				delete(f.indices[index], id)
+			default:
+				if !exists {
+					f.indices[index] = make(map[string]map[string]interface{})
+				}
+				f.indices[index][id] = source
+			}
+			if result["error"] != nil {
+				hasErrors = true
+			}
+			if result["status"] == http.StatusNotFound {
+				hasErrors = true
+			}
+			items = append(items, map[string]interface{}{op: result})
+		}
+	}
+	return http.StatusOK, map[string]interface{}{"errors": hasErrors, "items": items}
+}
+
+func toFakeSource(doc *biz.DrugDocument) map[string]interface{} {
+	raw, _ := json.Marshal(doc)
+	var source map[string]interface{}
+	json.Unmarshal(raw, &source)
+	return source
+}
+
+func fakeSearch(sources []map[string]interface{}, body map[string]interface{}) map[string]interface{} {
+	boolQuery := body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	var matched []map[string]interface{}
+	for _, source := range sources {
+		if fakeMatches(source, boolQuery) {
+			matched = append(matched, source)
+		}
+	}
+
+	sorts, _ := body["sort"].([]interface{})
+	sort.SliceStable(matched, func(i, j int) bool {
+		for _, s := range sorts {
+			for field, opts := range s.(map[string]interface{}) {
+				if field == "_score" {
+					continue
+				}
+				c := fakeCompare(matched[i][field], matched[j][field])
+				if c == 0 {
+					continue
+				}
+				if opts.(map[string]interface{})["order"] == "desc" {
+					return c > 0
+				}
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	aggregations := map[string]interface{}{}
+	aggs, _ := body["aggs"].(map[string]interface{})
+	for name, agg := range aggs {
+		agg := agg.(map[string]interface{})
+		if terms, ok := agg["terms"].(map[string]interface{}); ok {
+			aggregations[name] = fakeTermsAgg(matched, terms)
+		}
+		if rng, ok := agg["range"].(map[string]interface{}); ok {
+			aggregations[name] = fakeRangeAgg(matched, rng)
+		}
+	}
+
+	from, size := int(body["from"].(float64)), int(body["size"].(float64))
+	hits := []interface{}{}
+	for i := from; i < len(matched) && i < from+size; i++ {
+		hits = append(hits, map[string]interface{}{"_source": matched[i]})
+	}
+	return map[string]interface{}{
+		"hits": map[string]interface{}{
+			"total": map[string]interface{}{"value": len(matched), "relation": "eq"},
+			"hits":  hits,
+		},
+		"aggregations": aggregations,
+	}
+}
+
+func fakeMatches(source map[string]interface{}, boolQuery map[string]interface{}) bool {
+	must, _ := boolQuery["must"].([]interface{})
+	for _, clause := range must {
+		if !fakeClauseMatches(source, clause.(map[string]interface{})) {
+			return false
+		}
+	}
+	if should, ok := boolQuery["should"].([]interface{}); ok {
+		matched := false
+		for _, clause := range should {
+			if fakeClauseMatches(source, clause.(map[string]interface{})) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	filter, _ := boolQuery["filter"].([]interface{})
+	for _, clause := range filter {
+		clause := clause.(map[string]interface{})
+		if term, ok := clause["term"].(map[string]interface{}); ok {
+			for field, value := range term {
+				if fakeCompare(source[field], value) != 0 {
+					return false
+				}
+			}
+		}
+		if rng, ok := clause["range"].(map[string]interface{}); ok {
+			for field, bounds := range rng {
+				for op, bound := range bounds.(map[string]interface{}) {
+					c := fakeCompare(source[field], bound)
+					if (op == "gt" && c <= 0) || (op == "gte" && c < 0) || (op == "lt" && c >= 0) || (op == "lte" && c > 0) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// 支持 match_phrase（子串）、wildcard（仅 *子串* 形式）和嵌套 bool，数组字段任一元素命中即可
+func fakeClauseMatches(source map[string]interface{}, clause map[string]interface{}) bool {
+	if nested, ok := clause["bool"].(map[string]interface{}); ok {
+		return fakeMatches(source, nested)
+	}
+	if phrase, ok := clause["match_phrase"].(map[string]interface{}); ok {
+		for field, query := range phrase {
+			if opts, ok := query.(map[string]interface{}); ok {
+				query = opts["query"]
+			}
+			text := strings.ToLower(query.(string))
+			if !fakeAnyValue(source[field], func(value string) bool { return strings.Contains(strings.ToLower(value), text) }) {
+				return false
+			}
+		}
+		return true
+	}
+	if wildcard, ok := clause["wildcard"].(map[string]interface{}); ok {
+		for field, opts := range wildcard {
+			pattern := strings.Trim(opts.(map[string]interface{})["value"].(string), "*")
+			if !fakeAnyValue(source[field], func(value string) bool { return strings.Contains(value, pattern) }) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func fakeAnyValue(value interface{}, match func(string) bool) bool {
+	switch v := value.(type) {
+	case string:
+		return match(v)
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && match(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// terms聚合按数量降序，数量相同按键升序
+func fakeTermsAgg(matched []map[string]interface{}, terms map[string]interface{}) map[string]interface{} {
+	field := terms["field"].(string)
+	counts := map[interface{}]int64{}
+	var keys []interface{}
+	for _, source := range matched {
+		key := source[field]
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return fakeCompare(keys[i], keys[j]) < 0
+	})
+	if size := int(terms["size"].(float64)); len(keys) > size {
+		keys = keys[:size]
+	}
+	buckets := []interface{}{}
+	for _, key := range keys {
+		buckets = append(buckets, map[string]interface{}{"key": key, "doc_count": counts[key]})
+	}
+	return map[string]interface{}{"buckets": buckets}
+}
+
+func fakeRangeAgg(matched []map[string]interface{}, rng map[string]interface{}) map[string]interface{} {
+	field := rng["field"].(string)
+	buckets := []interface{}{}
+	for _, r := range rng["ranges"].([]interface{}) {
+		r := r.(map[string]interface{})
+		var count int64
+		for _, source := range matched {
+			if from, ok := r["from"]; ok && fakeCompare(source[field], from) < 0 {
+				continue
+			}
+			if to, ok := r["to"]; ok && fakeCompare(source[field], to) >= 0 {
+				continue
+			}
+			count++
+		}
+		buckets = append(buckets, map[string]interface{}{"key": r["key"], "doc_count": count})
+	}
+	return map[string]interface{}{"buckets": buckets}
+}
+
+func fakeCompare(a, b interface{}) int {
+	switch a := a.(type) {
+	case float64:
+		b, _ := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case bool:
+		if b, _ := b.(bool); a == b {
+			return 0
+		}
+		return 1
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
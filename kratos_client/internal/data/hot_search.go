@@ -0,0 +1,237 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kratos_client/internal/biz"
+)
+
+// 热门搜索按小时汇总数据模型 - 对应 mt_hot_search_stat 表，未配置Redis时使用
+type MtHotSearchStat struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CityID      int32     `gorm:"column:city_id;not null;uniqueIndex:uk_city_term_hour" json:"city_id"`
+	Term        string    `gorm:"column:term;size:64;not null;uniqueIndex:uk_city_term_hour" json:"term"`
+	StatHour    time.Time `gorm:"column:stat_hour;type:datetime(3);not null;uniqueIndex:uk_city_term_hour;index" json:"stat_hour"`
+	SearchCount int64     `gorm:"column:search_count;not null;default:0" json:"search_count"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:datetime(3)" json:"updated_at"`
+}
+
+// 表名
+func (MtHotSearchStat) TableName() string {
+	return "mt_hot_search_stat"
+}
+
+// 热搜屏蔽词数据模型 - 对应 mt_hot_search_block 表，由管理后台维护
+type MtHotSearchBlock struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Term      string     `gorm:"column:term;size:64;not null;uniqueIndex" json:"term"`
+	Remark    string     `gorm:"column:remark;size:255" json:"remark"`
+	CreatedAt time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt *time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtHotSearchBlock) TableName() string {
+	return "mt_hot_search_block"
+}
+
+// 创建热门搜索仓储，配置了Redis时使用有序集合，否则使用数据库按小时汇总
+func NewHotSearchRepo(data *Data, logger log.Logger) biz.HotSearchRepo {
+	if data.RDb != nil {
+		return &redisHotSearchRepo{hotSearchBlocklist: hotSearchBlocklist{data: data}, log: log.NewHelper(logger)}
+	}
+	return &dbHotSearchRepo{hotSearchBlocklist: hotSearchBlocklist{data: data}, log: log.NewHelper(logger)}
+}
+
+// 屏蔽词两种实现都从数据库读取，后台修改后立即生效
+type hotSearchBlocklist struct {
+	data *Data
+}
+
+func (b hotSearchBlocklist) ListBlockedTerms(ctx context.Context) ([]string, error) {
+	var terms []string
+	if err := b.data.Db.WithContext(ctx).Model(&MtHotSearchBlock{}).Pluck("term", &terms).Error; err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// 计入全国榜单，选择了城市时同时计入城市榜单
+func hotSearchCities(cityID int32) []int32 {
+	if cityID > 0 {
+		return []int32{0, cityID}
+	}
+	return []int32{0}
+}
+
+// 基于Redis有序集合的热门搜索仓储，每个城市每小时一个有序集合
+type redisHotSearchRepo struct {
+	hotSearchBlocklist
+	log *log.Helper
+}
+
+// 同一城市的键使用相同的哈希标签，集群模式下可以合并计算
+func hotSearchRedisKey(cityID int32, bucket time.Time) string {
+	return fmt.Sprintf("hot_search:{%d}:%d", cityID, bucket.Unix()/3600)
+}
+
+func (r *redisHotSearchRepo) Record(ctx context.Context, term string, cityID int32, at time.Time) error {
+	bucket := biz.HotSearchBucket(at)
+	_, err := r.data.RDb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, city := range hotSearchCities(cityID) {
+			key := hotSearchRedisKey(city, bucket)
+			pipe.ZIncrBy(ctx, key, 1, term)
+			pipe.Expire(ctx, key, biz.HotSearchRetention)
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("记录热门搜索失败: term=%s, cityId=%d, error=%v", term, cityID, err)
+	}
+	return err
+}
+
+// 按衰减权重合并窗口内的小时集合得到热度，权重全为1合并得到次数
+func (r *redisHotSearchRepo) Top(ctx context.Context, window biz.HotSearchWindow, cityID int32, now time.Time, limit int) ([]*biz.HotItem, error) {
+	buckets := window.Buckets(now)
+	keys := make([]string, 0, len(buckets))
+	weights := make([]float64, 0, len(buckets))
+	ones := make([]float64, 0, len(buckets))
+	for _, bucket := range buckets {
+		keys = append(keys, hotSearchRedisKey(cityID, bucket))
+		weights = append(weights, window.Weight(bucket, now))
+		ones = append(ones, 1)
+	}
+	suffix := fmt.Sprintf("%d%d", now.UnixNano(), rand.Int63())
+	scoreKey := fmt.Sprintf("hot_search:{%d}:tmp:score:%s", cityID, suffix)
+	countKey := fmt.Sprintf("hot_search:{%d}:tmp:count:%s", cityID, suffix)
+	defer r.data.RDb.Del(context.Background(), scoreKey, countKey)
+
+	var ranked *redis.ZSliceCmd
+	_, err := r.data.RDb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, scoreKey, &redis.ZStore{Keys: keys, Weights: weights})
+		pipe.ZUnionStore(ctx, countKey, &redis.ZStore{Keys: keys, Weights: ones})
+		pipe.Expire(ctx, scoreKey, time.Minute)
+		pipe.Expire(ctx, countKey, time.Minute)
+		ranked = pipe.ZRevRangeWithScores(ctx, scoreKey, 0, int64(limit-1))
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("查询热门搜索失败: cityId=%d, window=%s, error=%v", cityID, window, err)
+		return nil, err
+	}
+
+	entries := ranked.Val()
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	counts := make([]*redis.FloatCmd, len(entries))
+	_, err = r.data.RDb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, entry := range entries {
+			counts[i] = pipe.ZScore(ctx, countKey, entry.Member.(string))
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("查询热门搜索次数失败: cityId=%d, window=%s, error=%v", cityID, window, err)
+		return nil, err
+	}
+
+	items := make([]*biz.HotItem, 0, len(entries))
+	for i, entry := range entries {
+		items = append(items, &biz.HotItem{
+			Content: entry.Member.(string),
+			Count:   int64(counts[i].Val()),
+			Score:   entry.Score,
+		})
+	}
+	biz.SortHotItems(items)
+	return items, nil
+}
+
+// 基于数据库的热门搜索仓储，按城市、搜索词和小时汇总次数
+type dbHotSearchRepo struct {
+	hotSearchBlocklist
+	log *log.Helper
+
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+func (r *dbHotSearchRepo) Record(ctx context.Context, term string, cityID int32, at time.Time) error {
+	bucket := biz.HotSearchBucket(at)
+	err := r.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, city := range hotSearchCities(cityID) {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "city_id"}, {Name: "term"}, {Name: "stat_hour"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"search_count": gorm.Expr("search_count + 1"),
+					"updated_at":   at,
+				}),
+			}).Create(&MtHotSearchStat{CityID: city, Term: term, StatHour: bucket, SearchCount: 1, UpdatedAt: at}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("记录热门搜索失败: term=%s, cityId=%d, error=%v", term, cityID, err)
+		return err
+	}
+	r.purge(ctx, at)
+	return nil
+}
+
+// 每小时清理一次超过保留时长的汇总
+func (r *dbHotSearchRepo) purge(ctx context.Context, now time.Time) {
+	r.mu.Lock()
+	if now.Sub(r.lastPurged) < time.Hour {
+		r.mu.Unlock()
+		return
+	}
+	r.lastPurged = now
+	r.mu.Unlock()
+
+	if err := r.data.Db.WithContext(ctx).Where("stat_hour < ?", now.Add(-biz.HotSearchRetention)).
+		Delete(&MtHotSearchStat{}).Error; err != nil {
+		r.log.Warnf("清理热门搜索汇总失败: %v", err)
+	}
+}
+
+func (r *dbHotSearchRepo) Top(ctx context.Context, window biz.HotSearchWindow, cityID int32, now time.Time, limit int) ([]*biz.HotItem, error) {
+	buckets := window.Buckets(now)
+	var stats []MtHotSearchStat
+	if err := r.data.Db.WithContext(ctx).
+		Select("term", "stat_hour", "search_count").
+		Where("city_id = ? AND stat_hour >= ? AND stat_hour <= ?", cityID, buckets[len(buckets)-1], buckets[0]).
+		Find(&stats).Error; err != nil {
+		r.log.Errorf("查询热门搜索失败: cityId=%d, window=%s, error=%v", cityID, window, err)
+		return nil, err
+	}
+
+	byTerm := make(map[string]*biz.HotItem)
+	items := make([]*biz.HotItem, 0)
+	for _, stat := range stats {
+		item := byTerm[stat.Term]
+		if item == nil {
+			item = &biz.HotItem{Content: stat.Term}
+			byTerm[stat.Term] = item
+			items = append(items, item)
+		}
+		item.Count += stat.SearchCount
+		item.Score += float64(stat.SearchCount) * window.Weight(stat.StatHour, now)
+	}
+	biz.SortHotItems(items)
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
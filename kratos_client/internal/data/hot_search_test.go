@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"kratos_client/internal/biz"
+)
+
+func newHotSearchTestData(t *testing.T, withRedis bool) *Data {
+	t.Helper()
+	d := newTestData(t, &MtHotSearchStat{}, &MtHotSearchBlock{}, &biz.MtUser{})
+	if withRedis {
+		mr := miniredis.RunT(t)
+		d.RDb = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { d.RDb.Close() })
+	}
+	return d
+}
+
+func hotItemContents(items []*biz.HotItem) []string {
+	contents := make([]string, 0, len(items))
+	for _, item := range items {
+		contents = append(contents, item.Content)
+	}
+	return contents
+}
+
+func assertHotItems(t *testing.T, name string, items []*biz.HotItem, want []string, wantCounts []int64) {
+	t.Helper()
+	got := hotItemContents(items)
+	if len(got) != len(want) {
+		t.Fatalf("%s: expected %v, got %v", name, want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] || items[i].Count != wantCounts[i] {
+			t.Fatalf("%s: expected %v with counts %v, got %v at %d (count %d)", name, want, wantCounts, got, i, items[i].Count)
+		}
+	}
+}
+
+func TestHotSearch(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		withRedis bool
+	}{
+		{"database", false},
+		{"redis", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newHotSearchTestData(t, tc.withRedis)
+			logger := newTestLogger()
+			ctx := context.Background()
+			repo := NewHotSearchRepo(d, logger)
+			uc := biz.NewHotSearchUsecase(repo, NewUserRepo(d, logger), logger)
+
+			// 用户选择的城市作为默认热搜城市，请求指定的城市优先
+			if err := d.Db.Create(&biz.MtUser{Id: 1, NickName: "测试用户", CityId: 110100}).Error; err != nil {
+				t.Fatalf("创建用户失败: %v", err)
+			}
+			if city := uc.ResolveCity(ctx, 1, 0); city != 110100 {
+				t.Errorf("Expected selected city 110100, got %d", city)
+			}
+			if city := uc.ResolveCity(ctx, 1, 310100); city != 310100 {
+				t.Errorf("Expected requested city 310100, got %d", city)
+			}
+			if city := uc.ResolveCity(ctx, 2, 0); city != 0 {
+				t.Errorf("Expected no city for unknown user, got %d", city)
+			}
+
+			now := time.Now()
+			record := func(term string, cityID int32, at time.Time, times int) {
+				t.Helper()
+				for i := 0; i < times; i++ {
+					if err := repo.Record(ctx, term, cityID, at); err != nil {
+						t.Fatalf("Record failed: %v", err)
+					}
+				}
+			}
+			record("感冒药", 0, now.Add(-30*time.Hour), 3)
+			record("维生素c", 0, now.Add(-5*time.Hour), 2)
+			record("头痛吃什么药", 0, now.Add(-2*time.Hour), 1)
+			record("代购处方药", 0, now, 5)
+			record("咳嗽", 310100, now, 1)
+			for _, keyword := range []string{" 布洛芬", "布洛芬 ", "  "} {
+				if err := uc.Record(ctx, keyword, 110100); err != nil {
+					t.Fatalf("Record failed: %v", err)
+				}
+			}
+			if err := d.Db.Create(&MtHotSearchBlock{Term: "代购"}).Error; err != nil {
+				t.Fatalf("创建屏蔽词失败: %v", err)
+			}
+
+			// 最近24小时：30小时前的不计入，屏蔽词不展示，问句单独排行，症状从搜索词汇总
+			day, err := uc.GetHotSearch(ctx, "", 0, 10)
+			if err != nil {
+				t.Fatalf("GetHotSearch failed: %v", err)
+			}
+			if day.Window != biz.HotSearchWindowDay || day.CityID != 0 {
+				t.Errorf("Unexpected window %s city %d", day.Window, day.CityID)
+			}
+			assertHotItems(t, "day keywords", day.Keywords, []string{"布洛芬", "维生素c", "咳嗽"}, []int64{2, 2, 1})
+			assertHotItems(t, "day questions", day.Questions, []string{"头痛吃什么药"}, []int64{1})
+			assertHotItems(t, "day symptoms", day.Symptoms, []string{"咳嗽", "头痛"}, []int64{1, 1})
+			if day.Keywords[1].Score >= 2 {
+				t.Errorf("Expected earlier searches to decay, got score %v", day.Keywords[1].Score)
+			}
+
+			// 最近7天：次数更多但较早的搜索排在最近的搜索之后
+			week, err := uc.GetHotSearch(ctx, biz.HotSearchWindowWeek, 0, 2)
+			if err != nil {
+				t.Fatalf("GetHotSearch failed: %v", err)
+			}
+			assertHotItems(t, "week keywords", week.Keywords, []string{"布洛芬", "感冒药"}, []int64{2, 3})
+
+			// 城市榜单只统计该城市的搜索，暂无数据的城市返回全国榜单
+			city, err := uc.GetHotSearch(ctx, biz.HotSearchWindowDay, 110100, 10)
+			if err != nil {
+				t.Fatalf("GetHotSearch failed: %v", err)
+			}
+			if city.CityID != 110100 {
+				t.Errorf("Expected city 110100, got %d", city.CityID)
+			}
+			assertHotItems(t, "city keywords", city.Keywords, []string{"布洛芬"}, []int64{2})
+			empty, err := uc.GetHotSearch(ctx, biz.HotSearchWindowDay, 440300, 10)
+			if err != nil {
+				t.Fatalf("GetHotSearch failed: %v", err)
+			}
+			if empty.CityID != 0 || len(empty.Keywords) != 3 {
+				t.Errorf("Expected national fallback, got city %d with %v", empty.CityID, hotItemContents(empty.Keywords))
+			}
+
+			if _, err := uc.GetHotSearch(ctx, "month", 0, 10); err == nil {
+				t.Error("Expected unsupported window to fail")
+			}
+		})
+	}
+}
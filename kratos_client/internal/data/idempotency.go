@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+// 幂等记录默认保留时长
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// 幂等记录数据模型 - 对应 mt_idempotency_key 表，未配置Redis时使用
+type MtIdempotencyKey struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Scope       string    `gorm:"column:scope;size:32;not null;uniqueIndex:uk_scope_user_key" json:"scope"`
+	UserID      int64     `gorm:"column:user_id;not null;uniqueIndex:uk_scope_user_key" json:"user_id"`
+	IdemKey     string    `gorm:"column:idem_key;size:64;not null;uniqueIndex:uk_scope_user_key" json:"idem_key"`
+	Fingerprint string    `gorm:"column:fingerprint;size:64;not null" json:"fingerprint"`
+	Status      string    `gorm:"column:status;size:16;not null" json:"status"`
+	ResourceID  string    `gorm:"column:resource_id;size:64" json:"resource_id"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;type:datetime(3);not null;index" json:"expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:datetime(3);not null" json:"created_at"`
+}
+
+// 表名
+func (MtIdempotencyKey) TableName() string {
+	return "mt_idempotency_key"
+}
+
+// 创建幂等记录仓储，配置了Redis时使用Redis，否则使用数据库表
+func NewIdempotencyRepo(c *conf.Data, data *Data, logger log.Logger) biz.IdempotencyRepo {
+	ttl := defaultIdempotencyTTL
+	if c != nil && c.Idempotency != nil && c.Idempotency.Ttl.AsDuration() > 0 {
+		ttl = c.Idempotency.Ttl.AsDuration()
+	}
+
+	if data.RDb != nil {
+		return &redisIdempotencyRepo{data: data, ttl: ttl, log: log.NewHelper(logger)}
+	}
+	return &dbIdempotencyRepo{data: data, ttl: ttl, log: log.NewHelper(logger)}
+}
+
+// 基于Redis的幂等记录仓储
+type redisIdempotencyRepo struct {
+	data *Data
+	ttl  time.Duration
+	log  *log.Helper
+}
+
+func idempotencyRedisKey(scope string, userID int64, key string) string {
+	return fmt.Sprintf("idempotency:%s:%d:%s", scope, userID, key)
+}
+
+// 占用幂等键
+func (r *redisIdempotencyRepo) Acquire(ctx context.Context, record *biz.IdempotencyRecord) (*biz.IdempotencyRecord, error) {
+	redisKey := idempotencyRedisKey(record.Scope, record.UserID, record.Key)
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已占用的键恰好过期时再尝试一次
+	for i := 0; i < 2; i++ {
+		ok, err := r.data.RDb.SetNX(ctx, redisKey, payload, r.ttl).Result()
+		if err != nil {
+			r.log.Errorf("占用幂等键失败: key=%s, error=%v", redisKey, err)
+			return nil, err
+		}
+		if ok {
+			return nil, nil
+		}
+
+		existing, err := r.get(ctx, redisKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("幂等键状态变化频繁，请重试: %s", redisKey)
+}
+
+func (r *redisIdempotencyRepo) get(ctx context.Context, redisKey string) (*biz.IdempotencyRecord, error) {
+	data, err := r.data.RDb.Get(ctx, redisKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		r.log.Errorf("查询幂等记录失败: key=%s, error=%v", redisKey, err)
+		return nil, err
+	}
+
+	var record biz.IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("解析幂等记录失败: %v", err)
+	}
+	return &record, nil
+}
+
+// 标记处理完成
+func (r *redisIdempotencyRepo) Complete(ctx context.Context, scope string, userID int64, key, resourceID string) error {
+	redisKey := idempotencyRedisKey(scope, userID, key)
+	record, err := r.get(ctx, redisKey)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("幂等记录不存在: %s", redisKey)
+	}
+
+	record.Status = biz.IdempotencyCompleted
+	record.ResourceID = resourceID
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return r.data.RDb.Set(ctx, redisKey, payload, r.ttl).Err()
+}
+
+// 释放幂等键
+func (r *redisIdempotencyRepo) Release(ctx context.Context, scope string, userID int64, key string) error {
+	return r.data.RDb.Del(ctx, idempotencyRedisKey(scope, userID, key)).Err()
+}
+
+// 基于数据库的幂等记录仓储
+type dbIdempotencyRepo struct {
+	data *Data
+	ttl  time.Duration
+	log  *log.Helper
+}
+
+func (r *dbIdempotencyRepo) toBizRecord(do *MtIdempotencyKey) *biz.IdempotencyRecord {
+	return &biz.IdempotencyRecord{
+		Scope:       do.Scope,
+		UserID:      do.UserID,
+		Key:         do.IdemKey,
+		Fingerprint: do.Fingerprint,
+		Status:      do.Status,
+		ResourceID:  do.ResourceID,
+		CreatedAt:   do.CreatedAt,
+	}
+}
+
+// 占用幂等键，依赖 (scope, user_id, idem_key) 唯一索引保证并发请求只有一个占用成功
+func (r *dbIdempotencyRepo) Acquire(ctx context.Context, record *biz.IdempotencyRecord) (*biz.IdempotencyRecord, error) {
+	db := r.data.Db.WithContext(ctx)
+	now := time.Now()
+
+	// 清理该键已过期的记录
+	if err := db.Where("scope = ? AND user_id = ? AND idem_key = ? AND expires_at < ?", record.Scope, record.UserID, record.Key, now).
+		Delete(&MtIdempotencyKey{}).Error; err != nil {
+		r.log.Errorf("清理过期幂等记录失败: %v", err)
+		return nil, err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&MtIdempotencyKey{
+		Scope:       record.Scope,
+		UserID:      record.UserID,
+		IdemKey:     record.Key,
+		Fingerprint: record.Fingerprint,
+		Status:      record.Status,
+		ExpiresAt:   now.Add(r.ttl),
+		CreatedAt:   record.CreatedAt,
+	})
+	if result.Error != nil {
+		r.log.Errorf("占用幂等键失败: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing MtIdempotencyKey
+	if err := db.Where("scope = ? AND user_id = ? AND idem_key = ?", record.Scope, record.UserID, record.Key).First(&existing).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("幂等键状态变化频繁，请重试: %s", record.Key)
+		}
+		r.log.Errorf("查询幂等记录失败: %v", err)
+		return nil, err
+	}
+	return r.toBizRecord(&existing), nil
+}
+
+// 标记处理完成
+func (r *dbIdempotencyRepo) Complete(ctx context.Context, scope string, userID int64, key, resourceID string) error {
+	return r.data.Db.WithContext(ctx).Model(&MtIdempotencyKey{}).
+		Where("scope = ? AND user_id = ? AND idem_key = ?", scope, userID, key).
+		Updates(map[string]interface{}{
+			"status":      biz.IdempotencyCompleted,
+			"resource_id": resourceID,
+		}).Error
+}
+
+// 释放幂等键，只删除处理中的记录
+func (r *dbIdempotencyRepo) Release(ctx context.Context, scope string, userID int64, key string) error {
+	return r.data.Db.WithContext(ctx).
+		Where("scope = ? AND user_id = ? AND idem_key = ? AND status = ?", scope, userID, key, biz.IdempotencyProcessing).
+		Delete(&MtIdempotencyKey{}).Error
+}
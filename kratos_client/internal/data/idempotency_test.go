@@ -0,0 +1,118 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+func createKeyedOrder(uc *biz.OrderUsecase, key string, quantity int32) (*biz.MtOrder, error) {
+	return uc.CreateOrder(context.Background(), &biz.CreateOrderRequest{
+		UserID:         1001,
+		UserName:       "测试用户",
+		UserPhone:      "13800138000",
+		AddressID:      1,
+		AddressDetail:  "测试地址",
+		Items:          []*biz.CreateOrderItem{{DrugID: 1, Quantity: quantity}},
+		IdempotencyKey: key,
+	})
+}
+
+func countOrders(t *testing.T, d *Data) int64 {
+	t.Helper()
+	var count int64
+	if err := d.Db.Model(&MtOrder{}).Count(&count).Error; err != nil {
+		t.Fatalf("统计订单失败: %v", err)
+	}
+	return count
+}
+
+// 测试重复的幂等键返回首次创建的订单，且只预留一次库存
+func TestCreateOrderIdempotent(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+
+	first, err := createKeyedOrder(uc, "retry-1", 2)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	second, err := createKeyedOrder(uc, "retry-1", 2)
+	if err != nil {
+		t.Fatalf("Repeated CreateOrder failed: %v", err)
+	}
+	if second.OrderNo != first.OrderNo {
+		t.Errorf("Expected original order %s, got %s", first.OrderNo, second.OrderNo)
+	}
+	if got := countOrders(t, d); got != 1 {
+		t.Errorf("Expected 1 order, got %d", got)
+	}
+	if got := drugInventory(t, d); got != 98 {
+		t.Errorf("Expected inventory 98, got %d", got)
+	}
+
+	// 同一个键用于不同的请求
+	if _, err := createKeyedOrder(uc, "retry-1", 3); !errors.Is(err, biz.ErrIdempotencyKeyReused) {
+		t.Errorf("Expected ErrIdempotencyKeyReused, got %v", err)
+	}
+
+	// 失败的请求释放幂等键
+	if _, err := createKeyedOrder(uc, "retry-2", 1000); err == nil {
+		t.Fatal("Expected insufficient inventory error")
+	}
+	var keys int64
+	d.Db.Model(&MtIdempotencyKey{}).Where("idem_key = ?", "retry-2").Count(&keys)
+	if keys != 0 {
+		t.Errorf("Expected failed key to be released, got %d records", keys)
+	}
+
+	// 过期后同一个键视为新请求
+	d.Db.Model(&MtIdempotencyKey{}).Where("idem_key = ?", "retry-1").Update("expires_at", time.Now().Add(-time.Minute))
+	third, err := createKeyedOrder(uc, "retry-1", 2)
+	if err != nil {
+		t.Fatalf("CreateOrder after expiry failed: %v", err)
+	}
+	if third.OrderNo == first.OrderNo {
+		t.Error("Expected a new order after key expiry")
+	}
+}
+
+// 测试并发重试只创建一个订单
+func TestCreateOrderIdempotentConcurrent(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+
+	const workers = 5
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		orderNos = map[string]bool{}
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			order, err := createKeyedOrder(uc, "concurrent", 1)
+			if err != nil {
+				if !errors.Is(err, biz.ErrIdempotentRequestInProgress) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			mu.Lock()
+			orderNos[order.OrderNo] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(orderNos) != 1 {
+		t.Errorf("Expected all successful retries to share one order, got %v", orderNos)
+	}
+	if got := countOrders(t, d); got != 1 {
+		t.Errorf("Expected 1 order, got %d", got)
+	}
+}
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"kratos_client/internal/biz"
+)
+
+// 药物相互作用数据模型，由后台从CSV导入，drug_a_id 小于 drug_b_id
+type MtDrugInteraction struct {
+	ID          int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	DrugAID     int64     `gorm:"column:drug_a_id;not null;uniqueIndex:uk_drug_interaction_pair"`
+	DrugBID     int64     `gorm:"column:drug_b_id;not null;uniqueIndex:uk_drug_interaction_pair"`
+	Severity    string    `gorm:"column:severity;size:16;not null"`
+	Description string    `gorm:"column:description;size:500"`
+	Advice      string    `gorm:"column:advice;size:500"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (MtDrugInteraction) TableName() string {
+	return "mt_drug_interaction"
+}
+
+// 药品人群禁忌数据模型，由后台从CSV导入
+type MtDrugContraindication struct {
+	ID          int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	DrugID      int64     `gorm:"column:drug_id;not null;uniqueIndex:uk_drug_contraindication"`
+	Population  string    `gorm:"column:population;size:16;not null;uniqueIndex:uk_drug_contraindication"`
+	Level       string    `gorm:"column:level;size:16;not null"`
+	Description string    `gorm:"column:description;size:500"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (MtDrugContraindication) TableName() string {
+	return "mt_drug_contraindication"
+}
+
+type interactionRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建用药知识库仓储
+func NewInteractionRepo(data *Data, logger log.Logger) biz.InteractionRepo {
+	return &interactionRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *interactionRepo) ListInteractions(ctx context.Context, drugIDs []int64) ([]*biz.DrugInteraction, error) {
+	var rows []*MtDrugInteraction
+	err := r.data.Db.WithContext(ctx).
+		Where("drug_a_id IN ? AND drug_b_id IN ?", drugIDs, drugIDs).
+		Order("drug_a_id, drug_b_id").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Errorf("查询药物相互作用失败: %v", err)
+		return nil, err
+	}
+	result := make([]*biz.DrugInteraction, len(rows))
+	for i, row := range rows {
+		result[i] = &biz.DrugInteraction{
+			ID:          row.ID,
+			DrugAID:     row.DrugAID,
+			DrugBID:     row.DrugBID,
+			Severity:    row.Severity,
+			Description: row.Description,
+			Advice:      row.Advice,
+		}
+	}
+	return result, nil
+}
+
+func (r *interactionRepo) ListContraindications(ctx context.Context, drugIDs []int64, populations []string) ([]*biz.DrugContraindication, error) {
+	var rows []*MtDrugContraindication
+	err := r.data.Db.WithContext(ctx).
+		Where("drug_id IN ? AND population IN ?", drugIDs, populations).
+		Order("drug_id, population").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Errorf("查询用药禁忌失败: %v", err)
+		return nil, err
+	}
+	result := make([]*biz.DrugContraindication, len(rows))
+	for i, row := range rows {
+		result[i] = &biz.DrugContraindication{
+			ID:          row.ID,
+			DrugID:      row.DrugID,
+			Population:  row.Population,
+			Level:       row.Level,
+			Description: row.Description,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,173 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"kratos_client/internal/biz"
+)
+
+func newTestInteractionUsecase(d *Data) *biz.InteractionUsecase {
+	logger := newTestLogger()
+	return biz.NewInteractionUsecase(NewInteractionRepo(d, logger), NewDrugRepo(d, logger), logger)
+}
+
+// 在下单测试数据上增加两个药品和用药知识库：
+// 感冒灵颗粒与阿司匹林中度相互作用，阿司匹林与布洛芬严重相互作用，孕妇禁用布洛芬，儿童慎用感冒灵颗粒
+func newInteractionTestData(t *testing.T, d *Data) {
+	t.Helper()
+	for _, drug := range []*biz.MtDrug{
+		{Id: 2, DrugName: "阿司匹林肠溶片", DrugStore: 1, Price: 8, Inventory: 100},
+		{Id: 3, DrugName: "布洛芬缓释胶囊", DrugStore: 1, Price: 20, Inventory: 100},
+	} {
+		if err := d.Db.Create(drug).Error; err != nil {
+			t.Fatalf("创建测试药品失败: %v", err)
+		}
+		if err := d.Db.Create(&biz.MtDrugInventory{DrugID: int64(drug.Id), DrugStoreID: 1, Quantity: 100, Price: float64(drug.Price)}).Error; err != nil {
+			t.Fatalf("创建测试库存失败: %v", err)
+		}
+	}
+	interactions := []*MtDrugInteraction{
+		{DrugAID: 1, DrugBID: 2, Severity: biz.InteractionSeverityModerate, Description: "增加胃肠道出血风险", Advice: "间隔2小时服用"},
+		{DrugAID: 2, DrugBID: 3, Severity: biz.InteractionSeverityMajor, Description: "降低阿司匹林抗血小板作用"},
+	}
+	if err := d.Db.Create(&interactions).Error; err != nil {
+		t.Fatalf("创建相互作用失败: %v", err)
+	}
+	contraindications := []*MtDrugContraindication{
+		{DrugID: 3, Population: biz.PopulationPregnancy, Level: biz.ContraindicationForbidden, Description: "妊娠晚期禁用"},
+		{DrugID: 1, Population: biz.PopulationChild, Level: biz.ContraindicationCaution, Description: "儿童用量请咨询医师"},
+	}
+	if err := d.Db.Create(&contraindications).Error; err != nil {
+		t.Fatalf("创建用药禁忌失败: %v", err)
+	}
+}
+
+func TestDrugInteractionCheck(t *testing.T) {
+	d := newOrderTestData(t)
+	newInteractionTestData(t, d)
+	uc := newTestInteractionUsecase(d)
+	ctx := context.Background()
+
+	result, err := uc.Check(ctx, []int64{2, 1, 1}, nil)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Blocked || len(result.Warnings) != 1 {
+		t.Fatalf("Expected one non-blocking warning, got %+v", result)
+	}
+	warning := result.Warnings[0]
+	if warning.Type != biz.DrugWarningInteraction || warning.Level != biz.InteractionSeverityModerate ||
+		len(warning.DrugNames) != 2 || warning.DrugNames[0] != "感冒灵颗粒" || warning.DrugNames[1] != "阿司匹林肠溶片" {
+		t.Errorf("Unexpected warning %+v", warning)
+	}
+	if result.Err() != nil {
+		t.Errorf("Expected no error for non-blocking result, got %v", result.Err())
+	}
+
+	result, err = uc.Check(ctx, []int64{3, 2, 1}, []string{biz.PopulationChild, " child "})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !result.Blocked || len(result.Warnings) != 3 {
+		t.Fatalf("Expected three warnings with block, got %+v", result)
+	}
+	if !result.Warnings[0].Blocking || result.Warnings[0].Level != biz.InteractionSeverityMajor {
+		t.Errorf("Expected blocking warning first, got %+v", result.Warnings[0])
+	}
+	if !errors.Is(result.Err(), biz.ErrDrugCheckBlocked) {
+		t.Errorf("Expected ErrDrugCheckBlocked, got %v", result.Err())
+	}
+
+	result, err = uc.Check(ctx, []int64{3}, []string{biz.PopulationPregnancy})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !result.Blocked || len(result.Warnings) != 1 || result.Warnings[0].Type != biz.DrugWarningContraindication {
+		t.Errorf("Expected blocking contraindication, got %+v", result)
+	}
+
+	if _, err := uc.Check(ctx, []int64{1}, []string{"unknown"}); err == nil {
+		t.Error("Expected error for unknown population")
+	}
+}
+
+// 严重相互作用拦截下单且不预留库存，中度相互作用随订单返回提示
+func TestCreateOrderDrugCheck(t *testing.T) {
+	d := newOrderTestData(t)
+	newInteractionTestData(t, d)
+	uc := newTestOrderUsecase(d)
+	ctx := context.Background()
+
+	req := func(populations []string, drugIDs ...int64) *biz.CreateOrderRequest {
+		items := make([]*biz.CreateOrderItem, len(drugIDs))
+		for i, id := range drugIDs {
+			items[i] = &biz.CreateOrderItem{DrugID: id, Quantity: 1}
+		}
+		return &biz.CreateOrderRequest{UserID: 1001, UserName: "测试用户", UserPhone: "13800138000", AddressID: 1,
+			AddressDetail: "测试地址", Items: items, Populations: populations}
+	}
+	if _, err := uc.CreateOrder(ctx, req(nil, 2, 3)); !errors.Is(err, biz.ErrDrugCheckBlocked) {
+		t.Errorf("Expected ErrDrugCheckBlocked for major interaction, got %v", err)
+	}
+	if _, err := uc.CreateOrder(ctx, req([]string{biz.PopulationPregnancy}, 3)); !errors.Is(err, biz.ErrDrugCheckBlocked) {
+		t.Errorf("Expected ErrDrugCheckBlocked for pregnancy, got %v", err)
+	}
+	var reserved int64
+	d.Db.Model(&biz.MtDrugInventory{}).Select("COALESCE(SUM(reserved_qty), 0)").Scan(&reserved)
+	if reserved != 0 {
+		t.Errorf("Expected no reserved inventory, got %d", reserved)
+	}
+
+	order, err := uc.CreateOrder(ctx, req(nil, 1, 2))
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if len(order.DrugWarnings) != 1 || order.DrugWarnings[0].Blocking {
+		t.Errorf("Expected one non-blocking warning, got %+v", order.DrugWarnings)
+	}
+}
+
+// 开具时拦截对患者人群禁用的药品；知识库更新后审核通过前重新检查
+func TestPrescriptionDrugCheck(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	newInteractionTestData(t, env.d)
+	ctx := context.Background()
+
+	_, err := env.uc.IssuePrescription(ctx, &biz.IssuePrescriptionRequest{
+		DoctorID:       testDoctorID,
+		ConsultationNo: env.consultation.ConsultationNo,
+		Medicines:      []*biz.IssuePrescriptionMedicine{{MedicineID: 3, Quantity: 1, Unit: "盒"}},
+		Populations:    []string{biz.PopulationPregnancy},
+	})
+	if !errors.Is(err, biz.ErrDrugCheckBlocked) {
+		t.Errorf("Expected ErrDrugCheckBlocked, got %v", err)
+	}
+
+	detail, err := env.uc.IssuePrescription(ctx, &biz.IssuePrescriptionRequest{
+		DoctorID:       testDoctorID,
+		ConsultationNo: env.consultation.ConsultationNo,
+		Medicines:      []*biz.IssuePrescriptionMedicine{{MedicineID: 1, Quantity: 1, Unit: "盒"}},
+		Populations:    []string{biz.PopulationChild},
+	})
+	if err != nil {
+		t.Fatalf("IssuePrescription failed: %v", err)
+	}
+	if len(detail.DrugWarnings) != 1 || detail.DrugWarnings[0].Level != biz.ContraindicationCaution {
+		t.Errorf("Expected caution warning, got %+v", detail.DrugWarnings)
+	}
+	prescription, err := env.repo.GetPrescriptionByID(ctx, detail.Prescription.ID)
+	if err != nil || len(prescription.Populations) != 1 || prescription.Populations[0] != biz.PopulationChild {
+		t.Fatalf("Expected stored populations, got %+v, %v", prescription, err)
+	}
+
+	env.d.Db.Model(&MtDrugContraindication{}).Where("drug_id = ? AND population = ?", 1, biz.PopulationChild).
+		Update("level", biz.ContraindicationForbidden)
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, true, ""); !errors.Is(err, biz.ErrDrugCheckBlocked) {
+		t.Errorf("Expected ErrDrugCheckBlocked on approval, got %v", err)
+	}
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, false, "儿童禁用"); err != nil {
+		t.Errorf("Expected rejection to succeed, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"gorm.io/gorm"
@@ -10,6 +11,9 @@ import (
 )
 
 // 库存仓储实现
+// mt_drug_inventory 按药店维护在库数量(quantity)和已预留数量(reserved_qty)，
+// 可售库存为两者之差；每次变动都追加一条 mt_stock_movement 流水，
+// 并把可售库存汇总回写到 mt_drug.inventory，供购物车和列表展示使用
 type drugInventoryRepo struct {
 	data *Data
 	log  *log.Helper
@@ -31,22 +35,123 @@ func (r *drugInventoryRepo) getDB(ctx context.Context) *gorm.DB {
 	return r.data.Db
 }
 
-// 检查库存
-func (r *drugInventoryRepo) CheckInventory(ctx context.Context, drugID int64, quantity int32) (bool, error) {
+// 在事务中执行，已处于事务中时直接复用
+func (r *drugInventoryRepo) inTx(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return fn(tx)
+	}
+	return r.data.Db.WithContext(ctx).Transaction(fn)
+}
+
+// 查询药品所属药店
+func (r *drugInventoryRepo) getDrugStoreID(db *gorm.DB, drugID int64) (int32, error) {
 	var drug biz.MtDrug
-	db := r.getDB(ctx)
-	result := db.Where("id = ?", drugID).First(&drug)
+	result := db.Select("id", "drug_store").Where("id = ?", drugID).First(&drug)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return 0, fmt.Errorf("药品不存在: drugID=%d", drugID)
+		}
+		r.log.Errorf("查询药品信息失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return int32(drug.DrugStore), nil
+}
+
+// 查询药店库存记录，不存在时返回nil
+func (r *drugInventoryRepo) findInventory(db *gorm.DB, drugID int64, drugStoreID int32) (*biz.MtDrugInventory, error) {
+	var inventory biz.MtDrugInventory
+	result := db.Where("drug_id = ? AND drug_store_id = ?", drugID, drugStoreID).First(&inventory)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return false, fmt.Errorf("药品不存在: drugID=%d", drugID)
+			return nil, nil
 		}
-		r.log.Errorf("查询药品库存失败: %v", result.Error)
-		return false, result.Error
+		return nil, result.Error
+	}
+	return &inventory, nil
+}
+
+// 按条件变动库存并记录流水，guard 不满足时返回 ErrInsufficientInventory
+func (r *drugInventoryRepo) move(db *gorm.DB, drugID int64, drugStoreID int32, movementType string,
+	quantityDiff, reservedDiff int64, guard string, guardArgs []interface{}, refNo, remark string) (*biz.MtDrugInventory, error) {
+	now := time.Now()
+
+	query := db.Model(&biz.MtDrugInventory{}).
+		Where("drug_id = ? AND drug_store_id = ?", drugID, drugStoreID)
+	if guard != "" {
+		query = query.Where(guard, guardArgs...)
+	}
+	result := query.Updates(map[string]interface{}{
+		"quantity":     gorm.Expr("quantity + ?", quantityDiff),
+		"reserved_qty": gorm.Expr("reserved_qty + ?", reservedDiff),
+		"updated_at":   now,
+	})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: drugID=%d, storeID=%d, type=%s", biz.ErrInsufficientInventory, drugID, drugStoreID, movementType)
+	}
+
+	inventory, err := r.findInventory(db, drugID, drugStoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &biz.MtStockMovement{
+		DrugID:        drugID,
+		DrugStoreID:   drugStoreID,
+		MovementType:  movementType,
+		QuantityDiff:  quantityDiff,
+		ReservedDiff:  reservedDiff,
+		QuantityAfter: inventory.Quantity,
+		ReservedAfter: inventory.ReservedQty,
+		RefNo:         refNo,
+		Remark:        remark,
+		CreatedAt:     now,
+	}
+	if err := db.Create(movement).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.syncDrugInventory(db, drugID); err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// 将各药店可售库存汇总回写到药品表，并登记搜索索引同步
+func (r *drugInventoryRepo) syncDrugInventory(db *gorm.DB, drugID int64) error {
+	err := db.Model(&biz.MtDrug{}).
+		Where("id = ?", drugID).
+		UpdateColumn("inventory", gorm.Expr(
+			"(SELECT COALESCE(SUM(quantity - reserved_qty), 0) FROM mt_drug_inventory WHERE drug_id = ?)", drugID)).Error
+	if err != nil {
+		return err
+	}
+	return enqueueDrugIndexTask(db, drugID)
+}
+
+// 检查库存
+func (r *drugInventoryRepo) CheckInventory(ctx context.Context, drugID int64, quantity int32) (bool, error) {
+	db := r.getDB(ctx)
+	storeID, err := r.getDrugStoreID(db, drugID)
+	if err != nil {
+		return false, err
 	}
 
-	// 检查库存是否充足
-	available := int32(drug.Inventory)
-	if available < quantity {
+	inventory, err := r.findInventory(db, drugID, storeID)
+	if err != nil {
+		r.log.Errorf("查询药品库存失败: %v", err)
+		return false, err
+	}
+	if inventory == nil {
+		r.log.Warnf("药店库存记录不存在: drugID=%d, storeID=%d", drugID, storeID)
+		return false, nil
+	}
+
+	// 检查可售库存是否充足
+	available := inventory.Available()
+	if available < int64(quantity) {
 		r.log.Warnf("库存不足: drugID=%d, available=%d, required=%d", drugID, available, quantity)
 		return false, nil
 	}
@@ -54,52 +159,164 @@ func (r *drugInventoryRepo) CheckInventory(ctx context.Context, drugID int64, qu
 	return true, nil
 }
 
-// 预留库存
-func (r *drugInventoryRepo) ReserveInventory(ctx context.Context, drugID int64, quantity int32) error {
-	// 使用乐观锁更新库存
-	db := r.getDB(ctx)
-	result := db.Model(&biz.MtDrug{}).
-		Where("id = ? AND inventory >= ?", drugID, quantity).
-		Update("inventory", gorm.Expr("inventory - ?", quantity))
-
-	if result.Error != nil {
-		r.log.Errorf("预留库存失败: %v", result.Error)
-		return result.Error
+// 预留、出库和释放的数量必须为正数，负数会绕过库存条件反向修改库存
+func checkMoveQuantity(drugID int64, quantity int32) error {
+	if quantity <= 0 {
+		return fmt.Errorf("%w: drugID=%d, quantity=%d", biz.ErrInvalidStockQuantity, drugID, quantity)
 	}
+	return nil
+}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("库存不足或药品不存在: drugID=%d, quantity=%d", drugID, quantity)
+// 预留库存（下单时调用）
+func (r *drugInventoryRepo) ReserveInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error {
+	if err := checkMoveQuantity(drugID, quantity); err != nil {
+		return err
+	}
+	qty := int64(quantity)
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		storeID, err := r.getDrugStoreID(db, drugID)
+		if err != nil {
+			return err
+		}
+		_, err = r.move(db, drugID, storeID, biz.StockMovementReserve, 0, qty,
+			"quantity - reserved_qty >= ?", []interface{}{qty}, refNo, "下单预留")
+		return err
+	})
+	if err != nil {
+		r.log.Errorf("预留库存失败: drugID=%d, quantity=%d, error=%v", drugID, quantity, err)
+		return err
 	}
 
-	r.log.Infof("预留库存成功: drugID=%d, quantity=%d", drugID, quantity)
+	r.log.Infof("预留库存成功: drugID=%d, quantity=%d, refNo=%s", drugID, quantity, refNo)
 	return nil
 }
 
-// 减少库存（支付成功后调用）
-func (r *drugInventoryRepo) ReduceInventory(ctx context.Context, drugID int64, quantity int32) error {
-	// 由于在预留时已经减少了库存，这里只需要记录日志
-	// 在实际生产环境中，可能需要更复杂的库存管理逻辑
-	r.log.Infof("确认减少库存: drugID=%d, quantity=%d", drugID, quantity)
+// 减少库存（支付成功后调用），预留转为实际出库
+func (r *drugInventoryRepo) ReduceInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error {
+	if err := checkMoveQuantity(drugID, quantity); err != nil {
+		return err
+	}
+	qty := int64(quantity)
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		storeID, err := r.getDrugStoreID(db, drugID)
+		if err != nil {
+			return err
+		}
+		_, err = r.move(db, drugID, storeID, biz.StockMovementCommit, -qty, -qty,
+			"reserved_qty >= ? AND quantity >= ?", []interface{}{qty, qty}, refNo, "支付出库")
+		return err
+	})
+	if err != nil {
+		r.log.Errorf("确认出库失败: drugID=%d, quantity=%d, error=%v", drugID, quantity, err)
+		return err
+	}
+
+	r.log.Infof("确认出库成功: drugID=%d, quantity=%d, refNo=%s", drugID, quantity, refNo)
 	return nil
 }
 
 // 释放预留库存（取消订单时调用）
-func (r *drugInventoryRepo) ReleaseReservedInventory(ctx context.Context, drugID int64, quantity int32) error {
-	// 恢复库存
-	db := r.getDB(ctx)
-	result := db.Model(&biz.MtDrug{}).
-		Where("id = ?", drugID).
-		Update("inventory", gorm.Expr("inventory + ?", quantity))
-
-	if result.Error != nil {
-		r.log.Errorf("释放预留库存失败: %v", result.Error)
-		return result.Error
+func (r *drugInventoryRepo) ReleaseReservedInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error {
+	if err := checkMoveQuantity(drugID, quantity); err != nil {
+		return err
+	}
+	qty := int64(quantity)
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		storeID, err := r.getDrugStoreID(db, drugID)
+		if err != nil {
+			return err
+		}
+		_, err = r.move(db, drugID, storeID, biz.StockMovementRelease, 0, -qty,
+			"reserved_qty >= ?", []interface{}{qty}, refNo, "取消释放")
+		return err
+	})
+	if err != nil {
+		r.log.Errorf("释放预留库存失败: drugID=%d, quantity=%d, error=%v", drugID, quantity, err)
+		return err
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("药品不存在: drugID=%d", drugID)
+	r.log.Infof("释放预留库存成功: drugID=%d, quantity=%d, refNo=%s", drugID, quantity, refNo)
+	return nil
+}
+
+// 退款入库，退回的药品重新计入在库数量
+func (r *drugInventoryRepo) RestockInventory(ctx context.Context, drugID int64, quantity int32, refNo string) error {
+	qty := int64(quantity)
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		storeID, err := r.getDrugStoreID(db, drugID)
+		if err != nil {
+			return err
+		}
+		_, err = r.move(db, drugID, storeID, biz.StockMovementRestock, qty, 0, "", nil, refNo, "退款入库")
+		return err
+	})
+	if err != nil {
+		r.log.Errorf("退款入库失败: drugID=%d, quantity=%d, error=%v", drugID, quantity, err)
+		return err
 	}
 
-	r.log.Infof("释放预留库存成功: drugID=%d, quantity=%d", drugID, quantity)
+	r.log.Infof("退款入库成功: drugID=%d, quantity=%d, refNo=%s", drugID, quantity, refNo)
 	return nil
-}
\ No newline at end of file
+}
+
+// 查询药店库存
+func (r *drugInventoryRepo) GetInventory(ctx context.Context, drugID int64, drugStoreID int32) (*biz.MtDrugInventory, error) {
+	inventory, err := r.findInventory(r.getDB(ctx), drugID, drugStoreID)
+	if err != nil {
+		r.log.Errorf("查询药店库存失败: %v", err)
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// 查询药品在各药店的库存
+func (r *drugInventoryRepo) ListInventoriesByDrug(ctx context.Context, drugID int64) ([]*biz.MtDrugInventory, error) {
+	var inventories []*biz.MtDrugInventory
+	if err := r.getDB(ctx).Where("drug_id = ?", drugID).Find(&inventories).Error; err != nil {
+		r.log.Errorf("查询药品库存列表失败: %v", err)
+		return nil, err
+	}
+	return inventories, nil
+}
+
+// 盘点调整在库数量，记录不存在时新建
+func (r *drugInventoryRepo) AdjustInventory(ctx context.Context, drugID int64, drugStoreID int32, quantity int64, remark string) (*biz.MtDrugInventory, error) {
+	var inventory *biz.MtDrugInventory
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		current, err := r.findInventory(db, drugID, drugStoreID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			var drug biz.MtDrug
+			if err := db.Where("id = ?", drugID).First(&drug).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return fmt.Errorf("药品不存在: drugID=%d", drugID)
+				}
+				return err
+			}
+			current = &biz.MtDrugInventory{
+				DrugID:      drugID,
+				DrugStoreID: drugStoreID,
+				Price:       float64(drug.Price),
+			}
+			if err := db.Create(current).Error; err != nil {
+				return err
+			}
+		}
+
+		// 以当前值为条件更新，避免与并发的预留/出库互相覆盖
+		diff := quantity - current.Quantity
+		inventory, err = r.move(db, drugID, drugStoreID, biz.StockMovementAdjust, diff, 0,
+			"quantity = ? AND reserved_qty <= ?", []interface{}{current.Quantity, quantity}, "", remark)
+		if err != nil {
+			return fmt.Errorf("库存已变动或低于已预留数量，请重试: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("调整库存失败: drugID=%d, storeID=%d, error=%v", drugID, drugStoreID, err)
+		return nil, err
+	}
+	return inventory, nil
+}
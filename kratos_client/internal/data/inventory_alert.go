@@ -0,0 +1,234 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+// 库存告警仓储实现
+type inventoryAlertRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建库存告警仓储
+func NewInventoryAlertRepo(data *Data, logger log.Logger) biz.InventoryAlertRepo {
+	return &inventoryAlertRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 切换库存状态并写入告警
+func (r *inventoryAlertRepo) ChangeInventoryStatus(ctx context.Context, inventoryID int64, from, to biz.InventoryStatus, alert *biz.MtInventoryAlert) (bool, error) {
+	changed := false
+	err := r.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&biz.MtDrugInventory{}).
+			Where("id = ? AND status = ?", inventoryID, from).
+			Update("status", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		changed = true
+
+		if alert != nil {
+			return tx.Create(alert).Error
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("切换库存状态失败: inventoryID=%d, %d -> %d, error=%v", inventoryID, from, to, err)
+		return false, err
+	}
+	return changed, nil
+}
+
+// 库存告警通知渠道名称
+const (
+	alertSinkLog     = "log"
+	alertSinkWebhook = "webhook"
+	alertSinkEmail   = "email"
+)
+
+// 根据配置组装告警通知渠道，未配置时仅写日志
+func NewInventoryAlertSink(c *conf.Alert, logger log.Logger) biz.InventoryAlertSink {
+	helper := log.NewHelper(logger)
+	names := []string{alertSinkLog}
+	if c != nil && len(c.Sinks) > 0 {
+		names = c.Sinks
+	}
+
+	var sinks multiAlertSink
+	for _, name := range names {
+		switch name {
+		case alertSinkLog:
+			sinks = append(sinks, &logAlertSink{log: helper})
+		case alertSinkWebhook:
+			if c.Webhook == nil || c.Webhook.Url == "" {
+				helper.Warn("库存告警webhook未配置url，已跳过")
+				continue
+			}
+			sinks = append(sinks, &webhookAlertSink{
+				url:    c.Webhook.Url,
+				client: &http.Client{Timeout: alertTimeout(c.Webhook.Timeout.AsDuration())},
+			})
+		case alertSinkEmail:
+			if c.Email == nil || c.Email.Endpoint == "" || len(c.Email.To) == 0 {
+				helper.Warn("库存告警邮件未配置endpoint或收件人，已跳过")
+				continue
+			}
+			sinks = append(sinks, &emailAlertSink{
+				endpoint: strings.TrimRight(c.Email.Endpoint, "/"),
+				token:    c.Email.Token,
+				to:       strings.Join(c.Email.To, ","),
+				client:   &http.Client{Timeout: alertTimeout(c.Email.Timeout.AsDuration())},
+			})
+		default:
+			helper.Warnf("未知的库存告警渠道: %s", name)
+		}
+	}
+	return sinks
+}
+
+func alertTimeout(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 5 * time.Second
+	}
+	return d
+}
+
+// 依次通知所有渠道，单个渠道失败不影响其他渠道
+type multiAlertSink []biz.InventoryAlertSink
+
+func (s multiAlertSink) Notify(ctx context.Context, alert *biz.MtInventoryAlert) error {
+	var errs []string
+	for _, sink := range s {
+		if err := sink.Notify(ctx, alert); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("部分告警渠道发送失败: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// 日志渠道
+type logAlertSink struct {
+	log *log.Helper
+}
+
+func (s *logAlertSink) Notify(ctx context.Context, alert *biz.MtInventoryAlert) error {
+	s.log.WithContext(ctx).Warnf("[库存告警] %s", alertText(alert))
+	return nil
+}
+
+// Webhook渠道，POST告警JSON
+type webhookAlertSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookAlertSink) Notify(ctx context.Context, alert *biz.MtInventoryAlert) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event": "inventory_alert",
+		"level": biz.InventoryStatusText(alert.Level),
+		"text":  alertText(alert),
+		"alert": alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook返回异常状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// 邮件渠道，复用后台邮件插件的 /email/sendEmail 接口
+type emailAlertSink struct {
+	endpoint string
+	to       string
+	client   *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+func (s *emailAlertSink) Notify(ctx context.Context, alert *biz.MtInventoryAlert) error {
+	body, err := json.Marshal(map[string]string{
+		"to":      s.to,
+		"subject": fmt.Sprintf("【库存告警】%s %s", alert.DrugName, biz.InventoryStatusText(alert.Level)),
+		"body":    alertText(alert),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/email/sendEmail", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.mu.Lock()
+	req.Header.Set("x-token", s.token)
+	s.mu.Unlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("邮件接口请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// 后台临近过期时会在响应头下发续期后的token
+	if newToken := resp.Header.Get("new-token"); newToken != "" {
+		s.mu.Lock()
+		s.token = newToken
+		s.mu.Unlock()
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("解析邮件接口响应失败: status=%d, error=%v", resp.StatusCode, err)
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("邮件发送失败: %s", result.Msg)
+	}
+	return nil
+}
+
+// 告警文本
+func alertText(alert *biz.MtInventoryAlert) string {
+	return fmt.Sprintf("药品[%d]%s 在药店[%d]%s：可售数量%d，预警阈值%d，告警时间%s",
+		alert.DrugID, alert.DrugName, alert.DrugStoreID, biz.InventoryStatusText(alert.Level),
+		alert.AvailableQty, alert.AlertThreshold, alert.CreatedAt.Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+// 记录收到的告警
+type recordingAlertSink struct {
+	mu     sync.Mutex
+	alerts []*biz.MtInventoryAlert
+}
+
+func (s *recordingAlertSink) Notify(ctx context.Context, alert *biz.MtInventoryAlert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = append(s.alerts, alert)
+	return nil
+}
+
+func inventoryStatus(t *testing.T, d *Data) biz.InventoryStatus {
+	t.Helper()
+	var inventory biz.MtDrugInventory
+	if err := d.Db.Where("drug_id = ? AND drug_store_id = ?", 1, 1).First(&inventory).Error; err != nil {
+		t.Fatalf("查询药店库存失败: %v", err)
+	}
+	return inventory.Status
+}
+
+// 测试跌破阈值和缺货各告警一次，库存恢复后状态回落
+func TestStockAlerts(t *testing.T) {
+	d := newOrderTestData(t)
+	logger := newTestLogger()
+	sink := &recordingAlertSink{}
+	inventoryRepo := NewDrugInventoryRepo(d, logger)
+	inventoryUc := biz.NewInventoryUsecase(inventoryRepo, NewDrugRepo(d, logger), NewInventoryAlertRepo(d, logger), sink, logger)
+	couponUc := biz.NewCouponUsecase(NewCouponRepo(d, logger), NewDrugRepo(d, logger), logger)
+	idempotencyUc := biz.NewIdempotencyUsecase(NewIdempotencyRepo(nil, d, logger), logger)
+	paymentUc := biz.NewPaymentUsecase(NewPaymentRepo(d, logger), idempotencyUc, NewPaymentGateways(&conf.Payment{}, logger), logger)
+	uc := biz.NewOrderUsecase(NewOrderRepo(d, logger), NewDrugRepo(d, logger), inventoryRepo, inventoryUc, couponUc, idempotencyUc,
+		NewPrescriptionRepo(d, logger), newTestInteractionUsecase(d), paymentUc, logger)
+	ctx := context.Background()
+
+	// 可售100，阈值10：预留92后剩8触发库存不足
+	big := createTestOrder(t, uc, 92)
+	if len(sink.alerts) != 1 || sink.alerts[0].Level != biz.InventoryStatusLowStock || sink.alerts[0].AvailableQty != 8 {
+		t.Fatalf("Expected one low-stock alert with available 8, got %+v", sink.alerts)
+	}
+	if got := inventoryStatus(t, d); got != biz.InventoryStatusLowStock {
+		t.Errorf("Expected inventory status low-stock, got %d", got)
+	}
+
+	// 仍处于库存不足，不重复告警
+	createTestOrder(t, uc, 3)
+	if len(sink.alerts) != 1 {
+		t.Fatalf("Expected no repeated alert, got %d alerts", len(sink.alerts))
+	}
+
+	// 售罄触发缺货告警
+	createTestOrder(t, uc, 5)
+	if len(sink.alerts) != 2 || sink.alerts[1].Level != biz.InventoryStatusOutOfStock {
+		t.Fatalf("Expected out-of-stock alert, got %+v", sink.alerts)
+	}
+
+	// 取消大单释放库存，状态回落且不产生告警
+	if err := uc.CancelOrder(ctx, big.OrderNo, biz.SystemOperator, "测试取消"); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	if got := inventoryStatus(t, d); got != biz.InventoryStatusNormal {
+		t.Errorf("Expected inventory status normal after release, got %d", got)
+	}
+	if len(sink.alerts) != 2 {
+		t.Errorf("Expected no alert on recovery, got %d alerts", len(sink.alerts))
+	}
+
+	var alerts []biz.MtInventoryAlert
+	d.Db.Find(&alerts)
+	if len(alerts) != 2 {
+		t.Errorf("Expected 2 alert rows, got %d", len(alerts))
+	}
+	for _, alert := range alerts {
+		if alert.Status != biz.InventoryAlertOpen || alert.DrugName != "感冒灵颗粒" {
+			t.Errorf("Unexpected alert row: %+v", alert)
+		}
+	}
+}
+
+// 测试邮件渠道调用后台邮件接口并跟随续期token
+func TestEmailAlertSink(t *testing.T) {
+	var (
+		gotTokens []string
+		gotBody   map[string]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/email/sendEmail" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		gotTokens = append(gotTokens, r.Header.Get("x-token"))
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("new-token", "renewed")
+		w.Write([]byte(`{"code":0,"data":{},"msg":"发送成功"}`))
+	}))
+	defer server.Close()
+
+	sink := NewInventoryAlertSink(&conf.Alert{
+		Sinks: []string{"email"},
+		Email: &conf.Alert_Email{Endpoint: server.URL + "/", Token: "initial", To: []string{"a@example.com", "b@example.com"}},
+	}, newTestLogger())
+	alert := &biz.MtInventoryAlert{DrugID: 1, DrugName: "感冒灵颗粒", DrugStoreID: 1, Level: biz.InventoryStatusLowStock, AvailableQty: 8, AlertThreshold: 10, CreatedAt: time.Now()}
+
+	for i := 0; i < 2; i++ {
+		if err := sink.Notify(context.Background(), alert); err != nil {
+			t.Fatalf("Notify failed: %v", err)
+		}
+	}
+	if len(gotTokens) != 2 || gotTokens[0] != "initial" || gotTokens[1] != "renewed" {
+		t.Errorf("Expected tokens [initial renewed], got %v", gotTokens)
+	}
+	if gotBody["to"] != "a@example.com,b@example.com" || gotBody["subject"] == "" {
+		t.Errorf("Unexpected email payload: %v", gotBody)
+	}
+}
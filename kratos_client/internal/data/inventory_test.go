@@ -0,0 +1,171 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"kratos_client/internal/biz"
+)
+
+func getStoreInventory(t *testing.T, repo biz.DrugInventoryRepo) *biz.MtDrugInventory {
+	t.Helper()
+	inventory, err := repo.GetInventory(context.Background(), 1, 1)
+	if err != nil || inventory == nil {
+		t.Fatalf("查询药店库存失败: %v", err)
+	}
+	return inventory
+}
+
+// 测试预留、出库、释放分别作用于预留数量和在库数量，并逐笔记录流水
+func TestInventoryReserveCommitRelease(t *testing.T) {
+	d := newOrderTestData(t)
+	repo := NewDrugInventoryRepo(d, newTestLogger())
+	ctx := context.Background()
+
+	if err := repo.ReserveInventory(ctx, 1, 10, "ORD_A"); err != nil {
+		t.Fatalf("ReserveInventory failed: %v", err)
+	}
+	if err := repo.ReserveInventory(ctx, 1, 5, "ORD_B"); err != nil {
+		t.Fatalf("ReserveInventory failed: %v", err)
+	}
+	inv := getStoreInventory(t, repo)
+	if inv.Quantity != 100 || inv.ReservedQty != 15 || inv.Available() != 85 {
+		t.Fatalf("After reserve expected on-hand 100/reserved 15/available 85, got %d/%d/%d",
+			inv.Quantity, inv.ReservedQty, inv.Available())
+	}
+
+	if err := repo.ReduceInventory(ctx, 1, 10, "ORD_A"); err != nil {
+		t.Fatalf("ReduceInventory failed: %v", err)
+	}
+	if err := repo.ReleaseReservedInventory(ctx, 1, 5, "ORD_B"); err != nil {
+		t.Fatalf("ReleaseReservedInventory failed: %v", err)
+	}
+	inv = getStoreInventory(t, repo)
+	if inv.Quantity != 90 || inv.ReservedQty != 0 || inv.Available() != 90 {
+		t.Fatalf("After commit/release expected on-hand 90/reserved 0/available 90, got %d/%d/%d",
+			inv.Quantity, inv.ReservedQty, inv.Available())
+	}
+	if got := drugInventory(t, d); got != 90 {
+		t.Errorf("Expected mt_drug.inventory synced to 90, got %d", got)
+	}
+
+	var movements []biz.MtStockMovement
+	if err := d.Db.Order("id ASC").Find(&movements).Error; err != nil {
+		t.Fatalf("查询库存流水失败: %v", err)
+	}
+	expected := []struct {
+		movementType  string
+		refNo         string
+		quantityAfter int64
+		reservedAfter int64
+	}{
+		{biz.StockMovementReserve, "ORD_A", 100, 10},
+		{biz.StockMovementReserve, "ORD_B", 100, 15},
+		{biz.StockMovementCommit, "ORD_A", 90, 5},
+		{biz.StockMovementRelease, "ORD_B", 90, 0},
+	}
+	if len(movements) != len(expected) {
+		t.Fatalf("Expected %d movements, got %d", len(expected), len(movements))
+	}
+	for i, want := range expected {
+		got := movements[i]
+		if got.MovementType != want.movementType || got.RefNo != want.refNo ||
+			got.QuantityAfter != want.quantityAfter || got.ReservedAfter != want.reservedAfter {
+			t.Errorf("Movement %d: expected %+v, got %s/%s/%d/%d", i, want,
+				got.MovementType, got.RefNo, got.QuantityAfter, got.ReservedAfter)
+		}
+	}
+}
+
+// 测试可售不足时预留失败，且不产生流水
+func TestInventoryReserveInsufficient(t *testing.T) {
+	d := newOrderTestData(t)
+	repo := NewDrugInventoryRepo(d, newTestLogger())
+	ctx := context.Background()
+
+	if err := repo.ReserveInventory(ctx, 1, 80, "ORD_A"); err != nil {
+		t.Fatalf("ReserveInventory failed: %v", err)
+	}
+	err := repo.ReserveInventory(ctx, 1, 30, "ORD_B")
+	if !errors.Is(err, biz.ErrInsufficientInventory) {
+		t.Fatalf("Expected ErrInsufficientInventory, got %v", err)
+	}
+
+	var count int64
+	d.Db.Model(&biz.MtStockMovement{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 movement, got %d", count)
+	}
+}
+
+// 测试预留、出库、释放和下单拒绝非正数量，库存和流水不变
+func TestInventoryRejectsNonPositiveQuantity(t *testing.T) {
+	d := newOrderTestData(t)
+	repo := NewDrugInventoryRepo(d, newTestLogger())
+	ctx := context.Background()
+
+	moves := map[string]func() error{
+		"预留负数": func() error { return repo.ReserveInventory(ctx, 1, -10, "ORD_A") },
+		"预留零":  func() error { return repo.ReserveInventory(ctx, 1, 0, "ORD_A") },
+		"出库负数": func() error { return repo.ReduceInventory(ctx, 1, -10, "ORD_A") },
+		"释放负数": func() error { return repo.ReleaseReservedInventory(ctx, 1, -10, "ORD_A") },
+	}
+	for name, move := range moves {
+		if err := move(); !errors.Is(err, biz.ErrInvalidStockQuantity) {
+			t.Errorf("%s: expected ErrInvalidStockQuantity, got %v", name, err)
+		}
+	}
+
+	_, err := newTestOrderUsecase(d).CreateOrder(ctx, &biz.CreateOrderRequest{
+		UserID:        1001,
+		AddressID:     1,
+		AddressDetail: "测试地址",
+		Items:         []*biz.CreateOrderItem{{DrugID: 1, Quantity: -5}},
+	})
+	if !errors.Is(err, biz.ErrInvalidStockQuantity) {
+		t.Errorf("Expected order with negative quantity rejected, got %v", err)
+	}
+
+	inv := getStoreInventory(t, repo)
+	if inv.Quantity != 100 || inv.ReservedQty != 0 {
+		t.Errorf("Expected on-hand 100/reserved 0, got %d/%d", inv.Quantity, inv.ReservedQty)
+	}
+	if got := drugInventory(t, d); got != 100 {
+		t.Errorf("Expected mt_drug.inventory 100, got %d", got)
+	}
+	var count int64
+	d.Db.Model(&biz.MtStockMovement{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no movements, got %d", count)
+	}
+}
+
+// 测试盘点调整不能低于已预留数量
+func TestInventoryAdjust(t *testing.T) {
+	d := newOrderTestData(t)
+	repo := NewDrugInventoryRepo(d, newTestLogger())
+	ctx := context.Background()
+
+	if err := repo.ReserveInventory(ctx, 1, 20, "ORD_A"); err != nil {
+		t.Fatalf("ReserveInventory failed: %v", err)
+	}
+	if _, err := repo.AdjustInventory(ctx, 1, 1, 10, "盘亏"); err == nil {
+		t.Fatal("Expected adjust below reserved quantity to fail")
+	}
+
+	inv, err := repo.AdjustInventory(ctx, 1, 1, 50, "盘点")
+	if err != nil {
+		t.Fatalf("AdjustInventory failed: %v", err)
+	}
+	if inv.Quantity != 50 || inv.ReservedQty != 20 || inv.Available() != 30 {
+		t.Errorf("Expected on-hand 50/reserved 20/available 30, got %d/%d/%d",
+			inv.Quantity, inv.ReservedQty, inv.Available())
+	}
+
+	var movement biz.MtStockMovement
+	d.Db.Last(&movement)
+	if movement.MovementType != biz.StockMovementAdjust || movement.QuantityDiff != -50 {
+		t.Errorf("Expected adjust movement with diff -50, got %s/%d", movement.MovementType, movement.QuantityDiff)
+	}
+}
@@ -0,0 +1,83 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm/clause"
+	"kratos_client/internal/biz"
+)
+
+// 任务租约数据模型 - 对应 mt_job_lease 表
+type MtJobLease struct {
+	Name      string    `gorm:"column:name;size:64;primaryKey" json:"name"`
+	Holder    string    `gorm:"column:holder;size:128;not null" json:"holder"`
+	ExpiresAt time.Time `gorm:"column:expires_at;type:datetime(3);not null" json:"expires_at"`
+}
+
+// 表名
+func (MtJobLease) TableName() string {
+	return "mt_job_lease"
+}
+
+// 租约仓储实现
+type leaseRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建租约仓储
+func NewLeaseRepo(data *Data, logger log.Logger) biz.LeaseRepo {
+	return &leaseRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 获取或续期租约
+func (r *leaseRepo) TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	db := r.data.Db.WithContext(ctx)
+
+	// 自己持有或已过期的租约直接接管
+	result := db.Model(&MtJobLease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": expiresAt,
+		})
+	if result.Error != nil {
+		r.log.Errorf("续期任务租约失败: name=%s, error=%v", name, result.Error)
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// 租约不存在时尝试创建，并发创建只有一个实例会成功
+	result = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&MtJobLease{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: expiresAt,
+	})
+	if result.Error != nil {
+		r.log.Errorf("创建任务租约失败: name=%s, error=%v", name, result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// 释放租约
+func (r *leaseRepo) Release(ctx context.Context, name, holder string) error {
+	result := r.data.Db.WithContext(ctx).
+		Where("name = ? AND holder = ?", name, holder).
+		Delete(&MtJobLease{})
+	if result.Error != nil {
+		r.log.Errorf("释放任务租约失败: name=%s, error=%v", name, result.Error)
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+	"kratos_client/internal/biz"
+)
+
+// 创建登录失败计数仓储，配置了Redis时各副本共享计数，否则只在本进程内计数
+func NewLoginAttemptRepo(data *Data, logger log.Logger) biz.LoginAttemptRepo {
+	if data.RDb != nil {
+		return &redisLoginAttemptRepo{data: data, log: log.NewHelper(logger)}
+	}
+	log.NewHelper(logger).Warn("Redis未配置，登录失败次数只在本节点统计")
+	return &memoryLoginAttemptRepo{attempts: make(map[string]*loginAttempt)}
+}
+
+// 进程内计数超过该数量时清理已过期的记录
+const loginAttemptSweepSize = 10000
+
+// 累加失败次数，首次失败时设置过期时间，窗口期自首次失败起计算
+var loginFailureScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+if failures == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return failures
+`)
+
+func loginAttemptKey(scope, account string) string {
+	return fmt.Sprintf("login_attempt:%s:%s", scope, account)
+}
+
+// 基于Redis的登录失败计数
+type redisLoginAttemptRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func (r *redisLoginAttemptRepo) Failures(ctx context.Context, scope, account string) (int64, error) {
+	failures, err := r.data.RDb.Get(ctx, loginAttemptKey(scope, account)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return failures, err
+}
+
+func (r *redisLoginAttemptRepo) RecordFailure(ctx context.Context, scope, account string, window time.Duration) (int64, error) {
+	key := loginAttemptKey(scope, account)
+	failures, err := loginFailureScript.Run(ctx, r.data.RDb, []string{key}, window.Milliseconds()).Int64()
+	if err != nil {
+		r.log.Errorf("记录登录失败次数失败: key=%s, error=%v", key, err)
+		return 0, err
+	}
+	return failures, nil
+}
+
+func (r *redisLoginAttemptRepo) Reset(ctx context.Context, scope, account string) error {
+	return r.data.RDb.Del(ctx, loginAttemptKey(scope, account)).Err()
+}
+
+// 进程内的登录失败计数，过期记录在下次访问时清理
+type memoryLoginAttemptRepo struct {
+	mu       sync.Mutex
+	attempts map[string]*loginAttempt
+}
+
+type loginAttempt struct {
+	failures  int64
+	expiresAt time.Time
+}
+
+func (r *memoryLoginAttemptRepo) Failures(ctx context.Context, scope, account string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if attempt := r.get(loginAttemptKey(scope, account)); attempt != nil {
+		return attempt.failures, nil
+	}
+	return 0, nil
+}
+
+func (r *memoryLoginAttemptRepo) RecordFailure(ctx context.Context, scope, account string, window time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := loginAttemptKey(scope, account)
+	attempt := r.get(key)
+	if attempt == nil {
+		if len(r.attempts) >= loginAttemptSweepSize {
+			r.sweep()
+		}
+		attempt = &loginAttempt{expiresAt: time.Now().Add(window)}
+		r.attempts[key] = attempt
+	}
+	attempt.failures++
+	return attempt.failures, nil
+}
+
+func (r *memoryLoginAttemptRepo) Reset(ctx context.Context, scope, account string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, loginAttemptKey(scope, account))
+	return nil
+}
+
+// 查询未过期的记录，调用方持有锁
+func (r *memoryLoginAttemptRepo) get(key string) *loginAttempt {
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(attempt.expiresAt) {
+		delete(r.attempts, key)
+		return nil
+	}
+	return attempt
+}
+
+// 清理已过期的记录，调用方持有锁
+func (r *memoryLoginAttemptRepo) sweep() {
+	now := time.Now()
+	for key, attempt := range r.attempts {
+		if now.After(attempt.expiresAt) {
+			delete(r.attempts, key)
+		}
+	}
+}
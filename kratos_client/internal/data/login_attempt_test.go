@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"kratos_client/internal/biz"
+)
+
+// 测试登录失败计数在Redis和进程内两种存储下的累加、清零和过期
+func TestLoginAttemptRepo(t *testing.T) {
+	for _, withRedis := range []bool{false, true} {
+		d := newTestData(t)
+		var mr *miniredis.Miniredis
+		if withRedis {
+			mr = miniredis.RunT(t)
+			d.RDb = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { d.RDb.Close() })
+		}
+		repo := NewLoginAttemptRepo(d, newTestLogger())
+		ctx := context.Background()
+
+		for i := 1; i <= 3; i++ {
+			if failures, err := repo.RecordFailure(ctx, biz.LoginScopeDoctor, "D001", time.Minute); err != nil || failures != int64(i) {
+				t.Fatalf("redis=%v: expected %d failures, got %d, %v", withRedis, i, failures, err)
+			}
+		}
+		// 医生和药师分别计数
+		if failures, err := repo.Failures(ctx, biz.LoginScopePharmacist, "D001"); err != nil || failures != 0 {
+			t.Errorf("redis=%v: expected pharmacist scope untouched, got %d, %v", withRedis, failures, err)
+		}
+		if err := repo.Reset(ctx, biz.LoginScopeDoctor, "D001"); err != nil {
+			t.Fatalf("redis=%v: Reset failed: %v", withRedis, err)
+		}
+		if failures, err := repo.Failures(ctx, biz.LoginScopeDoctor, "D001"); err != nil || failures != 0 {
+			t.Errorf("redis=%v: expected failures reset, got %d, %v", withRedis, failures, err)
+		}
+
+		// 窗口期过后计数清零
+		if _, err := repo.RecordFailure(ctx, biz.LoginScopeDoctor, "D002", 10*time.Millisecond); err != nil {
+			t.Fatalf("redis=%v: RecordFailure failed: %v", withRedis, err)
+		}
+		if mr != nil {
+			mr.FastForward(time.Second)
+		} else {
+			time.Sleep(20 * time.Millisecond)
+		}
+		if failures, err := repo.Failures(ctx, biz.LoginScopeDoctor, "D002"); err != nil || failures != 0 {
+			t.Errorf("redis=%v: expected failures expired, got %d, %v", withRedis, failures, err)
+		}
+	}
+}
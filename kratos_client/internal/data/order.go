@@ -33,6 +33,8 @@ type MtOrder struct {
 	DiscountAmount decimal.Decimal `gorm:"column:discount_amount;type:decimal(10,2);default:0" json:"discount_amount"`
 	OriginalAmount decimal.Decimal `gorm:"column:original_amount;type:decimal(10,2);default:0" json:"original_amount"`
 	Remark         string          `gorm:"column:remark;size:500" json:"remark"`
+	PrescriptionID uint64          `gorm:"column:prescription_id;default:0" json:"prescription_id"`
+	CreatedAt      time.Time       `gorm:"column:created_at;type:datetime(3);autoCreateTime" json:"created_at"`
 }
 
 // 表名
@@ -42,15 +44,16 @@ func (MtOrder) TableName() string {
 
 // 订单项数据模型
 type MtOrderItem struct {
-	ID        int64           `gorm:"primaryKey;autoIncrement" json:"id"`
-	OrderID   int64           `gorm:"index;not null" json:"order_id"`
-	DrugID    int64           `gorm:"index;not null" json:"drug_id"`
-	DrugName  string          `gorm:"size:100;not null" json:"drug_name"`
-	DrugSpec  string          `gorm:"size:50" json:"drug_spec"`
-	Quantity  int32           `gorm:"not null" json:"quantity"`
-	Price     decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"price"`
-	Subtotal  decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"subtotal"`
-	CreatedAt time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	ID          int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID     int64           `gorm:"index;not null" json:"order_id"`
+	DrugID      int64           `gorm:"index;not null" json:"drug_id"`
+	DrugName    string          `gorm:"size:100;not null" json:"drug_name"`
+	DrugSpec    string          `gorm:"size:50" json:"drug_spec"`
+	Quantity    int32           `gorm:"not null" json:"quantity"`
+	RefundedQty int32           `gorm:"column:refunded_qty;not null;default:0" json:"refunded_qty"`
+	Price       decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"price"`
+	Subtotal    decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"subtotal"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
 }
 
 // 表名
@@ -58,6 +61,24 @@ func (MtOrderItem) TableName() string {
 	return "mt_order_items"
 }
 
+// 订单状态流转日志数据模型
+type MtOrderStatusLog struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID      int64     `gorm:"index;not null" json:"order_id"`
+	OrderNo      string    `gorm:"column:order_no;size:50;index;not null" json:"order_no"`
+	FromStatus   string    `gorm:"column:from_status;size:20" json:"from_status"`
+	ToStatus     string    `gorm:"column:to_status;size:20;not null" json:"to_status"`
+	OperatorType string    `gorm:"column:operator_type;size:20;not null" json:"operator_type"`
+	OperatorID   int64     `gorm:"column:operator_id;default:0" json:"operator_id"`
+	Reason       string    `gorm:"column:reason;size:500" json:"reason"`
+	CreatedAt    time.Time `gorm:"column:created_at;type:datetime(3)" json:"created_at"`
+}
+
+// 表名
+func (MtOrderStatusLog) TableName() string {
+	return "mt_order_status_log"
+}
+
 // 订单仓储实现
 type orderRepo struct {
 	data *Data
@@ -96,6 +117,8 @@ func (r *orderRepo) toBizOrder(do *MtOrder) *biz.MtOrder {
 		DiscountAmount: do.DiscountAmount,
 		OriginalAmount: do.OriginalAmount,
 		Remark:         do.Remark,
+		PrescriptionID: do.PrescriptionID,
+		CreatedAt:      do.CreatedAt,
 	}
 }
 
@@ -123,36 +146,55 @@ func (r *orderRepo) toDataOrder(bo *biz.MtOrder) *MtOrder {
 		DiscountAmount: bo.DiscountAmount,
 		OriginalAmount: bo.OriginalAmount,
 		Remark:         bo.Remark,
+		PrescriptionID: bo.PrescriptionID,
+		CreatedAt:      bo.CreatedAt,
 	}
 }
 
 // 转换订单项业务模型到数据模型
 func (r *orderRepo) toBizOrderItem(doi *MtOrderItem) *biz.MtOrderItem {
 	return &biz.MtOrderItem{
-		ID:        doi.ID,
-		OrderID:   doi.OrderID,
-		DrugID:    doi.DrugID,
-		DrugName:  doi.DrugName,
-		DrugSpec:  doi.DrugSpec,
-		Quantity:  doi.Quantity,
-		Price:     doi.Price,
-		Subtotal:  doi.Subtotal,
-		CreatedAt: doi.CreatedAt,
+		ID:          doi.ID,
+		OrderID:     doi.OrderID,
+		DrugID:      doi.DrugID,
+		DrugName:    doi.DrugName,
+		DrugSpec:    doi.DrugSpec,
+		Quantity:    doi.Quantity,
+		RefundedQty: doi.RefundedQty,
+		Price:       doi.Price,
+		Subtotal:    doi.Subtotal,
+		CreatedAt:   doi.CreatedAt,
 	}
 }
 
 // 转换订单项业务模型到数据模型
 func (r *orderRepo) toDataOrderItem(boi *biz.MtOrderItem) *MtOrderItem {
 	return &MtOrderItem{
-		ID:        boi.ID,
-		OrderID:   boi.OrderID,
-		DrugID:    boi.DrugID,
-		DrugName:  boi.DrugName,
-		DrugSpec:  boi.DrugSpec,
-		Quantity:  boi.Quantity,
-		Price:     boi.Price,
-		Subtotal:  boi.Subtotal,
-		CreatedAt: boi.CreatedAt,
+		ID:          boi.ID,
+		OrderID:     boi.OrderID,
+		DrugID:      boi.DrugID,
+		DrugName:    boi.DrugName,
+		DrugSpec:    boi.DrugSpec,
+		Quantity:    boi.Quantity,
+		RefundedQty: boi.RefundedQty,
+		Price:       boi.Price,
+		Subtotal:    boi.Subtotal,
+		CreatedAt:   boi.CreatedAt,
+	}
+}
+
+// 转换状态日志数据模型到业务模型
+func (r *orderRepo) toBizOrderStatusLog(do *MtOrderStatusLog) *biz.MtOrderStatusLog {
+	return &biz.MtOrderStatusLog{
+		ID:           do.ID,
+		OrderID:      do.OrderID,
+		OrderNo:      do.OrderNo,
+		FromStatus:   do.FromStatus,
+		ToStatus:     do.ToStatus,
+		OperatorType: do.OperatorType,
+		OperatorID:   do.OperatorID,
+		Reason:       do.Reason,
+		CreatedAt:    do.CreatedAt,
 	}
 }
 
@@ -205,13 +247,13 @@ func (r *orderRepo) GetOrderByOrderNo(ctx context.Context, orderNo string) (*biz
 }
 
 // 更新订单状态
-func (r *orderRepo) UpdateOrderStatus(ctx context.Context, orderNo string, status string, timestamp time.Time) error {
+func (r *orderRepo) UpdateOrderStatus(ctx context.Context, orderNo string, fromStatus, toStatus string, timestamp time.Time) error {
 	updates := map[string]interface{}{
-		"status": status,
+		"status": toStatus,
 	}
 
 	// 根据状态设置对应的时间字段
-	switch status {
+	switch toStatus {
 	case "2": // 已支付
 		if updates["pay_time"] == nil {
 			updates["pay_time"] = timestamp
@@ -228,7 +270,7 @@ func (r *orderRepo) UpdateOrderStatus(ctx context.Context, orderNo string, statu
 
 	db := r.getDB(ctx)
 	result := db.Model(&MtOrder{}).
-		Where("order_no = ?", orderNo).
+		Where("order_no = ? AND status = ?", orderNo, fromStatus).
 		Updates(updates)
 
 	if result.Error != nil {
@@ -237,7 +279,8 @@ func (r *orderRepo) UpdateOrderStatus(ctx context.Context, orderNo string, statu
 	}
 
 	if result.RowsAffected == 0 {
-		r.log.Warnf("更新订单状态未影响任何行: orderNo=%s", orderNo)
+		r.log.Warnf("订单状态已变更，更新未生效: orderNo=%s, from=%s, to=%s", orderNo, fromStatus, toStatus)
+		return biz.ErrOrderStatusConflict
 	}
 
 	return nil
@@ -348,6 +391,51 @@ func (r *orderRepo) GetOrderItems(ctx context.Context, orderID int64) ([]*biz.Mt
 	return bizItems, nil
 }
 
+// 记录订单状态流转日志
+func (r *orderRepo) CreateOrderStatusLog(ctx context.Context, statusLog *biz.MtOrderStatusLog) error {
+	do := &MtOrderStatusLog{
+		OrderID:      statusLog.OrderID,
+		OrderNo:      statusLog.OrderNo,
+		FromStatus:   statusLog.FromStatus,
+		ToStatus:     statusLog.ToStatus,
+		OperatorType: statusLog.OperatorType,
+		OperatorID:   statusLog.OperatorID,
+		Reason:       statusLog.Reason,
+		CreatedAt:    statusLog.CreatedAt,
+	}
+
+	db := r.getDB(ctx)
+	result := db.Create(do)
+	if result.Error != nil {
+		r.log.Errorf("记录订单状态日志失败: %v", result.Error)
+		return result.Error
+	}
+
+	statusLog.ID = do.ID
+	return nil
+}
+
+// 查询订单状态流转日志，按时间正序
+func (r *orderRepo) ListOrderStatusLogs(ctx context.Context, orderNo string) ([]*biz.MtOrderStatusLog, error) {
+	var logs []MtOrderStatusLog
+	db := r.getDB(ctx)
+	result := db.Where("order_no = ?", orderNo).
+		Order("id ASC").
+		Find(&logs)
+
+	if result.Error != nil {
+		r.log.Errorf("查询订单状态日志失败: %v", result.Error)
+		return nil, result.Error
+	}
+
+	bizLogs := make([]*biz.MtOrderStatusLog, len(logs))
+	for i, l := range logs {
+		bizLogs[i] = r.toBizOrderStatusLog(&l)
+	}
+
+	return bizLogs, nil
+}
+
 // 事务支持
 func (r *orderRepo) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
 	return r.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -407,6 +495,28 @@ func (r *orderRepo) CountOrdersByStatus(ctx context.Context, status string) (int
 	return count, nil
 }
 
+// 查询超时的待支付订单
+func (r *orderRepo) ListPendingOrdersBefore(ctx context.Context, before time.Time, limit int) ([]*biz.MtOrder, error) {
+	var orders []MtOrder
+	db := r.getDB(ctx)
+	result := db.Where("status = ? AND created_at < ?", biz.OrderStatusPending, before).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&orders)
+
+	if result.Error != nil {
+		r.log.Errorf("查询超时待支付订单失败: %v", result.Error)
+		return nil, result.Error
+	}
+
+	bizOrders := make([]*biz.MtOrder, len(orders))
+	for i, order := range orders {
+		bizOrders[i] = r.toBizOrder(&order)
+	}
+
+	return bizOrders, nil
+}
+
 // 获取数据库连接（支持事务）
 func (r *orderRepo) getDB(ctx context.Context) *gorm.DB {
 	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
@@ -0,0 +1,284 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+// 构建订单用例及其依赖的真实仓储
+func newTestOrderUsecase(d *Data) *biz.OrderUsecase {
+	uc, _ := newTestOrderPaymentUsecase(d, newTestSandboxGateways())
+	return uc
+}
+
+func newTestSandboxGateways() *biz.PaymentGateways {
+	return NewPaymentGateways(&conf.Payment{Sandbox: &conf.Payment_Sandbox{Enabled: true, Secret: "test"}}, newTestLogger())
+}
+
+// 构建订单用例及其注册的支付用例
+func newTestOrderPaymentUsecase(d *Data, gateways *biz.PaymentGateways) (*biz.OrderUsecase, *biz.PaymentUsecase) {
+	logger := newTestLogger()
+	inventoryRepo := NewDrugInventoryRepo(d, logger)
+	inventoryUc := biz.NewInventoryUsecase(inventoryRepo, NewDrugRepo(d, logger), NewInventoryAlertRepo(d, logger), NewInventoryAlertSink(nil, logger), logger)
+	couponUc := biz.NewCouponUsecase(NewCouponRepo(d, logger), NewDrugRepo(d, logger), logger)
+	idempotencyUc := biz.NewIdempotencyUsecase(NewIdempotencyRepo(nil, d, logger), logger)
+	paymentUc := biz.NewPaymentUsecase(NewPaymentRepo(d, logger), idempotencyUc, gateways, logger)
+	uc := biz.NewOrderUsecase(NewOrderRepo(d, logger), NewDrugRepo(d, logger), inventoryRepo, inventoryUc, couponUc, idempotencyUc,
+		NewPrescriptionRepo(d, logger), newTestInteractionUsecase(d), paymentUc, logger)
+	return uc, paymentUc
+}
+
+// 创建下单所需的表和一个测试药品，extra 为额外需要迁移的表
+func newOrderTestData(t *testing.T, extra ...interface{}) *Data {
+	models := []interface{}{&MtOrder{}, &MtOrderItem{}, &MtOrderStatusLog{}, &biz.MtDrug{}, &MtJobLease{},
+		&biz.MtDrugInventory{}, &biz.MtStockMovement{}, &biz.MtInventoryAlert{},
+		&MtDiscount{}, &MtCouponRule{}, &MtDiscountUser{}, &MtOrderCoupon{}, &MtIdempotencyKey{},
+		&PaymentOrder{}, &RefundRecord{}, &MtRefundItem{}, &MtDrugInteraction{}, &MtDrugContraindication{},
+		&MtDrugIndexTask{}}
+	d := newTestData(t, append(models, extra...)...)
+	if err := d.Db.Create(&biz.MtDrug{Id: 1, DrugName: "感冒灵颗粒", DrugStore: 1, Price: 12.5, Inventory: 100}).Error; err != nil {
+		t.Fatalf("创建测试药品失败: %v", err)
+	}
+	if err := d.Db.Create(&biz.MtDrugInventory{DrugID: 1, DrugStoreID: 1, Quantity: 100, Price: 12.5}).Error; err != nil {
+		t.Fatalf("创建测试库存失败: %v", err)
+	}
+	return d
+}
+
+func createTestOrder(t *testing.T, uc *biz.OrderUsecase, quantity int32) *biz.MtOrder {
+	t.Helper()
+	order, err := uc.CreateOrder(context.Background(), &biz.CreateOrderRequest{
+		UserID:        1001,
+		UserName:      "测试用户",
+		UserPhone:     "13800138000",
+		AddressID:     1,
+		AddressDetail: "测试地址",
+		Items:         []*biz.CreateOrderItem{{DrugID: 1, Quantity: quantity}},
+	})
+	if err != nil {
+		t.Fatalf("创建订单失败: %v", err)
+	}
+	return order
+}
+
+// 将订单的下单时间回拨，模拟超时
+func ageOrder(t *testing.T, d *Data, orderNo string, age time.Duration) {
+	t.Helper()
+	err := d.Db.Model(&MtOrder{}).Where("order_no = ?", orderNo).
+		Update("created_at", time.Now().Add(-age)).Error
+	if err != nil {
+		t.Fatalf("修改下单时间失败: %v", err)
+	}
+}
+
+func drugInventory(t *testing.T, d *Data) int16 {
+	t.Helper()
+	var drug biz.MtDrug
+	if err := d.Db.First(&drug, 1).Error; err != nil {
+		t.Fatalf("查询药品库存失败: %v", err)
+	}
+	return drug.Inventory
+}
+
+// 测试超时未支付订单被关闭并释放库存
+func TestExpirePendingOrders(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+	ctx := context.Background()
+
+	stale := createTestOrder(t, uc, 3)
+	fresh := createTestOrder(t, uc, 2)
+	ageOrder(t, d, stale.OrderNo, time.Hour)
+
+	if got := drugInventory(t, d); got != 95 {
+		t.Fatalf("Expected inventory 95 after reservation, got %d", got)
+	}
+
+	expired, err := uc.ExpirePendingOrders(ctx, 30*time.Minute, 100)
+	if err != nil {
+		t.Fatalf("ExpirePendingOrders failed: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("Expected 1 expired order, got %d", expired)
+	}
+
+	detail, _ := uc.GetOrder(ctx, stale.OrderNo)
+	if detail.Order.Status != biz.OrderStatusCancelled {
+		t.Errorf("Expected stale order cancelled, got status %s", detail.Order.Status)
+	}
+	detail, _ = uc.GetOrder(ctx, fresh.OrderNo)
+	if detail.Order.Status != biz.OrderStatusPending {
+		t.Errorf("Expected fresh order still pending, got status %s", detail.Order.Status)
+	}
+	if got := drugInventory(t, d); got != 98 {
+		t.Errorf("Expected inventory 98 after release, got %d", got)
+	}
+
+	logs, _ := uc.ListOrderStatusLogs(ctx, stale.OrderNo)
+	last := logs[len(logs)-1]
+	if last.ToStatus != biz.OrderStatusCancelled || last.OperatorType != biz.OrderOperatorSystem {
+		t.Errorf("Expected system cancellation log, got %+v", last)
+	}
+
+	// 再次扫描不应重复处理
+	expired, _ = uc.ExpirePendingOrders(ctx, 30*time.Minute, 100)
+	if expired != 0 {
+		t.Errorf("Expected no order expired on second run, got %d", expired)
+	}
+}
+
+// 测试已支付订单不会被超时关闭
+func TestExpirePendingOrdersSkipsPaid(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+	ctx := context.Background()
+
+	order := createTestOrder(t, uc, 1)
+	ageOrder(t, d, order.OrderNo, time.Hour)
+	err := uc.ProcessPayment(ctx, order.OrderNo, &biz.PaymentInfo{
+		PayType:     "2",
+		Amount:      order.TotalAmount,
+		PaymentTime: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("ProcessPayment failed: %v", err)
+	}
+
+	expired, _ := uc.ExpirePendingOrders(ctx, 30*time.Minute, 100)
+	if expired != 0 {
+		t.Errorf("Expected paid order to be skipped, got %d expired", expired)
+	}
+	if got := drugInventory(t, d); got != 99 {
+		t.Errorf("Expected inventory 99, got %d", got)
+	}
+}
+
+// 测试渠道已支付但通知丢失的订单在超时关闭前同步为已支付
+func TestExpirePendingOrdersSyncsGatewayPayment(t *testing.T) {
+	d := newOrderTestData(t)
+	gateways := newTestSandboxGateways()
+	uc, paymentUc := newTestOrderPaymentUsecase(d, gateways)
+	ctx := context.Background()
+
+	order := createTestOrder(t, uc, 2)
+	unpaid := createTestOrder(t, uc, 1)
+	for _, o := range []*biz.MtOrder{order, unpaid} {
+		ageOrder(t, d, o.OrderNo, time.Hour)
+		if _, err := paymentUc.CreatePaymentOrder(ctx, 1001, biz.PayTypeWechat, "药品订单", o.TotalAmount.StringFixed(2), biz.OrderTypeDrug, o.OrderNo, "", ""); err != nil {
+			t.Fatalf("CreatePaymentOrder failed: %v", err)
+		}
+	}
+	// 用户在渠道完成支付，支付通知丢失
+	var payment PaymentOrder
+	if err := d.Db.Where("business_id = ?", order.OrderNo).First(&payment).Error; err != nil {
+		t.Fatalf("查询支付单失败: %v", err)
+	}
+	gateway, _ := gateways.ByChannel(biz.PaymentChannelSandbox)
+	if _, err := gateway.(biz.PaymentSimulator).SimulatePay(ctx, payment.OrderID); err != nil {
+		t.Fatalf("SimulatePay failed: %v", err)
+	}
+
+	expired, err := uc.ExpirePendingOrders(ctx, 30*time.Minute, 100)
+	if err != nil || expired != 1 {
+		t.Fatalf("Expected only the unpaid order expired, got %d, %v", expired, err)
+	}
+	detail, _ := uc.GetOrder(ctx, order.OrderNo)
+	if detail.Order.Status != biz.OrderStatusPaid {
+		t.Errorf("Expected paid order kept and marked paid, got status %s", detail.Order.Status)
+	}
+	detail, _ = uc.GetOrder(ctx, unpaid.OrderNo)
+	if detail.Order.Status != biz.OrderStatusCancelled {
+		t.Errorf("Expected unpaid order cancelled, got status %s", detail.Order.Status)
+	}
+	if got := drugInventory(t, d); got != 98 {
+		t.Errorf("Expected inventory 98, got %d", got)
+	}
+}
+
+// 测试多个副本同时扫描时每个订单只释放一次库存
+func TestExpirePendingOrdersConcurrentReplicas(t *testing.T) {
+	d := newOrderTestData(t)
+	ctx := context.Background()
+
+	const orderCount = 5
+	seed := newTestOrderUsecase(d)
+	for i := 0; i < orderCount; i++ {
+		order := createTestOrder(t, seed, 2)
+		ageOrder(t, d, order.OrderNo, time.Hour)
+	}
+
+	replicas := []*biz.OrderUsecase{newTestOrderUsecase(d), newTestOrderUsecase(d), newTestOrderUsecase(d)}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	total := 0
+	for _, uc := range replicas {
+		wg.Add(1)
+		go func(uc *biz.OrderUsecase) {
+			defer wg.Done()
+			expired, err := uc.ExpirePendingOrders(ctx, 30*time.Minute, 100)
+			if err != nil {
+				t.Errorf("ExpirePendingOrders failed: %v", err)
+			}
+			mu.Lock()
+			total += expired
+			mu.Unlock()
+		}(uc)
+	}
+	wg.Wait()
+
+	if total != orderCount {
+		t.Errorf("Expected %d orders expired across replicas, got %d", orderCount, total)
+	}
+	if got := drugInventory(t, d); got != 100 {
+		t.Errorf("Expected inventory fully restored to 100, got %d", got)
+	}
+
+	var cancelLogs int64
+	d.Db.Model(&MtOrderStatusLog{}).Where("to_status = ?", biz.OrderStatusCancelled).Count(&cancelLogs)
+	if cancelLogs != orderCount {
+		t.Errorf("Expected %d cancellation logs, got %d", orderCount, cancelLogs)
+	}
+}
+
+// 测试任务租约的互斥、续期与过期接管
+func TestLeaseRepo(t *testing.T) {
+	d := newTestData(t, &MtJobLease{})
+	repo := NewLeaseRepo(d, newTestLogger())
+	ctx := context.Background()
+
+	ok, err := repo.TryAcquire(ctx, "order_expiry", "node-a", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("Expected node-a to acquire lease, got ok=%v err=%v", ok, err)
+	}
+	if ok, _ := repo.TryAcquire(ctx, "order_expiry", "node-b", time.Minute); ok {
+		t.Error("Expected node-b to be rejected while node-a holds the lease")
+	}
+	if ok, _ := repo.TryAcquire(ctx, "order_expiry", "node-a", time.Minute); !ok {
+		t.Error("Expected node-a to renew its own lease")
+	}
+
+	// 租约过期后其他实例可以接管
+	d.Db.Model(&MtJobLease{}).Where("name = ?", "order_expiry").
+		Update("expires_at", time.Now().Add(-time.Second))
+	if ok, _ := repo.TryAcquire(ctx, "order_expiry", "node-b", time.Minute); !ok {
+		t.Error("Expected node-b to take over expired lease")
+	}
+	if ok, _ := repo.TryAcquire(ctx, "order_expiry", "node-a", time.Minute); ok {
+		t.Error("Expected node-a to be rejected after takeover")
+	}
+
+	// 非持有者释放无效，持有者释放后可被重新获取
+	repo.Release(ctx, "order_expiry", "node-a")
+	if ok, _ := repo.TryAcquire(ctx, "order_expiry", "node-a", time.Minute); ok {
+		t.Error("Expected release by non-holder to be ignored")
+	}
+	repo.Release(ctx, "order_expiry", "node-b")
+	if ok, _ := repo.TryAcquire(ctx, "order_expiry", "node-a", time.Minute); !ok {
+		t.Error("Expected node-a to acquire released lease")
+	}
+}
@@ -20,6 +20,7 @@ type PaymentOrder struct {
 	Description  string    `gorm:"type:text" json:"description"`
 	TotalAmount  string    `gorm:"size:16;not null" json:"total_amount"`
 	Status       string    `gorm:"size:32;not null;default:'pending'" json:"status"`
+	Channel      string    `gorm:"size:16;not null;default:'alipay'" json:"channel"`
 	PaymentURL   string    `gorm:"type:text" json:"payment_url"`
 	TradeNo      string    `gorm:"size:64" json:"trade_no"`
 	PayTime      time.Time `json:"pay_time"`
@@ -34,14 +35,22 @@ func (PaymentOrder) TableName() string {
 
 // 退款记录数据模型
 type RefundRecord struct {
-	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	OrderID      string    `gorm:"index;size:64;not null" json:"order_id"`
-	RefundID     string    `gorm:"uniqueIndex;size:64;not null" json:"refund_id"`
-	RefundAmount string    `gorm:"size:16;not null" json:"refund_amount"`
-	RefundReason string    `gorm:"type:text" json:"refund_reason"`
-	RefundStatus string    `gorm:"size:32;not null;default:'processing'" json:"refund_status"`
-	RefundTime   time.Time `json:"refund_time"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID         string     `gorm:"index;size:64;not null" json:"order_id"`
+	OrderNo         string     `gorm:"index;size:64" json:"order_no"`
+	UserID          int64      `gorm:"index" json:"user_id"`
+	RefundID        string     `gorm:"uniqueIndex;size:64;not null" json:"refund_id"`
+	RefundAmount    string     `gorm:"size:16;not null" json:"refund_amount"`
+	RefundReason    string     `gorm:"type:text" json:"refund_reason"`
+	RefundStatus    string     `gorm:"index;size:32;not null;default:'requested'" json:"refund_status"`
+	AuditBy         int64      `json:"audit_by"`
+	AuditTime       *time.Time `json:"audit_time"`
+	AuditRemark     string     `gorm:"size:255" json:"audit_remark"`
+	GatewayRefundNo string     `gorm:"size:64" json:"gateway_refund_no"`
+	FailReason      string     `gorm:"size:255" json:"fail_reason"`
+	RefundTime      time.Time  `json:"refund_time"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 // 表名
@@ -63,6 +72,14 @@ func NewPaymentRepo(data *Data, logger log.Logger) biz.PaymentRepo {
 	}
 }
 
+// 获取数据库连接，在事务中时使用事务连接
+func (r *paymentRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
+}
+
 // 转换业务模型到数据模型
 func (r *paymentRepo) toBizPaymentOrder(po *PaymentOrder) *biz.PaymentOrder {
 	return &biz.PaymentOrder{
@@ -75,6 +92,7 @@ func (r *paymentRepo) toBizPaymentOrder(po *PaymentOrder) *biz.PaymentOrder {
 		Description: po.Description,
 		TotalAmount: po.TotalAmount,
 		Status:      po.Status,
+		Channel:     po.Channel,
 		PaymentURL:  po.PaymentURL,
 		TradeNo:     po.TradeNo,
 		PayTime:     po.PayTime,
@@ -95,6 +113,7 @@ func (r *paymentRepo) toDataPaymentOrder(bo *biz.PaymentOrder) *PaymentOrder {
 		Description: bo.Description,
 		TotalAmount: bo.TotalAmount,
 		Status:      bo.Status,
+		Channel:     bo.Channel,
 		PaymentURL:  bo.PaymentURL,
 		TradeNo:     bo.TradeNo,
 		PayTime:     bo.PayTime,
@@ -105,29 +124,53 @@ func (r *paymentRepo) toDataPaymentOrder(bo *biz.PaymentOrder) *PaymentOrder {
 
 // 转换退款记录
 func (r *paymentRepo) toBizRefundRecord(rr *RefundRecord) *biz.RefundRecord {
+	return toBizRefundRecord(rr)
+}
+
+func toBizRefundRecord(rr *RefundRecord) *biz.RefundRecord {
 	return &biz.RefundRecord{
-		ID:           rr.ID,
-		OrderID:      rr.OrderID,
-		RefundID:     rr.RefundID,
-		RefundAmount: rr.RefundAmount,
-		RefundReason: rr.RefundReason,
-		RefundStatus: rr.RefundStatus,
-		RefundTime:   rr.RefundTime,
-		CreatedAt:    rr.CreatedAt,
+		ID:              rr.ID,
+		OrderID:         rr.OrderID,
+		OrderNo:         rr.OrderNo,
+		UserID:          rr.UserID,
+		RefundID:        rr.RefundID,
+		RefundAmount:    rr.RefundAmount,
+		RefundReason:    rr.RefundReason,
+		RefundStatus:    rr.RefundStatus,
+		AuditBy:         rr.AuditBy,
+		AuditTime:       rr.AuditTime,
+		AuditRemark:     rr.AuditRemark,
+		GatewayRefundNo: rr.GatewayRefundNo,
+		FailReason:      rr.FailReason,
+		RefundTime:      rr.RefundTime,
+		CreatedAt:       rr.CreatedAt,
+		UpdatedAt:       rr.UpdatedAt,
 	}
 }
 
 // 转换退款记录
 func (r *paymentRepo) toDataRefundRecord(br *biz.RefundRecord) *RefundRecord {
+	return toDataRefundRecord(br)
+}
+
+func toDataRefundRecord(br *biz.RefundRecord) *RefundRecord {
 	return &RefundRecord{
-		ID:           br.ID,
-		OrderID:      br.OrderID,
-		RefundID:     br.RefundID,
-		RefundAmount: br.RefundAmount,
-		RefundReason: br.RefundReason,
-		RefundStatus: br.RefundStatus,
-		RefundTime:   br.RefundTime,
-		CreatedAt:    br.CreatedAt,
+		ID:              br.ID,
+		OrderID:         br.OrderID,
+		OrderNo:         br.OrderNo,
+		UserID:          br.UserID,
+		RefundID:        br.RefundID,
+		RefundAmount:    br.RefundAmount,
+		RefundReason:    br.RefundReason,
+		RefundStatus:    br.RefundStatus,
+		AuditBy:         br.AuditBy,
+		AuditTime:       br.AuditTime,
+		AuditRemark:     br.AuditRemark,
+		GatewayRefundNo: br.GatewayRefundNo,
+		FailReason:      br.FailReason,
+		RefundTime:      br.RefundTime,
+		CreatedAt:       br.CreatedAt,
+		UpdatedAt:       br.UpdatedAt,
 	}
 }
 
@@ -161,6 +204,44 @@ func (r *paymentRepo) GetPaymentOrderByOrderID(ctx context.Context, orderID stri
 	return r.toBizPaymentOrder(&po), nil
 }
 
+// 根据业务ID查询已支付的支付订单
+func (r *paymentRepo) GetPaidPaymentOrderByBusinessID(ctx context.Context, orderType, businessID string) (*biz.PaymentOrder, error) {
+	var po PaymentOrder
+	result := r.getDB(ctx).
+		Where("order_type = ? AND business_id = ? AND status IN ?", orderType, businessID,
+			[]string{biz.PaymentStatusPaid, biz.PaymentStatusRefunded}).
+		Order("id DESC").
+		First(&po)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.Errorf("查询已支付订单失败: %v", result.Error)
+		return nil, result.Error
+	}
+
+	return r.toBizPaymentOrder(&po), nil
+}
+
+// 根据业务ID查询待支付的支付订单
+func (r *paymentRepo) ListPendingPaymentOrdersByBusinessID(ctx context.Context, orderType, businessID string) ([]*biz.PaymentOrder, error) {
+	var orders []PaymentOrder
+	result := r.getDB(ctx).
+		Where("order_type = ? AND business_id = ? AND status = ?", orderType, businessID, biz.PaymentStatusPending).
+		Order("id ASC").
+		Find(&orders)
+	if result.Error != nil {
+		r.log.Errorf("查询待支付订单失败: %v", result.Error)
+		return nil, result.Error
+	}
+
+	bizOrders := make([]*biz.PaymentOrder, len(orders))
+	for i := range orders {
+		bizOrders[i] = r.toBizPaymentOrder(&orders[i])
+	}
+	return bizOrders, nil
+}
+
 // 根据用户ID查询支付订单列表
 func (r *paymentRepo) GetPaymentOrdersByUserID(ctx context.Context, userID int32, page, pageSize int32) ([]*biz.PaymentOrder, int64, error) {
 	var orders []PaymentOrder
@@ -210,7 +291,7 @@ func (r *paymentRepo) UpdatePaymentOrderStatus(ctx context.Context, orderID, sta
 		updates["pay_time"] = payTime
 	}
 
-	result := r.data.Db.WithContext(ctx).Model(&PaymentOrder{}).
+	result := r.getDB(ctx).Model(&PaymentOrder{}).
 		Where("order_id = ?", orderID).
 		Updates(updates)
 
@@ -226,6 +307,50 @@ func (r *paymentRepo) UpdatePaymentOrderStatus(ctx context.Context, orderID, sta
 	return nil
 }
 
+// 根据订单号批量查询支付订单
+func (r *paymentRepo) GetPaymentOrdersByOrderIDs(ctx context.Context, orderIDs []string) ([]*biz.PaymentOrder, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+
+	var orders []PaymentOrder
+	result := r.getDB(ctx).Where("order_id IN ?", orderIDs).Find(&orders)
+	if result.Error != nil {
+		r.log.Errorf("批量查询支付订单失败: %v", result.Error)
+		return nil, result.Error
+	}
+
+	bizOrders := make([]*biz.PaymentOrder, len(orders))
+	for i := range orders {
+		bizOrders[i] = r.toBizPaymentOrder(&orders[i])
+	}
+	return bizOrders, nil
+}
+
+// 查询渠道在支付时间范围内已支付的支付订单，含已退款
+func (r *paymentRepo) ListPaidPaymentOrders(ctx context.Context, channel string, start, end time.Time) ([]*biz.PaymentOrder, error) {
+	db := r.getDB(ctx).Where("status IN ? AND pay_time >= ? AND pay_time < ?",
+		[]string{biz.PaymentStatusPaid, biz.PaymentStatusRefunded}, start, end)
+	if channel == biz.PaymentChannelAlipay {
+		// 渠道为空的历史支付单均为支付宝
+		db = db.Where("channel IN ?", []string{channel, ""})
+	} else {
+		db = db.Where("channel = ?", channel)
+	}
+
+	var orders []PaymentOrder
+	if result := db.Order("pay_time").Find(&orders); result.Error != nil {
+		r.log.Errorf("查询已支付支付订单失败: %v", result.Error)
+		return nil, result.Error
+	}
+
+	bizOrders := make([]*biz.PaymentOrder, len(orders))
+	for i := range orders {
+		bizOrders[i] = r.toBizPaymentOrder(&orders[i])
+	}
+	return bizOrders, nil
+}
+
 // 创建退款记录
 func (r *paymentRepo) CreateRefundRecord(ctx context.Context, record *biz.RefundRecord) error {
 	rr := r.toDataRefundRecord(record)
@@ -0,0 +1,522 @@
+package data
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+// 根据配置组装支付渠道：支付宝始终启用，微信支付配置商户号后启用，沙箱模式下所有支付方式都走模拟渠道
+func NewPaymentGateways(c *conf.Payment, logger log.Logger) *biz.PaymentGateways {
+	helper := log.NewHelper(logger)
+	gateways := biz.NewPaymentGatewaySet()
+	gateways.Register(&alipayGateway{}, biz.PayTypeAlipay)
+
+	if wc := c.GetWechat(); wc.GetMchId() != "" {
+		client, err := comment.NewWechatPayClient(comment.WechatPayConfig{
+			AppID:             wc.AppId,
+			MchID:             wc.MchId,
+			SerialNo:          wc.SerialNo,
+			PrivateKey:        wc.PrivateKey,
+			APIv3Key:          wc.ApiV3Key,
+			PlatformPublicKey: wc.PlatformPublicKey,
+			PlatformSerial:    wc.PlatformSerial,
+			NotifyURL:         wc.NotifyUrl,
+			RefundNotifyURL:   wc.RefundNotifyUrl,
+			BaseURL:           wc.BaseUrl,
+			Timeout:           wc.Timeout.AsDuration(),
+		})
+		if err != nil {
+			helper.Warnf("微信支付配置无效，已跳过: %v", err)
+		} else {
+			gateways.Register(&wechatGateway{client: client}, biz.PayTypeWechat)
+		}
+	}
+
+	if c.GetSandbox().GetEnabled() {
+		sandbox, err := NewSandboxGateway(c.Sandbox.Secret)
+		if err != nil {
+			helper.Warnf("支付沙箱初始化失败，已跳过: %v", err)
+		} else {
+			gateways.Register(sandbox, biz.PayTypeWechat, biz.PayTypeAlipay, biz.PayTypeBankCard)
+			helper.Warn("支付沙箱已开启，所有支付方式均使用模拟渠道")
+		}
+	}
+	return gateways
+}
+
+// 元转分，金额最多两位小数
+func yuanToFen(amount string) (int64, error) {
+	d, err := decimal.NewFromString(amount)
+	if err != nil || !d.IsPositive() || !d.Equal(d.Round(2)) {
+		return 0, fmt.Errorf("金额格式错误: %s", amount)
+	}
+	return d.Shift(2).IntPart(), nil
+}
+
+// 分转元
+func fenToYuan(fen int64) string {
+	return decimal.New(fen, -2).StringFixed(2)
+}
+
+func parseGatewayTime(value string, layouts ...string) time.Time {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// 支付宝渠道
+type alipayGateway struct{}
+
+func (g *alipayGateway) Channel() string {
+	return biz.PaymentChannelAlipay
+}
+
+// 电脑网站支付，返回收银台链接
+func (g *alipayGateway) CreatePayment(ctx context.Context, req *biz.GatewayPayRequest) (*biz.GatewayPayResult, error) {
+	paymentURL, err := comment.CreateAlipayOrder(req.OrderID, req.Subject, req.Amount, strconv.Itoa(int(req.UserID)))
+	if err != nil {
+		return nil, err
+	}
+	return &biz.GatewayPayResult{PaymentURL: paymentURL}, nil
+}
+
+func (g *alipayGateway) ParsePaymentNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayPaymentResult, error) {
+	ok, err := comment.VerifyAlipayCallback(notify.Params)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("%w: 支付宝通知签名验证失败 %v", biz.ErrInvalidGatewayNotify, err)
+	}
+
+	callback := comment.ParseAlipayCallback(notify.Params)
+	return &biz.GatewayPaymentResult{
+		OrderID: callback.OrderID,
+		TradeNo: callback.TradeNo,
+		Status:  alipayPaymentStatus(callback.TradeStatus),
+		Amount:  callback.TotalAmount,
+		PayTime: parseGatewayTime(notify.Params["gmt_payment"], "2006-01-02 15:04:05"),
+	}, nil
+}
+
+func (g *alipayGateway) QueryPayment(ctx context.Context, orderID string) (*biz.GatewayPaymentResult, error) {
+	rsp, err := comment.QueryAlipayOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.IsFailure() {
+		// 用户未扫码时支付宝侧尚无交易
+		if rsp.SubCode == "ACQ.TRADE_NOT_EXIST" {
+			return &biz.GatewayPaymentResult{OrderID: orderID, Status: biz.PaymentStatusPending}, nil
+		}
+		return nil, fmt.Errorf("查询支付宝订单失败: %s %s", rsp.Msg, rsp.SubMsg)
+	}
+
+	return &biz.GatewayPaymentResult{
+		OrderID: orderID,
+		TradeNo: rsp.TradeNo,
+		Status:  alipayPaymentStatus(string(rsp.TradeStatus)),
+		Amount:  rsp.TotalAmount,
+		PayTime: parseGatewayTime(rsp.SendPayDate, "2006-01-02 15:04:05"),
+	}, nil
+}
+
+func alipayPaymentStatus(tradeStatus string) string {
+	switch tradeStatus {
+	case "TRADE_SUCCESS", "TRADE_FINISHED":
+		return biz.PaymentStatusPaid
+	case "TRADE_CLOSED":
+		return biz.PaymentStatusCancelled
+	default:
+		return biz.PaymentStatusPending
+	}
+}
+
+// 支付宝退款接口同步返回结果，成功即退款完成
+func (g *alipayGateway) Refund(ctx context.Context, req *biz.GatewayRefundRequest) (*biz.GatewayRefundResult, error) {
+	result, err := comment.RefundAlipayOrder(req.PaymentOrderID, req.TradeNo, req.RefundID, req.Amount, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.GatewayRefundResult{
+		RefundID:        req.RefundID,
+		GatewayRefundNo: result.TradeNo,
+		Status:          biz.RefundStatusSucceeded,
+		RefundTime:      time.Now(),
+	}, nil
+}
+
+// 解析支付宝退款通知
+func (g *alipayGateway) ParseRefundNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayRefundResult, error) {
+	ok, err := comment.VerifyAlipayCallback(notify.Params)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("%w: 退款通知签名验证失败 %v", biz.ErrInvalidGatewayNotify, err)
+	}
+
+	refund := comment.ParseAlipayRefundNotify(notify.Params)
+	if refund.RefundID == "" {
+		return nil, fmt.Errorf("退款通知缺少退款请求号")
+	}
+
+	return &biz.GatewayRefundResult{
+		RefundID:        refund.RefundID,
+		GatewayRefundNo: refund.TradeNo,
+		Status:          biz.RefundStatusSucceeded,
+		RefundTime:      parseGatewayTime(refund.RefundTime, "2006-01-02 15:04:05.000", "2006-01-02 15:04:05"),
+	}, nil
+}
+
+// 微信支付渠道，使用Native支付生成二维码链接
+type wechatGateway struct {
+	client *comment.WechatPayClient
+}
+
+func (g *wechatGateway) Channel() string {
+	return biz.PaymentChannelWechat
+}
+
+func (g *wechatGateway) CreatePayment(ctx context.Context, req *biz.GatewayPayRequest) (*biz.GatewayPayResult, error) {
+	total, err := yuanToFen(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	codeURL, err := g.client.NativePay(ctx, req.OrderID, req.Subject, total)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.GatewayPayResult{PaymentURL: codeURL}, nil
+}
+
+func (g *wechatGateway) ParsePaymentNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayPaymentResult, error) {
+	var transaction comment.WechatTransaction
+	if _, err := g.client.ParseNotify(notifyHeader(notify), notify.Body, &transaction); err != nil {
+		return nil, fmt.Errorf("%w: %v", biz.ErrInvalidGatewayNotify, err)
+	}
+	return toGatewayPaymentResult(&transaction), nil
+}
+
+func (g *wechatGateway) QueryPayment(ctx context.Context, orderID string) (*biz.GatewayPaymentResult, error) {
+	transaction, err := g.client.QueryOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return &biz.GatewayPaymentResult{OrderID: orderID, Status: biz.PaymentStatusPending}, nil
+	}
+	return toGatewayPaymentResult(transaction), nil
+}
+
+func toGatewayPaymentResult(transaction *comment.WechatTransaction) *biz.GatewayPaymentResult {
+	result := &biz.GatewayPaymentResult{
+		OrderID: transaction.OutTradeNo,
+		TradeNo: transaction.TransactionID,
+		Amount:  fenToYuan(transaction.Amount.Total),
+		PayTime: parseGatewayTime(transaction.SuccessTime, time.RFC3339),
+	}
+	switch transaction.TradeState {
+	case comment.WechatTradeStateSuccess, comment.WechatTradeStateRefund:
+		result.Status = biz.PaymentStatusPaid
+	case comment.WechatTradeStateClosed, comment.WechatTradeStateRevoked, comment.WechatTradeStatePayErr:
+		result.Status = biz.PaymentStatusCancelled
+	default:
+		result.Status = biz.PaymentStatusPending
+	}
+	return result
+}
+
+// 微信退款一般异步到账，受理后等待退款通知
+func (g *wechatGateway) Refund(ctx context.Context, req *biz.GatewayRefundRequest) (*biz.GatewayRefundResult, error) {
+	refund, err := yuanToFen(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	total, err := yuanToFen(req.TotalAmount)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := g.client.Refund(ctx, req.PaymentOrderID, req.RefundID, req.Reason, refund, total)
+	if err != nil {
+		return nil, err
+	}
+	return toGatewayRefundResult(rsp, rsp.Status), nil
+}
+
+func (g *wechatGateway) ParseRefundNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayRefundResult, error) {
+	var refund comment.WechatRefund
+	if _, err := g.client.ParseNotify(notifyHeader(notify), notify.Body, &refund); err != nil {
+		return nil, fmt.Errorf("%w: %v", biz.ErrInvalidGatewayNotify, err)
+	}
+	return toGatewayRefundResult(&refund, refund.RefundStatus), nil
+}
+
+func toGatewayRefundResult(refund *comment.WechatRefund, status string) *biz.GatewayRefundResult {
+	result := &biz.GatewayRefundResult{
+		RefundID:        refund.OutRefundNo,
+		GatewayRefundNo: refund.RefundID,
+		RefundTime:      parseGatewayTime(refund.SuccessTime, time.RFC3339),
+	}
+	if refund.Amount.Refund > 0 {
+		result.Amount = fenToYuan(refund.Amount.Refund)
+	}
+	switch status {
+	case comment.WechatRefundStatusSuccess:
+		result.Status = biz.RefundStatusSucceeded
+	case comment.WechatRefundStatusClosed, comment.WechatRefundStatusAbnormal:
+		result.Status = biz.RefundStatusFailed
+		result.FailReason = "微信退款" + status
+	default:
+		result.Status = biz.RefundStatusProcessing
+	}
+	return result
+}
+
+func notifyHeader(notify *biz.GatewayNotify) http.Header {
+	header := make(http.Header, len(notify.Header))
+	for k, v := range notify.Header {
+		header.Set(k, v)
+	}
+	return header
+}
+
+// 沙箱通知签名头
+const (
+	sandboxHeaderTimestamp = "Sandbox-Timestamp"
+	sandboxHeaderNonce     = "Sandbox-Nonce"
+	sandboxHeaderSignature = "Sandbox-Signature"
+)
+
+// 沙箱通知事件
+const (
+	sandboxEventPayment = "payment"
+	sandboxEventRefund  = "refund"
+)
+
+// 沙箱通知内容
+type sandboxNotify struct {
+	Event    string `json:"event"`
+	OrderID  string `json:"order_id"`
+	TradeNo  string `json:"trade_no"`
+	RefundID string `json:"refund_id,omitempty"`
+	Amount   string `json:"amount"`
+	Status   string `json:"status"`
+	Time     string `json:"time"`
+}
+
+// 沙箱交易
+type sandboxTrade struct {
+	amount   decimal.Decimal
+	tradeNo  string
+	status   string
+	payTime  time.Time
+	refunded decimal.Decimal
+}
+
+// 进程内模拟渠道，交易保存在内存中，通知以HMAC-SHA256签名，签名校验与真实渠道走同一条处理链路
+type sandboxGateway struct {
+	secret []byte
+
+	mu      sync.Mutex
+	trades  map[string]*sandboxTrade
+	refunds map[string]*biz.GatewayRefundResult
+}
+
+// 创建沙箱渠道，secret为空时随机生成
+func NewSandboxGateway(secret string) (biz.PaymentGateway, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	return &sandboxGateway{
+		secret:  key,
+		trades:  make(map[string]*sandboxTrade),
+		refunds: make(map[string]*biz.GatewayRefundResult),
+	}, nil
+}
+
+func (g *sandboxGateway) Channel() string {
+	return biz.PaymentChannelSandbox
+}
+
+// 登记交易，返回模拟收银台链接，访问该链接即完成支付
+func (g *sandboxGateway) CreatePayment(ctx context.Context, req *biz.GatewayPayRequest) (*biz.GatewayPayResult, error) {
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, fmt.Errorf("金额格式错误: %s", req.Amount)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.trades[req.OrderID]; ok {
+		return nil, fmt.Errorf("沙箱交易已存在: %s", req.OrderID)
+	}
+	g.trades[req.OrderID] = &sandboxTrade{amount: amount, status: biz.PaymentStatusPending}
+	return &biz.GatewayPayResult{PaymentURL: "/v1/payment/sandbox/pay?order_id=" + url.QueryEscape(req.OrderID)}, nil
+}
+
+// 模拟用户完成支付，重复调用返回相同交易号的通知
+func (g *sandboxGateway) SimulatePay(ctx context.Context, orderID string) (*biz.GatewayNotify, error) {
+	g.mu.Lock()
+	trade, ok := g.trades[orderID]
+	if !ok {
+		g.mu.Unlock()
+		return nil, fmt.Errorf("沙箱交易不存在: %s", orderID)
+	}
+	if trade.status == biz.PaymentStatusPending {
+		trade.status = biz.PaymentStatusPaid
+		trade.tradeNo = fmt.Sprintf("SANDBOX_%d", time.Now().UnixNano())
+		trade.payTime = time.Now()
+	}
+	notify := &sandboxNotify{
+		Event:   sandboxEventPayment,
+		OrderID: orderID,
+		TradeNo: trade.tradeNo,
+		Amount:  trade.amount.StringFixed(2),
+		Status:  trade.status,
+		Time:    trade.payTime.Format(time.RFC3339),
+	}
+	g.mu.Unlock()
+
+	return g.sign(notify)
+}
+
+func (g *sandboxGateway) ParsePaymentNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayPaymentResult, error) {
+	n, err := g.verify(notify, sandboxEventPayment)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.GatewayPaymentResult{
+		OrderID: n.OrderID,
+		TradeNo: n.TradeNo,
+		Status:  n.Status,
+		Amount:  n.Amount,
+		PayTime: parseGatewayTime(n.Time, time.RFC3339),
+	}, nil
+}
+
+func (g *sandboxGateway) QueryPayment(ctx context.Context, orderID string) (*biz.GatewayPaymentResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	trade, ok := g.trades[orderID]
+	if !ok {
+		return &biz.GatewayPaymentResult{OrderID: orderID, Status: biz.PaymentStatusPending}, nil
+	}
+	return &biz.GatewayPaymentResult{
+		OrderID: orderID,
+		TradeNo: trade.tradeNo,
+		Status:  trade.status,
+		Amount:  trade.amount.StringFixed(2),
+		PayTime: trade.payTime,
+	}, nil
+}
+
+// 退款同步完成，同一退款单号重复提交返回首次结果
+func (g *sandboxGateway) Refund(ctx context.Context, req *biz.GatewayRefundRequest) (*biz.GatewayRefundResult, error) {
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, fmt.Errorf("退款金额格式错误: %s", req.Amount)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if result, ok := g.refunds[req.RefundID]; ok {
+		return result, nil
+	}
+	trade, ok := g.trades[req.PaymentOrderID]
+	if !ok || trade.status != biz.PaymentStatusPaid {
+		return nil, fmt.Errorf("沙箱交易不存在或未支付: %s", req.PaymentOrderID)
+	}
+
+	result := &biz.GatewayRefundResult{
+		RefundID:        req.RefundID,
+		GatewayRefundNo: "SANDBOX_" + req.RefundID,
+		Amount:          amount.StringFixed(2),
+		RefundTime:      time.Now(),
+	}
+	if trade.refunded.Add(amount).GreaterThan(trade.amount) {
+		result.Status = biz.RefundStatusFailed
+		result.FailReason = "退款金额超过可退金额"
+	} else {
+		trade.refunded = trade.refunded.Add(amount)
+		result.Status = biz.RefundStatusSucceeded
+	}
+	g.refunds[req.RefundID] = result
+	return result, nil
+}
+
+func (g *sandboxGateway) ParseRefundNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayRefundResult, error) {
+	n, err := g.verify(notify, sandboxEventRefund)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.GatewayRefundResult{
+		RefundID:        n.RefundID,
+		GatewayRefundNo: n.TradeNo,
+		Status:          n.Status,
+		Amount:          n.Amount,
+		RefundTime:      parseGatewayTime(n.Time, time.RFC3339),
+	}, nil
+}
+
+// 签名通知，签名串与微信支付一致: 时间戳\n随机串\n报文\n
+func (g *sandboxGateway) sign(n *sandboxNotify) (*biz.GatewayNotify, error) {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonceStr := hex.EncodeToString(nonce)
+	return &biz.GatewayNotify{
+		Header: map[string]string{
+			sandboxHeaderTimestamp: timestamp,
+			sandboxHeaderNonce:     nonceStr,
+			sandboxHeaderSignature: g.signature(timestamp, nonceStr, body),
+		},
+		Body: body,
+	}, nil
+}
+
+func (g *sandboxGateway) verify(notify *biz.GatewayNotify, event string) (*sandboxNotify, error) {
+	header := notifyHeader(notify)
+	expected := g.signature(header.Get(sandboxHeaderTimestamp), header.Get(sandboxHeaderNonce), notify.Body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get(sandboxHeaderSignature))) {
+		return nil, fmt.Errorf("%w: 沙箱通知签名不匹配", biz.ErrInvalidGatewayNotify)
+	}
+
+	var n sandboxNotify
+	if err := json.Unmarshal(notify.Body, &n); err != nil {
+		return nil, fmt.Errorf("%w: %v", biz.ErrInvalidGatewayNotify, err)
+	}
+	if n.Event != event {
+		return nil, fmt.Errorf("%w: 沙箱通知事件不匹配 %s", biz.ErrInvalidGatewayNotify, n.Event)
+	}
+	return &n, nil
+}
+
+func (g *sandboxGateway) signature(timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(timestamp + "\n" + nonce + "\n" + string(body) + "\n"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,271 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+// 测试沙箱渠道的下单、通知验签和主动查询
+func TestSandboxPaymentFlow(t *testing.T) {
+	d := newOrderTestData(t)
+	gateways := NewPaymentGateways(&conf.Payment{Sandbox: &conf.Payment_Sandbox{Enabled: true, Secret: "test"}}, newTestLogger())
+	logger := newTestLogger()
+	uc := biz.NewPaymentUsecase(NewPaymentRepo(d, logger), biz.NewIdempotencyUsecase(NewIdempotencyRepo(nil, d, logger), logger), gateways, logger)
+	ctx := context.Background()
+
+	order, err := uc.CreatePaymentOrder(ctx, 1001, biz.PayTypeWechat, "药品订单", "25.50", biz.OrderTypeDrug, "ORDER_1", "", "")
+	if err != nil {
+		t.Fatalf("CreatePaymentOrder failed: %v", err)
+	}
+	if order.Channel != biz.PaymentChannelSandbox {
+		t.Fatalf("Expected sandbox channel, got %s", order.Channel)
+	}
+	if !strings.Contains(order.PaymentURL, order.OrderID) {
+		t.Errorf("Expected payment url for %s, got %s", order.OrderID, order.PaymentURL)
+	}
+	synced, err := uc.SyncPaymentOrder(ctx, order.OrderID)
+	if err != nil || synced.Status != biz.PaymentStatusPending {
+		t.Fatalf("Expected pending before pay, got %v, %v", synced, err)
+	}
+
+	// 篡改金额的通知验签失败
+	gateway, _ := gateways.ByChannel(biz.PaymentChannelSandbox)
+	notify, err := gateway.(biz.PaymentSimulator).SimulatePay(ctx, order.OrderID)
+	if err != nil {
+		t.Fatalf("SimulatePay failed: %v", err)
+	}
+	notify.Body = bytes.Replace(notify.Body, []byte(`"25.50"`), []byte(`"0.01"`), 1)
+	if err := uc.HandlePaymentNotify(ctx, biz.PaymentChannelSandbox, notify); !errors.Is(err, biz.ErrInvalidGatewayNotify) {
+		t.Fatalf("Expected ErrInvalidGatewayNotify, got %v", err)
+	}
+	payment, _ := uc.GetPaymentOrder(ctx, order.OrderID)
+	if payment.Status != biz.PaymentStatusPending {
+		t.Fatalf("Expected pending after forged notify, got %s", payment.Status)
+	}
+
+	// 通知丢失时主动查询补偿
+	synced, err = uc.SyncPaymentOrder(ctx, order.OrderID)
+	if err != nil {
+		t.Fatalf("SyncPaymentOrder failed: %v", err)
+	}
+	if synced.Status != biz.PaymentStatusPaid || synced.TradeNo == "" {
+		t.Errorf("Expected paid with trade no, got %s %q", synced.Status, synced.TradeNo)
+	}
+
+	// 模拟支付走通知链路，重复通知幂等
+	second, err := uc.CreatePaymentOrder(ctx, 1001, biz.PayTypeAlipay, "药品订单", "12.5", biz.OrderTypeDrug, "ORDER_2", "", "")
+	if err != nil {
+		t.Fatalf("CreatePaymentOrder failed: %v", err)
+	}
+	paid, err := uc.SimulatePayment(ctx, second.OrderID)
+	if err != nil {
+		t.Fatalf("SimulatePayment failed: %v", err)
+	}
+	if paid.Status != biz.PaymentStatusPaid {
+		t.Errorf("Expected paid, got %s", paid.Status)
+	}
+	if _, err := uc.SimulatePayment(ctx, second.OrderID); err != nil {
+		t.Errorf("Repeated SimulatePayment failed: %v", err)
+	}
+
+	// 未知的支付方式
+	if _, err := uc.CreatePaymentOrder(ctx, 1001, "4", "药品订单", "1.00", biz.OrderTypeDrug, "ORDER_3", "", ""); !errors.Is(err, biz.ErrPaymentGatewayUnavailable) {
+		t.Errorf("Expected ErrPaymentGatewayUnavailable for unknown pay type, got %v", err)
+	}
+}
+
+// 测试药品订单经沙箱渠道下单、支付通知后订单变为已支付并扣减库存
+func TestSandboxDrugOrderPayment(t *testing.T) {
+	d := newOrderTestData(t)
+	orderUc, paymentUc := newTestOrderPaymentUsecase(d, newTestSandboxGateways())
+	ctx := context.Background()
+
+	order := createTestOrder(t, orderUc, 2)
+	payment, err := paymentUc.CreatePaymentOrder(ctx, 1001, biz.PayTypeWechat, "药品订单", order.TotalAmount.StringFixed(2), biz.OrderTypeDrug, order.OrderNo, "", "")
+	if err != nil {
+		t.Fatalf("CreatePaymentOrder failed: %v", err)
+	}
+	paid, err := paymentUc.SimulatePayment(ctx, payment.OrderID)
+	if err != nil || paid.Status != biz.PaymentStatusPaid {
+		t.Fatalf("Expected payment paid, got %v, %v", paid, err)
+	}
+
+	detail, err := orderUc.GetOrder(ctx, order.OrderNo)
+	if err != nil || detail.Order.Status != biz.OrderStatusPaid {
+		t.Fatalf("Expected order status %s, got %+v, %v", biz.OrderStatusPaid, detail, err)
+	}
+	inv := getStoreInventory(t, NewDrugInventoryRepo(d, newTestLogger()))
+	if inv.Quantity != 98 || inv.ReservedQty != 0 {
+		t.Errorf("Expected on-hand 98/reserved 0 after commit, got %d/%d", inv.Quantity, inv.ReservedQty)
+	}
+	logs, _ := orderUc.ListOrderStatusLogs(ctx, order.OrderNo)
+	if last := logs[len(logs)-1]; last.ToStatus != biz.OrderStatusPaid || !strings.Contains(last.Reason, paid.TradeNo) {
+		t.Errorf("Expected paid log with trade no %s, got %+v", paid.TradeNo, last)
+	}
+
+	// 重复通知不重复扣减库存
+	if err := orderUc.OnPaymentPaid(ctx, paid); err != nil {
+		t.Fatalf("Expected duplicate notify ignored, got %v", err)
+	}
+	if inv := getStoreInventory(t, NewDrugInventoryRepo(d, newTestLogger())); inv.Quantity != 98 {
+		t.Errorf("Expected on-hand 98 after duplicate notify, got %d", inv.Quantity)
+	}
+}
+
+// 测试创建支付单前按订单确定应付金额：只能支付本人待支付的订单
+func TestGetPayableOrder(t *testing.T) {
+	d := newOrderTestData(t)
+	uc := newTestOrderUsecase(d)
+	ctx := context.Background()
+
+	order := createTestOrder(t, uc, 2)
+	payable, err := uc.GetPayableOrder(ctx, 1001, order.OrderNo)
+	if err != nil || payable == nil || !payable.TotalAmount.Equal(order.TotalAmount) {
+		t.Fatalf("Expected payable order %s, got %+v, %v", order.OrderNo, payable, err)
+	}
+	if payable, err := uc.GetPayableOrder(ctx, 1002, order.OrderNo); err != nil || payable != nil {
+		t.Errorf("Expected other user's order hidden, got %+v, %v", payable, err)
+	}
+	if payable, err := uc.GetPayableOrder(ctx, 1001, "ORD_NOPE"); err != nil || payable != nil {
+		t.Errorf("Expected missing order nil, got %+v, %v", payable, err)
+	}
+	if err := uc.ProcessPayment(ctx, order.OrderNo, &biz.PaymentInfo{PayType: "2", Amount: order.TotalAmount, PaymentTime: time.Now()}); err != nil {
+		t.Fatalf("ProcessPayment failed: %v", err)
+	}
+	if _, err := uc.GetPayableOrder(ctx, 1001, order.OrderNo); !errors.Is(err, biz.ErrInvalidOrderStatusTransition) {
+		t.Errorf("Expected paid order not payable, got %v", err)
+	}
+}
+
+// 测试微信支付请求签名、应答验签以及通知解密
+func TestWechatGateway(t *testing.T) {
+	merchantKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	platformKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	const apiV3Key = "0123456789abcdef0123456789abcdef"
+
+	signPlatform := func(h http.Header, body []byte) {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		digest := sha256.Sum256([]byte(ts + "\nnonce\n" + string(body) + "\n"))
+		sig, _ := rsa.SignPKCS1v15(rand.Reader, platformKey, crypto.SHA256, digest[:])
+		h.Set("Wechatpay-Timestamp", ts)
+		h.Set("Wechatpay-Nonce", "nonce")
+		h.Set("Wechatpay-Signature", base64.StdEncoding.EncodeToString(sig))
+		h.Set("Wechatpay-Serial", "PUB_KEY_ID_1")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		auth := r.Header.Get("Authorization")
+		fields := map[string]string{}
+		for _, kv := range strings.Split(strings.TrimPrefix(auth, "WECHATPAY2-SHA256-RSA2048 "), ",") {
+			parts := strings.SplitN(kv, "=", 2)
+			fields[parts[0]] = strings.Trim(parts[1], `"`)
+		}
+		message := r.Method + "\n" + r.URL.RequestURI() + "\n" + fields["timestamp"] + "\n" + fields["nonce_str"] + "\n" + string(body) + "\n"
+		digest := sha256.Sum256([]byte(message))
+		sig, _ := base64.StdEncoding.DecodeString(fields["signature"])
+		if fields["mchid"] != "1900000001" || rsa.VerifyPKCS1v15(&merchantKey.PublicKey, crypto.SHA256, digest[:], sig) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"SIGN_ERROR","message":"签名错误"}`))
+			return
+		}
+		var req struct {
+			Amount comment.WechatAmount `json:"amount"`
+		}
+		json.Unmarshal(body, &req)
+		if req.Amount.Total != 2550 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rsp := []byte(`{"code_url":"weixin://wxpay/bizpayurl?pr=test"}`)
+		signPlatform(w.Header(), rsp)
+		w.Write(rsp)
+	}))
+	defer server.Close()
+
+	merchantPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(merchantKey)})
+	platformDER, _ := x509.MarshalPKIXPublicKey(&platformKey.PublicKey)
+	platformPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: platformDER})
+	gateways := NewPaymentGateways(&conf.Payment{Wechat: &conf.Payment_Wechat{
+		AppId:             "wx0000000000000001",
+		MchId:             "1900000001",
+		SerialNo:          "SERIAL",
+		PrivateKey:        string(merchantPEM),
+		ApiV3Key:          apiV3Key,
+		PlatformPublicKey: string(platformPEM),
+		PlatformSerial:    "PUB_KEY_ID_1",
+		BaseUrl:           server.URL,
+	}}, newTestLogger())
+	gateway, err := gateways.ByPayType(biz.PayTypeWechat)
+	if err != nil {
+		t.Fatalf("Expected wechat gateway: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := gateway.CreatePayment(ctx, &biz.GatewayPayRequest{OrderID: "PAY_1", Subject: "药品订单", Amount: "25.50"})
+	if err != nil {
+		t.Fatalf("CreatePayment failed: %v", err)
+	}
+	if result.PaymentURL != "weixin://wxpay/bizpayurl?pr=test" {
+		t.Errorf("Unexpected code url %s", result.PaymentURL)
+	}
+
+	// 构造平台签名的加密通知
+	resource := []byte(`{"out_trade_no":"PAY_1","transaction_id":"4200000001","trade_state":"SUCCESS","success_time":"2026-10-18T10:00:00+08:00","amount":{"total":2550,"payer_total":2550}}`)
+	block, _ := aes.NewCipher([]byte(apiV3Key))
+	gcm, _ := cipher.NewGCM(block)
+	nonce := "0123456789ab"
+	ciphertext := gcm.Seal(nil, []byte(nonce), resource, []byte("transaction"))
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":            "EV-1",
+		"event_type":    "TRANSACTION.SUCCESS",
+		"resource_type": "encrypt-resource",
+		"resource": map[string]string{
+			"algorithm":       "AEAD_AES_256_GCM",
+			"ciphertext":      base64.StdEncoding.EncodeToString(ciphertext),
+			"associated_data": "transaction",
+			"nonce":           nonce,
+		},
+	})
+	header := http.Header{}
+	signPlatform(header, body)
+	notify := &biz.GatewayNotify{Header: map[string]string{}, Body: body}
+	for k := range header {
+		notify.Header[strings.ToLower(k)] = header.Get(k)
+	}
+
+	paid, err := gateway.ParsePaymentNotify(ctx, notify)
+	if err != nil {
+		t.Fatalf("ParsePaymentNotify failed: %v", err)
+	}
+	if paid.OrderID != "PAY_1" || paid.Status != biz.PaymentStatusPaid || paid.Amount != "25.50" || paid.TradeNo != "4200000001" {
+		t.Errorf("Unexpected payment result %+v", paid)
+	}
+
+	notify.Body = bytes.Replace(body, []byte("EV-1"), []byte("EV-2"), 1)
+	if _, err := gateway.ParsePaymentNotify(ctx, notify); !errors.Is(err, biz.ErrInvalidGatewayNotify) {
+		t.Errorf("Expected ErrInvalidGatewayNotify for tampered body, got %v", err)
+	}
+}
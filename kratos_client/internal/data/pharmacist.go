@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+)
+
+// 药师账号数据模型
+type MtPharmacist struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name          string     `gorm:"column:name;size:50;not null"`
+	Phone         string     `gorm:"column:phone;size:20;not null;uniqueIndex"`
+	LicenseNo     string     `gorm:"column:license_no;size:50"`
+	PasswordHash  string     `gorm:"column:password_hash;size:64"`
+	Salt          string     `gorm:"column:salt;size:32"`
+	Status        int32      `gorm:"column:status;not null;default:1"`
+	LastLoginTime *time.Time `gorm:"column:last_login_time"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
+
+func (MtPharmacist) TableName() string {
+	return "mt_pharmacists"
+}
+
+type pharmacistRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建药师仓储
+func NewPharmacistRepo(data *Data, logger log.Logger) biz.PharmacistRepo {
+	return &pharmacistRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *pharmacistRepo) FindByID(ctx context.Context, id int64) (*biz.Pharmacist, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+func (r *pharmacistRepo) FindByPhone(ctx context.Context, phone string) (*biz.Pharmacist, error) {
+	return r.find(ctx, "phone = ?", phone)
+}
+
+// 记录最后登录时间
+func (r *pharmacistRepo) UpdateLastLogin(ctx context.Context, id int64, at time.Time) error {
+	return r.data.Db.WithContext(ctx).Model(&MtPharmacist{}).Where("id = ?", id).
+		UpdateColumn("last_login_time", at).Error
+}
+
+// 更新登录密码哈希，同时清空盐值
+func (r *pharmacistRepo) UpdatePasswordHash(ctx context.Context, id int64, hash string) error {
+	return r.data.Db.WithContext(ctx).Model(&MtPharmacist{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"password_hash": hash, "salt": ""}).Error
+}
+
+func (r *pharmacistRepo) find(ctx context.Context, query string, arg interface{}) (*biz.Pharmacist, error) {
+	var pharmacist MtPharmacist
+	err := r.data.Db.WithContext(ctx).Where(query, arg).First(&pharmacist).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Errorf("查询药师失败: %v", err)
+		return nil, err
+	}
+	result := &biz.Pharmacist{
+		ID:           pharmacist.ID,
+		Name:         pharmacist.Name,
+		Phone:        pharmacist.Phone,
+		LicenseNo:    pharmacist.LicenseNo,
+		PasswordHash: pharmacist.PasswordHash,
+		Salt:         pharmacist.Salt,
+		Status:       pharmacist.Status,
+		CreatedAt:    pharmacist.CreatedAt,
+		UpdatedAt:    pharmacist.UpdatedAt,
+	}
+	if pharmacist.LastLoginTime != nil {
+		result.LastLoginTime = *pharmacist.LastLoginTime
+	}
+	return result, nil
+}
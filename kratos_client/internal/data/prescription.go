@@ -2,6 +2,8 @@ package data
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -25,6 +27,10 @@ type MtPrescription struct {
 	AuditorID         *uint64         `gorm:"column:auditor_id" json:"auditor_id"`
 	AuditTime         *time.Time      `gorm:"column:audit_time" json:"audit_time"`
 	AuditNotes        string          `gorm:"column:audit_notes;size:500" json:"audit_notes"`
+	ConsultationNo    string          `gorm:"column:consultation_no;size:64" json:"consultation_no"`
+	OrderNo           string          `gorm:"column:order_no;size:64" json:"order_no"`
+	ExpiresAt         *time.Time      `gorm:"column:expires_at" json:"expires_at"`
+	Populations       string          `gorm:"column:patient_populations;size:64" json:"patient_populations"`
 	CreatedAt         time.Time       `gorm:"column:created_at;autoCreateTime" json:"created_at"`
 	UpdatedAt         time.Time       `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
 }
@@ -70,7 +76,7 @@ func NewPrescriptionRepo(data *Data, logger log.Logger) biz.PrescriptionRepo {
 
 // 转换数据模型到业务模型
 func (r *prescriptionRepo) toBizPrescription(do *MtPrescription) *biz.MtPrescription {
-	return &biz.MtPrescription{
+	prescription := &biz.MtPrescription{
 		ID:                do.ID,
 		PrescriptionNo:    do.PrescriptionNo,
 		DoctorID:          do.DoctorID,
@@ -84,9 +90,26 @@ func (r *prescriptionRepo) toBizPrescription(do *MtPrescription) *biz.MtPrescrip
 		AuditorID:         do.AuditorID,
 		AuditTime:         do.AuditTime,
 		AuditNotes:        do.AuditNotes,
+		ConsultationNo:    do.ConsultationNo,
+		OrderNo:           do.OrderNo,
 		CreatedAt:         do.CreatedAt,
 		UpdatedAt:         do.UpdatedAt,
 	}
+	if do.ExpiresAt != nil {
+		prescription.ExpiresAt = *do.ExpiresAt
+	}
+	if do.Populations != "" {
+		prescription.Populations = strings.Split(do.Populations, ",")
+	}
+	return prescription
+}
+
+// 订单事务中使用事务连接
+func (r *prescriptionRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
 }
 
 // 转换处方药品明细
@@ -251,6 +274,107 @@ func (r *prescriptionRepo) GetPrescriptionByID(ctx context.Context, id uint64) (
 	return bizPrescription, nil
 }
 
+// 在同一事务内创建处方和药品明细
+func (r *prescriptionRepo) CreatePrescription(ctx context.Context, prescription *biz.MtPrescription, medicines []*biz.MtPrescriptionMedicine) error {
+	do := &MtPrescription{
+		PrescriptionNo:   prescription.PrescriptionNo,
+		DoctorID:         prescription.DoctorID,
+		PatientID:        prescription.PatientID,
+		MedicalRecordID:  prescription.MedicalRecordID,
+		PrescriptionDate: prescription.PrescriptionDate,
+		TotalAmount:      prescription.TotalAmount,
+		PrescriptionType: prescription.PrescriptionType,
+		UsageInstruction: prescription.UsageInstruction,
+		Status:           prescription.Status,
+		ConsultationNo:   prescription.ConsultationNo,
+		Populations:      strings.Join(prescription.Populations, ","),
+	}
+	if !prescription.ExpiresAt.IsZero() {
+		do.ExpiresAt = &prescription.ExpiresAt
+	}
+	err := r.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(do).Error; err != nil {
+			return err
+		}
+		lines := make([]*MtPrescriptionMedicine, len(medicines))
+		for i, medicine := range medicines {
+			lines[i] = &MtPrescriptionMedicine{
+				PrescriptionID: do.ID,
+				MedicineID:     medicine.MedicineID,
+				Quantity:       medicine.Quantity,
+				Unit:           medicine.Unit,
+				UnitPrice:      medicine.UnitPrice,
+				TotalPrice:     medicine.TotalPrice,
+				Dosage:         medicine.Dosage,
+				Frequency:      medicine.Frequency,
+				Duration:       medicine.Duration,
+				UsageMethod:    medicine.UsageMethod,
+				Notes:          medicine.Notes,
+			}
+		}
+		return tx.Create(&lines).Error
+	})
+	if err != nil {
+		r.log.Errorf("创建处方失败: %v", err)
+		return err
+	}
+	prescription.ID = do.ID
+	prescription.CreatedAt = do.CreatedAt
+	prescription.UpdatedAt = do.UpdatedAt
+	return nil
+}
+
+// 以当前状态为条件更新处方状态
+func (r *prescriptionRepo) UpdatePrescriptionStatus(ctx context.Context, id uint64, fromStatus string, update *biz.PrescriptionUpdate) error {
+	updates := map[string]interface{}{
+		"status":     update.Status,
+		"updated_at": time.Now(),
+	}
+	if update.AuditorID != 0 {
+		updates["auditor_id"] = update.AuditorID
+	}
+	if !update.AuditTime.IsZero() {
+		updates["audit_time"] = update.AuditTime
+	}
+	if update.AuditNotes != "" {
+		updates["audit_notes"] = update.AuditNotes
+	}
+	if update.OrderNo != "" {
+		updates["order_no"] = update.OrderNo
+	}
+
+	result := r.getDB(ctx).Model(&MtPrescription{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(updates)
+	if result.Error != nil {
+		r.log.Errorf("更新处方状态失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id=%d, from=%s, to=%s", biz.ErrPrescriptionStatusConflict, id, fromStatus, update.Status)
+	}
+	return nil
+}
+
+// 订单取消时释放处方
+func (r *prescriptionRepo) ReleasePrescription(ctx context.Context, id uint64, orderNo string) error {
+	result := r.getDB(ctx).Model(&MtPrescription{}).
+		Where("id = ? AND status = ? AND order_no = ?", id, biz.PrescriptionStatusOrdered, orderNo).
+		Updates(map[string]interface{}{
+			"status":     biz.PrescriptionStatusAudited,
+			"order_no":   "",
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		r.log.Errorf("释放处方失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id=%d, orderNo=%s", biz.ErrPrescriptionStatusConflict, id, orderNo)
+	}
+	return nil
+}
+
 // 获取处方药品明细
 func (r *prescriptionRepo) GetPrescriptionMedicines(ctx context.Context, prescriptionID uint64) ([]*biz.MtPrescriptionMedicine, error) {
 	var medicines []MtPrescriptionMedicine
@@ -275,12 +399,10 @@ func (r *prescriptionRepo) GetPrescriptionMedicines(ctx context.Context, prescri
 
 // 填充处方扩展信息
 func (r *prescriptionRepo) fillPrescriptionExtInfo(ctx context.Context, prescription *biz.MtPrescription) {
-	// 这里可以根据实际情况填充医生名称、患者名称、审核医生名称等
-	// 由于没有用户表的具体结构，这里先用占位符
-	prescription.DoctorName = "医生姓名" // 实际应该从用户表查询
-	prescription.PatientName = "患者姓名" // 实际应该从用户表查询
+	prescription.DoctorName = r.lookupName(ctx, "mt_doctors", "name", prescription.DoctorID)
+	prescription.PatientName = r.lookupName(ctx, "mt_user", "nick_name", prescription.PatientID)
 	if prescription.AuditorID != nil {
-		prescription.AuditorName = "审核医生姓名" // 实际应该从用户表查询
+		prescription.AuditorName = r.lookupName(ctx, "mt_pharmacists", "name", *prescription.AuditorID)
 	}
 
 	// 统计药品种类数量
@@ -294,9 +416,27 @@ func (r *prescriptionRepo) fillPrescriptionExtInfo(ctx context.Context, prescrip
 
 // 填充药品扩展信息
 func (r *prescriptionRepo) fillMedicineExtInfo(ctx context.Context, medicine *biz.MtPrescriptionMedicine) {
-	// 这里可以根据实际情况从药品表查询药品名称、规格、厂家等信息
-	// 由于没有药品表的具体结构，这里先用占位符
-	medicine.MedicineName = "药品名称" // 实际应该从药品表查询
-	medicine.MedicineSpec = "药品规格" // 实际应该从药品表查询
-	medicine.Manufacturer = "生产厂家" // 实际应该从药品表查询
-}
\ No newline at end of file
+	var drug biz.MtDrug
+	err := r.data.Db.WithContext(ctx).Select("drug_name", "specification", "manufacturer").
+		Where("id = ?", medicine.MedicineID).Limit(1).Find(&drug).Error
+	if err != nil {
+		r.log.Errorf("查询处方药品信息失败: medicineId=%d, error=%v", medicine.MedicineID, err)
+		return
+	}
+	medicine.MedicineName = drug.DrugName
+	medicine.MedicineSpec = drug.Specification
+	medicine.Manufacturer = drug.Manufacturer
+}
+
+// 按ID查询名称，查询失败或记录不存在时返回空字符串
+func (r *prescriptionRepo) lookupName(ctx context.Context, table, column string, id uint64) string {
+	var names []string
+	if err := r.data.Db.WithContext(ctx).Table(table).Where("id = ?", id).Limit(1).Pluck(column, &names).Error; err != nil {
+		r.log.Errorf("查询%s.%s失败: id=%d, error=%v", table, column, id, err)
+		return ""
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
@@ -0,0 +1,383 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+type prescriptionTestEnv struct {
+	*consultationTestEnv
+	uc           *biz.PrescriptionUsecase
+	pharmacistUc *biz.PharmacistUsecase
+	repo         biz.PrescriptionRepo
+	consultation *biz.Consultation
+	pharmacistID int64
+}
+
+// 在问诊测试环境上创建接诊中的问诊、启用和停用的药师各一名以及患者的默认收货地址
+func newPrescriptionTestEnv(t *testing.T) *prescriptionTestEnv {
+	t.Helper()
+	env := newConsultationTestEnv(t, &MtPrescription{}, &MtPrescriptionMedicine{}, &MtPharmacist{}, &biz.MtAddress{})
+	ctx := context.Background()
+
+	pharmacists := []*MtPharmacist{
+		{Name: "李药师", Phone: "13900000001", PasswordHash: testPasswordHash(t, "secret"), Status: biz.PharmacistStatusEnabled},
+		{Name: "王药师", Phone: "13900000002", PasswordHash: testPasswordHash(t, "secret"), Status: biz.PharmacistStatusDisabled},
+	}
+	if err := env.d.Db.Create(&pharmacists).Error; err != nil {
+		t.Fatalf("创建测试药师失败: %v", err)
+	}
+	// 状态列有默认值，零值需单独更新
+	if err := env.d.Db.Model(pharmacists[1]).Update("status", biz.PharmacistStatusDisabled).Error; err != nil {
+		t.Fatalf("停用测试药师失败: %v", err)
+	}
+	address := &biz.MtAddress{UserId: testPatientID, Consignee: "测试用户", Mobile: "13800138000", ShippingAddress: "北京市朝阳区", DoorplateFloor: "1号楼", IsDefault: true}
+	if err := env.d.Db.Create(address).Error; err != nil {
+		t.Fatalf("创建测试地址失败: %v", err)
+	}
+
+	consultation := env.paidConsultation(t, "咳嗽三天")
+	consultation, err := env.uc.AcceptConsultation(ctx, testDoctorID, consultation.ConsultationNo, time.Hour)
+	if err != nil {
+		t.Fatalf("AcceptConsultation failed: %v", err)
+	}
+
+	logger := newTestLogger()
+	repo := NewPrescriptionRepo(env.d, logger)
+	pharmacistRepo := NewPharmacistRepo(env.d, logger)
+	uc := biz.NewPrescriptionUsecase(repo, pharmacistRepo, NewConsultationRepo(env.d, logger), NewDrugRepo(env.d, logger),
+		NewCityRepo(env.d, logger), newTestOrderUsecase(env.d), newTestInteractionUsecase(env.d), logger)
+	return &prescriptionTestEnv{
+		consultationTestEnv: env,
+		uc:                  uc,
+		pharmacistUc:        biz.NewPharmacistUsecase(pharmacistRepo, biz.NewLoginThrottle(NewLoginAttemptRepo(env.d, logger), logger), logger),
+		repo:                repo,
+		consultation:        consultation,
+		pharmacistID:        pharmacists[0].ID,
+	}
+}
+
+// 为接诊中的问诊开具2盒感冒灵颗粒
+func (e *prescriptionTestEnv) issue(t *testing.T) *biz.MtPrescription {
+	t.Helper()
+	detail, err := e.uc.IssuePrescription(context.Background(), &biz.IssuePrescriptionRequest{
+		DoctorID:       testDoctorID,
+		ConsultationNo: e.consultation.ConsultationNo,
+		Medicines: []*biz.IssuePrescriptionMedicine{
+			{MedicineID: 1, Quantity: 2, Unit: "盒", Dosage: "1袋", Frequency: "每日3次", Duration: "3天", UsageMethod: "冲服"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("IssuePrescription failed: %v", err)
+	}
+	return detail.Prescription
+}
+
+func TestPharmacistLogin(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ name, phone, password string }{
+		{"密码错误", "13900000001", "wrong"},
+		{"账号不存在", "13900000009", "secret"},
+		{"账号已停用", "13900000002", "secret"},
+	} {
+		if _, err := env.pharmacistUc.Login(ctx, tc.phone, tc.password); !errors.Is(err, biz.ErrPharmacistLoginFailed) {
+			t.Errorf("%s: expected ErrPharmacistLoginFailed, got %v", tc.name, err)
+		}
+	}
+
+	pharmacist, err := env.pharmacistUc.Login(ctx, " 13900000001 ", "secret")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if pharmacist.ID != env.pharmacistID || pharmacist.LastLoginTime.IsZero() {
+		t.Errorf("Unexpected pharmacist %+v", pharmacist)
+	}
+
+	// 连续失败5次后锁定，正确密码也无法登录
+	for i := 0; i < 5; i++ {
+		if _, err := env.pharmacistUc.Login(ctx, "13900000001", "wrong"); !errors.Is(err, biz.ErrPharmacistLoginFailed) {
+			t.Fatalf("Attempt %d: expected ErrPharmacistLoginFailed, got %v", i+1, err)
+		}
+	}
+	if _, err := env.pharmacistUc.Login(ctx, "13900000001", "secret"); !errors.Is(err, biz.ErrLoginLocked) {
+		t.Errorf("Expected login locked, got %v", err)
+	}
+}
+
+func TestIssuePrescription(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+
+	valid := []*biz.IssuePrescriptionMedicine{{MedicineID: 1, Quantity: 2, Unit: "盒"}}
+	for _, tc := range []struct {
+		name string
+		req  *biz.IssuePrescriptionRequest
+	}{
+		{"非接诊医生", &biz.IssuePrescriptionRequest{DoctorID: 8, ConsultationNo: env.consultation.ConsultationNo, Medicines: valid}},
+		{"问诊不存在", &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: "CONS_NOT_EXIST", Medicines: valid}},
+		{"没有药品", &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: env.consultation.ConsultationNo}},
+		{"数量不合法", &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: env.consultation.ConsultationNo,
+			Medicines: []*biz.IssuePrescriptionMedicine{{MedicineID: 1, Quantity: 0, Unit: "盒"}}}},
+		{"药品重复", &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: env.consultation.ConsultationNo,
+			Medicines: append(valid, valid...)}},
+		{"药品不存在", &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: env.consultation.ConsultationNo,
+			Medicines: []*biz.IssuePrescriptionMedicine{{MedicineID: 99, Quantity: 1, Unit: "盒"}}}},
+		{"处方类型不合法", &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: env.consultation.ConsultationNo,
+			PrescriptionType: "保健品", Medicines: valid}},
+	} {
+		if _, err := env.uc.IssuePrescription(ctx, tc.req); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+
+	// 未接诊的问诊不能开处方
+	waiting := &MtConsultation{ConsultationNo: "CONS_WAITING", PatientID: testPatientID + 1, DoctorID: testDoctorID, Status: biz.ConsultationStatusWaiting}
+	if err := env.d.Db.Create(waiting).Error; err != nil {
+		t.Fatalf("创建待接诊问诊失败: %v", err)
+	}
+	if _, err := env.uc.IssuePrescription(ctx, &biz.IssuePrescriptionRequest{DoctorID: testDoctorID, ConsultationNo: waiting.ConsultationNo, Medicines: valid}); err == nil {
+		t.Error("Expected error for waiting consultation")
+	}
+
+	prescription := env.issue(t)
+	if prescription.Status != biz.PrescriptionStatusIssued || prescription.PatientID != uint64(testPatientID) ||
+		prescription.ConsultationNo != env.consultation.ConsultationNo || prescription.PrescriptionType != biz.PrescriptionTypeWestern {
+		t.Errorf("Unexpected prescription %+v", prescription)
+	}
+	if prescription.TotalAmount.String() != "25" {
+		t.Errorf("Expected total 25, got %s", prescription.TotalAmount)
+	}
+	if prescription.DoctorName != "张医生" || prescription.MedicineCount != 1 {
+		t.Errorf("Expected doctor name and medicine count, got %+v", prescription)
+	}
+	detail, err := env.uc.GetPrescriptionDetail(ctx, prescription.ID)
+	if err != nil {
+		t.Fatalf("GetPrescriptionDetail failed: %v", err)
+	}
+	medicine := detail.Medicines[0]
+	if medicine.MedicineName != "感冒灵颗粒" || medicine.Quantity.IntPart() != 2 || medicine.UnitPrice.String() != "12.5" ||
+		medicine.TotalPrice.String() != "25" || medicine.Frequency != "每日3次" {
+		t.Errorf("Unexpected medicine %+v", medicine)
+	}
+}
+
+// 开具 -> 驳回，驳回后不能再审核或下单
+func TestPrescriptionReject(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+	prescription := env.issue(t)
+
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, false, "  "); err == nil {
+		t.Error("Expected error when rejecting without notes")
+	}
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID+1, prescription.ID, true, ""); err == nil {
+		t.Error("Expected error for disabled pharmacist")
+	}
+
+	rejected, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, false, "剂量过大")
+	if err != nil {
+		t.Fatalf("AuditPrescription failed: %v", err)
+	}
+	if rejected.Status != biz.PrescriptionStatusRejected || rejected.AuditorID == nil || *rejected.AuditorID != uint64(env.pharmacistID) ||
+		rejected.AuditTime == nil || rejected.AuditNotes != "剂量过大" || rejected.AuditorName != "李药师" {
+		t.Errorf("Unexpected rejected prescription %+v", rejected)
+	}
+
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, true, ""); !errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) {
+		t.Errorf("Expected invalid transition on re-audit, got %v", err)
+	}
+	if _, err := env.uc.CreateOrderFromPrescription(ctx, &biz.PrescriptionOrderRequest{PatientID: testPatientID, PrescriptionID: prescription.ID}); !errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) {
+		t.Errorf("Expected invalid transition when ordering rejected prescription, got %v", err)
+	}
+}
+
+// 开具 -> 医生撤销，撤销后不能审核；其他医生不能撤销
+func TestPrescriptionCancel(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+	prescription := env.issue(t)
+
+	if _, err := env.uc.CancelPrescription(ctx, 8, prescription.ID); err == nil {
+		t.Error("Expected error when another doctor cancels")
+	}
+	cancelled, err := env.uc.CancelPrescription(ctx, testDoctorID, prescription.ID)
+	if err != nil {
+		t.Fatalf("CancelPrescription failed: %v", err)
+	}
+	if cancelled.Status != biz.PrescriptionStatusCancelled {
+		t.Errorf("Expected cancelled, got %s", cancelled.Status)
+	}
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, true, ""); !errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) {
+		t.Errorf("Expected invalid transition after cancel, got %v", err)
+	}
+}
+
+// 开具 -> 审核通过 -> 下单，重复下单返回同一订单
+func TestPrescriptionApproveAndOrder(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+	prescription := env.issue(t)
+
+	orderReq := &biz.PrescriptionOrderRequest{PatientID: testPatientID, PrescriptionID: prescription.ID}
+	if _, err := env.uc.CreateOrderFromPrescription(ctx, orderReq); !errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) {
+		t.Errorf("Expected invalid transition before audit, got %v", err)
+	}
+
+	audited, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, true, "")
+	if err != nil {
+		t.Fatalf("AuditPrescription failed: %v", err)
+	}
+	if audited.Status != biz.PrescriptionStatusAudited || audited.AuditorID == nil || audited.AuditTime == nil {
+		t.Errorf("Unexpected audited prescription %+v", audited)
+	}
+	if _, err := env.uc.CancelPrescription(ctx, testDoctorID, prescription.ID); !errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) {
+		t.Errorf("Expected invalid transition when cancelling audited prescription, got %v", err)
+	}
+	if _, err := env.uc.CreateOrderFromPrescription(ctx, &biz.PrescriptionOrderRequest{PatientID: testPatientID + 1, PrescriptionID: prescription.ID}); err == nil {
+		t.Error("Expected error when another patient orders")
+	}
+	if _, err := env.uc.CreateOrderFromPrescription(ctx, &biz.PrescriptionOrderRequest{PatientID: testPatientID, PrescriptionID: prescription.ID, AddressID: 999}); err == nil {
+		t.Error("Expected error for unknown address")
+	}
+
+	order, err := env.uc.CreateOrderFromPrescription(ctx, orderReq)
+	if err != nil {
+		t.Fatalf("CreateOrderFromPrescription failed: %v", err)
+	}
+	if order.UserID != int64(testPatientID) || order.DoctorID != int64(testDoctorID) || order.DoctorName != "张医生" ||
+		order.UserName != "测试用户" || order.AddressDetail != "北京市朝阳区1号楼" || order.TotalAmount.String() != "25" ||
+		order.Status != biz.OrderStatusPending || !strings.Contains(order.Remark, prescription.PrescriptionNo) {
+		t.Errorf("Unexpected order %+v", order)
+	}
+	ordered, err := env.uc.GetPrescriptionDetail(ctx, prescription.ID)
+	if err != nil {
+		t.Fatalf("GetPrescriptionDetail failed: %v", err)
+	}
+	if ordered.Prescription.Status != biz.PrescriptionStatusOrdered || ordered.Prescription.OrderNo != order.OrderNo {
+		t.Errorf("Expected ordered prescription, got %+v", ordered.Prescription)
+	}
+
+	// 重复下单返回同一订单，不会重复扣减库存
+	again, err := env.uc.CreateOrderFromPrescription(ctx, &biz.PrescriptionOrderRequest{PatientID: testPatientID, PrescriptionID: prescription.ID, Remark: "再次提交"})
+	if err != nil {
+		t.Fatalf("CreateOrderFromPrescription replay failed: %v", err)
+	}
+	if again.OrderNo != order.OrderNo {
+		t.Errorf("Expected same order %s, got %s", order.OrderNo, again.OrderNo)
+	}
+	var count int64
+	env.d.Db.Model(&MtOrder{}).Where("user_id = ?", testPatientID).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 order, got %d", count)
+	}
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, false, "重复审核"); !errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) {
+		t.Errorf("Expected invalid transition after order, got %v", err)
+	}
+}
+
+// 以过期状态为条件的更新返回状态冲突
+func TestUpdatePrescriptionStatusConflict(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+	prescription := env.issue(t)
+
+	err := env.repo.UpdatePrescriptionStatus(ctx, prescription.ID, biz.PrescriptionStatusAudited, &biz.PrescriptionUpdate{Status: biz.PrescriptionStatusOrdered, OrderNo: "ORD_X"})
+	if !errors.Is(err, biz.ErrPrescriptionStatusConflict) {
+		t.Errorf("Expected ErrPrescriptionStatusConflict, got %v", err)
+	}
+	latest, err := env.repo.GetPrescriptionByID(ctx, prescription.ID)
+	if err != nil || latest.Status != biz.PrescriptionStatusIssued || latest.OrderNo != "" {
+		t.Errorf("Expected unchanged prescription, got %+v, %v", latest, err)
+	}
+}
+
+// 处方药只能凭本人审核通过、未过期且覆盖所购数量的处方下单，取消后处方恢复可用，支付后处方已使用
+func TestPrescriptionDrugGating(t *testing.T) {
+	env := newPrescriptionTestEnv(t)
+	ctx := context.Background()
+	if err := env.d.Db.Model(&biz.MtDrug{}).Where("id = ?", 1).Update("is_prescription", true).Error; err != nil {
+		t.Fatalf("设置处方药失败: %v", err)
+	}
+	orderUc := newTestOrderUsecase(env.d)
+	prescription := env.issue(t)
+
+	newReq := func(userID int32, quantity int32, prescriptionID uint64) *biz.CreateOrderRequest {
+		return &biz.CreateOrderRequest{
+			UserID:         int64(userID),
+			UserName:       "测试用户",
+			UserPhone:      "13800138000",
+			AddressID:      1,
+			AddressDetail:  "测试地址",
+			Items:          []*biz.CreateOrderItem{{DrugID: 1, Quantity: quantity}},
+			PrescriptionID: prescriptionID,
+		}
+	}
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 1, 0)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired without prescription, got %v", err)
+	}
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 1, prescription.ID)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired for unaudited prescription, got %v", err)
+	}
+
+	if _, err := env.uc.AuditPrescription(ctx, env.pharmacistID, prescription.ID, true, ""); err != nil {
+		t.Fatalf("AuditPrescription failed: %v", err)
+	}
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID+1, 1, prescription.ID)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired for another patient, got %v", err)
+	}
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 3, prescription.ID)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired when quantity exceeds prescription, got %v", err)
+	}
+
+	// 过期处方不能下单
+	expiresAt := prescription.ExpiresAt
+	env.d.Db.Model(&MtPrescription{}).Where("id = ?", prescription.ID).Update("expires_at", time.Now().Add(-time.Minute))
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 1, prescription.ID)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired for expired prescription, got %v", err)
+	}
+	env.d.Db.Model(&MtPrescription{}).Where("id = ?", prescription.ID).Update("expires_at", expiresAt)
+
+	// 下单占用处方，取消订单后释放
+	order, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 1, prescription.ID))
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if order.PrescriptionID != prescription.ID {
+		t.Errorf("Expected order linked to prescription %d, got %d", prescription.ID, order.PrescriptionID)
+	}
+	assertStatus := func(status, orderNo string) {
+		t.Helper()
+		latest, err := env.repo.GetPrescriptionByID(ctx, prescription.ID)
+		if err != nil || latest.Status != status || latest.OrderNo != orderNo {
+			t.Errorf("Expected prescription %s/%s, got %+v, %v", status, orderNo, latest, err)
+		}
+	}
+	assertStatus(biz.PrescriptionStatusOrdered, order.OrderNo)
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 1, prescription.ID)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired for occupied prescription, got %v", err)
+	}
+	if err := orderUc.CancelOrder(ctx, order.OrderNo, biz.SystemOperator, "测试取消"); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	assertStatus(biz.PrescriptionStatusAudited, "")
+
+	// 重新下单并支付后处方已使用
+	order, err = orderUc.CreateOrder(ctx, newReq(testPatientID, 2, prescription.ID))
+	if err != nil {
+		t.Fatalf("CreateOrder after cancel failed: %v", err)
+	}
+	if err := orderUc.ProcessPayment(ctx, order.OrderNo, &biz.PaymentInfo{PayType: "2", Amount: order.TotalAmount, PaymentTime: time.Now()}); err != nil {
+		t.Fatalf("ProcessPayment failed: %v", err)
+	}
+	assertStatus(biz.PrescriptionStatusUsed, order.OrderNo)
+	if _, err := orderUc.CreateOrder(ctx, newReq(testPatientID, 1, prescription.ID)); !errors.Is(err, biz.ErrPrescriptionRequired) {
+		t.Errorf("Expected ErrPrescriptionRequired for used prescription, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"kratos_client/internal/biz"
+)
+
+// 支付宝业务明细账单样例，字段后带制表符与真实账单一致
+const testAlipayBill = `#支付宝业务明细查询
+#账号：[20880000000000000156]
+#起始日期：[2026年10月01日 00:00:00]   终止日期：[2026年10月02日 00:00:00]
+#-----------------------------------------业务明细列表----------------------------------------
+支付宝交易号,商户订单号,业务类型,商品名称,创建时间,完成时间,门店编号,门店名称,操作员,终端号,对方账户,订单金额（元）,商家实收（元）,退款批次号/请求号,备注
+2026100122001,PAY_HEAL	,交易	,药品订单	,2026-10-01 10:00:00	,2026-10-01 10:00:05	,,,,,用户A	,25.00	,25.00	,,
+2026100122001,PAY_HEAL	,交易	,药品订单	,2026-10-01 10:00:00	,2026-10-01 10:00:05	,,,,,用户A	,25.00	,25.00	,,
+2026100122002,PAY_DIFF	,交易	,药品订单	,2026-10-01 11:00:00	,2026-10-01 11:00:05	,,,,,用户B	,20.00	,20.00	,,
+2026100122003,PAY_GHOST	,交易	,药品订单	,2026-10-01 12:00:00	,2026-10-01 12:00:05	,,,,,用户C	,9.90	,9.90	,,
+2026100122002,PAY_DIFF	,退款	,药品订单	,2026-10-01 11:00:00	,2026-10-01 13:00:05	,,,,,用户B	,-5.00	,-5.00	,RF_1	,
+#-----------------------------------------业务明细列表结束------------------------------------
+#交易合计：4笔，商家实收共：79.90元
+`
+
+// 测试账单对账：差异报告和已支付未入账订单的自动补记
+func TestReconcileAlipayStatement(t *testing.T) {
+	d := newOrderTestData(t)
+	logger := newTestLogger()
+	orderUc := newTestOrderUsecase(d)
+	paymentRepo := NewPaymentRepo(d, logger)
+	orderRepo := NewOrderRepo(d, logger)
+	uc := biz.NewReconcileUsecase(NewStatementParser(), paymentRepo, orderRepo, orderUc, logger)
+	ctx := context.Background()
+
+	healOrder := createTestOrder(t, orderUc, 2)
+	diffOrder := createTestOrder(t, orderUc, 2)
+	payTime := time.Date(2026, 10, 1, 15, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	for _, p := range []*biz.PaymentOrder{
+		{OrderID: "PAY_HEAL", BusinessID: healOrder.OrderNo, Status: biz.PaymentStatusPending},
+		{OrderID: "PAY_DIFF", BusinessID: diffOrder.OrderNo, Status: biz.PaymentStatusPaid, TradeNo: "2026100122002", PayTime: payTime},
+		{OrderID: "PAY_LOST", BusinessID: "ORDER_LOST", Status: biz.PaymentStatusPaid, TradeNo: "2026100122009", PayTime: payTime},
+	} {
+		p.UserID, p.OrderType, p.Subject, p.TotalAmount, p.Channel = 1001, biz.OrderTypeDrug, "药品订单", "25.00", biz.PaymentChannelAlipay
+		if err := paymentRepo.CreatePaymentOrder(ctx, p); err != nil {
+			t.Fatalf("创建支付单失败: %v", err)
+		}
+	}
+
+	// 支付宝下载的账单为GBK编码
+	bill, err := simplifiedchinese.GBK.NewEncoder().String(testAlipayBill)
+	if err != nil {
+		t.Fatalf("账单编码失败: %v", err)
+	}
+
+	report, err := uc.Reconcile(ctx, bytes.NewBufferString(bill), biz.ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if report.PaymentCount != 4 || report.SkippedCount != 1 || report.HealedCount != 0 {
+		t.Errorf("Unexpected counts %+v", report)
+	}
+	issues := make(map[string]*biz.ReconcileIssue)
+	for _, issue := range report.Issues {
+		issues[issue.Type+":"+issue.OrderID] = issue
+	}
+	for _, key := range []string{
+		biz.ReconcileIssueDuplicate + ":PAY_HEAL",
+		biz.ReconcileIssueUnpaidLocal + ":PAY_HEAL",
+		biz.ReconcileIssueAmountMismatch + ":PAY_DIFF",
+		biz.ReconcileIssueMissingLocal + ":PAY_GHOST",
+		biz.ReconcileIssueMissingRemote + ":PAY_LOST",
+	} {
+		if issues[key] == nil {
+			t.Errorf("Expected issue %s, got %d issues", key, len(report.Issues))
+		}
+	}
+	if len(report.Issues) != 5 {
+		t.Errorf("Expected 5 issues, got %d", len(report.Issues))
+	}
+	if order, _ := orderRepo.GetOrderByOrderNo(ctx, healOrder.OrderNo); order.Status != biz.OrderStatusPending {
+		t.Fatalf("Dry run should not heal order, got status %s", order.Status)
+	}
+
+	// 正式对账补记支付，再次对账保持幂等
+	report, err = uc.Reconcile(ctx, strings.NewReader(testAlipayBill), biz.ReconcileOptions{})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if report.HealedCount != 1 {
+		t.Errorf("Expected 1 healed, got %d", report.HealedCount)
+	}
+	order, _ := orderRepo.GetOrderByOrderNo(ctx, healOrder.OrderNo)
+	if order.Status != biz.OrderStatusPaid || order.PayType != biz.PayTypeAlipay {
+		t.Errorf("Expected healed order paid by alipay, got status %s payType %s", order.Status, order.PayType)
+	}
+	payment, _ := paymentRepo.GetPaymentOrderByOrderID(ctx, "PAY_HEAL")
+	if payment.Status != biz.PaymentStatusPaid || payment.TradeNo != "2026100122001" {
+		t.Errorf("Expected healed payment, got %s %q", payment.Status, payment.TradeNo)
+	}
+
+	report, err = uc.Reconcile(ctx, strings.NewReader(testAlipayBill), biz.ReconcileOptions{})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if report.HealedCount != 0 || report.MatchedCount != 1 {
+		t.Errorf("Expected second run matched without healing, got %+v", report)
+	}
+}
@@ -0,0 +1,250 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+)
+
+// 退款明细数据模型 - 对应 mt_refund_item 表
+type MtRefundItem struct {
+	ID          int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	RefundID    string          `gorm:"column:refund_id;size:64;not null;index" json:"refund_id"`
+	OrderItemID int64           `gorm:"column:order_item_id;not null;index" json:"order_item_id"`
+	DrugID      int64           `gorm:"column:drug_id;not null" json:"drug_id"`
+	DrugName    string          `gorm:"column:drug_name;size:100" json:"drug_name"`
+	Quantity    int32           `gorm:"column:quantity;not null" json:"quantity"`
+	Amount      decimal.Decimal `gorm:"column:amount;type:decimal(10,2);not null" json:"amount"`
+	CreatedAt   time.Time       `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// 表名
+func (MtRefundItem) TableName() string {
+	return "mt_refund_item"
+}
+
+// 退款仓储实现
+type refundRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建退款仓储
+func NewRefundRepo(data *Data, logger log.Logger) biz.RefundRepo {
+	return &refundRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 获取数据库连接，在事务中时使用事务连接
+func (r *refundRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
+}
+
+// 在事务中执行，已处于事务中时直接复用
+func (r *refundRepo) inTx(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return fn(tx)
+	}
+	return r.data.Db.WithContext(ctx).Transaction(fn)
+}
+
+func (r *refundRepo) toBizRefundItem(do *MtRefundItem) *biz.RefundItem {
+	return &biz.RefundItem{
+		ID:          do.ID,
+		RefundID:    do.RefundID,
+		OrderItemID: do.OrderItemID,
+		DrugID:      do.DrugID,
+		DrugName:    do.DrugName,
+		Quantity:    do.Quantity,
+		Amount:      do.Amount,
+		CreatedAt:   do.CreatedAt,
+	}
+}
+
+// 批量加载退款明细
+func (r *refundRepo) withItems(db *gorm.DB, records []RefundRecord) ([]*biz.RefundRecord, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	refundIDs := make([]string, len(records))
+	for i, record := range records {
+		refundIDs[i] = record.RefundID
+	}
+	var items []MtRefundItem
+	if err := db.Where("refund_id IN ?", refundIDs).Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	itemMap := make(map[string][]*biz.RefundItem)
+	for i := range items {
+		itemMap[items[i].RefundID] = append(itemMap[items[i].RefundID], r.toBizRefundItem(&items[i]))
+	}
+
+	result := make([]*biz.RefundRecord, len(records))
+	for i := range records {
+		result[i] = toBizRefundRecord(&records[i])
+		result[i].Items = itemMap[records[i].RefundID]
+	}
+	return result, nil
+}
+
+// 创建退款单及明细
+func (r *refundRepo) CreateRefund(ctx context.Context, record *biz.RefundRecord) error {
+	rr := toDataRefundRecord(record)
+	err := r.inTx(ctx, func(db *gorm.DB) error {
+		if err := db.Create(rr).Error; err != nil {
+			return err
+		}
+		items := make([]*MtRefundItem, len(record.Items))
+		for i, item := range record.Items {
+			items[i] = &MtRefundItem{
+				RefundID:    record.RefundID,
+				OrderItemID: item.OrderItemID,
+				DrugID:      item.DrugID,
+				DrugName:    item.DrugName,
+				Quantity:    item.Quantity,
+				Amount:      item.Amount,
+				CreatedAt:   item.CreatedAt,
+			}
+		}
+		if len(items) > 0 {
+			if err := db.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		for i, item := range items {
+			record.Items[i].ID = item.ID
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("创建退款单失败: refundID=%s, error=%v", record.RefundID, err)
+		return err
+	}
+
+	record.ID = rr.ID
+	return nil
+}
+
+// 根据退款单号查询
+func (r *refundRepo) GetRefund(ctx context.Context, refundID string) (*biz.RefundRecord, error) {
+	db := r.getDB(ctx)
+	var record RefundRecord
+	if err := db.Where("refund_id = ?", refundID).First(&record).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.log.Errorf("查询退款单失败: %v", err)
+		return nil, err
+	}
+
+	records, err := r.withItems(db, []RefundRecord{record})
+	if err != nil {
+		r.log.Errorf("查询退款明细失败: %v", err)
+		return nil, err
+	}
+	return records[0], nil
+}
+
+// 查询订单的全部退款单
+func (r *refundRepo) ListRefundsByOrderNo(ctx context.Context, orderNo string) ([]*biz.RefundRecord, error) {
+	db := r.getDB(ctx)
+	var records []RefundRecord
+	if err := db.Where("order_no = ?", orderNo).Order("id ASC").Find(&records).Error; err != nil {
+		r.log.Errorf("查询订单退款单失败: %v", err)
+		return nil, err
+	}
+
+	result, err := r.withItems(db, records)
+	if err != nil {
+		r.log.Errorf("查询退款明细失败: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// 按状态查询退款单
+func (r *refundRepo) ListRefundsByStatus(ctx context.Context, status string, limit int) ([]*biz.RefundRecord, error) {
+	db := r.getDB(ctx)
+	var records []RefundRecord
+	if err := db.Where("refund_status = ?", status).Order("created_at ASC").Limit(limit).Find(&records).Error; err != nil {
+		r.log.Errorf("按状态查询退款单失败: %v", err)
+		return nil, err
+	}
+
+	result, err := r.withItems(db, records)
+	if err != nil {
+		r.log.Errorf("查询退款明细失败: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// 以当前状态为条件更新退款单状态
+func (r *refundRepo) UpdateRefundStatus(ctx context.Context, refundID, fromStatus string, update *biz.RefundStatusUpdate) error {
+	updates := map[string]interface{}{
+		"refund_status": update.Status,
+		"updated_at":    time.Now(),
+	}
+	if update.GatewayRefundNo != "" {
+		updates["gateway_refund_no"] = update.GatewayRefundNo
+	}
+	if update.FailReason != "" {
+		updates["fail_reason"] = update.FailReason
+	}
+	if !update.RefundTime.IsZero() {
+		updates["refund_time"] = update.RefundTime
+	}
+
+	result := r.getDB(ctx).Model(&RefundRecord{}).
+		Where("refund_id = ? AND refund_status = ?", refundID, fromStatus).
+		Updates(updates)
+	if result.Error != nil {
+		r.log.Errorf("更新退款单状态失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: refundID=%s, from=%s, to=%s", biz.ErrRefundStatusConflict, refundID, fromStatus, update.Status)
+	}
+	return nil
+}
+
+// 占用订单项可退数量
+func (r *refundRepo) ReserveRefundQuantity(ctx context.Context, orderItemID int64, quantity int32) error {
+	result := r.getDB(ctx).Model(&MtOrderItem{}).
+		Where("id = ? AND quantity - refunded_qty >= ?", orderItemID, quantity).
+		UpdateColumn("refunded_qty", gorm.Expr("refunded_qty + ?", quantity))
+	if result.Error != nil {
+		r.log.Errorf("占用可退数量失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: orderItemID=%d, quantity=%d", biz.ErrRefundQuantityExceeded, orderItemID, quantity)
+	}
+	return nil
+}
+
+// 归还订单项可退数量
+func (r *refundRepo) ReleaseRefundQuantity(ctx context.Context, orderItemID int64, quantity int32) error {
+	result := r.getDB(ctx).Model(&MtOrderItem{}).
+		Where("id = ? AND refunded_qty >= ?", orderItemID, quantity).
+		UpdateColumn("refunded_qty", gorm.Expr("refunded_qty - ?", quantity))
+	if result.Error != nil {
+		r.log.Errorf("归还可退数量失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		r.log.Warnf("归还可退数量未生效: orderItemID=%d, quantity=%d", orderItemID, quantity)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+// 假的支付渠道，顶替支付宝按预设状态返回退款结果
+type fakeRefundGateway struct {
+	biz.PaymentGateway
+	status   string
+	err      error
+	requests []*biz.GatewayRefundRequest
+}
+
+func (g *fakeRefundGateway) Refund(ctx context.Context, req *biz.GatewayRefundRequest) (*biz.GatewayRefundResult, error) {
+	g.requests = append(g.requests, req)
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &biz.GatewayRefundResult{
+		RefundID:        req.RefundID,
+		GatewayRefundNo: "GW_" + req.RefundID,
+		Status:          g.status,
+		FailReason:      "余额不足",
+	}, nil
+}
+
+func (g *fakeRefundGateway) Channel() string {
+	return biz.PaymentChannelAlipay
+}
+
+// 测试中通知参数直接携带结果
+func (g *fakeRefundGateway) ParseRefundNotify(ctx context.Context, notify *biz.GatewayNotify) (*biz.GatewayRefundResult, error) {
+	return &biz.GatewayRefundResult{
+		RefundID: notify.Params["refund_id"],
+		Status:   notify.Params["status"],
+		Amount:   notify.Params["amount"],
+	}, nil
+}
+
+func newTestRefundUsecase(d *Data, orderUc *biz.OrderUsecase, gateway biz.PaymentGateway) *biz.RefundUsecase {
+	logger := newTestLogger()
+	gateways := biz.NewPaymentGatewaySet()
+	gateways.Register(gateway, biz.PayTypeAlipay)
+	inventoryRepo := NewDrugInventoryRepo(d, logger)
+	inventoryUc := biz.NewInventoryUsecase(inventoryRepo, NewDrugRepo(d, logger), NewInventoryAlertRepo(d, logger), NewInventoryAlertSink(nil, logger), logger)
+	return biz.NewRefundUsecase(NewRefundRepo(d, logger), NewOrderRepo(d, logger), NewPaymentRepo(d, logger),
+		inventoryRepo, orderUc, inventoryUc, gateways, logger)
+}
+
+// 支付订单并写入对应的支付单，返回订单项ID
+func payTestOrder(t *testing.T, d *Data, uc *biz.OrderUsecase, order *biz.MtOrder) int64 {
+	t.Helper()
+	ctx := context.Background()
+	if err := uc.ProcessPayment(ctx, order.OrderNo, &biz.PaymentInfo{PayType: "2", Amount: order.TotalAmount, PaymentTime: time.Now()}); err != nil {
+		t.Fatalf("ProcessPayment failed: %v", err)
+	}
+	payment := &PaymentOrder{
+		OrderID:     "PAY_" + order.OrderNo,
+		UserID:      int32(order.UserID),
+		BusinessID:  order.OrderNo,
+		OrderType:   biz.OrderTypeDrug,
+		Subject:     "药品订单",
+		TotalAmount: order.TotalAmount.StringFixed(2),
+		Status:      biz.PaymentStatusPaid,
+		TradeNo:     "TRADE_" + order.OrderNo,
+		PayTime:     time.Now(),
+	}
+	if err := d.Db.Create(payment).Error; err != nil {
+		t.Fatalf("创建测试支付单失败: %v", err)
+	}
+	detail, err := uc.GetOrder(ctx, order.OrderNo)
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	return detail.Items[0].ID
+}
+
+func requestTestRefund(uc *biz.RefundUsecase, orderNo string, orderItemID int64, quantity int32) (*biz.RefundRecord, error) {
+	return uc.RequestRefund(context.Background(), &biz.RefundRequest{
+		OrderNo: orderNo,
+		UserID:  1001,
+		Items:   []*biz.RefundItemRequest{{OrderItemID: orderItemID, Quantity: quantity}},
+		Reason:  "不需要了",
+	})
+}
+
+// 模拟后台审核通过
+func approveRefund(t *testing.T, d *Data, refundID string) {
+	t.Helper()
+	err := d.Db.Model(&RefundRecord{}).Where("refund_id = ? AND refund_status = ?", refundID, biz.RefundStatusRequested).
+		Updates(map[string]interface{}{"refund_status": biz.RefundStatusApproved, "audit_by": 1, "audit_time": time.Now()}).Error
+	if err != nil {
+		t.Fatalf("审核退款单失败: %v", err)
+	}
+}
+
+func refundStatus(t *testing.T, d *Data, refundID string) string {
+	t.Helper()
+	var record RefundRecord
+	if err := d.Db.Where("refund_id = ?", refundID).First(&record).Error; err != nil {
+		t.Fatalf("查询退款单失败: %v", err)
+	}
+	return record.RefundStatus
+}
+
+func orderStatus(t *testing.T, uc *biz.OrderUsecase, orderNo string) string {
+	t.Helper()
+	detail, err := uc.GetOrder(context.Background(), orderNo)
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	return detail.Order.Status
+}
+
+// 测试部分退款、审核、渠道退款、通知和库存退回，全部退完后订单流转为已退款
+func TestRefundWorkflow(t *testing.T) {
+	d := newOrderTestData(t)
+	orderUc := newTestOrderUsecase(d)
+	gateway := &fakeRefundGateway{status: biz.RefundStatusSucceeded}
+	uc := newTestRefundUsecase(d, orderUc, gateway)
+	ctx := context.Background()
+
+	// 4 x 12.5 = 50
+	order := createTestOrder(t, orderUc, 4)
+	itemID := payTestOrder(t, d, orderUc, order)
+	if got := drugInventory(t, d); got != 96 {
+		t.Fatalf("Expected inventory 96 after payment, got %d", got)
+	}
+
+	first, err := requestTestRefund(uc, order.OrderNo, itemID, 1)
+	if err != nil {
+		t.Fatalf("RequestRefund failed: %v", err)
+	}
+	if first.RefundAmount != "12.50" || first.RefundStatus != biz.RefundStatusRequested {
+		t.Errorf("Unexpected refund: amount=%s status=%s", first.RefundAmount, first.RefundStatus)
+	}
+
+	// 剩余3件，不能再申请4件
+	if _, err := requestTestRefund(uc, order.OrderNo, itemID, 4); !errors.Is(err, biz.ErrRefundQuantityExceeded) {
+		t.Fatalf("Expected ErrRefundQuantityExceeded, got %v", err)
+	}
+
+	// 未审核的退款单不会提交
+	if processed, _ := uc.ProcessApprovedRefunds(ctx, 10); processed != 0 {
+		t.Errorf("Expected no refund processed before approval, got %d", processed)
+	}
+	approveRefund(t, d, first.RefundID)
+	if processed, err := uc.ProcessApprovedRefunds(ctx, 10); err != nil || processed != 1 {
+		t.Fatalf("Expected 1 refund processed, got %d, err=%v", processed, err)
+	}
+	if got := refundStatus(t, d, first.RefundID); got != biz.RefundStatusSucceeded {
+		t.Errorf("Expected refund succeeded, got %s", got)
+	}
+	if got := drugInventory(t, d); got != 97 {
+		t.Errorf("Expected inventory 97 after partial refund, got %d", got)
+	}
+	if got := orderStatus(t, orderUc, order.OrderNo); got != biz.OrderStatusPaid {
+		t.Errorf("Expected order still paid after partial refund, got %s", got)
+	}
+
+	// 渠道受理后通过通知确认结果
+	gateway.status = biz.RefundStatusProcessing
+	second, err := requestTestRefund(uc, order.OrderNo, itemID, 3)
+	if err != nil {
+		t.Fatalf("RequestRefund failed: %v", err)
+	}
+	if second.RefundAmount != "37.50" {
+		t.Errorf("Expected remaining amount 37.50, got %s", second.RefundAmount)
+	}
+	approveRefund(t, d, second.RefundID)
+	uc.ProcessApprovedRefunds(ctx, 10)
+	if got := refundStatus(t, d, second.RefundID); got != biz.RefundStatusProcessing {
+		t.Fatalf("Expected refund processing, got %s", got)
+	}
+
+	notify := &biz.GatewayNotify{Params: map[string]string{"refund_id": second.RefundID, "status": biz.RefundStatusSucceeded, "amount": "37.5"}}
+	if err := uc.HandleRefundNotify(ctx, biz.PaymentChannelAlipay, notify); err != nil {
+		t.Fatalf("HandleRefundNotify failed: %v", err)
+	}
+	if got := drugInventory(t, d); got != 100 {
+		t.Errorf("Expected inventory 100 after full refund, got %d", got)
+	}
+	if got := orderStatus(t, orderUc, order.OrderNo); got != biz.OrderStatusRefunded {
+		t.Errorf("Expected order refunded, got %s", got)
+	}
+	var payment PaymentOrder
+	d.Db.Where("business_id = ?", order.OrderNo).First(&payment)
+	if payment.Status != biz.PaymentStatusRefunded {
+		t.Errorf("Expected payment refunded, got %s", payment.Status)
+	}
+
+	// 重复通知不会重复入库
+	if err := uc.HandleRefundNotify(ctx, biz.PaymentChannelAlipay, notify); err != nil {
+		t.Fatalf("Repeated HandleRefundNotify failed: %v", err)
+	}
+	if got := drugInventory(t, d); got != 100 {
+		t.Errorf("Expected inventory unchanged after repeated notify, got %d", got)
+	}
+	if len(gateway.requests) != 2 || gateway.requests[0].PaymentOrderID != "PAY_"+order.OrderNo {
+		t.Errorf("Unexpected gateway requests: %+v", gateway.requests)
+	}
+}
+
+// 测试渠道退款失败后归还可退数量，调用异常时等待重试
+func TestRefundFailureReleasesQuantity(t *testing.T) {
+	d := newOrderTestData(t)
+	orderUc := newTestOrderUsecase(d)
+	gateway := &fakeRefundGateway{err: fmt.Errorf("connection reset")}
+	uc := newTestRefundUsecase(d, orderUc, gateway)
+	ctx := context.Background()
+
+	order := createTestOrder(t, orderUc, 2)
+	itemID := payTestOrder(t, d, orderUc, order)
+
+	refund, err := requestTestRefund(uc, order.OrderNo, itemID, 2)
+	if err != nil {
+		t.Fatalf("RequestRefund failed: %v", err)
+	}
+	approveRefund(t, d, refund.RefundID)
+
+	// 调用异常，退回审核通过状态
+	uc.ProcessApprovedRefunds(ctx, 10)
+	if got := refundStatus(t, d, refund.RefundID); got != biz.RefundStatusApproved {
+		t.Fatalf("Expected refund back to approved, got %s", got)
+	}
+
+	gateway.err = nil
+	gateway.status = biz.RefundStatusFailed
+	uc.ProcessApprovedRefunds(ctx, 10)
+	if got := refundStatus(t, d, refund.RefundID); got != biz.RefundStatusFailed {
+		t.Fatalf("Expected refund failed, got %s", got)
+	}
+	if got := drugInventory(t, d); got != 98 {
+		t.Errorf("Expected inventory unchanged after failed refund, got %d", got)
+	}
+
+	// 可退数量已归还，可以重新申请
+	if _, err := requestTestRefund(uc, order.OrderNo, itemID, 2); err != nil {
+		t.Errorf("Expected refund request after failure to succeed, got %v", err)
+	}
+}
+
+// 测试使用优惠券的订单按实付比例退款
+func TestRefundProratesDiscount(t *testing.T) {
+	d := newOrderTestData(t)
+	orderUc := newTestOrderUsecase(d)
+	uc := newTestRefundUsecase(d, orderUc, &fakeRefundGateway{status: biz.RefundStatusSucceeded})
+	userCouponID := seedUserCoupon(t, d)
+
+	// 3 x 12.5 = 37.5，满20减5，实付32.5
+	order, err := createCouponOrder(orderUc, userCouponID, 3)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	itemID := payTestOrder(t, d, orderUc, order)
+
+	first, err := requestTestRefund(uc, order.OrderNo, itemID, 1)
+	if err != nil {
+		t.Fatalf("RequestRefund failed: %v", err)
+	}
+	// 12.5 x 32.5 / 37.5 = 10.833...
+	if first.RefundAmount != "10.83" {
+		t.Errorf("Expected prorated amount 10.83, got %s", first.RefundAmount)
+	}
+	// 最后一笔补齐实付金额
+	second, err := requestTestRefund(uc, order.OrderNo, itemID, 2)
+	if err != nil {
+		t.Fatalf("RequestRefund failed: %v", err)
+	}
+	if second.RefundAmount != "21.67" {
+		t.Errorf("Expected remaining amount 21.67, got %s", second.RefundAmount)
+	}
+}
@@ -0,0 +1,386 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kratos_client/internal/biz"
+)
+
+const doctorScheduleEnabled = 1
+
+// 医生每周排班模板 - 对应 mt_doctor_schedule 表，由管理端维护
+type MtDoctorSchedule struct {
+	ID          int32     `gorm:"primaryKey;autoIncrement" json:"id"`
+	DoctorID    int32     `gorm:"column:doctor_id;not null;index:idx_schedule_doctor" json:"doctor_id"`
+	Weekday     int32     `gorm:"column:weekday;not null" json:"weekday"`
+	StartTime   string    `gorm:"column:start_time;size:5;not null" json:"start_time"`
+	EndTime     string    `gorm:"column:end_time;size:5;not null" json:"end_time"`
+	SlotMinutes int32     `gorm:"column:slot_minutes;not null;default:30" json:"slot_minutes"`
+	Capacity    int32     `gorm:"column:capacity;not null;default:1" json:"capacity"`
+	Status      int32     `gorm:"column:status;not null;default:1" json:"status"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtDoctorSchedule) TableName() string {
+	return "mt_doctor_schedule"
+}
+
+// 医生排班例外 - 对应 mt_doctor_schedule_exception 表，由管理端维护
+type MtDoctorScheduleException struct {
+	ID            int32     `gorm:"primaryKey;autoIncrement" json:"id"`
+	DoctorID      int32     `gorm:"column:doctor_id;not null;index:idx_schedule_exception_doctor" json:"doctor_id"`
+	ExceptionDate string    `gorm:"column:exception_date;size:10;not null;index:idx_schedule_exception_doctor" json:"exception_date"`
+	Type          string    `gorm:"column:type;size:16;not null" json:"type"`
+	StartTime     string    `gorm:"column:start_time;size:5" json:"start_time"`
+	EndTime       string    `gorm:"column:end_time;size:5" json:"end_time"`
+	SlotMinutes   int32     `gorm:"column:slot_minutes;not null;default:30" json:"slot_minutes"`
+	Capacity      int32     `gorm:"column:capacity;not null;default:1" json:"capacity"`
+	Reason        string    `gorm:"column:reason;size:255" json:"reason"`
+	CreatedAt     time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtDoctorScheduleException) TableName() string {
+	return "mt_doctor_schedule_exception"
+}
+
+// 号源时段 - 对应 mt_schedule_slot 表
+type MtScheduleSlot struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	DoctorID  int32     `gorm:"column:doctor_id;not null;uniqueIndex:idx_slot_doctor_time" json:"doctor_id"`
+	SlotDate  string    `gorm:"column:slot_date;size:10;not null;uniqueIndex:idx_slot_doctor_time" json:"slot_date"`
+	StartTime string    `gorm:"column:start_time;size:5;not null;uniqueIndex:idx_slot_doctor_time" json:"start_time"`
+	EndTime   string    `gorm:"column:end_time;size:5;not null" json:"end_time"`
+	Capacity  int32     `gorm:"column:capacity;not null" json:"capacity"`
+	Booked    int32     `gorm:"column:booked;not null;default:0" json:"booked"`
+	Status    string    `gorm:"column:status;size:16;not null" json:"status"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtScheduleSlot) TableName() string {
+	return "mt_schedule_slot"
+}
+
+// 预约挂号 - 对应 mt_appointment 表
+type MtAppointment struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AppointmentNo string     `gorm:"column:appointment_no;size:64;not null;uniqueIndex:idx_appointment_no" json:"appointment_no"`
+	SlotID        int64      `gorm:"column:slot_id;not null" json:"slot_id"`
+	DoctorID      int32      `gorm:"column:doctor_id;not null;index:idx_appointment_doctor" json:"doctor_id"`
+	DoctorName    string     `gorm:"column:doctor_name;size:50" json:"doctor_name"`
+	PatientID     int32      `gorm:"column:patient_id;not null;index:idx_appointment_patient" json:"patient_id"`
+	SlotDate      string     `gorm:"column:slot_date;size:10;not null" json:"slot_date"`
+	StartTime     string     `gorm:"column:start_time;size:5;not null" json:"start_time"`
+	EndTime       string     `gorm:"column:end_time;size:5;not null" json:"end_time"`
+	Status        string     `gorm:"column:status;size:16;not null" json:"status"`
+	BookingKey    *string    `gorm:"column:booking_key;size:64;uniqueIndex:idx_appointment_booking" json:"booking_key"`
+	CancelReason  string     `gorm:"column:cancel_reason;size:255" json:"cancel_reason"`
+	CancelledAt   *time.Time `gorm:"column:cancelled_at" json:"cancelled_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtAppointment) TableName() string {
+	return "mt_appointment"
+}
+
+// 排班与预约仓储实现
+type scheduleRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建排班与预约仓储
+func NewScheduleRepo(data *Data, logger log.Logger) biz.ScheduleRepo {
+	return &scheduleRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 获取数据库连接，在事务中时使用事务连接
+func (r *scheduleRepo) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
+		return tx
+	}
+	return r.data.Db.WithContext(ctx)
+}
+
+// 在事务中执行
+func (r *scheduleRepo) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.data.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, "tx", tx))
+	})
+}
+
+// 查询医生启用中的排班模板
+func (r *scheduleRepo) ListSchedules(ctx context.Context, doctorID int32) ([]*biz.DoctorSchedule, error) {
+	var records []MtDoctorSchedule
+	err := r.getDB(ctx).Where("doctor_id = ? AND status = ?", doctorID, doctorScheduleEnabled).
+		Order("weekday ASC, start_time ASC").Find(&records).Error
+	if err != nil {
+		r.log.Errorf("查询排班模板失败: %v", err)
+		return nil, err
+	}
+	result := make([]*biz.DoctorSchedule, len(records))
+	for i, do := range records {
+		result[i] = &biz.DoctorSchedule{
+			ID:          do.ID,
+			DoctorID:    do.DoctorID,
+			Weekday:     do.Weekday,
+			StartTime:   do.StartTime,
+			EndTime:     do.EndTime,
+			SlotMinutes: do.SlotMinutes,
+			Capacity:    do.Capacity,
+		}
+	}
+	return result, nil
+}
+
+// 查询医生在日期范围内的排班例外
+func (r *scheduleRepo) ListExceptions(ctx context.Context, doctorID int32, fromDate, toDate string) ([]*biz.ScheduleException, error) {
+	var records []MtDoctorScheduleException
+	err := r.getDB(ctx).Where("doctor_id = ? AND exception_date BETWEEN ? AND ?", doctorID, fromDate, toDate).
+		Order("exception_date ASC, id ASC").Find(&records).Error
+	if err != nil {
+		r.log.Errorf("查询排班例外失败: %v", err)
+		return nil, err
+	}
+	result := make([]*biz.ScheduleException, len(records))
+	for i, do := range records {
+		result[i] = &biz.ScheduleException{
+			ID:          do.ID,
+			DoctorID:    do.DoctorID,
+			Date:        do.ExceptionDate,
+			Type:        do.Type,
+			StartTime:   do.StartTime,
+			EndTime:     do.EndTime,
+			SlotMinutes: do.SlotMinutes,
+			Capacity:    do.Capacity,
+			Reason:      do.Reason,
+		}
+	}
+	return result, nil
+}
+
+// 批量创建时段，已存在的时段由唯一索引忽略，并发生成同一时段也只保留一条
+func (r *scheduleRepo) CreateSlots(ctx context.Context, slots []*biz.ScheduleSlot) error {
+	now := time.Now()
+	records := make([]MtScheduleSlot, len(slots))
+	for i, slot := range slots {
+		records[i] = MtScheduleSlot{
+			DoctorID:  slot.DoctorID,
+			SlotDate:  slot.SlotDate,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Capacity:  slot.Capacity,
+			Status:    slot.Status,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&records).Error; err != nil {
+		r.log.Errorf("批量创建时段失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+func toBizScheduleSlot(do *MtScheduleSlot) *biz.ScheduleSlot {
+	return &biz.ScheduleSlot{
+		ID:        do.ID,
+		DoctorID:  do.DoctorID,
+		SlotDate:  do.SlotDate,
+		StartTime: do.StartTime,
+		EndTime:   do.EndTime,
+		Capacity:  do.Capacity,
+		Booked:    do.Booked,
+		Status:    do.Status,
+	}
+}
+
+// 查询医生在日期范围内的时段
+func (r *scheduleRepo) ListSlots(ctx context.Context, doctorID int32, fromDate, toDate string) ([]*biz.ScheduleSlot, error) {
+	var records []MtScheduleSlot
+	err := r.getDB(ctx).Where("doctor_id = ? AND slot_date BETWEEN ? AND ?", doctorID, fromDate, toDate).
+		Order("slot_date ASC, start_time ASC").Find(&records).Error
+	if err != nil {
+		r.log.Errorf("查询时段失败: %v", err)
+		return nil, err
+	}
+	result := make([]*biz.ScheduleSlot, len(records))
+	for i := range records {
+		result[i] = toBizScheduleSlot(&records[i])
+	}
+	return result, nil
+}
+
+// 根据ID查询时段
+func (r *scheduleRepo) GetSlot(ctx context.Context, slotID int64) (*biz.ScheduleSlot, error) {
+	var do MtScheduleSlot
+	if err := r.getDB(ctx).First(&do, slotID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.log.Errorf("查询时段失败: %v", err)
+		return nil, err
+	}
+	return toBizScheduleSlot(&do), nil
+}
+
+// 以可预约且未约满为条件占用号源，并发预约时由数据库保证不超约
+func (r *scheduleRepo) ReserveSlot(ctx context.Context, slotID int64) error {
+	result := r.getDB(ctx).Model(&MtScheduleSlot{}).
+		Where("id = ? AND status = ? AND booked < capacity", slotID, biz.ScheduleSlotOpen).
+		Updates(map[string]interface{}{
+			"booked":     gorm.Expr("booked + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		r.log.Errorf("占用号源失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: slotID=%d", biz.ErrSlotUnavailable, slotID)
+	}
+	return nil
+}
+
+// 归还号源
+func (r *scheduleRepo) ReleaseSlot(ctx context.Context, slotID int64) error {
+	err := r.getDB(ctx).Model(&MtScheduleSlot{}).
+		Where("id = ? AND booked > 0", slotID).
+		Updates(map[string]interface{}{
+			"booked":     gorm.Expr("booked - 1"),
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		r.log.Errorf("归还号源失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// 创建预约，booking_key唯一索引冲突说明该患者已预约此时段
+func (r *scheduleRepo) CreateAppointment(ctx context.Context, appointment *biz.Appointment) error {
+	bookingKey := fmt.Sprintf("%d-%d", appointment.SlotID, appointment.PatientID)
+	do := &MtAppointment{
+		AppointmentNo: appointment.AppointmentNo,
+		SlotID:        appointment.SlotID,
+		DoctorID:      appointment.DoctorID,
+		DoctorName:    appointment.DoctorName,
+		PatientID:     appointment.PatientID,
+		SlotDate:      appointment.SlotDate,
+		StartTime:     appointment.StartTime,
+		EndTime:       appointment.EndTime,
+		Status:        appointment.Status,
+		BookingKey:    &bookingKey,
+		CreatedAt:     appointment.CreatedAt,
+		UpdatedAt:     appointment.UpdatedAt,
+	}
+	if err := r.getDB(ctx).Create(do).Error; err != nil {
+		// 只有同一患者对同一号源的有效预约冲突才是重复预约，预约单号冲突等其他错误原样返回
+		var count int64
+		if countErr := r.getDB(ctx).Model(&MtAppointment{}).Where("booking_key = ?", bookingKey).Count(&count).Error; countErr == nil && count > 0 {
+			return fmt.Errorf("%w: slotID=%d, patientID=%d", biz.ErrAppointmentDuplicated, appointment.SlotID, appointment.PatientID)
+		}
+		r.log.Errorf("创建预约失败: %v", err)
+		return err
+	}
+	appointment.ID = do.ID
+	return nil
+}
+
+func toBizAppointment(do *MtAppointment) *biz.Appointment {
+	appointment := &biz.Appointment{
+		ID:            do.ID,
+		AppointmentNo: do.AppointmentNo,
+		SlotID:        do.SlotID,
+		DoctorID:      do.DoctorID,
+		DoctorName:    do.DoctorName,
+		PatientID:     do.PatientID,
+		SlotDate:      do.SlotDate,
+		StartTime:     do.StartTime,
+		EndTime:       do.EndTime,
+		Status:        do.Status,
+		CancelReason:  do.CancelReason,
+		CreatedAt:     do.CreatedAt,
+		UpdatedAt:     do.UpdatedAt,
+	}
+	if do.CancelledAt != nil {
+		appointment.CancelledAt = *do.CancelledAt
+	}
+	return appointment
+}
+
+// 根据预约单号查询预约
+func (r *scheduleRepo) GetAppointment(ctx context.Context, appointmentNo string) (*biz.Appointment, error) {
+	var do MtAppointment
+	if err := r.getDB(ctx).Where("appointment_no = ?", appointmentNo).First(&do).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.log.Errorf("查询预约失败: %v", err)
+		return nil, err
+	}
+	return toBizAppointment(&do), nil
+}
+
+// 分页查询患者的预约
+func (r *scheduleRepo) ListAppointments(ctx context.Context, patientID int32, status string, page, pageSize int32) ([]*biz.Appointment, int64, error) {
+	query := r.getDB(ctx).Model(&MtAppointment{}).Where("patient_id = ?", patientID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.log.Errorf("统计预约数量失败: %v", err)
+		return nil, 0, err
+	}
+
+	var records []MtAppointment
+	offset := int((page - 1) * pageSize)
+	if err := query.Order("slot_date DESC, start_time DESC, id DESC").Offset(offset).Limit(int(pageSize)).Find(&records).Error; err != nil {
+		r.log.Errorf("查询预约列表失败: %v", err)
+		return nil, 0, err
+	}
+	result := make([]*biz.Appointment, len(records))
+	for i := range records {
+		result[i] = toBizAppointment(&records[i])
+	}
+	return result, total, nil
+}
+
+// 以已预约为条件取消预约，并释放booking_key以便再次预约该时段
+func (r *scheduleRepo) CancelAppointment(ctx context.Context, appointmentNo, reason string, cancelledAt time.Time) error {
+	result := r.getDB(ctx).Model(&MtAppointment{}).
+		Where("appointment_no = ? AND status = ?", appointmentNo, biz.AppointmentStatusBooked).
+		Updates(map[string]interface{}{
+			"status":        biz.AppointmentStatusCancelled,
+			"booking_key":   nil,
+			"cancel_reason": reason,
+			"cancelled_at":  cancelledAt,
+			"updated_at":    cancelledAt,
+		})
+	if result.Error != nil {
+		r.log.Errorf("取消预约失败: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: appointmentNo=%s", biz.ErrAppointmentStatusConflict, appointmentNo)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+// 测试医生每天9-11点出诊，每30分钟一个时段，每个时段2个号
+func newScheduleTestUsecase(t *testing.T) (*Data, *biz.ScheduleUsecase) {
+	t.Helper()
+	d := newTestData(t, &biz.MtDoctors{}, &MtDoctorSchedule{}, &MtDoctorScheduleException{}, &MtScheduleSlot{}, &MtAppointment{})
+	if err := d.Db.Create(&biz.MtDoctors{Id: uint64(testDoctorID), Name: "张医生", Status: "1"}).Error; err != nil {
+		t.Fatalf("创建测试医生失败: %v", err)
+	}
+	for weekday := int32(1); weekday <= 7; weekday++ {
+		schedule := &MtDoctorSchedule{DoctorID: testDoctorID, Weekday: weekday, StartTime: "09:00", EndTime: "11:00", SlotMinutes: 30, Capacity: 2, Status: 1}
+		if err := d.Db.Create(schedule).Error; err != nil {
+			t.Fatalf("创建测试排班失败: %v", err)
+		}
+	}
+	logger := newTestLogger()
+	return d, biz.NewScheduleUsecase(NewScheduleRepo(d, logger), NewDoctorsRepo(d, logger), logger)
+}
+
+// 测试按排班模板和停诊、加诊例外生成号源
+func TestListDoctorSlots(t *testing.T) {
+	d, uc := newScheduleTestUsecase(t)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	dayAfter := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+	exceptions := []*MtDoctorScheduleException{
+		{DoctorID: testDoctorID, ExceptionDate: tomorrow, Type: biz.ScheduleExceptionOff, StartTime: "10:00", EndTime: "10:30"},
+		{DoctorID: testDoctorID, ExceptionDate: tomorrow, Type: biz.ScheduleExceptionExtra, StartTime: "14:00", EndTime: "15:00", SlotMinutes: 60, Capacity: 1},
+		{DoctorID: testDoctorID, ExceptionDate: dayAfter, Type: biz.ScheduleExceptionOff, Reason: "外出会诊"},
+	}
+	if err := d.Db.Create(&exceptions).Error; err != nil {
+		t.Fatalf("创建排班例外失败: %v", err)
+	}
+
+	slots, err := uc.ListDoctorSlots(ctx, testDoctorID, tomorrow, 2)
+	if err != nil {
+		t.Fatalf("ListDoctorSlots failed: %v", err)
+	}
+	var got []string
+	for _, slot := range slots {
+		got = append(got, slot.SlotDate+" "+slot.StartTime+"-"+slot.EndTime)
+	}
+	want := []string{tomorrow + " 09:00-09:30", tomorrow + " 09:30-10:00", tomorrow + " 10:30-11:00", tomorrow + " 14:00-15:00"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected slots %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected slot %s, got %s", want[i], got[i])
+		}
+	}
+
+	// 重复查询不会重复生成
+	if _, err := uc.ListDoctorSlots(ctx, testDoctorID, tomorrow, 2); err != nil {
+		t.Fatalf("ListDoctorSlots failed: %v", err)
+	}
+	var count int64
+	d.Db.Model(&MtScheduleSlot{}).Count(&count)
+	if count != 4 {
+		t.Errorf("Expected 4 slots, got %d", count)
+	}
+}
+
+// 测试并发预约不超约、同一患者不能重复预约，取消后归还号源
+func TestConcurrentBookAppointment(t *testing.T) {
+	d, uc := newScheduleTestUsecase(t)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	slots, err := uc.ListDoctorSlots(ctx, testDoctorID, tomorrow, 1)
+	if err != nil || len(slots) == 0 {
+		t.Fatalf("Expected slots, got %v, %v", slots, err)
+	}
+	slotID := slots[0].ID
+
+	const workers = 6
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded []*biz.Appointment
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(patientID int32) {
+			defer wg.Done()
+			appointment, err := uc.BookAppointment(ctx, patientID, slotID)
+			if err == nil {
+				mu.Lock()
+				succeeded = append(succeeded, appointment)
+				mu.Unlock()
+			} else if !errors.Is(err, biz.ErrSlotUnavailable) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(testPatientID + int32(i))
+	}
+	wg.Wait()
+
+	if len(succeeded) != 2 {
+		t.Fatalf("Expected exactly 2 bookings, got %d", len(succeeded))
+	}
+	var slot MtScheduleSlot
+	d.Db.First(&slot, slotID)
+	if slot.Booked != 2 {
+		t.Errorf("Expected booked 2, got %d", slot.Booked)
+	}
+
+	// 同一患者并发重复预约另一个时段只成功一次
+	other := slots[1].ID
+	var duplicated int
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := uc.BookAppointment(ctx, 2001, other); errors.Is(err, biz.ErrAppointmentDuplicated) {
+				mu.Lock()
+				duplicated++
+				mu.Unlock()
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	var otherSlot MtScheduleSlot
+	d.Db.First(&otherSlot, other)
+	if duplicated != 2 || otherSlot.Booked != 1 {
+		t.Errorf("Expected 2 duplicated and booked 1, got %d and %d", duplicated, otherSlot.Booked)
+	}
+
+	// 取消后归还号源，其他患者可以再约
+	first := succeeded[0]
+	if _, err := uc.CancelAppointment(ctx, first.PatientID+100, first.AppointmentNo, ""); err == nil {
+		t.Errorf("Expected other patient denied")
+	}
+	cancelled, err := uc.CancelAppointment(ctx, first.PatientID, first.AppointmentNo, "")
+	if err != nil || cancelled.Status != biz.AppointmentStatusCancelled {
+		t.Fatalf("Expected cancelled, got %v, %v", cancelled, err)
+	}
+	if _, err := uc.CancelAppointment(ctx, first.PatientID, first.AppointmentNo, ""); err == nil {
+		t.Errorf("Expected repeated cancel rejected")
+	}
+	if _, err := uc.BookAppointment(ctx, first.PatientID, slotID); err != nil {
+		t.Errorf("Expected rebooking after cancel, got %v", err)
+	}
+	list, total, err := uc.ListAppointments(ctx, first.PatientID, "", 1, 10)
+	if err != nil || total != 2 || list[0].DoctorName != "张医生" {
+		t.Errorf("Unexpected appointments %v, %d, %v", list, total, err)
+	}
+	// 预约单号冲突不是重复预约，原样返回插入错误
+	repo := NewScheduleRepo(d, newTestLogger())
+	collided := *list[0]
+	collided.ID = 0
+	collided.PatientID = 3001
+	if err := repo.CreateAppointment(ctx, &collided); err == nil || errors.Is(err, biz.ErrAppointmentDuplicated) {
+		t.Errorf("Expected insert error for appointment_no collision, got %v", err)
+	}
+}
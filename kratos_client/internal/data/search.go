@@ -0,0 +1,497 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+)
+
+// 药品搜索索引别名，重建索引时切换别名指向
+const DrugIndexAlias = "mt_drug"
+
+// 药品索引映射，字段与 biz.DrugDocument 对应
+// drug_name 使用标准分词，中文按单字切分，match_phrase 即为子串匹配，与数据库 LIKE 语义一致
+// synonyms 为通用名和商品名，name_pinyin、name_initials 为名称拼音，用 wildcard 做子串匹配
+const DrugIndexMapping = `{
+	"mappings": {
+		"properties": {
+			"id":                 {"type": "long"},
+			"drug_name":          {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+			"specification":      {"type": "keyword"},
+			"first_category_id":  {"type": "integer"},
+			"second_category_id": {"type": "integer"},
+			"price":              {"type": "double"},
+			"sales_volume":       {"type": "double"},
+			"inventory":          {"type": "long"},
+			"manufacturer":       {"type": "keyword"},
+			"exhibition_id":      {"type": "integer"},
+			"keywords":           {"type": "text"},
+			"synonyms":           {"type": "text"},
+			"name_pinyin":        {"type": "keyword"},
+			"name_initials":      {"type": "keyword"},
+			"symptoms":           {"type": "text"},
+			"drug_store_id":      {"type": "integer"},
+			"is_prescription":    {"type": "boolean"},
+			"created_at":         {"type": "date"},
+			"updated_at":         {"type": "date"}
+		}
+	}
+}`
+
+// 药品一级分类，仅用于分面展示分类名称
+type MtDrugTypeStair struct {
+	ID        int64  `gorm:"column:id;primaryKey"`
+	StairName string `gorm:"column:stair_name;size:40"`
+}
+
+func (MtDrugTypeStair) TableName() string {
+	return "mt_drug_type_stair"
+}
+
+type drugSearchRepo struct {
+	data  *Data
+	index string
+	names *drugNameDictionary
+	log   *log.Helper
+}
+
+// 创建药品搜索仓储，Elasticsearch 不可用时回退到数据库查询
+func NewDrugSearchRepo(data *Data, logger log.Logger) biz.DrugSearchRepo {
+	return &drugSearchRepo{
+		data:  data,
+		index: DrugIndexAlias,
+		names: newDrugNameDictionary(data),
+		log:   log.NewHelper(logger),
+	}
+}
+
+func (r *drugSearchRepo) Search(ctx context.Context, req *biz.SearchRequest) (*biz.SearchResponse, error) {
+	var (
+		resp *biz.SearchResponse
+		err  error
+	)
+	if r.data.Es != nil {
+		resp, err = r.searchES(ctx, req)
+		if err != nil {
+			r.log.Warnf("Elasticsearch搜索失败，回退到数据库: %v", err)
+		}
+	}
+	if resp == nil {
+		resp, err = r.searchDB(ctx, req)
+		if err != nil {
+			r.log.Errorf("数据库搜索药品失败: %v", err)
+			return nil, err
+		}
+	}
+	r.fillCategoryNames(ctx, resp.Facets.Categories)
+	return resp, nil
+}
+
+func (r *drugSearchRepo) ListDrugNames(ctx context.Context) ([]*biz.DrugNameEntry, error) {
+	entries, err := r.names.Entries(ctx)
+	if err != nil {
+		r.log.Errorf("读取药品名称词典失败: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+type esBucket struct {
+	Key      interface{} `json:"key"`
+	DocCount int64       `json:"doc_count"`
+}
+
+type esSearchResult struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source biz.DrugDocument `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations struct {
+		Categories    struct{ Buckets []esBucket } `json:"categories"`
+		Manufacturers struct{ Buckets []esBucket } `json:"manufacturers"`
+		PriceRanges   struct{ Buckets []esBucket } `json:"price_ranges"`
+	} `json:"aggregations"`
+}
+
+func (r *drugSearchRepo) searchES(ctx context.Context, req *biz.SearchRequest) (*biz.SearchResponse, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(buildDrugSearchQuery(req)); err != nil {
+		return nil, err
+	}
+	es := r.data.Es
+	res, err := es.Search(
+		es.Search.WithContext(ctx),
+		es.Search.WithIndex(r.index),
+		es.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch返回错误: %s", res.String())
+	}
+
+	var result esSearchResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析搜索结果失败: %v", err)
+	}
+
+	resp := &biz.SearchResponse{
+		Total:  result.Hits.Total.Value,
+		Drugs:  make([]*biz.DrugInfo, 0, len(result.Hits.Hits)),
+		Facets: &biz.SearchFacets{},
+	}
+	for _, hit := range result.Hits.Hits {
+		doc := hit.Source
+		resp.Drugs = append(resp.Drugs, &biz.DrugInfo{
+			ID:             doc.ID,
+			DrugName:       doc.DrugName,
+			Specification:  doc.Specification,
+			Price:          doc.Price,
+			SalesVolume:    doc.SalesVolume,
+			Inventory:      doc.Inventory,
+			Manufacturer:   doc.Manufacturer,
+			IsPrescription: doc.IsPrescription,
+			ExhibitionID:   doc.ExhibitionID,
+		})
+	}
+	for _, bucket := range result.Aggregations.Categories.Buckets {
+		id, _ := bucket.Key.(float64)
+		resp.Facets.Categories = append(resp.Facets.Categories, biz.CategoryFacet{ID: int32(id), Count: bucket.DocCount})
+	}
+	for _, bucket := range result.Aggregations.Manufacturers.Buckets {
+		name, _ := bucket.Key.(string)
+		resp.Facets.Manufacturers = append(resp.Facets.Manufacturers, biz.ManufacturerFacet{Name: name, Count: bucket.DocCount})
+	}
+	for _, bucket := range result.Aggregations.PriceRanges.Buckets {
+		key, _ := bucket.Key.(string)
+		resp.Facets.PriceRanges = append(resp.Facets.PriceRanges, biz.PriceFacet{Range: key, Count: bucket.DocCount})
+	}
+	trimFacets(resp.Facets)
+	return resp, nil
+}
+
+// 构造Elasticsearch查询，过滤条件与 filterDrugs 保持一致
+func buildDrugSearchQuery(req *biz.SearchRequest) map[string]interface{} {
+	must := []interface{}{}
+	if req.Keyword != "" {
+		must = append(must, keywordQuery(biz.ParseDrugKeyword(req.Keyword)))
+	}
+	filter := []interface{}{}
+	term := func(field string, value interface{}) {
+		filter = append(filter, map[string]interface{}{
+			"term": map[string]interface{}{field: value},
+		})
+	}
+	if req.CategoryID > 0 {
+		term("first_category_id", req.CategoryID)
+	}
+	if req.DrugStoreID > 0 {
+		term("drug_store_id", req.DrugStoreID)
+	}
+	if !req.IncludePrescription {
+		term("is_prescription", false)
+	}
+	if req.OnlyInStock {
+		filter = append(filter, map[string]interface{}{
+			"range": map[string]interface{}{"inventory": map[string]interface{}{"gt": 0}},
+		})
+	}
+	if req.PriceRange != nil && (req.PriceRange.Min > 0 || req.PriceRange.Max > 0) {
+		price := map[string]interface{}{}
+		if req.PriceRange.Min > 0 {
+			price["gte"] = req.PriceRange.Min
+		}
+		if req.PriceRange.Max > 0 {
+			price["lte"] = req.PriceRange.Max
+		}
+		filter = append(filter, map[string]interface{}{
+			"range": map[string]interface{}{"price": price},
+		})
+	}
+
+	order := func(field, direction string) map[string]interface{} {
+		return map[string]interface{}{field: map[string]interface{}{"order": direction}}
+	}
+	var sort []interface{}
+	switch req.SortBy {
+	case biz.SearchSortPriceAsc:
+		sort = append(sort, order("price", "asc"))
+	case biz.SearchSortPriceDesc:
+		sort = append(sort, order("price", "desc"))
+	case biz.SearchSortSalesDesc:
+		sort = append(sort, order("sales_volume", "desc"))
+	default:
+		if req.Keyword != "" {
+			sort = append(sort, order("_score", "desc"))
+		}
+	}
+	sort = append(sort, order("id", "asc"))
+
+	ranges := make([]interface{}, 0, len(biz.SearchPriceBuckets))
+	for _, bucket := range biz.SearchPriceBuckets {
+		rng := map[string]interface{}{"key": bucket.Range, "from": bucket.Min}
+		if bucket.Max > 0 {
+			rng["to"] = bucket.Max
+		}
+		ranges = append(ranges, rng)
+	}
+
+	// 分面多取一个，剔除未分类和空厂家后仍能凑满
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{"must": must, "filter": filter},
+		},
+		"sort":             sort,
+		"from":             (req.Page - 1) * req.Size,
+		"size":             req.Size,
+		"track_total_hits": true,
+		"aggs": map[string]interface{}{
+			"categories": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "first_category_id", "size": biz.SearchCategoryFacetSize + 1},
+			},
+			"manufacturers": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "manufacturer", "size": biz.SearchManufacturerFacetSize + 1},
+			},
+			"price_ranges": map[string]interface{}{
+				"range": map[string]interface{}{"field": "price", "ranges": ranges},
+			},
+		},
+	}
+}
+
+// 关键词命中药品名称或同义名，或拼音全拼、首字母包含关键词的拼音
+func keywordQuery(kw biz.DrugKeyword) map[string]interface{} {
+	should := []interface{}{
+		map[string]interface{}{"match_phrase": map[string]interface{}{"drug_name": map[string]interface{}{"query": kw.Text, "boost": 2}}},
+		map[string]interface{}{"match_phrase": map[string]interface{}{"synonyms": kw.Text}},
+	}
+	wildcard := func(field, value string) {
+		should = append(should, map[string]interface{}{
+			"wildcard": map[string]interface{}{field: map[string]interface{}{"value": "*" + value + "*"}},
+		})
+	}
+	if kw.Pinyin != "" {
+		wildcard("name_pinyin", kw.Pinyin)
+	}
+	if kw.Initials != "" {
+		wildcard("name_initials", kw.Initials)
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{"should": should, "minimum_should_match": 1},
+	}
+}
+
+// 数据库过滤条件，与 buildDrugSearchQuery 保持一致
+// keywordIDs 为名称词典中按同义名和拼音命中关键词的药品ID
+func (r *drugSearchRepo) filterDrugs(ctx context.Context, req *biz.SearchRequest, keywordIDs []int64) *gorm.DB {
+	db := r.data.Db.WithContext(ctx).Model(&biz.MtDrug{})
+	if req.Keyword != "" {
+		db = db.Where(keywordCondition(r.data.Db, req.Keyword, keywordIDs))
+	}
+	if req.CategoryID > 0 {
+		db = db.Where("frist_category_id = ?", req.CategoryID)
+	}
+	if req.DrugStoreID > 0 {
+		db = db.Where("drug_store = ?", req.DrugStoreID)
+	}
+	if !req.IncludePrescription {
+		db = db.Where("is_prescription = ?", false)
+	}
+	if req.OnlyInStock {
+		db = db.Where("inventory > 0")
+	}
+	if req.PriceRange != nil {
+		if req.PriceRange.Min > 0 {
+			db = db.Where("price >= ?", req.PriceRange.Min)
+		}
+		if req.PriceRange.Max > 0 {
+			db = db.Where("price <= ?", req.PriceRange.Max)
+		}
+	}
+	return db
+}
+
+func (r *drugSearchRepo) searchDB(ctx context.Context, req *biz.SearchRequest) (*biz.SearchResponse, error) {
+	var keywordIDs []int64
+	if req.Keyword != "" {
+		var err error
+		if keywordIDs, err = r.names.MatchIDs(ctx, req.Keyword); err != nil {
+			r.log.Warnf("读取药品名称词典失败，仅按名称匹配: %v", err)
+		}
+	}
+	filterDrugs := func() *gorm.DB {
+		return r.filterDrugs(ctx, req, keywordIDs)
+	}
+
+	resp := &biz.SearchResponse{Facets: &biz.SearchFacets{}}
+	if err := filterDrugs().Count(&resp.Total).Error; err != nil {
+		return nil, err
+	}
+
+	// 数据库没有相关度，默认按ID排序
+	query := filterDrugs()
+	switch req.SortBy {
+	case biz.SearchSortPriceAsc:
+		query = query.Order("price ASC")
+	case biz.SearchSortPriceDesc:
+		query = query.Order("price DESC")
+	case biz.SearchSortSalesDesc:
+		query = query.Order("sales_volume DESC")
+	}
+	var drugs []*biz.MtDrug
+	if err := query.Order("id ASC").Offset((req.Page - 1) * req.Size).Limit(req.Size).Find(&drugs).Error; err != nil {
+		return nil, err
+	}
+	resp.Drugs = make([]*biz.DrugInfo, 0, len(drugs))
+	for _, drug := range drugs {
+		resp.Drugs = append(resp.Drugs, &biz.DrugInfo{
+			ID:             int64(drug.Id),
+			DrugName:       drug.DrugName,
+			Specification:  drug.Specification,
+			Price:          float64(drug.Price),
+			SalesVolume:    float64(drug.SalesVolume),
+			Inventory:      int64(drug.Inventory),
+			Manufacturer:   drug.Manufacturer,
+			IsPrescription: drug.IsPrescription,
+			ExhibitionID:   int32(drug.ExhibitionId),
+		})
+	}
+
+	// 分面排序与Elasticsearch terms聚合一致：数量降序，数量相同按键升序
+	var categories []struct {
+		ID    int32
+		Count int64
+	}
+	err := filterDrugs().
+		Select("frist_category_id AS id, COUNT(*) AS count").
+		Group("frist_category_id").
+		Order("count DESC, frist_category_id ASC").
+		Limit(biz.SearchCategoryFacetSize + 1).
+		Scan(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		resp.Facets.Categories = append(resp.Facets.Categories, biz.CategoryFacet{ID: category.ID, Count: category.Count})
+	}
+
+	var manufacturers []struct {
+		Name  string
+		Count int64
+	}
+	err = filterDrugs().
+		Select("manufacturer AS name, COUNT(*) AS count").
+		Group("manufacturer").
+		Order("count DESC, manufacturer ASC").
+		Limit(biz.SearchManufacturerFacetSize + 1).
+		Scan(&manufacturers).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, manufacturer := range manufacturers {
+		resp.Facets.Manufacturers = append(resp.Facets.Manufacturers, biz.ManufacturerFacet{Name: manufacturer.Name, Count: manufacturer.Count})
+	}
+
+	var cases strings.Builder
+	var args []interface{}
+	cases.WriteString("CASE")
+	for i, bucket := range biz.SearchPriceBuckets {
+		if bucket.Max > 0 {
+			cases.WriteString(" WHEN price >= ? AND price < ? THEN ?")
+			args = append(args, bucket.Min, bucket.Max, i)
+		} else {
+			cases.WriteString(" WHEN price >= ? THEN ?")
+			args = append(args, bucket.Min, i)
+		}
+	}
+	cases.WriteString(" ELSE -1 END")
+	var priceCounts []struct {
+		Bucket int
+		Count  int64
+	}
+	err = filterDrugs().
+		Select(cases.String()+" AS bucket, COUNT(*) AS count", args...).
+		Group("bucket").
+		Scan(&priceCounts).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int64, len(priceCounts))
+	for _, priceCount := range priceCounts {
+		counts[priceCount.Bucket] = priceCount.Count
+	}
+	for i, bucket := range biz.SearchPriceBuckets {
+		resp.Facets.PriceRanges = append(resp.Facets.PriceRanges, biz.PriceFacet{Range: bucket.Range, Count: counts[i]})
+	}
+
+	trimFacets(resp.Facets)
+	return resp, nil
+}
+
+// 剔除未分类和空厂家，并截断到分面上限
+func trimFacets(facets *biz.SearchFacets) {
+	categories := make([]biz.CategoryFacet, 0, len(facets.Categories))
+	for _, category := range facets.Categories {
+		if category.ID > 0 && len(categories) < biz.SearchCategoryFacetSize {
+			categories = append(categories, category)
+		}
+	}
+	facets.Categories = categories
+
+	manufacturers := make([]biz.ManufacturerFacet, 0, len(facets.Manufacturers))
+	for _, manufacturer := range facets.Manufacturers {
+		if manufacturer.Name != "" && len(manufacturers) < biz.SearchManufacturerFacetSize {
+			manufacturers = append(manufacturers, manufacturer)
+		}
+	}
+	facets.Manufacturers = manufacturers
+}
+
+// 补全分类分面的分类名称，查询失败时只返回分类ID
+func (r *drugSearchRepo) fillCategoryNames(ctx context.Context, categories []biz.CategoryFacet) {
+	if len(categories) == 0 {
+		return
+	}
+	ids := make([]int32, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+	var stairs []*MtDrugTypeStair
+	if err := r.data.Db.WithContext(ctx).Where("id IN ?", ids).Find(&stairs).Error; err != nil {
+		r.log.Warnf("查询药品分类名称失败: %v", err)
+		return
+	}
+	names := make(map[int64]string, len(stairs))
+	for _, stair := range stairs {
+		names[stair.ID] = stair.StairName
+	}
+	for i := range categories {
+		categories[i].Name = names[int64(categories[i].ID)]
+	}
+}
+
+// 名称子串匹配，或药品ID在词典命中结果中
+func keywordCondition(db *gorm.DB, keyword string, keywordIDs []int64) *gorm.DB {
+	cond := db.Where("drug_name LIKE ? ESCAPE '!'", "%"+escapeLike(keyword)+"%")
+	if len(keywordIDs) > 0 {
+		cond = cond.Or("id IN ?", keywordIDs)
+	}
+	return cond
+}
+
+// 转义LIKE通配符，配合 ESCAPE '!' 使用
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,334 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"kratos_client/internal/biz"
+)
+
+// 搜索测试药品：覆盖分类、药店、价格、库存、处方药以及未分类和空厂家
+var searchTestDrugs = []*biz.MtDrug{
+	{Id: 1, DrugName: "感冒灵颗粒", Explain: 1, FristCategoryId: 1, DrugStore: 1, Price: 15.8, SalesVolume: 156, Inventory: 120, Manufacturer: "三九药业", ExhibitionId: 1},
+	{Id: 2, DrugName: "板蓝根颗粒", Explain: 2, FristCategoryId: 1, DrugStore: 1, Price: 12.5, SalesVolume: 234, Inventory: 0, Manufacturer: "白云山", ExhibitionId: 2},
+	{Id: 3, DrugName: "复方感冒灵片", FristCategoryId: 1, DrugStore: 2, Price: 22, SalesVolume: 80, Inventory: 30, Manufacturer: "三九药业", ExhibitionId: 3},
+	{Id: 4, DrugName: "阿莫西林胶囊", FristCategoryId: 2, DrugStore: 1, Price: 18.9, SalesVolume: 78, Inventory: 95, Manufacturer: "石药集团", ExhibitionId: 4, IsPrescription: true},
+	{Id: 5, DrugName: "头孢克肟胶囊", FristCategoryId: 2, DrugStore: 2, Price: 35.6, SalesVolume: 45, Inventory: 60, Manufacturer: "齐鲁制药", ExhibitionId: 5, IsPrescription: true},
+	{Id: 6, DrugName: "维生素C片", Explain: 6, FristCategoryId: 3, DrugStore: 2, Price: 28, SalesVolume: 89, Inventory: 200, Manufacturer: "华北制药", ExhibitionId: 6},
+	{Id: 7, DrugName: "感冒清热颗粒", FristCategoryId: 1, DrugStore: 1, Price: 68, SalesVolume: 156, Inventory: 10, Manufacturer: "同仁堂", ExhibitionId: 7},
+	{Id: 8, DrugName: "医用口罩100%", DrugStore: 1, Price: 120, SalesVolume: 12, Inventory: 500},
+}
+
+// 搜索测试说明书：通用名和商品名作为同义名参与搜索
+var searchTestExplains = []*biz.MtExplain{
+	{Id: 1, CommonName: "感冒灵颗粒", GoodsName: "999感冒灵"},
+	{Id: 2, CommonName: "板蓝根颗粒", GoodsName: "白云山板蓝根"},
+	{Id: 6, CommonName: "维生素C咀嚼片", GoodsName: "力度伸"},
+}
+
+func newSearchTestData(t *testing.T) *Data {
+	t.Helper()
+	d := newTestData(t, &biz.MtDrug{}, &biz.MtExplain{}, &MtDrugTypeStair{})
+	if err := d.Db.Create(searchTestDrugs).Error; err != nil {
+		t.Fatalf("创建测试药品失败: %v", err)
+	}
+	if err := d.Db.Create(searchTestExplains).Error; err != nil {
+		t.Fatalf("创建测试说明书失败: %v", err)
+	}
+	stairs := []*MtDrugTypeStair{{ID: 1, StairName: "感冒发烧"}, {ID: 2, StairName: "抗生素"}, {ID: 3, StairName: "维生素"}}
+	if err := d.Db.Create(stairs).Error; err != nil {
+		t.Fatalf("创建测试分类失败: %v", err)
+	}
+	return d
+}
+
+// 设置 TEST_ELASTICSEARCH_ADDRESSES 时在真实集群上建临时索引，否则使用进程内模拟的Elasticsearch
+func newSearchTestEs(t *testing.T) (*elasticsearch.Client, string) {
+	t.Helper()
+	explains := make(map[int16]*biz.MtExplain, len(searchTestExplains))
+	for _, explain := range searchTestExplains {
+		explains[int16(explain.Id)] = explain
+	}
+	docs := make([]*biz.DrugDocument, len(searchTestDrugs))
+	for i, drug := range searchTestDrugs {
+		docs[i] = biz.BuildDrugDocument(&biz.DrugIndexSource{Drug: drug, Explain: explains[drug.Explain]})
+	}
+
+	addresses := os.Getenv("TEST_ELASTICSEARCH_ADDRESSES")
+	if addresses == "" {
+		es, fake := newFakeElasticsearch(t)
+		fake.seed(DrugIndexAlias+"_test", DrugIndexAlias, docs)
+		return es, DrugIndexAlias
+	}
+	es, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: strings.Split(addresses, ",")})
+	if err != nil {
+		t.Fatalf("连接Elasticsearch失败: %v", err)
+	}
+	index := fmt.Sprintf("%s_test_%d", DrugIndexAlias, time.Now().UnixNano())
+	res, err := es.Indices.Create(index, es.Indices.Create.WithBody(strings.NewReader(DrugIndexMapping)))
+	if err != nil || res.IsError() {
+		t.Fatalf("创建测试索引失败: %v %v", err, res)
+	}
+	res.Body.Close()
+	t.Cleanup(func() {
+		if res, err := es.Indices.Delete([]string{index}); err == nil {
+			res.Body.Close()
+		}
+	})
+	for _, doc := range docs {
+		body, _ := json.Marshal(doc)
+		res, err := es.Index(index, bytes.NewReader(body),
+			es.Index.WithDocumentID(strconv.FormatInt(doc.ID, 10)),
+			es.Index.WithRefresh("true"))
+		if err != nil || res.IsError() {
+			t.Fatalf("写入测试文档失败: %v %v", err, res)
+		}
+		res.Body.Close()
+	}
+	return es, index
+}
+
+func TestSearchDrugsElasticsearchMatchesDatabase(t *testing.T) {
+	d := newSearchTestData(t)
+	logger := newTestLogger()
+	dbUc := biz.NewDrugService(NewDrugRepo(d, logger), NewDrugSearchRepo(d, logger), logger)
+
+	es, index := newSearchTestEs(t)
+	esData := &Data{Db: d.Db, Es: es}
+	esRepo := NewDrugSearchRepo(esData, logger)
+	esRepo.(*drugSearchRepo).index = index
+	esUc := biz.NewDrugService(NewDrugRepo(esData, logger), esRepo, logger)
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		req     biz.SearchRequest
+		ordered bool // 默认排序时Elasticsearch按相关度，只比较结果集合
+	}{
+		{name: "全部非处方药", req: biz.SearchRequest{}, ordered: true},
+		{name: "关键词按价格升序", req: biz.SearchRequest{Keyword: "感冒", SortBy: biz.SearchSortPriceAsc}, ordered: true},
+		{name: "关键词按相关度", req: biz.SearchRequest{Keyword: "感冒"}},
+		{name: "分类有货按销量", req: biz.SearchRequest{CategoryID: 1, OnlyInStock: true, SortBy: biz.SearchSortSalesDesc}, ordered: true},
+		{name: "价格区间含处方药", req: biz.SearchRequest{PriceRange: &biz.PriceRange{Min: 15, Max: 40}, IncludePrescription: true, SortBy: biz.SearchSortPriceDesc}, ordered: true},
+		{name: "指定药店", req: biz.SearchRequest{DrugStoreID: 2, IncludePrescription: true, SortBy: biz.SearchSortPriceAsc}, ordered: true},
+		{name: "分页", req: biz.SearchRequest{IncludePrescription: true, SortBy: biz.SearchSortPriceAsc, Page: 2, Size: 3}, ordered: true},
+		{name: "通配符按字面匹配", req: biz.SearchRequest{Keyword: "100%"}, ordered: true},
+		{name: "无结果", req: biz.SearchRequest{Keyword: "不存在的药"}, ordered: true},
+		{name: "拼音全拼", req: biz.SearchRequest{Keyword: "GanMao"}},
+		{name: "拼音首字母", req: biz.SearchRequest{Keyword: "blg"}},
+		{name: "商品名", req: biz.SearchRequest{Keyword: "力度伸"}},
+		{name: "同音错字", req: biz.SearchRequest{Keyword: "感帽灵"}},
+		{name: "错字纠正", req: biz.SearchRequest{Keyword: "感冒清热颗料"}, ordered: true},
+		{name: "拼音纠正", req: biz.SearchRequest{Keyword: "banlangan"}, ordered: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbReq, esReq := tc.req, tc.req
+			want, err := dbUc.SearchDrugs(ctx, &dbReq)
+			if err != nil {
+				t.Fatalf("数据库搜索失败: %v", err)
+			}
+			got, err := esUc.SearchDrugs(ctx, &esReq)
+			if err != nil {
+				t.Fatalf("Elasticsearch搜索失败: %v", err)
+			}
+			// 出错时仓储会静默回退到数据库，直接查一次确认走的是索引
+			if _, err := esRepo.(*drugSearchRepo).searchES(ctx, &esReq); err != nil {
+				t.Fatalf("Elasticsearch查询失败: %v", err)
+			}
+			if got.Total != want.Total {
+				t.Errorf("Expected total %d, got %d", want.Total, got.Total)
+			}
+			if got.CorrectedKeyword != want.CorrectedKeyword {
+				t.Errorf("Expected corrected keyword %q, got %q", want.CorrectedKeyword, got.CorrectedKeyword)
+			}
+			wantDrugs, gotDrugs := want.Drugs, got.Drugs
+			if !tc.ordered {
+				sortDrugInfos(wantDrugs)
+				sortDrugInfos(gotDrugs)
+			}
+			if !reflect.DeepEqual(gotDrugs, wantDrugs) {
+				t.Errorf("Expected drugs %v, got %v", drugInfoIDs(wantDrugs), drugInfoIDs(gotDrugs))
+			}
+			if !reflect.DeepEqual(got.Facets, want.Facets) {
+				t.Errorf("Expected facets %+v, got %+v", want.Facets, got.Facets)
+			}
+		})
+	}
+}
+
+func TestSearchDrugsDatabase(t *testing.T) {
+	d := newSearchTestData(t)
+	logger := newTestLogger()
+	uc := biz.NewDrugService(NewDrugRepo(d, logger), NewDrugSearchRepo(d, logger), logger)
+	ctx := context.Background()
+
+	resp, err := uc.SearchDrugs(ctx, &biz.SearchRequest{Keyword: "感冒", SortBy: biz.SearchSortPriceAsc})
+	if err != nil {
+		t.Fatalf("SearchDrugs failed: %v", err)
+	}
+	if ids := drugInfoIDs(resp.Drugs); resp.Total != 3 || !reflect.DeepEqual(ids, []int64{1, 3, 7}) {
+		t.Errorf("Expected drugs [1 3 7], got total %d %v", resp.Total, ids)
+	}
+	wantCategories := []biz.CategoryFacet{{ID: 1, Name: "感冒发烧", Count: 3}}
+	if !reflect.DeepEqual(resp.Facets.Categories, wantCategories) {
+		t.Errorf("Expected categories %+v, got %+v", wantCategories, resp.Facets.Categories)
+	}
+	wantManufacturers := []biz.ManufacturerFacet{{Name: "三九药业", Count: 2}, {Name: "同仁堂", Count: 1}}
+	if !reflect.DeepEqual(resp.Facets.Manufacturers, wantManufacturers) {
+		t.Errorf("Expected manufacturers %+v, got %+v", wantManufacturers, resp.Facets.Manufacturers)
+	}
+	wantPrices := []biz.PriceFacet{{Range: "0-20", Count: 1}, {Range: "20-50", Count: 1}, {Range: "50-100", Count: 1}, {Range: "100以上", Count: 0}}
+	if !reflect.DeepEqual(resp.Facets.PriceRanges, wantPrices) {
+		t.Errorf("Expected price ranges %+v, got %+v", wantPrices, resp.Facets.PriceRanges)
+	}
+
+	// 默认不含处方药，未分类药品不出现在分类分面中
+	resp, err = uc.SearchDrugs(ctx, &biz.SearchRequest{})
+	if err != nil {
+		t.Fatalf("SearchDrugs failed: %v", err)
+	}
+	if ids := drugInfoIDs(resp.Drugs); resp.Total != 6 || !reflect.DeepEqual(ids, []int64{1, 2, 3, 6, 7, 8}) {
+		t.Errorf("Expected OTC drugs, got total %d %v", resp.Total, ids)
+	}
+	for _, category := range resp.Facets.Categories {
+		if category.ID == 0 {
+			t.Errorf("Expected uncategorized drugs excluded from facets, got %+v", resp.Facets.Categories)
+		}
+	}
+
+	// 拼音、首字母和商品名与名称子串取并集
+	for keyword, want := range map[string][]int64{
+		"ganmao": {1, 3, 7},
+		"gmqr":   {7},
+		"力度伸":    {6},
+		"感帽灵":    {1, 3},
+		"999":    {1},
+	} {
+		resp, err := uc.SearchDrugs(ctx, &biz.SearchRequest{Keyword: keyword})
+		if err != nil {
+			t.Fatalf("SearchDrugs failed: %v", err)
+		}
+		if ids := drugInfoIDs(resp.Drugs); !reflect.DeepEqual(ids, want) || resp.CorrectedKeyword != "" {
+			t.Errorf("%s: expected drugs %v, got %v (corrected %q)", keyword, want, ids, resp.CorrectedKeyword)
+		}
+	}
+
+	// 无结果时纠错重搜；纠错到处方药需要请求包含处方药
+	resp, err = uc.SearchDrugs(ctx, &biz.SearchRequest{Keyword: "感冒清热颗料"})
+	if err != nil {
+		t.Fatalf("SearchDrugs failed: %v", err)
+	}
+	if ids := drugInfoIDs(resp.Drugs); resp.CorrectedKeyword != "感冒清热颗粒" || !reflect.DeepEqual(ids, []int64{7}) {
+		t.Errorf("Expected correction to 感冒清热颗粒, got %q %v", resp.CorrectedKeyword, ids)
+	}
+	resp, err = uc.SearchDrugs(ctx, &biz.SearchRequest{Keyword: "阿莫西林胶襄"})
+	if err != nil {
+		t.Fatalf("SearchDrugs failed: %v", err)
+	}
+	if resp.Total != 0 || resp.CorrectedKeyword != "" {
+		t.Errorf("Expected no correction to prescription drug, got %q total %d", resp.CorrectedKeyword, resp.Total)
+	}
+	resp, err = uc.SearchDrugs(ctx, &biz.SearchRequest{Keyword: "阿莫西林胶襄", IncludePrescription: true})
+	if err != nil {
+		t.Fatalf("SearchDrugs failed: %v", err)
+	}
+	if ids := drugInfoIDs(resp.Drugs); resp.CorrectedKeyword != "阿莫西林胶囊" || !reflect.DeepEqual(ids, []int64{4}) {
+		t.Errorf("Expected correction to 阿莫西林胶囊, got %q %v", resp.CorrectedKeyword, ids)
+	}
+
+	if _, err := uc.SearchDrugs(ctx, &biz.SearchRequest{SortBy: "name"}); err == nil {
+		t.Error("Expected error for unsupported sort")
+	}
+	if _, err := uc.SearchDrugs(ctx, &biz.SearchRequest{PriceRange: &biz.PriceRange{Min: 50, Max: 10}}); err == nil {
+		t.Error("Expected error for invalid price range")
+	}
+}
+
+func TestSuggestDrugs(t *testing.T) {
+	d := newSearchTestData(t)
+	logger := newTestLogger()
+	uc := biz.NewDrugService(NewDrugRepo(d, logger), NewDrugSearchRepo(d, logger), logger)
+	ctx := context.Background()
+
+	// 前缀命中在前，同为前缀按销量降序，同一药品的名称和通用名相同时只出现一次
+	suggestions, err := uc.SuggestDrugs(ctx, "gm", false, 0)
+	if err != nil {
+		t.Fatalf("SuggestDrugs failed: %v", err)
+	}
+	var texts []string
+	for _, suggestion := range suggestions {
+		texts = append(texts, suggestion.Text)
+	}
+	want := []string{"感冒灵颗粒", "感冒清热颗粒", "999感冒灵", "复方感冒灵片"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("Expected suggestions %v, got %v", want, texts)
+	}
+	if suggestions[2].DrugID != 1 || suggestions[2].Source != biz.DrugSuggestSourceSynonym {
+		t.Errorf("Expected synonym suggestion of drug 1, got %+v", suggestions[2])
+	}
+
+	// 默认不联想处方药
+	if suggestions, err = uc.SuggestDrugs(ctx, "amx", false, 0); err != nil || len(suggestions) != 0 {
+		t.Errorf("Expected no prescription suggestions, got %v %v", suggestions, err)
+	}
+	if suggestions, err = uc.SuggestDrugs(ctx, "amx", true, 1); err != nil || len(suggestions) != 1 || suggestions[0].DrugID != 4 {
+		t.Errorf("Expected prescription suggestion, got %v %v", suggestions, err)
+	}
+
+	// 药品列表的关键词同样支持拼音
+	drugs, err := uc.ListDrug(ctx, 0, 0, "wss")
+	if err != nil {
+		t.Fatalf("ListDrug failed: %v", err)
+	}
+	if len(drugs) != 1 || drugs[0].Id != 6 {
+		t.Errorf("Expected drug 6 by initials, got %v", drugs)
+	}
+}
+
+func TestSearchDrugsFallbackOnElasticsearchError(t *testing.T) {
+	d := newSearchTestData(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		http.Error(w, `{"error":"index_not_found_exception"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+	es, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("创建Elasticsearch客户端失败: %v", err)
+	}
+	d.Es = es
+	repo := NewDrugSearchRepo(d, newTestLogger())
+
+	resp, err := repo.Search(context.Background(), &biz.SearchRequest{Keyword: "感冒", Page: 1, Size: 20})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.Total != 3 {
+		t.Errorf("Expected database fallback with 3 results, got %d", resp.Total)
+	}
+}
+
+func sortDrugInfos(drugs []*biz.DrugInfo) {
+	sort.Slice(drugs, func(i, j int) bool { return drugs[i].ID < drugs[j].ID })
+}
+
+func drugInfoIDs(drugs []*biz.DrugInfo) []int64 {
+	ids := make([]int64, len(drugs))
+	for i, drug := range drugs {
+		ids[i] = drug.ID
+	}
+	return ids
+}
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"kratos_client/internal/biz"
+)
+
+// 支付宝账单时间格式
+const (
+	alipayBillTimeLayout   = "2006-01-02 15:04:05"
+	alipayBillHeaderLayout = "2006年01月02日 15:04:05"
+)
+
+// 支付宝账单头部的起止日期，如 #起始日期：[2024年01月01日 00:00:00]   终止日期：[2024年01月02日 00:00:00]
+var (
+	alipayBillStartPattern = regexp.MustCompile(`起始日期：\[([^\]]+)\]`)
+	alipayBillEndPattern   = regexp.MustCompile(`终止日期：\[([^\]]+)\]`)
+)
+
+// 支付宝业务明细账单解析器
+type alipayStatementParser struct{}
+
+// 创建对账单解析器，目前对账的渠道为支付宝
+func NewStatementParser() biz.StatementParser {
+	return &alipayStatementParser{}
+}
+
+// 账单所属渠道
+func (p *alipayStatementParser) Channel() string {
+	return biz.PaymentChannelAlipay
+}
+
+// 解析支付宝业务明细账单，即账单下载包中的“业务明细”CSV文件，支持GBK和UTF-8编码
+func (p *alipayStatementParser) Parse(r io.Reader) (*biz.Statement, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取账单失败: %v", err)
+	}
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(raw) {
+		if raw, err = simplifiedchinese.GBK.NewDecoder().Bytes(raw); err != nil {
+			return nil, fmt.Errorf("账单编码转换失败: %v", err)
+		}
+	}
+
+	statement := &biz.Statement{Channel: p.Channel()}
+	loc := time.FixedZone("CST", 8*3600)
+
+	// 分离注释行和明细行，注释行中含账单起止日期
+	var body bytes.Buffer
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			body.WriteString(line)
+			body.WriteByte('\n')
+			continue
+		}
+		if m := alipayBillStartPattern.FindStringSubmatch(line); m != nil {
+			if statement.StartTime, err = time.ParseInLocation(alipayBillHeaderLayout, m[1], loc); err != nil {
+				return nil, fmt.Errorf("账单起始日期格式错误: %s", m[1])
+			}
+		}
+		if m := alipayBillEndPattern.FindStringSubmatch(line); m != nil {
+			if statement.EndTime, err = time.ParseInLocation(alipayBillHeaderLayout, m[1], loc); err != nil {
+				return nil, fmt.Errorf("账单终止日期格式错误: %s", m[1])
+			}
+		}
+	}
+
+	reader := csv.NewReader(&body)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析账单CSV失败: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("账单无明细表头")
+	}
+
+	// 定位所需列，支付宝会调整列顺序，按表头名称查找
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	index := func(names ...string) (int, error) {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("账单缺少列: %s", names[0])
+	}
+	var tradeNoCol, orderIDCol, typeCol, amountCol, timeCol, refundCol int
+	for _, col := range []struct {
+		dst   *int
+		names []string
+	}{
+		{&tradeNoCol, []string{"支付宝交易号"}},
+		{&orderIDCol, []string{"商户订单号"}},
+		{&typeCol, []string{"业务类型"}},
+		{&amountCol, []string{"订单金额（元）", "订单金额(元)"}},
+		{&timeCol, []string{"完成时间"}},
+		{&refundCol, []string{"退款批次号/请求号"}},
+	} {
+		if *col.dst, err = index(col.names...); err != nil {
+			return nil, err
+		}
+	}
+
+	for n, row := range rows[1:] {
+		field := func(i int) string {
+			if i >= len(row) {
+				return ""
+			}
+			// 支付宝账单为防止表格软件转换数字，在字段后追加制表符
+			return strings.TrimSpace(row[i])
+		}
+
+		record := &biz.StatementRecord{
+			TradeNo:  field(tradeNoCol),
+			OrderID:  field(orderIDCol),
+			RefundID: field(refundCol),
+		}
+		switch field(typeCol) {
+		case "交易":
+			record.Type = biz.StatementTypePayment
+		case "退款":
+			record.Type = biz.StatementTypeRefund
+		default:
+			record.Type = field(typeCol)
+		}
+		amount, err := decimal.NewFromString(field(amountCol))
+		if err != nil {
+			return nil, fmt.Errorf("账单第%d行金额格式错误: %s", n+1, field(amountCol))
+		}
+		record.Amount = amount.Abs()
+		if value := field(timeCol); value != "" {
+			if record.TradeTime, err = time.ParseInLocation(alipayBillTimeLayout, value, loc); err != nil {
+				return nil, fmt.Errorf("账单第%d行完成时间格式错误: %s", n+1, value)
+			}
+		}
+		statement.Records = append(statement.Records, record)
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,183 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"kratos_client/internal/biz"
+)
+
+// 症状分类数据模型 - 对应 mt_symptom 表，由管理后台维护
+// 同义说法、适应症关键词和药品分类ID均以英文逗号分隔
+type MtSymptom struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name         string     `gorm:"column:name;size:20;not null;uniqueIndex" json:"name"`
+	Aliases      string     `gorm:"column:aliases;size:255" json:"aliases"`
+	Indications  string     `gorm:"column:indications;size:255" json:"indications"`
+	CategoryIDs  string     `gorm:"column:category_ids;size:255" json:"category_ids"`
+	DepartmentID int32      `gorm:"column:department_id;not null;default:0" json:"department_id"`
+	Advice       string     `gorm:"column:advice;size:255" json:"advice"`
+	Sort         int        `gorm:"column:sort;not null;default:0" json:"sort"`
+	Enabled      bool       `gorm:"column:enabled;not null;default:1" json:"enabled"`
+	CreatedAt    time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt    *time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// 表名
+func (MtSymptom) TableName() string {
+	return "mt_symptom"
+}
+
+type symptomRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// 创建症状推荐仓储
+func NewSymptomRepo(data *Data, logger log.Logger) biz.SymptomRepo {
+	return &symptomRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *symptomRepo) ListSymptoms(ctx context.Context) ([]*biz.Symptom, error) {
+	var rows []*MtSymptom
+	if err := r.data.Db.WithContext(ctx).Where("enabled = ?", true).Order("sort ASC, id ASC").Find(&rows).Error; err != nil {
+		r.log.Errorf("查询症状分类失败: %v", err)
+		return nil, err
+	}
+	symptoms := make([]*biz.Symptom, len(rows))
+	for i, row := range rows {
+		symptom := &biz.Symptom{
+			ID:           row.ID,
+			Name:         row.Name,
+			Aliases:      splitList(row.Aliases),
+			Indications:  splitList(row.Indications),
+			DepartmentID: row.DepartmentID,
+			Advice:       row.Advice,
+		}
+		for _, item := range splitList(row.CategoryIDs) {
+			if id, err := strconv.ParseInt(item, 10, 32); err == nil && id > 0 {
+				symptom.CategoryIDs = append(symptom.CategoryIDs, int32(id))
+			}
+		}
+		symptoms[i] = symptom
+	}
+	return symptoms, nil
+}
+
+func (r *symptomRepo) ListSymptomCandidates(ctx context.Context, terms []string, categoryIDs []int32, limit int) ([]*biz.DrugIndexSource, error) {
+	db := r.data.Db.WithContext(ctx)
+	explainIDs, err := r.matchFunctionIDs(db.Model(&biz.MtExplain{}), "function", terms)
+	if err != nil {
+		r.log.Errorf("按功能主治查询说明书失败: %v", err)
+		return nil, err
+	}
+	guideIDs, err := r.matchFunctionIDs(db.Model(&biz.MtGuide{}), "major_function", terms)
+	if err != nil {
+		r.log.Errorf("按功能主治查询用药指导失败: %v", err)
+		return nil, err
+	}
+	if len(explainIDs) == 0 && len(guideIDs) == 0 && len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	cond := r.data.Db.Where("1 = 0")
+	if len(categoryIDs) > 0 {
+		cond = cond.Or("frist_category_id IN ?", categoryIDs)
+	}
+	if len(explainIDs) > 0 {
+		cond = cond.Or("`explain` IN ?", explainIDs)
+	}
+	if len(guideIDs) > 0 {
+		cond = cond.Or("guide IN ?", guideIDs)
+	}
+	var drugs []*biz.MtDrug
+	if err := db.Where("is_prescription = ?", false).Where(cond).
+		Order("sales_volume DESC, id ASC").
+		Limit(limit).
+		Find(&drugs).Error; err != nil {
+		r.log.Errorf("查询推荐候选药品失败: %v", err)
+		return nil, err
+	}
+
+	var explainRefs, guideRefs []int16
+	for _, drug := range drugs {
+		if drug.Explain > 0 {
+			explainRefs = append(explainRefs, drug.Explain)
+		}
+		if drug.Guide > 0 {
+			guideRefs = append(guideRefs, drug.Guide)
+		}
+	}
+	explains := make(map[int32]*biz.MtExplain)
+	if len(explainRefs) > 0 {
+		var rows []*biz.MtExplain
+		if err := db.Where("id IN ?", explainRefs).Find(&rows).Error; err != nil {
+			r.log.Errorf("查询说明书失败: %v", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			explains[row.Id] = row
+		}
+	}
+	guides := make(map[int32]*biz.MtGuide)
+	if len(guideRefs) > 0 {
+		var rows []*biz.MtGuide
+		if err := db.Where("id IN ?", guideRefs).Find(&rows).Error; err != nil {
+			r.log.Errorf("查询用药指导失败: %v", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			guides[row.Id] = row
+		}
+	}
+
+	sources := make([]*biz.DrugIndexSource, 0, len(drugs))
+	for _, drug := range drugs {
+		if !drug.DeletedAt.IsZero() {
+			continue
+		}
+		sources = append(sources, &biz.DrugIndexSource{
+			Drug:    drug,
+			Explain: explains[int32(drug.Explain)],
+			Guide:   guides[int32(drug.Guide)],
+		})
+	}
+	return sources, nil
+}
+
+// 功能主治包含任一关键词的记录ID
+func (r *symptomRepo) matchFunctionIDs(db *gorm.DB, column string, terms []string) ([]int32, error) {
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	cond := r.data.Db.Where("1 = 0")
+	for _, term := range terms {
+		cond = cond.Or(column+" LIKE ? ESCAPE '!'", "%"+escapeLike(term)+"%")
+	}
+	var ids []int32
+	if err := db.Where(cond).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// 按英文逗号、中文逗号和顿号切分，去空白
+func splitList(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '，' || r == '、'
+	})
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			result = append(result, field)
+		}
+	}
+	return result
+}
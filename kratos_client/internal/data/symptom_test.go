@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"kratos_client/internal/biz"
+)
+
+// 症状推荐测试数据：
+// 发烧维护了同义说法和适应症关键词，感冒只关联了分类1，咽痛已停用
+// 布洛芬无货且知识库中孕妇禁用，阿莫西林为处方药，退热贴已删除
+func newSymptomTestData(t *testing.T) *Data {
+	t.Helper()
+	d := newTestData(t, &biz.MtDrug{}, &biz.MtExplain{}, &biz.MtGuide{}, &MtSymptom{}, &MtDrugContraindication{})
+	drugs := []*biz.MtDrug{
+		{Id: 1, DrugName: "感冒灵颗粒", FristCategoryId: 1, Explain: 1, SalesVolume: 156, Inventory: 120},
+		{Id: 2, DrugName: "布洛芬混悬液", FristCategoryId: 2, Explain: 2, SalesVolume: 200},
+		{Id: 3, DrugName: "阿莫西林胶囊", FristCategoryId: 3, Explain: 3, SalesVolume: 300, Inventory: 50, IsPrescription: true},
+		{Id: 4, DrugName: "板蓝根颗粒", FristCategoryId: 1, Guide: 1, SalesVolume: 234, Inventory: 80},
+		{Id: 5, DrugName: "维生素C片", FristCategoryId: 1, SalesVolume: 89, Inventory: 200},
+		{Id: 6, DrugName: "退热贴", FristCategoryId: 2, Explain: 4, SalesVolume: 500, Inventory: 10, DeletedAt: time.Now()},
+	}
+	explains := []*biz.MtExplain{
+		{Id: 1, Function: "解热镇痛。用于感冒引起的头痛，发热，鼻塞，流涕，咽痛等。", Taboos: "对本品过敏者禁用。孕妇慎用。"},
+		{Id: 2, Function: "用于儿童普通感冒或流行性感冒引起的发热。"},
+		{Id: 3, Function: "用于敏感菌所致的感染及发热。"},
+		{Id: 4, Function: "用于物理退热。"},
+	}
+	guides := []*biz.MtGuide{
+		{Id: 1, MajorFunction: "清热解毒，凉血利咽。用于肺胃热盛所致的咽喉肿痛。", SpecialCrowd: "儿童、老人应在医师指导下服用。"},
+	}
+	symptoms := []*MtSymptom{
+		{Name: "发烧", Aliases: "发热，高烧", Indications: "解热,退热", DepartmentID: 3, Advice: "持续高热超过3天请及时就医", Enabled: true},
+		{Name: "感冒", CategoryIDs: "1", Sort: 1, Enabled: true},
+		{Name: "咽痛", Indications: "利咽", Enabled: false},
+	}
+	contraindications := []*MtDrugContraindication{
+		{DrugID: 2, Population: biz.PopulationPregnancy, Level: biz.ContraindicationForbidden, Description: "妊娠晚期禁用"},
+	}
+	for _, rows := range []interface{}{drugs, explains, guides, symptoms, contraindications} {
+		if err := d.Db.Create(rows).Error; err != nil {
+			t.Fatalf("创建测试数据失败: %v", err)
+		}
+	}
+	// 布尔字段为false时GORM使用默认值，单独更新停用状态
+	if err := d.Db.Model(&MtSymptom{}).Where("name = ?", "咽痛").Update("enabled", false).Error; err != nil {
+		t.Fatalf("停用症状失败: %v", err)
+	}
+	return d
+}
+
+func recommendedDrugIDs(result *biz.SymptomRecommendation) []int64 {
+	ids := make([]int64, 0, len(result.Drugs))
+	for _, drug := range result.Drugs {
+		ids = append(ids, drug.Drug.ID)
+	}
+	return ids
+}
+
+func warningKeys(warnings []*biz.DrugWarning) []string {
+	keys := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		keys = append(keys, warning.Population+":"+warning.Level)
+	}
+	return keys
+}
+
+func TestRecommendBySymptoms(t *testing.T) {
+	d := newSymptomTestData(t)
+	logger := newTestLogger()
+	uc := biz.NewSymptomUsecase(NewSymptomRepo(d, logger), NewInteractionRepo(d, logger), logger)
+	ctx := context.Background()
+
+	// 同义说法对应到症状分类；不推荐处方药和已删除的药品，有货的排在前面
+	result, err := uc.Recommend(ctx, &biz.SymptomRecommendRequest{Symptoms: []string{" 发热 "}})
+	if err != nil {
+		t.Fatalf("Recommend failed: %v", err)
+	}
+	if len(result.Symptoms) != 1 || result.Symptoms[0].Name != "发烧" || !result.Symptoms[0].Curated {
+		t.Errorf("Expected curated symptom 发烧, got %+v", result.Symptoms)
+	}
+	if ids := recommendedDrugIDs(result); !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Fatalf("Expected drugs [1 2], got %v", ids)
+	}
+	if result.Drugs[0].Indication != "解热镇痛" {
+		t.Errorf("Expected indication excerpt, got %q", result.Drugs[0].Indication)
+	}
+	// 未指定人群时返回全部人群的禁忌：知识库维护的和说明书中识别的
+	if keys := warningKeys(result.Drugs[0].Warnings); !reflect.DeepEqual(keys, []string{"pregnancy:caution"}) {
+		t.Errorf("Expected label warning for drug 1, got %v", keys)
+	}
+	if keys := warningKeys(result.Drugs[1].Warnings); !reflect.DeepEqual(keys, []string{"pregnancy:forbidden"}) {
+		t.Errorf("Expected curated warning for drug 2, got %v", keys)
+	}
+	want := biz.ConsultDoctorAction{Text: biz.ConsultDoctorText, Path: biz.DoctorListPath, DepartmentID: 3}
+	if result.Consult != want {
+		t.Errorf("Expected consult %+v, got %+v", want, result.Consult)
+	}
+
+	// 多个症状：覆盖症状多的优先，仅分类命中的排在后面；孕妇禁用的不推荐
+	result, err = uc.Recommend(ctx, &biz.SymptomRecommendRequest{
+		Symptoms:    []string{"感冒", "发烧", "高烧"},
+		Populations: []string{biz.PopulationPregnancy},
+	})
+	if err != nil {
+		t.Fatalf("Recommend failed: %v", err)
+	}
+	if len(result.Symptoms) != 2 {
+		t.Errorf("Expected duplicate aliases merged, got %+v", result.Symptoms)
+	}
+	if ids := recommendedDrugIDs(result); !reflect.DeepEqual(ids, []int64{1, 4, 5}) {
+		t.Fatalf("Expected drugs [1 4 5], got %v", ids)
+	}
+	if got := result.Drugs[0]; got.Score != 7 || !reflect.DeepEqual(got.MatchedSymptoms, []string{"感冒", "发烧"}) {
+		t.Errorf("Expected drug 1 to match both symptoms, got %+v", got)
+	}
+	if keys := warningKeys(result.Drugs[1].Warnings); len(keys) != 0 {
+		t.Errorf("Expected only pregnancy warnings, got %v", keys)
+	}
+
+	// 不在症状分类中（含已停用）的按原文匹配功能主治，同一句提到多个人群分别提示
+	result, err = uc.Recommend(ctx, &biz.SymptomRecommendRequest{Symptoms: []string{"咽喉肿痛"}})
+	if err != nil {
+		t.Fatalf("Recommend failed: %v", err)
+	}
+	if ids := recommendedDrugIDs(result); len(result.Symptoms) != 1 || result.Symptoms[0].Curated || !reflect.DeepEqual(ids, []int64{4}) {
+		t.Fatalf("Expected uncurated match of drug 4, got %+v %v", result.Symptoms, ids)
+	}
+	if keys := warningKeys(result.Drugs[0].Warnings); !reflect.DeepEqual(keys, []string{"child:caution", "elderly:caution"}) {
+		t.Errorf("Expected child and elderly warnings, got %v", keys)
+	}
+
+	// 没有推荐结果时仍返回问诊入口
+	result, err = uc.Recommend(ctx, &biz.SymptomRecommendRequest{Symptoms: []string{"失眠"}})
+	if err != nil {
+		t.Fatalf("Recommend failed: %v", err)
+	}
+	if len(result.Drugs) != 0 || result.Consult.Path != biz.DoctorListPath || result.Consult.DepartmentID != 0 {
+		t.Errorf("Expected no drugs with consult action, got %+v", result)
+	}
+
+	for _, req := range []*biz.SymptomRecommendRequest{
+		{Symptoms: []string{" "}},
+		{Symptoms: []string{"发烧"}, Populations: []string{"adult"}},
+		{Symptoms: []string{"a", "b", "c", "d", "e", "f"}},
+	} {
+		if _, err := uc.Recommend(ctx, req); err == nil {
+			t.Errorf("Expected error for %+v", req)
+		}
+	}
+}
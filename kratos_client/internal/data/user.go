@@ -58,3 +58,18 @@ func (r *userRepo) FindByID(ctx context.Context, id int32) (*biz.MtUser, error)
 	}
 	return &user, nil
 }
+
+func (r *userRepo) GetCityID(ctx context.Context, userID int32) (int32, error) {
+	var cityIDs []int32
+	if err := r.data.Db.WithContext(ctx).Model(&biz.MtUser{}).Where("id = ?", userID).Limit(1).Pluck("city_id", &cityIDs).Error; err != nil {
+		return 0, err
+	}
+	if len(cityIDs) == 0 {
+		return 0, nil
+	}
+	return cityIDs[0], nil
+}
+
+func (r *userRepo) UpdateCityID(ctx context.Context, userID int32, cityID int32) error {
+	return r.data.Db.WithContext(ctx).Model(&biz.MtUser{}).Where("id = ?", userID).Update("city_id", cityID).Error
+}
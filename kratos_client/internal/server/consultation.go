@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+const (
+	consultationLeaseName          = "consultation_expiry"
+	defaultConsultationAnswerLimit = 30 * time.Minute
+	defaultConsultationTick        = time.Minute
+	defaultConsultationBatch       = 100
+)
+
+// ConsultationServer 退款超时未接诊的问诊、结束到期的问诊，作为kratos Server随应用启停
+type ConsultationServer struct {
+	consultationUc *biz.ConsultationUsecase
+	lease          biz.LeaseRepo
+	holder         string
+	answerTimeout  time.Duration
+	interval       time.Duration
+	batchSize      int
+	stop           chan struct{}
+	log            *log.Helper
+}
+
+// NewConsultationServer 创建问诊超时处理任务
+func NewConsultationServer(c *conf.Consultation, consultationUc *biz.ConsultationUsecase, lease biz.LeaseRepo, logger log.Logger) *ConsultationServer {
+	s := &ConsultationServer{
+		consultationUc: consultationUc,
+		lease:          lease,
+		answerTimeout:  defaultConsultationAnswerLimit,
+		interval:       defaultConsultationTick,
+		batchSize:      defaultConsultationBatch,
+		stop:           make(chan struct{}),
+		log:            log.NewHelper(logger),
+	}
+	if c != nil {
+		if c.AnswerTimeout != nil && c.AnswerTimeout.AsDuration() > 0 {
+			s.answerTimeout = c.AnswerTimeout.AsDuration()
+		}
+		if c.Interval != nil && c.Interval.AsDuration() > 0 {
+			s.interval = c.Interval.AsDuration()
+		}
+		if c.BatchSize > 0 {
+			s.batchSize = int(c.BatchSize)
+		}
+	}
+	hostname, _ := os.Hostname()
+	s.holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	return s
+}
+
+// Start 按固定间隔扫描，直到应用停止
+func (s *ConsultationServer) Start(ctx context.Context) error {
+	s.log.Infof("问诊超时处理任务启动: answerTimeout=%s, interval=%s, holder=%s", s.answerTimeout, s.interval, s.holder)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// Stop 停止扫描并释放租约
+func (s *ConsultationServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	if err := s.lease.Release(ctx, consultationLeaseName, s.holder); err != nil {
+		s.log.Warnf("释放问诊任务租约失败: %v", err)
+	}
+	s.log.Info("问诊超时处理任务已停止")
+	return nil
+}
+
+// RunOnce 持有租约时处理一轮超时的问诊
+func (s *ConsultationServer) RunOnce(ctx context.Context) {
+	acquired, err := s.lease.TryAcquire(ctx, consultationLeaseName, s.holder, 2*s.interval)
+	if err != nil {
+		s.log.Errorf("获取问诊任务租约失败: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	if _, err := s.consultationUc.ExpireConsultations(ctx, s.answerTimeout, s.batchSize); err != nil {
+		s.log.Errorf("处理超时问诊失败: %v", err)
+	}
+}
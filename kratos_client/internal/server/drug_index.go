@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+const (
+	drugIndexLeaseName    = "drug_indexer"
+	defaultDrugIndexTick  = 10 * time.Second
+	defaultDrugIndexBatch = 200
+)
+
+// DrugIndexServer 将药品变更增量同步到Elasticsearch，作为kratos Server随应用启停
+type DrugIndexServer struct {
+	indexUc   *biz.DrugIndexUsecase
+	lease     biz.LeaseRepo
+	holder    string
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+	log       *log.Helper
+}
+
+// NewDrugIndexServer 创建药品索引同步任务
+func NewDrugIndexServer(c *conf.Data, indexUc *biz.DrugIndexUsecase, lease biz.LeaseRepo, logger log.Logger) *DrugIndexServer {
+	s := &DrugIndexServer{
+		indexUc:   indexUc,
+		lease:     lease,
+		interval:  defaultDrugIndexTick,
+		batchSize: defaultDrugIndexBatch,
+		stop:      make(chan struct{}),
+		log:       log.NewHelper(logger),
+	}
+	if c != nil && c.Elasticsearch != nil {
+		if c.Elasticsearch.SyncInterval != nil && c.Elasticsearch.SyncInterval.AsDuration() > 0 {
+			s.interval = c.Elasticsearch.SyncInterval.AsDuration()
+		}
+		if c.Elasticsearch.SyncBatchSize > 0 {
+			s.batchSize = int(c.Elasticsearch.SyncBatchSize)
+		}
+	}
+	hostname, _ := os.Hostname()
+	s.holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	return s
+}
+
+// Start 按固定间隔消费同步任务，未配置Elasticsearch时不执行
+func (s *DrugIndexServer) Start(ctx context.Context) error {
+	if !s.indexUc.Enabled() {
+		s.log.Info("未配置Elasticsearch，药品索引同步任务不启动")
+		return nil
+	}
+	s.log.Infof("药品索引同步任务启动: interval=%s, holder=%s", s.interval, s.holder)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// Stop 停止同步并释放租约
+func (s *DrugIndexServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	if !s.indexUc.Enabled() {
+		return nil
+	}
+	if err := s.lease.Release(ctx, drugIndexLeaseName, s.holder); err != nil {
+		s.log.Warnf("释放药品索引任务租约失败: %v", err)
+	}
+	s.log.Info("药品索引同步任务已停止")
+	return nil
+}
+
+// RunOnce 持有租约时消费同步任务，一批处理满则继续下一批
+func (s *DrugIndexServer) RunOnce(ctx context.Context) {
+	acquired, err := s.lease.TryAcquire(ctx, drugIndexLeaseName, s.holder, 2*s.interval)
+	if err != nil {
+		s.log.Errorf("获取药品索引任务租约失败: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	for {
+		n, err := s.indexUc.ProcessTasks(ctx, s.batchSize)
+		if err != nil {
+			s.log.Errorf("同步药品索引失败: %v", err)
+			return
+		}
+		if n < s.batchSize {
+			return
+		}
+	}
+}
@@ -2,11 +2,16 @@ package server
 
 import (
 	cartv1 "kratos_client/api/cart/v1"
+	chatv1 "kratos_client/api/chat/v1"
+	consultationv1 "kratos_client/api/consultation/v1"
 	doctorsv1 "kratos_client/api/doctors/v1"
 	drug "kratos_client/api/drug/v1"
 	estimate "kratos_client/api/estimate/v1"
 	paymentv1 "kratos_client/api/payment/v1"
+	prescriptionv1 "kratos_client/api/prescription/v1"
+	schedulev1 "kratos_client/api/schedule/v1"
 	userv1 "kratos_client/api/user/v1"
+	workbenchv1 "kratos_client/api/workbench/v1"
 	"kratos_client/comment"
 	"kratos_client/internal/conf"
 	"kratos_client/internal/service"
@@ -17,7 +22,7 @@ import (
 )
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, doctors *service.DoctorsService, drugs *service.DrugService, estimates *service.EstimateService, user *service.UserService, cart *service.CartService, payment *service.PaymentService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, doctors *service.DoctorsService, drugs *service.DrugService, estimates *service.EstimateService, user *service.UserService, cart *service.CartService, payment *service.PaymentService, reconcile *service.ReconcileService, chat *service.ChatService, consultation *service.ConsultationService, schedule *service.ScheduleService, workbench *service.DoctorWorkbenchService, prescription *service.PrescriptionService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Filter(comment.CorsFilter()),
 		http.Middleware(
@@ -46,14 +51,28 @@ func NewHTTPServer(c *conf.Server, doctors *service.DoctorsService, drugs *servi
 	cartv1.RegisterCartHTTPServer(srv, cart)
 	// 注册支付服务
 	paymentv1.RegisterPaymentHTTPServer(srv, payment)
+	// 注册聊天服务
+	chatv1.RegisterChatHTTPServer(srv, chat)
+	// 注册在线问诊服务
+	consultationv1.RegisterConsultationHTTPServer(srv, consultation)
+	schedulev1.RegisterScheduleHTTPServer(srv, schedule)
+	// 注册医生工作台服务
+	workbenchv1.RegisterDoctorWorkbenchHTTPServer(srv, workbench)
+	// 注册处方服务：医生开方、药师审核、患者按处方下单
+	prescriptionv1.RegisterPrescriptionServiceHTTPServer(srv, prescription)
+	// 微信支付、沙箱的通知需要原始报文和请求头验签
+	srv.Route("/").POST("/v1/payment/notify/{channel}", payment.GatewayPaymentNotify)
+	srv.Route("/").POST("/v1/payment/refund/notify/{channel}", payment.GatewayRefundNotify)
+	// 渠道账单对账，使用管理令牌鉴权
+	srv.Route("/").POST("/admin/v1/payment/reconcile", reconcile.Reconcile)
 	// 其他服务暂时注释，避免编译错误
 	// orderv1.RegisterOrderHTTPServer(srv, order)
 	// couponv1.RegisterCouponHTTPServer(srv, coupon)
-	// prescriptionv1.RegisterPrescriptionHTTPServer(srv, prescription)
-	// chatv1.RegisterChatHTTPServer(srv, chat)
 
 	srv.Route("/").POST("/upload", user.Upload, comment.JWTMiddleware())
 	srv.Route("/").POST("/GetTargeted", user.GetTargeted, comment.JWTMiddleware())
+	// 聊天图片、语音上传
+	srv.Route("/").POST("/v1/chat/upload", chat.UploadChatFile, comment.JWTMiddleware())
 
 	// 获取用户在线状态
 	srv.Route("/").GET("/api/heartbeat/user/{userId}/status", func(ctx http.Context) error {
@@ -96,7 +115,7 @@ func NewHTTPServer(c *conf.Server, doctors *service.DoctorsService, drugs *servi
 	}, comment.JWTMiddleware())
 
 	// WebSocket聊天路由 - 不使用JWT中间件，在WebSocket处理器内部验证token
-	// WebSocket功能暂时移除以简化启动
+	srv.HandleFunc("/ws/chat", comment.HandleWebSocket)
 
 	return srv
 }
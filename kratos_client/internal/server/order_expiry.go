@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+const (
+	orderExpiryLeaseName    = "order_expiry"
+	defaultOrderExpiryTTL   = 30 * time.Minute
+	defaultOrderExpiryTick  = time.Minute
+	defaultOrderExpiryBatch = 100
+)
+
+// OrderExpiryServer 超时未支付订单关闭任务，作为kratos Server随应用启停
+type OrderExpiryServer struct {
+	orderUc   *biz.OrderUsecase
+	lease     biz.LeaseRepo
+	holder    string
+	ttl       time.Duration
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+	log       *log.Helper
+}
+
+// NewOrderExpiryServer 创建订单超时关闭任务
+func NewOrderExpiryServer(c *conf.Order, orderUc *biz.OrderUsecase, lease biz.LeaseRepo, logger log.Logger) *OrderExpiryServer {
+	s := &OrderExpiryServer{
+		orderUc:   orderUc,
+		lease:     lease,
+		ttl:       defaultOrderExpiryTTL,
+		interval:  defaultOrderExpiryTick,
+		batchSize: defaultOrderExpiryBatch,
+		stop:      make(chan struct{}),
+		log:       log.NewHelper(logger),
+	}
+	if c != nil && c.Expiry != nil {
+		if c.Expiry.Ttl != nil && c.Expiry.Ttl.AsDuration() > 0 {
+			s.ttl = c.Expiry.Ttl.AsDuration()
+		}
+		if c.Expiry.Interval != nil && c.Expiry.Interval.AsDuration() > 0 {
+			s.interval = c.Expiry.Interval.AsDuration()
+		}
+		if c.Expiry.BatchSize > 0 {
+			s.batchSize = int(c.Expiry.BatchSize)
+		}
+	}
+	hostname, _ := os.Hostname()
+	s.holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	return s
+}
+
+// Start 按固定间隔扫描，直到应用停止
+func (s *OrderExpiryServer) Start(ctx context.Context) error {
+	s.log.Infof("订单超时关闭任务启动: ttl=%s, interval=%s, holder=%s", s.ttl, s.interval, s.holder)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// Stop 停止扫描并释放租约
+func (s *OrderExpiryServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	if err := s.lease.Release(ctx, orderExpiryLeaseName, s.holder); err != nil {
+		s.log.Warnf("释放订单超时任务租约失败: %v", err)
+	}
+	s.log.Info("订单超时关闭任务已停止")
+	return nil
+}
+
+// RunOnce 持有租约时执行一轮超时订单关闭
+func (s *OrderExpiryServer) RunOnce(ctx context.Context) {
+	// 租约时长覆盖两个扫描周期，持有者宕机后其他实例最迟在该时长后接管
+	acquired, err := s.lease.TryAcquire(ctx, orderExpiryLeaseName, s.holder, 2*s.interval)
+	if err != nil {
+		s.log.Errorf("获取订单超时任务租约失败: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	if _, err := s.orderUc.ExpirePendingOrders(ctx, s.ttl, s.batchSize); err != nil {
+		s.log.Errorf("关闭超时未支付订单失败: %v", err)
+	}
+}
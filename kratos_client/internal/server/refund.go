@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+)
+
+const (
+	refundLeaseName    = "refund_processor"
+	defaultRefundTick  = 30 * time.Second
+	defaultRefundBatch = 50
+)
+
+// RefundServer 将审核通过的退款单提交支付渠道，作为kratos Server随应用启停
+type RefundServer struct {
+	refundUc  *biz.RefundUsecase
+	lease     biz.LeaseRepo
+	holder    string
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+	log       *log.Helper
+}
+
+// NewRefundServer 创建退款处理任务
+func NewRefundServer(c *conf.Order, refundUc *biz.RefundUsecase, lease biz.LeaseRepo, logger log.Logger) *RefundServer {
+	s := &RefundServer{
+		refundUc:  refundUc,
+		lease:     lease,
+		interval:  defaultRefundTick,
+		batchSize: defaultRefundBatch,
+		stop:      make(chan struct{}),
+		log:       log.NewHelper(logger),
+	}
+	if c != nil && c.Refund != nil {
+		if c.Refund.Interval != nil && c.Refund.Interval.AsDuration() > 0 {
+			s.interval = c.Refund.Interval.AsDuration()
+		}
+		if c.Refund.BatchSize > 0 {
+			s.batchSize = int(c.Refund.BatchSize)
+		}
+	}
+	hostname, _ := os.Hostname()
+	s.holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	return s
+}
+
+// Start 按固定间隔扫描，直到应用停止
+func (s *RefundServer) Start(ctx context.Context) error {
+	s.log.Infof("退款处理任务启动: interval=%s, holder=%s", s.interval, s.holder)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// Stop 停止扫描并释放租约
+func (s *RefundServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	if err := s.lease.Release(ctx, refundLeaseName, s.holder); err != nil {
+		s.log.Warnf("释放退款任务租约失败: %v", err)
+	}
+	s.log.Info("退款处理任务已停止")
+	return nil
+}
+
+// RunOnce 持有租约时提交一轮审核通过的退款单
+func (s *RefundServer) RunOnce(ctx context.Context) {
+	acquired, err := s.lease.TryAcquire(ctx, refundLeaseName, s.holder, 2*s.interval)
+	if err != nil {
+		s.log.Errorf("获取退款任务租约失败: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	if _, err := s.refundUc.ProcessApprovedRefunds(ctx, s.batchSize); err != nil {
+		s.log.Errorf("提交退款失败: %v", err)
+	}
+}
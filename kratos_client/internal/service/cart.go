@@ -132,3 +132,27 @@ func (s *CartService) ListCart(ctx context.Context, req *cartv1.ListCartRequest)
 		Cart: cartList,
 	}, nil
 }
+
+// 结账前检查购物车药品的相互作用和用药禁忌
+func (s *CartService) CheckCart(ctx context.Context, req *cartv1.CheckCartRequest) (*cartv1.CheckCartReply, error) {
+	result, err := s.uc.CheckCart(ctx, req.UserId, req.Populations)
+	if err != nil {
+		return &cartv1.CheckCartReply{
+			Code: 400,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	msg := "检查通过"
+	if result.Blocked {
+		msg = "购物车中存在不能同时使用或禁用的药品"
+	} else if len(result.Warnings) > 0 {
+		msg = "请留意用药提示"
+	}
+	return &cartv1.CheckCartReply{
+		Code:     0,
+		Msg:      msg,
+		Blocked:  result.Blocked,
+		Warnings: toPbDrugWarnings(result.Warnings),
+	}, nil
+}
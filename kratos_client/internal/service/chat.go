@@ -2,64 +2,336 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"time"
+
 	chatv1 "kratos_client/api/chat/v1"
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
 type ChatService struct {
 	chatv1.UnimplementedChatServer
-	log  *log.Helper
+	uc  *biz.ChatUsecase
+	log *log.Helper
 }
 
-func NewChatService(logger log.Logger) *ChatService {
+func NewChatService(uc *biz.ChatUsecase, broker comment.Broker, logger log.Logger) *ChatService {
+	helper := log.NewHelper(logger)
+	// WebSocket收发的消息经聊天用例落库
+	comment.SetChatMessageStore(&chatMessageStore{uc: uc})
+	// 多副本部署时经代理把消息推送到接收方所在节点
+	if err := comment.SetChatBroker(broker); err != nil {
+		helper.Errorf("订阅聊天代理失败，消息只能推送给本节点的连接: %v", err)
+	}
 	return &ChatService{
-		UnimplementedChatServer: chatv1.UnimplementedChatServer{},
-		log:                     log.NewHelper(logger),
+		uc:  uc,
+		log: helper,
 	}
 }
 
 // 获取聊天历史记录
 func (s *ChatService) GetChatHistory(ctx context.Context, req *chatv1.GetChatHistoryRequest) (*chatv1.GetChatHistoryReply, error) {
-	s.log.Infof("获取聊天历史记录: UserId=%s, TargetId=%s", req.UserId, req.TargetId)
-	
-	// 简化实现 - 返回空的历史记录
+	userID, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &chatv1.GetChatHistoryReply{Code: 401, Message: errMsg}, nil
+	}
+
+	user := biz.ChatUser{Role: role, ID: userID}
+	history, room, err := s.uc.GetHistory(ctx, user, chatTarget(role, req.TargetId, req.TargetRole), req.Cursor, req.Page, req.PageSize)
+	if err != nil {
+		s.log.Errorf("获取聊天历史记录失败: %v", err)
+		return &chatv1.GetChatHistoryReply{Code: 1, Message: err.Error()}, nil
+	}
+
+	messages := make([]*chatv1.ChatMessageInfo, len(history.Messages))
+	for i, message := range history.Messages {
+		messages[i] = toChatMessageInfo(message, room.RoomID)
+	}
 	return &chatv1.GetChatHistoryReply{
-		Code:     0,
-		Message:  "success",
-		Messages: []*chatv1.ChatMessageInfo{},
-		Total:    0,
+		Code:       0,
+		Message:    "success",
+		Messages:   messages,
+		Total:      int32(history.Total),
+		NextCursor: history.NextCursor,
+		HasMore:    history.HasMore,
 	}, nil
 }
 
 // 保存聊天消息
 func (s *ChatService) SaveChatMessage(ctx context.Context, req *chatv1.SaveChatMessageRequest) (*chatv1.SaveChatMessageReply, error) {
-	s.log.Infof("保存聊天消息: FromId=%s, ToId=%s, Content=%s", req.FromId, req.ToId, req.Content)
-	
-	// 简化实现 - 仅记录日志
+	// 发送者以token为准
+	fromID, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &chatv1.SaveChatMessageReply{Code: 401, Message: errMsg}, nil
+	}
+	from, to := biz.ChatUser{Role: role, ID: fromID}, chatTarget(role, req.ToId, req.ToRole)
+	if req.RoomId != "" && req.RoomId != biz.ChatRoomID(from, to) {
+		return &chatv1.SaveChatMessageReply{Code: 403, Message: "无权在该房间发送消息"}, nil
+	}
+
+	sendReq := &biz.SendChatMessageRequest{
+		From:        from,
+		To:          to,
+		MessageType: req.MessageType,
+		Content:     req.Content,
+	}
+	var payload interface{}
+	switch req.MessageType {
+	case biz.ChatMessageTypeImage:
+		if req.Image != nil {
+			payload = biz.ChatImagePayload{URL: req.Image.Url, Width: req.Image.Width, Height: req.Image.Height, Size: req.Image.Size}
+		}
+	case biz.ChatMessageTypeVoice:
+		if req.Voice != nil {
+			payload = biz.ChatVoicePayload{URL: req.Voice.Url, Duration: req.Voice.Duration}
+		}
+	case biz.ChatMessageTypePrescription:
+		payload = biz.ChatPrescriptionPayload{PrescriptionID: req.PrescriptionId}
+	case biz.ChatMessageTypeOrder:
+		payload = biz.ChatOrderPayload{OrderNo: req.OrderNo}
+	}
+	if payload != nil {
+		sendReq.Payload, _ = json.Marshal(payload)
+	}
+
+	message, room, err := s.uc.SendMessage(ctx, sendReq)
+	if err != nil {
+		s.log.Errorf("保存聊天消息失败: %v", err)
+		return &chatv1.SaveChatMessageReply{Code: 1, Message: err.Error()}, nil
+	}
+	// 推送给双方在线的连接，接收方离线时在重连后补发
+	comment.DeliverChatMessage(toCommentChatMessage(message))
 	return &chatv1.SaveChatMessageReply{
 		Code:    0,
 		Message: "success",
+		Data:    toChatMessageInfo(message, room.RoomID),
 	}, nil
 }
 
 // 获取用户的聊天房间列表
 func (s *ChatService) GetUserChatRooms(ctx context.Context, req *chatv1.GetUserChatRoomsRequest) (*chatv1.GetUserChatRoomsReply, error) {
-	s.log.Infof("获取用户聊天房间列表: UserId=%s", req.UserId)
-	
-	// 简化实现 - 返回空房间列表
+	userID, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &chatv1.GetUserChatRoomsReply{Code: 401, Message: errMsg}, nil
+	}
+
+	user := biz.ChatUser{Role: role, ID: userID}
+	rooms, err := s.uc.ListRooms(ctx, user)
+	if err != nil {
+		s.log.Errorf("获取用户聊天房间列表失败: %v", err)
+		return &chatv1.GetUserChatRoomsReply{Code: 1, Message: err.Error()}, nil
+	}
+
+	infos := make([]*chatv1.ChatRoomInfo, len(rooms))
+	for i, room := range rooms {
+		target := room.Target(user)
+		infos[i] = &chatv1.ChatRoomInfo{
+			RoomId:          room.RoomID,
+			TargetId:        target.ID,
+			TargetRole:      target.Role,
+			LastMessage:     room.LastMessage,
+			LastMessageTime: room.LastMessageTime.Format(time.DateTime),
+			UnreadCount:     room.UnreadCount(user),
+		}
+	}
 	return &chatv1.GetUserChatRoomsReply{
 		Code:    0,
 		Message: "success",
-		Rooms:   []*chatv1.ChatRoomInfo{},
+		Rooms:   infos,
+	}, nil
+}
+
+// 将与对方的会话标记为已读
+func (s *ChatService) MarkChatRead(ctx context.Context, req *chatv1.MarkChatReadRequest) (*chatv1.MarkChatReadReply, error) {
+	userID, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &chatv1.MarkChatReadReply{Code: 401, Message: errMsg}, nil
+	}
+
+	user, target := biz.ChatUser{Role: role, ID: userID}, chatTarget(role, req.TargetId, req.TargetRole)
+	readID, err := s.uc.MarkRead(ctx, user, target, req.LastMessageId)
+	if err != nil {
+		s.log.Errorf("标记已读失败: %v", err)
+		return &chatv1.MarkChatReadReply{Code: 1, Message: err.Error()}, nil
+	}
+	if readID > 0 {
+		comment.NotifyChatRead(toChatPeer(user), toChatPeer(target), readID)
+	}
+	return &chatv1.MarkChatReadReply{
+		Code:    0,
+		Message: "success",
+		ReadId:  readID,
 	}, nil
 }
 
-// MarkMessageAsRead 方法已移除，因为protobuf中没有定义相关类型
+// 上传聊天图片、语音，返回的地址用于发送 image、voice 消息
+func (s *ChatService) UploadChatFile(ctx http.Context) error {
+	err, url := comment.Upload(ctx, ctx.Request())
+	if err != nil {
+		s.log.Errorf("上传聊天文件失败: %v", err)
+		return ctx.JSON(400, map[string]interface{}{
+			"code":    1,
+			"message": err.Error(),
+		})
+	}
+	return ctx.JSON(200, map[string]interface{}{
+		"code":    0,
+		"message": "success",
+		"url":     url,
+	})
+}
+
+// 会话对方，未指定角色时患者默认为医生，医生和药师默认为患者
+func chatTarget(role string, targetID int32, targetRole string) biz.ChatUser {
+	if targetRole == "" {
+		targetRole = biz.DefaultChatPeerRole(role)
+	}
+	return biz.ChatUser{Role: targetRole, ID: targetID}
+}
+
+func toChatPeer(user biz.ChatUser) comment.ChatPeer {
+	return comment.ChatPeer{Role: user.Role, ID: user.ID}
+}
+
+func toChatUser(peer comment.ChatPeer) biz.ChatUser {
+	return biz.ChatUser{Role: peer.Role, ID: peer.ID}
+}
+
+func toChatMessageInfo(message *biz.ChatMessage, roomID string) *chatv1.ChatMessageInfo {
+	info := &chatv1.ChatMessageInfo{
+		Id:          message.ID,
+		FromRole:    message.FromRole,
+		FromId:      message.FromID,
+		ToRole:      message.ToRole,
+		ToId:        message.ToID,
+		Content:     message.Content,
+		MessageType: message.MessageType,
+		RoomId:      roomID,
+		CreatedAt:   message.CreatedAt.Format(time.DateTime),
+		Status:      message.Status(),
+	}
+
+	// 结构化数据解析失败时按文本消息返回摘要
+	switch message.MessageType {
+	case biz.ChatMessageTypeImage:
+		var payload biz.ChatImagePayload
+		if message.DecodePayload(&payload) == nil {
+			info.Payload = &chatv1.ChatMessageInfo_Image{Image: &chatv1.ChatImage{
+				Url: payload.URL, Width: payload.Width, Height: payload.Height, Size: payload.Size,
+			}}
+		}
+	case biz.ChatMessageTypeVoice:
+		var payload biz.ChatVoicePayload
+		if message.DecodePayload(&payload) == nil {
+			info.Payload = &chatv1.ChatMessageInfo_Voice{Voice: &chatv1.ChatVoice{
+				Url: payload.URL, Duration: payload.Duration,
+			}}
+		}
+	case biz.ChatMessageTypePrescription:
+		var payload biz.ChatPrescriptionPayload
+		if message.DecodePayload(&payload) == nil {
+			info.Payload = &chatv1.ChatMessageInfo_Prescription{Prescription: &chatv1.ChatPrescriptionCard{
+				PrescriptionId:   payload.PrescriptionID,
+				PrescriptionNo:   payload.PrescriptionNo,
+				PrescriptionType: payload.PrescriptionType,
+				Status:           payload.Status,
+				TotalAmount:      payload.TotalAmount,
+				MedicineCount:    payload.MedicineCount,
+				PrescriptionDate: payload.PrescriptionDate,
+			}}
+		}
+	case biz.ChatMessageTypeOrder:
+		var payload biz.ChatOrderPayload
+		if message.DecodePayload(&payload) == nil {
+			info.Payload = &chatv1.ChatMessageInfo_Order{Order: &chatv1.ChatOrderCard{
+				OrderNo:     payload.OrderNo,
+				Status:      payload.Status,
+				TotalAmount: payload.TotalAmount,
+				ItemCount:   payload.ItemCount,
+				Title:       payload.Title,
+			}}
+		}
+	case biz.ChatMessageTypeSystem:
+		var payload biz.ChatSystemPayload
+		if message.DecodePayload(&payload) == nil {
+			info.Payload = &chatv1.ChatMessageInfo_System{System: &chatv1.ChatSystemNotice{
+				Event: payload.Event, Text: payload.Text,
+			}}
+		}
+	}
+	return info
+}
+
+func toCommentChatMessage(message *biz.ChatMessage) comment.ChatMessage {
+	return comment.ChatMessage{
+		ID:        message.ID,
+		Type:      message.MessageType,
+		Content:   message.Content,
+		FromRole:  message.FromRole,
+		FromID:    message.FromID,
+		ToRole:    message.ToRole,
+		ToID:      message.ToID,
+		Timestamp: message.CreatedAt,
+		RoomID:    biz.ChatRoomID(message.From(), message.To()),
+		Payload:   json.RawMessage(message.Payload),
+	}
+}
+
+// WebSocket消息的持久化实现，未送达消息即离线队列
+type chatMessageStore struct {
+	uc *biz.ChatUsecase
+}
+
+// 保存WebSocket消息，回填消息ID、发送时间以及校验后的摘要和结构化数据
+func (s *chatMessageStore) SaveChatMessage(ctx context.Context, message *comment.ChatMessage) error {
+	saved, _, err := s.uc.SendMessage(ctx, &biz.SendChatMessageRequest{
+		From:        biz.ChatUser{Role: message.FromRole, ID: message.FromID},
+		To:          biz.ChatUser{Role: message.ToRole, ID: message.ToID},
+		MessageType: message.Type,
+		Content:     message.Content,
+		Payload:     message.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	message.ID = saved.ID
+	message.Timestamp = saved.CreatedAt
+	message.Content = saved.Content
+	message.Payload = json.RawMessage(saved.Payload)
+	return nil
+}
+
+// 发给用户且尚未送达的消息
+func (s *chatMessageStore) PendingChatMessages(ctx context.Context, user comment.ChatPeer) ([]comment.ChatMessage, error) {
+	messages, err := s.uc.PendingMessages(ctx, toChatUser(user))
+	if err != nil {
+		return nil, err
+	}
+	return toCommentChatMessages(messages), nil
+}
+
+// 接收方确认收到消息
+func (s *chatMessageStore) AckChatMessages(ctx context.Context, user comment.ChatPeer, ids []int64) ([]comment.ChatMessage, error) {
+	messages, err := s.uc.AckDelivered(ctx, toChatUser(user), ids)
+	if err != nil {
+		return nil, err
+	}
+	return toCommentChatMessages(messages), nil
+}
+
+// 接收方已读
+func (s *chatMessageStore) ReadChatMessages(ctx context.Context, user, peer comment.ChatPeer, upToID int64) (int64, error) {
+	return s.uc.MarkRead(ctx, toChatUser(user), toChatUser(peer), upToID)
+}
 
-// 生成房间ID
-func generateRoomID(user1ID, user2ID string) string {
-	if user1ID < user2ID {
-		return user1ID + "_" + user2ID
+func toCommentChatMessages(messages []*biz.ChatMessage) []comment.ChatMessage {
+	result := make([]comment.ChatMessage, len(messages))
+	for i, message := range messages {
+		result[i] = toCommentChatMessage(message)
 	}
-	return user2ID + "_" + user1ID
+	return result
 }
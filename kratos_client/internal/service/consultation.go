@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "kratos_client/api/consultation/v1"
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const defaultConsultationDuration = 30 * time.Minute
+
+// 在线问诊服务
+type ConsultationService struct {
+	pb.UnimplementedConsultationServer
+	uc       *biz.ConsultationUsecase
+	duration time.Duration
+	log      *log.Helper
+}
+
+// 创建问诊服务
+func NewConsultationService(uc *biz.ConsultationUsecase, c *conf.Consultation, logger log.Logger) *ConsultationService {
+	s := &ConsultationService{
+		uc:       uc,
+		duration: defaultConsultationDuration,
+		log:      log.NewHelper(logger),
+	}
+	if c != nil && c.Duration != nil && c.Duration.AsDuration() > 0 {
+		s.duration = c.Duration.AsDuration()
+	}
+	return s
+}
+
+// 解析JWT token获取用户ID和角色，失败时返回错误信息
+func tokenClaims(token string) (int32, string, string) {
+	claims, errMsg := comment.GetToken(token)
+	if claims == nil || errMsg != "" {
+		return 0, "", "token无效: " + errMsg
+	}
+	userIDFloat, ok := claims["user"].(float64)
+	if !ok {
+		return 0, "", "token中用户ID格式错误"
+	}
+	return int32(userIDFloat), comment.TokenRole(claims), ""
+}
+
+// 解析患者token获取用户ID，失败时返回错误信息
+func tokenUserID(token string) (int32, string) {
+	userID, role, errMsg := tokenClaims(token)
+	if errMsg != "" {
+		return 0, errMsg
+	}
+	if role != comment.RolePatient {
+		return 0, "请使用患者账号登录"
+	}
+	return userID, ""
+}
+
+// 解析医生token获取医生ID，失败时返回错误信息
+func tokenDoctorID(token string) (int32, string) {
+	doctorID, role, errMsg := tokenClaims(token)
+	if errMsg != "" {
+		return 0, errMsg
+	}
+	if role != comment.RoleDoctor {
+		return 0, "请使用医生账号登录"
+	}
+	return doctorID, ""
+}
+
+// 解析药师token获取药师ID，失败时返回错误信息
+func tokenPharmacistID(token string) (int32, string) {
+	pharmacistID, role, errMsg := tokenClaims(token)
+	if errMsg != "" {
+		return 0, errMsg
+	}
+	if role != comment.RolePharmacist {
+		return 0, "请使用药师账号登录"
+	}
+	return pharmacistID, ""
+}
+
+// 发起问诊
+func (s *ConsultationService) CreateConsultation(ctx context.Context, req *pb.CreateConsultationRequest) (*pb.CreateConsultationReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.CreateConsultationReply{Code: 401, Message: errMsg}, nil
+	}
+
+	consultation, payment, err := s.uc.CreateConsultation(ctx, &biz.CreateConsultationRequest{
+		PatientID:      userID,
+		DoctorID:       req.DoctorId,
+		Description:    req.Description,
+		PayType:        req.PayType,
+		IdempotencyKey: idempotencyKey(ctx, req.IdempotencyKey),
+	})
+	if err != nil {
+		s.log.Errorf("发起问诊失败: %v", err)
+		if errors.Is(err, biz.ErrPaymentGatewayUnavailable) {
+			return &pb.CreateConsultationReply{Code: 400, Message: "不支持的支付方式"}, nil
+		}
+		return &pb.CreateConsultationReply{Code: 500, Message: err.Error()}, nil
+	}
+
+	reply := &pb.CreateConsultationReply{
+		Code:    0,
+		Message: "success",
+		Data:    toConsultationInfo(consultation),
+	}
+	if payment != nil {
+		reply.PaymentUrl = payment.PaymentURL
+	}
+	return reply, nil
+}
+
+// 查询问诊详情
+func (s *ConsultationService) GetConsultation(ctx context.Context, req *pb.GetConsultationRequest) (*pb.GetConsultationReply, error) {
+	userID, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &pb.GetConsultationReply{Code: 401, Message: errMsg}, nil
+	}
+	if role != comment.RoleDoctor && role != comment.RolePatient {
+		return &pb.GetConsultationReply{Code: 403, Message: "无权查看此问诊"}, nil
+	}
+
+	consultation, err := s.uc.GetConsultation(ctx, userID, req.ConsultationNo)
+	if err != nil {
+		return &pb.GetConsultationReply{Code: 500, Message: err.Error()}, nil
+	}
+	// 患者和医生ID可能相同，按token角色再核对一次
+	if (role == comment.RoleDoctor && consultation.DoctorID != userID) || (role == comment.RolePatient && consultation.PatientID != userID) {
+		return &pb.GetConsultationReply{Code: 403, Message: "无权查看此问诊"}, nil
+	}
+	return &pb.GetConsultationReply{
+		Code:    0,
+		Message: "success",
+		Data:    toConsultationInfo(consultation),
+	}, nil
+}
+
+// 查询问诊列表
+func (s *ConsultationService) ListConsultations(ctx context.Context, req *pb.ListConsultationsRequest) (*pb.ListConsultationsReply, error) {
+	userID, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &pb.ListConsultationsReply{Code: 401, Message: errMsg}, nil
+	}
+
+	// 查询角色以token为准，药师等其他角色无问诊记录
+	var listRole string
+	switch role {
+	case comment.RoleDoctor:
+		listRole = biz.ConsultationRoleDoctor
+	case comment.RolePatient:
+		listRole = biz.ConsultationRolePatient
+	default:
+		return &pb.ListConsultationsReply{Code: 403, Message: "无权查看问诊列表"}, nil
+	}
+	consultations, total, err := s.uc.ListConsultations(ctx, userID, listRole, req.Status, req.Page, req.PageSize)
+	if err != nil {
+		return &pb.ListConsultationsReply{Code: 500, Message: err.Error()}, nil
+	}
+	list := make([]*pb.ConsultationInfo, len(consultations))
+	for i, consultation := range consultations {
+		list[i] = toConsultationInfo(consultation)
+	}
+	return &pb.ListConsultationsReply{
+		Code:    0,
+		Message: "success",
+		List:    list,
+		Total:   total,
+	}, nil
+}
+
+// 患者取消问诊
+func (s *ConsultationService) CancelConsultation(ctx context.Context, req *pb.CancelConsultationRequest) (*pb.ConsultationReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.ConsultationReply{Code: 401, Message: errMsg}, nil
+	}
+	consultation, err := s.uc.CancelConsultation(ctx, userID, req.ConsultationNo)
+	return s.consultationReply(consultation, err)
+}
+
+// 医生接诊
+func (s *ConsultationService) AcceptConsultation(ctx context.Context, req *pb.AcceptConsultationRequest) (*pb.ConsultationReply, error) {
+	userID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.ConsultationReply{Code: 401, Message: errMsg}, nil
+	}
+	consultation, err := s.uc.AcceptConsultation(ctx, userID, req.ConsultationNo, s.duration)
+	return s.consultationReply(consultation, err)
+}
+
+// 医生结束问诊
+func (s *ConsultationService) CloseConsultation(ctx context.Context, req *pb.CloseConsultationRequest) (*pb.ConsultationReply, error) {
+	userID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.ConsultationReply{Code: 401, Message: errMsg}, nil
+	}
+	consultation, err := s.uc.CloseConsultation(ctx, userID, req.ConsultationNo, req.Diagnosis, req.Advice)
+	return s.consultationReply(consultation, err)
+}
+
+// 患者评价问诊
+func (s *ConsultationService) RateConsultation(ctx context.Context, req *pb.RateConsultationRequest) (*pb.ConsultationReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.ConsultationReply{Code: 401, Message: errMsg}, nil
+	}
+	consultation, err := s.uc.RateConsultation(ctx, userID, req.ConsultationNo, req.Rating, req.Comment)
+	return s.consultationReply(consultation, err)
+}
+
+func (s *ConsultationService) consultationReply(consultation *biz.Consultation, err error) (*pb.ConsultationReply, error) {
+	if err != nil {
+		s.log.Errorf("问诊操作失败: %v", err)
+		return &pb.ConsultationReply{Code: 500, Message: err.Error()}, nil
+	}
+	return &pb.ConsultationReply{
+		Code:    0,
+		Message: "success",
+		Data:    toConsultationInfo(consultation),
+	}, nil
+}
+
+func toConsultationInfo(consultation *biz.Consultation) *pb.ConsultationInfo {
+	return &pb.ConsultationInfo{
+		ConsultationNo: consultation.ConsultationNo,
+		PatientId:      consultation.PatientID,
+		DoctorId:       consultation.DoctorID,
+		DoctorName:     consultation.DoctorName,
+		Fee:            consultation.Fee.StringFixed(2),
+		Description:    consultation.Description,
+		Status:         consultation.Status,
+		PaymentOrderId: consultation.PaymentOrderID,
+		RefundId:       consultation.RefundID,
+		Diagnosis:      consultation.Diagnosis,
+		Advice:         consultation.Advice,
+		CloseReason:    consultation.CloseReason,
+		PaidAt:         formatConsultationTime(consultation.PaidAt),
+		StartedAt:      formatConsultationTime(consultation.StartedAt),
+		EndsAt:         formatConsultationTime(consultation.EndsAt),
+		ClosedAt:       formatConsultationTime(consultation.ClosedAt),
+		CreatedAt:      formatConsultationTime(consultation.CreatedAt),
+		RoomId:         biz.ChatRoomID(biz.ChatUser{Role: biz.ChatRolePatient, ID: consultation.PatientID}, biz.ChatUser{Role: biz.ChatRoleDoctor, ID: consultation.DoctorID}),
+		Rating:         consultation.Rating,
+		RatingComment:  consultation.RatingComment,
+		RatedAt:        formatConsultationTime(consultation.RatedAt),
+	}
+}
+
+func formatConsultationTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
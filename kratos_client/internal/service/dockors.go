@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	v1 "kratos_client/api/doctors/v1"
+	"kratos_client/comment"
 	"kratos_client/internal/biz"
 	"kratos_client/internal/data"
+	"strconv"
 )
 
 type DoctorsService struct {
@@ -21,26 +23,70 @@ func NewDoctorsService(uc *biz.DoctorsService, d *data.Data) *DoctorsService {
 		uc:                         uc,
 	}
 }
+
+// 按医院、科室、职称和专业特长筛选可问诊的医生
 func (c *DoctorsService) DoctorsList(ctx context.Context, in *v1.DoctorsListRequest) (*v1.DoctorsListReply, error) {
-	// 查询所有医生
-	find, err := c.uc.DoctorsFind(ctx, &biz.MtDoctors{})
+	doctors, total, err := c.uc.ListDoctors(ctx, &biz.DoctorFilter{
+		HospitalID:   in.HospitalId,
+		DepartmentID: in.DepartmentId,
+		Title:        in.Title,
+		Speciality:   in.Speciality,
+		SortBy:       in.SortBy,
+		Page:         in.Page,
+		PageSize:     in.PageSize,
+	})
 	if err != nil {
 		return &v1.DoctorsListReply{
-			Message: "查询失败",
-		}, err
+			Message: "查询失败: " + err.Error(),
+		}, nil
 	}
 
-	var doctorsList []*v1.DoctorsList
-
-	for _, doctor := range *find {
-		doctorsList = append(doctorsList, &v1.DoctorsList{
-			DoctorCode:  doctor.DoctorCode,
-			DoctorsName: doctor.Name,
-		})
+	doctorsList := make([]*v1.DoctorsList, len(doctors))
+	for i, doctor := range doctors {
+		doctorsList[i] = toDoctorsList(doctor)
 	}
 
 	return &v1.DoctorsListReply{
 		Message:     "查询成功",
 		DoctorsList: doctorsList,
+		Total:       total,
+	}, nil
+}
+
+// 医生详情
+func (c *DoctorsService) DoctorDetail(ctx context.Context, in *v1.DoctorDetailRequest) (*v1.DoctorDetailReply, error) {
+	doctor, err := c.uc.GetDoctor(ctx, in.Id)
+	if err != nil {
+		return &v1.DoctorDetailReply{
+			Message: "查询失败: " + err.Error(),
+		}, nil
+	}
+	return &v1.DoctorDetailReply{
+		Message: "查询成功",
+		Doctor:  toDoctorsList(doctor),
 	}, nil
 }
+
+func toDoctorsList(doctor *biz.DoctorProfile) *v1.DoctorsList {
+	return &v1.DoctorsList{
+		DoctorCode:        doctor.DoctorCode,
+		DoctorsName:       doctor.Name,
+		Id:                doctor.ID,
+		Gender:            doctor.Gender,
+		Avatar:            doctor.Avatar,
+		Title:             doctor.Title,
+		Speciality:        doctor.Speciality,
+		PracticeScope:     doctor.PracticeScope,
+		HospitalId:        doctor.HospitalID,
+		HospitalName:      doctor.HospitalName,
+		HospitalLevel:     doctor.HospitalLevel,
+		HospitalAddress:   doctor.HospitalAddress,
+		DepartmentId:      doctor.DepartmentID,
+		DepartmentName:    doctor.DepartmentName,
+		ConsultationFee:   doctor.ConsultationFee.StringFixed(2),
+		Rating:            doctor.Rating.StringFixed(2),
+		RatingCount:       doctor.RatingCount,
+		ConsultationCount: doctor.ConsultationCount,
+		Online:            comment.IsUserOnline(strconv.Itoa(int(doctor.ID))),
+	}
+}
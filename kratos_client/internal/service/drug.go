@@ -6,23 +6,46 @@ import (
 
 	drup "kratos_client/api/drug/v1"
 	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
 	"kratos_client/internal/data"
 )
 
 type DrugService struct {
 	drup.UnimplementedDrugServer
-	data *data.Data
-	uc   *biz.DrugService
+	data          *data.Data
+	uc            *biz.DrugService
+	inventoryUc   *biz.InventoryUsecase
+	interactionUc *biz.InteractionUsecase
+	hotSearchUc   *biz.HotSearchUsecase
+	symptomUc     *biz.SymptomUsecase
+	adminToken    string // 盘点调整库存接口令牌
 }
 
 // NewAppService new a app service.
-func NewDrugService(uc *biz.DrugService, d *data.Data) *DrugService {
+func NewDrugService(uc *biz.DrugService, inventoryUc *biz.InventoryUsecase, interactionUc *biz.InteractionUsecase, hotSearchUc *biz.HotSearchUsecase, symptomUc *biz.SymptomUsecase, d *data.Data, c *conf.Inventory) *DrugService {
 	return &DrugService{
 		UnimplementedDrugServer: drup.UnimplementedDrugServer{},
 		data:                    d,
 		uc:                      uc,
+		inventoryUc:             inventoryUc,
+		interactionUc:           interactionUc,
+		hotSearchUc:             hotSearchUc,
+		symptomUc:               symptomUc,
+		adminToken:              c.GetAdminToken(),
 	}
 }
+
+// 解析可选的患者token，未登录或token无效时返回0
+func optionalTokenUserID(token string) int32 {
+	if token == "" {
+		return 0
+	}
+	userID, errMsg := tokenUserID(token)
+	if errMsg != "" {
+		return 0
+	}
+	return userID
+}
 func (s *DrugService) ListDrug(ctx context.Context, in *drup.ListDrugRequest) (*drup.ListDrugReply, error) {
 	drugs, err := s.uc.ListDrug(ctx, int32(in.FristCategoryId), int32(in.SecondCategoryId), in.Keyword)
 	if err != nil {
@@ -31,12 +54,13 @@ func (s *DrugService) ListDrug(ctx context.Context, in *drup.ListDrugRequest) (*
 	var drugInfo []*drup.InfoDrugs
 	for _, drug := range drugs {
 		drugInfo = append(drugInfo, &drup.InfoDrugs{
-			DrugName:      drug.DrugName,
-			Specification: drug.Specification,
-			Price:         float64(drug.Price),
-			SalesVolume:   float64(drug.SalesVolume),
-			Inventory:     int64(drug.Inventory),
-			ExhibitionUrl: strconv.Itoa(int(drug.ExhibitionId)),
+			DrugName:       drug.DrugName,
+			Specification:  drug.Specification,
+			Price:          float64(drug.Price),
+			SalesVolume:    float64(drug.SalesVolume),
+			Inventory:      int64(drug.Inventory),
+			ExhibitionUrl:  strconv.Itoa(int(drug.ExhibitionId)),
+			IsPrescription: drug.IsPrescription,
 		})
 	}
 	return &drup.ListDrugReply{
@@ -58,12 +82,13 @@ func (s *DrugService) GetDrug(ctx context.Context, in *drup.GetDrugRequest) (*dr
 		Code: 0,
 		Msg:  "success",
 		Drug: &drup.InfoDrug{
-			DrugName:      drug.DrugName,
-			Specification: drug.Specification,
-			Price:         float64(drug.Price),
-			SalesVolume:   float64(drug.SalesVolume),
-			Inventory:     int64(drug.Inventory),
-			ExhibitionUrl: strconv.Itoa(int(drug.ExhibitionId)),
+			DrugName:       drug.DrugName,
+			Specification:  drug.Specification,
+			Price:          float64(drug.Price),
+			SalesVolume:    float64(drug.SalesVolume),
+			Inventory:      int64(drug.Inventory),
+			ExhibitionUrl:  strconv.Itoa(int(drug.ExhibitionId)),
+			IsPrescription: drug.IsPrescription,
 			Guide: &drup.GuideInfo{
 				MajorFunction:  guide.MajorFunction,
 				UsageAndDosage: guide.UsageAndDosage,
@@ -127,18 +152,22 @@ func (s *DrugService) GetGuide(ctx context.Context, in *drup.GetGuideRequest) (*
 
 // 搜索药品
 func (s *DrugService) SearchDrugs(ctx context.Context, in *drup.SearchDrugsRequest) (*drup.SearchDrugsReply, error) {
-	// 设置默认值
-	page := int(in.Page)
-	size := int(in.Size)
-	if page <= 0 {
-		page = 1
+	req := &biz.SearchRequest{
+		Keyword:             in.Keyword,
+		CategoryID:          in.CategoryId,
+		DrugStoreID:         in.DrugStoreId,
+		OnlyInStock:         in.OnlyInStock,
+		IncludePrescription: in.IncludePrescription,
+		Page:                int(in.Page),
+		Size:                int(in.Size),
+		SortBy:              in.SortBy,
 	}
-	if size <= 0 {
-		size = 20
+	if in.PriceMin > 0 || in.PriceMax > 0 {
+		req.PriceRange = &biz.PriceRange{Min: in.PriceMin, Max: in.PriceMax}
 	}
 
 	// 执行搜索
-	drugs, total, err := s.uc.SearchDrugs(ctx, in.Keyword, in.CategoryId, page, size)
+	result, err := s.uc.SearchDrugs(ctx, req)
 	if err != nil {
 		return &drup.SearchDrugsReply{
 			Code: 500,
@@ -146,90 +175,247 @@ func (s *DrugService) SearchDrugs(ctx context.Context, in *drup.SearchDrugsReque
 		}, nil
 	}
 
+	// 首页有结果的搜索计入热搜，纠错过的计入纠错后的关键词，记录失败不影响搜索
+	if req.Page == 1 && result.Total > 0 {
+		keyword := req.Keyword
+		if result.CorrectedKeyword != "" {
+			keyword = result.CorrectedKeyword
+		}
+		cityID := s.hotSearchUc.ResolveCity(ctx, optionalTokenUserID(in.Token), in.CityId)
+		_ = s.hotSearchUc.Record(ctx, keyword, cityID)
+	}
+
 	// 转换响应
 	var drugInfos []*drup.SearchDrugInfo
-	for _, drug := range drugs {
+	for _, drug := range result.Drugs {
 		drugInfos = append(drugInfos, &drup.SearchDrugInfo{
-			Id:             int64(drug.Id),
+			Id:             drug.ID,
 			DrugName:       drug.DrugName,
 			Specification:  drug.Specification,
-			Price:          float64(drug.Price),
-			Inventory:      int64(drug.Inventory),
+			Price:          drug.Price,
+			Inventory:      drug.Inventory,
 			Manufacturer:   drug.Manufacturer,
-			IsPrescription: false, // 暂时设为false，因为原表没有这个字段
-			ExhibitionUrl:  strconv.Itoa(int(drug.ExhibitionId)),
+			IsPrescription: drug.IsPrescription,
+			ExhibitionUrl:  strconv.Itoa(int(drug.ExhibitionID)),
+		})
+	}
+	facets := &drup.SearchFacets{}
+	for _, category := range result.Facets.Categories {
+		facets.Categories = append(facets.Categories, &drup.CategoryFacet{
+			Id:    category.ID,
+			Name:  category.Name,
+			Count: category.Count,
+		})
+	}
+	for _, price := range result.Facets.PriceRanges {
+		facets.PriceRanges = append(facets.PriceRanges, &drup.PriceFacet{
+			Range: price.Range,
+			Count: price.Count,
+		})
+	}
+	for _, manufacturer := range result.Facets.Manufacturers {
+		facets.Manufacturers = append(facets.Manufacturers, &drup.ManufacturerFacet{
+			Name:  manufacturer.Name,
+			Count: manufacturer.Count,
 		})
 	}
 
 	return &drup.SearchDrugsReply{
-		Code:  0,
-		Msg:   "success",
-		Drugs: drugInfos,
-		Total: total,
+		Code:             0,
+		Msg:              "success",
+		Drugs:            drugInfos,
+		Total:            result.Total,
+		Facets:           facets,
+		CorrectedKeyword: result.CorrectedKeyword,
 	}, nil
 }
 
-// 获取热门搜索
-func (s *DrugService) GetHotSearch(ctx context.Context, in *drup.GetHotSearchRequest) (*drup.GetHotSearchReply, error) {
-	limit := int(in.Limit)
-	if limit <= 0 {
-		limit = 10
-	}
-
-	// 获取热门关键词
-	keywords, err := s.uc.GetHotKeywords(ctx, limit)
+// 搜索联想，按前缀优先、销量降序返回药品名称和通用名、商品名
+func (s *DrugService) SuggestDrugs(ctx context.Context, in *drup.SuggestDrugsRequest) (*drup.SuggestDrugsReply, error) {
+	suggestions, err := s.uc.SuggestDrugs(ctx, in.Keyword, in.IncludePrescription, int(in.Limit))
 	if err != nil {
-		return &drup.GetHotSearchReply{
+		return &drup.SuggestDrugsReply{
 			Code: 500,
 			Msg:  err.Error(),
 		}, nil
 	}
 
-	// 转换响应
-	var hotKeywords []*drup.HotItem
-	for i, keyword := range keywords {
-		hotKeywords = append(hotKeywords, &drup.HotItem{
-			Content: keyword,
-			Count:   int64(100 - i*5), // 模拟计数
-			Score:   float64(100 - i*5),
+	items := make([]*drup.DrugSuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		items = append(items, &drup.DrugSuggestion{
+			Text:   suggestion.Text,
+			DrugId: suggestion.DrugID,
+			Source: suggestion.Source,
 		})
 	}
+	return &drup.SuggestDrugsReply{
+		Code:        0,
+		Msg:         "success",
+		Suggestions: items,
+	}, nil
+}
 
-	// 模拟热门症状
-	symptoms := []string{"感冒", "发烧", "咳嗽", "头痛", "胃痛"}
-	var hotSymptoms []*drup.HotItem
-	for i, symptom := range symptoms {
-		if i >= limit {
-			break
-		}
-		hotSymptoms = append(hotSymptoms, &drup.HotItem{
-			Content: symptom,
-			Count:   int64(80 - i*3),
-			Score:   float64(80 - i*3),
-		})
+// 按症状推荐非处方药，无论是否有推荐结果都返回问诊入口
+func (s *DrugService) RecommendBySymptoms(ctx context.Context, in *drup.RecommendBySymptomsRequest) (*drup.RecommendBySymptomsReply, error) {
+	result, err := s.symptomUc.Recommend(ctx, &biz.SymptomRecommendRequest{
+		Symptoms:    in.Symptoms,
+		Populations: in.Populations,
+		Limit:       int(in.Limit),
+	})
+	if err != nil {
+		return &drup.RecommendBySymptomsReply{
+			Code:    400,
+			Msg:     err.Error(),
+			Consult: &drup.ConsultDoctorAction{Text: biz.ConsultDoctorText, Path: biz.DoctorListPath},
+		}, nil
 	}
 
-	// 模拟热门问题
-	questions := []string{"感冒药怎么选？", "发烧了吃什么药？", "咳嗽药有哪些？"}
-	var hotQuestions []*drup.HotItem
-	for i, question := range questions {
-		if i >= limit {
-			break
-		}
-		hotQuestions = append(hotQuestions, &drup.HotItem{
-			Content: question,
-			Count:   int64(60 - i*2),
-			Score:   float64(60 - i*2),
+	symptoms := make([]*drup.MatchedSymptom, 0, len(result.Symptoms))
+	for _, symptom := range result.Symptoms {
+		symptoms = append(symptoms, &drup.MatchedSymptom{
+			Name:         symptom.Name,
+			Curated:      symptom.Curated,
+			DepartmentId: symptom.DepartmentID,
+			Advice:       symptom.Advice,
+		})
+	}
+	drugs := make([]*drup.SymptomDrug, 0, len(result.Drugs))
+	for _, drug := range result.Drugs {
+		drugs = append(drugs, &drup.SymptomDrug{
+			Drug: &drup.SearchDrugInfo{
+				Id:             drug.Drug.ID,
+				DrugName:       drug.Drug.DrugName,
+				Specification:  drug.Drug.Specification,
+				Price:          drug.Drug.Price,
+				Inventory:      drug.Drug.Inventory,
+				Manufacturer:   drug.Drug.Manufacturer,
+				IsPrescription: drug.Drug.IsPrescription,
+				ExhibitionUrl:  strconv.Itoa(int(drug.Drug.ExhibitionID)),
+			},
+			Score:           int32(drug.Score),
+			MatchedSymptoms: drug.MatchedSymptoms,
+			Indication:      drug.Indication,
+			Warnings:        toPbDrugWarnings(drug.Warnings),
 		})
 	}
+	return &drup.RecommendBySymptomsReply{
+		Code:     0,
+		Msg:      "success",
+		Symptoms: symptoms,
+		Drugs:    drugs,
+		Consult: &drup.ConsultDoctorAction{
+			Text:         result.Consult.Text,
+			Path:         result.Consult.Path,
+			DepartmentId: result.Consult.DepartmentID,
+		},
+	}, nil
+}
+
+// 获取热门搜索，按真实搜索记录统计
+func (s *DrugService) GetHotSearch(ctx context.Context, in *drup.GetHotSearchRequest) (*drup.GetHotSearchReply, error) {
+	cityID := s.hotSearchUc.ResolveCity(ctx, optionalTokenUserID(in.Token), in.CityId)
+	hot, err := s.hotSearchUc.GetHotSearch(ctx, biz.HotSearchWindow(in.Window), cityID, int(in.Limit))
+	if err != nil {
+		return &drup.GetHotSearchReply{
+			Code: 500,
+			Msg:  err.Error(),
+		}, nil
+	}
 
 	return &drup.GetHotSearchReply{
 		Code:         0,
 		Msg:          "success",
-		HotKeywords:  hotKeywords,
-		HotSymptoms:  hotSymptoms,
-		HotQuestions: hotQuestions,
+		HotKeywords:  toHotItems(hot.Keywords),
+		HotSymptoms:  toHotItems(hot.Symptoms),
+		HotQuestions: toHotItems(hot.Questions),
+		Window:       string(hot.Window),
+		CityId:       hot.CityID,
+	}, nil
+}
+
+func toHotItems(items []*biz.HotItem) []*drup.HotItem {
+	result := make([]*drup.HotItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, &drup.HotItem{
+			Content: item.Content,
+			Count:   item.Count,
+			Score:   item.Score,
+		})
+	}
+	return result
+}
+
+// 查询药店库存
+func (s *DrugService) GetInventory(ctx context.Context, in *drup.GetInventoryRequest) (*drup.GetInventoryReply, error) {
+	inventory, err := s.inventoryUc.GetInventory(ctx, in.DrugId, in.DrugStoreId)
+	if err != nil {
+		return nil, err
+	}
+	return &drup.GetInventoryReply{
+		Code:      0,
+		Msg:       "success",
+		Inventory: toInventoryInfo(inventory),
+	}, nil
+}
+
+// 盘点调整药店库存，仅供管理后台调用，需在请求头 X-Admin-Token 中携带配置的管理令牌
+func (s *DrugService) UpdateInventory(ctx context.Context, in *drup.UpdateInventoryRequest) (*drup.UpdateInventoryReply, error) {
+	if !adminAuthorized(ctx, s.adminToken) {
+		return &drup.UpdateInventoryReply{Code: 403, Msg: "无权调整库存"}, nil
+	}
+	inventory, err := s.inventoryUc.UpdateInventory(ctx, in.DrugId, in.DrugStoreId, in.Quantity, in.Remark)
+	if err != nil {
+		return nil, err
+	}
+	return &drup.UpdateInventoryReply{
+		Code:      0,
+		Msg:       "success",
+		Inventory: toInventoryInfo(inventory),
 	}, nil
 }
 
+// 检查药品之间的相互作用和对人群的禁忌
+func (s *DrugService) CheckDrugInteractions(ctx context.Context, in *drup.CheckDrugInteractionsRequest) (*drup.CheckDrugInteractionsReply, error) {
+	result, err := s.interactionUc.Check(ctx, in.DrugIds, in.Populations)
+	if err != nil {
+		return &drup.CheckDrugInteractionsReply{Code: 400, Msg: err.Error()}, nil
+	}
+	return &drup.CheckDrugInteractionsReply{
+		Code:     0,
+		Msg:      "success",
+		Blocked:  result.Blocked,
+		Warnings: toPbDrugWarnings(result.Warnings),
+	}, nil
+}
+
+// 用药提示转换为购物车、处方和订单共用的响应结构
+func toPbDrugWarnings(warnings []*biz.DrugWarning) []*drup.DrugWarning {
+	result := make([]*drup.DrugWarning, len(warnings))
+	for i, warning := range warnings {
+		result[i] = &drup.DrugWarning{
+			Type:        warning.Type,
+			Level:       warning.Level,
+			Blocking:    warning.Blocking,
+			DrugIds:     warning.DrugIDs,
+			DrugNames:   warning.DrugNames,
+			Population:  warning.Population,
+			Description: warning.Description,
+			Advice:      warning.Advice,
+			Message:     warning.Message(),
+		}
+	}
+	return result
+}
 
+func toInventoryInfo(inventory *biz.MtDrugInventory) *drup.InventoryInfo {
+	return &drup.InventoryInfo{
+		DrugId:         inventory.DrugID,
+		DrugStoreId:    inventory.DrugStoreID,
+		Quantity:       inventory.Quantity,
+		ReservedQty:    inventory.ReservedQty,
+		AlertThreshold: inventory.AlertThreshold,
+		Price:          inventory.Price,
+		Status:         int32(inventory.Status),
+		AvailableQty:   inventory.Available(),
+	}
+}
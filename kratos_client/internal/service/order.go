@@ -4,26 +4,30 @@ import (
 	"context"
 	"time"
 
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/shopspring/decimal"
 
 	pb "kratos_client/api/order/v1"
 	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
 )
 
 // OrderService 订单服务
 type OrderService struct {
 	pb.UnimplementedOrderServiceServer
 
-	orderUc *biz.OrderUsecase
-	log     *log.Helper
+	orderUc    *biz.OrderUsecase
+	adminToken string // 订单状态流转记录查询令牌
+	log        *log.Helper
 }
 
 // NewOrderService 创建订单服务
-func NewOrderService(orderUc *biz.OrderUsecase, logger log.Logger) *OrderService {
+func NewOrderService(orderUc *biz.OrderUsecase, c *conf.Order, logger log.Logger) *OrderService {
 	return &OrderService{
-		orderUc: orderUc,
-		log:     log.NewHelper(logger),
+		orderUc:    orderUc,
+		adminToken: c.GetAdminToken(),
+		log:        log.NewHelper(logger),
 	}
 }
 
@@ -39,15 +43,21 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *pb.CreateOrderReque
 	}
 
 	createReq := &biz.CreateOrderRequest{
-		UserID:        req.UserId,
-		UserName:      req.UserName,
-		UserPhone:     req.UserPhone,
-		DoctorID:      req.DoctorId,
-		DoctorName:    req.DoctorName,
-		AddressID:     req.AddressId,
-		AddressDetail: req.AddressDetail,
-		Items:         items,
-		Remark:        req.Remark,
+		UserID:         req.UserId,
+		UserName:       req.UserName,
+		UserPhone:      req.UserPhone,
+		DoctorID:       req.DoctorId,
+		DoctorName:     req.DoctorName,
+		AddressID:      req.AddressId,
+		AddressDetail:  req.AddressDetail,
+		Items:          items,
+		Remark:         req.Remark,
+		PrescriptionID: req.PrescriptionId,
+		Populations:    req.Populations,
+		IdempotencyKey: idempotencyKey(ctx, req.IdempotencyKey),
+	}
+	if req.UserCouponId > 0 {
+		createReq.UserCouponID = &req.UserCouponId
 	}
 
 	// 创建订单
@@ -60,10 +70,13 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *pb.CreateOrderReque
 	}
 
 	return &pb.CreateOrderReply{
-		OrderNo:     order.OrderNo,
-		TotalAmount: order.TotalAmount.String(),
-		Status:      order.Status,
-		Message:     "订单创建成功",
+		OrderNo:        order.OrderNo,
+		TotalAmount:    order.TotalAmount.String(),
+		Status:         order.Status,
+		Message:        "订单创建成功",
+		OriginalAmount: order.OriginalAmount.String(),
+		DiscountAmount: order.DiscountAmount.String(),
+		DrugWarnings:   toPbDrugWarnings(order.DrugWarnings),
 	}, nil
 }
 
@@ -77,19 +90,25 @@ func (s *OrderService) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*
 
 	// 转换订单信息
 	order := &pb.Order{
-		Id:            orderDetail.Order.ID,
-		OrderNo:       orderDetail.Order.OrderNo,
-		UserId:        orderDetail.Order.UserID,
-		UserName:      orderDetail.Order.UserName,
-		UserPhone:     orderDetail.Order.UserPhone,
-		DoctorId:      orderDetail.Order.DoctorID,
-		DoctorName:    orderDetail.Order.DoctorName,
-		AddressId:     orderDetail.Order.AddressID,
-		AddressDetail: orderDetail.Order.AddressDetail,
-		TotalAmount:   orderDetail.Order.TotalAmount.String(),
-		PayType:       orderDetail.Order.PayType,
-		Status:        orderDetail.Order.Status,
-		Remark:        orderDetail.Order.Remark,
+		Id:             orderDetail.Order.ID,
+		OrderNo:        orderDetail.Order.OrderNo,
+		UserId:         orderDetail.Order.UserID,
+		UserName:       orderDetail.Order.UserName,
+		UserPhone:      orderDetail.Order.UserPhone,
+		DoctorId:       orderDetail.Order.DoctorID,
+		DoctorName:     orderDetail.Order.DoctorName,
+		AddressId:      orderDetail.Order.AddressID,
+		AddressDetail:  orderDetail.Order.AddressDetail,
+		TotalAmount:    orderDetail.Order.TotalAmount.String(),
+		PayType:        orderDetail.Order.PayType,
+		Status:         orderDetail.Order.Status,
+		Remark:         orderDetail.Order.Remark,
+		OriginalAmount: orderDetail.Order.OriginalAmount.String(),
+		DiscountAmount: orderDetail.Order.DiscountAmount.String(),
+		PrescriptionId: orderDetail.Order.PrescriptionID,
+	}
+	if orderDetail.Order.UserCouponID != nil {
+		order.UserCouponId = *orderDetail.Order.UserCouponID
 	}
 
 	// 转换时间字段
@@ -199,4 +218,34 @@ func (s *OrderService) ProcessPayment(ctx context.Context, req *pb.ProcessPaymen
 		Success: true,
 		Message: "支付处理成功",
 	}, nil
-}
\ No newline at end of file
+}
+
+// ListOrderStatusLogs 获取订单状态流转记录，仅供客服后台调用，需在请求头 X-Admin-Token 中携带配置的管理令牌
+func (s *OrderService) ListOrderStatusLogs(ctx context.Context, req *pb.ListOrderStatusLogsRequest) (*pb.ListOrderStatusLogsReply, error) {
+	if !adminAuthorized(ctx, s.adminToken) {
+		return nil, kerrors.Forbidden("FORBIDDEN", "无权查看订单状态流转记录")
+	}
+	statusLogs, err := s.orderUc.ListOrderStatusLogs(ctx, req.OrderNo)
+	if err != nil {
+		s.log.Errorf("获取订单状态流转记录失败: %v", err)
+		return nil, err
+	}
+
+	logs := make([]*pb.OrderStatusLog, len(statusLogs))
+	for i, l := range statusLogs {
+		logs[i] = &pb.OrderStatusLog{
+			Id:           l.ID,
+			OrderNo:      l.OrderNo,
+			FromStatus:   l.FromStatus,
+			ToStatus:     l.ToStatus,
+			OperatorType: l.OperatorType,
+			OperatorId:   l.OperatorID,
+			Reason:       l.Reason,
+			CreatedAt:    l.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &pb.ListOrderStatusLogsReply{
+		Logs: logs,
+	}, nil
+}
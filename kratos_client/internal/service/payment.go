@@ -2,7 +2,8 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"io"
 	"net/url"
 	"strconv"
 	"strings"
@@ -11,131 +12,184 @@ import (
 	"kratos_client/comment"
 	"kratos_client/internal/biz"
 	"kratos_client/internal/data"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
 // 支付服务
 type PaymentService struct {
 	pb.UnimplementedPaymentServer
-	uc   *biz.PaymentUsecase
-	data *data.Data
+	uc       *biz.PaymentUsecase
+	refundUc *biz.RefundUsecase
+	orderUc  *biz.OrderUsecase
+	data     *data.Data
+	log      *log.Helper
 }
 
 // 创建支付服务
-func NewPaymentService(uc *biz.PaymentUsecase, data *data.Data) *PaymentService {
+func NewPaymentService(uc *biz.PaymentUsecase, refundUc *biz.RefundUsecase, orderUc *biz.OrderUsecase, data *data.Data, logger log.Logger) *PaymentService {
 	return &PaymentService{
-		uc:   uc,
-		data: data,
+		uc:       uc,
+		refundUc: refundUc,
+		orderUc:  orderUc,
+		data:     data,
+		log:      log.NewHelper(logger),
 	}
 }
 
 // 创建支付订单
 func (s *PaymentService) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.CreatePaymentReply, error) {
-	// 解析JWT token获取用户ID
-	claims, errMsg := comment.GetToken(req.Token)
-	if claims == nil || errMsg != "" {
-		return &pb.CreatePaymentReply{
-			Code:    401,
-			Message: "token无效: " + errMsg,
-		}, nil
-	}
-
-	userIDFloat, ok := claims["user"].(float64)
-	if !ok {
+	// 解析患者token获取用户ID
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
 		return &pb.CreatePaymentReply{
 			Code:    401,
-			Message: "token中用户ID格式错误",
+			Message: errMsg,
 		}, nil
 	}
-	userID := int32(userIDFloat)
 
 	// 验证参数
-	if req.Subject == "" || req.TotalAmount == "" || req.OrderType == "" || req.BusinessId == "" {
+	if req.Subject == "" || req.OrderType == "" || req.BusinessId == "" {
 		return &pb.CreatePaymentReply{
 			Code:    400,
 			Message: "参数不完整",
 		}, nil
 	}
 
-	// 验证金额格式
-	if _, err := strconv.ParseFloat(req.TotalAmount, 64); err != nil {
+	// 支付金额以订单为准，忽略客户端传入的金额；问诊支付单在发起问诊时创建
+	if req.OrderType != biz.OrderTypeDrug {
 		return &pb.CreatePaymentReply{
 			Code:    400,
-			Message: "金额格式错误",
+			Message: "不支持的订单类型",
 		}, nil
 	}
-
-	// 创建支付订单
-	order, err := s.uc.CreatePaymentOrder(ctx, userID, req.Subject, req.TotalAmount, req.OrderType, req.BusinessId, req.Description)
+	payable, err := s.orderUc.GetPayableOrder(ctx, int64(userID), req.BusinessId)
 	if err != nil {
+		if errors.Is(err, biz.ErrInvalidOrderStatusTransition) {
+			return &pb.CreatePaymentReply{
+				Code:    409,
+				Message: "订单状态不允许支付",
+			}, nil
+		}
 		return &pb.CreatePaymentReply{
 			Code:    500,
-			Message: "创建支付订单失败: " + err.Error(),
+			Message: "查询订单失败",
+		}, nil
+	}
+	if payable == nil {
+		return &pb.CreatePaymentReply{
+			Code:    404,
+			Message: "订单不存在",
 		}, nil
 	}
 
-	// 创建支付宝支付链接
-	paymentURL, err := comment.CreateAlipayOrder(order.OrderID, req.Subject, req.TotalAmount, strconv.Itoa(int(userID)))
+	// 按支付方式选择渠道创建支付订单
+	order, err := s.uc.CreatePaymentOrder(ctx, userID, req.PayType, req.Subject, payable.TotalAmount.StringFixed(2), req.OrderType, req.BusinessId, req.Description,
+		idempotencyKey(ctx, req.IdempotencyKey))
 	if err != nil {
+		if errors.Is(err, biz.ErrPaymentGatewayUnavailable) {
+			return &pb.CreatePaymentReply{
+				Code:    400,
+				Message: "不支持的支付方式",
+			}, nil
+		}
 		return &pb.CreatePaymentReply{
 			Code:    500,
-			Message: "创建支付链接失败: " + err.Error(),
+			Message: "创建支付订单失败: " + err.Error(),
 		}, nil
 	}
 
 	return &pb.CreatePaymentReply{
 		Code:       0,
 		Message:    "success",
-		PaymentUrl: paymentURL,
+		PaymentUrl: order.PaymentURL,
 		OrderId:    order.OrderID,
 	}, nil
 }
 
-// 支付回调通知
+// 支付宝支付回调通知
 func (s *PaymentService) PaymentNotify(ctx context.Context, req *pb.PaymentNotifyRequest) (*pb.PaymentNotifyReply, error) {
-	// 验证签名
-	ok, err := comment.VerifyAlipayCallback(req.Params)
-	if err != nil {
+	if !s.handlePaymentNotify(ctx, biz.PaymentChannelAlipay, &biz.GatewayNotify{Params: req.Params}) {
 		return &pb.PaymentNotifyReply{Result: "fail"}, nil
 	}
+	return &pb.PaymentNotifyReply{Result: "success"}, nil
+}
+
+// 处理支付通知，仅签名校验失败时返回false
+func (s *PaymentService) handlePaymentNotify(ctx context.Context, channel string, notify *biz.GatewayNotify) bool {
+	err := s.uc.HandlePaymentNotify(ctx, channel, notify)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, biz.ErrInvalidGatewayNotify) || errors.Is(err, biz.ErrPaymentGatewayUnavailable) {
+		s.log.Errorf("支付通知校验失败: channel=%s, error=%v", channel, err)
+		return false
+	}
+	// 记录错误但仍返回成功，避免渠道重复通知
+	s.log.Errorf("处理支付成功回调失败: channel=%s, error=%v", channel, err)
+	return true
+}
 
+// JSON报文类渠道(微信支付、沙箱)的支付通知，路由 /v1/payment/notify/{channel}
+func (s *PaymentService) GatewayPaymentNotify(ctx http.Context) error {
+	notify, err := readGatewayNotify(ctx)
+	if err != nil {
+		return ctx.JSON(400, gatewayNotifyReply(false))
+	}
+	ok := s.handlePaymentNotify(ctx, ctx.Vars().Get("channel"), notify)
 	if !ok {
-		return &pb.PaymentNotifyReply{Result: "fail"}, nil
+		return ctx.JSON(401, gatewayNotifyReply(false))
 	}
+	return ctx.JSON(200, gatewayNotifyReply(true))
+}
 
-	// 解析回调结果
-	result := comment.ParseAlipayCallback(req.Params)
-	
-	// 如果支付成功，更新订单状态
-	if result.Success {
-		err = s.uc.HandlePaymentSuccess(ctx, result.OrderID, result.TradeNo, result.TotalAmount)
-		if err != nil {
-			// 记录错误但仍返回success，避免支付宝重复通知
-			fmt.Printf("处理支付成功回调失败: %v\n", err)
-		}
+// JSON报文类渠道的退款通知，路由 /v1/payment/refund/notify/{channel}
+func (s *PaymentService) GatewayRefundNotify(ctx http.Context) error {
+	notify, err := readGatewayNotify(ctx)
+	if err != nil {
+		return ctx.JSON(400, gatewayNotifyReply(false))
 	}
+	if err := s.refundUc.HandleRefundNotify(ctx, ctx.Vars().Get("channel"), notify); err != nil {
+		// 返回失败，支付渠道会重新通知
+		s.log.Errorf("处理退款回调失败: %v", err)
+		return ctx.JSON(500, gatewayNotifyReply(false))
+	}
+	return ctx.JSON(200, gatewayNotifyReply(true))
+}
 
-	return &pb.PaymentNotifyReply{Result: "success"}, nil
+// 读取通知的请求头和原始报文，验签需要未经解析的报文
+func readGatewayNotify(ctx http.Context) (*biz.GatewayNotify, error) {
+	req := ctx.Request()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := make(map[string]string, len(req.Header))
+	for k := range req.Header {
+		header[k] = req.Header.Get(k)
+	}
+	return &biz.GatewayNotify{Header: header, Body: body}, nil
 }
 
-// 查询支付状态
-func (s *PaymentService) QueryPayment(ctx context.Context, req *pb.QueryPaymentRequest) (*pb.QueryPaymentReply, error) {
-	// 解析JWT token获取用户ID
-	claims, errMsg := comment.GetToken(req.Token)
-	if claims == nil || errMsg != "" {
-		return &pb.QueryPaymentReply{
-			Code:    401,
-			Message: "token无效: " + errMsg,
-		}, nil
+// 通知应答，格式与微信支付要求一致
+func gatewayNotifyReply(success bool) map[string]string {
+	if success {
+		return map[string]string{"code": "SUCCESS", "message": "成功"}
 	}
+	return map[string]string{"code": "FAIL", "message": "失败"}
+}
 
-	userIDFloat, ok := claims["user"].(float64)
-	if !ok {
+// 查询支付状态
+func (s *PaymentService) QueryPayment(ctx context.Context, req *pb.QueryPaymentRequest) (*pb.QueryPaymentReply, error) {
+	// 解析患者token获取用户ID
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
 		return &pb.QueryPaymentReply{
 			Code:    401,
-			Message: "token中用户ID格式错误",
+			Message: errMsg,
 		}, nil
 	}
-	userID := int32(userIDFloat)
 
 	// 查询订单
 	order, err := s.uc.GetPaymentOrder(ctx, req.OrderId)
@@ -161,37 +215,60 @@ func (s *PaymentService) QueryPayment(ctx context.Context, req *pb.QueryPaymentR
 		}, nil
 	}
 
-	// 如果订单状态为待支付，查询支付宝订单状态
-	if order.Status == biz.PaymentStatusPending {
-		alipayResp, err := comment.QueryAlipayOrder(order.OrderID)
-		if err == nil && alipayResp != nil {
-			// 根据支付宝返回的状态更新本地订单状态
-			if alipayResp.TradeStatus == "TRADE_SUCCESS" || alipayResp.TradeStatus == "TRADE_FINISHED" {
-				s.uc.UpdatePaymentOrderStatus(ctx, order.OrderID, biz.PaymentStatusPaid, alipayResp.TradeNo)
-				order.Status = biz.PaymentStatusPaid
-				order.TradeNo = alipayResp.TradeNo
-			}
-		}
+	// 如果订单状态为待支付，向支付渠道查询并同步支付结果
+	order, err = s.uc.SyncPaymentOrder(ctx, order.OrderID)
+	if err != nil {
+		return &pb.QueryPaymentReply{
+			Code:    500,
+			Message: "同步支付结果失败: " + err.Error(),
+		}, nil
+	}
+
+	return &pb.QueryPaymentReply{
+		Code:        0,
+		Message:     "success",
+		PaymentInfo: toPaymentInfo(order),
+	}, nil
+}
+
+// 沙箱模拟支付
+func (s *PaymentService) SandboxPay(ctx context.Context, req *pb.SandboxPayRequest) (*pb.SandboxPayReply, error) {
+	if req.OrderId == "" {
+		return &pb.SandboxPayReply{
+			Code:    400,
+			Message: "参数不完整",
+		}, nil
+	}
+
+	order, err := s.uc.SimulatePayment(ctx, req.OrderId)
+	if err != nil {
+		return &pb.SandboxPayReply{
+			Code:    500,
+			Message: "模拟支付失败: " + err.Error(),
+		}, nil
 	}
 
-	// 构造返回结果
-	paymentInfo := &pb.PaymentInfo{
+	return &pb.SandboxPayReply{
+		Code:        0,
+		Message:     "success",
+		PaymentInfo: toPaymentInfo(order),
+	}, nil
+}
+
+// 转换支付信息
+func toPaymentInfo(order *biz.PaymentOrder) *pb.PaymentInfo {
+	info := &pb.PaymentInfo{
 		OrderId:     order.OrderID,
 		TradeNo:     order.TradeNo,
 		TotalAmount: order.TotalAmount,
 		TradeStatus: order.Status,
 		Subject:     order.Subject,
+		Channel:     order.Channel,
 	}
-
 	if !order.PayTime.IsZero() {
-		paymentInfo.PayTime = order.PayTime.Format("2006-01-02 15:04:05")
+		info.PayTime = order.PayTime.Format("2006-01-02 15:04:05")
 	}
-
-	return &pb.QueryPaymentReply{
-		Code:        0,
-		Message:     "success",
-		PaymentInfo: paymentInfo,
-	}, nil
+	return info
 }
 
 // 支付返回页面
@@ -238,103 +315,121 @@ func (s *PaymentService) PaymentReturn(ctx context.Context, req *pb.PaymentRetur
 	}, nil
 }
 
-// 申请退款 - 暂时注释掉，因为proto定义缺失
-/*
-func (s *PaymentService) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentReply, error) {
-	// 解析JWT token获取用户ID
-	claims, errMsg := comment.GetToken(req.Token)
-	if claims == nil || errMsg != "" {
-		return &pb.RefundPaymentReply{
-			Code:    401,
-			Message: "token无效: " + errMsg,
-		}, nil
-	}
-
-	userIDFloat, ok := claims["user"].(float64)
-	if !ok {
-		return &pb.RefundPaymentReply{
+// 申请退款
+func (s *PaymentService) RequestRefund(ctx context.Context, req *pb.RequestRefundRequest) (*pb.RequestRefundReply, error) {
+	// 解析患者token获取用户ID
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.RequestRefundReply{
 			Code:    401,
-			Message: "token中用户ID格式错误",
+			Message: errMsg,
 		}, nil
 	}
-	userID := int32(userIDFloat)
 
-	// 查询订单
-	order, err := s.uc.GetPaymentOrder(ctx, req.OrderId)
-	if err != nil {
-		return &pb.RefundPaymentReply{
-			Code:    500,
-			Message: "查询订单失败: " + err.Error(),
+	// 验证参数
+	if req.OrderNo == "" || len(req.Items) == 0 {
+		return &pb.RequestRefundReply{
+			Code:    400,
+			Message: "参数不完整",
 		}, nil
 	}
 
-	if order == nil {
-		return &pb.RefundPaymentReply{
-			Code:    404,
-			Message: "订单不存在",
-		}, nil
+	items := make([]*biz.RefundItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &biz.RefundItemRequest{
+			OrderItemID: item.OrderItemId,
+			Quantity:    item.Quantity,
+		}
 	}
 
-	// 验证订单所有者
-	if order.UserID != userID {
-		return &pb.RefundPaymentReply{
-			Code:    403,
-			Message: "无权限操作此订单",
+	refund, err := s.refundUc.RequestRefund(ctx, &biz.RefundRequest{
+		OrderNo: req.OrderNo,
+		UserID:  int64(userID),
+		Items:   items,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		return &pb.RequestRefundReply{
+			Code:    500,
+			Message: "申请退款失败: " + err.Error(),
 		}, nil
 	}
 
-	// 验证订单状态
-	if order.Status != biz.PaymentStatusPaid {
-		return &pb.RefundPaymentReply{
-			Code:    400,
-			Message: "订单状态不允许退款",
-		}, nil
-	}
+	return &pb.RequestRefundReply{
+		Code:       0,
+		Message:    "退款申请已提交，等待审核",
+		RefundInfo: toRefundInfo(refund),
+	}, nil
+}
 
-	// 验证退款金额
-	if _, err := strconv.ParseFloat(req.RefundAmount, 64); err != nil {
-		return &pb.RefundPaymentReply{
-			Code:    400,
-			Message: "退款金额格式错误",
+// 查询订单的退款单
+func (s *PaymentService) ListRefunds(ctx context.Context, req *pb.ListRefundsRequest) (*pb.ListRefundsReply, error) {
+	// 解析患者token获取用户ID
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.ListRefundsReply{
+			Code:    401,
+			Message: errMsg,
 		}, nil
 	}
 
-	// 创建退款记录
-	refundRecord, err := s.uc.CreateRefund(ctx, req.OrderId, req.RefundAmount, req.RefundReason)
+	refunds, err := s.refundUc.ListOrderRefunds(ctx, req.OrderNo, int64(userID))
 	if err != nil {
-		return &pb.RefundPaymentReply{
+		return &pb.ListRefundsReply{
 			Code:    500,
-			Message: "创建退款记录失败: " + err.Error(),
+			Message: "查询退款单失败: " + err.Error(),
 		}, nil
 	}
 
-	// 调用支付宝退款接口
-	alipayResp, err := comment.RefundAlipayOrder(req.OrderId, req.RefundAmount, req.RefundReason)
-	if err != nil {
-		return &pb.RefundPaymentReply{
-			Code:    500,
-			Message: "申请退款失败: " + err.Error(),
-		}, nil
+	infos := make([]*pb.RefundInfo, len(refunds))
+	for i, refund := range refunds {
+		infos[i] = toRefundInfo(refund)
 	}
 
-	// 构造返回结果
-	refundInfo := &pb.RefundInfo{
-		RefundId:     refundRecord.RefundID,
-		RefundAmount: req.RefundAmount,
-		RefundStatus: "success",
-	}
+	return &pb.ListRefundsReply{
+		Code:    0,
+		Message: "success",
+		Refunds: infos,
+	}, nil
+}
 
-	if alipayResp != nil && alipayResp.Content.GmtRefundPay != "" {
-		refundInfo.RefundTime = alipayResp.Content.GmtRefundPay
+// 退款回调通知
+func (s *PaymentService) RefundNotify(ctx context.Context, req *pb.RefundNotifyRequest) (*pb.RefundNotifyReply, error) {
+	if err := s.refundUc.HandleRefundNotify(ctx, biz.PaymentChannelAlipay, &biz.GatewayNotify{Params: req.Params}); err != nil {
+		// 返回fail，支付渠道会重新通知
+		s.log.Errorf("处理退款回调失败: %v", err)
+		return &pb.RefundNotifyReply{Result: "fail"}, nil
 	}
 
-	return &pb.RefundPaymentReply{
-		Code:       0,
-		Message:    "退款申请成功",
-		RefundInfo: refundInfo,
-	}, nil
+	return &pb.RefundNotifyReply{Result: "success"}, nil
+}
+
+// 转换退款单
+func toRefundInfo(refund *biz.RefundRecord) *pb.RefundInfo {
+	info := &pb.RefundInfo{
+		RefundId:     refund.RefundID,
+		OrderNo:      refund.OrderNo,
+		RefundAmount: refund.RefundAmount,
+		RefundStatus: refund.RefundStatus,
+		RefundReason: refund.RefundReason,
+		AuditRemark:  refund.AuditRemark,
+		FailReason:   refund.FailReason,
+		CreatedAt:    refund.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if !refund.RefundTime.IsZero() {
+		info.RefundTime = refund.RefundTime.Format("2006-01-02 15:04:05")
+	}
+	for _, item := range refund.Items {
+		info.Items = append(info.Items, &pb.RefundItem{
+			OrderItemId: item.OrderItemID,
+			Quantity:    item.Quantity,
+			DrugId:      item.DrugID,
+			DrugName:    item.DrugName,
+			Amount:      item.Amount.StringFixed(2),
+		})
+	}
+	return info
 }
-*/
 
 // 解析表单参数为map
 func parseFormParams(formData string) map[string]string {
@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 
 	pb "kratos_client/api/prescription/v1"
+	"kratos_client/comment"
 	"kratos_client/internal/biz"
 )
 
@@ -15,19 +18,26 @@ type PrescriptionService struct {
 	pb.UnimplementedPrescriptionServiceServer
 
 	prescriptionUc *biz.PrescriptionUsecase
+	pharmacistUc   *biz.PharmacistUsecase
 	log            *log.Helper
 }
 
 // NewPrescriptionService 创建处方服务
-func NewPrescriptionService(prescriptionUc *biz.PrescriptionUsecase, logger log.Logger) *PrescriptionService {
+func NewPrescriptionService(prescriptionUc *biz.PrescriptionUsecase, pharmacistUc *biz.PharmacistUsecase, logger log.Logger) *PrescriptionService {
 	return &PrescriptionService{
 		prescriptionUc: prescriptionUc,
+		pharmacistUc:   pharmacistUc,
 		log:            log.NewHelper(logger),
 	}
 }
 
 // ListPrescriptions 获取处方列表
 func (s *PrescriptionService) ListPrescriptions(ctx context.Context, req *pb.ListPrescriptionsRequest) (*pb.ListPrescriptionsReply, error) {
+	// 全部处方只对药师开放，用于审核
+	if _, errMsg := tokenPharmacistID(req.Token); errMsg != "" {
+		return nil, kerrors.Unauthorized("UNAUTHORIZED", errMsg)
+	}
+
 	page := req.Page
 	if page <= 0 {
 		page = 1
@@ -68,7 +78,7 @@ func (s *PrescriptionService) ListPrescriptions(ctx context.Context, req *pb.Lis
 	// 转换为响应格式
 	pbPrescriptions := make([]*pb.Prescription, len(prescriptions))
 	for i, prescription := range prescriptions {
-		pbPrescriptions[i] = s.convertToPbPrescription(prescription)
+		pbPrescriptions[i] = toPbPrescription(prescription)
 	}
 
 	return &pb.ListPrescriptionsReply{
@@ -79,6 +89,14 @@ func (s *PrescriptionService) ListPrescriptions(ctx context.Context, req *pb.Lis
 
 // ListPatientPrescriptions 获取患者处方列表
 func (s *PrescriptionService) ListPatientPrescriptions(ctx context.Context, req *pb.ListPatientPrescriptionsRequest) (*pb.ListPatientPrescriptionsReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return nil, kerrors.Unauthorized("UNAUTHORIZED", errMsg)
+	}
+	if uint64(userID) != req.PatientId {
+		return nil, kerrors.Forbidden("FORBIDDEN", "只能查看自己的处方")
+	}
+
 	page := req.Page
 	if page <= 0 {
 		page = 1
@@ -104,7 +122,7 @@ func (s *PrescriptionService) ListPatientPrescriptions(ctx context.Context, req
 	// 转换为响应格式
 	pbPrescriptions := make([]*pb.Prescription, len(prescriptions))
 	for i, prescription := range prescriptions {
-		pbPrescriptions[i] = s.convertToPbPrescription(prescription)
+		pbPrescriptions[i] = toPbPrescription(prescription)
 	}
 
 	return &pb.ListPatientPrescriptionsReply{
@@ -115,6 +133,14 @@ func (s *PrescriptionService) ListPatientPrescriptions(ctx context.Context, req
 
 // ListDoctorPrescriptions 获取医生处方列表
 func (s *PrescriptionService) ListDoctorPrescriptions(ctx context.Context, req *pb.ListDoctorPrescriptionsRequest) (*pb.ListDoctorPrescriptionsReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return nil, kerrors.Unauthorized("UNAUTHORIZED", errMsg)
+	}
+	if uint64(doctorID) != req.DoctorId {
+		return nil, kerrors.Forbidden("FORBIDDEN", "只能查看自己开具的处方")
+	}
+
 	page := req.Page
 	if page <= 0 {
 		page = 1
@@ -140,7 +166,7 @@ func (s *PrescriptionService) ListDoctorPrescriptions(ctx context.Context, req *
 	// 转换为响应格式
 	pbPrescriptions := make([]*pb.Prescription, len(prescriptions))
 	for i, prescription := range prescriptions {
-		pbPrescriptions[i] = s.convertToPbPrescription(prescription)
+		pbPrescriptions[i] = toPbPrescription(prescription)
 	}
 
 	return &pb.ListDoctorPrescriptionsReply{
@@ -149,31 +175,211 @@ func (s *PrescriptionService) ListDoctorPrescriptions(ctx context.Context, req *
 	}, nil
 }
 
-// GetPrescriptionDetail 获取处方详情
+// GetPrescriptionDetail 获取处方详情，仅处方患者、开方医生和药师可以查看
 func (s *PrescriptionService) GetPrescriptionDetail(ctx context.Context, req *pb.GetPrescriptionDetailRequest) (*pb.GetPrescriptionDetailReply, error) {
+	id, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return nil, kerrors.Unauthorized("UNAUTHORIZED", errMsg)
+	}
+
 	detail, err := s.prescriptionUc.GetPrescriptionDetail(ctx, req.PrescriptionId)
 	if err != nil {
 		s.log.Errorf("获取处方详情失败: %v", err)
 		return nil, err
 	}
+	prescription := detail.Prescription
+	switch {
+	case role == comment.RolePharmacist:
+	case role == comment.RolePatient && prescription.PatientID == uint64(id):
+	case role == comment.RoleDoctor && prescription.DoctorID == uint64(id):
+	default:
+		return nil, kerrors.Forbidden("FORBIDDEN", "无权查看该处方")
+	}
 
-	// 转换处方信息
-	pbPrescription := s.convertToPbPrescription(detail.Prescription)
+	return &pb.GetPrescriptionDetailReply{
+		Prescription: toPbPrescription(prescription),
+		Medicines:    s.toPbPrescriptionMedicines(detail.Medicines),
+	}, nil
+}
 
-	// 转换药品明细
-	pbMedicines := make([]*pb.PrescriptionMedicine, len(detail.Medicines))
-	for i, medicine := range detail.Medicines {
-		pbMedicines[i] = s.convertToPbPrescriptionMedicine(medicine)
+// IssuePrescription 医生为接诊的问诊开具处方
+func (s *PrescriptionService) IssuePrescription(ctx context.Context, req *pb.IssuePrescriptionRequest) (*pb.PrescriptionDetailReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.PrescriptionDetailReply{Code: 401, Message: errMsg}, nil
 	}
 
-	return &pb.GetPrescriptionDetailReply{
-		Prescription: pbPrescription,
-		Medicines:    pbMedicines,
+	medicines := make([]*biz.IssuePrescriptionMedicine, len(req.Medicines))
+	for i, medicine := range req.Medicines {
+		medicines[i] = &biz.IssuePrescriptionMedicine{
+			MedicineID:  medicine.MedicineId,
+			Quantity:    medicine.Quantity,
+			Unit:        medicine.Unit,
+			Dosage:      medicine.Dosage,
+			Frequency:   medicine.Frequency,
+			Duration:    medicine.Duration,
+			UsageMethod: medicine.UsageMethod,
+			Notes:       medicine.Notes,
+		}
+	}
+	detail, err := s.prescriptionUc.IssuePrescription(ctx, &biz.IssuePrescriptionRequest{
+		DoctorID:         doctorID,
+		ConsultationNo:   req.ConsultationNo,
+		PrescriptionType: req.PrescriptionType,
+		UsageInstruction: req.UsageInstruction,
+		Medicines:        medicines,
+		Populations:      req.Populations,
+	})
+	if err != nil {
+		return &pb.PrescriptionDetailReply{Code: 400, Message: err.Error()}, nil
+	}
+	return &pb.PrescriptionDetailReply{
+		Code:         0,
+		Message:      "success",
+		Prescription: toPbPrescription(detail.Prescription),
+		Medicines:    s.toPbPrescriptionMedicines(detail.Medicines),
+		DrugWarnings: toPbDrugWarnings(detail.DrugWarnings),
+	}, nil
+}
+
+// CancelPrescription 医生撤销未审核的处方
+func (s *PrescriptionService) CancelPrescription(ctx context.Context, req *pb.CancelPrescriptionRequest) (*pb.PrescriptionDetailReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.PrescriptionDetailReply{Code: 401, Message: errMsg}, nil
+	}
+
+	prescription, err := s.prescriptionUc.CancelPrescription(ctx, doctorID, req.PrescriptionId)
+	if err != nil {
+		return prescriptionErrorReply(err), nil
+	}
+	return &pb.PrescriptionDetailReply{Code: 0, Message: "success", Prescription: toPbPrescription(prescription)}, nil
+}
+
+// PharmacistLogin 药师登录，签发药师token
+func (s *PrescriptionService) PharmacistLogin(ctx context.Context, req *pb.PharmacistLoginRequest) (*pb.PharmacistLoginReply, error) {
+	pharmacist, err := s.pharmacistUc.Login(ctx, req.Phone, req.Password)
+	if err != nil {
+		if errors.Is(err, biz.ErrPharmacistLoginFailed) {
+			return &pb.PharmacistLoginReply{Code: 401, Message: "账号或密码错误"}, nil
+		}
+		if errors.Is(err, biz.ErrLoginLocked) {
+			return &pb.PharmacistLoginReply{Code: 429, Message: "登录失败次数过多，请稍后再试"}, nil
+		}
+		return &pb.PharmacistLoginReply{Code: 400, Message: err.Error()}, nil
+	}
+	token, err := comment.PharmacistTokenHandler(int32(pharmacist.ID))
+	if err != nil {
+		s.log.Errorf("签发药师token失败: pharmacistId=%d, error=%v", pharmacist.ID, err)
+		return &pb.PharmacistLoginReply{Code: 500, Message: "登录失败"}, nil
+	}
+	return &pb.PharmacistLoginReply{
+		Code:         0,
+		Message:      "login success",
+		Token:        token,
+		PharmacistId: pharmacist.ID,
+		Name:         pharmacist.Name,
 	}, nil
 }
 
+// AuditPrescription 药师审核处方
+func (s *PrescriptionService) AuditPrescription(ctx context.Context, req *pb.AuditPrescriptionRequest) (*pb.PrescriptionDetailReply, error) {
+	pharmacistID, errMsg := tokenPharmacistID(req.Token)
+	if errMsg != "" {
+		return &pb.PrescriptionDetailReply{Code: 401, Message: errMsg}, nil
+	}
+
+	prescription, err := s.prescriptionUc.AuditPrescription(ctx, int64(pharmacistID), req.PrescriptionId, req.Approved, req.Notes)
+	if err != nil {
+		return prescriptionErrorReply(err), nil
+	}
+	return &pb.PrescriptionDetailReply{Code: 0, Message: "success", Prescription: toPbPrescription(prescription)}, nil
+}
+
+// OrderPrescription 患者将审核通过的处方一键下单
+func (s *PrescriptionService) OrderPrescription(ctx context.Context, req *pb.OrderPrescriptionRequest) (*pb.OrderPrescriptionReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.OrderPrescriptionReply{Code: 401, Message: errMsg}, nil
+	}
+
+	orderReq := &biz.PrescriptionOrderRequest{
+		PatientID:      userID,
+		PrescriptionID: req.PrescriptionId,
+		AddressID:      req.AddressId,
+		Remark:         req.Remark,
+	}
+	if req.UserCouponId > 0 {
+		orderReq.UserCouponID = &req.UserCouponId
+	}
+	order, err := s.prescriptionUc.CreateOrderFromPrescription(ctx, orderReq)
+	if err != nil {
+		code := int32(400)
+		if errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) || errors.Is(err, biz.ErrPrescriptionStatusConflict) {
+			code = 409
+		}
+		return &pb.OrderPrescriptionReply{Code: code, Message: err.Error()}, nil
+	}
+	return &pb.OrderPrescriptionReply{
+		Code:        0,
+		Message:     "success",
+		OrderNo:     order.OrderNo,
+		TotalAmount: order.TotalAmount.String(),
+		Status:      order.Status,
+	}, nil
+}
+
+// 处方状态冲突返回409，其他错误返回400
+// CheckPrescription 按处方药品和患者人群检查用药，处方的医生、患者和药师可查看
+func (s *PrescriptionService) CheckPrescription(ctx context.Context, req *pb.CheckPrescriptionRequest) (*pb.CheckPrescriptionReply, error) {
+	id, role, errMsg := tokenClaims(req.Token)
+	if errMsg != "" {
+		return &pb.CheckPrescriptionReply{Code: 401, Message: errMsg}, nil
+	}
+
+	detail, err := s.prescriptionUc.GetPrescriptionDetail(ctx, req.PrescriptionId)
+	if err != nil {
+		return &pb.CheckPrescriptionReply{Code: 404, Message: err.Error()}, nil
+	}
+	prescription := detail.Prescription
+	switch {
+	case role == comment.RolePharmacist:
+	case role == comment.RolePatient && prescription.PatientID == uint64(id):
+	case role == comment.RoleDoctor && prescription.DoctorID == uint64(id):
+	default:
+		return &pb.CheckPrescriptionReply{Code: 403, Message: "无权查看该处方"}, nil
+	}
+
+	result, err := s.prescriptionUc.CheckPrescription(ctx, prescription)
+	if err != nil {
+		s.log.Errorf("处方用药检查失败: prescriptionId=%d, error=%v", req.PrescriptionId, err)
+		return &pb.CheckPrescriptionReply{Code: 500, Message: "用药检查失败"}, nil
+	}
+	return &pb.CheckPrescriptionReply{
+		Code:     0,
+		Message:  "success",
+		Blocked:  result.Blocked,
+		Warnings: toPbDrugWarnings(result.Warnings),
+	}, nil
+}
+
+func prescriptionErrorReply(err error) *pb.PrescriptionDetailReply {
+	if errors.Is(err, biz.ErrInvalidPrescriptionStatusTransition) || errors.Is(err, biz.ErrPrescriptionStatusConflict) {
+		return &pb.PrescriptionDetailReply{Code: 409, Message: "处方状态已变更，请刷新后重试"}
+	}
+	return &pb.PrescriptionDetailReply{Code: 400, Message: err.Error()}
+}
+
+func (s *PrescriptionService) toPbPrescriptionMedicines(medicines []*biz.MtPrescriptionMedicine) []*pb.PrescriptionMedicine {
+	pbMedicines := make([]*pb.PrescriptionMedicine, len(medicines))
+	for i, medicine := range medicines {
+		pbMedicines[i] = s.convertToPbPrescriptionMedicine(medicine)
+	}
+	return pbMedicines
+}
+
 // 转换处方为protobuf格式
-func (s *PrescriptionService) convertToPbPrescription(prescription *biz.MtPrescription) *pb.Prescription {
+func toPbPrescription(prescription *biz.MtPrescription) *pb.Prescription {
 	pbPrescription := &pb.Prescription{
 		Id:               prescription.ID,
 		PrescriptionNo:   prescription.PrescriptionNo,
@@ -191,6 +397,9 @@ func (s *PrescriptionService) convertToPbPrescription(prescription *biz.MtPrescr
 		PatientName:      prescription.PatientName,
 		AuditorName:      prescription.AuditorName,
 		MedicineCount:    prescription.MedicineCount,
+		ConsultationNo:   prescription.ConsultationNo,
+		OrderNo:          prescription.OrderNo,
+		Populations:      prescription.Populations,
 	}
 
 	if prescription.MedicalRecordID != nil {
@@ -204,6 +413,9 @@ func (s *PrescriptionService) convertToPbPrescription(prescription *biz.MtPrescr
 	if prescription.AuditTime != nil {
 		pbPrescription.AuditTime = prescription.AuditTime.Format(time.RFC3339)
 	}
+	if !prescription.ExpiresAt.IsZero() {
+		pbPrescription.ExpiresAt = prescription.ExpiresAt.Format(time.RFC3339)
+	}
 
 	return pbPrescription
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"crypto/subtle"
+	"strconv"
+
+	"kratos_client/internal/biz"
+	"kratos_client/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// 对账单上传大小上限
+const maxStatementSize = 32 << 20
+
+// 对账管理服务
+type ReconcileService struct {
+	uc         *biz.ReconcileUsecase
+	adminToken string
+}
+
+// 创建对账管理服务
+func NewReconcileService(uc *biz.ReconcileUsecase, c *conf.Payment) *ReconcileService {
+	return &ReconcileService{
+		uc:         uc,
+		adminToken: c.GetReconcile().GetAdminToken(),
+	}
+}
+
+// 上传渠道账单执行对账，路由 /admin/v1/payment/reconcile
+// 表单字段 file 为账单文件，dry_run=true 时只报告差异不修复订单
+func (s *ReconcileService) Reconcile(ctx http.Context) error {
+	token := ctx.Request().Header.Get("X-Admin-Token")
+	if s.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		return ctx.JSON(403, map[string]interface{}{
+			"code":    403,
+			"message": "无权访问对账接口",
+		})
+	}
+
+	req := ctx.Request()
+	if err := req.ParseMultipartForm(maxStatementSize); err != nil {
+		return ctx.JSON(400, map[string]interface{}{
+			"code":    400,
+			"message": "请上传账单文件",
+		})
+	}
+	file, _, err := req.FormFile("file")
+	if err != nil {
+		return ctx.JSON(400, map[string]interface{}{
+			"code":    400,
+			"message": "请上传账单文件",
+		})
+	}
+	defer file.Close()
+	dryRun, _ := strconv.ParseBool(req.FormValue("dry_run"))
+
+	report, err := s.uc.Reconcile(ctx, file, biz.ReconcileOptions{DryRun: dryRun})
+	if err != nil {
+		return ctx.JSON(500, map[string]interface{}{
+			"code":    500,
+			"message": err.Error(),
+		})
+	}
+	return ctx.JSON(200, map[string]interface{}{
+		"code":    200,
+		"message": "对账完成",
+		"data":    report,
+	})
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	pb "kratos_client/api/schedule/v1"
+	"kratos_client/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 医生排班与预约挂号服务
+type ScheduleService struct {
+	pb.UnimplementedScheduleServer
+	uc  *biz.ScheduleUsecase
+	log *log.Helper
+}
+
+// 创建排班与预约服务
+func NewScheduleService(uc *biz.ScheduleUsecase, logger log.Logger) *ScheduleService {
+	return &ScheduleService{
+		uc:  uc,
+		log: log.NewHelper(logger),
+	}
+}
+
+// 查询医生可预约的号源
+func (s *ScheduleService) ListDoctorSlots(ctx context.Context, req *pb.ListDoctorSlotsRequest) (*pb.ListDoctorSlotsReply, error) {
+	if req.DoctorId <= 0 {
+		return &pb.ListDoctorSlotsReply{Code: 400, Message: "请选择医生"}, nil
+	}
+	slots, err := s.uc.ListDoctorSlots(ctx, req.DoctorId, req.StartDate, req.Days)
+	if err != nil {
+		return &pb.ListDoctorSlotsReply{Code: 500, Message: err.Error()}, nil
+	}
+	list := make([]*pb.SlotInfo, len(slots))
+	for i, slot := range slots {
+		list[i] = &pb.SlotInfo{
+			Id:        slot.ID,
+			DoctorId:  slot.DoctorID,
+			SlotDate:  slot.SlotDate,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Capacity:  slot.Capacity,
+			Remaining: slot.Capacity - slot.Booked,
+			Status:    slot.Status,
+		}
+	}
+	return &pb.ListDoctorSlotsReply{
+		Code:    0,
+		Message: "success",
+		List:    list,
+	}, nil
+}
+
+// 预约号源
+func (s *ScheduleService) BookAppointment(ctx context.Context, req *pb.BookAppointmentRequest) (*pb.AppointmentReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.AppointmentReply{Code: 401, Message: errMsg}, nil
+	}
+
+	appointment, err := s.uc.BookAppointment(ctx, userID, req.SlotId)
+	if err != nil {
+		switch {
+		case errors.Is(err, biz.ErrSlotUnavailable):
+			return &pb.AppointmentReply{Code: 409, Message: "该时段已约满或已停诊"}, nil
+		case errors.Is(err, biz.ErrAppointmentDuplicated):
+			return &pb.AppointmentReply{Code: 409, Message: "您已预约该时段"}, nil
+		}
+		return &pb.AppointmentReply{Code: 500, Message: err.Error()}, nil
+	}
+	return &pb.AppointmentReply{
+		Code:    0,
+		Message: "success",
+		Data:    toAppointmentInfo(appointment),
+	}, nil
+}
+
+// 取消预约
+func (s *ScheduleService) CancelAppointment(ctx context.Context, req *pb.CancelAppointmentRequest) (*pb.AppointmentReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.AppointmentReply{Code: 401, Message: errMsg}, nil
+	}
+
+	appointment, err := s.uc.CancelAppointment(ctx, userID, req.AppointmentNo, req.Reason)
+	if err != nil {
+		return &pb.AppointmentReply{Code: 500, Message: err.Error()}, nil
+	}
+	return &pb.AppointmentReply{
+		Code:    0,
+		Message: "success",
+		Data:    toAppointmentInfo(appointment),
+	}, nil
+}
+
+// 查询我的预约
+func (s *ScheduleService) ListAppointments(ctx context.Context, req *pb.ListAppointmentsRequest) (*pb.ListAppointmentsReply, error) {
+	userID, errMsg := tokenUserID(req.Token)
+	if errMsg != "" {
+		return &pb.ListAppointmentsReply{Code: 401, Message: errMsg}, nil
+	}
+
+	appointments, total, err := s.uc.ListAppointments(ctx, userID, req.Status, req.Page, req.PageSize)
+	if err != nil {
+		return &pb.ListAppointmentsReply{Code: 500, Message: err.Error()}, nil
+	}
+	list := make([]*pb.AppointmentInfo, len(appointments))
+	for i, appointment := range appointments {
+		list[i] = toAppointmentInfo(appointment)
+	}
+	return &pb.ListAppointmentsReply{
+		Code:    0,
+		Message: "success",
+		List:    list,
+		Total:   total,
+	}, nil
+}
+
+func toAppointmentInfo(appointment *biz.Appointment) *pb.AppointmentInfo {
+	return &pb.AppointmentInfo{
+		AppointmentNo: appointment.AppointmentNo,
+		SlotId:        appointment.SlotID,
+		DoctorId:      appointment.DoctorID,
+		DoctorName:    appointment.DoctorName,
+		PatientId:     appointment.PatientID,
+		SlotDate:      appointment.SlotDate,
+		StartTime:     appointment.StartTime,
+		EndTime:       appointment.EndTime,
+		Status:        appointment.Status,
+		CancelReason:  appointment.CancelReason,
+		CancelledAt:   formatConsultationTime(appointment.CancelledAt),
+		CreatedAt:     formatConsultationTime(appointment.CreatedAt),
+	}
+}
@@ -1,6 +1,43 @@
 package service
 
-import "github.com/google/wire"
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/google/wire"
+)
 
 // ProviderSet is service providers.
-var ProviderSet = wire.NewSet(NewUserService, NewDoctorsService, NewDrugService, NewEstimateService, NewCartService, NewOrderService, NewCouponService, NewPrescriptionService, NewPaymentService, NewChatService)
+var ProviderSet = wire.NewSet(NewUserService, NewDoctorsService, NewDrugService, NewEstimateService, NewCartService, NewOrderService, NewCouponService, NewPrescriptionService, NewPaymentService, NewChatService, NewReconcileService, NewConsultationService, NewScheduleService, NewDoctorWorkbenchService)
+
+// 幂等键请求头
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// 获取幂等键，请求字段优先，其次读取 Idempotency-Key 请求头（HTTP头或gRPC元数据）
+func idempotencyKey(ctx context.Context, field string) string {
+	if key := strings.TrimSpace(field); key != "" {
+		return key
+	}
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		return strings.TrimSpace(tr.RequestHeader().Get(idempotencyKeyHeader))
+	}
+	return ""
+}
+
+// 管理接口令牌请求头
+const adminTokenHeader = "X-Admin-Token"
+
+// 校验 X-Admin-Token 请求头（HTTP头或gRPC元数据），未配置令牌时一律拒绝
+func adminAuthorized(ctx context.Context, adminToken string) bool {
+	if adminToken == "" {
+		return false
+	}
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return false
+	}
+	token := tr.RequestHeader().Get(adminTokenHeader)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
@@ -184,6 +184,34 @@ func (s *UserService) UpdateMobile(ctx context.Context, in *v1.UpdateMobileReque
 	return &v1.UpdateMobileReply{Message: "update mobile success"}, nil
 }
 func (c *UserService) SelectTheCity(ctx context.Context, in *v1.SelectTheCityRequest) (*v1.SelectTheCityReply, error) {
+	userId := int32(ctx.Value("user_id").(float64))
+
+	// 传入城市时保存为用户所选城市，用于城市热搜
+	if in.CityId > 0 {
+		city, err := c.city.GetCity(ctx, in.CityId)
+		if err != nil {
+			return &v1.SelectTheCityReply{
+				Message: "查询失败",
+			}, err
+		}
+		if city == nil {
+			return &v1.SelectTheCityReply{
+				Message: "城市不存在",
+			}, nil
+		}
+		if err := c.uc.SelectCity(ctx, userId, in.CityId); err != nil {
+			return &v1.SelectTheCityReply{
+				Message: "保存城市失败",
+			}, err
+		}
+	}
+	selectedCityId, err := c.uc.GetSelectedCity(ctx, userId)
+	if err != nil {
+		return &v1.SelectTheCityReply{
+			Message: "查询失败",
+		}, err
+	}
+
 	find, err := c.city.Find(ctx, &biz.MtCity{})
 	if err != nil {
 		return &v1.SelectTheCityReply{
@@ -201,8 +229,9 @@ func (c *UserService) SelectTheCity(ctx context.Context, in *v1.SelectTheCityReq
 	}
 
 	return &v1.SelectTheCityReply{
-		Message:  "查询成功",
-		CityList: cityList,
+		Message:        "查询成功",
+		CityList:       cityList,
+		SelectedCityId: selectedCityId,
 	}, nil
 
 }
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	consultationv1 "kratos_client/api/consultation/v1"
+	prescriptionv1 "kratos_client/api/prescription/v1"
+	pb "kratos_client/api/workbench/v1"
+	"kratos_client/comment"
+	"kratos_client/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// 医生工作台服务
+type DoctorWorkbenchService struct {
+	pb.UnimplementedDoctorWorkbenchServer
+	doctorsUc      *biz.DoctorsService
+	consultationUc *biz.ConsultationUsecase
+	prescriptionUc *biz.PrescriptionUsecase
+	log            *log.Helper
+}
+
+// 创建医生工作台服务
+func NewDoctorWorkbenchService(doctorsUc *biz.DoctorsService, consultationUc *biz.ConsultationUsecase, prescriptionUc *biz.PrescriptionUsecase, logger log.Logger) *DoctorWorkbenchService {
+	return &DoctorWorkbenchService{
+		doctorsUc:      doctorsUc,
+		consultationUc: consultationUc,
+		prescriptionUc: prescriptionUc,
+		log:            log.NewHelper(logger),
+	}
+}
+
+// 医生登录，签发医生token
+func (s *DoctorWorkbenchService) DoctorLogin(ctx context.Context, req *pb.DoctorLoginRequest) (*pb.DoctorLoginReply, error) {
+	doctor, err := s.doctorsUc.Login(ctx, req.Account, req.Password, clientIP(ctx))
+	if err != nil {
+		if errors.Is(err, biz.ErrDoctorLoginFailed) {
+			return &pb.DoctorLoginReply{Code: 401, Message: "账号或密码错误"}, nil
+		}
+		if errors.Is(err, biz.ErrLoginLocked) {
+			return &pb.DoctorLoginReply{Code: 429, Message: "登录失败次数过多，请稍后再试"}, nil
+		}
+		return &pb.DoctorLoginReply{Code: 400, Message: err.Error()}, nil
+	}
+	token, err := comment.DoctorTokenHandler(int32(doctor.Id))
+	if err != nil {
+		s.log.Errorf("签发医生token失败: doctorId=%d, error=%v", doctor.Id, err)
+		return &pb.DoctorLoginReply{Code: 500, Message: "登录失败"}, nil
+	}
+	return &pb.DoctorLoginReply{
+		Code:    0,
+		Message: "login success",
+		Token:   token,
+		Data:    toDoctorProfileInfo(doctor, ""),
+	}, nil
+}
+
+// 我的问诊
+func (s *DoctorWorkbenchService) ListMyConsultations(ctx context.Context, req *pb.ListMyConsultationsRequest) (*pb.ListMyConsultationsReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.ListMyConsultationsReply{Code: 401, Message: errMsg}, nil
+	}
+
+	status := req.Status
+	switch status {
+	case "":
+		status = biz.ConsultationStatusWaiting
+	case "all":
+		status = ""
+	}
+	consultations, total, err := s.consultationUc.ListConsultations(ctx, doctorID, biz.ConsultationRoleDoctor, status, req.Page, req.PageSize)
+	if err != nil {
+		return &pb.ListMyConsultationsReply{Code: 500, Message: err.Error()}, nil
+	}
+	list := make([]*consultationv1.ConsultationInfo, len(consultations))
+	for i, consultation := range consultations {
+		list[i] = toConsultationInfo(consultation)
+	}
+	return &pb.ListMyConsultationsReply{
+		Code:    0,
+		Message: "success",
+		List:    list,
+		Total:   total,
+	}, nil
+}
+
+// 我的患者
+func (s *DoctorWorkbenchService) ListMyPatients(ctx context.Context, req *pb.ListMyPatientsRequest) (*pb.ListMyPatientsReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.ListMyPatientsReply{Code: 401, Message: errMsg}, nil
+	}
+
+	patients, total, err := s.doctorsUc.ListPatients(ctx, doctorID, req.Page, req.PageSize)
+	if err != nil {
+		s.log.Errorf("查询医生患者失败: doctorId=%d, error=%v", doctorID, err)
+		return &pb.ListMyPatientsReply{Code: 500, Message: "查询患者失败"}, nil
+	}
+	list := make([]*pb.PatientInfo, len(patients))
+	for i, patient := range patients {
+		list[i] = &pb.PatientInfo{
+			PatientId:        patient.PatientID,
+			NickName:         patient.NickName,
+			Avatar:           patient.Avatar,
+			RelationshipType: patient.RelationshipType,
+			Tags:             patient.Tags,
+			Notes:            patient.Notes,
+			FirstVisitTime:   formatConsultationTime(patient.FirstVisitTime),
+			LastVisitTime:    formatConsultationTime(patient.LastVisitTime),
+			VisitCount:       patient.VisitCount,
+		}
+	}
+	return &pb.ListMyPatientsReply{
+		Code:    0,
+		Message: "success",
+		List:    list,
+		Total:   total,
+	}, nil
+}
+
+// 我开具的处方
+func (s *DoctorWorkbenchService) ListMyPrescriptions(ctx context.Context, req *pb.ListMyPrescriptionsRequest) (*pb.ListMyPrescriptionsReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.ListMyPrescriptionsReply{Code: 401, Message: errMsg}, nil
+	}
+
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	prescriptions, total, err := s.prescriptionUc.ListDoctorPrescriptions(ctx, &biz.ListDoctorPrescriptionsRequest{
+		DoctorID: uint64(doctorID),
+		Status:   req.Status,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return &pb.ListMyPrescriptionsReply{Code: 500, Message: err.Error()}, nil
+	}
+	list := make([]*prescriptionv1.Prescription, len(prescriptions))
+	for i, prescription := range prescriptions {
+		list[i] = toPbPrescription(prescription)
+	}
+	return &pb.ListMyPrescriptionsReply{
+		Code:    0,
+		Message: "success",
+		List:    list,
+		Total:   total,
+	}, nil
+}
+
+// 查询我的资料
+func (s *DoctorWorkbenchService) GetMyProfile(ctx context.Context, req *pb.GetMyProfileRequest) (*pb.DoctorProfileReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.DoctorProfileReply{Code: 401, Message: errMsg}, nil
+	}
+
+	doctor, approvalStatus, err := s.doctorsUc.GetOwnProfile(ctx, doctorID)
+	if err != nil {
+		return &pb.DoctorProfileReply{Code: 500, Message: err.Error()}, nil
+	}
+	return &pb.DoctorProfileReply{
+		Code:    0,
+		Message: "success",
+		Data:    toDoctorProfileInfo(doctor, approvalStatus),
+	}, nil
+}
+
+// 修改我的资料并重新提交资质审核
+func (s *DoctorWorkbenchService) UpdateMyProfile(ctx context.Context, req *pb.UpdateMyProfileRequest) (*pb.DoctorProfileReply, error) {
+	doctorID, errMsg := tokenDoctorID(req.Token)
+	if errMsg != "" {
+		return &pb.DoctorProfileReply{Code: 401, Message: errMsg}, nil
+	}
+
+	doctor, approvalStatus, err := s.doctorsUc.UpdateProfile(ctx, doctorID, &biz.DoctorProfileUpdate{
+		Gender:        strings.TrimSpace(req.Gender),
+		Avatar:        strings.TrimSpace(req.Avatar),
+		Email:         strings.TrimSpace(req.Email),
+		Title:         strings.TrimSpace(req.Title),
+		Speciality:    strings.TrimSpace(req.Speciality),
+		PracticeScope: strings.TrimSpace(req.PracticeScope),
+	})
+	if err != nil {
+		return &pb.DoctorProfileReply{Code: 500, Message: err.Error()}, nil
+	}
+	return &pb.DoctorProfileReply{
+		Code:    0,
+		Message: "资料已提交审核",
+		Data:    toDoctorProfileInfo(doctor, approvalStatus),
+	}, nil
+}
+
+func toDoctorProfileInfo(doctor *biz.MtDoctors, approvalStatus string) *pb.DoctorProfileInfo {
+	return &pb.DoctorProfileInfo{
+		Id:              int32(doctor.Id),
+		DoctorCode:      doctor.DoctorCode,
+		Name:            doctor.Name,
+		Gender:          doctor.Gender,
+		Phone:           doctor.Phone,
+		Email:           doctor.Email,
+		Avatar:          doctor.Avatar,
+		Title:           doctor.Title,
+		Speciality:      doctor.Speciality,
+		PracticeScope:   doctor.PracticeScope,
+		HospitalId:      doctor.HospitalId,
+		DepartmentId:    doctor.DepartmentId,
+		ConsultationFee: doctor.ConsultationFee.StringFixed(2),
+		Status:          doctor.Status,
+		ApprovalStatus:  approvalStatus,
+		LastLoginTime:   formatConsultationTime(doctor.LastLoginTime),
+	}
+}
+
+// 请求来源IP，经过代理时取X-Forwarded-For的第一个地址
+func clientIP(ctx context.Context) string {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return ""
+	}
+	ht, ok := tr.(http.Transporter)
+	if !ok {
+		return ""
+	}
+	if forwarded := ht.RequestHeader().Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if realIP := ht.RequestHeader().Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+	host, _, err := net.SplitHostPort(ht.Request().RemoteAddr)
+	if err != nil {
+		return ht.Request().RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,6 @@
+-- 聊天富消息
+-- message_type 取值 text、image、voice、prescription、order、system
+-- 非文本消息的 content 保存摘要（如 [图片]、[处方] 处方号），结构化数据以JSON保存在 payload 中；处方、订单卡片保存发送时的快照
+
+ALTER TABLE mt_chat_message
+ADD COLUMN IF NOT EXISTS payload TEXT NULL COMMENT '消息结构化数据';
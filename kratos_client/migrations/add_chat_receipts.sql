@@ -0,0 +1,9 @@
+-- 聊天消息送达与已读回执
+-- 接收方任一设备确认收到后写入 delivered_at，标记已读时写入 read_at（未送达的消息一并补写 delivered_at）
+-- delivered_at 为空的消息即该用户的离线队列，WebSocket 重连后按ID顺序补发
+
+ALTER TABLE mt_chat_message
+ADD COLUMN IF NOT EXISTS delivered_at DATETIME(3) NULL COMMENT '送达时间',
+ADD COLUMN IF NOT EXISTS read_at DATETIME(3) NULL COMMENT '已读时间';
+
+CREATE INDEX IF NOT EXISTS idx_mt_chat_message_to_id_delivered ON mt_chat_message(to_id, delivered_at);
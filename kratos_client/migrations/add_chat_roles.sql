@@ -0,0 +1,54 @@
+-- 聊天参与方角色
+-- 患者、医生和药师的ID各自独立编号，聊天用户以角色和ID共同标识，角色取自token
+-- 房间号改为 room_{角色}_{ID}_{角色}_{ID}，先按角色再按ID排序，user1 为排序在前的一方（医患房间中为医生）
+-- 已有房间按问诊单、处方中的医患关系补全角色；无法判断的房间保留空角色，不会出现在新接口中，需人工确认后补全
+
+ALTER TABLE mt_char_room
+ADD COLUMN IF NOT EXISTS user1_role VARCHAR(20) NOT NULL DEFAULT '' COMMENT '用户1角色' AFTER room_name,
+ADD COLUMN IF NOT EXISTS user2_role VARCHAR(20) NOT NULL DEFAULT '' COMMENT '用户2角色' AFTER user1_id;
+
+ALTER TABLE mt_chat_message
+ADD COLUMN IF NOT EXISTS from_role VARCHAR(20) NOT NULL DEFAULT '' COMMENT '发送者角色' AFTER from_id,
+ADD COLUMN IF NOT EXISTS to_role VARCHAR(20) NOT NULL DEFAULT '' COMMENT '接收者角色' AFTER to_id;
+
+-- 已知的医患关系
+CREATE TEMPORARY TABLE tmp_chat_pair AS
+SELECT DISTINCT patient_id, doctor_id FROM mt_consultation
+UNION
+SELECT DISTINCT patient_id, doctor_id FROM mt_prescriptions;
+
+-- 旧房间 user1_id 为较小的ID，医生在前时直接补全角色
+UPDATE mt_char_room r
+JOIN tmp_chat_pair p ON p.doctor_id = r.user1_id AND p.patient_id = r.user2_id
+SET r.user1_role = 'doctor', r.user2_role = 'patient'
+WHERE r.user1_role = '';
+
+-- 患者在前时交换双方，使医生排在前面
+CREATE TEMPORARY TABLE tmp_chat_room_swap AS
+SELECT r.id, r.user1_id, r.user2_id, r.user1_unread, r.user2_unread
+FROM mt_char_room r
+JOIN tmp_chat_pair p ON p.patient_id = r.user1_id AND p.doctor_id = r.user2_id
+WHERE r.user1_role = '';
+
+UPDATE mt_char_room r
+JOIN tmp_chat_room_swap s ON s.id = r.id
+SET r.user1_role = 'doctor', r.user1_id = s.user2_id, r.user1_unread = s.user2_unread,
+    r.user2_role = 'patient', r.user2_id = s.user1_id, r.user2_unread = s.user1_unread;
+
+DROP TEMPORARY TABLE tmp_chat_room_swap;
+DROP TEMPORARY TABLE tmp_chat_pair;
+
+UPDATE mt_char_room
+SET room_id = CONCAT('room_', user1_role, '_', user1_id, '_', user2_role, '_', user2_id),
+    room_name = CONCAT('room_', user1_role, '_', user1_id, '_', user2_role, '_', user2_id)
+WHERE user1_role <> '';
+
+-- 消息按所属房间补全角色
+UPDATE mt_chat_message m
+JOIN mt_char_room r ON r.id = m.room_id
+SET m.from_role = IF(m.from_id = r.user1_id, r.user1_role, r.user2_role),
+    m.to_role = IF(m.to_id = r.user1_id, r.user1_role, r.user2_role)
+WHERE m.from_role = '' AND r.user1_role <> '';
+
+DROP INDEX IF EXISTS idx_mt_chat_message_to_id_delivered ON mt_chat_message;
+CREATE INDEX IF NOT EXISTS idx_mt_chat_message_to_delivered ON mt_chat_message(to_id, to_role, delivered_at);
@@ -0,0 +1,24 @@
+-- 下单核销优惠券
+-- mt_discount_user.status 作为核销的并发控制条件，0未使用 1已使用
+-- mt_order_coupon 保留每次核销记录，订单取消/超时关闭后标记为已退回
+-- mt_discount.used_count 随核销和退回同步增减
+
+ALTER TABLE mt_discount
+ADD COLUMN IF NOT EXISTS used_count INT NOT NULL DEFAULT 0 COMMENT '已使用数量';
+
+ALTER TABLE mt_discount_user
+ADD COLUMN IF NOT EXISTS status TINYINT NOT NULL DEFAULT 0 COMMENT '使用状态: 0未使用 1已使用';
+
+ALTER TABLE mt_order_coupon
+ADD COLUMN IF NOT EXISTS status TINYINT NOT NULL DEFAULT 1 COMMENT '核销状态: 1已使用 2已退回',
+ADD COLUMN IF NOT EXISTS return_time DATETIME NULL COMMENT '退回时间';
+
+CREATE INDEX IF NOT EXISTS idx_order_no ON mt_order_coupon(order_no);
+
+-- 按已有核销记录回填
+UPDATE mt_discount_user du
+SET du.status = 1
+WHERE EXISTS (SELECT 1 FROM mt_order_coupon oc WHERE oc.discount_user_id = du.id AND oc.status = 1);
+
+UPDATE mt_discount d
+SET d.used_count = (SELECT COUNT(*) FROM mt_order_coupon oc WHERE oc.discount_id = d.id AND oc.status = 1);
@@ -0,0 +1,19 @@
+-- 医生发现：评分与问诊量
+-- 患者对已结束的问诊评分(1-5)，评分写入 mt_consultation 并在同一事务内累计到 mt_doctors 的评分均值和评分人数
+-- 问诊结束(医生结束或到时自动结束)时累加医生的问诊量；医生列表按评分或问诊量排序
+-- 医生列表只返回 status = 1 且存在审核通过(mt_doctor_approval.approval_status = 1)记录的医生
+
+ALTER TABLE mt_doctors
+ADD COLUMN IF NOT EXISTS rating DECIMAL(3,2) NOT NULL DEFAULT 0 COMMENT '患者评分均值',
+ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0 COMMENT '评分人数',
+ADD COLUMN IF NOT EXISTS consultation_count INT NOT NULL DEFAULT 0 COMMENT '已完成问诊量';
+
+CREATE INDEX IF NOT EXISTS idx_doctors_rating ON mt_doctors(status, rating);
+CREATE INDEX IF NOT EXISTS idx_doctors_consultation_count ON mt_doctors(status, consultation_count);
+
+ALTER TABLE mt_consultation
+ADD COLUMN IF NOT EXISTS rating TINYINT NOT NULL DEFAULT 0 COMMENT '患者评分1-5，0表示未评价',
+ADD COLUMN IF NOT EXISTS rating_comment VARCHAR(255) DEFAULT '' COMMENT '患者评价',
+ADD COLUMN IF NOT EXISTS rated_at DATETIME(3) NULL COMMENT '评价时间';
+
+CREATE INDEX IF NOT EXISTS idx_doctor_approval_doctor ON mt_doctor_approval(doctor_id, approval_status);
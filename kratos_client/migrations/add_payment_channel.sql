@@ -0,0 +1,6 @@
+-- 支付渠道
+-- 支付单记录下单渠道(alipay、wechat、sandbox)，回调、主动查询和退款都按该字段找回渠道
+-- 历史支付单均为支付宝下单
+
+ALTER TABLE payment_orders
+ADD COLUMN IF NOT EXISTS channel VARCHAR(16) NOT NULL DEFAULT 'alipay' COMMENT '支付渠道';
@@ -0,0 +1,17 @@
+-- 处方药凭处方购买
+-- mt_drug.is_prescription = 1 的药品下单时必须关联患者本人审核通过、未过期且未使用的处方，且处方药品和数量覆盖订单中的处方药
+-- 下单时处方由 已审核 变为 已下单 并记录订单号；订单取消或超时关闭时恢复为 已审核；订单支付成功时处方变为 已使用
+-- 处方自开具起 3 天内有效，历史处方按开具日期补齐有效期
+
+ALTER TABLE mt_drug
+ADD COLUMN IF NOT EXISTS is_prescription TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否处方药';
+
+ALTER TABLE mt_prescriptions
+ADD COLUMN IF NOT EXISTS expires_at DATETIME(3) NULL COMMENT '处方有效期截止时间';
+
+UPDATE mt_prescriptions SET expires_at = DATE_ADD(prescription_date, INTERVAL 3 DAY) WHERE expires_at IS NULL;
+
+ALTER TABLE mt_orders
+ADD COLUMN IF NOT EXISTS prescription_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '关联处方ID，0表示未关联';
+
+CREATE INDEX IF NOT EXISTS idx_orders_prescription ON mt_orders(prescription_id);
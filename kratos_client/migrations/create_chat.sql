@@ -0,0 +1,29 @@
+-- 医患聊天
+-- 两个用户之间唯一一个房间，room_id 格式 room_{较小ID}_{较大ID}，user1_id 为较小ID
+-- 消息写入 mt_chat_message 的同时更新房间最后一条消息，并累加接收方的未读数；标记已读时清零
+-- mt_chat_message 由后台自动建表，内容列原为 VARCHAR(50)，放宽为 TEXT
+
+CREATE TABLE IF NOT EXISTS mt_char_room (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    room_id VARCHAR(50) DEFAULT NULL COMMENT '房间ID',
+    room_name VARCHAR(50) DEFAULT NULL COMMENT '房间名称',
+    user1_id INT NOT NULL DEFAULT 0 COMMENT '用户1id',
+    user2_id INT NOT NULL DEFAULT 0 COMMENT '用户2id',
+    last_message VARCHAR(50) DEFAULT NULL COMMENT '最后一条消息',
+    last_message_time DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6) COMMENT '最后消息时间',
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='聊天房间';
+
+ALTER TABLE mt_char_room
+ADD COLUMN IF NOT EXISTS user1_unread INT NOT NULL DEFAULT 0 COMMENT '用户1未读数',
+ADD COLUMN IF NOT EXISTS user2_unread INT NOT NULL DEFAULT 0 COMMENT '用户2未读数';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mt_char_room_room_id ON mt_char_room(room_id);
+CREATE INDEX IF NOT EXISTS idx_mt_char_room_user1_id ON mt_char_room(user1_id);
+CREATE INDEX IF NOT EXISTS idx_mt_char_room_user2_id ON mt_char_room(user2_id);
+
+ALTER TABLE mt_chat_message
+MODIFY COLUMN content TEXT COMMENT '消息内容';
+
+CREATE INDEX IF NOT EXISTS idx_mt_chat_message_room_id ON mt_chat_message(room_id);
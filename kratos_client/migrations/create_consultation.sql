@@ -0,0 +1,33 @@
+-- 在线问诊
+-- 患者选择医生发起问诊(pending_payment) -> 支付问诊费后待接诊(waiting) -> 医生接诊(in_progress) -> 医生填写诊断小结结束或到时自动结束(closed)
+-- 待接诊超时或患者在接诊前取消时整单退款(refunded)，退款单由退款任务提交支付渠道；待支付时患者可取消(cancelled)
+-- 问诊费取 mt_doctors.consultation_fee，为 0 表示医生未开通在线问诊；支付单 business_id 为问诊单号
+
+ALTER TABLE mt_doctors
+ADD COLUMN IF NOT EXISTS consultation_fee DECIMAL(10,2) NOT NULL DEFAULT 0 COMMENT '图文问诊费用，0表示未开通';
+
+CREATE TABLE IF NOT EXISTS mt_consultation (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    consultation_no VARCHAR(64) NOT NULL COMMENT '问诊单号',
+    patient_id INT NOT NULL COMMENT '患者用户ID',
+    doctor_id INT NOT NULL COMMENT '医生ID',
+    doctor_name VARCHAR(50) DEFAULT '' COMMENT '医生姓名',
+    fee DECIMAL(10,2) NOT NULL COMMENT '问诊费',
+    description VARCHAR(500) DEFAULT '' COMMENT '病情描述',
+    status VARCHAR(32) NOT NULL COMMENT '问诊状态',
+    payment_order_id VARCHAR(64) DEFAULT '' COMMENT '支付单号',
+    refund_id VARCHAR(64) DEFAULT '' COMMENT '退款单号',
+    diagnosis TEXT NULL COMMENT '诊断小结',
+    advice TEXT NULL COMMENT '医嘱',
+    close_reason VARCHAR(255) DEFAULT '' COMMENT '结束、取消或退款原因',
+    paid_at DATETIME(3) NULL COMMENT '支付时间',
+    started_at DATETIME(3) NULL COMMENT '接诊时间',
+    ends_at DATETIME(3) NULL COMMENT '会话截止时间',
+    closed_at DATETIME(3) NULL COMMENT '结束时间',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY idx_consultation_no (consultation_no),
+    INDEX idx_consultation_patient (patient_id, created_at),
+    INDEX idx_consultation_doctor (doctor_id, status),
+    INDEX idx_consultation_status (status, paid_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='在线问诊';
@@ -0,0 +1,71 @@
+-- 医生排班与预约挂号
+-- 管理端维护医生的每周排班模板(mt_doctor_schedule)和按日期的停诊/加诊例外(mt_doctor_schedule_exception)
+-- 患者查询号源时按模板和例外生成时段(mt_schedule_slot)，同一医生同一日期同一开始时间只生成一次，已生成的时段不随模板变化
+-- 管理端修改排班后删除未来未被预约的时段，下次查询时按新排班重新生成；已有预约的时段保留
+-- 预约时以 booked < capacity 为条件占用号源，防止超约；booking_key 为 时段ID-患者ID，取消后置空，防止同一患者重复预约同一时段
+-- 日期格式为 2006-01-02，时间格式为 15:04，weekday 取 1-7 表示周一到周日
+
+CREATE TABLE IF NOT EXISTS mt_doctor_schedule (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    doctor_id INT NOT NULL COMMENT '医生ID',
+    weekday TINYINT NOT NULL COMMENT '星期，1-7表示周一到周日',
+    start_time VARCHAR(5) NOT NULL COMMENT '出诊开始时间',
+    end_time VARCHAR(5) NOT NULL COMMENT '出诊结束时间',
+    slot_minutes INT NOT NULL DEFAULT 30 COMMENT '每个时段的分钟数',
+    capacity INT NOT NULL DEFAULT 1 COMMENT '每个时段的号源数',
+    status TINYINT NOT NULL DEFAULT 1 COMMENT '状态：1启用 0停用',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    INDEX idx_schedule_doctor (doctor_id, weekday)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='医生每周排班模板';
+
+CREATE TABLE IF NOT EXISTS mt_doctor_schedule_exception (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    doctor_id INT NOT NULL COMMENT '医生ID',
+    exception_date VARCHAR(10) NOT NULL COMMENT '日期',
+    type VARCHAR(16) NOT NULL COMMENT '类型：off停诊 extra加诊',
+    start_time VARCHAR(5) DEFAULT '' COMMENT '开始时间，停诊时为空表示全天',
+    end_time VARCHAR(5) DEFAULT '' COMMENT '结束时间',
+    slot_minutes INT NOT NULL DEFAULT 30 COMMENT '加诊每个时段的分钟数',
+    capacity INT NOT NULL DEFAULT 1 COMMENT '加诊每个时段的号源数',
+    reason VARCHAR(255) DEFAULT '' COMMENT '原因',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    INDEX idx_schedule_exception_doctor (doctor_id, exception_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='医生排班例外';
+
+CREATE TABLE IF NOT EXISTS mt_schedule_slot (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    doctor_id INT NOT NULL COMMENT '医生ID',
+    slot_date VARCHAR(10) NOT NULL COMMENT '日期',
+    start_time VARCHAR(5) NOT NULL COMMENT '开始时间',
+    end_time VARCHAR(5) NOT NULL COMMENT '结束时间',
+    capacity INT NOT NULL COMMENT '号源数',
+    booked INT NOT NULL DEFAULT 0 COMMENT '已预约数',
+    status VARCHAR(16) NOT NULL COMMENT '状态：open可预约 closed已停诊',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY idx_slot_doctor_time (doctor_id, slot_date, start_time)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='医生号源时段';
+
+CREATE TABLE IF NOT EXISTS mt_appointment (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    appointment_no VARCHAR(64) NOT NULL COMMENT '预约单号',
+    slot_id BIGINT NOT NULL COMMENT '时段ID',
+    doctor_id INT NOT NULL COMMENT '医生ID',
+    doctor_name VARCHAR(50) DEFAULT '' COMMENT '医生姓名',
+    patient_id INT NOT NULL COMMENT '患者用户ID',
+    slot_date VARCHAR(10) NOT NULL COMMENT '就诊日期',
+    start_time VARCHAR(5) NOT NULL COMMENT '就诊开始时间',
+    end_time VARCHAR(5) NOT NULL COMMENT '就诊结束时间',
+    status VARCHAR(16) NOT NULL COMMENT '状态：booked已预约 cancelled已取消',
+    booking_key VARCHAR(64) NULL COMMENT '时段ID-患者ID，取消后置空',
+    cancel_reason VARCHAR(255) DEFAULT '' COMMENT '取消原因',
+    cancelled_at DATETIME(3) NULL COMMENT '取消时间',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY idx_appointment_no (appointment_no),
+    UNIQUE KEY idx_appointment_booking (booking_key),
+    INDEX idx_appointment_patient (patient_id, slot_date),
+    INDEX idx_appointment_doctor (doctor_id, slot_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='预约挂号';
@@ -0,0 +1,14 @@
+-- 药品搜索索引同步任务表
+-- 后台新增、修改、删除药品以及C端库存变动时，在同一事务内登记药品ID
+-- 索引同步任务按ID顺序消费并写入Elasticsearch别名 mt_drug，处理后保留7天，供全量重建索引补齐重建期间的变更
+-- 首次启用搜索或修改映射后执行 kratos_client reindex -conf config.yaml 全量重建
+
+CREATE TABLE IF NOT EXISTS mt_drug_index_task (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    drug_id BIGINT NOT NULL COMMENT '药品ID',
+    created_at DATETIME(3) NOT NULL COMMENT '登记时间',
+    processed_at DATETIME(3) NULL COMMENT '同步时间，为空表示待同步',
+    KEY idx_drug_index_task_drug (drug_id),
+    KEY idx_drug_index_task_created (created_at),
+    KEY idx_drug_index_task_processed (processed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='药品搜索索引同步任务';
@@ -0,0 +1,31 @@
+-- 药物相互作用与人群禁忌知识库
+-- 后台从CSV导入，C端在购物车、开具处方、审核处方和下单时检查
+-- 严重(major)相互作用和禁用(forbidden)拦截开方、审核通过和下单；轻微、中度相互作用和慎用仅返回提示
+-- 相互作用按药品ID对存储，drug_a_id 小于 drug_b_id
+
+CREATE TABLE IF NOT EXISTS mt_drug_interaction (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    drug_a_id BIGINT NOT NULL COMMENT '药品A ID，小于药品B ID',
+    drug_b_id BIGINT NOT NULL COMMENT '药品B ID',
+    severity VARCHAR(16) NOT NULL COMMENT '严重程度: minor-轻微, moderate-中度, major-严重',
+    description VARCHAR(500) DEFAULT '' COMMENT '相互作用说明',
+    advice VARCHAR(500) DEFAULT '' COMMENT '用药建议',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY uk_drug_interaction_pair (drug_a_id, drug_b_id),
+    KEY idx_drug_interaction_b (drug_b_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='药物相互作用表';
+
+CREATE TABLE IF NOT EXISTS mt_drug_contraindication (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    drug_id BIGINT NOT NULL COMMENT '药品ID',
+    population VARCHAR(16) NOT NULL COMMENT '人群: pregnancy-孕妇, lactation-哺乳期, child-儿童, elderly-老人',
+    level VARCHAR(16) NOT NULL COMMENT '级别: caution-慎用, forbidden-禁用',
+    description VARCHAR(500) DEFAULT '' COMMENT '禁忌说明',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY uk_drug_contraindication (drug_id, population)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='药品人群禁忌表';
+
+ALTER TABLE mt_prescriptions
+ADD COLUMN IF NOT EXISTS patient_populations VARCHAR(64) DEFAULT '' COMMENT '患者所属人群，逗号分隔，用于用药检查';
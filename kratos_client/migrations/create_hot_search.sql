@@ -0,0 +1,28 @@
+-- 热门搜索
+-- 药品搜索首页有结果的关键词计入热搜，同时计入全国榜单(city_id = 0)和用户所选城市的榜单
+-- 配置Redis时统计写入按小时划分的有序集合，否则写入 mt_hot_search_stat 按小时汇总，保留8天
+-- 榜单按最近24小时或7天的搜索次数排名，越近的搜索权重越高；包含屏蔽词的搜索词不展示
+
+CREATE TABLE IF NOT EXISTS mt_hot_search_stat (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    city_id INT NOT NULL DEFAULT 0 COMMENT '城市行政区划代码，0表示全国',
+    term VARCHAR(64) NOT NULL COMMENT '搜索词，已合并空白并转小写',
+    stat_hour DATETIME(3) NOT NULL COMMENT '统计小时',
+    search_count BIGINT NOT NULL DEFAULT 0 COMMENT '该小时搜索次数',
+    updated_at DATETIME(3) NULL COMMENT '最后搜索时间',
+    UNIQUE KEY uk_city_term_hour (city_id, term, stat_hour),
+    KEY idx_hot_search_stat_hour (stat_hour)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='热门搜索按小时汇总';
+
+CREATE TABLE IF NOT EXISTS mt_hot_search_block (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    term VARCHAR(64) NOT NULL COMMENT '屏蔽词，搜索词包含该词即不展示',
+    remark VARCHAR(255) NULL COMMENT '备注',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY uk_hot_search_block_term (term)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='热搜屏蔽词';
+
+-- 用户所选城市，用于城市热搜
+ALTER TABLE mt_user
+ADD COLUMN IF NOT EXISTS city_id INT NOT NULL DEFAULT 0 COMMENT '所选城市行政区划代码，0表示未选择';
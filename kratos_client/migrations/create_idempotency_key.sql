@@ -0,0 +1,20 @@
+-- 接口幂等键去重表
+-- 未配置Redis时使用，同一用户在同一接口上重复提交相同的幂等键时返回首次结果
+-- 过期记录在下次使用同一个键时清理，也可定期执行末尾的清理语句
+
+CREATE TABLE IF NOT EXISTS mt_idempotency_key (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    scope VARCHAR(32) NOT NULL COMMENT '作用域: create_order/create_payment',
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    idem_key VARCHAR(64) NOT NULL COMMENT '客户端幂等键',
+    fingerprint VARCHAR(64) NOT NULL COMMENT '请求参数摘要',
+    status VARCHAR(16) NOT NULL COMMENT '处理状态: processing/completed',
+    resource_id VARCHAR(64) DEFAULT '' COMMENT '首次请求创建的资源编号',
+    expires_at DATETIME(3) NOT NULL COMMENT '过期时间',
+    created_at DATETIME(3) NOT NULL COMMENT '创建时间',
+    UNIQUE KEY uk_scope_user_key (scope, user_id, idem_key),
+    INDEX idx_mt_idempotency_key_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='接口幂等键';
+
+-- 清理过期记录
+-- DELETE FROM mt_idempotency_key WHERE expires_at < NOW(3);
@@ -0,0 +1,19 @@
+-- 库存告警表
+-- 可售数量跌破预警阈值或售罄时写入，后台确认后关闭；同一次跌破只告警一次
+
+CREATE TABLE IF NOT EXISTS mt_inventory_alert (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    inventory_id BIGINT NOT NULL COMMENT '药店库存ID',
+    drug_id BIGINT NOT NULL COMMENT '药品ID',
+    drug_name VARCHAR(50) DEFAULT '' COMMENT '药品名称',
+    drug_store_id INT NOT NULL COMMENT '药店ID',
+    level TINYINT NOT NULL COMMENT '告警级别 1-库存不足 2-缺货',
+    available_qty BIGINT NOT NULL COMMENT '触发时可售数量',
+    alert_threshold BIGINT NOT NULL COMMENT '预警阈值',
+    status TINYINT NOT NULL DEFAULT 0 COMMENT '处理状态 0-待处理 1-已确认',
+    ack_by VARCHAR(50) DEFAULT '' COMMENT '确认人',
+    ack_at DATETIME(3) NULL COMMENT '确认时间',
+    created_at DATETIME(3) NOT NULL COMMENT '告警时间',
+    INDEX idx_status_created_at (status, created_at),
+    INDEX idx_drug_store (drug_id, drug_store_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='库存告警';
@@ -0,0 +1,11 @@
+-- 后台任务租约表
+-- 多副本部署时，同名任务同一时刻只由持有未过期租约的实例执行
+
+CREATE TABLE IF NOT EXISTS mt_job_lease (
+    name VARCHAR(64) NOT NULL PRIMARY KEY COMMENT '任务名称',
+    holder VARCHAR(128) NOT NULL COMMENT '当前持有者（主机名-进程号）',
+    expires_at DATETIME(3) NOT NULL COMMENT '租约过期时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='后台任务租约';
+
+-- 超时未支付订单扫描依赖下单时间
+CREATE INDEX idx_mt_orders_status_created_at ON mt_orders(status, created_at);
@@ -0,0 +1,16 @@
+-- 订单状态流转日志表
+-- 记录每一次订单状态变更的操作人、时间和原因，供客服追溯
+
+CREATE TABLE IF NOT EXISTS mt_order_status_log (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT NOT NULL COMMENT '订单ID',
+    order_no VARCHAR(50) NOT NULL COMMENT '订单编号',
+    from_status VARCHAR(20) DEFAULT '' COMMENT '原状态，新建订单时为空',
+    to_status VARCHAR(20) NOT NULL COMMENT '目标状态',
+    operator_type VARCHAR(20) NOT NULL COMMENT '操作人类型: user/doctor/admin/system',
+    operator_id BIGINT DEFAULT 0 COMMENT '操作人ID，系统操作为0',
+    reason VARCHAR(500) DEFAULT '' COMMENT '流转原因',
+    created_at DATETIME(3) NOT NULL COMMENT '流转时间',
+    INDEX idx_order_id (order_id),
+    INDEX idx_order_no (order_no)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='订单状态流转日志';
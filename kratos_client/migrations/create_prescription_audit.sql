@@ -0,0 +1,27 @@
+-- 处方开具与药师审核
+-- 医生在问诊中或问诊结束后为该问诊的患者开具处方，处方状态为 已开具
+-- 药师审核通过(已审核)或驳回(已驳回，须填写审核意见)，审核人、审核时间、审核意见写入 mt_prescriptions
+-- 患者可将审核通过的处方一键下单，下单后处方状态为 已下单 并记录订单号；同一处方只能下单一次
+-- 药师账号由运维创建，密码与医生账号使用相同的加盐 sha256 算法
+
+ALTER TABLE mt_prescriptions
+ADD COLUMN IF NOT EXISTS consultation_no VARCHAR(64) DEFAULT '' COMMENT '关联问诊单号',
+ADD COLUMN IF NOT EXISTS order_no VARCHAR(64) DEFAULT '' COMMENT '处方下单生成的订单号';
+
+CREATE INDEX IF NOT EXISTS idx_prescriptions_status ON mt_prescriptions(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_prescriptions_consultation ON mt_prescriptions(consultation_no);
+CREATE INDEX IF NOT EXISTS idx_prescription_medicines_prescription ON mt_prescription_medicines(prescription_id);
+
+CREATE TABLE IF NOT EXISTS mt_pharmacists (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    name VARCHAR(50) NOT NULL COMMENT '药师姓名',
+    phone VARCHAR(20) NOT NULL COMMENT '登录手机号',
+    license_no VARCHAR(50) DEFAULT '' COMMENT '药师执业证号',
+    password_hash VARCHAR(64) DEFAULT '' COMMENT '登录密码哈希 sha256(salt+password)',
+    salt VARCHAR(32) DEFAULT '' COMMENT '密码盐',
+    status TINYINT NOT NULL DEFAULT 1 COMMENT '状态: 1-启用, 0-停用',
+    last_login_time DATETIME(3) NULL COMMENT '最后登录时间',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY uk_pharmacists_phone (phone)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='药师账号表';
@@ -0,0 +1,48 @@
+-- 退款流程
+-- 用户按订单项申请部分退款 -> 后台审核(approved/rejected) -> 退款任务提交支付渠道(processing) -> succeeded/failed
+-- mt_order_items.refunded_qty 记录已占用的可退数量，申请时以 quantity - refunded_qty 为条件占用，驳回或失败时归还
+-- 退款成功后药品退回库存，订单全部退完时流转为 7 已退款
+
+ALTER TABLE mt_order_items
+ADD COLUMN IF NOT EXISTS refunded_qty INT NOT NULL DEFAULT 0 COMMENT '已申请退款数量';
+
+CREATE TABLE IF NOT EXISTS refund_records (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    order_id VARCHAR(64) NOT NULL COMMENT '支付订单号',
+    refund_id VARCHAR(64) NOT NULL COMMENT '退款单号',
+    refund_amount VARCHAR(16) NOT NULL COMMENT '退款金额',
+    refund_reason TEXT COMMENT '退款原因',
+    refund_status VARCHAR(32) NOT NULL DEFAULT 'requested' COMMENT '退款状态',
+    refund_time DATETIME(3) NULL COMMENT '退款时间',
+    created_at DATETIME(3) NULL COMMENT '申请时间',
+    UNIQUE KEY idx_refund_records_refund_id (refund_id),
+    INDEX idx_refund_records_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='退款单';
+
+ALTER TABLE refund_records
+ADD COLUMN IF NOT EXISTS order_no VARCHAR(64) DEFAULT '' COMMENT '药品订单号',
+ADD COLUMN IF NOT EXISTS user_id BIGINT DEFAULT 0 COMMENT '申请人',
+ADD COLUMN IF NOT EXISTS audit_by BIGINT DEFAULT 0 COMMENT '审核人',
+ADD COLUMN IF NOT EXISTS audit_time DATETIME(3) NULL COMMENT '审核时间',
+ADD COLUMN IF NOT EXISTS audit_remark VARCHAR(255) DEFAULT '' COMMENT '审核备注',
+ADD COLUMN IF NOT EXISTS gateway_refund_no VARCHAR(64) DEFAULT '' COMMENT '渠道退款流水号',
+ADD COLUMN IF NOT EXISTS fail_reason VARCHAR(255) DEFAULT '' COMMENT '失败原因',
+ADD COLUMN IF NOT EXISTS updated_at DATETIME(3) NULL COMMENT '更新时间',
+ALTER COLUMN refund_status SET DEFAULT 'requested';
+
+CREATE INDEX IF NOT EXISTS idx_refund_records_order_no ON refund_records(order_no);
+CREATE INDEX IF NOT EXISTS idx_refund_records_user_id ON refund_records(user_id);
+CREATE INDEX IF NOT EXISTS idx_refund_records_refund_status ON refund_records(refund_status);
+
+CREATE TABLE IF NOT EXISTS mt_refund_item (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    refund_id VARCHAR(64) NOT NULL COMMENT '退款单号',
+    order_item_id BIGINT NOT NULL COMMENT '订单项ID',
+    drug_id BIGINT NOT NULL COMMENT '药品ID',
+    drug_name VARCHAR(100) DEFAULT '' COMMENT '药品名称',
+    quantity INT NOT NULL COMMENT '退款数量',
+    amount DECIMAL(10,2) NOT NULL COMMENT '退款金额',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    INDEX idx_mt_refund_item_refund_id (refund_id),
+    INDEX idx_mt_refund_item_order_item_id (order_item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='退款明细';
@@ -0,0 +1,40 @@
+-- 药店库存与库存流水
+-- mt_drug_inventory 按药店记录在库数量和已预留数量，可售数量 = quantity - reserved_qty
+-- mt_stock_movement 记录每一次预留/出库/释放/盘点，只追加不修改
+
+CREATE TABLE IF NOT EXISTS mt_drug_inventory (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    drug_id BIGINT NOT NULL COMMENT '药品ID',
+    drug_store_id INT NOT NULL COMMENT '药店ID',
+    quantity BIGINT NOT NULL DEFAULT 0 COMMENT '在库数量(含已预留)',
+    reserved_qty BIGINT NOT NULL DEFAULT 0 COMMENT '预留库存',
+    alert_threshold BIGINT NOT NULL DEFAULT 10 COMMENT '预警阈值',
+    price DECIMAL(10,2) NOT NULL DEFAULT 0 COMMENT '药店特定价格',
+    status TINYINT NOT NULL DEFAULT 0 COMMENT '库存状态',
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+    UNIQUE KEY uk_drug_store (drug_id, drug_store_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='药店库存';
+
+CREATE TABLE IF NOT EXISTS mt_stock_movement (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    drug_id BIGINT NOT NULL COMMENT '药品ID',
+    drug_store_id INT NOT NULL COMMENT '药店ID',
+    movement_type VARCHAR(20) NOT NULL COMMENT '流水类型: reserve/commit/release/adjust',
+    quantity_diff BIGINT NOT NULL DEFAULT 0 COMMENT '在库数量变化',
+    reserved_diff BIGINT NOT NULL DEFAULT 0 COMMENT '预留数量变化',
+    quantity_after BIGINT NOT NULL COMMENT '变动后在库数量',
+    reserved_after BIGINT NOT NULL COMMENT '变动后预留数量',
+    ref_no VARCHAR(50) DEFAULT '' COMMENT '关联单号',
+    remark VARCHAR(200) DEFAULT '' COMMENT '备注',
+    created_at DATETIME(3) NOT NULL COMMENT '发生时间',
+    INDEX idx_drug_store (drug_id, drug_store_id),
+    INDEX idx_ref_no (ref_no)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='库存流水';
+
+-- 以药品表现有库存初始化药品所属药店的库存记录
+INSERT INTO mt_drug_inventory (drug_id, drug_store_id, quantity, price)
+SELECT d.id, d.drug_store, d.inventory, d.price
+FROM mt_drug d
+WHERE NOT EXISTS (
+    SELECT 1 FROM mt_drug_inventory i WHERE i.drug_id = d.id AND i.drug_store_id = d.drug_store
+);
@@ -0,0 +1,28 @@
+-- 症状分类
+-- 由管理后台“症状分类”维护，C端按症状推荐非处方药时使用
+-- 症状名称和同义说法用于识别用户选择的症状，名称、同义说法和适应症关键词用于匹配说明书和用药指导的功能主治
+-- 关联的药品一级分类下的非处方药同样推荐，但排在功能主治命中的药品之后；建议就诊科室用于问诊入口筛选医生
+
+CREATE TABLE IF NOT EXISTS mt_symptom (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    name VARCHAR(20) NOT NULL COMMENT '症状名称',
+    aliases VARCHAR(255) NULL COMMENT '同义说法，英文逗号分隔',
+    indications VARCHAR(255) NULL COMMENT '适应症关键词，英文逗号分隔',
+    category_ids VARCHAR(255) NULL COMMENT '对症的药品一级分类ID，英文逗号分隔',
+    department_id INT NOT NULL DEFAULT 0 COMMENT '建议就诊科室ID，0表示不限',
+    advice VARCHAR(255) NULL COMMENT '就医提示',
+    sort INT NOT NULL DEFAULT 0 COMMENT '排序，越小越靠前',
+    enabled TINYINT(1) NOT NULL DEFAULT 1 COMMENT '是否启用',
+    created_at DATETIME(3) NULL COMMENT '创建时间',
+    updated_at DATETIME(3) NULL COMMENT '更新时间',
+    UNIQUE KEY uk_symptom_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='症状分类';
+
+-- 常见症状初始数据，分类和科室按实际数据在后台补充
+INSERT IGNORE INTO mt_symptom (name, aliases, indications, advice, sort, created_at, updated_at) VALUES
+('感冒', '伤风', '风寒,风热', '症状超过一周未缓解请及时就医', 1, NOW(3), NOW(3)),
+('发热', '发烧,高烧,低烧', '解热,退热', '体温超过39℃或持续发热超过3天请及时就医', 2, NOW(3), NOW(3)),
+('头痛', '头疼', '镇痛,止痛', '突发剧烈头痛请立即就医', 3, NOW(3), NOW(3)),
+('咳嗽', '干咳', '止咳,化痰', '咳嗽超过两周或痰中带血请及时就医', 4, NOW(3), NOW(3)),
+('咽痛', '喉咙痛,嗓子疼,咽喉肿痛', '利咽', '', 5, NOW(3), NOW(3)),
+('腹泻', '拉肚子', '止泻', '伴有高热或便血请及时就医', 6, NOW(3), NOW(3));
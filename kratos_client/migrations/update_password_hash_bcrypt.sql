@@ -0,0 +1,7 @@
+-- 医生、药师登录密码改用 bcrypt
+-- bcrypt 哈希自带盐值，新密码的 salt 列留空；旧的 sha256(salt+password) 哈希仍可登录，登录成功后改存 bcrypt 并清空 salt
+-- 同一账号15分钟内连续5次密码错误后锁定，计数存于Redis的 login_attempt:{doctor|pharmacist}:{账号}
+
+ALTER TABLE mt_pharmacists
+MODIFY COLUMN password_hash VARCHAR(64) DEFAULT '' COMMENT '登录密码哈希 bcrypt，旧数据为 sha256(salt+password)',
+MODIFY COLUMN salt VARCHAR(32) DEFAULT '' COMMENT '旧版密码盐，bcrypt 哈希为空';
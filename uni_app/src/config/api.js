@@ -20,7 +20,8 @@ const API_CONFIG = {
     
     // 医生相关接口
     DOCTORS: {
-      LIST: '/v1/DoctorsList'
+      LIST: '/v1/DoctorsList',
+      DETAIL: '/v1/doctors/detail'
     },
     
     // 药品相关接口
@@ -28,7 +29,8 @@ const API_CONFIG = {
       LIST: '/v1/drug/list',
       DETAIL: '/v1/drug',
       SEARCH: '/v1/drug/search',
-      HOT_SEARCH: '/v1/drug/hot-search'
+      HOT_SEARCH: '/v1/drug/hot-search',
+      SYMPTOM_RECOMMEND: '/v1/drug/symptom-recommend'
     },
     
     // 购物车相关接口
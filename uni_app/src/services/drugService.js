@@ -207,6 +207,62 @@ class DrugService {
     }
   }
 
+  // 按症状推荐非处方药，populations 可选 pregnancy、lactation、child、elderly
+  // 无论是否有推荐结果都返回问诊入口 consult
+  async recommendBySymptoms(symptoms = [], populations = [], limit = 10) {
+    try {
+      console.log('🔍 按症状推荐药品:', symptoms, populations);
+
+      const response = await fetch(`${this.baseURL}${API_CONFIG.ENDPOINTS.DRUGS.SYMPTOM_RECOMMEND}`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          'Accept': 'application/json'
+        },
+        body: JSON.stringify({
+          symptoms,
+          populations,
+          limit
+        })
+      });
+
+      if (!response.ok) {
+        throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+      }
+
+      const data = await response.json();
+      console.log('✅ 症状推荐API响应:', data);
+
+      if (data.code != 0) {
+        return {
+          success: false,
+          symptoms: [],
+          drugs: [],
+          consult: data.consult || null,
+          message: data.msg || 'API调用失败'
+        };
+      }
+
+      return {
+        success: true,
+        symptoms: data.symptoms || [],
+        drugs: data.drugs || [],
+        consult: data.consult || null,
+        message: data.msg
+      };
+
+    } catch (error) {
+      console.error('❌ 按症状推荐药品失败:', error);
+      return {
+        success: false,
+        symptoms: [],
+        drugs: [],
+        consult: null,
+        message: error.message
+      };
+    }
+  }
+
   // 格式化药品数据用于显示
   formatDrugForDisplay(drug) {
     return {